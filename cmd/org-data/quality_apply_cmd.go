@@ -185,7 +185,7 @@ func newQualityApplyCmd() *cobra.Command {
 	cmd.Flags().StringVar(&opts.beforeBackend, "before-backend", "api", "Read before-state via: api|db")
 
 	cmd.Flags().StringVar(&opts.baseURL, "base-url", "", "Base URL for org api (default: ORIGIN)")
-	cmd.Flags().StringVar(&opts.authToken, "auth-token", "", "Authorization token (sent as Authorization header)")
+	cmd.Flags().StringVar(&opts.authToken, "auth-token", "", "Authorization token: a session token or an API token (sent as Authorization header)")
 
 	_ = cmd.MarkFlagRequired("fix-plan")
 	return cmd
@@ -119,7 +119,7 @@ func newQualityCheckCmd() *cobra.Command {
 	cmd.Flags().IntVar(&opts.maxIssues, "max-issues", qualityMaxIssuesDefaultLimit, "Max issues to output (truncate beyond this)")
 
 	cmd.Flags().StringVar(&opts.baseURL, "base-url", "", "Base URL for api backend (default: ORIGIN)")
-	cmd.Flags().StringVar(&opts.authToken, "auth-token", "", "Authorization token for api backend (sent as Authorization header)")
+	cmd.Flags().StringVar(&opts.authToken, "auth-token", "", "Authorization token for api backend: a session token or an API token (sent as Authorization header)")
 
 	_ = cmd.MarkFlagRequired("tenant")
 	return cmd
@@ -102,7 +102,7 @@ func newQualityRollbackCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&opts.apply, "apply", false, "Apply rollback (default is dry-run)")
 	cmd.Flags().BoolVar(&opts.yes, "yes", false, "Confirm applying rollback")
 	cmd.Flags().StringVar(&opts.baseURL, "base-url", "", "Base URL for org api (default: ORIGIN)")
-	cmd.Flags().StringVar(&opts.authToken, "auth-token", "", "Authorization token (sent as Authorization header)")
+	cmd.Flags().StringVar(&opts.authToken, "auth-token", "", "Authorization token: a session token or an API token (sent as Authorization header)")
 
 	_ = cmd.MarkFlagRequired("manifest")
 	return cmd
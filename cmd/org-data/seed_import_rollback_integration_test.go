@@ -58,6 +58,12 @@ func TestOrgData_SeedImport_ApplyAndRollbackByManifest(t *testing.T) {
 		"20260101020930_org_job_catalog_effective_dated_slices_gates_and_backfill.sql",
 		"20260104100000_org_drop_job_profile_job_families_legacy.sql",
 		"20260104120000_org_drop_job_catalog_identity_legacy_columns.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
 	}
 	for _, f := range migrations {
 		sql := readGooseUpSQL(t, filepath.Join(root, "migrations", "org", f))
@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 )
 
@@ -44,6 +45,9 @@ func newClosureBuildCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("invalid --tenant: %w", err)
 			}
+			if !services.IsValidHierarchyType(hierarchyType) {
+				return fmt.Errorf("invalid --hierarchy: %q", hierarchyType)
+			}
 			if requestID == "" {
 				requestID = uuid.NewString()
 			}
@@ -72,7 +76,7 @@ func newClosureBuildCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&tenantID, "tenant", "", "Tenant UUID (required)")
-	cmd.Flags().StringVar(&hierarchyType, "hierarchy", "OrgUnit", "Hierarchy type")
+	cmd.Flags().StringVar(&hierarchyType, "hierarchy", "OrgUnit", "Hierarchy type (OrgUnit, CostCenter, LegalEntity, Geographic, Project)")
 	cmd.Flags().BoolVar(&apply, "apply", false, "Apply changes (default dry-run)")
 	cmd.Flags().StringVar(&requestID, "request-id", "", "Source request id (optional)")
 	_ = cmd.MarkFlagRequired("tenant")
@@ -94,6 +98,9 @@ func newClosureActivateCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("invalid --tenant: %w", err)
 			}
+			if !services.IsValidHierarchyType(hierarchyType) {
+				return fmt.Errorf("invalid --hierarchy: %q", hierarchyType)
+			}
 			bid, err := uuid.Parse(buildID)
 			if err != nil {
 				return fmt.Errorf("invalid --build-id: %w", err)
@@ -133,7 +140,7 @@ func newClosureActivateCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&tenantID, "tenant", "", "Tenant UUID (required)")
-	cmd.Flags().StringVar(&hierarchyType, "hierarchy", "OrgUnit", "Hierarchy type")
+	cmd.Flags().StringVar(&hierarchyType, "hierarchy", "OrgUnit", "Hierarchy type (OrgUnit, CostCenter, LegalEntity, Geographic, Project)")
 	cmd.Flags().StringVar(&buildID, "build-id", "", "Build UUID (required)")
 	_ = cmd.MarkFlagRequired("tenant")
 	_ = cmd.MarkFlagRequired("build-id")
@@ -155,6 +162,9 @@ func newClosurePruneCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("invalid --tenant: %w", err)
 			}
+			if !services.IsValidHierarchyType(hierarchyType) {
+				return fmt.Errorf("invalid --hierarchy: %q", hierarchyType)
+			}
 			if keep <= 0 {
 				keep = 1
 			}
@@ -183,7 +193,7 @@ func newClosurePruneCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&tenantID, "tenant", "", "Tenant UUID (required)")
-	cmd.Flags().StringVar(&hierarchyType, "hierarchy", "OrgUnit", "Hierarchy type")
+	cmd.Flags().StringVar(&hierarchyType, "hierarchy", "OrgUnit", "Hierarchy type (OrgUnit, CostCenter, LegalEntity, Geographic, Project)")
 	cmd.Flags().IntVar(&keep, "keep", 2, "How many builds to keep (>=1)")
 	_ = cmd.MarkFlagRequired("tenant")
 	return cmd
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -15,3 +17,20 @@ func newHTTPClient() *http.Client {
 		},
 	}
 }
+
+// validateCredentials requires a session cookie or an API token.
+func validateCredentials(sid, authToken string) error {
+	if strings.TrimSpace(sid) == "" && strings.TrimSpace(authToken) == "" {
+		return errors.New("--sid or --auth-token is required")
+	}
+	return nil
+}
+
+// authenticate adds the API token as a bearer token, or the session cookie when no token is set.
+func authenticate(req *http.Request, sid, authToken string) {
+	if token := strings.TrimSpace(authToken); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return
+	}
+	req.AddCookie(&http.Cookie{Name: "sid", Value: sid})
+}
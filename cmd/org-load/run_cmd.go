@@ -22,6 +22,7 @@ type runOptions struct {
 	BaseURL       string
 	TenantID      string
 	SID           string
+	AuthToken     string
 	Profile       string
 	OutPath       string
 	EffectiveDate string
@@ -33,7 +34,7 @@ func newRunCmd() *cobra.Command {
 	var opts runOptions
 
 	cmd := &cobra.Command{
-		Use:   "run --profile <name> --base-url <url> --tenant <uuid> --sid <cookie> --out <path>",
+		Use:   "run --profile <name> --base-url <url> --tenant <uuid> --sid <cookie>|--auth-token <token> --out <path>",
 		Short: "Run a load test profile and write org_load_report.v1 JSON",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if strings.TrimSpace(opts.BaseURL) == "" {
@@ -42,8 +43,8 @@ func newRunCmd() *cobra.Command {
 			if strings.TrimSpace(opts.TenantID) == "" {
 				return errors.New("--tenant is required")
 			}
-			if strings.TrimSpace(opts.SID) == "" {
-				return errors.New("--sid is required")
+			if err := validateCredentials(opts.SID, opts.AuthToken); err != nil {
+				return err
 			}
 			if strings.TrimSpace(opts.Profile) == "" {
 				return errors.New("--profile is required")
@@ -96,7 +97,7 @@ func newRunCmd() *cobra.Command {
 
 			finishedAt := time.Now().UTC()
 
-			backend, cacheEnabled := discoverBackend(cmd.Context(), client, opts.BaseURL, opts.SID)
+			backend, cacheEnabled := discoverBackend(cmd.Context(), client, opts.BaseURL, opts.SID, opts.AuthToken)
 
 			report := loadReportV1{
 				SchemaVersion: 1,
@@ -141,6 +142,7 @@ func newRunCmd() *cobra.Command {
 	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "http://localhost:3200", "server base URL")
 	cmd.Flags().StringVar(&opts.TenantID, "tenant", "", "tenant UUID (for report metadata)")
 	cmd.Flags().StringVar(&opts.SID, "sid", "", "session cookie value (sid)")
+	cmd.Flags().StringVar(&opts.AuthToken, "auth-token", "", "API token (sent as a bearer token instead of --sid)")
 	cmd.Flags().StringVar(&opts.OutPath, "out", "", "output report path")
 	cmd.Flags().StringVar(&opts.EffectiveDate, "effective-date", "", "effective date (YYYY-MM-DD)")
 	cmd.Flags().StringVar(&opts.ParentNodeID, "parent-node-id", "", "parent node UUID (required for org_mix_read_write)")
@@ -165,12 +167,12 @@ func smokeCheck(ctx context.Context, client *http.Client, baseURL string) error
 	return nil
 }
 
-func discoverBackend(ctx context.Context, client *http.Client, baseURL, sid string) (string, bool) {
+func discoverBackend(ctx context.Context, client *http.Client, baseURL, sid, authToken string) (string, bool) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(baseURL, "/")+"/org/api/ops/health", nil)
 	if err != nil {
 		return "", false
 	}
-	req.AddCookie(&http.Cookie{Name: "sid", Value: sid})
+	authenticate(req, sid, authToken)
 	req.Header.Set("Accept", "application/json")
 	resp, err := client.Do(req)
 	if err != nil {
@@ -239,7 +241,7 @@ func doRequest(ctx context.Context, client *http.Client, opts runOptions, t targ
 	if err != nil {
 		return requestResult{Endpoint: t.Endpoint, Err: err}
 	}
-	req.AddCookie(&http.Cookie{Name: "sid", Value: opts.SID})
+	authenticate(req, opts.SID, opts.AuthToken)
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("X-Request-Id", uuid.NewString())
 	if t.Method == http.MethodPost || t.Method == http.MethodPatch || t.Method == http.MethodPut {
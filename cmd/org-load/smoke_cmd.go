@@ -12,16 +12,17 @@ import (
 )
 
 type smokeOptions struct {
-	BaseURL  string
-	TenantID string
-	SID      string
+	BaseURL   string
+	TenantID  string
+	SID       string
+	AuthToken string
 }
 
 func newSmokeCmd() *cobra.Command {
 	var opts smokeOptions
 
 	cmd := &cobra.Command{
-		Use:   "smoke --base-url <url> --tenant <uuid> --sid <cookie>",
+		Use:   "smoke --base-url <url> --tenant <uuid> --sid <cookie>|--auth-token <token>",
 		Short: "Run a small smoke check against /health and Org API",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if strings.TrimSpace(opts.BaseURL) == "" {
@@ -30,8 +31,8 @@ func newSmokeCmd() *cobra.Command {
 			if strings.TrimSpace(opts.TenantID) == "" {
 				return errors.New("--tenant is required")
 			}
-			if strings.TrimSpace(opts.SID) == "" {
-				return errors.New("--sid is required")
+			if err := validateCredentials(opts.SID, opts.AuthToken); err != nil {
+				return err
 			}
 
 			client := newHTTPClient()
@@ -46,7 +47,7 @@ func newSmokeCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
-			req.AddCookie(&http.Cookie{Name: "sid", Value: opts.SID})
+			authenticate(req, opts.SID, opts.AuthToken)
 			resp, err := client.Do(req)
 			if err != nil {
 				return err
@@ -63,6 +64,7 @@ func newSmokeCmd() *cobra.Command {
 	cmd.Flags().StringVar(&opts.BaseURL, "base-url", "http://localhost:3200", "server base URL")
 	cmd.Flags().StringVar(&opts.TenantID, "tenant", "", "tenant UUID (for required flag parity)")
 	cmd.Flags().StringVar(&opts.SID, "sid", "", "session cookie value (sid)")
+	cmd.Flags().StringVar(&opts.AuthToken, "auth-token", "", "API token (sent as a bearer token instead of --sid)")
 
 	return cmd
 }
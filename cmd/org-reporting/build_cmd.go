@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 )
 
@@ -36,6 +37,9 @@ func newBuildCmd() *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("invalid --tenant: %w", err)
 			}
+			if !services.IsValidHierarchyType(hierarchyType) {
+				return fmt.Errorf("invalid --hierarchy: %q", hierarchyType)
+			}
 			d, err := parseDateUTC(asOfDate)
 			if err != nil {
 				return err
@@ -69,7 +73,7 @@ func newBuildCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVar(&tenantID, "tenant", "", "Tenant UUID (required)")
-	cmd.Flags().StringVar(&hierarchyType, "hierarchy", "OrgUnit", "Hierarchy type")
+	cmd.Flags().StringVar(&hierarchyType, "hierarchy", "OrgUnit", "Hierarchy type (OrgUnit, CostCenter, LegalEntity, Geographic, Project)")
 	cmd.Flags().StringVar(&asOfDate, "as-of-date", time.Now().UTC().Format("2006-01-02"), "As-of date (UTC, YYYY-MM-DD)")
 	cmd.Flags().BoolVar(&includeSG, "include-security-groups", false, "Populate security_group_keys (requires DEV-PLAN-032 tables)")
 	cmd.Flags().BoolVar(&includeLinks, "include-links", false, "Populate links (requires DEV-PLAN-032 tables)")
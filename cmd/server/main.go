@@ -14,7 +14,11 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/infrastructure/persistence"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers"
 	orgoutboxdispatcher "github.com/iota-uz/iota-sdk/modules/org/infrastructure/outbox"
+	orgscheduler "github.com/iota-uz/iota-sdk/modules/org/infrastructure/scheduler"
+	orgservices "github.com/iota-uz/iota-sdk/modules/org/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/authz/policystore"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/eventbus"
 	"github.com/iota-uz/iota-sdk/pkg/logging"
@@ -80,6 +84,8 @@ func main() {
 	}
 
 	startOutboxBackground(conf, pool, logger, app.EventPublisher())
+	startOrgChangeRequestScheduler(conf, pool, logger, app)
+	startAuthzPolicyListener(conf, pool, logger)
 
 	app.RegisterNavItems(modules.NavLinks...)
 	app.RegisterHashFsAssets(internalassets.HashFS)
@@ -197,3 +203,56 @@ func startOutboxBackground(
 		outboxLog.Info("outbox: cleaner enabled but no tables configured")
 	}
 }
+
+func startOrgChangeRequestScheduler(
+	conf *configuration.Configuration,
+	pool *pgxpool.Pool,
+	logger *logrus.Logger,
+	app application.Application,
+) {
+	if !conf.OrgChangeRequestSchedulerEnabled {
+		return
+	}
+	schedulerLog := logger.WithField("component", "org_change_request_scheduler")
+	if !conf.OrgChangeRequestsEnabled {
+		schedulerLog.Info("org change request scheduler enabled but ORG_CHANGE_REQUESTS_ENABLED is off; not started")
+		return
+	}
+
+	executor := orgservices.NewChangeRequestExecutor(
+		app.Service(orgservices.OrgService{}).(*orgservices.OrgService),
+		app.Service(orgservices.ChangeRequestService{}).(*orgservices.ChangeRequestService),
+	)
+	scheduler := orgscheduler.NewChangeRequestScheduler(executor, pool, orgscheduler.Options{
+		PollInterval: conf.OrgChangeRequestSchedulerInterval,
+		Logger:       schedulerLog,
+	})
+	go func() {
+		if err := scheduler.Run(context.Background()); err != nil {
+			schedulerLog.WithError(err).Error("org change request scheduler stopped")
+		}
+	}()
+}
+
+func startAuthzPolicyListener(
+	conf *configuration.Configuration,
+	pool *pgxpool.Pool,
+	logger *logrus.Logger,
+) {
+	if conf.Authz.PolicyStore != "db" {
+		return
+	}
+	listenerLog := logger.WithField("component", "authz_policy_listener")
+	listener, err := policystore.NewListener(pool, authz.Use().ReloadPolicy, policystore.ListenerOptions{
+		Logger: listenerLog,
+	})
+	if err != nil {
+		listenerLog.WithError(err).Warn("authz policy listener: failed to create listener")
+		return
+	}
+	go func() {
+		if err := listener.Run(context.Background()); err != nil {
+			listenerLog.WithError(err).Error("authz policy listener stopped")
+		}
+	}()
+}
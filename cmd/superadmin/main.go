@@ -100,11 +100,12 @@ func main() {
 
 	tenantService := services.NewTenantService(tenantRepo)
 	uploadService := services.NewUploadService(uploadRepo, fsStorage, app.EventPublisher())
+	userService := services.NewUserService(userRepo, userValidator, app.EventPublisher())
 
 	// Register first batch of services (without AuthService)
 	app.RegisterServices(
 		uploadService,
-		services.NewUserService(userRepo, userValidator, app.EventPublisher()),
+		userService,
 		services.NewUserQueryService(userQueryRepo),
 		services.NewGroupQueryService(groupQueryRepo),
 		services.NewSessionService(persistence.NewSessionRepository(), app.EventPublisher()),
@@ -118,6 +119,7 @@ func main() {
 		tenantService,
 		services.NewPermissionService(permRepo, app.EventPublisher()),
 		services.NewGroupService(persistence.NewGroupRepository(userRepo, roleRepo), app.EventPublisher()),
+		services.NewAPITokenService(persistence.NewAPITokenRepository(), userService, app.EventPublisher(), conf.APITokenMaxTTL),
 	)
 
 	// Register only auth-related controllers from core (Login, Logout, Account)
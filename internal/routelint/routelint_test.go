@@ -192,10 +192,11 @@ func buildSuperadminHTTPServer(t *testing.T) *pkgserver.HTTPServer {
 
 	tenantService := services.NewTenantService(tenantRepo)
 	uploadService := services.NewUploadService(uploadRepo, fsStorage, app.EventPublisher())
+	userService := services.NewUserService(userRepo, userValidator, app.EventPublisher())
 
 	app.RegisterServices(
 		uploadService,
-		services.NewUserService(userRepo, userValidator, app.EventPublisher()),
+		userService,
 		services.NewUserQueryService(userQueryRepo),
 		services.NewGroupQueryService(groupQueryRepo),
 		services.NewSessionService(corepersistence.NewSessionRepository(), app.EventPublisher()),
@@ -208,6 +209,7 @@ func buildSuperadminHTTPServer(t *testing.T) *pkgserver.HTTPServer {
 		tenantService,
 		services.NewPermissionService(permRepo, app.EventPublisher()),
 		services.NewGroupService(corepersistence.NewGroupRepository(userRepo, roleRepo), app.EventPublisher()),
+		services.NewAPITokenService(corepersistence.NewAPITokenRepository(), userService, app.EventPublisher(), conf.APITokenMaxTTL),
 	)
 
 	app.RegisterControllers(
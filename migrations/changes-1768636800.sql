@@ -0,0 +1,32 @@
+-- +migrate Up
+
+-- Shared Casbin policy store (AUTHZ_POLICY_STORE=db): every applied policy is kept as a full CSV
+-- snapshot and authz_policy_head points at the one all replicas enforce.
+
+-- Change CREATE_TABLE: authz_policy_revisions
+CREATE TABLE authz_policy_revisions (
+    id bigserial PRIMARY KEY,
+    revision varchar(64) NOT NULL,
+    parent_id int8 NULL REFERENCES authz_policy_revisions(id),
+    policy text NOT NULL,
+    entries int4 NOT NULL,
+    source varchar(32) NOT NULL,
+    created_by varchar(255) NULL,
+    created_at timestamptz DEFAULT now() NOT NULL
+);
+
+CREATE INDEX authz_policy_revisions_revision_idx ON authz_policy_revisions(revision);
+CREATE INDEX authz_policy_revisions_time_idx ON authz_policy_revisions(created_at DESC);
+
+-- Change CREATE_TABLE: authz_policy_head
+CREATE TABLE authz_policy_head (
+    singleton bool PRIMARY KEY DEFAULT true,
+    revision_id int8 NOT NULL REFERENCES authz_policy_revisions(id),
+    updated_at timestamptz DEFAULT now() NOT NULL,
+    CONSTRAINT authz_policy_head_singleton_check CHECK (singleton)
+);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS authz_policy_head;
+DROP TABLE IF EXISTS authz_policy_revisions;
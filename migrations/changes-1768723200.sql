@@ -0,0 +1,26 @@
+-- +migrate Up
+
+-- Applied authz policy change sets: who applied what and why, with the resulting snapshot so any
+-- change set can be restored regardless of AUTHZ_POLICY_STORE.
+
+-- Change CREATE_TABLE: authz_policy_changes
+CREATE TABLE authz_policy_changes (
+    id bigserial PRIMARY KEY,
+    base_revision varchar(64) NOT NULL,
+    revision varchar(64) NOT NULL,
+    policy text NOT NULL,
+    added text[] DEFAULT '{}' NOT NULL,
+    removed text[] DEFAULT '{}' NOT NULL,
+    reason text NULL,
+    author_id int8 NULL,
+    author varchar(255) NULL,
+    revert_of int8 NULL REFERENCES authz_policy_changes(id) ON DELETE SET NULL,
+    created_at timestamptz DEFAULT now() NOT NULL
+);
+
+CREATE INDEX authz_policy_changes_time_idx ON authz_policy_changes(created_at DESC);
+CREATE INDEX authz_policy_changes_revision_idx ON authz_policy_changes(revision);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS authz_policy_changes;
@@ -0,0 +1,65 @@
+-- +migrate Up
+
+-- Staged policy edits (drafts) and the change requests they are submitted as. A change request is
+-- applied only after a second admin approves it. User ids are not foreign keys so that requests
+-- and comments outlive the accounts that wrote them, like authz_policy_changes.author_id.
+
+-- Change CREATE_TABLE: authz_policy_drafts
+CREATE TABLE authz_policy_drafts (
+    id uuid DEFAULT gen_random_uuid() PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    user_id int8 NOT NULL,
+    stage_kind varchar(16) NOT NULL CHECK (stage_kind IN ('add', 'remove')),
+    type varchar(8) NOT NULL,
+    subject varchar(255) NOT NULL,
+    domain varchar(255) NOT NULL,
+    object varchar(255) NOT NULL,
+    action varchar(255) NOT NULL,
+    effect varchar(16) NOT NULL,
+    condition text DEFAULT '' NOT NULL,
+    created_at timestamptz DEFAULT now() NOT NULL
+);
+
+CREATE INDEX authz_policy_drafts_owner_idx ON authz_policy_drafts (tenant_id, user_id, subject, domain);
+
+-- Change CREATE_TABLE: authz_policy_change_requests
+CREATE TABLE authz_policy_change_requests (
+    id bigserial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    author_id int8 NOT NULL,
+    reviewer_id int8 NULL,
+    subject varchar(255) DEFAULT '' NOT NULL,
+    domain varchar(255) DEFAULT '' NOT NULL,
+    reason text NOT NULL,
+    base_revision varchar(64) DEFAULT '' NOT NULL,
+    changes jsonb NOT NULL,
+    revert_of int8 NULL REFERENCES authz_policy_changes (id) ON DELETE SET NULL,
+    status varchar(16) DEFAULT 'pending' NOT NULL CHECK (status IN ('pending', 'approved', 'rejected', 'withdrawn')),
+    decided_by int8 NULL,
+    decided_at timestamptz NULL,
+    applied_revision varchar(64) NULL,
+    created_at timestamptz DEFAULT now() NOT NULL,
+    updated_at timestamptz DEFAULT now() NOT NULL,
+    CONSTRAINT authz_policy_change_requests_four_eyes CHECK (status = 'withdrawn' OR decided_by IS NULL OR decided_by <> author_id)
+);
+
+CREATE INDEX authz_policy_change_requests_status_idx ON authz_policy_change_requests (tenant_id, status, created_at DESC);
+
+-- Change CREATE_TABLE: authz_policy_change_request_comments
+CREATE TABLE authz_policy_change_request_comments (
+    id bigserial PRIMARY KEY,
+    request_id int8 NOT NULL REFERENCES authz_policy_change_requests (id) ON DELETE CASCADE,
+    author_id int8 NOT NULL,
+    body text NOT NULL,
+    created_at timestamptz DEFAULT now() NOT NULL
+);
+
+CREATE INDEX authz_policy_change_request_comments_request_idx ON authz_policy_change_request_comments (request_id, created_at);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS authz_policy_change_request_comments;
+
+DROP TABLE IF EXISTS authz_policy_change_requests;
+
+DROP TABLE IF EXISTS authz_policy_drafts;
@@ -0,0 +1,41 @@
+-- +migrate Up
+
+-- Service accounts are users rows of type 'service'. They have no password and never sign in;
+-- they act only through API tokens.
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_type_check;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_type_check CHECK (type IN ('system', 'user', 'superadmin', 'service'));
+
+-- Personal access tokens and service account tokens. Only the sha256 of the secret is stored;
+-- token_prefix keeps the first characters of the secret so owners can tell tokens apart.
+
+-- Change CREATE_TABLE: api_tokens
+CREATE TABLE api_tokens (
+    id bigserial PRIMARY KEY,
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    user_id int8 NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name varchar(255) NOT NULL,
+    token_prefix varchar(32) NOT NULL,
+    token_hash varchar(64) NOT NULL UNIQUE,
+    scopes text[] NOT NULL,
+    created_by int8 NULL REFERENCES users (id) ON DELETE SET NULL,
+    expires_at timestamptz NOT NULL,
+    last_used_at timestamptz NULL,
+    last_used_ip varchar(64) NULL,
+    revoked_at timestamptz NULL,
+    created_at timestamptz DEFAULT now() NOT NULL
+);
+
+CREATE INDEX api_tokens_user_idx ON api_tokens (tenant_id, user_id, created_at DESC);
+
+-- +migrate Down
+
+DROP TABLE IF EXISTS api_tokens;
+
+DELETE FROM users WHERE type = 'service';
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_type_check;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_type_check CHECK (type IN ('system', 'user', 'superadmin'));
@@ -0,0 +1,31 @@
+-- +goose Up
+-- org_change_requests: review (approve/reject) + apply metadata.
+
+ALTER TABLE org_change_requests
+    ADD COLUMN IF NOT EXISTS reviewer_id uuid NULL,
+    ADD COLUMN IF NOT EXISTS review_comment text NULL,
+    ADD COLUMN IF NOT EXISTS reviewed_at timestamptz NULL,
+    ADD COLUMN IF NOT EXISTS applied_by uuid NULL,
+    ADD COLUMN IF NOT EXISTS applied_at timestamptz NULL;
+
+ALTER TABLE org_change_requests
+    DROP CONSTRAINT IF EXISTS org_change_requests_status_check;
+
+ALTER TABLE org_change_requests
+    ADD CONSTRAINT org_change_requests_status_check
+    CHECK (status IN ('draft', 'submitted', 'approved', 'rejected', 'applied', 'cancelled'));
+
+-- +goose Down
+ALTER TABLE org_change_requests
+    DROP CONSTRAINT IF EXISTS org_change_requests_status_check;
+
+ALTER TABLE org_change_requests
+    ADD CONSTRAINT org_change_requests_status_check
+    CHECK (status IN ('draft', 'submitted', 'approved', 'rejected', 'cancelled'));
+
+ALTER TABLE org_change_requests
+    DROP COLUMN IF EXISTS applied_at,
+    DROP COLUMN IF EXISTS applied_by,
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS review_comment,
+    DROP COLUMN IF EXISTS reviewer_id;
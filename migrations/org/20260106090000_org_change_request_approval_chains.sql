@@ -0,0 +1,54 @@
+-- +goose Up
+-- org_change_requests: configurable multi-step approval chains.
+
+CREATE TABLE IF NOT EXISTS org_approval_chain_steps (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    chain_code varchar(64) NOT NULL DEFAULT 'default',
+    step_order int NOT NULL,
+    role_code varchar(64) NOT NULL,
+    condition text NOT NULL DEFAULT 'always',
+    sla_hours int NOT NULL DEFAULT 72,
+    escalation_role_code varchar(64) NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_approval_chain_steps_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_approval_chain_steps_tenant_chain_order_key UNIQUE (tenant_id, chain_code, step_order),
+    CONSTRAINT org_approval_chain_steps_step_order_check CHECK (step_order >= 0),
+    CONSTRAINT org_approval_chain_steps_condition_check CHECK (condition IN ('always', 'fte_change', 'node_move', 'node_rescind')),
+    CONSTRAINT org_approval_chain_steps_sla_hours_check CHECK (sla_hours > 0)
+);
+
+CREATE TABLE IF NOT EXISTS org_change_request_approval_steps (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    change_request_id uuid NOT NULL,
+    step_order int NOT NULL,
+    role_code varchar(64) NOT NULL,
+    status text NOT NULL DEFAULT 'waiting',
+    approver_subjects jsonb NOT NULL DEFAULT '[]'::jsonb,
+    sla_hours int NOT NULL DEFAULT 72,
+    due_at timestamptz NULL,
+    escalation_role_code varchar(64) NULL,
+    escalation_subjects jsonb NOT NULL DEFAULT '[]'::jsonb,
+    escalated_at timestamptz NULL,
+    decided_by uuid NULL,
+    decided_at timestamptz NULL,
+    comment text NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_change_request_approval_steps_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_change_request_approval_steps_request_order_key UNIQUE (tenant_id, change_request_id, step_order),
+    CONSTRAINT org_change_request_approval_steps_status_check CHECK (status IN ('waiting', 'pending', 'approved', 'rejected', 'skipped')),
+    CONSTRAINT org_change_request_approval_steps_sla_hours_check CHECK (sla_hours > 0),
+    CONSTRAINT org_change_request_approval_steps_approver_subjects_is_array_check CHECK (jsonb_typeof(approver_subjects) = 'array'),
+    CONSTRAINT org_change_request_approval_steps_escalation_subjects_is_array_check CHECK (jsonb_typeof(escalation_subjects) = 'array'),
+    CONSTRAINT org_change_request_approval_steps_change_request_fk FOREIGN KEY (tenant_id, change_request_id) REFERENCES org_change_requests (tenant_id, id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS org_change_request_approval_steps_tenant_status_due_idx
+    ON org_change_request_approval_steps (tenant_id, status, due_at);
+
+-- +goose Down
+DROP TABLE IF EXISTS org_change_request_approval_steps;
+DROP TABLE IF EXISTS org_approval_chain_steps;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- org_change_requests: scheduled (future-dated) execution + execution outcome.
+
+ALTER TABLE org_change_requests
+    ADD COLUMN IF NOT EXISTS scheduled_for timestamptz NULL,
+    ADD COLUMN IF NOT EXISTS execution_attempts int NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS last_executed_at timestamptz NULL,
+    ADD COLUMN IF NOT EXISTS execution_result jsonb NULL,
+    ADD COLUMN IF NOT EXISTS execution_error jsonb NULL,
+    ADD COLUMN IF NOT EXISTS audit_request_id text NULL;
+
+CREATE INDEX IF NOT EXISTS org_change_requests_due_idx
+    ON org_change_requests (scheduled_for, id)
+    WHERE status = 'approved' AND scheduled_for IS NOT NULL AND execution_error IS NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS org_change_requests_due_idx;
+
+ALTER TABLE org_change_requests
+    DROP COLUMN IF EXISTS audit_request_id,
+    DROP COLUMN IF EXISTS execution_error,
+    DROP COLUMN IF EXISTS execution_result,
+    DROP COLUMN IF EXISTS last_executed_at,
+    DROP COLUMN IF EXISTS execution_attempts,
+    DROP COLUMN IF EXISTS scheduled_for;
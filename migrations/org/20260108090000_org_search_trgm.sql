@@ -0,0 +1,53 @@
+-- +goose Up
+-- org search: trigram indexes for /org/api/search (name/code/pernr/i18n_names, fuzzy + substring).
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION org_i18n_names_text (names jsonb)
+    RETURNS text
+    LANGUAGE sql
+    IMMUTABLE
+    PARALLEL SAFE
+    AS $$
+    SELECT
+        COALESCE(string_agg(value, ' ' ORDER BY key), '')
+    FROM
+        jsonb_each_text(COALESCE(names, '{}'::jsonb));
+$$;
+-- +goose StatementEnd
+
+CREATE INDEX IF NOT EXISTS org_nodes_code_trgm_idx ON org_nodes USING gin (code gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS org_node_slices_name_trgm_idx ON org_node_slices USING gin (name gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS org_node_slices_i18n_names_trgm_idx ON org_node_slices USING gin (org_i18n_names_text (i18n_names) gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS org_positions_code_trgm_idx ON org_positions USING gin (code gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS org_position_slices_title_trgm_idx ON org_position_slices USING gin (title gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS org_job_profiles_code_trgm_idx ON org_job_profiles USING gin (code gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS org_job_profile_slices_name_trgm_idx ON org_job_profile_slices USING gin (name gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS org_assignments_pernr_trgm_idx ON org_assignments USING gin (pernr gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS org_assignments_pernr_trgm_idx;
+
+DROP INDEX IF EXISTS org_job_profile_slices_name_trgm_idx;
+
+DROP INDEX IF EXISTS org_job_profiles_code_trgm_idx;
+
+DROP INDEX IF EXISTS org_position_slices_title_trgm_idx;
+
+DROP INDEX IF EXISTS org_positions_code_trgm_idx;
+
+DROP INDEX IF EXISTS org_node_slices_i18n_names_trgm_idx;
+
+DROP INDEX IF EXISTS org_node_slices_name_trgm_idx;
+
+DROP INDEX IF EXISTS org_nodes_code_trgm_idx;
+
+DROP FUNCTION IF EXISTS org_i18n_names_text (jsonb);
@@ -0,0 +1,261 @@
+-- +goose Up
+-- org hierarchy types: parallel CostCenter/LegalEntity/Geographic/Project trees next to OrgUnit,
+-- plus effective-dated memberships that attach positions/assignments to nodes of those trees.
+
+ALTER TABLE org_nodes DROP CONSTRAINT IF EXISTS org_nodes_type_check;
+ALTER TABLE org_nodes
+    ADD CONSTRAINT org_nodes_type_check CHECK (type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project'));
+
+DROP INDEX IF EXISTS org_nodes_tenant_root_unique;
+CREATE UNIQUE INDEX org_nodes_tenant_root_unique ON org_nodes (tenant_id, type) WHERE is_root;
+
+ALTER TABLE org_edges DROP CONSTRAINT IF EXISTS org_edges_hierarchy_type_check;
+ALTER TABLE org_edges
+    ADD CONSTRAINT org_edges_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project'));
+
+ALTER TABLE org_hierarchy_closure_builds DROP CONSTRAINT IF EXISTS org_hierarchy_closure_builds_hierarchy_type_check;
+ALTER TABLE org_hierarchy_closure_builds
+    ADD CONSTRAINT org_hierarchy_closure_builds_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project'));
+
+ALTER TABLE org_hierarchy_closure DROP CONSTRAINT IF EXISTS org_hierarchy_closure_hierarchy_type_check;
+ALTER TABLE org_hierarchy_closure
+    ADD CONSTRAINT org_hierarchy_closure_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project'));
+
+ALTER TABLE org_hierarchy_snapshot_builds DROP CONSTRAINT IF EXISTS org_hierarchy_snapshot_builds_hierarchy_type_check;
+ALTER TABLE org_hierarchy_snapshot_builds
+    ADD CONSTRAINT org_hierarchy_snapshot_builds_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project'));
+
+ALTER TABLE org_hierarchy_snapshots DROP CONSTRAINT IF EXISTS org_hierarchy_snapshots_hierarchy_type_check;
+ALTER TABLE org_hierarchy_snapshots
+    ADD CONSTRAINT org_hierarchy_snapshots_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project'));
+
+ALTER TABLE org_reporting_nodes DROP CONSTRAINT IF EXISTS org_reporting_nodes_hierarchy_type_check;
+ALTER TABLE org_reporting_nodes
+    ADD CONSTRAINT org_reporting_nodes_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project'));
+
+-- Parent lookups are now scoped to the edge's hierarchy, and every edge must connect nodes of
+-- that hierarchy's type so trees never leak into each other.
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION org_edges_set_path_depth_and_prevent_cycle()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    parent_path ltree;
+    child_path ltree;
+    child_key text;
+    node_type text;
+BEGIN
+    SELECT n.type INTO node_type
+    FROM org_nodes n
+    WHERE n.tenant_id = NEW.tenant_id AND n.id = NEW.child_node_id;
+    IF node_type IS DISTINCT FROM NEW.hierarchy_type THEN
+        RAISE EXCEPTION 'org_edges: child node type % does not match hierarchy_type %', node_type, NEW.hierarchy_type
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    child_key := replace(lower(NEW.child_node_id::text), '-', '');
+
+    IF NEW.parent_node_id IS NULL THEN
+        NEW.path := child_key::ltree;
+        NEW.depth := nlevel(NEW.path) - 1;
+        RETURN NEW;
+    END IF;
+
+    SELECT n.type INTO node_type
+    FROM org_nodes n
+    WHERE n.tenant_id = NEW.tenant_id AND n.id = NEW.parent_node_id;
+    IF node_type IS DISTINCT FROM NEW.hierarchy_type THEN
+        RAISE EXCEPTION 'org_edges: parent node type % does not match hierarchy_type %', node_type, NEW.hierarchy_type
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    SELECT e.path
+    INTO parent_path
+    FROM org_edges e
+    WHERE e.tenant_id = NEW.tenant_id
+      AND e.hierarchy_type = NEW.hierarchy_type
+      AND e.child_node_id = NEW.parent_node_id
+      AND e.effective_date <= NEW.effective_date
+      AND e.end_date >= NEW.effective_date
+    ORDER BY e.effective_date DESC
+    LIMIT 1;
+
+    IF parent_path IS NULL THEN
+        RAISE EXCEPTION 'org_edges: parent path not found (tenant_id=%, parent_node_id=%, as_of=%)',
+            NEW.tenant_id, NEW.parent_node_id, NEW.effective_date
+            USING ERRCODE = 'foreign_key_violation';
+    END IF;
+
+    SELECT e.path
+    INTO child_path
+    FROM org_edges e
+    WHERE e.tenant_id = NEW.tenant_id
+      AND e.hierarchy_type = NEW.hierarchy_type
+      AND e.child_node_id = NEW.child_node_id
+      AND e.effective_date <= NEW.effective_date
+      AND e.end_date >= NEW.effective_date
+    ORDER BY e.effective_date DESC
+    LIMIT 1;
+
+    IF child_path IS NOT NULL AND parent_path <@ child_path THEN
+        RAISE EXCEPTION 'org_edges: cycle detected (parent_node_id=% inside child_node_id=% subtree)',
+            NEW.parent_node_id, NEW.child_node_id
+            USING ERRCODE = 'integrity_constraint_violation';
+    END IF;
+
+    NEW.path := parent_path || child_key::ltree;
+    NEW.depth := nlevel(NEW.path) - 1;
+    RETURN NEW;
+END;
+$$;
+-- +goose StatementEnd
+
+CREATE TABLE IF NOT EXISTS org_hierarchy_memberships (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    subject_type text NOT NULL,
+    subject_id uuid NOT NULL,
+    hierarchy_type text NOT NULL,
+    org_node_id uuid NOT NULL,
+    effective_date date NOT NULL,
+    end_date date NOT NULL DEFAULT DATE '9999-12-31',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_hierarchy_memberships_subject_type_check CHECK (subject_type IN ('position', 'assignment')),
+    CONSTRAINT org_hierarchy_memberships_hierarchy_type_check CHECK (hierarchy_type IN ('CostCenter', 'LegalEntity', 'Geographic', 'Project')),
+    CONSTRAINT org_hierarchy_memberships_effective_check CHECK (effective_date <= end_date),
+    CONSTRAINT org_hierarchy_memberships_node_fk FOREIGN KEY (tenant_id, org_node_id) REFERENCES org_nodes (tenant_id, id) ON DELETE RESTRICT,
+    CONSTRAINT org_hierarchy_memberships_subject_no_overlap
+        EXCLUDE USING gist (
+            tenant_id gist_uuid_ops WITH =,
+            subject_type gist_text_ops WITH =,
+            subject_id gist_uuid_ops WITH =,
+            hierarchy_type gist_text_ops WITH =,
+            daterange(effective_date, end_date + 1, '[)') WITH &&
+        )
+);
+
+CREATE INDEX IF NOT EXISTS org_hierarchy_memberships_tenant_subject_idx
+    ON org_hierarchy_memberships (tenant_id, subject_type, subject_id, effective_date);
+CREATE INDEX IF NOT EXISTS org_hierarchy_memberships_tenant_node_idx
+    ON org_hierarchy_memberships (tenant_id, hierarchy_type, org_node_id, effective_date);
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION org_hierarchy_memberships_check_node_type()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    node_type text;
+BEGIN
+    SELECT n.type INTO node_type
+    FROM org_nodes n
+    WHERE n.tenant_id = NEW.tenant_id AND n.id = NEW.org_node_id;
+    IF node_type IS DISTINCT FROM NEW.hierarchy_type THEN
+        RAISE EXCEPTION 'org_hierarchy_memberships: node type % does not match hierarchy_type %', node_type, NEW.hierarchy_type
+            USING ERRCODE = 'check_violation';
+    END IF;
+    RETURN NEW;
+END;
+$$;
+-- +goose StatementEnd
+
+DROP TRIGGER IF EXISTS org_hierarchy_memberships_before_write_check_node_type ON org_hierarchy_memberships;
+CREATE TRIGGER org_hierarchy_memberships_before_write_check_node_type
+    BEFORE INSERT OR UPDATE OF hierarchy_type, org_node_id ON org_hierarchy_memberships
+    FOR EACH ROW
+    EXECUTE FUNCTION org_hierarchy_memberships_check_node_type();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS org_hierarchy_memberships_before_write_check_node_type ON org_hierarchy_memberships;
+DROP FUNCTION IF EXISTS org_hierarchy_memberships_check_node_type;
+DROP TABLE IF EXISTS org_hierarchy_memberships;
+
+-- +goose StatementBegin
+CREATE OR REPLACE FUNCTION org_edges_set_path_depth_and_prevent_cycle()
+RETURNS trigger
+LANGUAGE plpgsql
+AS $$
+DECLARE
+    parent_path ltree;
+    child_path ltree;
+    child_key text;
+BEGIN
+    child_key := replace(lower(NEW.child_node_id::text), '-', '');
+
+    IF NEW.parent_node_id IS NULL THEN
+        NEW.path := child_key::ltree;
+        NEW.depth := nlevel(NEW.path) - 1;
+        RETURN NEW;
+    END IF;
+
+    SELECT e.path
+    INTO parent_path
+    FROM org_edges e
+    WHERE e.tenant_id = NEW.tenant_id
+      AND e.child_node_id = NEW.parent_node_id
+      AND e.effective_date <= NEW.effective_date
+      AND e.end_date >= NEW.effective_date
+    ORDER BY e.effective_date DESC
+    LIMIT 1;
+
+    IF parent_path IS NULL THEN
+        RAISE EXCEPTION 'org_edges: parent path not found (tenant_id=%, parent_node_id=%, as_of=%)',
+            NEW.tenant_id, NEW.parent_node_id, NEW.effective_date
+            USING ERRCODE = 'foreign_key_violation';
+    END IF;
+
+    SELECT e.path
+    INTO child_path
+    FROM org_edges e
+    WHERE e.tenant_id = NEW.tenant_id
+      AND e.child_node_id = NEW.child_node_id
+      AND e.effective_date <= NEW.effective_date
+      AND e.end_date >= NEW.effective_date
+    ORDER BY e.effective_date DESC
+    LIMIT 1;
+
+    IF child_path IS NOT NULL AND parent_path <@ child_path THEN
+        RAISE EXCEPTION 'org_edges: cycle detected (parent_node_id=% inside child_node_id=% subtree)',
+            NEW.parent_node_id, NEW.child_node_id
+            USING ERRCODE = 'integrity_constraint_violation';
+    END IF;
+
+    NEW.path := parent_path || child_key::ltree;
+    NEW.depth := nlevel(NEW.path) - 1;
+    RETURN NEW;
+END;
+$$;
+-- +goose StatementEnd
+
+DROP INDEX IF EXISTS org_nodes_tenant_root_unique;
+CREATE UNIQUE INDEX org_nodes_tenant_root_unique ON org_nodes (tenant_id) WHERE is_root;
+
+ALTER TABLE org_reporting_nodes DROP CONSTRAINT IF EXISTS org_reporting_nodes_hierarchy_type_check;
+ALTER TABLE org_reporting_nodes
+    ADD CONSTRAINT org_reporting_nodes_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit'));
+
+ALTER TABLE org_hierarchy_snapshots DROP CONSTRAINT IF EXISTS org_hierarchy_snapshots_hierarchy_type_check;
+ALTER TABLE org_hierarchy_snapshots
+    ADD CONSTRAINT org_hierarchy_snapshots_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit'));
+
+ALTER TABLE org_hierarchy_snapshot_builds DROP CONSTRAINT IF EXISTS org_hierarchy_snapshot_builds_hierarchy_type_check;
+ALTER TABLE org_hierarchy_snapshot_builds
+    ADD CONSTRAINT org_hierarchy_snapshot_builds_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit'));
+
+ALTER TABLE org_hierarchy_closure DROP CONSTRAINT IF EXISTS org_hierarchy_closure_hierarchy_type_check;
+ALTER TABLE org_hierarchy_closure
+    ADD CONSTRAINT org_hierarchy_closure_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit'));
+
+ALTER TABLE org_hierarchy_closure_builds DROP CONSTRAINT IF EXISTS org_hierarchy_closure_builds_hierarchy_type_check;
+ALTER TABLE org_hierarchy_closure_builds
+    ADD CONSTRAINT org_hierarchy_closure_builds_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit'));
+
+ALTER TABLE org_edges DROP CONSTRAINT IF EXISTS org_edges_hierarchy_type_check;
+ALTER TABLE org_edges
+    ADD CONSTRAINT org_edges_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit'));
+
+ALTER TABLE org_nodes DROP CONSTRAINT IF EXISTS org_nodes_type_check;
+ALTER TABLE org_nodes
+    ADD CONSTRAINT org_nodes_type_check CHECK (type IN ('OrgUnit'));
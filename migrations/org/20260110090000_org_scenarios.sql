@@ -0,0 +1,27 @@
+-- +goose Up
+-- org_scenarios: what-if restructuring sandboxes. A scenario stores staged batch commands that
+-- are evaluated in a rolled-back transaction and can be turned into a change request.
+
+CREATE TABLE IF NOT EXISTS org_scenarios (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    name varchar(255) NOT NULL,
+    description text NULL,
+    effective_date date NOT NULL,
+    commands jsonb NOT NULL DEFAULT '[]'::jsonb,
+    status text NOT NULL DEFAULT 'draft',
+    change_request_id uuid NULL,
+    created_by uuid NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_scenarios_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_scenarios_status_check CHECK (status IN ('draft', 'converted')),
+    CONSTRAINT org_scenarios_commands_is_array_check CHECK (jsonb_typeof(commands) = 'array'),
+    CONSTRAINT org_scenarios_converted_check CHECK ((status = 'converted') = (change_request_id IS NOT NULL)),
+    CONSTRAINT org_scenarios_change_request_fk FOREIGN KEY (tenant_id, change_request_id) REFERENCES org_change_requests (tenant_id, id) ON DELETE RESTRICT
+);
+
+CREATE INDEX IF NOT EXISTS org_scenarios_tenant_updated_idx ON org_scenarios (tenant_id, updated_at DESC, id);
+
+-- +goose Down
+DROP TABLE IF EXISTS org_scenarios;
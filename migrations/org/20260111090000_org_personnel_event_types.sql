@@ -0,0 +1,11 @@
+-- +goose Up
+-- Extended personnel event types: promotion, leave, return, rehire and FTE change.
+
+ALTER TABLE org_personnel_events DROP CONSTRAINT IF EXISTS org_personnel_events_event_type_check;
+ALTER TABLE org_personnel_events
+    ADD CONSTRAINT org_personnel_events_event_type_check CHECK (event_type IN ('hire', 'transfer', 'termination', 'promotion', 'leave', 'return', 'rehire', 'fte_change'));
+
+-- +goose Down
+ALTER TABLE org_personnel_events DROP CONSTRAINT IF EXISTS org_personnel_events_event_type_check;
+ALTER TABLE org_personnel_events
+    ADD CONSTRAINT org_personnel_events_event_type_check CHECK (event_type IN ('hire', 'transfer', 'termination')) NOT VALID;
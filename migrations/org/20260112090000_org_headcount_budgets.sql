@@ -0,0 +1,61 @@
+-- +goose Up
+-- org headcount budgets: effective-dated planned headcount/FTE/cost per org node, optionally
+-- narrowed to a job profile and/or job level, plus org_settings.position_budget_validation_mode.
+
+ALTER TABLE org_settings
+    ADD COLUMN IF NOT EXISTS position_budget_validation_mode text NOT NULL DEFAULT 'disabled';
+
+-- +goose StatementBegin
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM pg_constraint
+        WHERE conname = 'org_settings_position_budget_validation_mode_check'
+    ) THEN
+        ALTER TABLE org_settings
+            ADD CONSTRAINT org_settings_position_budget_validation_mode_check
+            CHECK (position_budget_validation_mode IN ('disabled', 'shadow', 'enforce'));
+    END IF;
+END $$;
+-- +goose StatementEnd
+
+CREATE TABLE IF NOT EXISTS org_headcount_budgets (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_node_id uuid NOT NULL,
+    job_profile_id uuid NULL,
+    job_level_code varchar(64) NULL,
+    planned_headcount int NOT NULL,
+    planned_fte numeric(9, 2) NOT NULL,
+    planned_cost numeric(14, 2) NULL,
+    effective_date date NOT NULL,
+    end_date date NOT NULL DEFAULT DATE '9999-12-31',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_headcount_budgets_effective_check CHECK (effective_date <= end_date),
+    CONSTRAINT org_headcount_budgets_planned_headcount_check CHECK (planned_headcount >= 0),
+    CONSTRAINT org_headcount_budgets_planned_fte_check CHECK (planned_fte >= 0),
+    CONSTRAINT org_headcount_budgets_planned_cost_check CHECK (planned_cost IS NULL OR planned_cost >= 0),
+    CONSTRAINT org_headcount_budgets_node_fk FOREIGN KEY (tenant_id, org_node_id) REFERENCES org_nodes (tenant_id, id) ON DELETE RESTRICT,
+    CONSTRAINT org_headcount_budgets_line_no_overlap
+        EXCLUDE USING gist (
+            tenant_id gist_uuid_ops WITH =,
+            org_node_id gist_uuid_ops WITH =,
+            (COALESCE(job_profile_id, '00000000-0000-0000-0000-000000000000'::uuid)) gist_uuid_ops WITH =,
+            (COALESCE(job_level_code, '')) gist_text_ops WITH =,
+            daterange(effective_date, end_date + 1, '[)') WITH &&
+        )
+);
+
+CREATE INDEX IF NOT EXISTS org_headcount_budgets_tenant_node_idx
+    ON org_headcount_budgets (tenant_id, org_node_id, effective_date);
+
+-- +goose Down
+DROP TABLE IF EXISTS org_headcount_budgets;
+
+ALTER TABLE org_settings
+    DROP CONSTRAINT IF EXISTS org_settings_position_budget_validation_mode_check;
+
+ALTER TABLE org_settings
+    DROP COLUMN IF EXISTS position_budget_validation_mode;
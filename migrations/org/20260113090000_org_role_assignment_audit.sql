@@ -0,0 +1,11 @@
+-- +goose Up
+-- Role and role assignment writes are audited alongside the other org commands.
+
+ALTER TABLE org_audit_logs DROP CONSTRAINT IF EXISTS org_audit_logs_entity_type_check;
+ALTER TABLE org_audit_logs
+    ADD CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment', 'org_role', 'org_role_assignment'));
+
+-- +goose Down
+ALTER TABLE org_audit_logs DROP CONSTRAINT IF EXISTS org_audit_logs_entity_type_check;
+ALTER TABLE org_audit_logs
+    ADD CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment')) NOT VALID;
@@ -0,0 +1,48 @@
+-- +goose Up
+-- org delegations: a user acts for the manager of an org node, or for a role holder on it,
+-- over a bounded date range (end_date is the last day in force).
+
+CREATE TABLE IF NOT EXISTS org_delegations (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    delegation_type text NOT NULL,
+    org_node_id uuid NOT NULL,
+    role_id uuid NULL,
+    delegator_subject_id uuid NULL,
+    delegate_user_id bigint NOT NULL,
+    delegate_subject_id uuid NOT NULL,
+    effective_date date NOT NULL,
+    end_date date NOT NULL,
+    reason_note text NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_delegations_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_delegations_effective_check CHECK (effective_date <= end_date),
+    CONSTRAINT org_delegations_type_check CHECK (delegation_type IN ('manager', 'role')),
+    CONSTRAINT org_delegations_role_check CHECK ((delegation_type = 'role') = (role_id IS NOT NULL)),
+    CONSTRAINT org_delegations_node_fk FOREIGN KEY (tenant_id, org_node_id) REFERENCES org_nodes (tenant_id, id) ON DELETE RESTRICT,
+    CONSTRAINT org_delegations_role_fk FOREIGN KEY (tenant_id, role_id) REFERENCES org_roles (tenant_id, id) ON DELETE RESTRICT,
+    CONSTRAINT org_delegations_acting_manager_no_overlap
+        EXCLUDE USING gist (
+            tenant_id gist_uuid_ops WITH =,
+            org_node_id gist_uuid_ops WITH =,
+            daterange(effective_date, end_date + 1, '[)') WITH &&
+        ) WHERE (delegation_type = 'manager')
+);
+
+CREATE INDEX IF NOT EXISTS org_delegations_tenant_node_idx
+    ON org_delegations (tenant_id, org_node_id, effective_date);
+
+CREATE INDEX IF NOT EXISTS org_delegations_tenant_delegate_idx
+    ON org_delegations (tenant_id, delegate_user_id, effective_date);
+
+ALTER TABLE org_audit_logs DROP CONSTRAINT IF EXISTS org_audit_logs_entity_type_check;
+ALTER TABLE org_audit_logs
+    ADD CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment', 'org_role', 'org_role_assignment', 'org_delegation'));
+
+-- +goose Down
+ALTER TABLE org_audit_logs DROP CONSTRAINT IF EXISTS org_audit_logs_entity_type_check;
+ALTER TABLE org_audit_logs
+    ADD CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment', 'org_role', 'org_role_assignment')) NOT VALID;
+
+DROP TABLE IF EXISTS org_delegations;
@@ -0,0 +1,40 @@
+-- +goose Up
+-- org inheritance breaks: an org node stops inheriting one attribute from its parent and starts
+-- a new inheritance chain for its subtree (end_date is the last day in force).
+
+CREATE TABLE IF NOT EXISTS org_attribute_inheritance_breaks (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    hierarchy_type text NOT NULL,
+    attribute_name text NOT NULL,
+    org_node_id uuid NOT NULL,
+    effective_date date NOT NULL,
+    end_date date NOT NULL DEFAULT DATE '9999-12-31',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_attribute_inheritance_breaks_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_attribute_inheritance_breaks_effective_check CHECK (effective_date <= end_date),
+    CONSTRAINT org_attribute_inheritance_breaks_node_fk FOREIGN KEY (tenant_id, org_node_id) REFERENCES org_nodes (tenant_id, id) ON DELETE RESTRICT,
+    CONSTRAINT org_attribute_inheritance_breaks_no_overlap
+        EXCLUDE USING gist (
+            tenant_id gist_uuid_ops WITH =,
+            hierarchy_type gist_text_ops WITH =,
+            attribute_name gist_text_ops WITH =,
+            org_node_id gist_uuid_ops WITH =,
+            daterange(effective_date, end_date + 1, '[)') WITH &&
+        )
+);
+
+CREATE INDEX IF NOT EXISTS org_attribute_inheritance_breaks_tenant_hierarchy_attribute_effective_idx
+    ON org_attribute_inheritance_breaks (tenant_id, hierarchy_type, attribute_name, effective_date);
+
+ALTER TABLE org_audit_logs DROP CONSTRAINT IF EXISTS org_audit_logs_entity_type_check;
+ALTER TABLE org_audit_logs
+    ADD CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment', 'org_role', 'org_role_assignment', 'org_delegation', 'org_inheritance_rule', 'org_inheritance_break'));
+
+-- +goose Down
+ALTER TABLE org_audit_logs DROP CONSTRAINT IF EXISTS org_audit_logs_entity_type_check;
+ALTER TABLE org_audit_logs
+    ADD CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment', 'org_role', 'org_role_assignment', 'org_delegation')) NOT VALID;
+
+DROP TABLE IF EXISTS org_attribute_inheritance_breaks;
@@ -0,0 +1,59 @@
+-- +goose Up
+-- org custom attributes: tenant-defined, effective-dated attribute definitions for org nodes and
+-- positions (end_date is the last day in force). Values live on the node/position slices.
+
+CREATE TABLE IF NOT EXISTS org_attribute_definitions (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    entity_type text NOT NULL,
+    code text NOT NULL,
+    label text NOT NULL,
+    value_type text NOT NULL,
+    options jsonb NOT NULL DEFAULT '[]'::jsonb,
+    required boolean NOT NULL DEFAULT false,
+    pattern text NULL,
+    min_value numeric NULL,
+    max_value numeric NULL,
+    max_length integer NULL,
+    display_order integer NOT NULL DEFAULT 0,
+    effective_date date NOT NULL,
+    end_date date NOT NULL DEFAULT DATE '9999-12-31',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_attribute_definitions_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_attribute_definitions_entity_type_check CHECK (entity_type IN ('org_node', 'org_position')),
+    CONSTRAINT org_attribute_definitions_value_type_check CHECK (value_type IN ('text', 'number', 'boolean', 'date', 'select')),
+    CONSTRAINT org_attribute_definitions_code_check CHECK (code ~ '^[a-z][a-z0-9_]*$'),
+    CONSTRAINT org_attribute_definitions_options_check CHECK (jsonb_typeof(options) = 'array'),
+    CONSTRAINT org_attribute_definitions_effective_check CHECK (effective_date <= end_date),
+    CONSTRAINT org_attribute_definitions_no_overlap
+        EXCLUDE USING gist (
+            tenant_id gist_uuid_ops WITH =,
+            entity_type gist_text_ops WITH =,
+            code gist_text_ops WITH =,
+            daterange(effective_date, end_date + 1, '[)') WITH &&
+        )
+);
+
+CREATE INDEX IF NOT EXISTS org_attribute_definitions_tenant_entity_effective_idx
+    ON org_attribute_definitions (tenant_id, entity_type, effective_date);
+
+ALTER TABLE org_node_slices
+    ADD COLUMN IF NOT EXISTS custom_attributes jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+ALTER TABLE org_position_slices
+    ADD COLUMN IF NOT EXISTS custom_attributes jsonb NOT NULL DEFAULT '{}'::jsonb;
+
+ALTER TABLE org_audit_logs DROP CONSTRAINT IF EXISTS org_audit_logs_entity_type_check;
+ALTER TABLE org_audit_logs
+    ADD CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment', 'org_role', 'org_role_assignment', 'org_delegation', 'org_inheritance_rule', 'org_inheritance_break', 'org_attribute_definition'));
+
+-- +goose Down
+ALTER TABLE org_audit_logs DROP CONSTRAINT IF EXISTS org_audit_logs_entity_type_check;
+ALTER TABLE org_audit_logs
+    ADD CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment', 'org_role', 'org_role_assignment', 'org_delegation', 'org_inheritance_rule', 'org_inheritance_break')) NOT VALID;
+
+ALTER TABLE org_position_slices DROP COLUMN IF EXISTS custom_attributes;
+ALTER TABLE org_node_slices DROP COLUMN IF EXISTS custom_attributes;
+
+DROP TABLE IF EXISTS org_attribute_definitions;
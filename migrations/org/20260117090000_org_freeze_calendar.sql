@@ -0,0 +1,37 @@
+-- +goose Up
+-- org freeze calendar: explicit close periods (period_end is the last day of the period). Writes
+-- affecting a day inside a period are frozen from locks_at on; when a tenant has any periods the
+-- calendar replaces the rolling freeze_grace_days cutoff.
+
+CREATE TABLE IF NOT EXISTS org_freeze_periods (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    label text NOT NULL,
+    period_start date NOT NULL,
+    period_end date NOT NULL,
+    locks_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_freeze_periods_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_freeze_periods_label_check CHECK (btrim(label) <> ''),
+    CONSTRAINT org_freeze_periods_range_check CHECK (period_start <= period_end),
+    CONSTRAINT org_freeze_periods_no_overlap
+        EXCLUDE USING gist (
+            tenant_id gist_uuid_ops WITH =,
+            daterange(period_start, period_end + 1, '[)') WITH &&
+        )
+);
+
+CREATE INDEX IF NOT EXISTS org_freeze_periods_tenant_start_idx
+    ON org_freeze_periods (tenant_id, period_start);
+
+ALTER TABLE org_audit_logs DROP CONSTRAINT IF EXISTS org_audit_logs_entity_type_check;
+ALTER TABLE org_audit_logs
+    ADD CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment', 'org_role', 'org_role_assignment', 'org_delegation', 'org_inheritance_rule', 'org_inheritance_break', 'org_attribute_definition', 'org_freeze_period'));
+
+-- +goose Down
+ALTER TABLE org_audit_logs DROP CONSTRAINT IF EXISTS org_audit_logs_entity_type_check;
+ALTER TABLE org_audit_logs
+    ADD CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment', 'org_role', 'org_role_assignment', 'org_delegation', 'org_inheritance_rule', 'org_inheritance_break', 'org_attribute_definition')) NOT VALID;
+
+DROP TABLE IF EXISTS org_freeze_periods;
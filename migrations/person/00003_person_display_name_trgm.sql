@@ -0,0 +1,10 @@
+-- +goose Up
+-- org search: fuzzy matching on persons.display_name (display_name % query).
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS persons_display_name_trgm_idx ON persons USING gin (display_name gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS persons_display_name_trgm_idx;
+
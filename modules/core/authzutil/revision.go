@@ -10,7 +10,7 @@ import (
 
 var (
 	revisionOnce sync.Once
-	revisionProv *authzVersion.FileProvider
+	revisionProv authzVersion.Provider
 )
 
 // ResetRevisionProvider clears the cached revision provider; intended for tests.
@@ -19,6 +19,14 @@ func ResetRevisionProvider() {
 	revisionProv = nil
 }
 
+// UseRevisionProvider replaces the policy.csv.rev provider, e.g. with the database policy store.
+func UseRevisionProvider(p authzVersion.Provider) {
+	revisionOnce = sync.Once{}
+	revisionOnce.Do(func() {
+		revisionProv = p
+	})
+}
+
 // BaseRevision returns the current aggregated policy revision string for UI rendering.
 func BaseRevision(ctx context.Context) string {
 	revisionOnce.Do(func() {
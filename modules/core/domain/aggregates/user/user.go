@@ -23,6 +23,8 @@ const (
 	TypeSystem     Type = "system"
 	TypeUser       Type = "user"
 	TypeSuperAdmin Type = "superadmin"
+	// TypeService is a non-human account that acts only through API tokens and cannot sign in.
+	TypeService Type = "service"
 )
 
 // --- Option setters ---
@@ -0,0 +1,42 @@
+package apitoken
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrNotFound = errors.New("api token not found")
+
+// Token is an API credential issued to a user or a service account. The secret itself is never
+// stored: Hash is its sha256 and Prefix its first characters, shown so owners can tell tokens
+// apart. Scopes narrow what the token may do below what its owner is allowed to do.
+type Token struct {
+	ID         int64
+	TenantID   uuid.UUID
+	UserID     uint
+	Name       string
+	Prefix     string
+	Hash       string
+	Scopes     []string
+	CreatedBy  uint
+	ExpiresAt  time.Time
+	LastUsedAt *time.Time
+	LastUsedIP string
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (t Token) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+func (t Token) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
+
+// IsActive reports whether the token can still be used to authenticate.
+func (t Token) IsActive(now time.Time) bool {
+	return !t.IsRevoked() && !t.IsExpired(now)
+}
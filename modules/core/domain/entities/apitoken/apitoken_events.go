@@ -0,0 +1,23 @@
+package apitoken
+
+func NewCreatedEvent(result Token) (*CreatedEvent, error) {
+	return &CreatedEvent{
+		Result: result,
+	}, nil
+}
+
+func NewRevokedEvent(result Token) (*RevokedEvent, error) {
+	return &RevokedEvent{
+		Result: result,
+	}, nil
+}
+
+// CreatedEvent is published when an API token is issued.
+type CreatedEvent struct {
+	Result Token
+}
+
+// RevokedEvent is published when an API token is revoked.
+type RevokedEvent struct {
+	Result Token
+}
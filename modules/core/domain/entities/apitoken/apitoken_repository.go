@@ -0,0 +1,19 @@
+package apitoken
+
+import (
+	"context"
+	"time"
+)
+
+type Repository interface {
+	// List returns the tokens owned by any of userIDs, newest first.
+	List(ctx context.Context, userIDs []uint) ([]Token, error)
+	GetByID(ctx context.Context, id int64) (Token, error)
+	// GetByHash looks a token up by the hash of its secret. It is not tenant scoped, since the
+	// tenant is only known once the token has been found.
+	GetByHash(ctx context.Context, hash string) (Token, error)
+	Create(ctx context.Context, data Token) (Token, error)
+	Revoke(ctx context.Context, id int64) error
+	// TouchLastUsed is not tenant scoped for the same reason as GetByHash.
+	TouchLastUsed(ctx context.Context, id int64, at time.Time, ip string) error
+}
@@ -0,0 +1,88 @@
+package policyrequest
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound   = errors.New("policy change request not found")
+	ErrNotPending = errors.New("policy change request is no longer pending")
+)
+
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusApproved  Status = "approved"
+	StatusRejected  Status = "rejected"
+	StatusWithdrawn Status = "withdrawn"
+)
+
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusApproved, StatusRejected, StatusWithdrawn:
+		return true
+	}
+	return false
+}
+
+// Change is one staged policy edit: add or remove a single policy row.
+type Change struct {
+	StageKind string `json:"stage_kind"`
+	Type      string `json:"type"`
+	Subject   string `json:"subject"`
+	Domain    string `json:"domain"`
+	Object    string `json:"object"`
+	Action    string `json:"action"`
+	Effect    string `json:"effect"`
+	Condition string `json:"condition,omitempty"`
+}
+
+// Draft is a change a user has staged but not yet submitted for review.
+type Draft struct {
+	ID        uuid.UUID
+	TenantID  uuid.UUID
+	UserID    uint
+	Change    Change
+	CreatedAt time.Time
+}
+
+// ChangeRequest is a set of changes waiting for, or decided by, a second admin. ReviewerID is the
+// reviewer the author asked for; zero means any admin allowed to review.
+type ChangeRequest struct {
+	ID              int64
+	TenantID        uuid.UUID
+	AuthorID        uint
+	AuthorName      string
+	ReviewerID      uint
+	ReviewerName    string
+	Subject         string
+	Domain          string
+	Reason          string
+	BaseRevision    string
+	Changes         []Change
+	RevertOf        *int64
+	Status          Status
+	DecidedBy       uint
+	DeciderName     string
+	DecidedAt       *time.Time
+	AppliedRevision string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (r ChangeRequest) IsPending() bool {
+	return r.Status == StatusPending
+}
+
+type Comment struct {
+	ID         int64
+	RequestID  int64
+	AuthorID   uint
+	AuthorName string
+	Body       string
+	CreatedAt  time.Time
+}
@@ -0,0 +1,36 @@
+package policyrequest
+
+func NewSubmittedEvent(result ChangeRequest) (*SubmittedEvent, error) {
+	return &SubmittedEvent{
+		Result: result,
+	}, nil
+}
+
+func NewDecidedEvent(result ChangeRequest) (*DecidedEvent, error) {
+	return &DecidedEvent{
+		Result: result,
+	}, nil
+}
+
+func NewCommentedEvent(request ChangeRequest, result Comment) (*CommentedEvent, error) {
+	return &CommentedEvent{
+		Request: request,
+		Result:  result,
+	}, nil
+}
+
+// SubmittedEvent is published when a change request is submitted for review.
+type SubmittedEvent struct {
+	Result ChangeRequest
+}
+
+// DecidedEvent is published when a change request is approved, rejected or withdrawn.
+type DecidedEvent struct {
+	Result ChangeRequest
+}
+
+// CommentedEvent is published when someone comments on a change request.
+type CommentedEvent struct {
+	Request ChangeRequest
+	Result  Comment
+}
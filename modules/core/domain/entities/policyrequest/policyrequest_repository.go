@@ -0,0 +1,36 @@
+package policyrequest
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type FindParams struct {
+	Limit    int
+	Offset   int
+	Status   Status
+	AuthorID uint
+}
+
+type Repository interface {
+	Drafts(ctx context.Context, userID uint, subject, domain string) ([]Draft, error)
+	CountDrafts(ctx context.Context, userID uint) (int, error)
+	CreateDrafts(ctx context.Context, drafts []Draft) ([]Draft, error)
+	// DeleteDrafts deletes the user's drafts with the given ids and returns how many it deleted.
+	DeleteDrafts(ctx context.Context, userID uint, ids []uuid.UUID) (int64, error)
+	// ClearDrafts deletes the user's drafts, narrowed to subject and domain when they are set.
+	ClearDrafts(ctx context.Context, userID uint, subject, domain string) error
+
+	Count(ctx context.Context, params *FindParams) (int64, error)
+	GetPaginated(ctx context.Context, params *FindParams) ([]ChangeRequest, error)
+	GetByID(ctx context.Context, id int64) (ChangeRequest, error)
+	Create(ctx context.Context, data ChangeRequest) (ChangeRequest, error)
+	// Decide moves a pending request to status. It returns ErrNotPending when the request was
+	// decided in the meantime, so two reviewers cannot both act on it.
+	Decide(ctx context.Context, id int64, status Status, decidedBy uint) error
+	SetAppliedRevision(ctx context.Context, id int64, revision string) error
+
+	AddComment(ctx context.Context, data Comment) (Comment, error)
+	Comments(ctx context.Context, requestID int64) ([]Comment, error)
+}
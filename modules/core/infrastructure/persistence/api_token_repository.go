@@ -0,0 +1,176 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+const (
+	selectAPITokensQuery = `
+        SELECT id, tenant_id, user_id, name, token_prefix, token_hash, scopes, COALESCE(created_by, 0),
+               expires_at, last_used_at, COALESCE(last_used_ip, ''), revoked_at, created_at
+        FROM api_tokens`
+	insertAPITokenQuery = `
+        INSERT INTO api_tokens (tenant_id, user_id, name, token_prefix, token_hash, scopes, created_by, expires_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+        RETURNING id, created_at`
+	revokeAPITokenQuery = `
+        UPDATE api_tokens SET revoked_at = now()
+        WHERE id = $1 AND tenant_id = $2 AND revoked_at IS NULL`
+	touchAPITokenQuery = `UPDATE api_tokens SET last_used_at = $1, last_used_ip = $2 WHERE id = $3`
+)
+
+type APITokenRepository struct{}
+
+func NewAPITokenRepository() apitoken.Repository {
+	return &APITokenRepository{}
+}
+
+func (g *APITokenRepository) List(ctx context.Context, userIDs []uint) ([]apitoken.Token, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, int64(id))
+	}
+	return g.queryTokens(
+		ctx,
+		repo.Join(selectAPITokensQuery, "WHERE tenant_id = $1 AND user_id = ANY($2)", "ORDER BY created_at DESC, id DESC"),
+		tenantID,
+		ids,
+	)
+}
+
+func (g *APITokenRepository) GetByID(ctx context.Context, id int64) (apitoken.Token, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return apitoken.Token{}, err
+	}
+	tokens, err := g.queryTokens(ctx, repo.Join(selectAPITokensQuery, "WHERE id = $1 AND tenant_id = $2"), id, tenantID)
+	if err != nil {
+		return apitoken.Token{}, err
+	}
+	if len(tokens) == 0 {
+		return apitoken.Token{}, apitoken.ErrNotFound
+	}
+	return tokens[0], nil
+}
+
+func (g *APITokenRepository) GetByHash(ctx context.Context, hash string) (apitoken.Token, error) {
+	tokens, err := g.queryTokens(ctx, repo.Join(selectAPITokensQuery, "WHERE token_hash = $1"), hash)
+	if err != nil {
+		return apitoken.Token{}, err
+	}
+	if len(tokens) == 0 {
+		return apitoken.Token{}, apitoken.ErrNotFound
+	}
+	return tokens[0], nil
+}
+
+func (g *APITokenRepository) Create(ctx context.Context, data apitoken.Token) (apitoken.Token, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return apitoken.Token{}, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return apitoken.Token{}, err
+	}
+	var createdBy *int64
+	if data.CreatedBy != 0 {
+		id := int64(data.CreatedBy)
+		createdBy = &id
+	}
+	data.TenantID = tenantID
+	if err := tx.QueryRow(
+		ctx,
+		insertAPITokenQuery,
+		data.TenantID,
+		data.UserID,
+		data.Name,
+		data.Prefix,
+		data.Hash,
+		data.Scopes,
+		createdBy,
+		data.ExpiresAt,
+	).Scan(&data.ID, &data.CreatedAt); err != nil {
+		return apitoken.Token{}, err
+	}
+	return data, nil
+}
+
+func (g *APITokenRepository) Revoke(ctx context.Context, id int64) error {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, revokeAPITokenQuery, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return apitoken.ErrNotFound
+	}
+	return nil
+}
+
+func (g *APITokenRepository) TouchLastUsed(ctx context.Context, id int64, at time.Time, ip string) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, touchAPITokenQuery, at, ip, id)
+	return err
+}
+
+func (g *APITokenRepository) queryTokens(ctx context.Context, query string, args ...interface{}) ([]apitoken.Token, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := []apitoken.Token{}
+	for rows.Next() {
+		var (
+			t         apitoken.Token
+			userID    int64
+			createdBy int64
+		)
+		if err := rows.Scan(
+			&t.ID,
+			&t.TenantID,
+			&userID,
+			&t.Name,
+			&t.Prefix,
+			&t.Hash,
+			&t.Scopes,
+			&createdBy,
+			&t.ExpiresAt,
+			&t.LastUsedAt,
+			&t.LastUsedIP,
+			&t.RevokedAt,
+			&t.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		t.UserID = uint(userID)
+		t.CreatedBy = uint(createdBy)
+		tokens = append(tokens, t)
+	}
+	return tokens, rows.Err()
+}
@@ -0,0 +1,447 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policyrequest"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/repo"
+)
+
+const (
+	selectPolicyDraftsQuery = `
+        SELECT id, tenant_id, user_id, stage_kind, type, subject, domain, object, action, effect, condition, created_at
+        FROM authz_policy_drafts`
+	countPolicyDraftsQuery = `SELECT COUNT(*) FROM authz_policy_drafts WHERE tenant_id = $1 AND user_id = $2`
+	insertPolicyDraftQuery = `
+        INSERT INTO authz_policy_drafts (tenant_id, user_id, stage_kind, type, subject, domain, object, action, effect, condition)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING id, created_at`
+	deletePolicyDraftsQuery = `DELETE FROM authz_policy_drafts WHERE tenant_id = $1 AND user_id = $2 AND id = ANY($3)`
+
+	policyUserNameExpr = `COALESCE(NULLIF(TRIM(CONCAT_WS(' ', %[1]s.first_name, %[1]s.last_name)), ''), %[1]s.email, '')`
+
+	countPolicyRequestsQuery = `SELECT COUNT(*) FROM authz_policy_change_requests r`
+	insertPolicyRequestQuery = `
+        INSERT INTO authz_policy_change_requests (
+            tenant_id, author_id, reviewer_id, subject, domain, reason, base_revision, changes, revert_of
+        )
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        RETURNING id`
+	decidePolicyRequestQuery = `
+        UPDATE authz_policy_change_requests
+        SET status = $1, decided_by = $2, decided_at = now(), updated_at = now()
+        WHERE id = $3 AND tenant_id = $4 AND status = 'pending'`
+	setPolicyRequestRevisionQuery = `
+        UPDATE authz_policy_change_requests
+        SET applied_revision = $1, updated_at = now()
+        WHERE id = $2 AND tenant_id = $3`
+
+	insertPolicyRequestCommentQuery = `
+        INSERT INTO authz_policy_change_request_comments (request_id, author_id, body)
+        VALUES ($1, $2, $3)
+        RETURNING id, created_at`
+)
+
+var (
+	selectPolicyRequestsQuery = fmt.Sprintf(`
+        SELECT r.id, r.tenant_id, r.author_id, %s, COALESCE(r.reviewer_id, 0), %s, r.subject, r.domain,
+               r.reason, r.base_revision, r.changes, r.revert_of, r.status, COALESCE(r.decided_by, 0), %s,
+               r.decided_at, COALESCE(r.applied_revision, ''), r.created_at, r.updated_at
+        FROM authz_policy_change_requests r
+        LEFT JOIN users author ON author.id = r.author_id
+        LEFT JOIN users reviewer ON reviewer.id = r.reviewer_id
+        LEFT JOIN users decider ON decider.id = r.decided_by`,
+		fmt.Sprintf(policyUserNameExpr, "author"),
+		fmt.Sprintf(policyUserNameExpr, "reviewer"),
+		fmt.Sprintf(policyUserNameExpr, "decider"),
+	)
+	selectPolicyRequestCommentsQuery = fmt.Sprintf(`
+        SELECT c.id, c.request_id, c.author_id, %s, c.body, c.created_at
+        FROM authz_policy_change_request_comments c
+        JOIN authz_policy_change_requests r ON r.id = c.request_id
+        LEFT JOIN users author ON author.id = c.author_id
+        WHERE c.request_id = $1 AND r.tenant_id = $2
+        ORDER BY c.created_at, c.id`,
+		fmt.Sprintf(policyUserNameExpr, "author"),
+	)
+)
+
+type PolicyRequestRepository struct{}
+
+func NewPolicyRequestRepository() policyrequest.Repository {
+	return &PolicyRequestRepository{}
+}
+
+func (g *PolicyRequestRepository) Drafts(ctx context.Context, userID uint, subject, domain string) ([]policyrequest.Draft, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	where, args := []string{"tenant_id = $1", "user_id = $2"}, []interface{}{tenantID, userID}
+	if subject != "" {
+		args = append(args, subject)
+		where = append(where, fmt.Sprintf("subject = $%d", len(args)))
+	}
+	if domain != "" {
+		args = append(args, domain)
+		where = append(where, fmt.Sprintf("domain = $%d", len(args)))
+	}
+
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, repo.Join(selectPolicyDraftsQuery, repo.JoinWhere(where...), "ORDER BY created_at, id"), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	drafts := []policyrequest.Draft{}
+	for rows.Next() {
+		var (
+			d      policyrequest.Draft
+			userID int64
+		)
+		if err := rows.Scan(
+			&d.ID,
+			&d.TenantID,
+			&userID,
+			&d.Change.StageKind,
+			&d.Change.Type,
+			&d.Change.Subject,
+			&d.Change.Domain,
+			&d.Change.Object,
+			&d.Change.Action,
+			&d.Change.Effect,
+			&d.Change.Condition,
+			&d.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		d.UserID = uint(userID)
+		drafts = append(drafts, d)
+	}
+	return drafts, rows.Err()
+}
+
+func (g *PolicyRequestRepository) CountDrafts(ctx context.Context, userID uint) (int, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	if err := tx.QueryRow(ctx, countPolicyDraftsQuery, tenantID, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *PolicyRequestRepository) CreateDrafts(ctx context.Context, drafts []policyrequest.Draft) ([]policyrequest.Draft, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	created := make([]policyrequest.Draft, 0, len(drafts))
+	for _, d := range drafts {
+		d.TenantID = tenantID
+		if err := tx.QueryRow(
+			ctx,
+			insertPolicyDraftQuery,
+			d.TenantID,
+			d.UserID,
+			d.Change.StageKind,
+			d.Change.Type,
+			d.Change.Subject,
+			d.Change.Domain,
+			d.Change.Object,
+			d.Change.Action,
+			d.Change.Effect,
+			d.Change.Condition,
+		).Scan(&d.ID, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		created = append(created, d)
+	}
+	return created, nil
+}
+
+func (g *PolicyRequestRepository) DeleteDrafts(ctx context.Context, userID uint, ids []uuid.UUID) (int64, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	tag, err := tx.Exec(ctx, deletePolicyDraftsQuery, tenantID, userID, ids)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+func (g *PolicyRequestRepository) ClearDrafts(ctx context.Context, userID uint, subject, domain string) error {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	where, args := []string{"tenant_id = $1", "user_id = $2"}, []interface{}{tenantID, userID}
+	if subject != "" {
+		args = append(args, subject)
+		where = append(where, fmt.Sprintf("subject = $%d", len(args)))
+	}
+	if domain != "" {
+		args = append(args, domain)
+		where = append(where, fmt.Sprintf("domain = $%d", len(args)))
+	}
+	return g.execQuery(ctx, repo.Join("DELETE FROM authz_policy_drafts", repo.JoinWhere(where...)), args...)
+}
+
+func (g *PolicyRequestRepository) buildFilters(ctx context.Context, params *policyrequest.FindParams) ([]string, []interface{}, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	where, args := []string{"r.tenant_id = $1"}, []interface{}{tenantID}
+	if params.Status != "" {
+		args = append(args, string(params.Status))
+		where = append(where, fmt.Sprintf("r.status = $%d", len(args)))
+	}
+	if params.AuthorID != 0 {
+		args = append(args, params.AuthorID)
+		where = append(where, fmt.Sprintf("r.author_id = $%d", len(args)))
+	}
+	return where, args, nil
+}
+
+func (g *PolicyRequestRepository) Count(ctx context.Context, params *policyrequest.FindParams) (int64, error) {
+	where, args, err := g.buildFilters(ctx, params)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	if err := tx.QueryRow(ctx, repo.Join(countPolicyRequestsQuery, repo.JoinWhere(where...)), args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (g *PolicyRequestRepository) GetPaginated(ctx context.Context, params *policyrequest.FindParams) ([]policyrequest.ChangeRequest, error) {
+	where, args, err := g.buildFilters(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	query := repo.Join(
+		selectPolicyRequestsQuery,
+		repo.JoinWhere(where...),
+		"ORDER BY r.created_at DESC, r.id DESC",
+		repo.FormatLimitOffset(params.Limit, params.Offset),
+	)
+	return g.queryRequests(ctx, query, args...)
+}
+
+func (g *PolicyRequestRepository) GetByID(ctx context.Context, id int64) (policyrequest.ChangeRequest, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	requests, err := g.queryRequests(ctx, repo.Join(selectPolicyRequestsQuery, "WHERE r.id = $1 AND r.tenant_id = $2"), id, tenantID)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	if len(requests) == 0 {
+		return policyrequest.ChangeRequest{}, policyrequest.ErrNotFound
+	}
+	return requests[0], nil
+}
+
+func (g *PolicyRequestRepository) Create(ctx context.Context, data policyrequest.ChangeRequest) (policyrequest.ChangeRequest, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	changes, err := json.Marshal(data.Changes)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	var reviewerID *int64
+	if data.ReviewerID != 0 {
+		id := int64(data.ReviewerID)
+		reviewerID = &id
+	}
+	var id int64
+	if err := tx.QueryRow(
+		ctx,
+		insertPolicyRequestQuery,
+		tenantID,
+		data.AuthorID,
+		reviewerID,
+		data.Subject,
+		data.Domain,
+		data.Reason,
+		data.BaseRevision,
+		changes,
+		data.RevertOf,
+	).Scan(&id); err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	return g.GetByID(ctx, id)
+}
+
+func (g *PolicyRequestRepository) Decide(ctx context.Context, id int64, status policyrequest.Status, decidedBy uint) error {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, decidePolicyRequestQuery, string(status), decidedBy, id, tenantID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return policyrequest.ErrNotPending
+	}
+	return nil
+}
+
+func (g *PolicyRequestRepository) SetAppliedRevision(ctx context.Context, id int64, revision string) error {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return err
+	}
+	return g.execQuery(ctx, setPolicyRequestRevisionQuery, revision, id, tenantID)
+}
+
+func (g *PolicyRequestRepository) AddComment(ctx context.Context, data policyrequest.Comment) (policyrequest.Comment, error) {
+	if _, err := g.GetByID(ctx, data.RequestID); err != nil {
+		return policyrequest.Comment{}, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return policyrequest.Comment{}, err
+	}
+	if err := tx.QueryRow(ctx, insertPolicyRequestCommentQuery, data.RequestID, data.AuthorID, data.Body).
+		Scan(&data.ID, &data.CreatedAt); err != nil {
+		return policyrequest.Comment{}, err
+	}
+	return data, nil
+}
+
+func (g *PolicyRequestRepository) Comments(ctx context.Context, requestID int64) ([]policyrequest.Comment, error) {
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, selectPolicyRequestCommentsQuery, requestID, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []policyrequest.Comment{}
+	for rows.Next() {
+		var (
+			c        policyrequest.Comment
+			authorID int64
+		)
+		if err := rows.Scan(&c.ID, &c.RequestID, &authorID, &c.AuthorName, &c.Body, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		c.AuthorID = uint(authorID)
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
+
+func (g *PolicyRequestRepository) queryRequests(ctx context.Context, query string, args ...interface{}) ([]policyrequest.ChangeRequest, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var requests []policyrequest.ChangeRequest
+	for rows.Next() {
+		var (
+			r                               policyrequest.ChangeRequest
+			authorID, reviewerID, decidedBy int64
+			changes                         []byte
+			status                          string
+		)
+		if err := rows.Scan(
+			&r.ID,
+			&r.TenantID,
+			&authorID,
+			&r.AuthorName,
+			&reviewerID,
+			&r.ReviewerName,
+			&r.Subject,
+			&r.Domain,
+			&r.Reason,
+			&r.BaseRevision,
+			&changes,
+			&r.RevertOf,
+			&status,
+			&decidedBy,
+			&r.DeciderName,
+			&r.DecidedAt,
+			&r.AppliedRevision,
+			&r.CreatedAt,
+			&r.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &r.Changes); err != nil {
+			return nil, fmt.Errorf("decode changes of policy change request %d: %w", r.ID, err)
+		}
+		r.AuthorID = uint(authorID)
+		r.ReviewerID = uint(reviewerID)
+		r.DecidedBy = uint(decidedBy)
+		r.Status = policyrequest.Status(status)
+		requests = append(requests, r)
+	}
+	return requests, rows.Err()
+}
+
+func (g *PolicyRequestRepository) execQuery(ctx context.Context, query string, args ...interface{}) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, query, args...)
+	return err
+}
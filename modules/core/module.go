@@ -1,9 +1,14 @@
 package core
 
 import (
+	"context"
 	"embed"
+	"time"
 
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
 	"github.com/iota-uz/iota-sdk/modules/core/validators"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/authz/policystore"
 	"github.com/iota-uz/iota-sdk/pkg/rbac"
 	"github.com/iota-uz/iota-sdk/pkg/spotlight"
 
@@ -73,10 +78,18 @@ func (m *Module) Register(app application.Application) error {
 	tenantService := services.NewTenantService(tenantRepo)
 	uploadService := services.NewUploadService(uploadRepo, fsStorage, app.EventPublisher())
 	policyService := services.NewAuthzPolicyService(cfg.Authz.PolicyPath)
+	if cfg.Authz.PolicyStore == "db" {
+		policyService, err = useAuthzPolicyStore(app, cfg)
+		if err != nil {
+			return err
+		}
+	}
+	policyService.UseChangeLog(policystore.NewChangeLog(app.DB()))
+	userService := services.NewUserService(userRepo, userValidator, app.EventPublisher())
 
 	app.RegisterServices(
 		uploadService,
-		services.NewUserService(userRepo, userValidator, app.EventPublisher()),
+		userService,
 		services.NewUserQueryService(userQueryRepo),
 		services.NewGroupQueryService(groupQueryRepo),
 		services.NewRoleQueryService(roleQueryRepo),
@@ -91,6 +104,8 @@ func (m *Module) Register(app application.Application) error {
 		services.NewPermissionService(permRepo, app.EventPublisher()),
 		services.NewGroupService(persistence.NewGroupRepository(userRepo, roleRepo), app.EventPublisher()),
 		policyService,
+		services.NewAuthzChangeRequestService(persistence.NewPolicyRequestRepository(), policyService, app.EventPublisher()),
+		services.NewAPITokenService(persistence.NewAPITokenRepository(), userService, app.EventPublisher(), cfg.APITokenMaxTTL),
 	)
 
 	// handlers.RegisterUserHandler(app)
@@ -157,3 +172,20 @@ func (m *Module) Register(app application.Application) error {
 func (m *Module) Name() string {
 	return "core"
 }
+
+// useAuthzPolicyStore moves authz onto the shared policy store, seeding it from the local policy
+// file when no replica has done so yet. cmd/server keeps the enforcer current via policystore.Listener.
+func useAuthzPolicyStore(app application.Application, cfg *configuration.Configuration) (*services.AuthzPolicyService, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	store := policystore.NewStore(app.DB())
+	if err := store.Bootstrap(ctx, cfg.Authz.PolicyPath); err != nil {
+		return nil, err
+	}
+	if err := authz.Use().UseAdapter(ctx, store); err != nil {
+		return nil, err
+	}
+	authzutil.UseRevisionProvider(store)
+	return services.NewDBAuthzPolicyService(store), nil
+}
@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
+	"github.com/iota-uz/iota-sdk/modules/core/permissions"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/pages/account"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/mapping"
+	"github.com/iota-uz/iota-sdk/pkg/validators"
+)
+
+// apiTokenDefaultTTL is the expiry suggested on the create form.
+const apiTokenDefaultTTL = 90 * 24 * time.Hour
+
+func (c *AccountController) apiTokensPath() string {
+	return c.basePath + "/api-tokens"
+}
+
+func (c *AccountController) apiTokensProps(r *http.Request) (*account.APITokensPageProps, error) {
+	ctx := r.Context()
+	tokens, err := c.apiTokenService.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	maxTTL := c.apiTokenService.MaxTTL()
+	defaultTTL := apiTokenDefaultTTL
+	if maxTTL > 0 && defaultTTL > maxTTL {
+		defaultTTL = maxTTL
+	}
+	props := &account.APITokensPageProps{
+		BasePath:      c.apiTokensPath(),
+		Tokens:        apiTokenViewModels(tokens, now),
+		DefaultExpiry: now.Add(defaultTTL).UTC().Format(time.DateOnly),
+		MinExpiry:     now.UTC().Format(time.DateOnly),
+	}
+	if maxTTL > 0 {
+		// The token lives through the chosen day, so the last allowed day is one before the cap.
+		props.MaxExpiry = now.Add(maxTTL).UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+	}
+
+	if composables.CanUser(ctx, permissions.UserUpdate) == nil {
+		accounts, err := c.apiTokenService.ServiceAccounts(ctx)
+		switch {
+		case isAuthzForbidden(err):
+		case err != nil:
+			return nil, err
+		default:
+			props.CanManageServiceAccounts = true
+			for _, sa := range accounts {
+				props.ServiceAccounts = append(props.ServiceAccounts, serviceAccountViewModel(sa, now))
+			}
+		}
+	}
+	if props.CanManageServiceAccounts && composables.CanUser(ctx, permissions.UserCreate) == nil {
+		roles, err := c.roleService.GetAll(ctx)
+		switch {
+		case isAuthzForbidden(err):
+		case err != nil:
+			return nil, err
+		default:
+			props.CanCreateServiceAccounts = true
+			props.Roles = mapping.MapViewModels(roles, mappers.RoleToViewModel)
+		}
+	}
+	return props, nil
+}
+
+func (c *AccountController) renderAPITokens(w http.ResponseWriter, r *http.Request, status int, mutate func(*account.APITokensPageProps)) {
+	props, err := c.apiTokensProps(r)
+	if err != nil {
+		composables.UseLogger(r.Context()).WithError(err).Error("failed to load api tokens")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if mutate != nil {
+		mutate(props)
+	}
+	w.WriteHeader(status)
+	templ.Handler(account.APITokens(props)).ServeHTTP(w, r)
+}
+
+// writeAPITokenError re-renders the page with err for errors the user can fix, and answers with
+// the matching status otherwise.
+func (c *AccountController) writeAPITokenError(w http.ResponseWriter, r *http.Request, err error) {
+	var validationErr *validators.ValidationError
+	switch {
+	case errors.Is(err, services.ErrAPITokenInvalid):
+		c.renderAPITokens(w, r, http.StatusBadRequest, func(props *account.APITokensPageProps) {
+			props.Error = err.Error()
+		})
+	case errors.As(err, &validationErr):
+		messages := make([]string, 0, len(validationErr.Fields))
+		for _, message := range validationErr.Fields {
+			messages = append(messages, message)
+		}
+		sort.Strings(messages)
+		c.renderAPITokens(w, r, http.StatusBadRequest, func(props *account.APITokensPageProps) {
+			props.Error = strings.Join(messages, "; ")
+		})
+	case errors.Is(err, services.ErrAPITokenForbidden), errors.Is(err, composables.ErrForbidden), isAuthzForbidden(err):
+		RenderForbidden(w, r)
+	case errors.Is(err, apitoken.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		composables.UseLogger(r.Context()).WithError(err).Error("api token request failed")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (c *AccountController) GetAPITokens(w http.ResponseWriter, r *http.Request) {
+	c.renderAPITokens(w, r, http.StatusOK, nil)
+}
+
+func (c *AccountController) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	dto, err := composables.UseForm(&dtos.CreateAPITokenDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if dto.UserID != 0 && composables.CanUser(r.Context(), permissions.UserUpdate) != nil {
+		RenderForbidden(w, r)
+		return
+	}
+	expiresAt, err := dto.Expiry()
+	if err != nil {
+		c.writeAPITokenError(w, r, fmt.Errorf("%w: expiry must be a date", services.ErrAPITokenInvalid))
+		return
+	}
+	token, secret, err := c.apiTokenService.Create(r.Context(), services.CreateAPITokenDTO{
+		UserID:    dto.UserID,
+		Name:      dto.Name,
+		Scopes:    dto.ScopeList(),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		c.writeAPITokenError(w, r, err)
+		return
+	}
+	// The secret is shown on this response only; it cannot be recovered afterwards.
+	w.Header().Set("Cache-Control", "no-store")
+	c.renderAPITokens(w, r, http.StatusOK, func(props *account.APITokensPageProps) {
+		props.Created = &account.CreatedAPIToken{Name: token.Name, Secret: secret}
+	})
+}
+
+func (c *AccountController) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.apiTokenService.Revoke(r.Context(), id); err != nil {
+		c.writeAPITokenError(w, r, err)
+		return
+	}
+	http.Redirect(w, r, c.apiTokensPath(), http.StatusFound)
+}
+
+func (c *AccountController) CreateServiceAccount(w http.ResponseWriter, r *http.Request) {
+	if !ensureAuthz(w, r, authz.ObjectName("core", "users"), "create", permissions.UserCreate) {
+		return
+	}
+	dto, err := composables.UseForm(&dtos.CreateServiceAccountDTO{}, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.apiTokenService.CreateServiceAccount(r.Context(), services.CreateServiceAccountDTO{
+		Name:    dto.Name,
+		RoleIDs: dto.RoleIDs,
+	}); err != nil {
+		c.writeAPITokenError(w, r, err)
+		return
+	}
+	http.Redirect(w, r, c.apiTokensPath(), http.StatusFound)
+}
+
+func (c *AccountController) DeleteServiceAccount(w http.ResponseWriter, r *http.Request) {
+	if !ensureAuthz(w, r, authz.ObjectName("core", "users"), "delete", permissions.UserDelete) {
+		return
+	}
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if _, err := c.apiTokenService.DeleteServiceAccount(r.Context(), uint(id)); err != nil {
+		c.writeAPITokenError(w, r, err)
+		return
+	}
+	http.Redirect(w, r, c.apiTokensPath(), http.StatusFound)
+}
+
+func apiTokenViewModels(tokens []apitoken.Token, now time.Time) []viewmodels.APIToken {
+	vms := make([]viewmodels.APIToken, 0, len(tokens))
+	for _, t := range tokens {
+		vm := viewmodels.APIToken{
+			ID:         strconv.FormatInt(t.ID, 10),
+			Name:       t.Name,
+			Prefix:     t.Prefix,
+			Scopes:     t.Scopes,
+			Status:     "active",
+			ExpiresAt:  t.ExpiresAt.Format(time.RFC3339),
+			LastUsedIP: t.LastUsedIP,
+			CreatedAt:  t.CreatedAt.Format(time.RFC3339),
+		}
+		switch {
+		case t.IsRevoked():
+			vm.Status = "revoked"
+		case t.IsExpired(now):
+			vm.Status = "expired"
+		}
+		if t.LastUsedAt != nil {
+			vm.LastUsedAt = t.LastUsedAt.Format(time.RFC3339)
+		}
+		vms = append(vms, vm)
+	}
+	return vms
+}
+
+func serviceAccountViewModel(sa services.ServiceAccount, now time.Time) viewmodels.ServiceAccount {
+	vm := viewmodels.ServiceAccount{
+		ID:     strconv.FormatUint(uint64(sa.User.ID()), 10),
+		Name:   sa.User.FirstName(),
+		Tokens: apiTokenViewModels(sa.Tokens, now),
+	}
+	if email := sa.User.Email(); email != nil {
+		vm.Email = email.Value()
+	}
+	for _, r := range sa.User.Roles() {
+		vm.Roles = append(vm.Roles, r.Name())
+	}
+	return vm
+}
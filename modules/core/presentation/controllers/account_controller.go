@@ -18,20 +18,24 @@ import (
 )
 
 type AccountController struct {
-	app           application.Application
-	userService   *services.UserService
-	tenantService *services.TenantService
-	uploadService *services.UploadService
-	basePath      string
+	app             application.Application
+	userService     *services.UserService
+	tenantService   *services.TenantService
+	uploadService   *services.UploadService
+	apiTokenService *services.APITokenService
+	roleService     *services.RoleService
+	basePath        string
 }
 
 func NewAccountController(app application.Application) application.Controller {
 	return &AccountController{
-		app:           app,
-		userService:   app.Service(services.UserService{}).(*services.UserService),
-		tenantService: app.Service(services.TenantService{}).(*services.TenantService),
-		uploadService: app.Service(services.UploadService{}).(*services.UploadService),
-		basePath:      "/account",
+		app:             app,
+		userService:     app.Service(services.UserService{}).(*services.UserService),
+		tenantService:   app.Service(services.TenantService{}).(*services.TenantService),
+		uploadService:   app.Service(services.UploadService{}).(*services.UploadService),
+		apiTokenService: app.Service(services.APITokenService{}).(*services.APITokenService),
+		roleService:     app.Service(services.RoleService{}).(*services.RoleService),
+		basePath:        "/account",
 	}
 }
 
@@ -52,10 +56,15 @@ func (c *AccountController) Register(r *mux.Router) {
 	getRouter := r.PathPrefix(c.basePath).Subrouter()
 	getRouter.Use(commonMiddleware...)
 	getRouter.HandleFunc("", c.Get).Methods(http.MethodGet)
+	getRouter.HandleFunc("/api-tokens", c.GetAPITokens).Methods(http.MethodGet)
 
 	setRouter := r.PathPrefix(c.basePath).Subrouter()
 	setRouter.Use(commonMiddleware...)
 	setRouter.HandleFunc("", c.Update).Methods(http.MethodPost)
+	setRouter.HandleFunc("/api-tokens", c.CreateAPIToken).Methods(http.MethodPost)
+	setRouter.HandleFunc("/api-tokens/{id:[0-9]+}/revoke", c.RevokeAPIToken).Methods(http.MethodPost)
+	setRouter.HandleFunc("/api-tokens/service-accounts", c.CreateServiceAccount).Methods(http.MethodPost)
+	setRouter.HandleFunc("/api-tokens/service-accounts/{id:[0-9]+}/delete", c.DeleteServiceAccount).Methods(http.MethodPost)
 }
 
 func (c *AccountController) defaultProps(r *http.Request, errors map[string]string) (*account.ProfilePageProps, error) {
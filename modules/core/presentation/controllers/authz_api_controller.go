@@ -19,24 +19,29 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	authz "github.com/iota-uz/iota-sdk/pkg/authz"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/di"
 	"github.com/iota-uz/iota-sdk/pkg/htmx"
 	"github.com/iota-uz/iota-sdk/pkg/middleware"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
 )
 
-// AuthzAPIController exposes REST APIs for policy listings and direct apply.
+// AuthzAPIController exposes REST APIs for policy listings, staging, change requests and (when
+// approval is not required) direct apply.
 type AuthzAPIController struct {
-	app        application.Application
-	basePath   string
-	stageStore *policyStageStore
+	app           application.Application
+	basePath      string
+	stageStore    *policyStageStore
+	notifications *AuthzChangeRequestNotifications
 }
 
 // NewAuthzAPIController wires the controller into the router.
 func NewAuthzAPIController(app application.Application) application.Controller {
 	return &AuthzAPIController{
-		app:        app,
-		basePath:   "/core/api/authz",
-		stageStore: usePolicyStageStore(),
+		app:           app,
+		basePath:      "/core/api/authz",
+		stageStore:    usePolicyStageStore(app),
+		notifications: NewAuthzChangeRequestNotifications(app),
 	}
 }
 
@@ -65,6 +70,37 @@ func (c *AuthzAPIController) Register(r *mux.Router) {
 			KeyFunc:           middleware.EndpointKeyFunc("core.api.authz.policies.apply"),
 		})(di.H(c.applyPolicies)),
 	).Methods(http.MethodPost)
+	router.HandleFunc("/history", di.H(c.listHistory)).Methods(http.MethodGet)
+	router.HandleFunc("/history/{id:[0-9]+}", di.H(c.getChangeSet)).Methods(http.MethodGet)
+	router.Handle(
+		"/history/{id:[0-9]+}/revert",
+		middleware.RateLimit(middleware.RateLimitConfig{
+			RequestsPerPeriod: 20,
+			Period:            time.Minute,
+			KeyFunc:           middleware.EndpointKeyFunc("core.api.authz.history.revert"),
+		})(di.H(c.revertPolicies)),
+	).Methods(http.MethodPost)
+	router.Handle(
+		"/requests",
+		middleware.RateLimit(middleware.RateLimitConfig{
+			RequestsPerPeriod: 20,
+			Period:            time.Minute,
+			KeyFunc:           middleware.EndpointKeyFunc("core.api.authz.requests.submit"),
+		})(di.H(c.submitChangeRequest)),
+	).Methods(http.MethodPost)
+	router.HandleFunc("/requests", di.H(c.listChangeRequests)).Methods(http.MethodGet)
+	router.HandleFunc("/requests/{id:[0-9]+}", di.H(c.getChangeRequest)).Methods(http.MethodGet)
+	router.Handle(
+		"/requests/{id:[0-9]+}/approve",
+		middleware.RateLimit(middleware.RateLimitConfig{
+			RequestsPerPeriod: 20,
+			Period:            time.Minute,
+			KeyFunc:           middleware.EndpointKeyFunc("core.api.authz.requests.approve"),
+		})(di.H(c.approveChangeRequest)),
+	).Methods(http.MethodPost)
+	router.HandleFunc("/requests/{id:[0-9]+}/reject", di.H(c.rejectChangeRequest)).Methods(http.MethodPost)
+	router.HandleFunc("/requests/{id:[0-9]+}/withdraw", di.H(c.withdrawChangeRequest)).Methods(http.MethodPost)
+	router.HandleFunc("/requests/{id:[0-9]+}/comments", di.H(c.commentChangeRequest)).Methods(http.MethodPost)
 	router.Handle(
 		"/debug",
 		middleware.RateLimit(middleware.RateLimitConfig{
@@ -73,6 +109,8 @@ func (c *AuthzAPIController) Register(r *mux.Router) {
 			KeyFunc:           middleware.EndpointKeyFunc("core.api.authz.debug"),
 		})(di.H(c.debugRequest)),
 	).Methods(http.MethodGet)
+
+	c.notifications.Register()
 }
 
 func (c *AuthzAPIController) listPolicies(
@@ -99,12 +137,13 @@ func (c *AuthzAPIController) listPolicies(
 	pageData := make([]dtos.PolicyEntryResponse, 0, len(pageEntries))
 	for _, entry := range pageEntries {
 		pageData = append(pageData, dtos.PolicyEntryResponse{
-			Type:    entry.Type,
-			Subject: entry.Subject,
-			Domain:  entry.Domain,
-			Object:  entry.Object,
-			Action:  entry.Action,
-			Effect:  entry.Effect,
+			Type:      entry.Type,
+			Subject:   entry.Subject,
+			Domain:    entry.Domain,
+			Object:    entry.Object,
+			Action:    entry.Action,
+			Effect:    entry.Effect,
+			Condition: entry.Condition,
 		})
 	}
 	writeJSON(w, http.StatusOK, dtos.PolicyListResponse{
@@ -120,21 +159,17 @@ func (c *AuthzAPIController) stagePolicy(
 	r *http.Request,
 	logger *logrus.Entry,
 ) {
-	_ = logger
 	if !c.ensurePermission(w, r, permissions.AuthzRequestsWrite) {
 		return
 	}
-	currentUser, err := composables.UseUser(r.Context())
-	if err != nil {
+	if _, err := composables.UseUser(r.Context()); err != nil {
 		c.writeHTMXError(w, r, http.StatusUnauthorized, "AUTHZ_NO_USER", "user not found in context")
 		return
 	}
-	tenantID, err := composables.UseTenantID(r.Context())
-	if err != nil {
+	if _, err := composables.UseTenantID(r.Context()); err != nil {
 		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_NO_TENANT", "tenant not found in context")
 		return
 	}
-	key := policyStageKey(currentUser.ID(), tenantID)
 
 	switch r.Method {
 	case http.MethodPost:
@@ -143,9 +178,9 @@ func (c *AuthzAPIController) stagePolicy(
 			c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_BODY", err.Error())
 			return
 		}
-		entries, createdIDs, err := c.stageStore.AddManyWithIDs(key, payloads)
+		entries, createdIDs, err := c.stageStore.AddMany(r.Context(), payloads)
 		if err != nil {
-			c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_STAGE_ERROR", err.Error())
+			c.respondStageError(w, r, logger, err)
 			return
 		}
 		htmx.SetTrigger(w, "policies:staged", fmt.Sprintf(`{"total":%d}`, len(entries)))
@@ -164,9 +199,9 @@ func (c *AuthzAPIController) stagePolicy(
 				c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_BODY", "invalid delete payload")
 				return
 			}
-			entries, err := c.stageStore.DeleteMany(key, payload.IDs)
+			entries, err := c.stageStore.Delete(r.Context(), payload.IDs)
 			if err != nil {
-				c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_STAGE_ERROR", err.Error())
+				c.respondStageError(w, r, logger, err)
 				return
 			}
 			htmx.SetTrigger(w, "policies:staged", fmt.Sprintf(`{"total":%d}`, len(entries)))
@@ -179,9 +214,9 @@ func (c *AuthzAPIController) stagePolicy(
 
 		id := strings.TrimSpace(r.URL.Query().Get("id"))
 		if id != "" {
-			entries, err := c.stageStore.Delete(key, id)
+			entries, err := c.stageStore.Delete(r.Context(), []string{id})
 			if err != nil {
-				c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_STAGE_ERROR", err.Error())
+				c.respondStageError(w, r, logger, err)
 				return
 			}
 			htmx.SetTrigger(w, "policies:staged", fmt.Sprintf(`{"total":%d}`, len(entries)))
@@ -198,8 +233,15 @@ func (c *AuthzAPIController) stagePolicy(
 			c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", "id or subject/domain is required")
 			return
 		}
-		c.stageStore.Clear(key, subject, domain)
-		entries := c.stageStore.List(key, "", "")
+		if err := c.stageStore.Clear(r.Context(), subject, domain); err != nil {
+			c.respondStageError(w, r, logger, err)
+			return
+		}
+		entries, err := c.stageStore.List(r.Context(), "", "")
+		if err != nil {
+			c.respondStageError(w, r, logger, err)
+			return
+		}
 		htmx.SetTrigger(w, "policies:staged", fmt.Sprintf(`{"total":%d}`, len(entries)))
 		writeJSON(w, http.StatusOK, dtos.StagePolicyResponse{
 			Data:  entries,
@@ -219,15 +261,12 @@ func (c *AuthzAPIController) applyPolicies(
 	if !c.ensurePermission(w, r, permissions.AuthzRequestsWrite) {
 		return
 	}
-
-	currentUser, err := composables.UseUser(r.Context())
-	if err != nil {
-		c.writeHTMXError(w, r, http.StatusUnauthorized, "AUTHZ_NO_USER", "user not found in context")
+	if configuration.Use().Authz.RequireApproval {
+		c.writeHTMXError(w, r, http.StatusForbidden, "AUTHZ_APPROVAL_REQUIRED", "policy changes must be submitted for review")
 		return
 	}
-	tenantID, err := composables.UseTenantID(r.Context())
-	if err != nil {
-		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_NO_TENANT", "tenant not found in context")
+	if _, err := composables.UseUser(r.Context()); err != nil {
+		c.writeHTMXError(w, r, http.StatusUnauthorized, "AUTHZ_NO_USER", "user not found in context")
 		return
 	}
 
@@ -245,12 +284,15 @@ func (c *AuthzAPIController) applyPolicies(
 		return
 	}
 
-	key := policyStageKey(currentUser.ID(), tenantID)
 	changes := make([]services.PolicyChange, 0, len(payload.Changes))
 	if len(payload.Changes) > 0 {
 		changes = append(changes, payload.Changes...)
 	} else {
-		entries := c.stageStore.List(key, payload.Subject, payload.Domain)
+		entries, err := c.stageStore.List(r.Context(), payload.Subject, payload.Domain)
+		if err != nil {
+			c.respondStageError(w, r, logger, err)
+			return
+		}
 		if len(entries) == 0 {
 			c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_STAGE_EMPTY", "no staged policies found")
 			return
@@ -259,26 +301,32 @@ func (c *AuthzAPIController) applyPolicies(
 			changes = append(changes, services.PolicyChange{
 				StageKind: entry.StageKind,
 				PolicyEntry: services.PolicyEntry{
-					Type:    entry.Type,
-					Subject: entry.Subject,
-					Domain:  entry.Domain,
-					Object:  entry.Object,
-					Action:  entry.Action,
-					Effect:  entry.Effect,
+					Type:      entry.Type,
+					Subject:   entry.Subject,
+					Domain:    entry.Domain,
+					Object:    entry.Object,
+					Action:    entry.Action,
+					Effect:    entry.Effect,
+					Condition: entry.Condition,
 				},
 			})
 		}
 	}
 
-	result, err := svc.ApplyAndReload(r.Context(), payload.BaseRevision, changes, authz.Use().ReloadPolicy)
+	result, err := svc.ApplyAndReload(r.Context(), payload.BaseRevision, changes, payload.Reason, authz.Use().ReloadPolicy)
 	if err != nil {
 		logger.WithError(err).Warn("authz api: apply failed")
 		c.respondApplyError(w, r, err)
 		return
 	}
 
-	c.stageStore.Clear(key, payload.Subject, payload.Domain)
-	remaining := c.stageStore.List(key, "", "")
+	if err := c.stageStore.Clear(r.Context(), payload.Subject, payload.Domain); err != nil {
+		logger.WithError(err).Warn("authz api: clear applied drafts failed")
+	}
+	remaining, err := c.stageStore.List(r.Context(), "", "")
+	if err != nil {
+		logger.WithError(err).Warn("authz api: list drafts failed")
+	}
 
 	if htmx.IsHxRequest(r) {
 		htmx.SetTrigger(w, "policies:staged", fmt.Sprintf(`{"total":%d}`, len(remaining)))
@@ -295,6 +343,122 @@ func (c *AuthzAPIController) applyPolicies(
 	writeJSON(w, http.StatusOK, result)
 }
 
+func (c *AuthzAPIController) listHistory(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	svc *services.AuthzPolicyService,
+) {
+	if !c.ensurePermission(w, r, permissions.AuthzDebug) {
+		return
+	}
+	params, err := parsePolicyListParams(r.URL.Query())
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", err.Error())
+		return
+	}
+	sets, total, err := svc.History(r.Context(), params.Limit, params.Offset())
+	if err != nil {
+		logger.WithError(err).Error("authz api: list history failed")
+		c.respondApplyError(w, r, err)
+		return
+	}
+	data := make([]dtos.PolicyChangeSetResponse, 0, len(sets))
+	for _, cs := range sets {
+		data = append(data, policyChangeSetResponse(cs))
+	}
+	writeJSON(w, http.StatusOK, dtos.PolicyHistoryResponse{
+		Data:  data,
+		Total: total,
+		Page:  params.Page,
+		Limit: params.Limit,
+	})
+}
+
+func (c *AuthzAPIController) getChangeSet(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	svc *services.AuthzPolicyService,
+) {
+	if !c.ensurePermission(w, r, permissions.AuthzDebug) {
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", err.Error())
+		return
+	}
+	cs, err := svc.ChangeSet(r.Context(), int64(id))
+	if err != nil {
+		logger.WithError(err).Warn("authz api: load change set failed")
+		c.respondApplyError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, policyChangeSetResponse(cs))
+}
+
+func (c *AuthzAPIController) revertPolicies(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	svc *services.AuthzPolicyService,
+	requests *services.AuthzChangeRequestService,
+) {
+	if !c.ensurePermission(w, r, permissions.AuthzRequestsWrite) {
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", err.Error())
+		return
+	}
+	payload, err := decodeRevertPolicyRequest(r)
+	if err != nil {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_BODY", err.Error())
+		return
+	}
+	if payload.BaseRevision == "" {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_BODY", "base_revision is required")
+		return
+	}
+
+	if configuration.Use().Authz.RequireApproval {
+		req, err := requests.SubmitRevert(r.Context(), payload.BaseRevision, int64(id), payload.Reason, payload.ReviewerID)
+		if err != nil {
+			logger.WithError(err).Warn("authz api: submit revert failed")
+			c.respondChangeRequestError(w, r, err)
+			return
+		}
+		if htmx.IsHxRequest(r) {
+			htmx.Redirect(w, changeRequestPath(req.ID))
+		}
+		writeJSON(w, http.StatusAccepted, changeRequestResponse(req, nil))
+		return
+	}
+
+	result, err := svc.RevertTo(r.Context(), payload.BaseRevision, int64(id), payload.Reason, authz.Use().ReloadPolicy)
+	if err != nil {
+		logger.WithError(err).Warn("authz api: revert failed")
+		c.respondApplyError(w, r, err)
+		return
+	}
+
+	if htmx.IsHxRequest(r) {
+		if detail, marshalErr := json.Marshal(map[string]any{
+			"base_revision": result.BaseRevision,
+			"revision":      result.Revision,
+			"added":         result.Added,
+			"removed":       result.Removed,
+		}); marshalErr == nil {
+			htmx.SetTrigger(w, "authz:policies-applied", string(detail))
+		}
+		htmx.Refresh(w)
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
 type applyPolicyPayload struct {
 	BaseRevision string                  `json:"base_revision"`
 	Subject      string                  `json:"subject"`
@@ -384,6 +548,11 @@ func (c *AuthzAPIController) debugRequest(
 	}
 	logger.WithFields(logFields).Info("authz debug evaluated request")
 
+	trace := dtos.DebugTraceDTO{MatchedPolicy: result.Trace}
+	if rule := result.MatchedRule; rule != nil {
+		trace.Effect = rule.Effect
+		trace.Condition = rule.Condition
+	}
 	writeJSON(w, http.StatusOK, dtos.DebugResponse{
 		Allowed:       result.Allowed,
 		Mode:          string(result.Mode),
@@ -395,9 +564,7 @@ func (c *AuthzAPIController) debugRequest(
 			Action:  result.OriginalRequest.Action,
 		},
 		Attributes: result.OriginalRequest.Attributes,
-		Trace: dtos.DebugTraceDTO{
-			MatchedPolicy: result.Trace,
-		},
+		Trace:      trace,
 	})
 }
 
@@ -411,11 +578,25 @@ func (c *AuthzAPIController) respondApplyError(w http.ResponseWriter, r *http.Re
 		c.writeHTMXErrorWithMeta(w, r, http.StatusConflict, "AUTHZ_BASE_REVISION_MISMATCH", "Policy base revision is stale, please refresh", meta)
 	case errors.Is(err, services.ErrPolicyApply):
 		c.writeHTMXError(w, r, http.StatusUnprocessableEntity, "AUTHZ_POLICY_APPLY_FAILED", err.Error())
+	case errors.Is(err, services.ErrPolicyChangeSetNotFound):
+		c.writeHTMXError(w, r, http.StatusNotFound, "AUTHZ_CHANGE_SET_NOT_FOUND", "policy change set not found")
+	case errors.Is(err, services.ErrPolicyHistoryDisabled):
+		c.writeHTMXError(w, r, http.StatusNotImplemented, "AUTHZ_HISTORY_DISABLED", "policy history is not configured")
 	default:
 		c.writeHTMXError(w, r, http.StatusInternalServerError, "AUTHZ_POLICY_WRITE_FAILED", "failed to apply policies")
 	}
 }
 
+func (c *AuthzAPIController) respondStageError(w http.ResponseWriter, r *http.Request, logger *logrus.Entry, err error) {
+	switch {
+	case errors.Is(err, services.ErrChangeRequestInvalid), errors.Is(err, services.ErrPolicyDraftNotFound):
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_STAGE_ERROR", err.Error())
+	default:
+		logger.WithError(err).Error("authz api: policy drafts failed")
+		c.writeHTMXError(w, r, http.StatusInternalServerError, "AUTHZ_STAGE_FAILED", "failed to update staged policies")
+	}
+}
+
 func (c *AuthzAPIController) ensurePermission(
 	w http.ResponseWriter,
 	r *http.Request,
@@ -479,6 +660,10 @@ func errorMessageKey(code string, fallback string) string {
 		return "Request validation failed"
 	case "AUTHZ_STAGE_EMPTY":
 		return "No staged policies to apply"
+	case "AUTHZ_APPROVAL_REQUIRED":
+		return "Policy changes must be submitted for review"
+	case "AUTHZ_SELF_REVIEW":
+		return "A change request must be reviewed by another admin"
 	case "AUTHZ_BASE_REVISION_MISMATCH":
 		return "Policy base revision is stale, please refresh"
 	case "AUTHZ_FORBIDDEN":
@@ -542,6 +727,7 @@ func decodeStagePolicyRequest(r *http.Request) (dtos.StagePolicyRequest, error)
 		Object:    r.FormValue("object"),
 		Action:    r.FormValue("action"),
 		Effect:    r.FormValue("effect"),
+		Condition: r.FormValue("condition"),
 		StageKind: r.FormValue("stage_kind"),
 	}, nil
 }
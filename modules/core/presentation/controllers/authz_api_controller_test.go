@@ -3,6 +3,7 @@ package controllers_test
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/url"
 	"os"
@@ -14,6 +15,8 @@ import (
 
 	"github.com/iota-uz/iota-sdk/modules/core"
 	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
 	"github.com/iota-uz/iota-sdk/modules/core/permissions"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
@@ -26,6 +29,7 @@ import (
 
 func TestAuthzAPIController_Apply_FromStage(t *testing.T) {
 	suite := setupAuthzAPISuiteWithTempPolicy(t)
+	setAuthzRequireApproval(t, false)
 	user := itf.User(
 		permissions.AuthzRequestsWrite,
 		permissions.AuthzDebug,
@@ -74,6 +78,7 @@ func TestAuthzAPIController_Apply_FromStage(t *testing.T) {
 
 func TestAuthzAPIController_Apply_BaseRevisionMismatch(t *testing.T) {
 	suite := setupAuthzAPISuiteWithTempPolicy(t)
+	setAuthzRequireApproval(t, false)
 	user := itf.User(
 		permissions.AuthzRequestsWrite,
 		permissions.AuthzDebug,
@@ -104,7 +109,160 @@ func TestAuthzAPIController_Apply_BaseRevisionMismatch(t *testing.T) {
 	require.NotEmpty(t, payload.Meta["base_revision"])
 }
 
-func TestAuthzAPIController_StagePolicy_RejectsDeny(t *testing.T) {
+func TestAuthzAPIController_History_Revert(t *testing.T) {
+	suite := setupAuthzAPISuiteWithTempPolicy(t)
+	setAuthzRequireApproval(t, false)
+	user := itf.User(
+		permissions.AuthzRequestsWrite,
+		permissions.AuthzDebug,
+	)
+	suite.AsUser(user)
+	revPath := configuration.Use().Authz.PolicyPath + ".rev"
+
+	apply := func(object, reason string) {
+		suite.POST("/core/api/authz/policies/apply").
+			JSON(map[string]any{
+				"base_revision": currentPolicyRevision(t, revPath),
+				"subject":       "role:test.history",
+				"domain":        "global",
+				"reason":        reason,
+				"changes": []map[string]any{{
+					"stage_kind": "add",
+					"type":       "p",
+					"subject":    "role:test.history",
+					"object":     object,
+					"action":     "read",
+					"domain":     "global",
+				}},
+			}).
+			Expect(t).
+			Status(http.StatusOK)
+	}
+	apply("core.users", "grant users")
+	apply("core.roles", "grant roles")
+
+	resp := suite.GET("/core/api/authz/history?limit=10").
+		Expect(t).
+		Status(http.StatusOK)
+	var history dtos.PolicyHistoryResponse
+	require.NoError(t, json.Unmarshal([]byte(resp.Body()), &history))
+	require.Equal(t, 2, history.Total)
+	require.Equal(t, "grant roles", history.Data[0].Reason)
+	require.Equal(t, []string{"p, role:test.history, core.roles, read, global, allow"}, history.Data[0].Added)
+	first := history.Data[1]
+
+	suite.POST(fmt.Sprintf("/core/api/authz/history/%d/revert", first.ID)).
+		Form(url.Values{"base_revision": []string{"bogus"}, "reason": []string{"undo roles"}}).
+		Expect(t).
+		Status(http.StatusConflict)
+
+	suite.POST(fmt.Sprintf("/core/api/authz/history/%d/revert", first.ID)).
+		Form(url.Values{"base_revision": []string{currentPolicyRevision(t, revPath)}, "reason": []string{"undo roles"}}).
+		Expect(t).
+		Status(http.StatusOK)
+	require.Equal(t, first.Revision, currentPolicyRevision(t, revPath))
+
+	resp = suite.GET("/core/api/authz/history?limit=1").
+		Expect(t).
+		Status(http.StatusOK)
+	require.NoError(t, json.Unmarshal([]byte(resp.Body()), &history))
+	require.Equal(t, 3, history.Total)
+	require.Equal(t, first.ID, *history.Data[0].RevertOf)
+	require.Equal(t, []string{"p, role:test.history, core.roles, read, global, allow"}, history.Data[0].Removed)
+
+	suite.GET("/core/api/authz/history/999999").
+		Expect(t).
+		Status(http.StatusNotFound)
+}
+
+func TestAuthzAPIController_ChangeRequest_FourEyes(t *testing.T) {
+	setAuthzRequireApproval(t, true)
+	suite := setupAuthzAPISuiteWithTempPolicy(t)
+	author := itf.User(
+		permissions.AuthzRequestsWrite,
+		permissions.AuthzRequestsRead,
+		permissions.AuthzRequestsReview,
+	)
+	suite.AsUser(author)
+	revPath := configuration.Use().Authz.PolicyPath + ".rev"
+
+	suite.POST("/core/api/authz/policies/apply").
+		JSON(map[string]any{"base_revision": currentPolicyRevision(t, revPath)}).
+		Expect(t).
+		Status(http.StatusForbidden)
+
+	suite.POST("/core/api/authz/policies/stage").
+		JSON(dtos.StagePolicyRequest{
+			Type:    "p",
+			Subject: "role:test.review",
+			Domain:  "global",
+			Object:  "core.users",
+			Action:  "read",
+			Effect:  "allow",
+		}).
+		Expect(t).
+		Status(http.StatusCreated)
+
+	resp := suite.POST("/core/api/authz/requests").
+		Form(url.Values{
+			"base_revision": []string{currentPolicyRevision(t, revPath)},
+			"subject":       []string{"role:test.review"},
+			"domain":        []string{"global"},
+			"reason":        []string{"grant users"},
+		}).
+		Expect(t).
+		Status(http.StatusCreated)
+	var submitted dtos.ChangeRequestResponse
+	require.NoError(t, json.Unmarshal([]byte(resp.Body()), &submitted))
+	require.Equal(t, "pending", submitted.Status)
+	require.Len(t, submitted.Changes, 1)
+
+	approvePath := fmt.Sprintf("/core/api/authz/requests/%d/approve", submitted.ID)
+	suite.POST(approvePath).
+		Form(url.Values{"base_revision": []string{currentPolicyRevision(t, revPath)}}).
+		Expect(t).
+		Status(http.StatusForbidden)
+
+	suite.AsUser(itfUserWithID(2,
+		permissions.AuthzRequestsRead,
+		permissions.AuthzRequestsReview,
+	))
+	suite.POST(fmt.Sprintf("/core/api/authz/requests/%d/comments", submitted.ID)).
+		Form(url.Values{"comment": []string{"looks good"}}).
+		Expect(t).
+		Status(http.StatusCreated)
+	suite.POST(approvePath).
+		Form(url.Values{"base_revision": []string{"stale"}}).
+		Expect(t).
+		Status(http.StatusConflict)
+	resp = suite.POST(approvePath).
+		Form(url.Values{"base_revision": []string{currentPolicyRevision(t, revPath)}}).
+		Expect(t).
+		Status(http.StatusOK)
+	var approved dtos.ChangeRequestResponse
+	require.NoError(t, json.Unmarshal([]byte(resp.Body()), &approved))
+	require.Equal(t, "approved", approved.Status)
+	require.Equal(t, currentPolicyRevision(t, revPath), approved.AppliedRevision)
+
+	policyBytes, err := os.ReadFile(configuration.Use().Authz.PolicyPath)
+	require.NoError(t, err)
+	require.Contains(t, string(policyBytes), "p, role:test.review, core.users, read, global, allow\n")
+
+	suite.POST(approvePath).
+		Form(url.Values{"base_revision": []string{currentPolicyRevision(t, revPath)}}).
+		Expect(t).
+		Status(http.StatusConflict)
+
+	resp = suite.GET(fmt.Sprintf("/core/api/authz/requests/%d", submitted.ID)).
+		Expect(t).
+		Status(http.StatusOK)
+	var detail dtos.ChangeRequestResponse
+	require.NoError(t, json.Unmarshal([]byte(resp.Body()), &detail))
+	require.Len(t, detail.Comments, 1)
+	require.Equal(t, "looks good", detail.Comments[0].Body)
+}
+
+func TestAuthzAPIController_StagePolicy_DenyWithCondition(t *testing.T) {
 	suite := setupAuthzAPISuiteWithTempPolicy(t)
 	user := itf.User(
 		permissions.AuthzRequestsWrite,
@@ -113,14 +271,33 @@ func TestAuthzAPIController_StagePolicy_RejectsDeny(t *testing.T) {
 	suite.AsUser(user)
 
 	payload := dtos.StagePolicyRequest{
-		Type:    "p",
-		Subject: "role:test.deny",
-		Domain:  "global",
-		Object:  "core.users",
-		Action:  "read",
-		Effect:  "deny",
+		Type:      "p",
+		Subject:   "role:test.deny",
+		Domain:    "global",
+		Object:    "core.users",
+		Action:    "read",
+		Effect:    "deny",
+		Condition: "amount >= $approval_limit",
 	}
+	resp := suite.POST("/core/api/authz/policies/stage").
+		JSON(payload).
+		Expect(t).
+		Status(http.StatusCreated)
+
+	var staged dtos.StagePolicyResponse
+	require.NoError(t, json.Unmarshal([]byte(resp.Body()), &staged))
+	require.Len(t, staged.Data, 1)
+	require.Equal(t, "deny", staged.Data[0].Effect)
+	require.Equal(t, "amount >= $approval_limit", staged.Data[0].Condition)
+
+	payload.Condition = "amount ~ 10"
+	suite.POST("/core/api/authz/policies/stage").
+		JSON(payload).
+		Expect(t).
+		Status(http.StatusBadRequest)
 
+	payload.Effect = "maybe"
+	payload.Condition = ""
 	suite.POST("/core/api/authz/policies/stage").
 		JSON(payload).
 		Expect(t).
@@ -184,6 +361,33 @@ func setupAuthzAPISuiteWithTempPolicy(t *testing.T) *itf.Suite {
 	return suite
 }
 
+// setAuthzRequireApproval switches between applying policy changes directly and going through
+// change requests for the duration of the test.
+func setAuthzRequireApproval(t *testing.T, required bool) {
+	t.Helper()
+	cfg := configuration.Use()
+	orig := cfg.Authz.RequireApproval
+	cfg.Authz.RequireApproval = required
+	t.Cleanup(func() {
+		cfg.Authz.RequireApproval = orig
+	})
+}
+
+// itfUserWithID is itf.User with a different id, for tests that need a second admin.
+func itfUserWithID(id uint, perms ...*permission.Permission) user.User {
+	u := itf.User(perms...)
+	return user.New(
+		u.FirstName(),
+		u.LastName(),
+		u.Email(),
+		u.UILanguage(),
+		user.WithID(id),
+		user.WithRoles(u.Roles()),
+		user.WithCreatedAt(u.CreatedAt()),
+		user.WithUpdatedAt(u.UpdatedAt()),
+	)
+}
+
 func copyFile(src, dst string) error {
 	data, err := os.ReadFile(src)
 	if err != nil {
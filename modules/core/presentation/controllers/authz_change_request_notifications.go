@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policyrequest"
+	"github.com/iota-uz/iota-sdk/modules/core/permissions"
+	corecomponents "github.com/iota-uz/iota-sdk/modules/core/presentation/templates/components"
+	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// AuthzChangeRequestNotifications tells the people involved in a policy change request about it
+// over the websocket: reviewers when it is submitted, the author when it is decided, and both
+// sides when someone comments.
+type AuthzChangeRequestNotifications struct {
+	app application.Application
+}
+
+func NewAuthzChangeRequestNotifications(app application.Application) *AuthzChangeRequestNotifications {
+	return &AuthzChangeRequestNotifications{
+		app: app,
+	}
+}
+
+func (n *AuthzChangeRequestNotifications) Register() {
+	n.app.EventPublisher().Subscribe(n.onSubmitted)
+	n.app.EventPublisher().Subscribe(n.onDecided)
+	n.app.EventPublisher().Subscribe(n.onCommented)
+}
+
+func (n *AuthzChangeRequestNotifications) onSubmitted(event *policyrequest.SubmittedEvent) {
+	req := event.Result
+	n.broadcast(req, "submitted", req.AuthorName, "info", func(u user.User) bool {
+		return isChangeRequestReviewer(req, u)
+	})
+}
+
+func (n *AuthzChangeRequestNotifications) onDecided(event *policyrequest.DecidedEvent) {
+	req := event.Result
+	variant := "info"
+	switch req.Status {
+	case policyrequest.StatusApproved:
+		variant = "success"
+	case policyrequest.StatusRejected:
+		variant = "error"
+	case policyrequest.StatusPending:
+		return
+	case policyrequest.StatusWithdrawn:
+		n.broadcast(req, string(req.Status), req.AuthorName, variant, func(u user.User) bool {
+			return isChangeRequestReviewer(req, u)
+		})
+		return
+	}
+	n.broadcast(req, string(req.Status), req.DeciderName, variant, func(u user.User) bool {
+		return u.ID() == req.AuthorID
+	})
+}
+
+func (n *AuthzChangeRequestNotifications) onCommented(event *policyrequest.CommentedEvent) {
+	req := event.Request
+	commenter := event.Result.AuthorID
+	n.broadcast(req, "commented", event.Result.AuthorName, "info", func(u user.User) bool {
+		if u.ID() == commenter {
+			return false
+		}
+		return u.ID() == req.AuthorID || (req.ReviewerID != 0 && u.ID() == req.ReviewerID)
+	})
+}
+
+// isChangeRequestReviewer reports whether u is expected to review req: the assigned reviewer, or
+// anyone other than the author who may review when nobody was assigned.
+func isChangeRequestReviewer(req policyrequest.ChangeRequest, u user.User) bool {
+	if req.ReviewerID != 0 {
+		return u.ID() == req.ReviewerID
+	}
+	return u.ID() != req.AuthorID && u.Can(permissions.AuthzRequestsReview)
+}
+
+func (n *AuthzChangeRequestNotifications) broadcast(
+	req policyrequest.ChangeRequest,
+	kind string,
+	actor string,
+	variant string,
+	recipient func(u user.User) bool,
+) {
+	logger := configuration.Use().Logger()
+
+	if err := n.app.Websocket().ForEach(application.ChannelAuthenticated, func(connCtx context.Context, conn application.Connection) error {
+		u := conn.User()
+		if u == nil || u.TenantID() != req.TenantID || !recipient(u) {
+			return nil
+		}
+		pageCtx := composables.UsePageCtx(connCtx)
+		data := map[string]interface{}{"ID": req.ID, "Actor": actor}
+		component := corecomponents.AuthzRequestNotification(corecomponents.AuthzRequestNotificationProps{
+			Variant: variant,
+			Title:   pageCtx.T("Authz.Requests.Notify."+kind+".Title", data),
+			Message: pageCtx.T("Authz.Requests.Notify."+kind+".Message", data),
+		})
+		var buf bytes.Buffer
+		if err := component.Render(connCtx, &buf); err != nil {
+			logger.WithError(err).Error("failed to render policy change request notification for websocket")
+			return nil // Continue processing other connections
+		}
+		if err := conn.SendMessage(buf.Bytes()); err != nil {
+			logger.WithError(err).Error("failed to send policy change request notification to websocket connection")
+			return nil // Continue processing other connections
+		}
+		return nil
+	}); err != nil {
+		logger.WithError(err).Error("failed to broadcast policy change request notification to websocket")
+	}
+}
@@ -0,0 +1,487 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policyrequest"
+	"github.com/iota-uz/iota-sdk/modules/core/permissions"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
+	corecomponents "github.com/iota-uz/iota-sdk/modules/core/presentation/templates/components"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	authz "github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/htmx"
+	"github.com/iota-uz/iota-sdk/pkg/shared"
+)
+
+type submitChangeRequestPayload struct {
+	BaseRevision string                  `json:"base_revision"`
+	Subject      string                  `json:"subject"`
+	Domain       string                  `json:"domain"`
+	Reason       string                  `json:"reason"`
+	ReviewerID   uint                    `json:"reviewer_id"`
+	Changes      []services.PolicyChange `json:"changes"`
+}
+
+// changeRequestDecisionPayload is the body of approve, reject and comment calls.
+type changeRequestDecisionPayload struct {
+	BaseRevision string `json:"base_revision"`
+	Comment      string `json:"comment"`
+}
+
+func (c *AuthzAPIController) submitChangeRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	requests *services.AuthzChangeRequestService,
+) {
+	if !c.ensurePermission(w, r, permissions.AuthzRequestsWrite) {
+		return
+	}
+	payload, err := decodeSubmitChangeRequest(r)
+	if err != nil {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_BODY", err.Error())
+		return
+	}
+	req, err := requests.Submit(r.Context(), services.SubmitChangeRequestDTO{
+		Subject:      payload.Subject,
+		Domain:       payload.Domain,
+		Reason:       payload.Reason,
+		BaseRevision: payload.BaseRevision,
+		ReviewerID:   payload.ReviewerID,
+		Changes:      payload.Changes,
+	})
+	if err != nil {
+		logger.WithError(err).Warn("authz api: submit change request failed")
+		c.respondChangeRequestError(w, r, err)
+		return
+	}
+	if htmx.IsHxRequest(r) {
+		htmx.Redirect(w, changeRequestPath(req.ID))
+	}
+	writeJSON(w, http.StatusCreated, changeRequestResponse(req, nil))
+}
+
+func (c *AuthzAPIController) listChangeRequests(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	requests *services.AuthzChangeRequestService,
+) {
+	if !c.ensurePermission(w, r, permissions.AuthzRequestsRead) {
+		return
+	}
+	params, err := parsePolicyListParams(r.URL.Query())
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", err.Error())
+		return
+	}
+	status, err := parseChangeRequestStatus(r.URL.Query().Get("status"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", err.Error())
+		return
+	}
+	items, total, err := requests.GetPaginated(r.Context(), &policyrequest.FindParams{
+		Limit:  params.Limit,
+		Offset: params.Offset(),
+		Status: status,
+	})
+	if err != nil {
+		logger.WithError(err).Error("authz api: list change requests failed")
+		c.respondChangeRequestError(w, r, err)
+		return
+	}
+	data := make([]dtos.ChangeRequestResponse, 0, len(items))
+	for _, item := range items {
+		data = append(data, changeRequestResponse(item, nil))
+	}
+	writeJSON(w, http.StatusOK, dtos.ChangeRequestListResponse{
+		Data:  data,
+		Total: total,
+		Page:  params.Page,
+		Limit: params.Limit,
+	})
+}
+
+func (c *AuthzAPIController) getChangeRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	requests *services.AuthzChangeRequestService,
+) {
+	if !c.ensurePermission(w, r, permissions.AuthzRequestsRead) {
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", err.Error())
+		return
+	}
+	req, err := requests.GetByID(r.Context(), int64(id))
+	if err != nil {
+		c.respondChangeRequestError(w, r, err)
+		return
+	}
+	comments, err := requests.Comments(r.Context(), req.ID)
+	if err != nil {
+		logger.WithError(err).Error("authz api: load change request comments failed")
+		c.respondChangeRequestError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, changeRequestResponse(req, comments))
+}
+
+func (c *AuthzAPIController) approveChangeRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	requests *services.AuthzChangeRequestService,
+) {
+	if !c.ensurePermission(w, r, permissions.AuthzRequestsReview) {
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", err.Error())
+		return
+	}
+	payload, err := decodeChangeRequestDecision(r)
+	if err != nil {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_BODY", err.Error())
+		return
+	}
+	if payload.BaseRevision == "" {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_BODY", "base_revision is required")
+		return
+	}
+	req, result, err := requests.Approve(r.Context(), int64(id), payload.BaseRevision, payload.Comment, authz.Use().ReloadPolicy)
+	if err != nil {
+		logger.WithError(err).Warn("authz api: approve change request failed")
+		c.respondChangeRequestError(w, r, err)
+		return
+	}
+	if htmx.IsHxRequest(r) {
+		if detail, marshalErr := json.Marshal(map[string]any{
+			"base_revision": result.BaseRevision,
+			"revision":      result.Revision,
+			"added":         result.Added,
+			"removed":       result.Removed,
+		}); marshalErr == nil {
+			htmx.SetTrigger(w, "authz:policies-applied", string(detail))
+		}
+		htmx.Refresh(w)
+	}
+	writeJSON(w, http.StatusOK, changeRequestResponse(req, nil))
+}
+
+func (c *AuthzAPIController) rejectChangeRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	requests *services.AuthzChangeRequestService,
+) {
+	if !c.ensurePermission(w, r, permissions.AuthzRequestsReview) {
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", err.Error())
+		return
+	}
+	payload, err := decodeChangeRequestDecision(r)
+	if err != nil {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_BODY", err.Error())
+		return
+	}
+	req, err := requests.Reject(r.Context(), int64(id), payload.Comment)
+	if err != nil {
+		logger.WithError(err).Warn("authz api: reject change request failed")
+		c.respondChangeRequestError(w, r, err)
+		return
+	}
+	if htmx.IsHxRequest(r) {
+		htmx.Refresh(w)
+	}
+	writeJSON(w, http.StatusOK, changeRequestResponse(req, nil))
+}
+
+func (c *AuthzAPIController) withdrawChangeRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	requests *services.AuthzChangeRequestService,
+) {
+	if !c.ensurePermission(w, r, permissions.AuthzRequestsWrite) {
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", err.Error())
+		return
+	}
+	req, err := requests.Withdraw(r.Context(), int64(id))
+	if err != nil {
+		logger.WithError(err).Warn("authz api: withdraw change request failed")
+		c.respondChangeRequestError(w, r, err)
+		return
+	}
+	if htmx.IsHxRequest(r) {
+		htmx.Refresh(w)
+	}
+	writeJSON(w, http.StatusOK, changeRequestResponse(req, nil))
+}
+
+func (c *AuthzAPIController) commentChangeRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	logger *logrus.Entry,
+	requests *services.AuthzChangeRequestService,
+) {
+	if !c.ensurePermission(w, r, permissions.AuthzRequestsRead) {
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_QUERY", err.Error())
+		return
+	}
+	payload, err := decodeChangeRequestDecision(r)
+	if err != nil {
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_INVALID_BODY", err.Error())
+		return
+	}
+	comment, err := requests.Comment(r.Context(), int64(id), payload.Comment)
+	if err != nil {
+		logger.WithError(err).Warn("authz api: comment on change request failed")
+		c.respondChangeRequestError(w, r, err)
+		return
+	}
+	if htmx.IsHxRequest(r) {
+		htmx.Refresh(w)
+	}
+	writeJSON(w, http.StatusCreated, changeRequestCommentResponse(comment))
+}
+
+func (c *AuthzAPIController) respondChangeRequestError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, policyrequest.ErrNotFound):
+		c.writeHTMXError(w, r, http.StatusNotFound, "AUTHZ_CHANGE_REQUEST_NOT_FOUND", "policy change request not found")
+	case errors.Is(err, policyrequest.ErrNotPending):
+		c.writeHTMXError(w, r, http.StatusConflict, "AUTHZ_CHANGE_REQUEST_NOT_PENDING", "policy change request is no longer pending")
+	case errors.Is(err, services.ErrChangeRequestSelfReview):
+		c.writeHTMXError(w, r, http.StatusForbidden, "AUTHZ_SELF_REVIEW", err.Error())
+	case errors.Is(err, services.ErrChangeRequestNotAuthor):
+		c.writeHTMXError(w, r, http.StatusForbidden, "AUTHZ_NOT_AUTHOR", err.Error())
+	case errors.Is(err, services.ErrChangeRequestInvalid):
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_CHANGE_REQUEST_INVALID", err.Error())
+	case errors.Is(err, services.ErrPolicyDraftsEmpty):
+		c.writeHTMXError(w, r, http.StatusBadRequest, "AUTHZ_STAGE_EMPTY", "no staged policies found")
+	default:
+		c.respondApplyError(w, r, err)
+	}
+}
+
+func decodeSubmitChangeRequest(r *http.Request) (submitChangeRequestPayload, error) {
+	var payload submitChangeRequestPayload
+	if strings.Contains(strings.ToLower(r.Header.Get("Content-Type")), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			return submitChangeRequestPayload{}, err
+		}
+		return payload, nil
+	}
+	if err := r.ParseForm(); err != nil {
+		return submitChangeRequestPayload{}, err
+	}
+	reviewerID, err := parseReviewerID(r.FormValue("reviewer_id"))
+	if err != nil {
+		return submitChangeRequestPayload{}, err
+	}
+	return submitChangeRequestPayload{
+		BaseRevision: r.FormValue("base_revision"),
+		Subject:      r.FormValue("subject"),
+		Domain:       r.FormValue("domain"),
+		Reason:       r.FormValue("reason"),
+		ReviewerID:   reviewerID,
+	}, nil
+}
+
+func decodeChangeRequestDecision(r *http.Request) (changeRequestDecisionPayload, error) {
+	var payload changeRequestDecisionPayload
+	if strings.Contains(strings.ToLower(r.Header.Get("Content-Type")), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			return changeRequestDecisionPayload{}, err
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return changeRequestDecisionPayload{}, err
+		}
+		payload.BaseRevision = r.FormValue("base_revision")
+		payload.Comment = r.FormValue("comment")
+	}
+	payload.BaseRevision = strings.TrimSpace(payload.BaseRevision)
+	payload.Comment = strings.TrimSpace(payload.Comment)
+	return payload, nil
+}
+
+func parseReviewerID(raw string) (uint, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, errors.New("reviewer_id must be a user id")
+	}
+	return uint(id), nil
+}
+
+func parseChangeRequestStatus(raw string) (policyrequest.Status, error) {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	if raw == "" || raw == "all" {
+		return "", nil
+	}
+	status := policyrequest.Status(raw)
+	if !status.IsValid() {
+		return "", fmt.Errorf("unsupported status %q", raw)
+	}
+	return status, nil
+}
+
+func changeRequestPath(id int64) string {
+	return fmt.Sprintf("%s/%d", corecomponents.AuthzPolicyRequestsPath, id)
+}
+
+func changeRequestResponse(req policyrequest.ChangeRequest, comments []policyrequest.Comment) dtos.ChangeRequestResponse {
+	resp := dtos.ChangeRequestResponse{
+		ID:              req.ID,
+		Status:          string(req.Status),
+		Subject:         req.Subject,
+		Domain:          req.Domain,
+		Reason:          req.Reason,
+		BaseRevision:    req.BaseRevision,
+		Author:          req.AuthorName,
+		AuthorID:        req.AuthorID,
+		Reviewer:        req.ReviewerName,
+		ReviewerID:      req.ReviewerID,
+		DecidedBy:       req.DeciderName,
+		AppliedRevision: req.AppliedRevision,
+		RevertOf:        req.RevertOf,
+		Changes:         make([]dtos.ChangeRequestChangeDTO, 0, len(req.Changes)),
+		CreatedAt:       req.CreatedAt.Format(time.RFC3339),
+	}
+	if req.DecidedAt != nil {
+		resp.DecidedAt = req.DecidedAt.Format(time.RFC3339)
+	}
+	for _, change := range req.Changes {
+		resp.Changes = append(resp.Changes, dtos.ChangeRequestChangeDTO{
+			StageKind: change.StageKind,
+			PolicyEntryResponse: dtos.PolicyEntryResponse{
+				Type:      change.Type,
+				Subject:   change.Subject,
+				Domain:    change.Domain,
+				Object:    change.Object,
+				Action:    change.Action,
+				Effect:    change.Effect,
+				Condition: change.Condition,
+			},
+		})
+	}
+	for _, comment := range comments {
+		resp.Comments = append(resp.Comments, changeRequestCommentResponse(comment))
+	}
+	return resp
+}
+
+func changeRequestCommentResponse(comment policyrequest.Comment) dtos.ChangeRequestCommentResponse {
+	return dtos.ChangeRequestCommentResponse{
+		ID:        comment.ID,
+		Author:    comment.AuthorName,
+		AuthorID:  comment.AuthorID,
+		Body:      comment.Body,
+		CreatedAt: comment.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func changeRequestViewModel(req policyrequest.ChangeRequest, comments []policyrequest.Comment) viewmodels.AuthzChangeRequest {
+	vm := viewmodels.AuthzChangeRequest{
+		ID:              strconv.FormatInt(req.ID, 10),
+		Status:          string(req.Status),
+		Subject:         req.Subject,
+		Domain:          req.Domain,
+		Reason:          req.Reason,
+		Author:          req.AuthorName,
+		AuthorID:        req.AuthorID,
+		Reviewer:        req.ReviewerName,
+		DecidedBy:       req.DeciderName,
+		AppliedRevision: req.AppliedRevision,
+		CreatedAt:       req.CreatedAt.Format(time.RFC3339),
+	}
+	if req.DecidedAt != nil {
+		vm.DecidedAt = req.DecidedAt.Format(time.RFC3339)
+	}
+	if req.RevertOf != nil {
+		vm.RevertOf = strconv.FormatInt(*req.RevertOf, 10)
+	}
+	for _, change := range req.Changes {
+		line := services.PolicyEntry{
+			Type:      change.Type,
+			Subject:   change.Subject,
+			Domain:    change.Domain,
+			Object:    change.Object,
+			Action:    change.Action,
+			Effect:    change.Effect,
+			Condition: change.Condition,
+		}.Line()
+		if change.StageKind == "remove" {
+			vm.Removed = append(vm.Removed, line)
+		} else {
+			vm.Added = append(vm.Added, line)
+		}
+	}
+	for _, comment := range comments {
+		vm.Comments = append(vm.Comments, viewmodels.AuthzChangeRequestComment{
+			Author:    comment.AuthorName,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt.Format(time.RFC3339),
+		})
+	}
+	return vm
+}
+
+// authzReviewerOptions lists the tenant's users who may approve change requests, excluding the
+// current user since nobody can approve their own request.
+func authzReviewerOptions(ctx context.Context, userService *services.UserService) ([]viewmodels.AuthzReviewer, error) {
+	users, err := userService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var currentID uint
+	if current, err := composables.UseUser(ctx); err == nil && current != nil {
+		currentID = current.ID()
+	}
+	reviewers := make([]viewmodels.AuthzReviewer, 0, len(users))
+	for _, u := range users {
+		if u.ID() == currentID || !u.Can(permissions.AuthzRequestsReview) {
+			continue
+		}
+		name := strings.TrimSpace(u.FirstName() + " " + u.LastName())
+		if name == "" {
+			name = u.Email().Value()
+		}
+		reviewers = append(reviewers, viewmodels.AuthzReviewer{
+			ID:   strconv.FormatUint(uint64(u.ID()), 10),
+			Name: name,
+		})
+	}
+	return reviewers, nil
+}
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+)
+
+type revertPolicyPayload struct {
+	BaseRevision string `json:"base_revision"`
+	Reason       string `json:"reason"`
+	ReviewerID   uint   `json:"reviewer_id"`
+}
+
+func decodeRevertPolicyRequest(r *http.Request) (revertPolicyPayload, error) {
+	var payload revertPolicyPayload
+	if strings.Contains(strings.ToLower(r.Header.Get("Content-Type")), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			return revertPolicyPayload{}, err
+		}
+	} else {
+		if err := r.ParseForm(); err != nil {
+			return revertPolicyPayload{}, err
+		}
+		payload.BaseRevision = r.FormValue("base_revision")
+		payload.Reason = r.FormValue("reason")
+		reviewerID, err := parseReviewerID(r.FormValue("reviewer_id"))
+		if err != nil {
+			return revertPolicyPayload{}, err
+		}
+		payload.ReviewerID = reviewerID
+	}
+	payload.BaseRevision = strings.TrimSpace(payload.BaseRevision)
+	payload.Reason = strings.TrimSpace(payload.Reason)
+	return payload, nil
+}
+
+func policyChangeSetResponse(cs services.PolicyChangeSet) dtos.PolicyChangeSetResponse {
+	return dtos.PolicyChangeSetResponse{
+		ID:           cs.ID,
+		BaseRevision: cs.BaseRevision,
+		Revision:     cs.Revision,
+		Added:        cs.Added,
+		Removed:      cs.Removed,
+		Reason:       cs.Reason,
+		Author:       cs.Author,
+		RevertOf:     cs.RevertOf,
+		CreatedAt:    cs.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func policyChangeSetViewModel(cs services.PolicyChangeSet) viewmodels.AuthzPolicyChangeSet {
+	vm := viewmodels.AuthzPolicyChangeSet{
+		ID:           strconv.FormatInt(cs.ID, 10),
+		BaseRevision: cs.BaseRevision,
+		Revision:     cs.Revision,
+		Added:        cs.Added,
+		Removed:      cs.Removed,
+		Reason:       cs.Reason,
+		Author:       cs.Author,
+		CreatedAt:    cs.CreatedAt.Format(time.RFC3339),
+	}
+	if cs.RevertOf != nil {
+		vm.RevertOf = strconv.FormatInt(*cs.RevertOf, 10)
+	}
+	return vm
+}
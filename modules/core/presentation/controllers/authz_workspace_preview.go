@@ -49,6 +49,7 @@ func buildWorkspacePreview(entries []dtos.StagedPolicyEntry) viewmodels.AuthzWor
 			Object:    object,
 			Action:    action,
 			Effect:    effect,
+			Condition: strings.TrimSpace(entry.Condition),
 		})
 	}
 
@@ -84,7 +85,10 @@ func buildWorkspacePreview(entries []dtos.StagedPolicyEntry) viewmodels.AuthzWor
 		if left.Action != right.Action {
 			return left.Action < right.Action
 		}
-		return left.Effect < right.Effect
+		if left.Effect != right.Effect {
+			return left.Effect < right.Effect
+		}
+		return left.Condition < right.Condition
 	})
 
 	return preview
@@ -0,0 +1,43 @@
+package dtos
+
+import (
+	"strings"
+	"time"
+	"unicode"
+)
+
+// CreateAPITokenDTO is the form for issuing an API token. UserID is the service account to
+// issue it for; zero issues a personal token.
+type CreateAPITokenDTO struct {
+	UserID    uint
+	Name      string
+	Scopes    string
+	ExpiresAt string
+}
+
+// ScopeList splits the scopes field on commas and whitespace.
+func (d *CreateAPITokenDTO) ScopeList() []string {
+	return strings.FieldsFunc(d.Scopes, func(r rune) bool {
+		return r == ',' || unicode.IsSpace(r)
+	})
+}
+
+// Expiry returns the moment the token expires: the end of the ExpiresAt day, in UTC. It is the
+// zero time when no date was given.
+func (d *CreateAPITokenDTO) Expiry() (time.Time, error) {
+	value := strings.TrimSpace(d.ExpiresAt)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return day.AddDate(0, 0, 1), nil
+}
+
+// CreateServiceAccountDTO is the form for creating a service account.
+type CreateServiceAccountDTO struct {
+	Name    string
+	RoleIDs []uint
+}
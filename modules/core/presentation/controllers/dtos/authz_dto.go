@@ -9,12 +9,13 @@ type APIError struct {
 
 // PolicyEntryResponse mirrors services.PolicyEntry for JSON responses.
 type PolicyEntryResponse struct {
-	Type    string `json:"type"`
-	Subject string `json:"subject"`
-	Domain  string `json:"domain"`
-	Object  string `json:"object"`
-	Action  string `json:"action"`
-	Effect  string `json:"effect"`
+	Type      string `json:"type"`
+	Subject   string `json:"subject"`
+	Domain    string `json:"domain"`
+	Object    string `json:"object"`
+	Action    string `json:"action"`
+	Effect    string `json:"effect"`
+	Condition string `json:"condition,omitempty"`
 }
 
 // PolicyListResponse wraps paginated policies.
@@ -33,6 +34,7 @@ type StagePolicyRequest struct {
 	Object    string `json:"object"`
 	Action    string `json:"action"`
 	Effect    string `json:"effect"`
+	Condition string `json:"condition,omitempty"`
 	StageKind string `json:"stage_kind,omitempty"`
 }
 
@@ -63,6 +65,8 @@ type DebugResponse struct {
 // DebugTraceDTO contains rule chain information for Authz.Debug.
 type DebugTraceDTO struct {
 	MatchedPolicy []string `json:"matched_policy,omitempty"`
+	Effect        string   `json:"effect,omitempty"`
+	Condition     string   `json:"condition,omitempty"`
 }
 
 // DebugRequestDTO echoes the evaluated request payload.
@@ -72,3 +76,68 @@ type DebugRequestDTO struct {
 	Object  string `json:"object"`
 	Action  string `json:"action"`
 }
+
+// PolicyChangeSetResponse mirrors services.PolicyChangeSet for JSON responses.
+type PolicyChangeSetResponse struct {
+	ID           int64    `json:"id"`
+	BaseRevision string   `json:"base_revision"`
+	Revision     string   `json:"revision"`
+	Added        []string `json:"added"`
+	Removed      []string `json:"removed"`
+	Reason       string   `json:"reason,omitempty"`
+	Author       string   `json:"author,omitempty"`
+	RevertOf     *int64   `json:"revert_of,omitempty"`
+	CreatedAt    string   `json:"created_at"`
+}
+
+// PolicyHistoryResponse wraps paginated change sets.
+type PolicyHistoryResponse struct {
+	Data  []PolicyChangeSetResponse `json:"data"`
+	Total int                       `json:"total"`
+	Page  int                       `json:"page"`
+	Limit int                       `json:"limit"`
+}
+
+// ChangeRequestChangeDTO is one change of a policy change request.
+type ChangeRequestChangeDTO struct {
+	StageKind string `json:"stage_kind"`
+	PolicyEntryResponse
+}
+
+// ChangeRequestCommentResponse is a comment on a policy change request.
+type ChangeRequestCommentResponse struct {
+	ID        int64  `json:"id"`
+	Author    string `json:"author"`
+	AuthorID  uint   `json:"author_id"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ChangeRequestResponse is a policy change request. Comments is only set on single-request reads.
+type ChangeRequestResponse struct {
+	ID              int64                          `json:"id"`
+	Status          string                         `json:"status"`
+	Subject         string                         `json:"subject,omitempty"`
+	Domain          string                         `json:"domain,omitempty"`
+	Reason          string                         `json:"reason"`
+	BaseRevision    string                         `json:"base_revision,omitempty"`
+	Author          string                         `json:"author"`
+	AuthorID        uint                           `json:"author_id"`
+	Reviewer        string                         `json:"reviewer,omitempty"`
+	ReviewerID      uint                           `json:"reviewer_id,omitempty"`
+	DecidedBy       string                         `json:"decided_by,omitempty"`
+	DecidedAt       string                         `json:"decided_at,omitempty"`
+	AppliedRevision string                         `json:"applied_revision,omitempty"`
+	RevertOf        *int64                         `json:"revert_of,omitempty"`
+	Changes         []ChangeRequestChangeDTO       `json:"changes"`
+	Comments        []ChangeRequestCommentResponse `json:"comments,omitempty"`
+	CreatedAt       string                         `json:"created_at"`
+}
+
+// ChangeRequestListResponse wraps paginated change requests.
+type ChangeRequestListResponse struct {
+	Data  []ChangeRequestResponse `json:"data"`
+	Total int64                   `json:"total"`
+	Page  int                     `json:"page"`
+	Limit int                     `json:"limit"`
+}
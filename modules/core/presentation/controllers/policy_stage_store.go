@@ -1,32 +1,31 @@
 package controllers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
-	"sync"
 
 	"github.com/google/uuid"
 
 	authz "github.com/iota-uz/iota-sdk/pkg/authz"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policyrequest"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/application"
 )
 
-var sharedPolicyStageStore = newPolicyStageStore()
-
-func usePolicyStageStore() *policyStageStore {
-	return sharedPolicyStageStore
-}
-
+// policyStageStore validates staged policy edits and keeps them as the signed-in user's drafts in
+// the database, so they survive restarts and are visible on every replica. Drafts are submitted
+// as change requests through services.AuthzChangeRequestService.
 type policyStageStore struct {
-	mu   sync.Mutex
-	data map[string][]dtos.StagedPolicyEntry
+	requests *services.AuthzChangeRequestService
 }
 
-func newPolicyStageStore() *policyStageStore {
+func usePolicyStageStore(app application.Application) *policyStageStore {
 	return &policyStageStore{
-		data: make(map[string][]dtos.StagedPolicyEntry),
+		requests: app.Service(services.AuthzChangeRequestService{}).(*services.AuthzChangeRequestService),
 	}
 }
 
@@ -41,226 +40,147 @@ func normalizeStageKind(stageKind string) (string, error) {
 	return kind, nil
 }
 
-func (s *policyStageStore) buildEntry(payload dtos.StagePolicyRequest) (dtos.StagedPolicyEntry, error) {
+func (s *policyStageStore) buildEntry(payload dtos.StagePolicyRequest) (policyrequest.Change, error) {
 	typ := strings.ToLower(strings.TrimSpace(payload.Type))
 	if typ == "" {
-		return dtos.StagedPolicyEntry{}, errors.New("type is required")
+		return policyrequest.Change{}, errors.New("type is required")
 	}
 	if typ == "g2" {
-		return dtos.StagedPolicyEntry{}, errors.New("type must be p or g")
+		return policyrequest.Change{}, errors.New("type must be p or g")
 	}
 	if strings.TrimSpace(payload.Object) == "" {
-		return dtos.StagedPolicyEntry{}, errors.New("object is required")
+		return policyrequest.Change{}, errors.New("object is required")
 	}
 	if strings.TrimSpace(payload.Domain) == "" {
-		return dtos.StagedPolicyEntry{}, errors.New("domain is required")
+		return policyrequest.Change{}, errors.New("domain is required")
 	}
 
 	action := strings.TrimSpace(payload.Action)
-	effect := strings.TrimSpace(payload.Effect)
+	effect := strings.ToLower(strings.TrimSpace(payload.Effect))
+	condition := strings.TrimSpace(payload.Condition)
 	isGrouping := typ == "g" || typ == "g2"
 	if isGrouping {
 		if action == "" {
 			action = "*"
 		}
+		if condition != "" {
+			return policyrequest.Change{}, errors.New("condition is only supported on p rows")
+		}
 		effect = "allow"
 	} else {
 		if action == "" {
-			return dtos.StagedPolicyEntry{}, errors.New("action is required")
+			return policyrequest.Change{}, errors.New("action is required")
 		}
 		if effect == "" {
 			effect = "allow"
 		}
-		if !strings.EqualFold(effect, "allow") {
-			return dtos.StagedPolicyEntry{}, errors.New("effect must be allow")
+		if effect != "allow" && effect != "deny" {
+			return policyrequest.Change{}, errors.New("effect must be allow or deny")
+		}
+		if condition != "" {
+			cond, err := authz.ParseCondition(condition)
+			if err != nil {
+				return policyrequest.Change{}, err
+			}
+			condition = cond.String()
 		}
 	}
 
 	stageKind, err := normalizeStageKind(payload.StageKind)
 	if err != nil {
-		return dtos.StagedPolicyEntry{}, err
+		return policyrequest.Change{}, err
 	}
 
-	return dtos.StagedPolicyEntry{
-		ID:        uuid.New().String(),
+	return policyrequest.Change{
 		StageKind: stageKind,
-		PolicyEntryResponse: dtos.PolicyEntryResponse{
-			Type:    typ,
-			Subject: payload.Subject,
-			Domain:  payload.Domain,
-			Object:  payload.Object,
-			Action:  authz.NormalizeAction(action),
-			Effect:  effect,
-		},
+		Type:      typ,
+		Subject:   payload.Subject,
+		Domain:    payload.Domain,
+		Object:    payload.Object,
+		Action:    authz.NormalizeAction(action),
+		Effect:    effect,
+		Condition: condition,
 	}, nil
 }
 
-func policyStageKey(userID uint, tenantID uuid.UUID) string {
-	return fmt.Sprintf("%d:%s", userID, tenantID.String())
-}
-
-func (s *policyStageStore) Add(key string, payload dtos.StagePolicyRequest) ([]dtos.StagedPolicyEntry, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	entries, _, err := s.addAllLocked(key, []dtos.StagePolicyRequest{payload})
-	return entries, err
-}
-
-func (s *policyStageStore) AddMany(key string, payloads []dtos.StagePolicyRequest) ([]dtos.StagedPolicyEntry, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	entries, _, err := s.addAllLocked(key, payloads)
-	return entries, err
-}
-
-func (s *policyStageStore) AddManyWithIDs(key string, payloads []dtos.StagePolicyRequest) ([]dtos.StagedPolicyEntry, []string, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	return s.addAllLocked(key, payloads)
-}
-
-func (s *policyStageStore) addAllLocked(key string, payloads []dtos.StagePolicyRequest) ([]dtos.StagedPolicyEntry, []string, error) {
+// AddMany validates and stages payloads. It returns all of the user's drafts along with the ids
+// of the ones it created.
+func (s *policyStageStore) AddMany(ctx context.Context, payloads []dtos.StagePolicyRequest) ([]dtos.StagedPolicyEntry, []string, error) {
 	if len(payloads) == 0 {
-		return nil, nil, errors.New("at least one staged policy is required")
+		return nil, nil, fmt.Errorf("%w: at least one staged policy is required", services.ErrChangeRequestInvalid)
 	}
-
-	current := s.data[key]
-	if len(current)+len(payloads) > 50 {
-		return nil, nil, errors.New("stage limit reached (50)")
-	}
-
-	entries := make([]dtos.StagedPolicyEntry, 0, len(current)+len(payloads))
-	entries = append(entries, current...)
-	createdIDs := make([]string, 0, len(payloads))
-
+	changes := make([]policyrequest.Change, 0, len(payloads))
 	for _, payload := range payloads {
-		entry, err := s.buildEntry(payload)
+		change, err := s.buildEntry(payload)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, fmt.Errorf("%w: %v", services.ErrChangeRequestInvalid, err)
 		}
-		createdIDs = append(createdIDs, entry.ID)
-		entries = append(entries, entry)
+		changes = append(changes, change)
 	}
-	s.data[key] = entries
-	return entries, createdIDs, nil
-}
-
-func (s *policyStageStore) Delete(key string, id string) ([]dtos.StagedPolicyEntry, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	entries, ok := s.data[key]
-	if !ok {
-		return []dtos.StagedPolicyEntry{}, nil
+	created, err := s.requests.StageDrafts(ctx, changes)
+	if err != nil {
+		return nil, nil, err
 	}
-	next := make([]dtos.StagedPolicyEntry, 0, len(entries))
-	found := false
-	for _, entry := range entries {
-		if entry.ID == id {
-			found = true
-			continue
-		}
-		next = append(next, entry)
+	createdIDs := make([]string, 0, len(created))
+	for _, draft := range created {
+		createdIDs = append(createdIDs, draft.ID.String())
 	}
-	if !found {
-		return nil, errors.New("stage entry not found")
+	entries, err := s.List(ctx, "", "")
+	if err != nil {
+		return nil, nil, err
 	}
-	s.data[key] = next
-	return next, nil
+	return entries, createdIDs, nil
 }
 
-func (s *policyStageStore) DeleteMany(key string, ids []string) ([]dtos.StagedPolicyEntry, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	entries, ok := s.data[key]
-	if !ok {
-		return []dtos.StagedPolicyEntry{}, nil
-	}
-	if len(entries) == 0 {
-		return []dtos.StagedPolicyEntry{}, nil
-	}
-	idSet := make(map[string]struct{}, len(ids))
+// Delete removes the drafts with the given ids and returns the remaining ones.
+func (s *policyStageStore) Delete(ctx context.Context, ids []string) ([]dtos.StagedPolicyEntry, error) {
+	parsed := make([]uuid.UUID, 0, len(ids))
 	for _, id := range ids {
 		id = strings.TrimSpace(id)
 		if id == "" {
 			continue
 		}
-		idSet[id] = struct{}{}
-	}
-	if len(idSet) == 0 {
-		return nil, errors.New("at least one stage entry id is required")
-	}
-
-	next := make([]dtos.StagedPolicyEntry, 0, len(entries))
-	removed := false
-	for _, entry := range entries {
-		if _, ok := idSet[entry.ID]; ok {
-			removed = true
-			continue
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			return nil, services.ErrPolicyDraftNotFound
 		}
-		next = append(next, entry)
+		parsed = append(parsed, uid)
 	}
-	if !removed {
-		return nil, errors.New("stage entry not found")
+	if err := s.requests.DeleteDrafts(ctx, parsed); err != nil {
+		return nil, err
 	}
-	s.data[key] = next
-	return next, nil
+	return s.List(ctx, "", "")
 }
 
-func (s *policyStageStore) Clear(key string, subject, domain string) int {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// Clear removes the drafts for subject and domain, or all drafts when both are empty.
+func (s *policyStageStore) Clear(ctx context.Context, subject, domain string) error {
+	return s.requests.ClearDrafts(ctx, subject, domain)
+}
 
-	entries, ok := s.data[key]
-	if !ok || len(entries) == 0 {
-		return 0
-	}
-	subject = strings.TrimSpace(subject)
-	domain = strings.TrimSpace(domain)
-	if subject == "" && domain == "" {
-		delete(s.data, key)
-		return 0
-	}
-	next := make([]dtos.StagedPolicyEntry, 0, len(entries))
-	for _, entry := range entries {
-		if subject != "" && entry.Subject != subject {
-			next = append(next, entry)
-			continue
-		}
-		if domain != "" && entry.Domain != domain {
-			next = append(next, entry)
-			continue
-		}
+func (s *policyStageStore) List(ctx context.Context, subject, domain string) ([]dtos.StagedPolicyEntry, error) {
+	drafts, err := s.requests.Drafts(ctx, subject, domain)
+	if err != nil {
+		return nil, err
 	}
-	if len(next) == 0 {
-		delete(s.data, key)
-		return 0
+	entries := make([]dtos.StagedPolicyEntry, 0, len(drafts))
+	for _, draft := range drafts {
+		entries = append(entries, stagedPolicyEntry(draft))
 	}
-	s.data[key] = next
-	return len(next)
+	return entries, nil
 }
 
-func (s *policyStageStore) List(key string, subject, domain string) []dtos.StagedPolicyEntry {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	entries := s.data[key]
-	if len(entries) == 0 {
-		return []dtos.StagedPolicyEntry{}
-	}
-	result := make([]dtos.StagedPolicyEntry, 0, len(entries))
-	for _, entry := range entries {
-		if subject != "" && entry.Subject != subject {
-			continue
-		}
-		if domain != "" && entry.Domain != domain {
-			continue
-		}
-		result = append(result, entry)
+func stagedPolicyEntry(draft policyrequest.Draft) dtos.StagedPolicyEntry {
+	return dtos.StagedPolicyEntry{
+		ID:        draft.ID.String(),
+		StageKind: draft.Change.StageKind,
+		PolicyEntryResponse: dtos.PolicyEntryResponse{
+			Type:      draft.Change.Type,
+			Subject:   draft.Change.Subject,
+			Domain:    draft.Change.Domain,
+			Object:    draft.Change.Object,
+			Action:    draft.Change.Action,
+			Effect:    draft.Change.Effect,
+			Condition: draft.Change.Condition,
+		},
 	}
-	return result
 }
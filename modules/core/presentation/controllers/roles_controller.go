@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/role"
 	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policyrequest"
 	"github.com/iota-uz/iota-sdk/modules/core/permissions"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/mappers"
@@ -18,6 +20,7 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/application"
 	"github.com/iota-uz/iota-sdk/pkg/authz"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/di"
 	"github.com/iota-uz/iota-sdk/pkg/htmx"
 	"github.com/iota-uz/iota-sdk/pkg/mapping"
@@ -75,7 +78,7 @@ func NewRolesController(app application.Application, opts *RolesControllerOption
 		app:              app,
 		basePath:         opts.BasePath,
 		permissionSchema: opts.PermissionSchema,
-		stageStore:       usePolicyStageStore(),
+		stageStore:       usePolicyStageStore(app),
 	}
 }
 
@@ -99,6 +102,10 @@ func (c *RolesController) Register(r *mux.Router) {
 	router.HandleFunc("/new", di.H(c.GetNew)).Methods(http.MethodGet)
 	router.HandleFunc("/{id:[0-9]+}", di.H(c.GetEdit)).Methods(http.MethodGet)
 	router.HandleFunc("/{id:[0-9]+}/policies", di.H(c.GetPolicies)).Methods(http.MethodGet)
+	router.HandleFunc("/policies/history", di.H(c.GetPolicyHistory)).Methods(http.MethodGet)
+	router.HandleFunc("/policies/history/{id:[0-9]+}", di.H(c.GetPolicyChangeSet)).Methods(http.MethodGet)
+	router.HandleFunc("/policies/requests", di.H(c.GetPolicyRequests)).Methods(http.MethodGet)
+	router.HandleFunc("/policies/requests/{id:[0-9]+}", di.H(c.GetPolicyRequest)).Methods(http.MethodGet)
 
 	router.HandleFunc("", di.H(c.Create)).Methods(http.MethodPost)
 	router.HandleFunc("/{id:[0-9]+}", di.H(c.Update)).Methods(http.MethodPost)
@@ -390,6 +397,169 @@ func (c *RolesController) GetPolicies(
 	templ.Handler(roles.PolicyMatrix(matrixProps), templ.WithStreaming()).ServeHTTP(w, r)
 }
 
+func (c *RolesController) GetPolicyHistory(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	policyService *services.AuthzPolicyService,
+) {
+	if !ensureAuthz(w, r, authz.ObjectName("core", "authz"), "debug", nil) {
+		return
+	}
+	params, err := parsePolicyListParams(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.URL.Query().Get("limit") == "" {
+		params.Limit = 20
+	}
+	sets, total, err := policyService.History(r.Context(), params.Limit, params.Offset())
+	if err != nil {
+		logger.WithError(err).Error("failed to load policy history")
+		http.Error(w, "Error retrieving policy history", http.StatusInternalServerError)
+		return
+	}
+	items := make([]viewmodels.AuthzPolicyChangeSet, 0, len(sets))
+	for _, cs := range sets {
+		items = append(items, policyChangeSetViewModel(cs))
+	}
+	templ.Handler(roles.PolicyHistory(&roles.PolicyHistoryProps{
+		Items: items,
+		Total: total,
+		Page:  params.Page,
+		Limit: params.Limit,
+	}), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *RolesController) GetPolicyChangeSet(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	policyService *services.AuthzPolicyService,
+	userService *services.UserService,
+) {
+	if !ensureAuthz(w, r, authz.ObjectName("core", "authz"), "debug", nil) {
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cs, err := policyService.ChangeSet(r.Context(), int64(id))
+	if errors.Is(err, services.ErrPolicyChangeSetNotFound) {
+		http.Error(w, "Policy change set not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to load policy change set")
+		http.Error(w, "Error retrieving policy change set", http.StatusInternalServerError)
+		return
+	}
+	props := &roles.PolicyChangeSetProps{
+		ChangeSet:       policyChangeSetViewModel(cs),
+		CanRevert:       composables.CanUser(r.Context(), permissions.AuthzRequestsWrite) == nil,
+		RequireApproval: configuration.Use().Authz.RequireApproval,
+	}
+	if props.CanRevert && props.RequireApproval {
+		props.Reviewers, err = authzReviewerOptions(r.Context(), userService)
+		if err != nil {
+			logger.WithError(err).Error("failed to load policy reviewers")
+			http.Error(w, "Error retrieving policy change set", http.StatusInternalServerError)
+			return
+		}
+	}
+	templ.Handler(roles.PolicyChangeSet(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *RolesController) GetPolicyRequests(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	requestService *services.AuthzChangeRequestService,
+) {
+	if !ensureAuthz(w, r, authz.ObjectName("core", "authz"), "read", permissions.AuthzRequestsRead) {
+		return
+	}
+	params, err := parsePolicyListParams(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.URL.Query().Get("limit") == "" {
+		params.Limit = 20
+	}
+	status, err := parseChangeRequestStatus(r.URL.Query().Get("status"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	requests, total, err := requestService.GetPaginated(r.Context(), &policyrequest.FindParams{
+		Limit:  params.Limit,
+		Offset: params.Offset(),
+		Status: status,
+	})
+	if err != nil {
+		logger.WithError(err).Error("failed to load policy change requests")
+		http.Error(w, "Error retrieving policy change requests", http.StatusInternalServerError)
+		return
+	}
+	items := make([]viewmodels.AuthzChangeRequest, 0, len(requests))
+	for _, req := range requests {
+		items = append(items, changeRequestViewModel(req, nil))
+	}
+	templ.Handler(roles.PolicyRequests(&roles.PolicyRequestsProps{
+		Items:  items,
+		Total:  int(total),
+		Page:   params.Page,
+		Limit:  params.Limit,
+		Status: string(status),
+	}), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *RolesController) GetPolicyRequest(
+	r *http.Request,
+	w http.ResponseWriter,
+	logger *logrus.Entry,
+	requestService *services.AuthzChangeRequestService,
+) {
+	if !ensureAuthz(w, r, authz.ObjectName("core", "authz"), "read", permissions.AuthzRequestsRead) {
+		return
+	}
+	id, err := shared.ParseID(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req, err := requestService.GetByID(r.Context(), int64(id))
+	if errors.Is(err, policyrequest.ErrNotFound) {
+		http.Error(w, "Policy change request not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		logger.WithError(err).Error("failed to load policy change request")
+		http.Error(w, "Error retrieving policy change request", http.StatusInternalServerError)
+		return
+	}
+	comments, err := requestService.Comments(r.Context(), req.ID)
+	if err != nil {
+		logger.WithError(err).Error("failed to load policy change request comments")
+		http.Error(w, "Error retrieving policy change request", http.StatusInternalServerError)
+		return
+	}
+	var isAuthor bool
+	if currentUser, err := composables.UseUser(r.Context()); err == nil && currentUser != nil {
+		isAuthor = currentUser.ID() == req.AuthorID
+	}
+	templ.Handler(roles.PolicyRequest(&roles.PolicyRequestProps{
+		Request: changeRequestViewModel(req, comments),
+		CanReview: req.IsPending() && !isAuthor &&
+			composables.CanUser(r.Context(), permissions.AuthzRequestsReview) == nil,
+		CanWithdraw: req.IsPending() && isAuthor,
+	}), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
 func (c *RolesController) buildPolicyMatrixProps(
 	ctx context.Context,
 	r *http.Request,
@@ -413,44 +583,42 @@ func (c *RolesController) buildPolicyMatrixProps(
 	if params.Domain == "" {
 		params.Domain = authz.DomainFromTenant(roleEntity.TenantID())
 	}
-	var stagedEntries []dtos.StagedPolicyEntry
-	var canStage bool
-	if currentUser, err := composables.UseUser(ctx); err == nil && currentUser != nil {
-		if tenantID, tenantErr := composables.UseTenantID(ctx); tenantErr == nil {
-			stagedEntries = c.stageStore.List(
-				policyStageKey(currentUser.ID(), tenantID),
-				params.Subject,
-				params.Domain,
-			)
-		}
+	stagedEntries, err := c.stageStore.List(ctx, params.Subject, params.Domain)
+	if err != nil {
+		return nil, err
 	}
-	canStage = composables.CanUser(ctx, permissions.AuthzRequestsWrite) == nil &&
+	canStage := composables.CanUser(ctx, permissions.AuthzRequestsWrite) == nil &&
 		composables.CanUser(ctx, permissions.RoleUpdate) == nil
+	requireApproval := configuration.Use().Authz.RequireApproval
+	var reviewers []viewmodels.AuthzReviewer
+	if requireApproval && len(stagedEntries) > 0 {
+		userService := c.app.Service(services.UserService{}).(*services.UserService)
+		if reviewers, err = authzReviewerOptions(ctx, userService); err != nil {
+			return nil, err
+		}
+	}
 	opts := buildAuthzSelectorOptions(entries)
 	matrixEntries, total := mergePolicyMatrixEntries(entries, stagedEntries, params)
 	return &roles.PolicyMatrixProps{
-		RoleID:        fmt.Sprintf("%d", roleEntity.ID()),
-		Entries:       matrixEntries,
-		Total:         total,
-		Page:          params.Page,
-		Limit:         params.Limit,
-		ObjectOptions: opts.Objects,
-		ActionOptions: opts.Actions,
-		RoleOptions:   opts.Roles,
-		Subject:       params.Subject,
-		Domain:        params.Domain,
-		Type:          params.Type,
-		Search:        params.Search,
-		CanDebug:      true,
-		CanStage:      canStage,
-		StageTotal: func() int {
-			if stagedEntries == nil {
-				return 0
-			}
-			return len(stagedEntries)
-		}(),
-		StageSummary: summarizeStagedEntries(stagedEntries),
-		StagePreview: buildWorkspacePreview(stagedEntries),
+		RoleID:          fmt.Sprintf("%d", roleEntity.ID()),
+		Entries:         matrixEntries,
+		Total:           total,
+		Page:            params.Page,
+		Limit:           params.Limit,
+		ObjectOptions:   opts.Objects,
+		ActionOptions:   opts.Actions,
+		RoleOptions:     opts.Roles,
+		Subject:         params.Subject,
+		Domain:          params.Domain,
+		Type:            params.Type,
+		Search:          params.Search,
+		CanDebug:        true,
+		CanStage:        canStage,
+		StageTotal:      len(stagedEntries),
+		StageSummary:    summarizeStagedEntries(stagedEntries),
+		StagePreview:    buildWorkspacePreview(stagedEntries),
+		RequireApproval: requireApproval,
+		Reviewers:       reviewers,
 	}, nil
 }
 
@@ -465,12 +633,13 @@ func mergePolicyMatrixEntries(
 	stageMeta := make(map[string]dtos.StagedPolicyEntry, len(staged))
 	for _, entry := range staged {
 		policy := services.PolicyEntry{
-			Type:    entry.Type,
-			Subject: entry.Subject,
-			Domain:  entry.Domain,
-			Object:  entry.Object,
-			Action:  entry.Action,
-			Effect:  entry.Effect,
+			Type:      entry.Type,
+			Subject:   entry.Subject,
+			Domain:    entry.Domain,
+			Object:    entry.Object,
+			Action:    entry.Action,
+			Effect:    entry.Effect,
+			Condition: entry.Condition,
 		}
 		stageMeta[policyEntryKey(policy)] = entry
 		stagePolicies = append(stagePolicies, policy)
@@ -489,12 +658,13 @@ func mergePolicyMatrixEntries(
 		key := policyEntryKey(entry)
 		row := roles.PolicyMatrixEntry{
 			PolicyEntryResponse: dtos.PolicyEntryResponse{
-				Type:    entry.Type,
-				Subject: entry.Subject,
-				Domain:  entry.Domain,
-				Object:  entry.Object,
-				Action:  entry.Action,
-				Effect:  entry.Effect,
+				Type:      entry.Type,
+				Subject:   entry.Subject,
+				Domain:    entry.Domain,
+				Object:    entry.Object,
+				Action:    entry.Action,
+				Effect:    entry.Effect,
+				Condition: entry.Condition,
 			},
 		}
 		if stagedEntry, ok := stageOverlay[key]; ok {
@@ -563,5 +733,6 @@ func policyEntryKey(entry services.PolicyEntry) string {
 		entry.Object,
 		entry.Action,
 		entry.Effect,
+		entry.Condition,
 	}, "|")
 }
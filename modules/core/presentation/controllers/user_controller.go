@@ -189,7 +189,7 @@ func NewUsersController(app application.Application, opts *UsersControllerOption
 		basePath:         opts.BasePath,
 		realtime:         NewUserRealtimeUpdates(app, userService, opts.BasePath),
 		permissionSchema: opts.PermissionSchema,
-		stageStore:       usePolicyStageStore(),
+		stageStore:       usePolicyStageStore(app),
 	}
 
 	return controller
@@ -944,10 +944,17 @@ func (c *UsersController) buildUserPolicyBoardProps(
 	selectorOpts := buildAuthzSelectorOptions(entries)
 	subjectEntries := filterPolicies(entries, PolicyListParams{Subject: subject})
 
-	tenantID := us.TenantID()
-	var stagedEntries []dtos.StagedPolicyEntry
-	if currentUser, err := composables.UseUser(ctx); err == nil && currentUser != nil {
-		stagedEntries = c.stageStore.List(policyStageKey(currentUser.ID(), tenantID), subject, activeDomain)
+	stagedEntries, err := c.stageStore.List(ctx, subject, activeDomain)
+	if err != nil {
+		return nil, err
+	}
+	requireApproval := configuration.Use().Authz.RequireApproval
+	var reviewers []viewmodels.AuthzReviewer
+	if requireApproval && len(stagedEntries) > 0 {
+		userService := c.app.Service(services.UserService{}).(*services.UserService)
+		if reviewers, err = authzReviewerOptions(ctx, userService); err != nil {
+			return nil, err
+		}
 	}
 
 	baseURL := fmt.Sprintf("%s/%d/policies", c.basePath, us.ID())
@@ -1025,19 +1032,21 @@ func (c *UsersController) buildUserPolicyBoardProps(
 	effectiveProps := buildUserEffectivePoliciesProps(entries, subject, defaultDomain, effectiveParams, roleNameToID, baseURL)
 
 	return &users.UserPolicyBoardProps{
-		Subject:       subject,
-		Domain:        activeDomain,
-		DefaultDomain: defaultDomain,
-		StageTotal:    len(stagedEntries),
-		StageSummary:  summarizeStagedEntries(stagedEntries),
-		StagePreview:  buildWorkspacePreview(stagedEntries),
-		Effective:     effectiveProps,
-		Inherited:     inheritedColumn,
-		Direct:        directColumn,
-		Overrides:     overrideColumn,
-		BaseURL:       baseURL,
-		CanStage:      canStage,
-		CanDebug:      canDebug,
+		Subject:         subject,
+		Domain:          activeDomain,
+		DefaultDomain:   defaultDomain,
+		StageTotal:      len(stagedEntries),
+		StageSummary:    summarizeStagedEntries(stagedEntries),
+		StagePreview:    buildWorkspacePreview(stagedEntries),
+		RequireApproval: requireApproval,
+		Reviewers:       reviewers,
+		Effective:       effectiveProps,
+		Inherited:       inheritedColumn,
+		Direct:          directColumn,
+		Overrides:       overrideColumn,
+		BaseURL:         baseURL,
+		CanStage:        canStage,
+		CanDebug:        canDebug,
 	}, nil
 }
 
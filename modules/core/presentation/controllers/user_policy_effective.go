@@ -34,10 +34,11 @@ func buildUserEffectivePoliciesProps(
 	roleChains := buildRoleChains(allEntries, userSubject, domain)
 
 	type effectiveKey struct {
-		Domain string
-		Object string
-		Action string
-		Effect string
+		Domain    string
+		Object    string
+		Action    string
+		Effect    string
+		Condition string
 	}
 	type effectiveAgg struct {
 		Direct bool
@@ -58,10 +59,11 @@ func buildUserEffectivePoliciesProps(
 		}
 
 		key := effectiveKey{
-			Domain: strings.TrimSpace(entry.Domain),
-			Object: strings.TrimSpace(entry.Object),
-			Action: strings.TrimSpace(entry.Action),
-			Effect: strings.TrimSpace(entry.Effect),
+			Domain:    strings.TrimSpace(entry.Domain),
+			Object:    strings.TrimSpace(entry.Object),
+			Action:    strings.TrimSpace(entry.Action),
+			Effect:    strings.TrimSpace(entry.Effect),
+			Condition: strings.TrimSpace(entry.Condition),
 		}
 		if key.Effect == "" {
 			key.Effect = "allow"
@@ -113,12 +115,13 @@ func buildUserEffectivePoliciesProps(
 		})
 
 		effectiveList = append(effectiveList, users.UserEffectivePolicyEntry{
-			Domain: key.Domain,
-			Object: key.Object,
-			Action: key.Action,
-			Effect: key.Effect,
-			Direct: agg.Direct,
-			Roles:  roleSources,
+			Domain:    key.Domain,
+			Object:    key.Object,
+			Action:    key.Action,
+			Effect:    key.Effect,
+			Condition: key.Condition,
+			Direct:    agg.Direct,
+			Roles:     roleSources,
 		})
 	}
 
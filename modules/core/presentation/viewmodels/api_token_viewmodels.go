@@ -0,0 +1,23 @@
+package viewmodels
+
+// APIToken is an API token as listed on the account page. It never carries the secret.
+type APIToken struct {
+	ID         string
+	Name       string
+	Prefix     string
+	Scopes     []string
+	Status     string
+	ExpiresAt  string
+	LastUsedAt string
+	LastUsedIP string
+	CreatedAt  string
+}
+
+// ServiceAccount is a service account with its roles and tokens.
+type ServiceAccount struct {
+	ID     string
+	Name   string
+	Email  string
+	Roles  []string
+	Tokens []APIToken
+}
@@ -14,6 +14,7 @@ type AuthzWorkspacePreviewItem struct {
 	Object    string
 	Action    string
 	Effect    string
+	Condition string
 }
 
 type AuthzWorkspacePreview struct {
@@ -37,3 +38,49 @@ type AuthzPolicySuggestionItem struct {
 	Action  string
 	Effect  string
 }
+
+// AuthzPolicyChangeSet is an applied policy change set in the history view.
+type AuthzPolicyChangeSet struct {
+	ID           string
+	BaseRevision string
+	Revision     string
+	Added        []string
+	Removed      []string
+	Reason       string
+	Author       string
+	RevertOf     string
+	CreatedAt    string
+}
+
+// AuthzChangeRequest is a policy change request in the review queue and detail views.
+type AuthzChangeRequest struct {
+	ID              string
+	Status          string
+	Subject         string
+	Domain          string
+	Reason          string
+	Author          string
+	AuthorID        uint
+	Reviewer        string
+	DecidedBy       string
+	DecidedAt       string
+	AppliedRevision string
+	RevertOf        string
+	Added           []string
+	Removed         []string
+	Comments        []AuthzChangeRequestComment
+	CreatedAt       string
+}
+
+// AuthzChangeRequestComment is a comment on a policy change request.
+type AuthzChangeRequestComment struct {
+	Author    string
+	Body      string
+	CreatedAt string
+}
+
+// AuthzReviewer is an admin who can be asked to review a policy change request.
+type AuthzReviewer struct {
+	ID   string
+	Name string
+}
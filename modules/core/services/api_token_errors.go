@@ -0,0 +1,9 @@
+package services
+
+import "errors"
+
+var (
+	ErrAPITokenInvalid   = errors.New("invalid api token request")
+	ErrAPITokenRejected  = errors.New("api token is invalid, expired or revoked")
+	ErrAPITokenForbidden = errors.New("api tokens can only be managed by their owner from an interactive session")
+)
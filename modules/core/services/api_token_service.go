@@ -0,0 +1,356 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/role"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/value_objects/internet"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+const (
+	// APITokenPrefix marks API token secrets so they can be told apart from session tokens.
+	APITokenPrefix = "iota_pat_"
+
+	apiTokenDisplayLength = len(APITokenPrefix) + 6
+	// apiTokenTouchInterval throttles last-used updates so busy tokens do not write on every call.
+	apiTokenTouchInterval = time.Minute
+	serviceAccountDomain  = "service-accounts.invalid"
+)
+
+var serviceAccountSlugRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// APITokenService issues, revokes and authenticates API tokens. A token acts as the user that
+// owns it, either the signed-in user or a service account, limited to the token's scopes.
+type APITokenService struct {
+	repo      apitoken.Repository
+	users     *UserService
+	publisher eventbus.EventBus
+	maxTTL    time.Duration
+	now       func() time.Time
+}
+
+func NewAPITokenService(
+	repo apitoken.Repository,
+	users *UserService,
+	publisher eventbus.EventBus,
+	maxTTL time.Duration,
+) *APITokenService {
+	return &APITokenService{
+		repo:      repo,
+		users:     users,
+		publisher: publisher,
+		maxTTL:    maxTTL,
+		now:       time.Now,
+	}
+}
+
+// CreateAPITokenDTO describes a token to issue. UserID is the service account to issue it for;
+// zero issues a personal token for the signed-in user.
+type CreateAPITokenDTO struct {
+	UserID    uint
+	Name      string
+	Scopes    []string
+	ExpiresAt time.Time
+}
+
+// CreateServiceAccountDTO describes a service account. Its roles decide what its tokens may do.
+type CreateServiceAccountDTO struct {
+	Name    string
+	RoleIDs []uint
+}
+
+// ServiceAccount is a service account together with the tokens issued for it.
+type ServiceAccount struct {
+	User   user.User
+	Tokens []apitoken.Token
+}
+
+// MaxTTL returns the longest lifetime a token may be issued with.
+func (s *APITokenService) MaxTTL() time.Duration {
+	return s.maxTTL
+}
+
+// List returns the signed-in user's personal tokens.
+func (s *APITokenService) List(ctx context.Context) ([]apitoken.Token, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.List(ctx, []uint{u.ID()})
+}
+
+// Create issues a token and returns it along with its secret. The secret is only available here;
+// just its hash is stored.
+func (s *APITokenService) Create(ctx context.Context, dto CreateAPITokenDTO) (apitoken.Token, string, error) {
+	actor, err := s.interactiveUser(ctx)
+	if err != nil {
+		return apitoken.Token{}, "", err
+	}
+	ownerID := actor.ID()
+	if dto.UserID != 0 && dto.UserID != actor.ID() {
+		if _, err := s.serviceAccount(ctx, dto.UserID); err != nil {
+			return apitoken.Token{}, "", err
+		}
+		ownerID = dto.UserID
+	}
+
+	name := strings.TrimSpace(dto.Name)
+	if name == "" || len(name) > 255 {
+		return apitoken.Token{}, "", fmt.Errorf("%w: name is required and must be at most 255 characters", ErrAPITokenInvalid)
+	}
+	scopes, err := normalizeAPITokenScopes(dto.Scopes)
+	if err != nil {
+		return apitoken.Token{}, "", err
+	}
+	now := s.now()
+	if dto.ExpiresAt.IsZero() {
+		return apitoken.Token{}, "", fmt.Errorf("%w: expiry is required", ErrAPITokenInvalid)
+	}
+	if !dto.ExpiresAt.After(now) {
+		return apitoken.Token{}, "", fmt.Errorf("%w: expiry must be in the future", ErrAPITokenInvalid)
+	}
+	if s.maxTTL > 0 && dto.ExpiresAt.After(now.Add(s.maxTTL)) {
+		return apitoken.Token{}, "", fmt.Errorf("%w: expiry must be within %s", ErrAPITokenInvalid, s.maxTTL)
+	}
+
+	secret, err := newAPITokenSecret()
+	if err != nil {
+		return apitoken.Token{}, "", err
+	}
+	created, err := s.repo.Create(ctx, apitoken.Token{
+		UserID:    ownerID,
+		Name:      name,
+		Prefix:    secret[:apiTokenDisplayLength],
+		Hash:      HashAPIToken(secret),
+		Scopes:    scopes,
+		CreatedBy: actor.ID(),
+		ExpiresAt: dto.ExpiresAt,
+	})
+	if err != nil {
+		return apitoken.Token{}, "", err
+	}
+
+	event, err := apitoken.NewCreatedEvent(created)
+	if err != nil {
+		return apitoken.Token{}, "", err
+	}
+	s.publisher.Publish(event)
+	return created, secret, nil
+}
+
+// Revoke revokes a personal token of the signed-in user, or a service account token when the
+// signed-in user may manage users.
+func (s *APITokenService) Revoke(ctx context.Context, id int64) (apitoken.Token, error) {
+	actor, err := s.interactiveUser(ctx)
+	if err != nil {
+		return apitoken.Token{}, err
+	}
+	token, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return apitoken.Token{}, err
+	}
+	if token.UserID != actor.ID() {
+		if _, err := s.serviceAccount(ctx, token.UserID); err != nil {
+			return apitoken.Token{}, err
+		}
+	}
+	if err := s.repo.Revoke(ctx, id); err != nil {
+		return apitoken.Token{}, err
+	}
+	revokedAt := s.now()
+	token.RevokedAt = &revokedAt
+
+	event, err := apitoken.NewRevokedEvent(token)
+	if err != nil {
+		return apitoken.Token{}, err
+	}
+	s.publisher.Publish(event)
+	return token, nil
+}
+
+// Authenticate resolves a token secret to the token it belongs to. Unknown, expired and revoked
+// tokens are all rejected with ErrAPITokenRejected.
+func (s *APITokenService) Authenticate(ctx context.Context, secret, ip string) (apitoken.Token, error) {
+	if !strings.HasPrefix(secret, APITokenPrefix) {
+		return apitoken.Token{}, ErrAPITokenRejected
+	}
+	token, err := s.repo.GetByHash(ctx, HashAPIToken(secret))
+	if err != nil {
+		if errors.Is(err, apitoken.ErrNotFound) {
+			return apitoken.Token{}, ErrAPITokenRejected
+		}
+		return apitoken.Token{}, err
+	}
+	now := s.now()
+	if !token.IsActive(now) {
+		return apitoken.Token{}, ErrAPITokenRejected
+	}
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= apiTokenTouchInterval || token.LastUsedIP != ip {
+		if err := s.repo.TouchLastUsed(ctx, token.ID, now, ip); err != nil {
+			return apitoken.Token{}, err
+		}
+		token.LastUsedAt = &now
+		token.LastUsedIP = ip
+	}
+	return token, nil
+}
+
+// ServiceAccounts returns the tenant's service accounts with their tokens.
+func (s *APITokenService) ServiceAccounts(ctx context.Context) ([]ServiceAccount, error) {
+	if err := authorizeUsers(ctx, "update"); err != nil {
+		return nil, err
+	}
+	all, err := s.users.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	accounts := []ServiceAccount{}
+	ids := []uint{}
+	for _, u := range all {
+		if u.Type() == user.TypeService {
+			accounts = append(accounts, ServiceAccount{User: u})
+			ids = append(ids, u.ID())
+		}
+	}
+	if len(ids) == 0 {
+		return accounts, nil
+	}
+	tokens, err := s.repo.List(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range accounts {
+		for _, t := range tokens {
+			if t.UserID == accounts[i].User.ID() {
+				accounts[i].Tokens = append(accounts[i].Tokens, t)
+			}
+		}
+	}
+	return accounts, nil
+}
+
+// CreateServiceAccount creates a service account. Service accounts have no password, so they
+// cannot sign in and act only through their tokens.
+func (s *APITokenService) CreateServiceAccount(ctx context.Context, dto CreateServiceAccountDTO) (user.User, error) {
+	actor, err := s.interactiveUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(dto.Name)
+	if name == "" || len(name) > 255 {
+		return nil, fmt.Errorf("%w: name is required and must be at most 255 characters", ErrAPITokenInvalid)
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+	slug := strings.Trim(serviceAccountSlugRe.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if slug == "" {
+		slug = "service"
+	}
+	email, err := internet.NewEmail(fmt.Sprintf("%s-%s@%s", slug, hex.EncodeToString(suffix), serviceAccountDomain))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrAPITokenInvalid, err)
+	}
+	roles := make([]role.Role, 0, len(dto.RoleIDs))
+	for _, id := range dto.RoleIDs {
+		roles = append(roles, role.New("", role.WithID(id)))
+	}
+	return s.users.Create(ctx, user.New(
+		name,
+		"",
+		email,
+		actor.UILanguage(),
+		user.WithType(user.TypeService),
+		user.WithTenantID(tenantID),
+		user.WithRoles(roles),
+	))
+}
+
+// DeleteServiceAccount deletes a service account; its tokens go with it.
+func (s *APITokenService) DeleteServiceAccount(ctx context.Context, id uint) (user.User, error) {
+	if _, err := s.interactiveUser(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := s.serviceAccount(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.users.Delete(ctx, id)
+}
+
+// HashAPIToken returns the hex sha256 of a token secret, the form tokens are stored and looked up in.
+func HashAPIToken(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// interactiveUser returns the signed-in user, refusing requests that are themselves authenticated
+// with a token so a token can never mint or revoke credentials.
+func (s *APITokenService) interactiveUser(ctx context.Context) (user.User, error) {
+	if _, scoped := authz.ScopesFromContext(ctx); scoped {
+		return nil, ErrAPITokenForbidden
+	}
+	return composables.UseUser(ctx)
+}
+
+// serviceAccount returns the service account with id, provided the signed-in user may manage it.
+func (s *APITokenService) serviceAccount(ctx context.Context, id uint) (user.User, error) {
+	if err := authorizeUsers(ctx, "update"); err != nil {
+		return nil, err
+	}
+	u, err := s.users.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if u.Type() != user.TypeService {
+		return nil, ErrAPITokenForbidden
+	}
+	return u, nil
+}
+
+func normalizeAPITokenScopes(raw []string) ([]string, error) {
+	scopes := []string{}
+	for _, entry := range raw {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		scope, err := authz.ParseScope(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrAPITokenInvalid, err)
+		}
+		if !slices.Contains(scopes, scope.String()) {
+			scopes = append(scopes, scope.String())
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", ErrAPITokenInvalid)
+	}
+	return scopes, nil
+}
+
+func newAPITokenSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return APITokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,132 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/apitoken"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// stubAPITokenRepo keeps tokens in memory and counts last-used writes.
+type stubAPITokenRepo struct {
+	apitoken.Repository
+	tokens  map[int64]apitoken.Token
+	touches int
+}
+
+func (r *stubAPITokenRepo) GetByHash(_ context.Context, hash string) (apitoken.Token, error) {
+	for _, t := range r.tokens {
+		if t.Hash == hash {
+			return t, nil
+		}
+	}
+	return apitoken.Token{}, apitoken.ErrNotFound
+}
+
+func (r *stubAPITokenRepo) Create(_ context.Context, data apitoken.Token) (apitoken.Token, error) {
+	data.ID = int64(len(r.tokens) + 1)
+	data.CreatedAt = time.Now()
+	r.tokens[data.ID] = data
+	return data, nil
+}
+
+func (r *stubAPITokenRepo) TouchLastUsed(_ context.Context, id int64, at time.Time, ip string) error {
+	t := r.tokens[id]
+	t.LastUsedAt = &at
+	t.LastUsedIP = ip
+	r.tokens[id] = t
+	r.touches++
+	return nil
+}
+
+func newAPITokenFixture() (*services.APITokenService, *stubAPITokenRepo) {
+	repo := &stubAPITokenRepo{tokens: map[int64]apitoken.Token{}}
+	svc := services.NewAPITokenService(repo, nil, eventbus.NewEventPublisher(logrus.New()), 30*24*time.Hour)
+	return svc, repo
+}
+
+func TestAPITokenService_Create(t *testing.T) {
+	svc, repo := newAPITokenFixture()
+	ctx := asUserWithID(7)
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	_, _, err := svc.Create(ctx, services.CreateAPITokenDTO{Name: " ", Scopes: []string{"*"}, ExpiresAt: expiresAt})
+	require.ErrorIs(t, err, services.ErrAPITokenInvalid)
+	_, _, err = svc.Create(ctx, services.CreateAPITokenDTO{Name: "cli", ExpiresAt: expiresAt})
+	require.ErrorIs(t, err, services.ErrAPITokenInvalid, "at least one scope is required")
+	_, _, err = svc.Create(ctx, services.CreateAPITokenDTO{Name: "cli", Scopes: []string{"co*re"}, ExpiresAt: expiresAt})
+	require.ErrorIs(t, err, services.ErrAPITokenInvalid)
+	_, _, err = svc.Create(ctx, services.CreateAPITokenDTO{Name: "cli", Scopes: []string{"*"}})
+	require.ErrorIs(t, err, services.ErrAPITokenInvalid, "expiry is required")
+	_, _, err = svc.Create(ctx, services.CreateAPITokenDTO{Name: "cli", Scopes: []string{"*"}, ExpiresAt: time.Now().Add(60 * 24 * time.Hour)})
+	require.ErrorIs(t, err, services.ErrAPITokenInvalid, "expiry is capped by the max TTL")
+
+	scoped := authz.WithScopes(ctx, []authz.Scope{{Object: "*", Action: "*"}})
+	_, _, err = svc.Create(scoped, services.CreateAPITokenDTO{Name: "cli", Scopes: []string{"*"}, ExpiresAt: expiresAt})
+	require.ErrorIs(t, err, services.ErrAPITokenForbidden, "a token cannot mint tokens")
+
+	token, secret, err := svc.Create(ctx, services.CreateAPITokenDTO{
+		Name:      "org-data",
+		Scopes:    []string{"org.*", "Core.Users:READ", "org.*"},
+		ExpiresAt: expiresAt,
+	})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(secret, services.APITokenPrefix))
+	require.Equal(t, uint(7), token.UserID)
+	require.Equal(t, []string{"org.*:*", "core.users:read"}, token.Scopes)
+	require.Equal(t, services.HashAPIToken(secret), token.Hash)
+	require.NotContains(t, token.Hash, secret)
+	require.True(t, strings.HasPrefix(secret, token.Prefix))
+	require.Len(t, repo.tokens, 1)
+}
+
+func TestAPITokenService_Authenticate(t *testing.T) {
+	svc, repo := newAPITokenFixture()
+	ctx := asUserWithID(7)
+
+	_, secret, err := svc.Create(ctx, services.CreateAPITokenDTO{
+		Name:      "cli",
+		Scopes:    []string{"*"},
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	require.NoError(t, err)
+
+	_, err = svc.Authenticate(context.Background(), "session-token", "10.0.0.1")
+	require.ErrorIs(t, err, services.ErrAPITokenRejected)
+	_, err = svc.Authenticate(context.Background(), services.APITokenPrefix+"unknown", "10.0.0.1")
+	require.ErrorIs(t, err, services.ErrAPITokenRejected)
+
+	token, err := svc.Authenticate(context.Background(), secret, "10.0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, uint(7), token.UserID)
+	require.NotNil(t, token.LastUsedAt)
+	require.Equal(t, 1, repo.touches)
+
+	_, err = svc.Authenticate(context.Background(), secret, "10.0.0.1")
+	require.NoError(t, err)
+	require.Equal(t, 1, repo.touches, "last use is recorded at most once a minute per address")
+	_, err = svc.Authenticate(context.Background(), secret, "10.0.0.2")
+	require.NoError(t, err)
+	require.Equal(t, 2, repo.touches)
+
+	stored := repo.tokens[token.ID]
+	revokedAt := time.Now()
+	stored.RevokedAt = &revokedAt
+	repo.tokens[token.ID] = stored
+	_, err = svc.Authenticate(context.Background(), secret, "10.0.0.1")
+	require.ErrorIs(t, err, services.ErrAPITokenRejected)
+
+	stored.RevokedAt = nil
+	stored.ExpiresAt = time.Now().Add(-time.Second)
+	repo.tokens[token.ID] = stored
+	_, err = svc.Authenticate(context.Background(), secret, "10.0.0.1")
+	require.ErrorIs(t, err, services.ErrAPITokenRejected)
+}
@@ -0,0 +1,412 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policyrequest"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+)
+
+// maxPolicyDrafts caps how many edits one user can have staged at a time.
+const maxPolicyDrafts = 50
+
+// AuthzChangeRequestService keeps staged policy edits as per-user drafts in the database and turns
+// them into change requests. A change request is applied only when an admin other than its author
+// approves it (four-eyes principle).
+type AuthzChangeRequestService struct {
+	repo      policyrequest.Repository
+	policies  *AuthzPolicyService
+	publisher eventbus.EventBus
+}
+
+func NewAuthzChangeRequestService(
+	repo policyrequest.Repository,
+	policies *AuthzPolicyService,
+	publisher eventbus.EventBus,
+) *AuthzChangeRequestService {
+	return &AuthzChangeRequestService{
+		repo:      repo,
+		policies:  policies,
+		publisher: publisher,
+	}
+}
+
+// SubmitChangeRequestDTO describes a change request. When Changes is empty the author's drafts for
+// Subject and Domain are submitted and cleared.
+type SubmitChangeRequestDTO struct {
+	Subject      string
+	Domain       string
+	Reason       string
+	BaseRevision string
+	ReviewerID   uint
+	Changes      []PolicyChange
+	RevertOf     *int64
+}
+
+// Drafts returns the signed-in user's drafts, narrowed to subject and domain when they are set.
+func (s *AuthzChangeRequestService) Drafts(ctx context.Context, subject, domain string) ([]policyrequest.Draft, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.Drafts(ctx, u.ID(), strings.TrimSpace(subject), strings.TrimSpace(domain))
+}
+
+// StageDrafts adds changes to the signed-in user's drafts and returns the new drafts.
+func (s *AuthzChangeRequestService) StageDrafts(ctx context.Context, changes []policyrequest.Change) ([]policyrequest.Draft, error) {
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: at least one staged policy is required", ErrChangeRequestInvalid)
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.repo.CountDrafts(ctx, u.ID())
+	if err != nil {
+		return nil, err
+	}
+	if count+len(changes) > maxPolicyDrafts {
+		return nil, fmt.Errorf("%w: stage limit reached (%d)", ErrChangeRequestInvalid, maxPolicyDrafts)
+	}
+	drafts := make([]policyrequest.Draft, 0, len(changes))
+	for _, change := range changes {
+		drafts = append(drafts, policyrequest.Draft{UserID: u.ID(), Change: change})
+	}
+	return s.repo.CreateDrafts(ctx, drafts)
+}
+
+// DeleteDrafts removes the signed-in user's drafts with the given ids.
+func (s *AuthzChangeRequestService) DeleteDrafts(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return fmt.Errorf("%w: at least one stage entry id is required", ErrChangeRequestInvalid)
+	}
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return err
+	}
+	deleted, err := s.repo.DeleteDrafts(ctx, u.ID(), ids)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return ErrPolicyDraftNotFound
+	}
+	return nil
+}
+
+// ClearDrafts removes the signed-in user's drafts, narrowed to subject and domain when they are set.
+func (s *AuthzChangeRequestService) ClearDrafts(ctx context.Context, subject, domain string) error {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return err
+	}
+	return s.repo.ClearDrafts(ctx, u.ID(), strings.TrimSpace(subject), strings.TrimSpace(domain))
+}
+
+// Submit creates a pending change request authored by the signed-in user and notifies reviewers.
+func (s *AuthzChangeRequestService) Submit(ctx context.Context, dto SubmitChangeRequestDTO) (policyrequest.ChangeRequest, error) {
+	author, err := composables.UseUser(ctx)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	dto.Subject = strings.TrimSpace(dto.Subject)
+	dto.Domain = strings.TrimSpace(dto.Domain)
+	dto.Reason = strings.TrimSpace(dto.Reason)
+	if dto.Reason == "" {
+		return policyrequest.ChangeRequest{}, fmt.Errorf("%w: reason is required", ErrChangeRequestInvalid)
+	}
+	if dto.ReviewerID != 0 && dto.ReviewerID == author.ID() {
+		return policyrequest.ChangeRequest{}, ErrChangeRequestSelfReview
+	}
+
+	fromDrafts := len(dto.Changes) == 0
+	changes := dto.Changes
+	if fromDrafts {
+		if dto.Subject == "" || dto.Domain == "" {
+			return policyrequest.ChangeRequest{}, fmt.Errorf("%w: subject and domain are required", ErrChangeRequestInvalid)
+		}
+		drafts, err := s.repo.Drafts(ctx, author.ID(), dto.Subject, dto.Domain)
+		if err != nil {
+			return policyrequest.ChangeRequest{}, err
+		}
+		if len(drafts) == 0 {
+			return policyrequest.ChangeRequest{}, ErrPolicyDraftsEmpty
+		}
+		for _, d := range drafts {
+			changes = append(changes, fromRequestChange(d.Change))
+		}
+	}
+	normalized := make([]policyrequest.Change, 0, len(changes))
+	for _, change := range changes {
+		c, err := normalizeChange(change)
+		if err != nil {
+			return policyrequest.ChangeRequest{}, fmt.Errorf("%w: %v", ErrChangeRequestInvalid, err)
+		}
+		normalized = append(normalized, toRequestChange(c))
+	}
+
+	var created policyrequest.ChangeRequest
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		var err error
+		created, err = s.repo.Create(txCtx, policyrequest.ChangeRequest{
+			AuthorID:     author.ID(),
+			ReviewerID:   dto.ReviewerID,
+			Subject:      dto.Subject,
+			Domain:       dto.Domain,
+			Reason:       dto.Reason,
+			BaseRevision: strings.TrimSpace(dto.BaseRevision),
+			Changes:      normalized,
+			RevertOf:     dto.RevertOf,
+		})
+		if err != nil {
+			return err
+		}
+		if fromDrafts {
+			return s.repo.ClearDrafts(txCtx, author.ID(), dto.Subject, dto.Domain)
+		}
+		return nil
+	})
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+
+	event, err := policyrequest.NewSubmittedEvent(created)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	s.publisher.Publish(event)
+	return created, nil
+}
+
+// SubmitRevert submits the changes that restore change set id as a change request, so reverts go
+// through review like any other policy change.
+func (s *AuthzChangeRequestService) SubmitRevert(
+	ctx context.Context,
+	baseRevision string,
+	id int64,
+	reason string,
+	reviewerID uint,
+) (policyrequest.ChangeRequest, error) {
+	changes, err := s.policies.RevertChanges(ctx, id)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	return s.Submit(ctx, SubmitChangeRequestDTO{
+		Reason:       reason,
+		BaseRevision: baseRevision,
+		ReviewerID:   reviewerID,
+		Changes:      changes,
+		RevertOf:     &id,
+	})
+}
+
+func (s *AuthzChangeRequestService) GetByID(ctx context.Context, id int64) (policyrequest.ChangeRequest, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
+// GetPaginated returns change requests newest first, along with the total count.
+func (s *AuthzChangeRequestService) GetPaginated(
+	ctx context.Context,
+	params *policyrequest.FindParams,
+) ([]policyrequest.ChangeRequest, int64, error) {
+	requests, err := s.repo.GetPaginated(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.repo.Count(ctx, params)
+	if err != nil {
+		return nil, 0, err
+	}
+	return requests, total, nil
+}
+
+func (s *AuthzChangeRequestService) Comments(ctx context.Context, id int64) ([]policyrequest.Comment, error) {
+	return s.repo.Comments(ctx, id)
+}
+
+// Approve applies a pending change request on top of baseRevision. The approver must not be the
+// author. The decision, the applied revision and the approver's comment are written in one
+// transaction around the apply: the claimed row stays locked so that two reviewers cannot both
+// apply the request, and a failed apply leaves it pending.
+func (s *AuthzChangeRequestService) Approve(
+	ctx context.Context,
+	id int64,
+	baseRevision string,
+	comment string,
+	reload func(context.Context) error,
+) (policyrequest.ChangeRequest, PolicyApplyResult, error) {
+	approver, err := composables.UseUser(ctx)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, PolicyApplyResult{}, err
+	}
+	req, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, PolicyApplyResult{}, err
+	}
+	if !req.IsPending() {
+		return policyrequest.ChangeRequest{}, PolicyApplyResult{}, policyrequest.ErrNotPending
+	}
+	if req.AuthorID == approver.ID() {
+		return policyrequest.ChangeRequest{}, PolicyApplyResult{}, ErrChangeRequestSelfReview
+	}
+
+	changes := make([]PolicyChange, 0, len(req.Changes))
+	for _, change := range req.Changes {
+		changes = append(changes, fromRequestChange(change))
+	}
+	reason := fmt.Sprintf("Change request #%d by %s: %s", req.ID, req.AuthorName, req.Reason)
+	comment = strings.TrimSpace(comment)
+
+	var result PolicyApplyResult
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Decide(txCtx, id, policyrequest.StatusApproved, approver.ID()); err != nil {
+			return err
+		}
+		var err error
+		if req.RevertOf != nil {
+			result, err = s.policies.ApplyRevert(txCtx, baseRevision, *req.RevertOf, changes, reason, reload)
+		} else {
+			result, err = s.policies.ApplyAndReload(txCtx, baseRevision, changes, reason, reload)
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.SetAppliedRevision(txCtx, id, result.Revision); err != nil {
+			return err
+		}
+		if comment != "" {
+			_, err = s.repo.AddComment(txCtx, policyrequest.Comment{RequestID: id, AuthorID: approver.ID(), Body: comment})
+		}
+		return err
+	})
+	if err != nil {
+		return policyrequest.ChangeRequest{}, PolicyApplyResult{}, err
+	}
+
+	decided, err := s.publishDecided(ctx, id)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, PolicyApplyResult{}, err
+	}
+	return decided, result, nil
+}
+
+// Reject closes a pending change request without applying it. A comment explaining why is required.
+func (s *AuthzChangeRequestService) Reject(ctx context.Context, id int64, comment string) (policyrequest.ChangeRequest, error) {
+	reviewer, err := composables.UseUser(ctx)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	comment = strings.TrimSpace(comment)
+	if comment == "" {
+		return policyrequest.ChangeRequest{}, fmt.Errorf("%w: comment is required", ErrChangeRequestInvalid)
+	}
+	req, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	if req.AuthorID == reviewer.ID() {
+		return policyrequest.ChangeRequest{}, ErrChangeRequestSelfReview
+	}
+	err = composables.InTx(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Decide(txCtx, id, policyrequest.StatusRejected, reviewer.ID()); err != nil {
+			return err
+		}
+		_, err := s.repo.AddComment(txCtx, policyrequest.Comment{RequestID: id, AuthorID: reviewer.ID(), Body: comment})
+		return err
+	})
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	return s.publishDecided(ctx, id)
+}
+
+// Withdraw closes a pending change request on behalf of its author.
+func (s *AuthzChangeRequestService) Withdraw(ctx context.Context, id int64) (policyrequest.ChangeRequest, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	req, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	if req.AuthorID != u.ID() {
+		return policyrequest.ChangeRequest{}, ErrChangeRequestNotAuthor
+	}
+	if err := s.repo.Decide(ctx, id, policyrequest.StatusWithdrawn, u.ID()); err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	return s.publishDecided(ctx, id)
+}
+
+// Comment adds a comment by the signed-in user to change request id.
+func (s *AuthzChangeRequestService) Comment(ctx context.Context, id int64, body string) (policyrequest.Comment, error) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return policyrequest.Comment{}, err
+	}
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return policyrequest.Comment{}, fmt.Errorf("%w: comment is required", ErrChangeRequestInvalid)
+	}
+	req, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return policyrequest.Comment{}, err
+	}
+	created, err := s.repo.AddComment(ctx, policyrequest.Comment{RequestID: id, AuthorID: u.ID(), Body: body})
+	if err != nil {
+		return policyrequest.Comment{}, err
+	}
+	event, err := policyrequest.NewCommentedEvent(req, created)
+	if err != nil {
+		return policyrequest.Comment{}, err
+	}
+	s.publisher.Publish(event)
+	return created, nil
+}
+
+func (s *AuthzChangeRequestService) publishDecided(ctx context.Context, id int64) (policyrequest.ChangeRequest, error) {
+	decided, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	event, err := policyrequest.NewDecidedEvent(decided)
+	if err != nil {
+		return policyrequest.ChangeRequest{}, err
+	}
+	s.publisher.Publish(event)
+	return decided, nil
+}
+
+func toRequestChange(change PolicyChange) policyrequest.Change {
+	return policyrequest.Change{
+		StageKind: change.StageKind,
+		Type:      change.Type,
+		Subject:   change.Subject,
+		Domain:    change.Domain,
+		Object:    change.Object,
+		Action:    change.Action,
+		Effect:    change.Effect,
+		Condition: change.Condition,
+	}
+}
+
+func fromRequestChange(change policyrequest.Change) PolicyChange {
+	return PolicyChange{
+		StageKind: change.StageKind,
+		PolicyEntry: PolicyEntry{
+			Type:      change.Type,
+			Subject:   change.Subject,
+			Domain:    change.Domain,
+			Object:    change.Object,
+			Action:    change.Action,
+			Effect:    change.Effect,
+			Condition: change.Condition,
+		},
+	}
+}
@@ -0,0 +1,123 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/policyrequest"
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/eventbus"
+	"github.com/iota-uz/iota-sdk/pkg/itf"
+)
+
+// stubPolicyRequestRepo keeps change requests in memory. Drafts are not needed by these tests.
+type stubPolicyRequestRepo struct {
+	policyrequest.Repository
+	requests map[int64]policyrequest.ChangeRequest
+}
+
+func (r *stubPolicyRequestRepo) GetByID(_ context.Context, id int64) (policyrequest.ChangeRequest, error) {
+	req, ok := r.requests[id]
+	if !ok {
+		return policyrequest.ChangeRequest{}, policyrequest.ErrNotFound
+	}
+	return req, nil
+}
+
+func (r *stubPolicyRequestRepo) Decide(_ context.Context, id int64, status policyrequest.Status, decidedBy uint) error {
+	req, ok := r.requests[id]
+	if !ok || !req.IsPending() {
+		return policyrequest.ErrNotPending
+	}
+	req.Status = status
+	req.DecidedBy = decidedBy
+	r.requests[id] = req
+	return nil
+}
+
+func newChangeRequestFixture(t *testing.T) (*services.AuthzChangeRequestService, *stubPolicyRequestRepo, string) {
+	t.Helper()
+	policyPath, base := writePolicyFixture(t)
+	repo := &stubPolicyRequestRepo{
+		requests: map[int64]policyrequest.ChangeRequest{
+			1: {
+				ID:       1,
+				TenantID: uuid.New(),
+				AuthorID: 1,
+				Reason:   "grant roles view",
+				Status:   policyrequest.StatusPending,
+				Changes: []policyrequest.Change{{
+					StageKind: "add",
+					Type:      "p",
+					Subject:   "role:core.admin",
+					Domain:    "global",
+					Object:    "core.roles",
+					Action:    "view",
+					Effect:    "allow",
+				}},
+			},
+		},
+	}
+	svc := services.NewAuthzChangeRequestService(
+		repo,
+		services.NewAuthzPolicyService(policyPath),
+		eventbus.NewEventPublisher(logrus.New()),
+	)
+	return svc, repo, base
+}
+
+func asUserWithID(id uint) context.Context {
+	u := itf.User()
+	return composables.WithUser(context.Background(), user.New(
+		u.FirstName(),
+		u.LastName(),
+		u.Email(),
+		u.UILanguage(),
+		user.WithID(id),
+		user.WithRoles(u.Roles()),
+	))
+}
+
+func noReload(context.Context) error { return nil }
+
+// TestAuthzChangeRequestService_Approve covers the checks made before Approve opens its
+// transaction; TestAuthzAPIController_ChangeRequest_FourEyes covers the apply itself.
+func TestAuthzChangeRequestService_Approve(t *testing.T) {
+	svc, repo, base := newChangeRequestFixture(t)
+
+	_, _, err := svc.Approve(asUserWithID(1), 1, base, "", noReload)
+	require.ErrorIs(t, err, services.ErrChangeRequestSelfReview)
+	require.Equal(t, policyrequest.StatusPending, repo.requests[1].Status)
+
+	_, _, err = svc.Approve(asUserWithID(2), 2, base, "", noReload)
+	require.ErrorIs(t, err, policyrequest.ErrNotFound)
+
+	req := repo.requests[1]
+	req.Status = policyrequest.StatusWithdrawn
+	repo.requests[1] = req
+	_, _, err = svc.Approve(asUserWithID(2), 1, base, "", noReload)
+	require.ErrorIs(t, err, policyrequest.ErrNotPending)
+}
+
+func TestAuthzChangeRequestService_RejectAndWithdraw(t *testing.T) {
+	svc, repo, _ := newChangeRequestFixture(t)
+
+	_, err := svc.Reject(asUserWithID(2), 1, "  ")
+	require.ErrorIs(t, err, services.ErrChangeRequestInvalid)
+	_, err = svc.Reject(asUserWithID(1), 1, "no")
+	require.ErrorIs(t, err, services.ErrChangeRequestSelfReview)
+
+	_, err = svc.Withdraw(asUserWithID(2), 1)
+	require.ErrorIs(t, err, services.ErrChangeRequestNotAuthor)
+
+	req, err := svc.Withdraw(asUserWithID(1), 1)
+	require.NoError(t, err)
+	require.Equal(t, policyrequest.StatusWithdrawn, req.Status)
+	require.Equal(t, policyrequest.StatusWithdrawn, repo.requests[1].Status)
+}
@@ -5,4 +5,13 @@ import "errors"
 var (
 	ErrRevisionMismatch = errors.New("policy base revision is stale")
 	ErrPolicyApply      = errors.New("policy apply failed")
+
+	ErrPolicyHistoryDisabled   = errors.New("policy history is not configured")
+	ErrPolicyChangeSetNotFound = errors.New("policy change set not found")
+
+	ErrPolicyDraftNotFound     = errors.New("stage entry not found")
+	ErrPolicyDraftsEmpty       = errors.New("no staged policies found")
+	ErrChangeRequestInvalid    = errors.New("invalid policy change request")
+	ErrChangeRequestSelfReview = errors.New("a policy change request must be reviewed by another admin")
+	ErrChangeRequestNotAuthor  = errors.New("only the author can withdraw a policy change request")
 )
@@ -3,9 +3,7 @@ package services
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"encoding/csv"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -18,7 +16,9 @@ import (
 	"time"
 
 	authz "github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/authz/policystore"
 	authzVersion "github.com/iota-uz/iota-sdk/pkg/authz/version"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
 )
 
 type PolicyEntry struct {
@@ -28,6 +28,13 @@ type PolicyEntry struct {
 	Object  string `json:"object"`
 	Action  string `json:"action"`
 	Effect  string `json:"effect"`
+	// Condition is the optional ABAC condition of a p row (see authz.ParseCondition).
+	Condition string `json:"condition,omitempty"`
+}
+
+// Line returns the entry as a canonical policy.csv line, as used in change set diffs.
+func (e PolicyEntry) Line() string {
+	return canonicalPolicyLine(e)
 }
 
 type PolicyChange struct {
@@ -43,42 +50,99 @@ type PolicyApplyResult struct {
 }
 
 type AuthzPolicyService struct {
-	policyPath   string
-	revisionPath string
-	provider     authzVersion.Provider
-	mu           sync.Mutex
+	backend   policyBackend
+	changeLog *policystore.ChangeLog
+	mu        sync.Mutex
+}
+
+// PolicyChangeSet is an applied change set from the policy history. Added and Removed are
+// canonical policy lines.
+type PolicyChangeSet struct {
+	ID           int64     `json:"id"`
+	BaseRevision string    `json:"base_revision"`
+	Revision     string    `json:"revision"`
+	Added        []string  `json:"added"`
+	Removed      []string  `json:"removed"`
+	Reason       string    `json:"reason,omitempty"`
+	AuthorID     uint      `json:"author_id,omitempty"`
+	Author       string    `json:"author,omitempty"`
+	RevertOf     *int64    `json:"revert_of,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// changeMeta is what ApplyAndReload records next to the diff.
+type changeMeta struct {
+	reason   string
+	revertOf *int64
+}
+
+// policyBackend is where the aggregated policy lives: policy.csv on local disk or the shared
+// policystore tables.
+type policyBackend interface {
+	// current returns the policy and its revision.
+	current(ctx context.Context) ([]byte, string, error)
+	// replace stores next if baseRevision is still current and returns its revision along with a
+	// func that puts previous back.
+	replace(ctx context.Context, baseRevision string, previous, next []byte) (string, func(context.Context) error, error)
 }
 
+// NewAuthzPolicyService stores policy in policyPath and its revision metadata in policyPath.rev.
 func NewAuthzPolicyService(policyPath string) *AuthzPolicyService {
 	path := filepath.Clean(strings.TrimSpace(policyPath))
 	revPath := path + ".rev"
 	return &AuthzPolicyService{
-		policyPath:   path,
-		revisionPath: revPath,
-		provider:     authzVersion.NewFileProvider(revPath),
+		backend: &filePolicyBackend{
+			policyPath:   path,
+			revisionPath: revPath,
+			provider:     authzVersion.NewFileProvider(revPath),
+		},
 	}
 }
 
+// NewDBAuthzPolicyService stores policy in the shared policy store. Other replicas pick up an
+// applied change through the store's NOTIFY channel (see policystore.Listener).
+func NewDBAuthzPolicyService(store *policystore.Store) *AuthzPolicyService {
+	return &AuthzPolicyService{backend: &dbPolicyBackend{store: store}}
+}
+
+// UseChangeLog records every applied change set in log, which enables History, ChangeSet and
+// RevertTo. Without one, applies are not recorded.
+func (s *AuthzPolicyService) UseChangeLog(log *policystore.ChangeLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changeLog = log
+}
+
 func (s *AuthzPolicyService) Policies(ctx context.Context) ([]PolicyEntry, error) {
-	if s.policyPath == "" {
-		return nil, errors.New("policy file path is not configured")
-	}
-	data, err := os.ReadFile(s.policyPath)
+	data, _, err := s.backend.current(ctx)
 	if err != nil {
 		return nil, err
 	}
 	return parsePolicyEntries(data)
 }
 
+// ApplyAndReload applies changes on top of baseRevision, reloads the enforcer and records the
+// change set with reason. The policy is restored if the reload or the recording fails.
 func (s *AuthzPolicyService) ApplyAndReload(
 	ctx context.Context,
 	baseRevision string,
 	changes []PolicyChange,
+	reason string,
 	reload func(context.Context) error,
 ) (PolicyApplyResult, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	return s.applyLocked(ctx, baseRevision, changes, changeMeta{reason: strings.TrimSpace(reason)}, reload)
+}
+
+func (s *AuthzPolicyService) applyLocked(
+	ctx context.Context,
+	baseRevision string,
+	changes []PolicyChange,
+	meta changeMeta,
+	reload func(context.Context) error,
+) (PolicyApplyResult, error) {
 	if strings.TrimSpace(baseRevision) == "" {
 		return PolicyApplyResult{}, errors.New("base_revision is required")
 	}
@@ -86,23 +150,14 @@ func (s *AuthzPolicyService) ApplyAndReload(
 		return PolicyApplyResult{}, errors.New("reload callback is required")
 	}
 
-	meta, err := s.provider.Current(ctx)
+	policyBefore, revision, err := s.backend.current(ctx)
 	if err != nil {
 		return PolicyApplyResult{}, err
 	}
-	if meta.Revision != strings.TrimSpace(baseRevision) {
+	if revision != strings.TrimSpace(baseRevision) {
 		return PolicyApplyResult{}, ErrRevisionMismatch
 	}
 
-	policyBefore, err := os.ReadFile(s.policyPath)
-	if err != nil {
-		return PolicyApplyResult{}, err
-	}
-	revBefore, err := os.ReadFile(s.revisionPath)
-	if err != nil {
-		return PolicyApplyResult{}, err
-	}
-
 	current, err := parsePolicyEntries(policyBefore)
 	if err != nil {
 		return PolicyApplyResult{}, err
@@ -112,8 +167,7 @@ func (s *AuthzPolicyService) ApplyAndReload(
 		set[canonicalPolicyLine(entry)] = entry
 	}
 
-	added := 0
-	removed := 0
+	var added, removed []string
 	for _, change := range changes {
 		normalized, err := normalizeChange(change)
 		if err != nil {
@@ -127,13 +181,13 @@ func (s *AuthzPolicyService) ApplyAndReload(
 				continue
 			}
 			set[line] = normalized.PolicyEntry
-			added++
+			added = append(added, line)
 		case "remove":
 			if _, ok := set[line]; !ok {
 				return PolicyApplyResult{}, fmt.Errorf("%w: entry not found", ErrPolicyApply)
 			}
 			delete(set, line)
-			removed++
+			removed = append(removed, line)
 		default:
 			return PolicyApplyResult{}, fmt.Errorf("%w: stage_kind must be add or remove", ErrPolicyApply)
 		}
@@ -151,40 +205,311 @@ func (s *AuthzPolicyService) ApplyAndReload(
 		out.WriteString(line)
 		out.WriteByte('\n')
 	}
-	nextPolicy := out.Bytes()
 
-	sum := sha256.Sum256(nextPolicy)
-	nextRevision := hex.EncodeToString(sum[:])
+	nextRevision, restore, err := s.backend.replace(ctx, revision, policyBefore, out.Bytes())
+	if err != nil {
+		return PolicyApplyResult{}, err
+	}
+	if err := reload(ctx); err != nil {
+		_ = restore(ctx)
+		return PolicyApplyResult{}, err
+	}
+	if s.changeLog != nil {
+		authorID, author := policyAuthor(ctx)
+		sort.Strings(added)
+		sort.Strings(removed)
+		if _, err := s.changeLog.Record(ctx, policystore.ChangeSet{
+			BaseRevision: revision,
+			Revision:     nextRevision,
+			Policy:       out.Bytes(),
+			Added:        added,
+			Removed:      removed,
+			Reason:       meta.reason,
+			AuthorID:     authorID,
+			Author:       author,
+			RevertOf:     meta.revertOf,
+		}); err != nil {
+			// An unrecorded change must not stay in force.
+			if restoreErr := restore(ctx); restoreErr == nil {
+				_ = reload(ctx)
+			}
+			return PolicyApplyResult{}, err
+		}
+	}
+
+	return PolicyApplyResult{
+		BaseRevision: revision,
+		Revision:     nextRevision,
+		Added:        len(added),
+		Removed:      len(removed),
+	}, nil
+}
+
+// History lists applied change sets, newest first, with the total count.
+func (s *AuthzPolicyService) History(ctx context.Context, limit, offset int) ([]PolicyChangeSet, int, error) {
+	if s.changeLog == nil {
+		return nil, 0, ErrPolicyHistoryDisabled
+	}
+	sets, total, err := s.changeLog.List(ctx, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	out := make([]PolicyChangeSet, 0, len(sets))
+	for _, cs := range sets {
+		out = append(out, toPolicyChangeSet(cs))
+	}
+	return out, total, nil
+}
+
+// ChangeSet returns one applied change set.
+func (s *AuthzPolicyService) ChangeSet(ctx context.Context, id int64) (PolicyChangeSet, error) {
+	if s.changeLog == nil {
+		return PolicyChangeSet{}, ErrPolicyHistoryDisabled
+	}
+	cs, err := s.changeLog.Get(ctx, id)
+	if errors.Is(err, policystore.ErrNoChangeSet) {
+		return PolicyChangeSet{}, ErrPolicyChangeSetNotFound
+	}
+	if err != nil {
+		return PolicyChangeSet{}, err
+	}
+	return toPolicyChangeSet(cs), nil
+}
+
+// RevertTo restores the policy as it was right after change set id. The difference to the current
+// policy is applied through the same base revision check as ApplyAndReload and recorded as a new
+// change set pointing back at id.
+func (s *AuthzPolicyService) RevertTo(
+	ctx context.Context,
+	baseRevision string,
+	id int64,
+	reason string,
+	reload func(context.Context) error,
+) (PolicyApplyResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changes, err := s.revertChangesLocked(ctx, id)
+	if err != nil {
+		return PolicyApplyResult{}, err
+	}
+	return s.applyLocked(ctx, baseRevision, changes, changeMeta{reason: strings.TrimSpace(reason), revertOf: &id}, reload)
+}
+
+// RevertChanges returns the changes RevertTo would apply now, so that a revert can be reviewed
+// before ApplyRevert applies it.
+func (s *AuthzPolicyService) RevertChanges(ctx context.Context, id int64) ([]PolicyChange, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.revertChangesLocked(ctx, id)
+}
+
+// ApplyRevert applies changes previously computed by RevertChanges and records them as a revert
+// of change set id.
+func (s *AuthzPolicyService) ApplyRevert(
+	ctx context.Context,
+	baseRevision string,
+	id int64,
+	changes []PolicyChange,
+	reason string,
+	reload func(context.Context) error,
+) (PolicyApplyResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.changeLog == nil {
+		return PolicyApplyResult{}, ErrPolicyHistoryDisabled
+	}
+	return s.applyLocked(ctx, baseRevision, changes, changeMeta{reason: strings.TrimSpace(reason), revertOf: &id}, reload)
+}
+
+func (s *AuthzPolicyService) revertChangesLocked(ctx context.Context, id int64) ([]PolicyChange, error) {
+	if s.changeLog == nil {
+		return nil, ErrPolicyHistoryDisabled
+	}
+	target, err := s.changeLog.Get(ctx, id)
+	if errors.Is(err, policystore.ErrNoChangeSet) {
+		return nil, ErrPolicyChangeSetNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	wanted, err := parsePolicyEntries(target.Policy)
+	if err != nil {
+		return nil, err
+	}
+	policy, _, err := s.backend.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := parsePolicyEntries(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := diffPolicyEntries(current, wanted)
+	if len(changes) == 0 {
+		return nil, fmt.Errorf("%w: policy already matches change set %d", ErrPolicyApply, id)
+	}
+	return changes, nil
+}
+
+// diffPolicyEntries returns the changes that turn from into to.
+func diffPolicyEntries(from, to []PolicyEntry) []PolicyChange {
+	fromSet := make(map[string]PolicyEntry, len(from))
+	for _, entry := range from {
+		fromSet[canonicalPolicyLine(entry)] = entry
+	}
+	toSet := make(map[string]PolicyEntry, len(to))
+	for _, entry := range to {
+		toSet[canonicalPolicyLine(entry)] = entry
+	}
+
+	var changes []PolicyChange
+	for line, entry := range fromSet {
+		if _, ok := toSet[line]; !ok {
+			changes = append(changes, PolicyChange{StageKind: "remove", PolicyEntry: entry})
+		}
+	}
+	for line, entry := range toSet {
+		if _, ok := fromSet[line]; !ok {
+			changes = append(changes, PolicyChange{StageKind: "add", PolicyEntry: entry})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].StageKind != changes[j].StageKind {
+			return changes[i].StageKind > changes[j].StageKind
+		}
+		return canonicalPolicyLine(changes[i].PolicyEntry) < canonicalPolicyLine(changes[j].PolicyEntry)
+	})
+	return changes
+}
+
+func toPolicyChangeSet(cs policystore.ChangeSet) PolicyChangeSet {
+	return PolicyChangeSet{
+		ID:           cs.ID,
+		BaseRevision: cs.BaseRevision,
+		Revision:     cs.Revision,
+		Added:        cs.Added,
+		Removed:      cs.Removed,
+		Reason:       cs.Reason,
+		AuthorID:     cs.AuthorID,
+		Author:       cs.Author,
+		RevertOf:     cs.RevertOf,
+		CreatedAt:    cs.CreatedAt,
+	}
+}
+
+type filePolicyBackend struct {
+	policyPath   string
+	revisionPath string
+	provider     authzVersion.Provider
+}
+
+func (b *filePolicyBackend) current(ctx context.Context) ([]byte, string, error) {
+	if b.policyPath == "" {
+		return nil, "", errors.New("policy file path is not configured")
+	}
+	meta, err := b.provider.Current(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := os.ReadFile(b.policyPath)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, meta.Revision, nil
+}
+
+func (b *filePolicyBackend) replace(_ context.Context, _ string, previous, next []byte) (string, func(context.Context) error, error) {
+	revBefore, err := os.ReadFile(b.revisionPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	nextRevision := policystore.RevisionHash(next)
 	revPayload, err := json.MarshalIndent(authzVersion.Metadata{
 		Revision:    nextRevision,
 		GeneratedAt: time.Now().UTC(),
-		Entries:     len(lines),
+		Entries:     policystore.CountEntries(next),
 	}, "", "  ")
 	if err != nil {
-		return PolicyApplyResult{}, err
+		return "", nil, err
 	}
 	revPayload = append(revPayload, '\n')
 
-	if err := writeAtomicFile(s.policyPath, nextPolicy); err != nil {
-		return PolicyApplyResult{}, err
+	if err := writeAtomicFile(b.policyPath, next); err != nil {
+		return "", nil, err
 	}
-	if err := writeAtomicFile(s.revisionPath, revPayload); err != nil {
-		_ = writeAtomicFile(s.policyPath, policyBefore)
-		return PolicyApplyResult{}, err
+	if err := writeAtomicFile(b.revisionPath, revPayload); err != nil {
+		_ = writeAtomicFile(b.policyPath, previous)
+		return "", nil, err
 	}
 
-	if err := reload(ctx); err != nil {
-		_ = writeAtomicFile(s.policyPath, policyBefore)
-		_ = writeAtomicFile(s.revisionPath, revBefore)
-		return PolicyApplyResult{}, err
+	restore := func(context.Context) error {
+		if err := writeAtomicFile(b.policyPath, previous); err != nil {
+			return err
+		}
+		return writeAtomicFile(b.revisionPath, revBefore)
 	}
+	return nextRevision, restore, nil
+}
 
-	return PolicyApplyResult{
-		BaseRevision: meta.Revision,
-		Revision:     nextRevision,
-		Added:        added,
-		Removed:      removed,
-	}, nil
+type dbPolicyBackend struct {
+	store *policystore.Store
+}
+
+func (b *dbPolicyBackend) current(ctx context.Context) ([]byte, string, error) {
+	head, err := b.store.Head(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	return head.Policy, head.Revision, nil
+}
+
+// replace commits next as the new head. Once committed the change is visible to every replica, so
+// restoring means committing previous again as a revert revision rather than rewriting history.
+func (b *dbPolicyBackend) replace(ctx context.Context, baseRevision string, previous, next []byte) (string, func(context.Context) error, error) {
+	actor := policyActor(ctx)
+	rev, err := b.store.Commit(ctx, policystore.CommitInput{
+		BaseRevision: baseRevision,
+		Policy:       next,
+		Source:       policystore.SourceApply,
+		CreatedBy:    actor,
+	})
+	if errors.Is(err, policystore.ErrStaleRevision) {
+		return "", nil, ErrRevisionMismatch
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	restore := func(ctx context.Context) error {
+		_, err := b.store.Commit(ctx, policystore.CommitInput{
+			BaseRevision: rev.Revision,
+			Policy:       previous,
+			Source:       policystore.SourceRevert,
+			CreatedBy:    actor,
+		})
+		return err
+	}
+	return rev.Revision, restore, nil
+}
+
+// policyActor identifies the signed-in user on stored revisions.
+func policyActor(ctx context.Context) string {
+	_, email := policyAuthor(ctx)
+	return email
+}
+
+// policyAuthor returns the id and email of the signed-in user, if any.
+func policyAuthor(ctx context.Context) (uint, string) {
+	u, err := composables.UseUser(ctx)
+	if err != nil {
+		return 0, ""
+	}
+	return u.ID(), u.Email().Value()
 }
 
 func normalizeChange(change PolicyChange) (PolicyChange, error) {
@@ -199,6 +524,7 @@ func normalizeChange(change PolicyChange) (PolicyChange, error) {
 	result.Object = strings.TrimSpace(result.Object)
 	result.Action = strings.TrimSpace(result.Action)
 	result.Effect = strings.ToLower(strings.TrimSpace(result.Effect))
+	result.Condition = strings.TrimSpace(result.Condition)
 
 	switch result.Type {
 	case "p":
@@ -217,8 +543,11 @@ func normalizeChange(change PolicyChange) (PolicyChange, error) {
 		if result.Effect == "" {
 			result.Effect = "allow"
 		}
-		if result.Effect != "allow" {
-			return PolicyChange{}, errors.New("effect must be allow")
+		if result.Effect != "allow" && result.Effect != "deny" {
+			return PolicyChange{}, errors.New("effect must be allow or deny")
+		}
+		if _, err := authz.ParseCondition(result.Condition); err != nil {
+			return PolicyChange{}, err
 		}
 		result.Action = authz.NormalizeAction(result.Action)
 		return result, nil
@@ -238,6 +567,9 @@ func normalizeChange(change PolicyChange) (PolicyChange, error) {
 		if result.Effect != "allow" {
 			return PolicyChange{}, errors.New("effect must be allow")
 		}
+		if result.Condition != "" {
+			return PolicyChange{}, errors.New("condition is only supported on p rows")
+		}
 		result.Action = "*"
 		return result, nil
 	default:
@@ -283,6 +615,9 @@ func parsePolicyEntries(data []byte) ([]PolicyEntry, error) {
 				Domain:  strings.TrimSpace(record[4]),
 				Effect:  strings.ToLower(strings.TrimSpace(record[5])),
 			}
+			if len(record) > 6 {
+				entry.Condition = strings.TrimSpace(record[6])
+			}
 			entries = append(entries, entry)
 		case "g":
 			if len(record) < 4 {
@@ -314,7 +649,7 @@ func canonicalPolicyLine(entry PolicyEntry) string {
 			strings.TrimSpace(entry.Domain),
 		)
 	default:
-		return fmt.Sprintf(
+		line := fmt.Sprintf(
 			"p, %s, %s, %s, %s, %s",
 			strings.TrimSpace(entry.Subject),
 			strings.TrimSpace(entry.Object),
@@ -322,6 +657,10 @@ func canonicalPolicyLine(entry PolicyEntry) string {
 			strings.TrimSpace(entry.Domain),
 			strings.ToLower(strings.TrimSpace(entry.Effect)),
 		)
+		if cond := strings.TrimSpace(entry.Condition); cond != "" {
+			line += ", " + cond
+		}
+		return line
 	}
 }
 
@@ -0,0 +1,75 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz/policystore"
+	authzVersion "github.com/iota-uz/iota-sdk/pkg/authz/version"
+)
+
+func writePolicyFixture(t *testing.T) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "policy.csv")
+	policy := []byte("# Code generated by authz-pack. DO NOT EDIT.\np, role:core.admin, core.users, list, global, allow\n")
+	require.NoError(t, os.WriteFile(policyPath, policy, 0o644))
+	rev, err := json.Marshal(authzVersion.Metadata{Revision: policystore.RevisionHash(policy), Entries: 1})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(policyPath+".rev", rev, 0o644))
+	return policyPath, policystore.RevisionHash(policy)
+}
+
+func TestAuthzPolicyService_ApplyAndReload_File(t *testing.T) {
+	ctx := context.Background()
+	policyPath, base := writePolicyFixture(t)
+	svc := services.NewAuthzPolicyService(policyPath)
+	change := services.PolicyChange{
+		StageKind: "add",
+		PolicyEntry: services.PolicyEntry{
+			Type: "p", Subject: "role:core.admin", Domain: "global", Object: "core.roles", Action: "view",
+		},
+	}
+
+	_, err := svc.ApplyAndReload(ctx, "stale", []services.PolicyChange{change}, "grant roles view", func(context.Context) error { return nil })
+	require.ErrorIs(t, err, services.ErrRevisionMismatch)
+
+	before, err := os.ReadFile(policyPath)
+	require.NoError(t, err)
+	_, err = svc.ApplyAndReload(ctx, base, []services.PolicyChange{change}, "grant roles view", func(context.Context) error {
+		return errors.New("reload failed")
+	})
+	require.Error(t, err)
+	after, err := os.ReadFile(policyPath)
+	require.NoError(t, err)
+	require.Equal(t, before, after, "a failed reload restores the previous policy")
+
+	res, err := svc.ApplyAndReload(ctx, base, []services.PolicyChange{change}, "grant roles view", func(context.Context) error { return nil })
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Added)
+	written, err := os.ReadFile(policyPath)
+	require.NoError(t, err)
+	require.Equal(t, policystore.RevisionHash(written), res.Revision)
+
+	entries, err := svc.Policies(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
+
+func TestAuthzPolicyService_HistoryRequiresChangeLog(t *testing.T) {
+	ctx := context.Background()
+	policyPath, base := writePolicyFixture(t)
+	svc := services.NewAuthzPolicyService(policyPath)
+
+	_, _, err := svc.History(ctx, 10, 0)
+	require.ErrorIs(t, err, services.ErrPolicyHistoryDisabled)
+	_, err = svc.RevertTo(ctx, base, 1, "undo", func(context.Context) error { return nil })
+	require.ErrorIs(t, err, services.ErrPolicyHistoryDisabled)
+}
@@ -0,0 +1,212 @@
+package changerequest
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const DefaultApprovalChainCode = "default"
+
+const (
+	ConditionAlways      = "always"
+	ConditionFTEChange   = "fte_change"
+	ConditionNodeMove    = "node_move"
+	ConditionNodeRescind = "node_rescind"
+)
+
+const (
+	ApprovalStepWaiting  = "waiting"
+	ApprovalStepPending  = "pending"
+	ApprovalStepApproved = "approved"
+	ApprovalStepRejected = "rejected"
+	ApprovalStepSkipped  = "skipped"
+)
+
+// ApprovalChainStep is one step of a tenant's approval chain template. Approvers are the
+// holders of RoleCode on the nearest ancestor (including self) of each affected org node.
+type ApprovalChainStep struct {
+	TenantID           uuid.UUID `json:"tenant_id"`
+	ID                 uuid.UUID `json:"id"`
+	ChainCode          string    `json:"chain_code"`
+	StepOrder          int32     `json:"step_order"`
+	RoleCode           string    `json:"role_code"`
+	Condition          string    `json:"condition"`
+	SLAHours           int32     `json:"sla_hours"`
+	EscalationRoleCode *string   `json:"escalation_role_code,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// ApprovalStep is a chain step instantiated for a submitted change request. Subjects use
+// the "user:<uuid>" / "group:<uuid>" form of org_role_assignments.
+type ApprovalStep struct {
+	TenantID           uuid.UUID  `json:"tenant_id"`
+	ID                 uuid.UUID  `json:"id"`
+	ChangeRequestID    uuid.UUID  `json:"change_request_id"`
+	StepOrder          int32      `json:"step_order"`
+	RoleCode           string     `json:"role_code"`
+	Status             string     `json:"status"`
+	ApproverSubjects   []string   `json:"approver_subjects"`
+	SLAHours           int32      `json:"sla_hours"`
+	DueAt              *time.Time `json:"due_at,omitempty"`
+	EscalationRoleCode *string    `json:"escalation_role_code,omitempty"`
+	EscalationSubjects []string   `json:"escalation_subjects"`
+	EscalatedAt        *time.Time `json:"escalated_at,omitempty"`
+	DecidedBy          *uuid.UUID `json:"decided_by,omitempty"`
+	DecidedAt          *time.Time `json:"decided_at,omitempty"`
+	Comment            *string    `json:"comment,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// CanDecide reports whether any of subjects may approve or reject the step. Once escalated,
+// the escalation role holders may decide as well. A step without approvers blocks everyone.
+func (s *ApprovalStep) CanDecide(subjects []string) bool {
+	allowed := make(map[string]struct{}, len(s.ApproverSubjects)+len(s.EscalationSubjects))
+	for _, v := range s.ApproverSubjects {
+		allowed[v] = struct{}{}
+	}
+	if s.EscalatedAt != nil {
+		for _, v := range s.EscalationSubjects {
+			allowed[v] = struct{}{}
+		}
+	}
+	for _, v := range subjects {
+		if _, ok := allowed[v]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func IsValidCondition(condition string) bool {
+	switch condition {
+	case ConditionAlways, ConditionFTEChange, ConditionNodeMove, ConditionNodeRescind:
+		return true
+	default:
+		return false
+	}
+}
+
+// PositionRef names an affected org node indirectly, through a position or an assignment.
+// It resolves to the position's org node as of EffectiveDate.
+type PositionRef struct {
+	PositionID    *uuid.UUID
+	AssignmentID  *uuid.UUID
+	EffectiveDate time.Time
+}
+
+// PayloadFacts summarizes what a change request payload touches, for chain routing.
+type PayloadFacts struct {
+	AffectedNodeIDs []uuid.UUID
+	PositionRefs    []PositionRef
+	ChangesFTE      bool
+	MovesNode       bool
+	RescindsNode    bool
+}
+
+func (f PayloadFacts) Matches(condition string) bool {
+	switch condition {
+	case ConditionAlways:
+		return true
+	case ConditionFTEChange:
+		return f.ChangesFTE
+	case ConditionNodeMove:
+		return f.MovesNode
+	case ConditionNodeRescind:
+		return f.RescindsNode
+	default:
+		return false
+	}
+}
+
+func AnalyzePayload(raw json.RawMessage) (PayloadFacts, error) {
+	var payload struct {
+		EffectiveDate string `json:"effective_date"`
+		Commands      []struct {
+			Type    string          `json:"type"`
+			Payload json.RawMessage `json:"payload"`
+		} `json:"commands"`
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return PayloadFacts{}, err
+	}
+
+	var out PayloadFacts
+	seen := map[uuid.UUID]struct{}{}
+	add := func(id *uuid.UUID) {
+		if id == nil || *id == uuid.Nil {
+			return
+		}
+		if _, ok := seen[*id]; ok {
+			return
+		}
+		seen[*id] = struct{}{}
+		out.AffectedNodeIDs = append(out.AffectedNodeIDs, *id)
+	}
+
+	globalEffective := parsePayloadDate(payload.EffectiveDate)
+	for _, cmd := range payload.Commands {
+		var body struct {
+			ID            *uuid.UUID `json:"id"`
+			ParentID      *uuid.UUID `json:"parent_id"`
+			NewParentID   *uuid.UUID `json:"new_parent_id"`
+			OrgNodeID     *uuid.UUID `json:"org_node_id"`
+			PositionID    *uuid.UUID `json:"position_id"`
+			AllocatedFTE  *float64   `json:"allocated_fte"`
+			EffectiveDate string     `json:"effective_date"`
+		}
+		_ = json.Unmarshal(cmd.Payload, &body)
+		effective := parsePayloadDate(body.EffectiveDate)
+		if effective.IsZero() {
+			effective = globalEffective
+		}
+
+		cmdType := strings.TrimSpace(cmd.Type)
+		switch {
+		case strings.HasPrefix(cmdType, "node."):
+			if cmdType != "node.create" {
+				add(body.ID)
+			}
+			add(body.ParentID)
+			add(body.NewParentID)
+			switch cmdType {
+			case "node.move", "node.correct_move":
+				out.MovesNode = true
+			case "node.rescind":
+				out.RescindsNode = true
+			}
+		case strings.HasPrefix(cmdType, "assignment."):
+			add(body.OrgNodeID)
+			// Assignments usually carry only position_id (create/update/correct) or just their
+			// own id (rescind); both the current and the target position count as affected.
+			if cmdType != "assignment.create" && body.ID != nil && *body.ID != uuid.Nil {
+				out.PositionRefs = append(out.PositionRefs, PositionRef{AssignmentID: body.ID, EffectiveDate: effective})
+			}
+			if body.PositionID != nil && *body.PositionID != uuid.Nil {
+				out.PositionRefs = append(out.PositionRefs, PositionRef{PositionID: body.PositionID, EffectiveDate: effective})
+			}
+			if cmdType == "assignment.create" || cmdType == "assignment.rescind" || body.AllocatedFTE != nil {
+				out.ChangesFTE = true
+			}
+		}
+	}
+	return out, nil
+}
+
+func parsePayloadDate(v string) time.Time {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.DateOnly, v); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UTC()
+	}
+	return time.Time{}
+}
@@ -0,0 +1,79 @@
+package changerequest
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzePayload(t *testing.T) {
+	nodeID := uuid.New()
+	parentID := uuid.New()
+	assignmentNodeID := uuid.New()
+
+	raw := json.RawMessage(`{"effective_date":"2025-01-01","commands":[
+		{"type":"node.move","payload":{"id":"` + nodeID.String() + `","new_parent_id":"` + parentID.String() + `"}},
+		{"type":"node.update","payload":{"id":"` + nodeID.String() + `","name":"X"}},
+		{"type":"assignment.update","payload":{"id":"` + uuid.NewString() + `","org_node_id":"` + assignmentNodeID.String() + `"}}
+	]}`)
+
+	facts, err := AnalyzePayload(raw)
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{nodeID, parentID, assignmentNodeID}, facts.AffectedNodeIDs)
+	require.True(t, facts.MovesNode)
+	require.False(t, facts.RescindsNode)
+	require.False(t, facts.ChangesFTE)
+
+	require.True(t, facts.Matches(ConditionAlways))
+	require.True(t, facts.Matches(ConditionNodeMove))
+	require.False(t, facts.Matches(ConditionFTEChange))
+	require.False(t, facts.Matches("unknown"))
+
+	facts, err = AnalyzePayload(json.RawMessage(`{"commands":[{"type":"assignment.update","payload":{"id":"` + uuid.NewString() + `","allocated_fte":0.5}}]}`))
+	require.NoError(t, err)
+	require.True(t, facts.ChangesFTE)
+	require.Empty(t, facts.AffectedNodeIDs)
+}
+
+func TestAnalyzePayload_RescindOnlyResolvesThroughAssignment(t *testing.T) {
+	assignmentID := uuid.New()
+	positionID := uuid.New()
+
+	raw := json.RawMessage(`{"effective_date":"2025-03-01","commands":[
+		{"type":"assignment.rescind","payload":{"id":"` + assignmentID.String() + `","reason":"left"}},
+		{"type":"assignment.correct","payload":{"id":"` + assignmentID.String() + `","position_id":"` + positionID.String() + `","effective_date":"2025-04-01"}}
+	]}`)
+
+	facts, err := AnalyzePayload(raw)
+	require.NoError(t, err)
+	require.Empty(t, facts.AffectedNodeIDs)
+	require.True(t, facts.ChangesFTE)
+
+	march := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	april := time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, []PositionRef{
+		{AssignmentID: &assignmentID, EffectiveDate: march},
+		{AssignmentID: &assignmentID, EffectiveDate: april},
+		{PositionID: &positionID, EffectiveDate: april},
+	}, facts.PositionRefs)
+}
+
+func TestApprovalStep_CanDecide(t *testing.T) {
+	unresolved := &ApprovalStep{}
+	require.False(t, unresolved.CanDecide([]string{"user:a"}))
+
+	step := &ApprovalStep{
+		ApproverSubjects:   []string{"user:a", "group:g"},
+		EscalationSubjects: []string{"user:b"},
+	}
+	require.True(t, step.CanDecide([]string{"user:x", "group:g"}))
+	require.False(t, step.CanDecide([]string{"user:b"}))
+
+	now := time.Now()
+	step.EscalatedAt = &now
+	require.True(t, step.CanDecide([]string{"user:b"}))
+	require.True(t, step.CanDecide([]string{"user:a"}))
+}
@@ -12,6 +12,7 @@ const (
 	StatusSubmitted = "submitted"
 	StatusApproved  = "approved"
 	StatusRejected  = "rejected"
+	StatusApplied   = "applied"
 	StatusCancelled = "cancelled"
 )
 
@@ -24,6 +25,28 @@ type ChangeRequest struct {
 	PayloadSchemaVersion int32           `json:"payload_schema_version"`
 	Payload              json.RawMessage `json:"payload"`
 	Notes                *string         `json:"notes,omitempty"`
+	ReviewerID           *uuid.UUID      `json:"reviewer_id,omitempty"`
+	ReviewComment        *string         `json:"review_comment,omitempty"`
+	ReviewedAt           *time.Time      `json:"reviewed_at,omitempty"`
+	AppliedBy            *uuid.UUID      `json:"applied_by,omitempty"`
+	AppliedAt            *time.Time      `json:"applied_at,omitempty"`
+	ScheduledFor         *time.Time      `json:"scheduled_for,omitempty"`
+	ExecutionAttempts    int32           `json:"execution_attempts"`
+	LastExecutedAt       *time.Time      `json:"last_executed_at,omitempty"`
+	ExecutionResult      json.RawMessage `json:"execution_result,omitempty"`
+	ExecutionError       json.RawMessage `json:"execution_error,omitempty"`
+	AuditRequestID       *string         `json:"audit_request_id,omitempty"`
 	CreatedAt            time.Time       `json:"created_at"`
 	UpdatedAt            time.Time       `json:"updated_at"`
 }
+
+// DueRef identifies an approved change request whose scheduled_for has passed.
+type DueRef struct {
+	TenantID uuid.UUID
+	ID       uuid.UUID
+}
+
+// IsDue reports whether an approved request may be applied at now.
+func (cr *ChangeRequest) IsDue(now time.Time) bool {
+	return cr.ScheduledFor == nil || !cr.ScheduledFor.After(now)
+}
@@ -11,7 +11,24 @@ type Repository interface {
 	Upsert(ctx context.Context, cr *ChangeRequest) (*ChangeRequest, error)
 	UpdateDraftByID(ctx context.Context, id uuid.UUID, payload []byte, notes *string) (*ChangeRequest, error)
 	UpdateStatusByID(ctx context.Context, id uuid.UUID, status string) (*ChangeRequest, error)
+	UpdateReviewByID(ctx context.Context, id uuid.UUID, fromStatus, toStatus string, reviewerID uuid.UUID, comment *string) (*ChangeRequest, error)
+	MarkAppliedByID(ctx context.Context, id uuid.UUID, appliedBy uuid.UUID) (*ChangeRequest, error)
+	ScheduleByID(ctx context.Context, id uuid.UUID, scheduledFor *time.Time) (*ChangeRequest, error)
+	RecordExecutionByID(ctx context.Context, id uuid.UUID, result, execErr []byte, auditRequestID *string) (*ChangeRequest, error)
+	ListDue(ctx context.Context, now time.Time, limit int) ([]DueRef, error)
 	GetByRequestID(ctx context.Context, requestID string) (*ChangeRequest, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*ChangeRequest, error)
 	List(ctx context.Context, status string, limit int, cursorUpdatedAt *time.Time, cursorID *uuid.UUID) ([]*ChangeRequest, error)
+
+	ListChainSteps(ctx context.Context, chainCode string) ([]*ApprovalChainStep, error)
+	ReplaceChainSteps(ctx context.Context, chainCode string, steps []*ApprovalChainStep) ([]*ApprovalChainStep, error)
+	ResolveRoleHolders(ctx context.Context, roleCode string, nodeIDs []uuid.UUID, asOf time.Time) ([]string, error)
+	ResolvePositionOrgNodes(ctx context.Context, refs []PositionRef) ([]uuid.UUID, error)
+	InsertApprovalStep(ctx context.Context, step *ApprovalStep) (*ApprovalStep, error)
+	ListApprovalSteps(ctx context.Context, changeRequestID uuid.UUID) ([]*ApprovalStep, error)
+	DecideApprovalStep(ctx context.Context, id uuid.UUID, fromStatus, toStatus string, decidedBy *uuid.UUID, comment *string) (*ApprovalStep, error)
+	ActivateApprovalStep(ctx context.Context, id uuid.UUID, dueAt time.Time) (*ApprovalStep, error)
+	EscalateApprovalStep(ctx context.Context, id uuid.UUID, subjects []string) (*ApprovalStep, error)
+	ListOverdueApprovalSteps(ctx context.Context, now time.Time, limit int) ([]*ApprovalStep, error)
+	ListTenantsWithOverdueApprovalSteps(ctx context.Context, now time.Time) ([]uuid.UUID, error)
 }
@@ -0,0 +1,423 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/iota-uz/iota-sdk/modules/org/domain/changerequest"
+	changerequests_sqlc "github.com/iota-uz/iota-sdk/modules/org/infrastructure/sqlc/changerequests"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func toApprovalChainStep(row changerequests_sqlc.OrgApprovalChainStep) *changerequest.ApprovalChainStep {
+	return &changerequest.ApprovalChainStep{
+		TenantID:           asUUID(row.TenantID),
+		ID:                 asUUID(row.ID),
+		ChainCode:          row.ChainCode,
+		StepOrder:          row.StepOrder,
+		RoleCode:           row.RoleCode,
+		Condition:          row.Condition,
+		SLAHours:           row.SlaHours,
+		EscalationRoleCode: row.EscalationRoleCode,
+		CreatedAt:          asTime(row.CreatedAt),
+		UpdatedAt:          asTime(row.UpdatedAt),
+	}
+}
+
+func toApprovalStep(row changerequests_sqlc.OrgChangeRequestApprovalStep) (*changerequest.ApprovalStep, error) {
+	approvers, err := decodeSubjects(row.ApproverSubjects)
+	if err != nil {
+		return nil, err
+	}
+	escalation, err := decodeSubjects(row.EscalationSubjects)
+	if err != nil {
+		return nil, err
+	}
+	return &changerequest.ApprovalStep{
+		TenantID:           asUUID(row.TenantID),
+		ID:                 asUUID(row.ID),
+		ChangeRequestID:    asUUID(row.ChangeRequestID),
+		StepOrder:          row.StepOrder,
+		RoleCode:           row.RoleCode,
+		Status:             row.Status,
+		ApproverSubjects:   approvers,
+		SLAHours:           row.SlaHours,
+		DueAt:              asOptionalTime(row.DueAt),
+		EscalationRoleCode: row.EscalationRoleCode,
+		EscalationSubjects: escalation,
+		EscalatedAt:        asOptionalTime(row.EscalatedAt),
+		DecidedBy:          asOptionalUUID(row.DecidedBy),
+		DecidedAt:          asOptionalTime(row.DecidedAt),
+		Comment:            row.Comment,
+		CreatedAt:          asTime(row.CreatedAt),
+		UpdatedAt:          asTime(row.UpdatedAt),
+	}, nil
+}
+
+func toApprovalSteps(rows []changerequests_sqlc.OrgChangeRequestApprovalStep) ([]*changerequest.ApprovalStep, error) {
+	out := make([]*changerequest.ApprovalStep, 0, len(rows))
+	for _, row := range rows {
+		step, err := toApprovalStep(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, step)
+	}
+	return out, nil
+}
+
+func decodeSubjects(raw []byte) ([]string, error) {
+	out := []string{}
+	if len(raw) == 0 {
+		return out, nil
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, fmt.Errorf("decode approval subjects: %w", err)
+	}
+	return out, nil
+}
+
+func encodeSubjects(subjects []string) ([]byte, error) {
+	if subjects == nil {
+		subjects = []string{}
+	}
+	return json.Marshal(subjects)
+}
+
+func pgOptionalTimestamptz(t *time.Time) pgtype.Timestamptz {
+	if t == nil {
+		return pgtype.Timestamptz{}
+	}
+	return pgtype.Timestamptz{Time: *t, Valid: true}
+}
+
+func pgOptionalUUID(id *uuid.UUID) pgtype.UUID {
+	if id == nil {
+		return pgtype.UUID{}
+	}
+	return pgUUID(*id)
+}
+
+func (r *ChangeRequestRepository) ListChainSteps(ctx context.Context, chainCode string) ([]*changerequest.ApprovalChainStep, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	rows, err := q.ListOrgApprovalChainSteps(ctx, changerequests_sqlc.ListOrgApprovalChainStepsParams{
+		TenantID:  pgUUID(tenantID),
+		ChainCode: chainCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*changerequest.ApprovalChainStep, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, toApprovalChainStep(row))
+	}
+	return out, nil
+}
+
+func (r *ChangeRequestRepository) ReplaceChainSteps(ctx context.Context, chainCode string, steps []*changerequest.ApprovalChainStep) ([]*changerequest.ApprovalChainStep, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	if err := q.DeleteOrgApprovalChainSteps(ctx, changerequests_sqlc.DeleteOrgApprovalChainStepsParams{
+		TenantID:  pgUUID(tenantID),
+		ChainCode: chainCode,
+	}); err != nil {
+		return nil, err
+	}
+
+	out := make([]*changerequest.ApprovalChainStep, 0, len(steps))
+	for _, step := range steps {
+		row, err := q.InsertOrgApprovalChainStep(ctx, changerequests_sqlc.InsertOrgApprovalChainStepParams{
+			TenantID:           pgUUID(tenantID),
+			ChainCode:          chainCode,
+			StepOrder:          step.StepOrder,
+			RoleCode:           step.RoleCode,
+			Condition:          step.Condition,
+			SlaHours:           step.SLAHours,
+			EscalationRoleCode: step.EscalationRoleCode,
+		})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, toApprovalChainStep(row))
+	}
+	return out, nil
+}
+
+// ResolveRoleHolders returns the "user:<id>" / "group:<id>" subjects holding roleCode on the
+// nearest ancestor of each node, via the tenant's active OrgUnit closure build.
+func (r *ChangeRequestRepository) ResolveRoleHolders(ctx context.Context, roleCode string, nodeIDs []uuid.UUID, asOf time.Time) ([]string, error) {
+	out := []string{}
+	if len(nodeIDs) == 0 {
+		return out, nil
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	buildID, err := activeClosureBuildID(ctx, tx, tenantID, "OrgUnit")
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]pgtype.UUID, 0, len(nodeIDs))
+	for _, id := range nodeIDs {
+		ids = append(ids, pgUUID(id))
+	}
+
+	q := changerequests_sqlc.New(tx)
+	rows, err := q.ListOrgApprovalRoleHolders(ctx, changerequests_sqlc.ListOrgApprovalRoleHoldersParams{
+		AsOf:     pgValidDate(asOf),
+		RoleCode: roleCode,
+		TenantID: pgUUID(tenantID),
+		BuildID:  pgUUID(buildID),
+		NodeIds:  ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out = append(out, fmt.Sprintf("%s:%s", row.SubjectType, asUUID(row.SubjectID)))
+	}
+	return out, nil
+}
+
+// ResolvePositionOrgNodes maps each ref to the org node its position belongs to as of the
+// ref's effective date. Refs whose position or assignment does not exist yet are skipped.
+func (r *ChangeRequestRepository) ResolvePositionOrgNodes(ctx context.Context, refs []changerequest.PositionRef) ([]uuid.UUID, error) {
+	out := []uuid.UUID{}
+	if len(refs) == 0 {
+		return out, nil
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range refs {
+		var nodeID uuid.UUID
+		err := tx.QueryRow(ctx, `
+SELECT ps.org_node_id
+FROM org_position_slices ps
+WHERE ps.tenant_id=$1
+  AND ps.position_id = COALESCE(
+    $2::uuid,
+    (SELECT a.position_id FROM org_assignments a WHERE a.tenant_id=$1 AND a.id=$3::uuid)
+  )
+  AND ps.effective_date <= $4
+  AND ps.end_date >= $4
+ORDER BY ps.effective_date DESC
+LIMIT 1
+`, pgUUID(tenantID), pgOptionalUUID(ref.PositionID), pgOptionalUUID(ref.AssignmentID), pgValidDate(ref.EffectiveDate)).Scan(&nodeID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return nil, err
+		}
+		out = append(out, nodeID)
+	}
+	return out, nil
+}
+
+func (r *ChangeRequestRepository) InsertApprovalStep(ctx context.Context, step *changerequest.ApprovalStep) (*changerequest.ApprovalStep, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	approvers, err := encodeSubjects(step.ApproverSubjects)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	row, err := q.InsertOrgChangeRequestApprovalStep(ctx, changerequests_sqlc.InsertOrgChangeRequestApprovalStepParams{
+		TenantID:           pgUUID(tenantID),
+		ChangeRequestID:    pgUUID(step.ChangeRequestID),
+		StepOrder:          step.StepOrder,
+		RoleCode:           step.RoleCode,
+		Status:             step.Status,
+		ApproverSubjects:   approvers,
+		SlaHours:           step.SLAHours,
+		DueAt:              pgOptionalTimestamptz(step.DueAt),
+		EscalationRoleCode: step.EscalationRoleCode,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toApprovalStep(row)
+}
+
+func (r *ChangeRequestRepository) ListApprovalSteps(ctx context.Context, changeRequestID uuid.UUID) ([]*changerequest.ApprovalStep, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	rows, err := q.ListOrgChangeRequestApprovalSteps(ctx, changerequests_sqlc.ListOrgChangeRequestApprovalStepsParams{
+		TenantID:        pgUUID(tenantID),
+		ChangeRequestID: pgUUID(changeRequestID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toApprovalSteps(rows)
+}
+
+func (r *ChangeRequestRepository) DecideApprovalStep(ctx context.Context, id uuid.UUID, fromStatus, toStatus string, decidedBy *uuid.UUID, comment *string) (*changerequest.ApprovalStep, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	row, err := q.DecideOrgChangeRequestApprovalStep(ctx, changerequests_sqlc.DecideOrgChangeRequestApprovalStepParams{
+		ToStatus:   toStatus,
+		DecidedBy:  pgOptionalUUID(decidedBy),
+		Comment:    comment,
+		TenantID:   pgUUID(tenantID),
+		ID:         pgUUID(id),
+		FromStatus: fromStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toApprovalStep(row)
+}
+
+func (r *ChangeRequestRepository) ActivateApprovalStep(ctx context.Context, id uuid.UUID, dueAt time.Time) (*changerequest.ApprovalStep, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	row, err := q.ActivateOrgChangeRequestApprovalStep(ctx, changerequests_sqlc.ActivateOrgChangeRequestApprovalStepParams{
+		TenantID: pgUUID(tenantID),
+		ID:       pgUUID(id),
+		DueAt:    pgOptionalTimestamptz(&dueAt),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toApprovalStep(row)
+}
+
+func (r *ChangeRequestRepository) EscalateApprovalStep(ctx context.Context, id uuid.UUID, subjects []string) (*changerequest.ApprovalStep, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := encodeSubjects(subjects)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	row, err := q.EscalateOrgChangeRequestApprovalStep(ctx, changerequests_sqlc.EscalateOrgChangeRequestApprovalStepParams{
+		TenantID:           pgUUID(tenantID),
+		ID:                 pgUUID(id),
+		EscalationSubjects: encoded,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toApprovalStep(row)
+}
+
+func (r *ChangeRequestRepository) ListOverdueApprovalSteps(ctx context.Context, now time.Time, limit int) ([]*changerequest.ApprovalStep, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	rows, err := q.ListOverdueOrgChangeRequestApprovalSteps(ctx, changerequests_sqlc.ListOverdueOrgChangeRequestApprovalStepsParams{
+		TenantID: pgUUID(tenantID),
+		DueAt:    pgOptionalTimestamptz(&now),
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toApprovalSteps(rows)
+}
+
+// ListTenantsWithOverdueApprovalSteps scans every tenant, so it only needs a transaction in ctx.
+func (r *ChangeRequestRepository) ListTenantsWithOverdueApprovalSteps(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	rows, err := q.ListTenantsWithOverdueOrgApprovalSteps(ctx, pgOptionalTimestamptz(&now))
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]uuid.UUID, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, asUUID(row))
+	}
+	return out, nil
+}
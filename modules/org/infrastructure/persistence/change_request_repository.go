@@ -40,6 +40,48 @@ func asTime(v pgtype.Timestamptz) time.Time {
 	return v.Time
 }
 
+func asOptionalUUID(v pgtype.UUID) *uuid.UUID {
+	if !v.Valid {
+		return nil
+	}
+	id := uuid.UUID(v.Bytes)
+	return &id
+}
+
+func asOptionalTime(v pgtype.Timestamptz) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := v.Time
+	return &t
+}
+
+func toChangeRequest(row changerequests_sqlc.OrgChangeRequest) *changerequest.ChangeRequest {
+	return &changerequest.ChangeRequest{
+		TenantID:             asUUID(row.TenantID),
+		ID:                   asUUID(row.ID),
+		RequestID:            row.RequestID,
+		RequesterID:          asUUID(row.RequesterID),
+		Status:               row.Status,
+		PayloadSchemaVersion: row.PayloadSchemaVersion,
+		Payload:              json.RawMessage(row.Payload),
+		Notes:                row.Notes,
+		ReviewerID:           asOptionalUUID(row.ReviewerID),
+		ReviewComment:        row.ReviewComment,
+		ReviewedAt:           asOptionalTime(row.ReviewedAt),
+		AppliedBy:            asOptionalUUID(row.AppliedBy),
+		AppliedAt:            asOptionalTime(row.AppliedAt),
+		ScheduledFor:         asOptionalTime(row.ScheduledFor),
+		ExecutionAttempts:    row.ExecutionAttempts,
+		LastExecutedAt:       asOptionalTime(row.LastExecutedAt),
+		ExecutionResult:      json.RawMessage(row.ExecutionResult),
+		ExecutionError:       json.RawMessage(row.ExecutionError),
+		AuditRequestID:       row.AuditRequestID,
+		CreatedAt:            asTime(row.CreatedAt),
+		UpdatedAt:            asTime(row.UpdatedAt),
+	}
+}
+
 func (r *ChangeRequestRepository) Upsert(ctx context.Context, cr *changerequest.ChangeRequest) (*changerequest.ChangeRequest, error) {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
@@ -64,18 +106,7 @@ func (r *ChangeRequestRepository) Upsert(ctx context.Context, cr *changerequest.
 		return nil, err
 	}
 
-	return &changerequest.ChangeRequest{
-		TenantID:             asUUID(row.TenantID),
-		ID:                   asUUID(row.ID),
-		RequestID:            row.RequestID,
-		RequesterID:          asUUID(row.RequesterID),
-		Status:               row.Status,
-		PayloadSchemaVersion: row.PayloadSchemaVersion,
-		Payload:              json.RawMessage(row.Payload),
-		Notes:                row.Notes,
-		CreatedAt:            asTime(row.CreatedAt),
-		UpdatedAt:            asTime(row.UpdatedAt),
-	}, nil
+	return toChangeRequest(row), nil
 }
 
 func (r *ChangeRequestRepository) GetByRequestID(ctx context.Context, requestID string) (*changerequest.ChangeRequest, error) {
@@ -97,18 +128,7 @@ func (r *ChangeRequestRepository) GetByRequestID(ctx context.Context, requestID
 		return nil, err
 	}
 
-	return &changerequest.ChangeRequest{
-		TenantID:             asUUID(row.TenantID),
-		ID:                   asUUID(row.ID),
-		RequestID:            row.RequestID,
-		RequesterID:          asUUID(row.RequesterID),
-		Status:               row.Status,
-		PayloadSchemaVersion: row.PayloadSchemaVersion,
-		Payload:              json.RawMessage(row.Payload),
-		Notes:                row.Notes,
-		CreatedAt:            asTime(row.CreatedAt),
-		UpdatedAt:            asTime(row.UpdatedAt),
-	}, nil
+	return toChangeRequest(row), nil
 }
 
 func (r *ChangeRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*changerequest.ChangeRequest, error) {
@@ -130,18 +150,7 @@ func (r *ChangeRequestRepository) GetByID(ctx context.Context, id uuid.UUID) (*c
 		return nil, err
 	}
 
-	return &changerequest.ChangeRequest{
-		TenantID:             asUUID(row.TenantID),
-		ID:                   asUUID(row.ID),
-		RequestID:            row.RequestID,
-		RequesterID:          asUUID(row.RequesterID),
-		Status:               row.Status,
-		PayloadSchemaVersion: row.PayloadSchemaVersion,
-		Payload:              json.RawMessage(row.Payload),
-		Notes:                row.Notes,
-		CreatedAt:            asTime(row.CreatedAt),
-		UpdatedAt:            asTime(row.UpdatedAt),
-	}, nil
+	return toChangeRequest(row), nil
 }
 
 func (r *ChangeRequestRepository) UpdateDraftByID(ctx context.Context, id uuid.UUID, payload []byte, notes *string) (*changerequest.ChangeRequest, error) {
@@ -165,18 +174,7 @@ func (r *ChangeRequestRepository) UpdateDraftByID(ctx context.Context, id uuid.U
 		return nil, err
 	}
 
-	return &changerequest.ChangeRequest{
-		TenantID:             asUUID(row.TenantID),
-		ID:                   asUUID(row.ID),
-		RequestID:            row.RequestID,
-		RequesterID:          asUUID(row.RequesterID),
-		Status:               row.Status,
-		PayloadSchemaVersion: row.PayloadSchemaVersion,
-		Payload:              json.RawMessage(row.Payload),
-		Notes:                row.Notes,
-		CreatedAt:            asTime(row.CreatedAt),
-		UpdatedAt:            asTime(row.UpdatedAt),
-	}, nil
+	return toChangeRequest(row), nil
 }
 
 func (r *ChangeRequestRepository) UpdateStatusByID(ctx context.Context, id uuid.UUID, status string) (*changerequest.ChangeRequest, error) {
@@ -199,18 +197,127 @@ func (r *ChangeRequestRepository) UpdateStatusByID(ctx context.Context, id uuid.
 		return nil, err
 	}
 
-	return &changerequest.ChangeRequest{
-		TenantID:             asUUID(row.TenantID),
-		ID:                   asUUID(row.ID),
-		RequestID:            row.RequestID,
-		RequesterID:          asUUID(row.RequesterID),
-		Status:               row.Status,
-		PayloadSchemaVersion: row.PayloadSchemaVersion,
-		Payload:              json.RawMessage(row.Payload),
-		Notes:                row.Notes,
-		CreatedAt:            asTime(row.CreatedAt),
-		UpdatedAt:            asTime(row.UpdatedAt),
-	}, nil
+	return toChangeRequest(row), nil
+}
+
+func (r *ChangeRequestRepository) UpdateReviewByID(ctx context.Context, id uuid.UUID, fromStatus, toStatus string, reviewerID uuid.UUID, comment *string) (*changerequest.ChangeRequest, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	row, err := q.UpdateOrgChangeRequestReviewByID(ctx, changerequests_sqlc.UpdateOrgChangeRequestReviewByIDParams{
+		ToStatus:      toStatus,
+		ReviewerID:    pgUUID(reviewerID),
+		ReviewComment: comment,
+		TenantID:      pgUUID(tenantID),
+		ID:            pgUUID(id),
+		FromStatus:    fromStatus,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toChangeRequest(row), nil
+}
+
+func (r *ChangeRequestRepository) MarkAppliedByID(ctx context.Context, id uuid.UUID, appliedBy uuid.UUID) (*changerequest.ChangeRequest, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	row, err := q.MarkOrgChangeRequestAppliedByID(ctx, changerequests_sqlc.MarkOrgChangeRequestAppliedByIDParams{
+		TenantID:  pgUUID(tenantID),
+		ID:        pgUUID(id),
+		AppliedBy: pgUUID(appliedBy),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toChangeRequest(row), nil
+}
+
+func (r *ChangeRequestRepository) ScheduleByID(ctx context.Context, id uuid.UUID, scheduledFor *time.Time) (*changerequest.ChangeRequest, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	row, err := q.ScheduleOrgChangeRequestByID(ctx, changerequests_sqlc.ScheduleOrgChangeRequestByIDParams{
+		ScheduledFor: pgOptionalTimestamptz(scheduledFor),
+		TenantID:     pgUUID(tenantID),
+		ID:           pgUUID(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toChangeRequest(row), nil
+}
+
+func (r *ChangeRequestRepository) RecordExecutionByID(ctx context.Context, id uuid.UUID, result, execErr []byte, auditRequestID *string) (*changerequest.ChangeRequest, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tenantID, err := composables.UseTenantID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	row, err := q.RecordOrgChangeRequestExecutionByID(ctx, changerequests_sqlc.RecordOrgChangeRequestExecutionByIDParams{
+		ExecutionResult: result,
+		ExecutionError:  execErr,
+		AuditRequestID:  auditRequestID,
+		TenantID:        pgUUID(tenantID),
+		ID:              pgUUID(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toChangeRequest(row), nil
+}
+
+// ListDue scans every tenant, so it only needs a transaction in ctx, not a tenant.
+func (r *ChangeRequestRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]changerequest.DueRef, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := changerequests_sqlc.New(tx)
+	rows, err := q.ListDueOrgChangeRequests(ctx, changerequests_sqlc.ListDueOrgChangeRequestsParams{
+		ScheduledFor: pgOptionalTimestamptz(&now),
+		Limit:        int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]changerequest.DueRef, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, changerequest.DueRef{TenantID: asUUID(row.TenantID), ID: asUUID(row.ID)})
+	}
+	return out, nil
 }
 
 func (r *ChangeRequestRepository) List(ctx context.Context, status string, limit int, cursorUpdatedAt *time.Time, cursorID *uuid.UUID) ([]*changerequest.ChangeRequest, error) {
@@ -246,18 +353,7 @@ func (r *ChangeRequestRepository) List(ctx context.Context, status string, limit
 
 	out := make([]*changerequest.ChangeRequest, 0, len(rows))
 	for _, row := range rows {
-		out = append(out, &changerequest.ChangeRequest{
-			TenantID:             asUUID(row.TenantID),
-			ID:                   asUUID(row.ID),
-			RequestID:            row.RequestID,
-			RequesterID:          asUUID(row.RequesterID),
-			Status:               row.Status,
-			PayloadSchemaVersion: row.PayloadSchemaVersion,
-			Payload:              json.RawMessage(row.Payload),
-			Notes:                row.Notes,
-			CreatedAt:            asTime(row.CreatedAt),
-			UpdatedAt:            asTime(row.UpdatedAt),
-		})
+		out = append(out, toChangeRequest(row))
 	}
 	return out, nil
 }
@@ -22,7 +22,7 @@ func (r *OrgRepository) HasChildrenAt(ctx context.Context, tenantID uuid.UUID, n
 SELECT EXISTS(
 	SELECT 1
 	FROM org_edges
-	WHERE tenant_id=$1 AND hierarchy_type='OrgUnit' AND parent_node_id=$2 AND effective_date <= $3 AND end_date >= $3
+	WHERE tenant_id=$1 AND parent_node_id=$2 AND effective_date <= $3 AND end_date >= $3
 )
 `, pgUUID(tenantID), pgUUID(nodeID), pgValidDate(asOf)).Scan(&exists); err != nil {
 		return false, err
@@ -65,6 +65,7 @@ SELECT
 	display_order,
 	parent_hint,
 	manager_user_id,
+	custom_attributes,
 	effective_date,
 	end_date
 FROM org_node_slices
@@ -93,6 +94,7 @@ SELECT
 	display_order,
 	parent_hint,
 	manager_user_id,
+	custom_attributes,
 	effective_date,
 	end_date
 FROM org_node_slices
@@ -116,6 +118,7 @@ func scanNodeSliceRow(row pgRow) (services.NodeSliceRow, error) {
 	var parentHint pgtype.UUID
 	var company pgtype.Text
 	var manager pgtype.Int8
+	var customAttributes []byte
 	if err := row.Scan(
 		&out.ID,
 		&out.Name,
@@ -127,6 +130,7 @@ func scanNodeSliceRow(row pgRow) (services.NodeSliceRow, error) {
 		&out.DisplayOrder,
 		&parentHint,
 		&manager,
+		&customAttributes,
 		&out.EffectiveDate,
 		&out.EndDate,
 	); err != nil {
@@ -140,6 +144,7 @@ func scanNodeSliceRow(row pgRow) (services.NodeSliceRow, error) {
 	out.ParentHint = nullableUUID(parentHint)
 	out.CompanyCode = nullableText(company)
 	out.ManagerUserID = nullableInt8(manager)
+	out.CustomAttributes = decodeCustomAttributes(customAttributes)
 	return out, nil
 }
 
@@ -164,6 +169,11 @@ func (r *OrgRepository) UpdateNodeSliceInPlace(ctx context.Context, tenantID uui
 	setLocation := patch.LocationID != nil
 	setParentHint := patch.ParentHint != nil
 	setManager := patch.ManagerUserID != nil
+	setCustomAttributes := patch.CustomAttributes != nil
+	customAttributes, err := encodeCustomAttributes(patch.CustomAttributes)
+	if err != nil {
+		return err
+	}
 
 	_, err = tx.Exec(ctx, `
 UPDATE org_node_slices
@@ -177,6 +187,7 @@ SET
 	location_id = CASE WHEN $12 THEN $13 ELSE location_id END,
 	parent_hint = CASE WHEN $14 THEN $15 ELSE parent_hint END,
 	manager_user_id = CASE WHEN $16 THEN $17 ELSE manager_user_id END,
+	custom_attributes = CASE WHEN $18 THEN $19::jsonb ELSE custom_attributes END,
 	updated_at = now()
 WHERE tenant_id=$1 AND id=$2
 `, pgUUID(tenantID),
@@ -196,6 +207,8 @@ WHERE tenant_id=$1 AND id=$2
 		pgNullableUUID(derefUUIDPtr(patch.ParentHint)),
 		setManager,
 		pgNullableInt8(derefInt64Ptr(patch.ManagerUserID)),
+		setCustomAttributes,
+		customAttributes,
 	)
 	return err
 }
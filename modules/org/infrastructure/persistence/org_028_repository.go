@@ -21,15 +21,24 @@ func (r *OrgRepository) ListAttributeInheritanceRulesAsOf(ctx context.Context, t
 
 	rows, err := tx.Query(ctx, `
 	SELECT
-		attribute_name,
-		can_override,
-		inheritance_break_node_type
-	FROM org_attribute_inheritance_rules
-	WHERE tenant_id=$1
-		AND hierarchy_type=$2
-		AND effective_date <= $3
-		AND end_date >= $3
-	ORDER BY attribute_name ASC
+		r.attribute_name,
+		r.can_override,
+		r.inheritance_break_node_type,
+		COALESCE((
+			SELECT array_agg(b.org_node_id ORDER BY b.org_node_id)
+			FROM org_attribute_inheritance_breaks b
+			WHERE b.tenant_id=r.tenant_id
+				AND b.hierarchy_type=r.hierarchy_type
+				AND b.attribute_name=r.attribute_name
+				AND b.effective_date <= $3
+				AND b.end_date >= $3
+		), '{}'::uuid[]) AS break_node_ids
+	FROM org_attribute_inheritance_rules r
+	WHERE r.tenant_id=$1
+		AND r.hierarchy_type=$2
+		AND r.effective_date <= $3
+		AND r.end_date >= $3
+	ORDER BY r.attribute_name ASC
 	`, pgUUID(tenantID), hierarchyType, pgValidDate(asOf))
 	if err != nil {
 		return nil, err
@@ -40,7 +49,7 @@ func (r *OrgRepository) ListAttributeInheritanceRulesAsOf(ctx context.Context, t
 	for rows.Next() {
 		var row services.AttributeInheritanceRule
 		var breakType pgtype.Text
-		if err := rows.Scan(&row.AttributeName, &row.CanOverride, &breakType); err != nil {
+		if err := rows.Scan(&row.AttributeName, &row.CanOverride, &breakType, &row.BreakNodeIDs); err != nil {
 			return nil, err
 		}
 		row.InheritanceBreakNodeType = nullableText(breakType)
@@ -69,7 +78,8 @@ func (r *OrgRepository) ListNodeAttributesAsOf(ctx context.Context, tenantID uui
 		legal_entity_id,
 		company_code,
 		location_id,
-		manager_user_id
+		manager_user_id,
+		custom_attributes
 	FROM org_node_slices
 	WHERE tenant_id=$1
 		AND org_node_id = ANY($2)
@@ -88,8 +98,9 @@ func (r *OrgRepository) ListNodeAttributesAsOf(ctx context.Context, tenantID uui
 			company  pgtype.Text
 			location pgtype.UUID
 			manager  pgtype.Int8
+			custom   []byte
 		)
-		if err := rows.Scan(&nodeID, &legal, &company, &location, &manager); err != nil {
+		if err := rows.Scan(&nodeID, &legal, &company, &location, &manager, &custom); err != nil {
 			return nil, err
 		}
 		out[nodeID] = services.OrgNodeAttributes{
@@ -97,6 +108,7 @@ func (r *OrgRepository) ListNodeAttributesAsOf(ctx context.Context, tenantID uui
 			CompanyCode:   nullableText(company),
 			LocationID:    nullableUUID(location),
 			ManagerUserID: nullableInt8(manager),
+			Custom:        decodeCustomAttributes(custom),
 		}
 	}
 	if rows.Err() != nil {
@@ -13,7 +13,7 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 )
 
-func (r *OrgRepository) GetTenantRootNodeID(ctx context.Context, tenantID uuid.UUID) (uuid.UUID, error) {
+func (r *OrgRepository) GetTenantRootNodeID(ctx context.Context, tenantID uuid.UUID, hierarchyType string) (uuid.UUID, error) {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
 		return uuid.Nil, err
@@ -22,9 +22,9 @@ func (r *OrgRepository) GetTenantRootNodeID(ctx context.Context, tenantID uuid.U
 	if err := tx.QueryRow(ctx, `
 SELECT id
 FROM org_nodes
-WHERE tenant_id=$1 AND is_root=true
+WHERE tenant_id=$1 AND type=$2 AND is_root=true
 LIMIT 1
-`, pgUUID(tenantID)).Scan(&id); err != nil {
+`, pgUUID(tenantID), hierarchyType).Scan(&id); err != nil {
 		return uuid.Nil, err
 	}
 	return id, nil
@@ -48,7 +48,8 @@ SELECT
 	n.code,
 	s.name,
 	s.status,
-	e.parent_node_id
+	e.parent_node_id,
+	s.custom_attributes
 FROM org_nodes n
 JOIN org_node_slices s
 	ON s.tenant_id = n.tenant_id
@@ -74,9 +75,11 @@ ORDER BY n.id ASC
 	for rows.Next() {
 		var row services.OrgNodeAsOfRow
 		var parent pgtype.UUID
-		if err := rows.Scan(&row.ID, &row.Code, &row.Name, &row.Status, &parent); err != nil {
+		var customAttributes []byte
+		if err := rows.Scan(&row.ID, &row.Code, &row.Name, &row.Status, &parent, &customAttributes); err != nil {
 			return nil, err
 		}
+		row.CustomAttributes = decodeCustomAttributes(customAttributes)
 		if parent.Valid {
 			p := uuid.UUID(parent.Bytes)
 			row.ParentID = &p
@@ -75,6 +75,10 @@ func (r *OrgRepository) InsertPositionSlice(ctx context.Context, tenantID uuid.U
 	if len(in.Profile) != 0 {
 		profile = string(in.Profile)
 	}
+	customAttributes, err := encodeCustomAttributes(in.CustomAttributes)
+	if err != nil {
+		return uuid.Nil, err
+	}
 	var id uuid.UUID
 	if err := tx.QueryRow(ctx, `
 			INSERT INTO org_position_slices (
@@ -91,10 +95,11 @@ func (r *OrgRepository) InsertPositionSlice(ctx context.Context, tenantID uuid.U
 			job_profile_id,
 				cost_center_code,
 				profile,
+				custom_attributes,
 				effective_date,
 				end_date
 			)
-			VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13::jsonb,$14,$15)
+			VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13::jsonb,$14::jsonb,$15,$16)
 			RETURNING id
 			`,
 		pgUUID(tenantID),
@@ -110,6 +115,7 @@ func (r *OrgRepository) InsertPositionSlice(ctx context.Context, tenantID uuid.U
 		pgUUID(in.JobProfileID),
 		pgNullableText(in.CostCenterCode),
 		profile,
+		customAttributes,
 		pgValidDate(in.EffectiveDate),
 		pgValidDate(in.EndDate),
 	).Scan(&id); err != nil {
@@ -194,6 +200,7 @@ func (r *OrgRepository) GetPositionSliceAt(ctx context.Context, tenantID uuid.UU
 			job_profile_id,
 			cost_center_code,
 			profile,
+			custom_attributes,
 			effective_date,
 			end_date
 	FROM org_position_slices
@@ -210,6 +217,7 @@ func (r *OrgRepository) GetPositionSliceAt(ctx context.Context, tenantID uuid.UU
 	var jobLevelCode pgtype.Text
 	var costCenterCode pgtype.Text
 	var profile []byte
+	var customAttributes []byte
 	if err := row.Scan(
 		&out.ID,
 		&out.PositionID,
@@ -224,6 +232,7 @@ func (r *OrgRepository) GetPositionSliceAt(ctx context.Context, tenantID uuid.UU
 		&out.JobProfileID,
 		&costCenterCode,
 		&profile,
+		&customAttributes,
 		&out.EffectiveDate,
 		&out.EndDate,
 	); err != nil {
@@ -236,6 +245,7 @@ func (r *OrgRepository) GetPositionSliceAt(ctx context.Context, tenantID uuid.UU
 	out.JobLevelCode = nullableText(jobLevelCode)
 	out.CostCenterCode = nullableText(costCenterCode)
 	out.Profile = profile
+	out.CustomAttributes = decodeCustomAttributes(customAttributes)
 	return out, nil
 }
 
@@ -260,6 +270,7 @@ func (r *OrgRepository) LockPositionSliceStartingAt(ctx context.Context, tenantI
 			job_profile_id,
 			cost_center_code,
 			profile,
+			custom_attributes,
 			effective_date,
 			end_date
 	FROM org_position_slices
@@ -276,6 +287,7 @@ func (r *OrgRepository) LockPositionSliceStartingAt(ctx context.Context, tenantI
 	var jobLevelCode pgtype.Text
 	var costCenterCode pgtype.Text
 	var profile []byte
+	var customAttributes []byte
 	if err := row.Scan(
 		&out.ID,
 		&out.PositionID,
@@ -290,6 +302,7 @@ func (r *OrgRepository) LockPositionSliceStartingAt(ctx context.Context, tenantI
 		&out.JobProfileID,
 		&costCenterCode,
 		&profile,
+		&customAttributes,
 		&out.EffectiveDate,
 		&out.EndDate,
 	); err != nil {
@@ -302,6 +315,7 @@ func (r *OrgRepository) LockPositionSliceStartingAt(ctx context.Context, tenantI
 	out.JobLevelCode = nullableText(jobLevelCode)
 	out.CostCenterCode = nullableText(costCenterCode)
 	out.Profile = profile
+	out.CustomAttributes = decodeCustomAttributes(customAttributes)
 	return out, nil
 }
 
@@ -326,6 +340,7 @@ func (r *OrgRepository) LockPositionSliceEndingAt(ctx context.Context, tenantID
 			job_profile_id,
 			cost_center_code,
 			profile,
+			custom_attributes,
 			effective_date,
 			end_date
 	FROM org_position_slices
@@ -342,6 +357,7 @@ func (r *OrgRepository) LockPositionSliceEndingAt(ctx context.Context, tenantID
 	var jobLevelCode pgtype.Text
 	var costCenterCode pgtype.Text
 	var profile []byte
+	var customAttributes []byte
 	if err := row.Scan(
 		&out.ID,
 		&out.PositionID,
@@ -356,6 +372,7 @@ func (r *OrgRepository) LockPositionSliceEndingAt(ctx context.Context, tenantID
 		&out.JobProfileID,
 		&costCenterCode,
 		&profile,
+		&customAttributes,
 		&out.EffectiveDate,
 		&out.EndDate,
 	); err != nil {
@@ -368,6 +385,7 @@ func (r *OrgRepository) LockPositionSliceEndingAt(ctx context.Context, tenantID
 	out.JobLevelCode = nullableText(jobLevelCode)
 	out.CostCenterCode = nullableText(costCenterCode)
 	out.Profile = profile
+	out.CustomAttributes = decodeCustomAttributes(customAttributes)
 	return out, nil
 }
 
@@ -435,6 +453,7 @@ func (r *OrgRepository) ListPositionSlicesTimeline(ctx context.Context, tenantID
 			job_profile_id,
 			cost_center_code,
 			profile,
+			custom_attributes,
 			effective_date,
 			end_date
 	FROM org_position_slices
@@ -456,6 +475,7 @@ func (r *OrgRepository) ListPositionSlicesTimeline(ctx context.Context, tenantID
 		var jobLevelCode pgtype.Text
 		var costCenterCode pgtype.Text
 		var profile []byte
+		var customAttributes []byte
 		if err := rows.Scan(
 			&row.ID,
 			&row.PositionID,
@@ -470,6 +490,7 @@ func (r *OrgRepository) ListPositionSlicesTimeline(ctx context.Context, tenantID
 			&row.JobProfileID,
 			&costCenterCode,
 			&profile,
+			&customAttributes,
 			&row.EffectiveDate,
 			&row.EndDate,
 		); err != nil {
@@ -482,6 +503,7 @@ func (r *OrgRepository) ListPositionSlicesTimeline(ctx context.Context, tenantID
 		row.JobLevelCode = nullableText(jobLevelCode)
 		row.CostCenterCode = nullableText(costCenterCode)
 		row.Profile = profile
+		row.CustomAttributes = decodeCustomAttributes(customAttributes)
 		out = append(out, row)
 	}
 	return out, rows.Err()
@@ -668,6 +690,7 @@ func (r *OrgRepository) GetPositionAsOf(ctx context.Context, tenantID uuid.UUID,
 			s.job_profile_id,
 			s.cost_center_code,
 			s.profile,
+			s.custom_attributes,
 			s.effective_date,
 			s.end_date
 		FROM org_positions p
@@ -716,6 +739,7 @@ func (r *OrgRepository) GetPositionAsOf(ctx context.Context, tenantID uuid.UUID,
 			s.job_profile_id,
 			s.cost_center_code,
 			s.profile,
+			s.custom_attributes,
 			s.effective_date,
 			s.end_date
 		`, pgUUID(tenantID), pgUUID(positionID), pgValidDate(asOf))
@@ -730,6 +754,7 @@ func (r *OrgRepository) GetPositionAsOf(ctx context.Context, tenantID uuid.UUID,
 	var jobLevelCode pgtype.Text
 	var costCenterCode pgtype.Text
 	var profile []byte
+	var customAttributes []byte
 	if err := row.Scan(
 		&out.PositionID,
 		&out.Code,
@@ -749,6 +774,7 @@ func (r *OrgRepository) GetPositionAsOf(ctx context.Context, tenantID uuid.UUID,
 		&out.JobProfileID,
 		&costCenterCode,
 		&profile,
+		&customAttributes,
 		&out.EffectiveDate,
 		&out.EndDate,
 	); err != nil {
@@ -763,6 +789,7 @@ func (r *OrgRepository) GetPositionAsOf(ctx context.Context, tenantID uuid.UUID,
 	out.JobLevelCode = nullableText(jobLevelCode)
 	out.CostCenterCode = nullableText(costCenterCode)
 	out.Profile = profile
+	out.CustomAttributes = decodeCustomAttributes(customAttributes)
 	return out, nil
 }
 
@@ -800,6 +827,7 @@ func (r *OrgRepository) UpdatePositionSliceInPlace(ctx context.Context, tenantID
 	setJobProfileID := patch.JobProfileID != nil
 	setCostCenterCode := patch.CostCenterCode != nil
 	setProfile := patch.Profile != nil
+	setCustomAttributes := patch.CustomAttributes != nil
 
 	profile := "{}"
 	if setProfile {
@@ -808,6 +836,10 @@ func (r *OrgRepository) UpdatePositionSliceInPlace(ctx context.Context, tenantID
 			profile = string(raw)
 		}
 	}
+	customAttributes, err := encodeCustomAttributes(patch.CustomAttributes)
+	if err != nil {
+		return err
+	}
 
 	_, err = tx.Exec(ctx, `
 		UPDATE org_position_slices
@@ -823,6 +855,7 @@ func (r *OrgRepository) UpdatePositionSliceInPlace(ctx context.Context, tenantID
 			job_profile_id = CASE WHEN $19 THEN $20 ELSE job_profile_id END,
 			cost_center_code = CASE WHEN $21 THEN $22 ELSE cost_center_code END,
 			profile = CASE WHEN $23 THEN $24::jsonb ELSE profile END,
+			custom_attributes = CASE WHEN $25 THEN $26::jsonb ELSE custom_attributes END,
 			updated_at = now()
 		WHERE tenant_id=$1 AND id=$2
 		`,
@@ -850,6 +883,8 @@ func (r *OrgRepository) UpdatePositionSliceInPlace(ctx context.Context, tenantID
 		pgNullableText(patch.CostCenterCode),
 		setProfile,
 		profile,
+		setCustomAttributes,
+		customAttributes,
 	)
 	return err
 }
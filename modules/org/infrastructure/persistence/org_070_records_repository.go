@@ -30,6 +30,7 @@ func (r *OrgRepository) ListNodeSlicesTimeline(ctx context.Context, tenantID uui
 		display_order,
 		parent_hint,
 		manager_user_id,
+		custom_attributes,
 		effective_date,
 		end_date
 	FROM org_node_slices
@@ -50,6 +51,7 @@ func (r *OrgRepository) ListNodeSlicesTimeline(ctx context.Context, tenantID uui
 		var parentHint pgtype.UUID
 		var company pgtype.Text
 		var manager pgtype.Int8
+		var customAttributes []byte
 		if err := rows.Scan(
 			&row.ID,
 			&row.Name,
@@ -61,6 +63,7 @@ func (r *OrgRepository) ListNodeSlicesTimeline(ctx context.Context, tenantID uui
 			&row.DisplayOrder,
 			&parentHint,
 			&manager,
+			&customAttributes,
 			&row.EffectiveDate,
 			&row.EndDate,
 		); err != nil {
@@ -76,6 +79,7 @@ func (r *OrgRepository) ListNodeSlicesTimeline(ctx context.Context, tenantID uui
 		row.ParentHint = nullableUUID(parentHint)
 		row.CompanyCode = nullableText(company)
 		row.ManagerUserID = nullableInt8(manager)
+		row.CustomAttributes = decodeCustomAttributes(customAttributes)
 
 		out = append(out, row)
 	}
@@ -0,0 +1,318 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const attributeDefinitionColumns = `
+  id,
+  entity_type,
+  code,
+  label,
+  value_type,
+  options,
+  required,
+  pattern,
+  min_value::float8,
+  max_value::float8,
+  max_length,
+  display_order,
+  effective_date,
+  end_date
+FROM org_attribute_definitions`
+
+func scanAttributeDefinitionRow(row pgx.Row) (services.AttributeDefinitionRow, error) {
+	var out services.AttributeDefinitionRow
+	var options []byte
+	var pattern pgtype.Text
+	var minValue, maxValue pgtype.Float8
+	var maxLength pgtype.Int4
+	if err := row.Scan(
+		&out.ID,
+		&out.EntityType,
+		&out.Code,
+		&out.Label,
+		&out.ValueType,
+		&options,
+		&out.Required,
+		&pattern,
+		&minValue,
+		&maxValue,
+		&maxLength,
+		&out.DisplayOrder,
+		&out.EffectiveDate,
+		&out.EndDate,
+	); err != nil {
+		return services.AttributeDefinitionRow{}, err
+	}
+	if len(options) > 0 {
+		if err := json.Unmarshal(options, &out.Options); err != nil {
+			return services.AttributeDefinitionRow{}, err
+		}
+	}
+	out.Pattern = nullableText(pattern)
+	out.MinValue = nullableFloat8(minValue)
+	out.MaxValue = nullableFloat8(maxValue)
+	if maxLength.Valid {
+		v := int(maxLength.Int32)
+		out.MaxLength = &v
+	}
+	return out, nil
+}
+
+func attributeDefinitionArgs(in services.AttributeDefinitionInsert) ([]any, error) {
+	options := in.Options
+	if options == nil {
+		options = []string{}
+	}
+	optionsJSON, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	maxLength := pgtype.Int4{}
+	if in.MaxLength != nil {
+		maxLength = pgtype.Int4{Int32: int32(*in.MaxLength), Valid: true}
+	}
+	return []any{
+		in.Label,
+		in.ValueType,
+		string(optionsJSON),
+		in.Required,
+		pgNullableText(in.Pattern),
+		pgNullableFloat8(in.MinValue),
+		pgNullableFloat8(in.MaxValue),
+		maxLength,
+		in.DisplayOrder,
+	}, nil
+}
+
+func (r *OrgRepository) ListAttributeDefinitions(ctx context.Context, tenantID uuid.UUID, filter services.AttributeDefinitionListFilter) ([]services.AttributeDefinitionRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `
+SELECT` + attributeDefinitionColumns + `
+WHERE tenant_id = $1`
+	args := []any{pgUUID(tenantID)}
+	i := 2
+	if filter.EntityType != nil {
+		q += "\n  AND entity_type = $" + itoa(i)
+		args = append(args, *filter.EntityType)
+		i++
+	}
+	if filter.AsOf != nil && !filter.AsOf.IsZero() {
+		q += "\n  AND effective_date <= $" + itoa(i) + "\n  AND end_date >= $" + itoa(i)
+		args = append(args, pgValidDate(*filter.AsOf))
+	}
+	q += `
+ORDER BY entity_type, display_order, code, effective_date, id
+`
+
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []services.AttributeDefinitionRow{}
+	for rows.Next() {
+		row, err := scanAttributeDefinitionRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
+
+func (r *OrgRepository) LockAttributeDefinitionAt(ctx context.Context, tenantID uuid.UUID, entityType string, code string, asOf time.Time) (services.AttributeDefinitionRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.AttributeDefinitionRow{}, err
+	}
+	return scanAttributeDefinitionRow(tx.QueryRow(ctx, `
+SELECT`+attributeDefinitionColumns+`
+WHERE tenant_id=$1 AND entity_type=$2 AND code=$3
+  AND effective_date <= $4 AND end_date >= $4
+FOR UPDATE
+`, pgUUID(tenantID), entityType, code, pgValidDate(asOf)))
+}
+
+func (r *OrgRepository) LockAttributeDefinitionByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (services.AttributeDefinitionRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.AttributeDefinitionRow{}, err
+	}
+	return scanAttributeDefinitionRow(tx.QueryRow(ctx, `
+SELECT`+attributeDefinitionColumns+`
+WHERE tenant_id=$1 AND id=$2
+FOR UPDATE
+`, pgUUID(tenantID), pgUUID(id)))
+}
+
+func (r *OrgRepository) NextAttributeDefinitionStart(ctx context.Context, tenantID uuid.UUID, entityType string, code string, after time.Time) (*time.Time, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var next pgtype.Date
+	if err := tx.QueryRow(ctx, `
+SELECT min(effective_date)
+FROM org_attribute_definitions
+WHERE tenant_id=$1 AND entity_type=$2 AND code=$3
+  AND effective_date > $4
+`, pgUUID(tenantID), entityType, code, pgValidDate(after)).Scan(&next); err != nil {
+		return nil, err
+	}
+	if !next.Valid {
+		return nil, nil
+	}
+	t := next.Time.UTC()
+	return &t, nil
+}
+
+func (r *OrgRepository) InsertAttributeDefinition(ctx context.Context, tenantID uuid.UUID, in services.AttributeDefinitionInsert) (uuid.UUID, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	args, err := attributeDefinitionArgs(in)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	args = append([]any{pgUUID(tenantID), in.EntityType, in.Code}, args...)
+	args = append(args, pgValidDate(in.EffectiveDate), pgValidDate(in.EndDate))
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+	INSERT INTO org_attribute_definitions (
+	  tenant_id,
+	  entity_type,
+	  code,
+	  label,
+	  value_type,
+	  options,
+	  required,
+	  pattern,
+	  min_value,
+	  max_value,
+	  max_length,
+	  display_order,
+	  effective_date,
+	  end_date
+	)
+	VALUES ($1,$2,$3,$4,$5,$6::jsonb,$7,$8,$9,$10,$11,$12,$13,$14)
+	RETURNING id
+	`, args...).Scan(&id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (r *OrgRepository) UpdateAttributeDefinition(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, in services.AttributeDefinitionInsert) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	args, err := attributeDefinitionArgs(in)
+	if err != nil {
+		return err
+	}
+	args = append([]any{pgUUID(tenantID), pgUUID(id)}, args...)
+
+	_, err = tx.Exec(ctx, `
+	UPDATE org_attribute_definitions
+	SET
+	  label=$3,
+	  value_type=$4,
+	  options=$5::jsonb,
+	  required=$6,
+	  pattern=$7,
+	  min_value=$8,
+	  max_value=$9,
+	  max_length=$10,
+	  display_order=$11,
+	  updated_at=now()
+	WHERE tenant_id=$1 AND id=$2
+	`, args...)
+	return err
+}
+
+func (r *OrgRepository) UpdateAttributeDefinitionEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE org_attribute_definitions
+	SET end_date=$3, updated_at=now()
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id), pgValidDate(endDate))
+	return err
+}
+
+func (r *OrgRepository) DeleteAttributeDefinition(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	DELETE FROM org_attribute_definitions
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id))
+	return err
+}
+
+// encodeCustomAttributes renders slice custom attribute values for a jsonb column; nil is stored as {}.
+func encodeCustomAttributes(values map[string]any) (string, error) {
+	if len(values) == 0 {
+		return "{}", nil
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// decodeCustomAttributes is the inverse of encodeCustomAttributes; an empty object decodes to nil.
+func decodeCustomAttributes(raw []byte) map[string]any {
+	if len(raw) == 0 {
+		return nil
+	}
+	var out map[string]any
+	if err := json.Unmarshal(raw, &out); err != nil || len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func pgNullableFloat8(v *float64) pgtype.Float8 {
+	if v == nil {
+		return pgtype.Float8{}
+	}
+	return pgtype.Float8{Float64: *v, Valid: true}
+}
+
+func nullableFloat8(v pgtype.Float8) *float64 {
+	if !v.Valid {
+		return nil
+	}
+	f := v.Float64
+	return &f
+}
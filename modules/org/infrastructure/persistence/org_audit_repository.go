@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -22,34 +23,33 @@ func (r *OrgRepository) GetOrgSettings(ctx context.Context, tenantID uuid.UUID)
 	var catalogMode string
 	var restrictionsMode string
 	var reasonCodeMode string
+	var budgetMode string
 	err = tx.QueryRow(ctx, `
 	SELECT
 		freeze_mode,
 		freeze_grace_days,
 		position_catalog_validation_mode,
 		position_restrictions_validation_mode,
-		reason_code_mode
+		reason_code_mode,
+		position_budget_validation_mode
 	FROM org_settings
 	WHERE tenant_id=$1
-	`, pgUUID(tenantID)).Scan(&mode, &graceDays, &catalogMode, &restrictionsMode, &reasonCodeMode)
+	`, pgUUID(tenantID)).Scan(&mode, &graceDays, &catalogMode, &restrictionsMode, &reasonCodeMode, &budgetMode)
 	if err == pgx.ErrNoRows {
-		return services.OrgSettings{
-			FreezeMode:                         "enforce",
-			FreezeGraceDays:                    3,
-			PositionCatalogValidationMode:      "shadow",
-			PositionRestrictionsValidationMode: "shadow",
-			ReasonCodeMode:                     "shadow",
-		}, nil
-	}
-	if err != nil {
+		mode, graceDays = "enforce", 3
+		catalogMode, restrictionsMode, reasonCodeMode = "shadow", "shadow", "shadow"
+		budgetMode = "disabled"
+	} else if err != nil {
 		return services.OrgSettings{}, err
 	}
+
 	return services.OrgSettings{
 		FreezeMode:                         mode,
 		FreezeGraceDays:                    graceDays,
 		PositionCatalogValidationMode:      catalogMode,
 		PositionRestrictionsValidationMode: restrictionsMode,
 		ReasonCodeMode:                     reasonCodeMode,
+		PositionBudgetValidationMode:       budgetMode,
 	}, nil
 }
 
@@ -118,3 +118,110 @@ func (r *OrgRepository) InsertAuditLog(ctx context.Context, tenantID uuid.UUID,
 
 	return id, nil
 }
+
+func (r *OrgRepository) ListAuditLogs(ctx context.Context, tenantID uuid.UUID, filter services.AuditLogFilter) ([]services.AuditLogRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `
+SELECT
+  id,
+  request_id,
+  transaction_time,
+  initiator_id,
+  change_type,
+  entity_type,
+  entity_id,
+  effective_date,
+  end_date,
+  old_values,
+  new_values,
+  meta
+FROM org_audit_logs
+WHERE tenant_id = $1
+`
+	args := []any{pgUUID(tenantID)}
+	i := 2
+
+	if filter.EntityType != nil && *filter.EntityType != "" {
+		q += "\n  AND entity_type = $" + itoa(i)
+		args = append(args, *filter.EntityType)
+		i++
+	}
+	if filter.EntityID != nil && *filter.EntityID != uuid.Nil {
+		q += "\n  AND entity_id = $" + itoa(i)
+		args = append(args, pgUUID(*filter.EntityID))
+		i++
+	}
+	if filter.RequestID != nil && *filter.RequestID != "" {
+		q += "\n  AND request_id = $" + itoa(i)
+		args = append(args, *filter.RequestID)
+		i++
+	}
+	if filter.InitiatorID != nil && *filter.InitiatorID != uuid.Nil {
+		q += "\n  AND initiator_id = $" + itoa(i)
+		args = append(args, pgUUID(*filter.InitiatorID))
+		i++
+	}
+	if filter.ChangeType != nil && *filter.ChangeType != "" {
+		q += "\n  AND change_type = $" + itoa(i)
+		args = append(args, *filter.ChangeType)
+		i++
+	}
+	if filter.From != nil && !filter.From.IsZero() {
+		q += "\n  AND transaction_time >= $" + itoa(i)
+		args = append(args, filter.From.UTC())
+		i++
+	}
+	if filter.To != nil && !filter.To.IsZero() {
+		q += "\n  AND transaction_time < $" + itoa(i)
+		args = append(args, filter.To.UTC())
+		i++
+	}
+	if filter.CursorAt != nil && filter.CursorID != nil && !filter.CursorAt.IsZero() && *filter.CursorID != uuid.Nil {
+		q += "\n  AND (transaction_time, id) < ($" + itoa(i) + ", $" + itoa(i+1) + ")"
+		args = append(args, filter.CursorAt.UTC(), pgUUID(*filter.CursorID))
+		i += 2
+	}
+
+	q += "\nORDER BY transaction_time DESC, id DESC\nLIMIT $" + itoa(i)
+	args = append(args, filter.Limit+1)
+
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]services.AuditLogRow, 0, minInt(filter.Limit+1, 64))
+	for rows.Next() {
+		var row services.AuditLogRow
+		var oldValues, newValues, meta []byte
+		if err := rows.Scan(
+			&row.ID,
+			&row.RequestID,
+			&row.TransactionTime,
+			&row.InitiatorID,
+			&row.ChangeType,
+			&row.EntityType,
+			&row.EntityID,
+			&row.EffectiveDate,
+			&row.EndDate,
+			&oldValues,
+			&newValues,
+			&meta,
+		); err != nil {
+			return nil, err
+		}
+		row.OldValues = json.RawMessage(oldValues)
+		row.NewValues = json.RawMessage(newValues)
+		row.Meta = json.RawMessage(meta)
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
@@ -0,0 +1,83 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// ListOrgChartNodeDetailsAsOf returns, per node, its display order, manager user id and its
+// direct, non-system positions as of the date. A position counts as filled when it has an
+// active primary assignment. Manager names are resolved by the caller.
+func (r *OrgRepository) ListOrgChartNodeDetailsAsOf(ctx context.Context, tenantID uuid.UUID, nodeIDs []uuid.UUID, asOf time.Time) (map[uuid.UUID]services.OrgChartNodeDetails, error) {
+	out := make(map[uuid.UUID]services.OrgChartNodeDetails, len(nodeIDs))
+	if len(nodeIDs) == 0 {
+		return out, nil
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+WITH pos AS (
+	SELECT
+		s.org_node_id,
+		COUNT(*)::int AS positions_total,
+		COUNT(*) FILTER (WHERE EXISTS (
+			SELECT 1
+			FROM org_assignments a
+			WHERE a.tenant_id = p.tenant_id
+				AND a.position_id = p.id
+				AND a.assignment_type = 'primary'
+				AND a.employment_status = 'active'
+				AND a.effective_date <= $3
+				AND a.end_date >= $3
+		))::int AS positions_filled
+	FROM org_positions p
+	JOIN org_position_slices s
+		ON s.tenant_id = p.tenant_id
+		AND s.position_id = p.id
+		AND s.effective_date <= $3
+		AND s.end_date >= $3
+	WHERE p.tenant_id = $1
+		AND p.is_auto_created = false
+		AND s.org_node_id = ANY($2::uuid[])
+		AND s.lifecycle_status IN ('planned', 'active')
+	GROUP BY s.org_node_id
+)
+SELECT
+	ns.org_node_id,
+	ns.display_order,
+	ns.manager_user_id,
+	COALESCE(pos.positions_total, 0),
+	COALESCE(pos.positions_filled, 0)
+FROM org_node_slices ns
+LEFT JOIN pos ON pos.org_node_id = ns.org_node_id
+WHERE ns.tenant_id = $1
+	AND ns.org_node_id = ANY($2::uuid[])
+	AND ns.effective_date <= $3
+	AND ns.end_date >= $3
+`, pgUUID(tenantID), pgUUIDArray(nodeIDs), pgValidDate(asOf))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var nodeID uuid.UUID
+		var d services.OrgChartNodeDetails
+		if err := rows.Scan(&nodeID, &d.DisplayOrder, &d.ManagerUserID, &d.PositionsTotal, &d.PositionsFilled); err != nil {
+			return nil, err
+		}
+		out[nodeID] = d
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
@@ -14,13 +14,13 @@ import (
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 )
 
-func (r *OrgRepository) HasRoot(ctx context.Context, tenantID uuid.UUID) (bool, error) {
+func (r *OrgRepository) HasRoot(ctx context.Context, tenantID uuid.UUID, hierarchyType string) (bool, error) {
 	tx, err := composables.UseTx(ctx)
 	if err != nil {
 		return false, err
 	}
 	var exists bool
-	if err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM org_nodes WHERE tenant_id=$1 AND is_root=true)`, pgUUID(tenantID)).Scan(&exists); err != nil {
+	if err := tx.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM org_nodes WHERE tenant_id=$1 AND type=$2 AND is_root=true)`, pgUUID(tenantID), hierarchyType).Scan(&exists); err != nil {
 		return false, err
 	}
 	return exists, nil
@@ -57,6 +57,10 @@ func (r *OrgRepository) InsertNodeSlice(ctx context.Context, tenantID uuid.UUID,
 		}
 		i18nNames = string(b)
 	}
+	customAttributes, err := encodeCustomAttributes(slice.CustomAttributes)
+	if err != nil {
+		return uuid.Nil, err
+	}
 
 	var id uuid.UUID
 	if err := tx.QueryRow(ctx, `
@@ -72,10 +76,11 @@ func (r *OrgRepository) InsertNodeSlice(ctx context.Context, tenantID uuid.UUID,
 	display_order,
 		parent_hint,
 		manager_user_id,
+		custom_attributes,
 		effective_date,
 		end_date
 	)
-	VALUES ($1,$2,$3,$4::jsonb,$5,$6,$7,$8,$9,$10,$11,$12,$13)
+	VALUES ($1,$2,$3,$4::jsonb,$5,$6,$7,$8,$9,$10,$11,$12::jsonb,$13,$14)
 	RETURNING id
 		`,
 		pgUUID(tenantID),
@@ -89,6 +94,7 @@ func (r *OrgRepository) InsertNodeSlice(ctx context.Context, tenantID uuid.UUID,
 		slice.DisplayOrder,
 		pgNullableUUID(slice.ParentHint),
 		pgNullableInt8(slice.ManagerUserID),
+		customAttributes,
 		pgValidDate(slice.EffectiveDate),
 		pgValidDate(slice.EndDate),
 	).Scan(&id); err != nil {
@@ -177,6 +183,7 @@ func (r *OrgRepository) GetNodeSliceAt(ctx context.Context, tenantID uuid.UUID,
 		display_order,
 		parent_hint,
 		manager_user_id,
+		custom_attributes,
 		effective_date,
 		end_date
 	FROM org_node_slices
@@ -192,6 +199,7 @@ func (r *OrgRepository) GetNodeSliceAt(ctx context.Context, tenantID uuid.UUID,
 	var parentHint pgtype.UUID
 	var company pgtype.Text
 	var manager pgtype.Int8
+	var customAttributes []byte
 	if err := row.Scan(
 		&out.ID,
 		&out.Name,
@@ -203,6 +211,7 @@ func (r *OrgRepository) GetNodeSliceAt(ctx context.Context, tenantID uuid.UUID,
 		&out.DisplayOrder,
 		&parentHint,
 		&manager,
+		&customAttributes,
 		&out.EffectiveDate,
 		&out.EndDate,
 	); err != nil {
@@ -218,6 +227,7 @@ func (r *OrgRepository) GetNodeSliceAt(ctx context.Context, tenantID uuid.UUID,
 	out.ParentHint = nullableUUID(parentHint)
 	out.CompanyCode = nullableText(company)
 	out.ManagerUserID = nullableInt8(manager)
+	out.CustomAttributes = decodeCustomAttributes(customAttributes)
 
 	return out, nil
 }
@@ -240,6 +250,7 @@ SELECT
 	display_order,
 	parent_hint,
 	manager_user_id,
+	custom_attributes,
 	effective_date,
 	end_date
 FROM org_node_slices
@@ -256,6 +267,7 @@ FOR UPDATE
 	var parentHint pgtype.UUID
 	var company pgtype.Text
 	var manager pgtype.Int8
+	var customAttributes []byte
 	if err := row.Scan(
 		&out.ID,
 		&out.Name,
@@ -267,6 +279,7 @@ FOR UPDATE
 		&out.DisplayOrder,
 		&parentHint,
 		&manager,
+		&customAttributes,
 		&out.EffectiveDate,
 		&out.EndDate,
 	); err != nil {
@@ -282,6 +295,7 @@ FOR UPDATE
 	out.ParentHint = nullableUUID(parentHint)
 	out.CompanyCode = nullableText(company)
 	out.ManagerUserID = nullableInt8(manager)
+	out.CustomAttributes = decodeCustomAttributes(customAttributes)
 
 	return out, nil
 }
@@ -567,6 +581,7 @@ func (r *OrgRepository) LockPositionSliceAt(ctx context.Context, tenantID uuid.U
 		job_profile_id,
 		cost_center_code,
 		profile,
+		custom_attributes,
 		effective_date,
 		end_date
 	FROM org_position_slices
@@ -584,6 +599,7 @@ func (r *OrgRepository) LockPositionSliceAt(ctx context.Context, tenantID uuid.U
 	var jobLevelCode pgtype.Text
 	var costCenterCode pgtype.Text
 	var profile []byte
+	var customAttributes []byte
 	if err := row.Scan(
 		&out.ID,
 		&out.PositionID,
@@ -598,6 +614,7 @@ func (r *OrgRepository) LockPositionSliceAt(ctx context.Context, tenantID uuid.U
 		&out.JobProfileID,
 		&costCenterCode,
 		&profile,
+		&customAttributes,
 		&out.EffectiveDate,
 		&out.EndDate,
 	); err != nil {
@@ -610,6 +627,7 @@ func (r *OrgRepository) LockPositionSliceAt(ctx context.Context, tenantID uuid.U
 	out.JobLevelCode = nullableText(jobLevelCode)
 	out.CostCenterCode = nullableText(costCenterCode)
 	out.Profile = profile
+	out.CustomAttributes = decodeCustomAttributes(customAttributes)
 	return out, nil
 }
 
@@ -817,7 +835,7 @@ func (r *OrgRepository) ListAssignmentsTimeline(ctx context.Context, tenantID uu
 					FROM org_personnel_events e
 					WHERE e.tenant_id=a.tenant_id
 				AND e.person_uuid=a.subject_id
-				AND e.event_type IN ('hire', 'transfer')
+				AND e.event_type <> 'termination'
 				AND e.effective_date = a.effective_date
 			ORDER BY e.created_at DESC
 			LIMIT 1
@@ -996,7 +1014,7 @@ func (r *OrgRepository) ListAssignmentsAsOf(ctx context.Context, tenantID uuid.U
 					FROM org_personnel_events e
 					WHERE e.tenant_id=a.tenant_id
 				AND e.person_uuid=a.subject_id
-				AND e.event_type IN ('hire', 'transfer')
+				AND e.event_type <> 'termination'
 				AND e.effective_date = a.effective_date
 			ORDER BY e.created_at DESC
 			LIMIT 1
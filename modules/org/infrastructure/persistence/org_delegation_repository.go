@@ -0,0 +1,198 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const delegationColumns = `
+  d.id,
+  d.delegation_type,
+  d.org_node_id,
+  d.role_id,
+  r.code,
+  d.delegator_subject_id,
+  d.delegate_user_id,
+  d.delegate_subject_id,
+  d.effective_date,
+  d.end_date,
+  d.reason_note
+FROM org_delegations d
+LEFT JOIN org_roles r
+  ON r.tenant_id=d.tenant_id
+  AND r.id=d.role_id`
+
+func scanDelegationRow(row pgx.Row) (services.DelegationRow, error) {
+	var out services.DelegationRow
+	var roleID, delegatorSubjectID pgtype.UUID
+	var roleCode, reasonNote pgtype.Text
+	if err := row.Scan(
+		&out.ID,
+		&out.DelegationType,
+		&out.OrgNodeID,
+		&roleID,
+		&roleCode,
+		&delegatorSubjectID,
+		&out.DelegateUserID,
+		&out.DelegateSubjectID,
+		&out.EffectiveDate,
+		&out.EndDate,
+		&reasonNote,
+	); err != nil {
+		return services.DelegationRow{}, err
+	}
+	out.RoleID = nullableUUID(roleID)
+	out.RoleCode = nullableText(roleCode)
+	out.DelegatorSubjectID = nullableUUID(delegatorSubjectID)
+	out.ReasonNote = nullableText(reasonNote)
+	return out, nil
+}
+
+func collectDelegationRows(rows pgx.Rows) ([]services.DelegationRow, error) {
+	defer rows.Close()
+	out := []services.DelegationRow{}
+	for rows.Next() {
+		row, err := scanDelegationRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
+
+func (r *OrgRepository) InsertDelegation(ctx context.Context, tenantID uuid.UUID, in services.DelegationInsert) (uuid.UUID, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+	INSERT INTO org_delegations (
+	  tenant_id,
+	  delegation_type,
+	  org_node_id,
+	  role_id,
+	  delegator_subject_id,
+	  delegate_user_id,
+	  delegate_subject_id,
+	  effective_date,
+	  end_date,
+	  reason_note
+	)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+	RETURNING id
+	`,
+		pgUUID(tenantID),
+		in.DelegationType,
+		pgUUID(in.OrgNodeID),
+		pgNullableUUID(in.RoleID),
+		pgNullableUUID(in.DelegatorSubjectID),
+		in.DelegateUserID,
+		pgUUID(in.DelegateSubjectID),
+		pgValidDate(in.EffectiveDate),
+		pgValidDate(in.EndDate),
+		pgNullableText(in.ReasonNote),
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (r *OrgRepository) LockDelegationByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (services.DelegationRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.DelegationRow{}, err
+	}
+	return scanDelegationRow(tx.QueryRow(ctx, `
+SELECT`+delegationColumns+`
+WHERE d.tenant_id=$1 AND d.id=$2
+FOR UPDATE OF d
+`, pgUUID(tenantID), pgUUID(id)))
+}
+
+func (r *OrgRepository) UpdateDelegationEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE org_delegations
+	SET end_date=$3, updated_at=now()
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id), pgValidDate(endDate))
+	return err
+}
+
+func (r *OrgRepository) ListDelegations(ctx context.Context, tenantID uuid.UUID, filter services.DelegationListFilter) ([]services.DelegationRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `
+SELECT` + delegationColumns + `
+WHERE d.tenant_id = $1
+`
+	args := []any{pgUUID(tenantID)}
+	i := 2
+
+	if filter.OrgNodeID != nil && *filter.OrgNodeID != uuid.Nil {
+		q += "\n  AND d.org_node_id = $" + itoa(i)
+		args = append(args, pgUUID(*filter.OrgNodeID))
+		i++
+	}
+	if filter.DelegateUserID != nil {
+		q += "\n  AND d.delegate_user_id = $" + itoa(i)
+		args = append(args, *filter.DelegateUserID)
+		i++
+	}
+	if filter.AsOf != nil && !filter.AsOf.IsZero() {
+		q += "\n  AND d.effective_date <= $" + itoa(i) + "\n  AND d.end_date >= $" + itoa(i)
+		args = append(args, pgValidDate(*filter.AsOf))
+		i++
+	}
+
+	q += "\nORDER BY d.effective_date DESC, d.id\nLIMIT $" + itoa(i)
+	args = append(args, filter.Limit)
+
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	return collectDelegationRows(rows)
+}
+
+func (r *OrgRepository) ListDelegationsForNodesAsOf(ctx context.Context, tenantID uuid.UUID, orgNodeIDs []uuid.UUID, asOf time.Time) ([]services.DelegationRow, error) {
+	if len(orgNodeIDs) == 0 {
+		return []services.DelegationRow{}, nil
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, `
+SELECT`+delegationColumns+`
+WHERE d.tenant_id=$1
+  AND d.org_node_id = ANY($2::uuid[])
+  AND d.effective_date <= $3
+  AND d.end_date >= $3
+ORDER BY d.org_node_id, d.delegation_type, d.id
+`, pgUUID(tenantID), pgUUIDArray(orgNodeIDs), pgValidDate(asOf))
+	if err != nil {
+		return nil, err
+	}
+	return collectDelegationRows(rows)
+}
@@ -0,0 +1,114 @@
+package persistence
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const freezePeriodColumns = `
+  id,
+  label,
+  period_start,
+  period_end,
+  locks_at
+FROM org_freeze_periods`
+
+func scanFreezePeriod(row pgx.Row) (services.FreezePeriod, error) {
+	var out services.FreezePeriod
+	if err := row.Scan(&out.ID, &out.Label, &out.PeriodStart, &out.PeriodEnd, &out.LocksAt); err != nil {
+		return services.FreezePeriod{}, err
+	}
+	out.PeriodStart = out.PeriodStart.UTC()
+	out.PeriodEnd = out.PeriodEnd.UTC()
+	out.LocksAt = out.LocksAt.UTC()
+	return out, nil
+}
+
+func (r *OrgRepository) ListFreezePeriods(ctx context.Context, tenantID uuid.UUID) ([]services.FreezePeriod, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+SELECT`+freezePeriodColumns+`
+WHERE tenant_id = $1
+ORDER BY period_start, id
+`, pgUUID(tenantID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []services.FreezePeriod{}
+	for rows.Next() {
+		p, err := scanFreezePeriod(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
+
+func (r *OrgRepository) LockFreezePeriodByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (services.FreezePeriod, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.FreezePeriod{}, err
+	}
+	return scanFreezePeriod(tx.QueryRow(ctx, `
+SELECT`+freezePeriodColumns+`
+WHERE tenant_id=$1 AND id=$2
+FOR UPDATE
+`, pgUUID(tenantID), pgUUID(id)))
+}
+
+func (r *OrgRepository) InsertFreezePeriod(ctx context.Context, tenantID uuid.UUID, in services.FreezePeriod) (uuid.UUID, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+	INSERT INTO org_freeze_periods (tenant_id, label, period_start, period_end, locks_at)
+	VALUES ($1,$2,$3,$4,$5)
+	RETURNING id
+	`, pgUUID(tenantID), in.Label, pgValidDate(in.PeriodStart), pgValidDate(in.PeriodEnd), in.LocksAt.UTC()).Scan(&id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (r *OrgRepository) UpdateFreezePeriod(ctx context.Context, tenantID uuid.UUID, in services.FreezePeriod) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE org_freeze_periods
+	SET label=$3, period_start=$4, period_end=$5, locks_at=$6, updated_at=now()
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(in.ID), in.Label, pgValidDate(in.PeriodStart), pgValidDate(in.PeriodEnd), in.LocksAt.UTC())
+	return err
+}
+
+func (r *OrgRepository) DeleteFreezePeriod(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	DELETE FROM org_freeze_periods
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id))
+	return err
+}
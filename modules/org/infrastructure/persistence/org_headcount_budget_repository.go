@@ -0,0 +1,339 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const headcountBudgetColumns = `
+  id,
+  org_node_id,
+  job_profile_id,
+  job_level_code,
+  planned_headcount,
+  planned_fte::float8,
+  planned_cost::float8,
+  effective_date,
+  end_date,
+  created_at,
+  updated_at`
+
+func scanHeadcountBudget(row interface{ Scan(dest ...any) error }) (services.HeadcountBudgetRow, error) {
+	var out services.HeadcountBudgetRow
+	var jobProfileID pgtype.UUID
+	var jobLevelCode pgtype.Text
+	err := row.Scan(
+		&out.ID,
+		&out.OrgNodeID,
+		&jobProfileID,
+		&jobLevelCode,
+		&out.PlannedHeadcount,
+		&out.PlannedFTE,
+		&out.PlannedCost,
+		&out.EffectiveDate,
+		&out.EndDate,
+		&out.CreatedAt,
+		&out.UpdatedAt,
+	)
+	out.JobProfileID = nullableUUID(jobProfileID)
+	out.JobLevelCode = nullableText(jobLevelCode)
+	return out, err
+}
+
+func scanHeadcountBudgets(rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}) ([]services.HeadcountBudgetRow, error) {
+	out := make([]services.HeadcountBudgetRow, 0, 16)
+	for rows.Next() {
+		row, err := scanHeadcountBudget(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
+
+func (r *OrgRepository) InsertHeadcountBudget(ctx context.Context, tenantID uuid.UUID, in services.HeadcountBudgetInsert) (uuid.UUID, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+	INSERT INTO org_headcount_budgets (
+	  tenant_id,
+	  org_node_id,
+	  job_profile_id,
+	  job_level_code,
+	  planned_headcount,
+	  planned_fte,
+	  planned_cost,
+	  effective_date,
+	  end_date
+	)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)
+	RETURNING id
+	`, pgUUID(tenantID), pgUUID(in.OrgNodeID), pgNullableUUID(in.JobProfileID), pgNullableText(in.JobLevelCode), in.PlannedHeadcount, in.PlannedFTE, in.PlannedCost, pgValidDate(in.EffectiveDate), pgValidDate(in.EndDate)).Scan(&id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (r *OrgRepository) LockHeadcountBudgetAt(ctx context.Context, tenantID uuid.UUID, orgNodeID uuid.UUID, jobProfileID *uuid.UUID, jobLevelCode *string, asOf time.Time) (services.HeadcountBudgetRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.HeadcountBudgetRow{}, err
+	}
+	return scanHeadcountBudget(tx.QueryRow(ctx, `
+SELECT`+headcountBudgetColumns+`
+FROM org_headcount_budgets
+WHERE tenant_id=$1 AND org_node_id=$2
+  AND job_profile_id IS NOT DISTINCT FROM $3 AND job_level_code IS NOT DISTINCT FROM $4
+  AND effective_date <= $5 AND end_date >= $5
+FOR UPDATE
+`, pgUUID(tenantID), pgUUID(orgNodeID), pgNullableUUID(jobProfileID), pgNullableText(jobLevelCode), pgValidDate(asOf)))
+}
+
+func (r *OrgRepository) LockHeadcountBudgetByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (services.HeadcountBudgetRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.HeadcountBudgetRow{}, err
+	}
+	return scanHeadcountBudget(tx.QueryRow(ctx, `
+SELECT`+headcountBudgetColumns+`
+FROM org_headcount_budgets
+WHERE tenant_id=$1 AND id=$2
+FOR UPDATE
+`, pgUUID(tenantID), pgUUID(id)))
+}
+
+func (r *OrgRepository) LockHeadcountBudgetsAt(ctx context.Context, tenantID uuid.UUID, orgNodeID uuid.UUID, asOf time.Time) ([]services.HeadcountBudgetRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, `
+SELECT`+headcountBudgetColumns+`
+FROM org_headcount_budgets
+WHERE tenant_id=$1 AND org_node_id=$2 AND effective_date <= $3 AND end_date >= $3
+ORDER BY id
+FOR UPDATE
+`, pgUUID(tenantID), pgUUID(orgNodeID), pgValidDate(asOf))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanHeadcountBudgets(rows)
+}
+
+func (r *OrgRepository) NextHeadcountBudgetStart(ctx context.Context, tenantID uuid.UUID, orgNodeID uuid.UUID, jobProfileID *uuid.UUID, jobLevelCode *string, after time.Time) (*time.Time, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var next pgtype.Date
+	if err := tx.QueryRow(ctx, `
+SELECT min(effective_date)
+FROM org_headcount_budgets
+WHERE tenant_id=$1 AND org_node_id=$2
+  AND job_profile_id IS NOT DISTINCT FROM $3 AND job_level_code IS NOT DISTINCT FROM $4
+  AND effective_date > $5
+`, pgUUID(tenantID), pgUUID(orgNodeID), pgNullableUUID(jobProfileID), pgNullableText(jobLevelCode), pgValidDate(after)).Scan(&next); err != nil {
+		return nil, err
+	}
+	if !next.Valid {
+		return nil, nil
+	}
+	t := next.Time.UTC()
+	return &t, nil
+}
+
+func (r *OrgRepository) UpdateHeadcountBudgetValues(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, values services.HeadcountBudgetValues) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+UPDATE org_headcount_budgets
+SET planned_headcount=$3, planned_fte=$4, planned_cost=$5, updated_at=now()
+WHERE tenant_id=$1 AND id=$2
+`, pgUUID(tenantID), pgUUID(id), values.PlannedHeadcount, values.PlannedFTE, values.PlannedCost)
+	return err
+}
+
+func (r *OrgRepository) UpdateHeadcountBudgetEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+UPDATE org_headcount_budgets
+SET end_date=$3, updated_at=now()
+WHERE tenant_id=$1 AND id=$2
+`, pgUUID(tenantID), pgUUID(id), pgValidDate(endDate))
+	return err
+}
+
+func (r *OrgRepository) ListHeadcountBudgets(ctx context.Context, tenantID uuid.UUID, filter services.HeadcountBudgetListFilter) ([]services.HeadcountBudgetRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `
+SELECT` + headcountBudgetColumns + `
+FROM org_headcount_budgets
+WHERE tenant_id = $1
+`
+	args := []any{pgUUID(tenantID)}
+	i := 2
+
+	if filter.ID != nil && *filter.ID != uuid.Nil {
+		q += "\n  AND id = $" + itoa(i)
+		args = append(args, pgUUID(*filter.ID))
+		i++
+	}
+	if filter.OrgNodeID != nil && *filter.OrgNodeID != uuid.Nil {
+		q += "\n  AND org_node_id = $" + itoa(i)
+		args = append(args, pgUUID(*filter.OrgNodeID))
+		i++
+	}
+	if filter.AsOf != nil && !filter.AsOf.IsZero() {
+		q += "\n  AND effective_date <= $" + itoa(i) + "\n  AND end_date >= $" + itoa(i)
+		args = append(args, pgValidDate(*filter.AsOf))
+		i++
+	}
+
+	q += "\nORDER BY org_node_id ASC, job_profile_id ASC NULLS FIRST, job_level_code ASC NULLS FIRST, effective_date ASC\nLIMIT $" + itoa(i)
+	args = append(args, filter.Limit)
+
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanHeadcountBudgets(rows)
+}
+
+func (r *OrgRepository) ListHeadcountBudgetsAsOf(ctx context.Context, tenantID uuid.UUID, asOf time.Time, orgNodeIDs []uuid.UUID) ([]services.HeadcountBudgetRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(orgNodeIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := tx.Query(ctx, `
+SELECT`+headcountBudgetColumns+`
+FROM org_headcount_budgets
+WHERE tenant_id=$1 AND org_node_id = ANY($2::uuid[]) AND effective_date <= $3 AND end_date >= $3
+ORDER BY org_node_id ASC, job_profile_id ASC NULLS FIRST, job_level_code ASC NULLS FIRST
+`, pgUUID(tenantID), orgNodeIDs, pgValidDate(asOf))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanHeadcountBudgets(rows)
+}
+
+// ListHeadcountBudgetActualsAsOf groups positions per node and job profile/level with the
+// occupancy rules of GetStaffingSummaryReport.
+func (r *OrgRepository) ListHeadcountBudgetActualsAsOf(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	asOf time.Time,
+	orgNodeIDs []uuid.UUID,
+	lifecycleStatuses []string,
+	includeSystem bool,
+) ([]services.HeadcountBudgetActualRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(orgNodeIDs) == 0 {
+		return nil, nil
+	}
+
+	rows, err := tx.Query(ctx, `
+WITH pos AS (
+	SELECT
+		p.id AS position_id,
+		s.org_node_id,
+		s.job_profile_id,
+		s.job_level_code,
+		s.capacity_fte::float8 AS capacity_fte,
+		COALESCE(SUM(a.allocated_fte), 0)::float8 AS occupied_fte
+	FROM org_positions p
+	JOIN org_position_slices s
+		ON s.tenant_id = p.tenant_id
+		AND s.position_id = p.id
+		AND s.effective_date <= $2
+		AND s.end_date >= $2
+		LEFT JOIN org_assignments a
+			ON a.tenant_id = p.tenant_id
+			AND a.position_id = p.id
+			AND a.assignment_type = 'primary'
+			AND a.employment_status = 'active'
+			AND a.effective_date <= $2
+			AND a.end_date >= $2
+	WHERE p.tenant_id = $1
+		AND s.org_node_id = ANY($3::uuid[])
+		AND s.lifecycle_status = ANY($4::text[])
+		AND ($5 OR p.is_auto_created = false)
+	GROUP BY p.id, s.org_node_id, s.job_profile_id, s.job_level_code, s.capacity_fte
+)
+SELECT
+	org_node_id,
+	job_profile_id,
+	job_level_code,
+	COUNT(*)::int AS positions,
+	COUNT(*) FILTER (WHERE occupied_fte > 0)::int AS filled,
+	COALESCE(SUM(capacity_fte), 0)::float8 AS capacity_fte,
+	COALESCE(SUM(occupied_fte), 0)::float8 AS occupied_fte,
+	COALESCE(SUM(GREATEST(capacity_fte - occupied_fte, 0)), 0)::float8 AS available_fte
+FROM pos
+GROUP BY org_node_id, job_profile_id, job_level_code
+`, pgUUID(tenantID), pgValidDate(asOf), orgNodeIDs, lifecycleStatuses, includeSystem)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]services.HeadcountBudgetActualRow, 0, minInt(len(orgNodeIDs), 64))
+	for rows.Next() {
+		var row services.HeadcountBudgetActualRow
+		var jobLevelCode pgtype.Text
+		if err := rows.Scan(
+			&row.OrgNodeID,
+			&row.JobProfileID,
+			&jobLevelCode,
+			&row.Positions,
+			&row.Filled,
+			&row.CapacityFTE,
+			&row.OccupiedFTE,
+			&row.AvailableFTE,
+		); err != nil {
+			return nil, err
+		}
+		row.JobLevelCode = nullableText(jobLevelCode)
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
@@ -0,0 +1,97 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func (r *OrgRepository) ListPositionStatesAsOf(ctx context.Context, tenantID uuid.UUID, asOf time.Time) ([]services.PositionStateRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+SELECT
+	p.id,
+	p.code,
+	s.title,
+	s.org_node_id,
+	s.lifecycle_status,
+	s.capacity_fte::float8
+FROM org_positions p
+JOIN org_position_slices s
+	ON s.tenant_id = p.tenant_id
+	AND s.position_id = p.id
+	AND s.effective_date <= $2
+	AND s.end_date >= $2
+WHERE p.tenant_id = $1
+ORDER BY p.code ASC, p.id ASC
+`, pgUUID(tenantID), pgValidDate(asOf))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]services.PositionStateRow, 0, 64)
+	for rows.Next() {
+		var row services.PositionStateRow
+		if err := rows.Scan(&row.PositionID, &row.Code, &row.Title, &row.OrgNodeID, &row.LifecycleStatus, &row.CapacityFTE); err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+func (r *OrgRepository) ListAssignmentStatesAsOf(ctx context.Context, tenantID uuid.UUID, asOf time.Time) ([]services.AssignmentStateRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+SELECT
+	a.id,
+	a.position_id,
+	p.code,
+	s.org_node_id,
+	a.subject_id,
+	a.pernr,
+	a.assignment_type,
+	a.allocated_fte::float8
+FROM org_assignments a
+JOIN org_positions p
+	ON p.tenant_id = a.tenant_id
+	AND p.id = a.position_id
+JOIN org_position_slices s
+	ON s.tenant_id = a.tenant_id
+	AND s.position_id = a.position_id
+	AND s.effective_date <= $2
+	AND s.end_date >= $2
+WHERE a.tenant_id = $1
+	AND a.effective_date <= $2
+	AND a.end_date >= $2
+	AND a.employment_status = 'active'
+ORDER BY a.pernr ASC, a.id ASC
+`, pgUUID(tenantID), pgValidDate(asOf))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]services.AssignmentStateRow, 0, 64)
+	for rows.Next() {
+		var row services.AssignmentStateRow
+		if err := rows.Scan(&row.AssignmentID, &row.PositionID, &row.PositionCode, &row.OrgNodeID, &row.SubjectID, &row.Pernr, &row.AssignmentType, &row.AllocatedFTE); err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
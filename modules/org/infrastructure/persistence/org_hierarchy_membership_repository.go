@@ -0,0 +1,301 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const hierarchyMembershipColumns = `
+  id,
+  subject_type,
+  subject_id,
+  hierarchy_type,
+  org_node_id,
+  effective_date,
+  end_date,
+  created_at,
+  updated_at`
+
+func scanHierarchyMembership(row interface{ Scan(dest ...any) error }) (services.HierarchyMembershipRow, error) {
+	var out services.HierarchyMembershipRow
+	err := row.Scan(
+		&out.ID,
+		&out.SubjectType,
+		&out.SubjectID,
+		&out.HierarchyType,
+		&out.OrgNodeID,
+		&out.EffectiveDate,
+		&out.EndDate,
+		&out.CreatedAt,
+		&out.UpdatedAt,
+	)
+	return out, err
+}
+
+func (r *OrgRepository) HierarchyMembershipSubjectExistsAt(ctx context.Context, tenantID uuid.UUID, subjectType string, subjectID uuid.UUID, asOf time.Time) (bool, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	table := "org_positions"
+	if subjectType == services.HierarchyMembershipSubjectAssignment {
+		table = "org_assignments"
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx, `
+SELECT EXISTS(
+	SELECT 1
+	FROM `+table+`
+	WHERE tenant_id=$1 AND id=$2 AND effective_date <= $3 AND end_date >= $3
+)
+`, pgUUID(tenantID), pgUUID(subjectID), pgValidDate(asOf)).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
+
+func (r *OrgRepository) InsertHierarchyMembership(ctx context.Context, tenantID uuid.UUID, in services.HierarchyMembershipInsert) (uuid.UUID, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+	INSERT INTO org_hierarchy_memberships (
+	  tenant_id,
+	  subject_type,
+	  subject_id,
+	  hierarchy_type,
+	  org_node_id,
+	  effective_date,
+	  end_date
+	)
+	VALUES ($1,$2,$3,$4,$5,$6,$7)
+	RETURNING id
+	`, pgUUID(tenantID), in.SubjectType, pgUUID(in.SubjectID), in.HierarchyType, pgUUID(in.OrgNodeID), pgValidDate(in.EffectiveDate), pgValidDate(in.EndDate)).Scan(&id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (r *OrgRepository) LockHierarchyMembershipAt(ctx context.Context, tenantID uuid.UUID, subjectType string, subjectID uuid.UUID, hierarchyType string, asOf time.Time) (services.HierarchyMembershipRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.HierarchyMembershipRow{}, err
+	}
+	return scanHierarchyMembership(tx.QueryRow(ctx, `
+SELECT`+hierarchyMembershipColumns+`
+FROM org_hierarchy_memberships
+WHERE tenant_id=$1 AND subject_type=$2 AND subject_id=$3 AND hierarchy_type=$4
+  AND effective_date <= $5 AND end_date >= $5
+FOR UPDATE
+`, pgUUID(tenantID), subjectType, pgUUID(subjectID), hierarchyType, pgValidDate(asOf)))
+}
+
+func (r *OrgRepository) LockHierarchyMembershipByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (services.HierarchyMembershipRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.HierarchyMembershipRow{}, err
+	}
+	return scanHierarchyMembership(tx.QueryRow(ctx, `
+SELECT`+hierarchyMembershipColumns+`
+FROM org_hierarchy_memberships
+WHERE tenant_id=$1 AND id=$2
+FOR UPDATE
+`, pgUUID(tenantID), pgUUID(id)))
+}
+
+func (r *OrgRepository) NextHierarchyMembershipStart(ctx context.Context, tenantID uuid.UUID, subjectType string, subjectID uuid.UUID, hierarchyType string, after time.Time) (*time.Time, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var next pgtype.Date
+	if err := tx.QueryRow(ctx, `
+SELECT min(effective_date)
+FROM org_hierarchy_memberships
+WHERE tenant_id=$1 AND subject_type=$2 AND subject_id=$3 AND hierarchy_type=$4 AND effective_date > $5
+`, pgUUID(tenantID), subjectType, pgUUID(subjectID), hierarchyType, pgValidDate(after)).Scan(&next); err != nil {
+		return nil, err
+	}
+	if !next.Valid {
+		return nil, nil
+	}
+	t := next.Time.UTC()
+	return &t, nil
+}
+
+func (r *OrgRepository) UpdateHierarchyMembershipNode(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, orgNodeID uuid.UUID) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+UPDATE org_hierarchy_memberships
+SET org_node_id=$3, updated_at=now()
+WHERE tenant_id=$1 AND id=$2
+`, pgUUID(tenantID), pgUUID(id), pgUUID(orgNodeID))
+	return err
+}
+
+func (r *OrgRepository) UpdateHierarchyMembershipEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+UPDATE org_hierarchy_memberships
+SET end_date=$3, updated_at=now()
+WHERE tenant_id=$1 AND id=$2
+`, pgUUID(tenantID), pgUUID(id), pgValidDate(endDate))
+	return err
+}
+
+func (r *OrgRepository) ListHierarchyMemberships(ctx context.Context, tenantID uuid.UUID, filter services.HierarchyMembershipListFilter) ([]services.HierarchyMembershipRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	q := `
+SELECT` + hierarchyMembershipColumns + `
+FROM org_hierarchy_memberships
+WHERE tenant_id = $1
+`
+	args := []any{pgUUID(tenantID)}
+	i := 2
+
+	if filter.ID != nil && *filter.ID != uuid.Nil {
+		q += "\n  AND id = $" + itoa(i)
+		args = append(args, pgUUID(*filter.ID))
+		i++
+	}
+	if filter.SubjectType != nil && *filter.SubjectType != "" {
+		q += "\n  AND subject_type = $" + itoa(i)
+		args = append(args, *filter.SubjectType)
+		i++
+	}
+	if filter.SubjectID != nil && *filter.SubjectID != uuid.Nil {
+		q += "\n  AND subject_id = $" + itoa(i)
+		args = append(args, pgUUID(*filter.SubjectID))
+		i++
+	}
+	if filter.HierarchyType != nil && *filter.HierarchyType != "" {
+		q += "\n  AND hierarchy_type = $" + itoa(i)
+		args = append(args, *filter.HierarchyType)
+		i++
+	}
+	if filter.OrgNodeID != nil && *filter.OrgNodeID != uuid.Nil {
+		q += "\n  AND org_node_id = $" + itoa(i)
+		args = append(args, pgUUID(*filter.OrgNodeID))
+		i++
+	}
+	if filter.AsOf != nil && !filter.AsOf.IsZero() {
+		q += "\n  AND effective_date <= $" + itoa(i) + "\n  AND end_date >= $" + itoa(i)
+		args = append(args, pgValidDate(*filter.AsOf))
+		i++
+	}
+
+	q += "\nORDER BY hierarchy_type ASC, subject_type ASC, subject_id ASC, effective_date ASC\nLIMIT $" + itoa(i)
+	args = append(args, filter.Limit)
+
+	rows, err := tx.Query(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]services.HierarchyMembershipRow, 0, minInt(filter.Limit, 64))
+	for rows.Next() {
+		row, err := scanHierarchyMembership(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
+
+// ListHierarchyMembershipTotalsAsOf returns direct per-node staffing for one parallel hierarchy.
+// Active assignments resolve to their own membership first and fall back to their position's.
+func (r *OrgRepository) ListHierarchyMembershipTotalsAsOf(ctx context.Context, tenantID uuid.UUID, hierarchyType string, asOf time.Time) ([]services.HierarchyMembershipNodeTotals, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+WITH m AS (
+	SELECT subject_type, subject_id, org_node_id
+	FROM org_hierarchy_memberships
+	WHERE tenant_id = $1 AND hierarchy_type = $2 AND effective_date <= $3 AND end_date >= $3
+),
+positions AS (
+	SELECT m.org_node_id, count(*)::int AS positions
+	FROM m
+	JOIN org_positions p ON p.tenant_id = $1 AND p.id = m.subject_id AND p.effective_date <= $3 AND p.end_date >= $3
+	WHERE m.subject_type = 'position'
+	GROUP BY m.org_node_id
+),
+staffed AS (
+	SELECT COALESCE(am.org_node_id, pm.org_node_id) AS org_node_id,
+		count(*)::int AS headcount,
+		COALESCE(sum(a.allocated_fte), 0)::float8 AS fte
+	FROM org_assignments a
+	LEFT JOIN m am ON am.subject_type = 'assignment' AND am.subject_id = a.id
+	LEFT JOIN m pm ON pm.subject_type = 'position' AND pm.subject_id = a.position_id
+	WHERE a.tenant_id = $1 AND a.effective_date <= $3 AND a.end_date >= $3 AND a.employment_status = 'active'
+		AND COALESCE(am.org_node_id, pm.org_node_id) IS NOT NULL
+	GROUP BY 1
+)
+SELECT COALESCE(p.org_node_id, s.org_node_id), COALESCE(p.positions, 0), COALESCE(s.headcount, 0), COALESCE(s.fte, 0)
+FROM positions p
+FULL JOIN staffed s ON s.org_node_id = p.org_node_id
+`, pgUUID(tenantID), hierarchyType, pgValidDate(asOf))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]services.HierarchyMembershipNodeTotals, 0, 64)
+	for rows.Next() {
+		var row services.HierarchyMembershipNodeTotals
+		if err := rows.Scan(&row.OrgNodeID, &row.Positions, &row.Headcount, &row.FTE); err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
+
+func (r *OrgRepository) HasHierarchyMembershipsAt(ctx context.Context, tenantID uuid.UUID, orgNodeID uuid.UUID, asOf time.Time) (bool, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return false, err
+	}
+	var exists bool
+	if err := tx.QueryRow(ctx, `
+SELECT EXISTS(
+	SELECT 1
+	FROM org_hierarchy_memberships
+	WHERE tenant_id=$1 AND org_node_id=$2 AND effective_date <= $3 AND end_date >= $3
+)
+`, pgUUID(tenantID), pgUUID(orgNodeID), pgValidDate(asOf)).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
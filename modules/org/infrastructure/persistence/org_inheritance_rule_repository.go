@@ -0,0 +1,332 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const inheritanceRuleColumns = `
+  id,
+  hierarchy_type,
+  attribute_name,
+  can_override,
+  inheritance_break_node_type,
+  effective_date,
+  end_date
+FROM org_attribute_inheritance_rules`
+
+func scanInheritanceRuleRow(row pgx.Row) (services.InheritanceRuleRow, error) {
+	var out services.InheritanceRuleRow
+	var breakType pgtype.Text
+	if err := row.Scan(
+		&out.ID,
+		&out.HierarchyType,
+		&out.AttributeName,
+		&out.CanOverride,
+		&breakType,
+		&out.EffectiveDate,
+		&out.EndDate,
+	); err != nil {
+		return services.InheritanceRuleRow{}, err
+	}
+	out.InheritanceBreakNodeType = nullableText(breakType)
+	return out, nil
+}
+
+const inheritanceBreakColumns = `
+  id,
+  hierarchy_type,
+  attribute_name,
+  org_node_id,
+  effective_date,
+  end_date
+FROM org_attribute_inheritance_breaks`
+
+func scanInheritanceBreakRow(row pgx.Row) (services.InheritanceBreakRow, error) {
+	var out services.InheritanceBreakRow
+	if err := row.Scan(
+		&out.ID,
+		&out.HierarchyType,
+		&out.AttributeName,
+		&out.OrgNodeID,
+		&out.EffectiveDate,
+		&out.EndDate,
+	); err != nil {
+		return services.InheritanceBreakRow{}, err
+	}
+	return out, nil
+}
+
+// inheritanceListWhere renders the filter shared by the rule and break listings.
+func inheritanceListWhere(tenantID uuid.UUID, filter services.InheritanceRuleListFilter) (string, []any) {
+	q := `
+WHERE tenant_id = $1
+  AND hierarchy_type = $2`
+	args := []any{pgUUID(tenantID), filter.HierarchyType}
+	i := 3
+
+	if filter.AttributeName != nil {
+		q += "\n  AND attribute_name = $" + itoa(i)
+		args = append(args, *filter.AttributeName)
+		i++
+	}
+	if filter.AsOf != nil && !filter.AsOf.IsZero() {
+		q += "\n  AND effective_date <= $" + itoa(i) + "\n  AND end_date >= $" + itoa(i)
+		args = append(args, pgValidDate(*filter.AsOf))
+	}
+	return q, args
+}
+
+func (r *OrgRepository) ListInheritanceRules(ctx context.Context, tenantID uuid.UUID, filter services.InheritanceRuleListFilter) ([]services.InheritanceRuleRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := inheritanceListWhere(tenantID, filter)
+	rows, err := tx.Query(ctx, `
+SELECT`+inheritanceRuleColumns+where+`
+ORDER BY attribute_name, effective_date, id
+`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []services.InheritanceRuleRow{}
+	for rows.Next() {
+		row, err := scanInheritanceRuleRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
+
+func (r *OrgRepository) LockInheritanceRuleAt(ctx context.Context, tenantID uuid.UUID, hierarchyType string, attributeName string, asOf time.Time) (services.InheritanceRuleRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.InheritanceRuleRow{}, err
+	}
+	return scanInheritanceRuleRow(tx.QueryRow(ctx, `
+SELECT`+inheritanceRuleColumns+`
+WHERE tenant_id=$1 AND hierarchy_type=$2 AND attribute_name=$3
+  AND effective_date <= $4 AND end_date >= $4
+FOR UPDATE
+`, pgUUID(tenantID), hierarchyType, attributeName, pgValidDate(asOf)))
+}
+
+func (r *OrgRepository) LockInheritanceRuleByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (services.InheritanceRuleRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.InheritanceRuleRow{}, err
+	}
+	return scanInheritanceRuleRow(tx.QueryRow(ctx, `
+SELECT`+inheritanceRuleColumns+`
+WHERE tenant_id=$1 AND id=$2
+FOR UPDATE
+`, pgUUID(tenantID), pgUUID(id)))
+}
+
+func (r *OrgRepository) NextInheritanceRuleStart(ctx context.Context, tenantID uuid.UUID, hierarchyType string, attributeName string, after time.Time) (*time.Time, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var next pgtype.Date
+	if err := tx.QueryRow(ctx, `
+SELECT min(effective_date)
+FROM org_attribute_inheritance_rules
+WHERE tenant_id=$1 AND hierarchy_type=$2 AND attribute_name=$3
+  AND effective_date > $4
+`, pgUUID(tenantID), hierarchyType, attributeName, pgValidDate(after)).Scan(&next); err != nil {
+		return nil, err
+	}
+	if !next.Valid {
+		return nil, nil
+	}
+	t := next.Time.UTC()
+	return &t, nil
+}
+
+func (r *OrgRepository) InsertInheritanceRule(ctx context.Context, tenantID uuid.UUID, in services.InheritanceRuleInsert) (uuid.UUID, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+	INSERT INTO org_attribute_inheritance_rules (
+	  tenant_id,
+	  hierarchy_type,
+	  attribute_name,
+	  can_override,
+	  inheritance_break_node_type,
+	  effective_date,
+	  end_date
+	)
+	VALUES ($1,$2,$3,$4,$5,$6,$7)
+	RETURNING id
+	`,
+		pgUUID(tenantID),
+		in.HierarchyType,
+		in.AttributeName,
+		in.CanOverride,
+		pgNullableText(in.InheritanceBreakNodeType),
+		pgValidDate(in.EffectiveDate),
+		pgValidDate(in.EndDate),
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (r *OrgRepository) UpdateInheritanceRuleCanOverride(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, canOverride bool) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE org_attribute_inheritance_rules
+	SET can_override=$3, updated_at=now()
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id), canOverride)
+	return err
+}
+
+func (r *OrgRepository) UpdateInheritanceRuleEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE org_attribute_inheritance_rules
+	SET end_date=$3, updated_at=now()
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id), pgValidDate(endDate))
+	return err
+}
+
+func (r *OrgRepository) DeleteInheritanceRule(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	DELETE FROM org_attribute_inheritance_rules
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id))
+	return err
+}
+
+func (r *OrgRepository) ListInheritanceBreaks(ctx context.Context, tenantID uuid.UUID, filter services.InheritanceRuleListFilter) ([]services.InheritanceBreakRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := inheritanceListWhere(tenantID, filter)
+	rows, err := tx.Query(ctx, `
+SELECT`+inheritanceBreakColumns+where+`
+ORDER BY attribute_name, org_node_id, effective_date, id
+`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []services.InheritanceBreakRow{}
+	for rows.Next() {
+		row, err := scanInheritanceBreakRow(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
+
+func (r *OrgRepository) LockInheritanceBreakByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (services.InheritanceBreakRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.InheritanceBreakRow{}, err
+	}
+	return scanInheritanceBreakRow(tx.QueryRow(ctx, `
+SELECT`+inheritanceBreakColumns+`
+WHERE tenant_id=$1 AND id=$2
+FOR UPDATE
+`, pgUUID(tenantID), pgUUID(id)))
+}
+
+func (r *OrgRepository) InsertInheritanceBreak(ctx context.Context, tenantID uuid.UUID, in services.InheritanceBreakInsert) (uuid.UUID, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+	INSERT INTO org_attribute_inheritance_breaks (
+	  tenant_id,
+	  hierarchy_type,
+	  attribute_name,
+	  org_node_id,
+	  effective_date,
+	  end_date
+	)
+	VALUES ($1,$2,$3,$4,$5,$6)
+	RETURNING id
+	`,
+		pgUUID(tenantID),
+		in.HierarchyType,
+		in.AttributeName,
+		pgUUID(in.OrgNodeID),
+		pgValidDate(in.EffectiveDate),
+		pgValidDate(in.EndDate),
+	).Scan(&id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (r *OrgRepository) UpdateInheritanceBreakEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE org_attribute_inheritance_breaks
+	SET end_date=$3, updated_at=now()
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id), pgValidDate(endDate))
+	return err
+}
+
+func (r *OrgRepository) DeleteInheritanceBreak(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	DELETE FROM org_attribute_inheritance_breaks
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id))
+	return err
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -90,19 +91,7 @@ func (r *OrgRepository) UpsertPersonnelEvent(ctx context.Context, tenantID uuid.
 }
 
 func (r *OrgRepository) getPersonnelEventByRequestID(ctx context.Context, tenantID uuid.UUID, requestID string) (services.PersonnelEventRow, error) {
-	tx, err := composables.UseTx(ctx)
-	if err != nil {
-		return services.PersonnelEventRow{}, err
-	}
-
-	var row services.PersonnelEventRow
-	var initiator pgtype.UUID
-	var personUUID pgtype.UUID
-	var createdAt pgtype.Timestamptz
-	var updatedAt pgtype.Timestamptz
-	var payloadOut []byte
-
-	err = tx.QueryRow(ctx, `
+	return r.getPersonnelEvent(ctx, `
 SELECT
   id,
   request_id,
@@ -118,7 +107,46 @@ SELECT
 FROM org_personnel_events
 WHERE tenant_id = $1 AND request_id = $2
 LIMIT 1
-`, pgUUID(tenantID), strings.TrimSpace(requestID)).Scan(
+`, pgUUID(tenantID), strings.TrimSpace(requestID))
+}
+
+// LatestPersonnelEventBefore returns the person's most recent event effective strictly before
+// the date, or pgx.ErrNoRows.
+func (r *OrgRepository) LatestPersonnelEventBefore(ctx context.Context, tenantID uuid.UUID, personUUID uuid.UUID, before time.Time) (services.PersonnelEventRow, error) {
+	return r.getPersonnelEvent(ctx, `
+SELECT
+  id,
+  request_id,
+  initiator_id,
+  event_type,
+  person_uuid,
+  pernr,
+  effective_date,
+  reason_code,
+  payload,
+  created_at,
+  updated_at
+FROM org_personnel_events
+WHERE tenant_id = $1 AND person_uuid = $2 AND effective_date < $3
+ORDER BY effective_date DESC, created_at DESC
+LIMIT 1
+`, pgUUID(tenantID), pgUUID(personUUID), pgValidDate(before))
+}
+
+func (r *OrgRepository) getPersonnelEvent(ctx context.Context, query string, args ...any) (services.PersonnelEventRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.PersonnelEventRow{}, err
+	}
+
+	var row services.PersonnelEventRow
+	var initiator pgtype.UUID
+	var personUUID pgtype.UUID
+	var createdAt pgtype.Timestamptz
+	var updatedAt pgtype.Timestamptz
+	var payloadOut []byte
+
+	err = tx.QueryRow(ctx, query, args...).Scan(
 		&row.ID,
 		&row.RequestID,
 		&initiator,
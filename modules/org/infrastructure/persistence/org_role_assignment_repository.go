@@ -0,0 +1,140 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func (r *OrgRepository) GetRoleByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (services.OrgRole, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.OrgRole{}, err
+	}
+
+	var role services.OrgRole
+	var desc pgtype.Text
+	err = tx.QueryRow(ctx, `
+	SELECT id, code, name, description, is_system
+	FROM org_roles
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id)).Scan(&role.ID, &role.Code, &role.Name, &desc, &role.IsSystem)
+	if err != nil {
+		return services.OrgRole{}, err
+	}
+	role.Description = nullableText(desc)
+	return role, nil
+}
+
+func (r *OrgRepository) InsertRole(ctx context.Context, tenantID uuid.UUID, in services.RoleInsert) (uuid.UUID, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+	INSERT INTO org_roles (tenant_id, code, name, description, is_system)
+	VALUES ($1,$2,$3,$4,$5)
+	RETURNING id
+	`, pgUUID(tenantID), in.Code, in.Name, pgNullableText(in.Description), in.IsSystem).Scan(&id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (r *OrgRepository) InsertRoleAssignment(ctx context.Context, tenantID uuid.UUID, in services.RoleAssignmentInsert) (uuid.UUID, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	err = tx.QueryRow(ctx, `
+	INSERT INTO org_role_assignments (
+	  tenant_id,
+	  role_id,
+	  subject_type,
+	  subject_id,
+	  org_node_id,
+	  effective_date,
+	  end_date
+	)
+	VALUES ($1,$2,$3,$4,$5,$6,$7)
+	RETURNING id
+	`, pgUUID(tenantID), pgUUID(in.RoleID), in.SubjectType, pgUUID(in.SubjectID), pgUUID(in.OrgNodeID), pgValidDate(in.EffectiveDate), pgValidDate(in.EndDate)).Scan(&id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+func (r *OrgRepository) LockRoleAssignmentByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (services.RoleAssignmentRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.RoleAssignmentRow{}, err
+	}
+
+	var row services.RoleAssignmentRow
+	err = tx.QueryRow(ctx, `
+SELECT
+  a.id,
+  a.role_id,
+  r.code,
+  a.subject_type,
+  a.subject_id,
+  a.org_node_id,
+  a.effective_date,
+  a.end_date
+FROM org_role_assignments a
+JOIN org_roles r
+  ON r.tenant_id=a.tenant_id
+  AND r.id=a.role_id
+WHERE a.tenant_id=$1 AND a.id=$2
+FOR UPDATE OF a
+`, pgUUID(tenantID), pgUUID(id)).Scan(
+		&row.AssignmentID,
+		&row.RoleID,
+		&row.RoleCode,
+		&row.SubjectType,
+		&row.SubjectID,
+		&row.SourceOrgNodeID,
+		&row.EffectiveDate,
+		&row.EndDate,
+	)
+	if err != nil {
+		return services.RoleAssignmentRow{}, err
+	}
+	return row, nil
+}
+
+func (r *OrgRepository) UpdateRoleAssignmentEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	UPDATE org_role_assignments
+	SET end_date=$3, updated_at=now()
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id), pgValidDate(endDate))
+	return err
+}
+
+func (r *OrgRepository) DeleteRoleAssignment(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+	DELETE FROM org_role_assignments
+	WHERE tenant_id=$1 AND id=$2
+	`, pgUUID(tenantID), pgUUID(id))
+	return err
+}
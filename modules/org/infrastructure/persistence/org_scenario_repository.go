@@ -0,0 +1,153 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const scenarioColumns = `
+  id,
+  name,
+  description,
+  effective_date,
+  commands,
+  status,
+  change_request_id,
+  created_by,
+  created_at,
+  updated_at`
+
+func scanScenario(row interface{ Scan(dest ...any) error }) (services.ScenarioRow, error) {
+	var out services.ScenarioRow
+	var commands []byte
+	err := row.Scan(
+		&out.ID,
+		&out.Name,
+		&out.Description,
+		&out.EffectiveDate,
+		&commands,
+		&out.Status,
+		&out.ChangeRequestID,
+		&out.CreatedBy,
+		&out.CreatedAt,
+		&out.UpdatedAt,
+	)
+	if err != nil {
+		return services.ScenarioRow{}, err
+	}
+	if err := json.Unmarshal(commands, &out.Commands); err != nil {
+		return services.ScenarioRow{}, err
+	}
+	return out, nil
+}
+
+func (r *OrgRepository) InsertScenario(ctx context.Context, tenantID uuid.UUID, createdBy uuid.UUID, in services.ScenarioInput) (services.ScenarioRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.ScenarioRow{}, err
+	}
+	commands, err := json.Marshal(in.Commands)
+	if err != nil {
+		return services.ScenarioRow{}, err
+	}
+	return scanScenario(tx.QueryRow(ctx, `
+INSERT INTO org_scenarios (tenant_id, name, description, effective_date, commands, created_by)
+VALUES ($1,$2,$3,$4,$5::jsonb,$6)
+RETURNING`+scenarioColumns+`
+`, pgUUID(tenantID), in.Name, in.Description, pgValidDate(in.EffectiveDate), commands, pgUUID(createdBy)))
+}
+
+func (r *OrgRepository) GetScenario(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (services.ScenarioRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.ScenarioRow{}, err
+	}
+	return scanScenario(tx.QueryRow(ctx, `
+SELECT`+scenarioColumns+`
+FROM org_scenarios
+WHERE tenant_id=$1 AND id=$2
+`, pgUUID(tenantID), pgUUID(id)))
+}
+
+func (r *OrgRepository) ListScenarios(ctx context.Context, tenantID uuid.UUID, limit int) ([]services.ScenarioRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := tx.Query(ctx, `
+SELECT`+scenarioColumns+`
+FROM org_scenarios
+WHERE tenant_id=$1
+ORDER BY updated_at DESC, id ASC
+LIMIT $2
+`, pgUUID(tenantID), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]services.ScenarioRow, 0, limit)
+	for rows.Next() {
+		row, err := scanScenario(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
+
+func (r *OrgRepository) UpdateScenarioDraft(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, in services.ScenarioInput) (services.ScenarioRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.ScenarioRow{}, err
+	}
+	commands, err := json.Marshal(in.Commands)
+	if err != nil {
+		return services.ScenarioRow{}, err
+	}
+	return scanScenario(tx.QueryRow(ctx, `
+UPDATE org_scenarios
+SET name=$3, description=$4, effective_date=$5, commands=$6::jsonb, updated_at=$7
+WHERE tenant_id=$1 AND id=$2 AND status='draft'
+RETURNING`+scenarioColumns+`
+`, pgUUID(tenantID), pgUUID(id), in.Name, in.Description, pgValidDate(in.EffectiveDate), commands, time.Now().UTC()))
+}
+
+func (r *OrgRepository) DeleteScenarioDraft(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `
+DELETE FROM org_scenarios
+WHERE tenant_id=$1 AND id=$2 AND status='draft'
+`, pgUUID(tenantID), pgUUID(id))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+func (r *OrgRepository) MarkScenarioConverted(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, changeRequestID uuid.UUID) (services.ScenarioRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return services.ScenarioRow{}, err
+	}
+	return scanScenario(tx.QueryRow(ctx, `
+UPDATE org_scenarios
+SET status='converted', change_request_id=$3, updated_at=$4
+WHERE tenant_id=$1 AND id=$2 AND status='draft'
+RETURNING`+scenarioColumns+`
+`, pgUUID(tenantID), pgUUID(id), pgUUID(changeRequestID), time.Now().UTC()))
+}
@@ -0,0 +1,239 @@
+package persistence
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// searchQuery matches each searchable field in its own branch so every branch can use its
+// trigram index; the outer query keeps the best-scoring field per entity.
+//
+// Scores: exact (case-insensitive) 1.0, prefix 0.9, substring 0.7, otherwise trigram similarity.
+//
+// $8 scopes hits to one hierarchy type (empty = all): node hits by org_nodes.type, position and
+// person hits only belong to OrgUnit.
+const searchQuery = `
+WITH candidates AS (
+	SELECT 'node'::text AS kind, n.id AS entity_id, n.code::text AS code, s.name::text AS name, n.id AS org_node_id,
+		n.type::text AS hierarchy_type, 'code'::text AS field, n.code::text AS value, 0 AS pref
+	FROM org_nodes n
+	JOIN org_node_slices s
+		ON s.tenant_id = n.tenant_id AND s.org_node_id = n.id
+		AND s.effective_date <= $5 AND s.end_date >= $5 AND s.status <> 'rescinded'
+	WHERE n.tenant_id = $1 AND 'node' = ANY($6::text[]) AND ($8 = '' OR n.type = $8)
+		AND (n.code ILIKE $4 OR n.code % $2)
+
+	UNION ALL
+	SELECT 'node', n.id, n.code, s.name, n.id, n.type, 'name', s.name, 0
+	FROM org_node_slices s
+	JOIN org_nodes n ON n.tenant_id = s.tenant_id AND n.id = s.org_node_id
+	WHERE s.tenant_id = $1 AND 'node' = ANY($6::text[]) AND ($8 = '' OR n.type = $8)
+		AND s.effective_date <= $5 AND s.end_date >= $5 AND s.status <> 'rescinded'
+		AND (s.name ILIKE $4 OR s.name % $2)
+
+	UNION ALL
+	SELECT 'node', n.id, n.code, s.name, n.id, n.type, 'i18n_names', org_i18n_names_text(s.i18n_names), 0
+	FROM org_node_slices s
+	JOIN org_nodes n ON n.tenant_id = s.tenant_id AND n.id = s.org_node_id
+	WHERE s.tenant_id = $1 AND 'node' = ANY($6::text[]) AND ($8 = '' OR n.type = $8)
+		AND s.effective_date <= $5 AND s.end_date >= $5 AND s.status <> 'rescinded'
+		AND (org_i18n_names_text(s.i18n_names) ILIKE $4 OR org_i18n_names_text(s.i18n_names) % $2)
+
+	UNION ALL
+	SELECT 'position', p.id, p.code, COALESCE(ps.title, p.code), ps.org_node_id, 'OrgUnit', 'code', p.code, 0
+	FROM org_positions p
+	JOIN org_position_slices ps
+		ON ps.tenant_id = p.tenant_id AND ps.position_id = p.id
+		AND ps.effective_date <= $5 AND ps.end_date >= $5 AND ps.lifecycle_status <> 'rescinded'
+	WHERE p.tenant_id = $1 AND 'position' = ANY($6::text[]) AND $8 IN ('', 'OrgUnit')
+		AND (p.code ILIKE $4 OR p.code % $2)
+
+	UNION ALL
+	SELECT 'position', p.id, p.code, ps.title, ps.org_node_id, 'OrgUnit', 'title', ps.title, 0
+	FROM org_position_slices ps
+	JOIN org_positions p ON p.tenant_id = ps.tenant_id AND p.id = ps.position_id
+	WHERE ps.tenant_id = $1 AND 'position' = ANY($6::text[]) AND $8 IN ('', 'OrgUnit')
+		AND ps.effective_date <= $5 AND ps.end_date >= $5 AND ps.lifecycle_status <> 'rescinded'
+		AND (ps.title ILIKE $4 OR ps.title % $2)
+
+	UNION ALL
+	SELECT 'job_profile', jp.id, jp.code, js.name, NULL::uuid, NULL::text, 'code', jp.code, 0
+	FROM org_job_profiles jp
+	JOIN org_job_profile_slices js
+		ON js.tenant_id = jp.tenant_id AND js.job_profile_id = jp.id
+		AND js.effective_date <= $5 AND js.end_date >= $5
+	WHERE jp.tenant_id = $1 AND 'job_profile' = ANY($6::text[])
+		AND (jp.code ILIKE $4 OR jp.code % $2)
+
+	UNION ALL
+	SELECT 'job_profile', jp.id, jp.code, js.name, NULL::uuid, NULL, 'name', js.name, 0
+	FROM org_job_profile_slices js
+	JOIN org_job_profiles jp ON jp.tenant_id = js.tenant_id AND jp.id = js.job_profile_id
+	WHERE js.tenant_id = $1 AND 'job_profile' = ANY($6::text[])
+		AND js.effective_date <= $5 AND js.end_date >= $5
+		AND (js.name ILIKE $4 OR js.name % $2)
+
+	UNION ALL
+	SELECT 'person', a.subject_id, a.pernr, COALESCE(pe.display_name, a.pernr), ps.org_node_id, 'OrgUnit', 'pernr', a.pernr,
+		CASE WHEN a.assignment_type = 'primary' THEN 0 ELSE 1 END
+	FROM org_assignments a
+	JOIN org_position_slices ps
+		ON ps.tenant_id = a.tenant_id AND ps.position_id = a.position_id
+		AND ps.effective_date <= $5 AND ps.end_date >= $5
+	LEFT JOIN persons pe ON pe.tenant_id = a.tenant_id AND pe.person_uuid = a.subject_id
+	WHERE a.tenant_id = $1 AND 'person' = ANY($6::text[]) AND $8 IN ('', 'OrgUnit')
+		AND a.effective_date <= $5 AND a.end_date >= $5
+		AND (a.pernr ILIKE $4 OR a.pernr % $2)
+
+	UNION ALL
+	SELECT 'person', a.subject_id, a.pernr, pe.display_name, ps.org_node_id, 'OrgUnit', 'name', pe.display_name,
+		CASE WHEN a.assignment_type = 'primary' THEN 0 ELSE 1 END
+	FROM persons pe
+	JOIN org_assignments a
+		ON a.tenant_id = pe.tenant_id AND a.subject_id = pe.person_uuid
+		AND a.effective_date <= $5 AND a.end_date >= $5
+	JOIN org_position_slices ps
+		ON ps.tenant_id = a.tenant_id AND ps.position_id = a.position_id
+		AND ps.effective_date <= $5 AND ps.end_date >= $5
+	WHERE pe.tenant_id = $1 AND 'person' = ANY($6::text[]) AND $8 IN ('', 'OrgUnit')
+		AND (pe.display_name ILIKE $4 OR pe.display_name % $2)
+),
+ranked AS (
+	SELECT c.*,
+		GREATEST(
+			CASE
+				WHEN lower(c.value) = lower($2) THEN 1.0
+				WHEN c.value ILIKE $3 THEN 0.9
+				WHEN c.value ILIKE $4 THEN 0.7
+				ELSE 0
+			END,
+			similarity(c.value, $2)
+		)::float8 AS score
+	FROM candidates c
+),
+best AS (
+	SELECT DISTINCT ON (kind, entity_id) kind, entity_id, code, name, org_node_id, hierarchy_type, field, score
+	FROM ranked
+	ORDER BY kind, entity_id, score DESC, pref ASC, field ASC
+)
+SELECT kind, entity_id, code, name, field, score, org_node_id, hierarchy_type
+FROM best
+ORDER BY score DESC, kind ASC, name ASC, entity_id ASC
+LIMIT $7
+`
+
+func (r *OrgRepository) SearchAsOf(ctx context.Context, tenantID uuid.UUID, query string, kinds []services.SearchKind, hierarchyType string, asOf time.Time, limit int) ([]services.SearchHitRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	kindValues := make([]string, 0, len(kinds))
+	for _, k := range kinds {
+		kindValues = append(kindValues, string(k))
+	}
+	escaped := escapeLikePattern(query)
+
+	rows, err := tx.Query(ctx, searchQuery,
+		pgUUID(tenantID),
+		query,
+		escaped+"%",
+		"%"+escaped+"%",
+		pgValidDate(asOf),
+		kindValues,
+		limit,
+		hierarchyType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]services.SearchHitRow, 0, limit)
+	for rows.Next() {
+		var row services.SearchHitRow
+		var kind string
+		var hierarchyType *string
+		if err := rows.Scan(&kind, &row.ID, &row.Code, &row.Name, &row.MatchedField, &row.Score, &row.OrgNodeID, &hierarchyType); err != nil {
+			return nil, err
+		}
+		row.Kind = services.SearchKind(kind)
+		if hierarchyType != nil {
+			row.HierarchyType = *hierarchyType
+		}
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
+
+// ListNodePathsAsOf returns root-first paths for several nodes in one round trip, using the
+// org_edges ltree paths that are always maintained regardless of the deep-read backend.
+func (r *OrgRepository) ListNodePathsAsOf(ctx context.Context, tenantID uuid.UUID, hierarchyType string, nodeIDs []uuid.UUID, asOf time.Time) (map[uuid.UUID][]services.NodePathNode, error) {
+	if len(nodeIDs) == 0 {
+		return map[uuid.UUID][]services.NodePathNode{}, nil
+	}
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(ctx, `
+WITH target AS (
+	SELECT DISTINCT ON (e.child_node_id) e.child_node_id AS node_id, e.path
+	FROM org_edges e
+	WHERE e.tenant_id = $1
+		AND e.hierarchy_type = $2
+		AND e.child_node_id = ANY($3::uuid[])
+		AND e.effective_date <= $4
+		AND e.end_date >= $4
+	ORDER BY e.child_node_id, e.effective_date DESC
+)
+SELECT t.node_id, a.child_node_id, n.code, s.name
+FROM target t
+JOIN org_edges a
+	ON a.tenant_id = $1
+	AND a.hierarchy_type = $2
+	AND a.effective_date <= $4
+	AND a.end_date >= $4
+	AND a.path @> t.path
+JOIN org_nodes n ON n.tenant_id = $1 AND n.id = a.child_node_id
+JOIN org_node_slices s
+	ON s.tenant_id = $1
+	AND s.org_node_id = a.child_node_id
+	AND s.effective_date <= $4
+	AND s.end_date >= $4
+ORDER BY t.node_id ASC, a.depth ASC
+`, pgUUID(tenantID), hierarchyType, pgUUIDArray(nodeIDs), pgValidDate(asOf))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[uuid.UUID][]services.NodePathNode, len(nodeIDs))
+	for rows.Next() {
+		var target uuid.UUID
+		var node services.NodePathNode
+		if err := rows.Scan(&target, &node.ID, &node.Code, &node.Name); err != nil {
+			return nil, err
+		}
+		node.Depth = len(out[target])
+		out[target] = append(out[target], node)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
+
+func escapeLikePattern(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(v)
+}
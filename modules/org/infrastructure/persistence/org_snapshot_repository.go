@@ -27,6 +27,7 @@ SELECT
     'name', s.name,
     'status', s.status,
     'parent_node_id', e.parent_node_id,
+    'custom_attributes', s.custom_attributes,
     'effective_date', s.effective_date,
     'end_date', s.end_date
   ) AS new_values
@@ -135,6 +136,7 @@ func (r *OrgRepository) ListSnapshotPositions(ctx context.Context, tenantID uuid
 	    'lifecycle_status', s.lifecycle_status,
 	    'is_auto_created', p.is_auto_created,
 	    'capacity_fte', s.capacity_fte,
+	    'custom_attributes', s.custom_attributes,
 	    'effective_date', s.effective_date,
 	    'end_date', s.end_date
 	  ) AS new_values
@@ -0,0 +1,190 @@
+package persistence
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// GetTrendReport aggregates every bucket in one round trip. Headcount counts distinct people with
+// an active primary assignment on a position whose slice sits in scope that day, regardless of
+// the position's lifecycle; positions, vacancies and spans honour the lifecycle/system filters.
+// Hires are attributed to the node in the event payload, terminations to the node of the anchor
+// assignment's position on the last working day.
+func (r *OrgRepository) GetTrendReport(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	buckets []services.TrendBucket,
+	orgNodeIDs []uuid.UUID,
+	lifecycleStatuses []string,
+	includeSystem bool,
+) ([]services.TrendBucketDBRow, error) {
+	tx, err := composables.UseTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+
+	starts := make([]time.Time, 0, len(buckets))
+	ends := make([]time.Time, 0, len(buckets))
+	for _, b := range buckets {
+		starts = append(starts, b.Start.UTC())
+		ends = append(ends, b.End.UTC())
+	}
+
+	rows, err := tx.Query(ctx, `
+WITH b AS (
+	SELECT t.idx::int AS idx, t.start_date, t.end_date
+	FROM unnest($2::date[], $3::date[]) WITH ORDINALITY AS t(start_date, end_date, idx)
+),
+d AS (
+	SELECT idx, 'start'::text AS edge, start_date AS on_date FROM b
+	UNION ALL
+	SELECT idx, 'end'::text AS edge, end_date AS on_date FROM b
+),
+heads AS (
+	SELECT d.idx, d.edge, COUNT(DISTINCT a.subject_id)::int AS headcount
+	FROM d
+	JOIN org_assignments a
+		ON a.tenant_id = $1
+		AND a.assignment_type = 'primary'
+		AND a.employment_status = 'active'
+		AND a.effective_date <= d.on_date
+		AND a.end_date >= d.on_date
+	JOIN org_position_slices s
+		ON s.tenant_id = a.tenant_id
+		AND s.position_id = a.position_id
+		AND s.effective_date <= d.on_date
+		AND s.end_date >= d.on_date
+	WHERE s.org_node_id = ANY($4::uuid[])
+	GROUP BY d.idx, d.edge
+),
+moves AS (
+	SELECT
+		b.idx,
+		COUNT(*) FILTER (
+			WHERE e.event_type IN ('hire', 'rehire')
+				AND NULLIF(e.payload->>'org_node_id', '')::uuid = ANY($4::uuid[])
+		)::int AS hires,
+		COUNT(*) FILTER (
+			WHERE e.event_type = 'termination'
+				AND EXISTS (
+					SELECT 1
+					FROM org_assignments a
+					JOIN org_position_slices s
+						ON s.tenant_id = a.tenant_id
+						AND s.position_id = a.position_id
+						AND s.effective_date <= e.effective_date - 1
+						AND s.end_date >= e.effective_date - 1
+					WHERE a.tenant_id = e.tenant_id
+						AND a.id = NULLIF(e.payload->>'anchor_assignment_id', '')::uuid
+						AND s.org_node_id = ANY($4::uuid[])
+				)
+		)::int AS terminations
+	FROM b
+	JOIN org_personnel_events e
+		ON e.tenant_id = $1
+		AND e.event_type IN ('hire', 'rehire', 'termination')
+		AND e.effective_date >= b.start_date
+		AND e.effective_date <= b.end_date
+	GROUP BY b.idx
+),
+pos AS (
+	SELECT
+		b.idx,
+		b.end_date,
+		p.id AS position_id,
+		EXISTS (
+			SELECT 1
+			FROM org_assignments a
+			WHERE a.tenant_id = p.tenant_id
+				AND a.position_id = p.id
+				AND a.assignment_type = 'primary'
+				AND a.employment_status = 'active'
+				AND a.effective_date <= b.end_date
+				AND a.end_date >= b.end_date
+		) AS filled
+	FROM b
+	JOIN org_position_slices s
+		ON s.tenant_id = $1
+		AND s.effective_date <= b.end_date
+		AND s.end_date >= b.end_date
+	JOIN org_positions p
+		ON p.tenant_id = s.tenant_id
+		AND p.id = s.position_id
+	WHERE s.org_node_id = ANY($4::uuid[])
+		AND s.lifecycle_status = ANY($5::text[])
+		AND ($6 OR p.is_auto_created = false)
+),
+vac AS (
+	SELECT
+		idx,
+		COUNT(*)::int AS positions_total,
+		COUNT(*) FILTER (WHERE NOT filled)::int AS vacant_positions
+	FROM pos
+	GROUP BY idx
+),
+span AS (
+	SELECT
+		m.idx,
+		COUNT(DISTINCT m.position_id)::int AS manager_positions,
+		COUNT(*)::int AS direct_reports
+	FROM pos m
+	JOIN org_position_slices r
+		ON r.tenant_id = $1
+		AND r.reports_to_position_id = m.position_id
+		AND r.effective_date <= m.end_date
+		AND r.end_date >= m.end_date
+		AND r.lifecycle_status = ANY($5::text[])
+	GROUP BY m.idx
+)
+SELECT
+	COALESCE(hs.headcount, 0),
+	COALESCE(he.headcount, 0),
+	COALESCE(mv.hires, 0),
+	COALESCE(mv.terminations, 0),
+	COALESCE(v.positions_total, 0),
+	COALESCE(v.vacant_positions, 0),
+	COALESCE(sp.manager_positions, 0),
+	COALESCE(sp.direct_reports, 0)
+FROM b
+LEFT JOIN heads hs ON hs.idx = b.idx AND hs.edge = 'start'
+LEFT JOIN heads he ON he.idx = b.idx AND he.edge = 'end'
+LEFT JOIN moves mv ON mv.idx = b.idx
+LEFT JOIN vac v ON v.idx = b.idx
+LEFT JOIN span sp ON sp.idx = b.idx
+ORDER BY b.idx ASC
+`, pgUUID(tenantID), starts, ends, orgNodeIDs, lifecycleStatuses, includeSystem)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]services.TrendBucketDBRow, 0, len(buckets))
+	for rows.Next() {
+		var row services.TrendBucketDBRow
+		if err := rows.Scan(
+			&row.HeadcountStart,
+			&row.HeadcountEnd,
+			&row.Hires,
+			&row.Terminations,
+			&row.PositionsTotal,
+			&row.VacantPositions,
+			&row.ManagerPositions,
+			&row.DirectReports,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, row)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return out, nil
+}
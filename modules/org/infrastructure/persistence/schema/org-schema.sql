@@ -4,6 +4,20 @@ CREATE EXTENSION IF NOT EXISTS ltree;
 
 CREATE EXTENSION IF NOT EXISTS btree_gist;
 
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE OR REPLACE FUNCTION org_i18n_names_text (names jsonb)
+    RETURNS text
+    LANGUAGE sql
+    IMMUTABLE
+    PARALLEL SAFE
+    AS $$
+    SELECT
+        COALESCE(string_agg(value, ' ' ORDER BY key), '')
+    FROM
+        jsonb_each_text(COALESCE(names, '{}'::jsonb));
+$$;
+
 CREATE TABLE org_nodes (
     tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
     id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
@@ -12,12 +26,12 @@ CREATE TABLE org_nodes (
     is_root boolean NOT NULL DEFAULT FALSE,
     created_at timestamptz NOT NULL DEFAULT now(),
     updated_at timestamptz NOT NULL DEFAULT now(),
-    CONSTRAINT org_nodes_type_check CHECK (type IN ('OrgUnit')),
+    CONSTRAINT org_nodes_type_check CHECK (type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project')),
     CONSTRAINT org_nodes_tenant_id_id_key UNIQUE (tenant_id, id),
     CONSTRAINT org_nodes_tenant_id_code_key UNIQUE (tenant_id, code)
 );
 
-CREATE UNIQUE INDEX org_nodes_tenant_root_unique ON org_nodes (tenant_id)
+CREATE UNIQUE INDEX org_nodes_tenant_root_unique ON org_nodes (tenant_id, type)
 WHERE
     is_root;
 
@@ -36,6 +50,7 @@ CREATE TABLE org_node_slices (
     display_order int NOT NULL DEFAULT 0,
     parent_hint uuid NULL,
     manager_user_id bigint NULL,
+    custom_attributes jsonb NOT NULL DEFAULT '{}' ::jsonb,
     effective_date date NOT NULL,
     end_date date NOT NULL DEFAULT DATE '9999-12-31',
     created_at timestamptz NOT NULL DEFAULT now(),
@@ -72,7 +87,7 @@ CREATE TABLE org_edges (
     end_date date NOT NULL DEFAULT DATE '9999-12-31',
     created_at timestamptz NOT NULL DEFAULT now(),
     updated_at timestamptz NOT NULL DEFAULT now(),
-    CONSTRAINT org_edges_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit')),
+    CONSTRAINT org_edges_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project')),
     CONSTRAINT org_edges_effective_check CHECK (effective_date <= end_date),
     CONSTRAINT org_edges_parent_not_child CHECK (parent_node_id IS NULL OR parent_node_id <> child_node_id),
     CONSTRAINT org_edges_child_fk FOREIGN KEY (tenant_id, child_node_id) REFERENCES org_nodes (tenant_id, id) ON DELETE RESTRICT,
@@ -97,19 +112,43 @@ DECLARE
     parent_path ltree;
     child_path ltree;
     child_key text;
+    node_type text;
 BEGIN
-    child_key := replace(lower(NEW.child_node_id::text), '-', '');
-    IF NEW.parent_node_id IS NULL THEN
-        NEW.path := child_key::ltree;
-        NEW.depth := nlevel (NEW.path) - 1;
-        RETURN NEW;
-    END IF;
+    SELECT
+        n.type INTO node_type
+    FROM
+        org_nodes n
+    WHERE
+        n.tenant_id = NEW.tenant_id
+        AND n.id = NEW.child_node_id;
+    IF node_type IS DISTINCT FROM NEW.hierarchy_type THEN
+        RAISE EXCEPTION 'org_edges: child node type % does not match hierarchy_type %', node_type, NEW.hierarchy_type
+            USING ERRCODE = 'check_violation';
+        END IF;
+        child_key := replace(lower(NEW.child_node_id::text), '-', '');
+        IF NEW.parent_node_id IS NULL THEN
+            NEW.path := child_key::ltree;
+            NEW.depth := nlevel (NEW.path) - 1;
+            RETURN NEW;
+        END IF;
+        SELECT
+            n.type INTO node_type
+        FROM
+            org_nodes n
+        WHERE
+            n.tenant_id = NEW.tenant_id
+            AND n.id = NEW.parent_node_id;
+        IF node_type IS DISTINCT FROM NEW.hierarchy_type THEN
+            RAISE EXCEPTION 'org_edges: parent node type % does not match hierarchy_type %', node_type, NEW.hierarchy_type
+                USING ERRCODE = 'check_violation';
+            END IF;
     SELECT
         e.path INTO parent_path
     FROM
         org_edges e
     WHERE
         e.tenant_id = NEW.tenant_id
+        AND e.hierarchy_type = NEW.hierarchy_type
         AND e.child_node_id = NEW.parent_node_id
         AND e.effective_date <= NEW.effective_date
         AND e.end_date >= NEW.effective_date
@@ -126,6 +165,7 @@ BEGIN
             org_edges e
         WHERE
             e.tenant_id = NEW.tenant_id
+            AND e.hierarchy_type = NEW.hierarchy_type
             AND e.child_node_id = NEW.child_node_id
             AND e.effective_date <= NEW.effective_date
             AND e.end_date >= NEW.effective_date
@@ -179,7 +219,7 @@ CREATE TABLE org_hierarchy_closure_builds (
     built_at timestamptz NOT NULL DEFAULT now(),
     source_request_id text NULL,
     notes text NULL,
-    CONSTRAINT org_hierarchy_closure_builds_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit')),
+    CONSTRAINT org_hierarchy_closure_builds_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project')),
     CONSTRAINT org_hierarchy_closure_builds_status_check CHECK (status IN ('building', 'ready', 'failed')),
     CONSTRAINT org_hierarchy_closure_builds_pkey PRIMARY KEY (tenant_id, hierarchy_type, build_id)
 );
@@ -199,7 +239,7 @@ CREATE TABLE org_hierarchy_closure (
     depth int NOT NULL,
     effective_date date NOT NULL,
     end_date date NOT NULL DEFAULT DATE '9999-12-31',
-    CONSTRAINT org_hierarchy_closure_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit')),
+    CONSTRAINT org_hierarchy_closure_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project')),
     CONSTRAINT org_hierarchy_closure_effective_check CHECK (effective_date <= end_date),
     CONSTRAINT org_hierarchy_closure_depth_check CHECK (depth >= 0),
     CONSTRAINT org_hierarchy_closure_build_fk FOREIGN KEY (tenant_id, hierarchy_type, build_id) REFERENCES org_hierarchy_closure_builds (tenant_id, hierarchy_type, build_id) ON DELETE CASCADE,
@@ -229,7 +269,7 @@ CREATE TABLE org_hierarchy_snapshot_builds (
     built_at timestamptz NOT NULL DEFAULT now(),
     source_request_id text NULL,
     notes text NULL,
-    CONSTRAINT org_hierarchy_snapshot_builds_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit')),
+    CONSTRAINT org_hierarchy_snapshot_builds_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project')),
     CONSTRAINT org_hierarchy_snapshot_builds_status_check CHECK (status IN ('building', 'ready', 'failed')),
     CONSTRAINT org_hierarchy_snapshot_builds_pkey PRIMARY KEY (tenant_id, hierarchy_type, as_of_date, build_id)
 );
@@ -248,7 +288,7 @@ CREATE TABLE org_hierarchy_snapshots (
     ancestor_node_id uuid NOT NULL,
     descendant_node_id uuid NOT NULL,
     depth int NOT NULL,
-    CONSTRAINT org_hierarchy_snapshots_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit')),
+    CONSTRAINT org_hierarchy_snapshots_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project')),
     CONSTRAINT org_hierarchy_snapshots_depth_check CHECK (depth >= 0),
     CONSTRAINT org_hierarchy_snapshots_build_fk FOREIGN KEY (tenant_id, hierarchy_type, as_of_date, build_id) REFERENCES org_hierarchy_snapshot_builds (tenant_id, hierarchy_type, as_of_date, build_id) ON DELETE CASCADE,
     CONSTRAINT org_hierarchy_snapshots_ancestor_fk FOREIGN KEY (tenant_id, ancestor_node_id) REFERENCES org_nodes (tenant_id, id) ON DELETE RESTRICT,
@@ -279,7 +319,7 @@ CREATE TABLE org_reporting_nodes (
     security_group_keys text[] NOT NULL DEFAULT '{}' ::text[],
     links jsonb NOT NULL DEFAULT '[]' ::jsonb,
     created_at timestamptz NOT NULL DEFAULT now(),
-    CONSTRAINT org_reporting_nodes_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit')),
+    CONSTRAINT org_reporting_nodes_hierarchy_type_check CHECK (hierarchy_type IN ('OrgUnit', 'CostCenter', 'LegalEntity', 'Geographic', 'Project')),
     CONSTRAINT org_reporting_nodes_depth_check CHECK (depth >= 0),
     CONSTRAINT org_reporting_nodes_attributes_is_object_check CHECK (jsonb_typeof(attributes) = 'object'),
     CONSTRAINT org_reporting_nodes_links_is_array_check CHECK (jsonb_typeof(links) = 'array'),
@@ -348,6 +388,7 @@ CREATE TABLE org_position_slices (
     job_profile_id uuid NOT NULL,
     cost_center_code varchar(64) NULL,
     profile jsonb NOT NULL DEFAULT '{}' ::jsonb,
+    custom_attributes jsonb NOT NULL DEFAULT '{}' ::jsonb,
     effective_date date NOT NULL,
     end_date date NOT NULL DEFAULT DATE '9999-12-31',
     created_at timestamptz NOT NULL DEFAULT now(),
@@ -697,6 +738,22 @@ CREATE INDEX org_assignments_tenant_position_effective_idx ON org_assignments (t
 
 CREATE INDEX org_assignments_tenant_pernr_effective_idx ON org_assignments (tenant_id, pernr, effective_date);
 
+CREATE INDEX org_nodes_code_trgm_idx ON org_nodes USING gin (code gin_trgm_ops);
+
+CREATE INDEX org_node_slices_name_trgm_idx ON org_node_slices USING gin (name gin_trgm_ops);
+
+CREATE INDEX org_node_slices_i18n_names_trgm_idx ON org_node_slices USING gin (org_i18n_names_text (i18n_names) gin_trgm_ops);
+
+CREATE INDEX org_positions_code_trgm_idx ON org_positions USING gin (code gin_trgm_ops);
+
+CREATE INDEX org_position_slices_title_trgm_idx ON org_position_slices USING gin (title gin_trgm_ops);
+
+CREATE INDEX org_job_profiles_code_trgm_idx ON org_job_profiles USING gin (code gin_trgm_ops);
+
+CREATE INDEX org_job_profile_slices_name_trgm_idx ON org_job_profile_slices USING gin (name gin_trgm_ops);
+
+CREATE INDEX org_assignments_pernr_trgm_idx ON org_assignments USING gin (pernr gin_trgm_ops);
+
 CREATE TABLE org_attribute_inheritance_rules (
     tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
     id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
@@ -718,6 +775,79 @@ ALTER TABLE org_attribute_inheritance_rules
 
 CREATE INDEX org_attribute_inheritance_rules_tenant_hierarchy_attribute_effective_idx ON org_attribute_inheritance_rules (tenant_id, hierarchy_type, attribute_name, effective_date);
 
+CREATE TABLE org_attribute_inheritance_breaks (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    hierarchy_type text NOT NULL,
+    attribute_name text NOT NULL,
+    org_node_id uuid NOT NULL,
+    effective_date date NOT NULL,
+    end_date date NOT NULL DEFAULT DATE '9999-12-31',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_attribute_inheritance_breaks_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_attribute_inheritance_breaks_effective_check CHECK (effective_date <= end_date),
+    CONSTRAINT org_attribute_inheritance_breaks_node_fk FOREIGN KEY (tenant_id, org_node_id) REFERENCES org_nodes (tenant_id, id) ON DELETE RESTRICT
+);
+
+ALTER TABLE org_attribute_inheritance_breaks
+    ADD CONSTRAINT org_attribute_inheritance_breaks_no_overlap
+    EXCLUDE USING gist (tenant_id gist_uuid_ops WITH =, hierarchy_type gist_text_ops WITH =, attribute_name gist_text_ops WITH =, org_node_id gist_uuid_ops WITH =, daterange(effective_date, end_date + 1, '[)') WITH &&);
+
+CREATE INDEX org_attribute_inheritance_breaks_tenant_hierarchy_attribute_effective_idx ON org_attribute_inheritance_breaks (tenant_id, hierarchy_type, attribute_name, effective_date);
+
+CREATE TABLE org_attribute_definitions (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    entity_type text NOT NULL,
+    code text NOT NULL,
+    label text NOT NULL,
+    value_type text NOT NULL,
+    options jsonb NOT NULL DEFAULT '[]' ::jsonb,
+    required boolean NOT NULL DEFAULT FALSE,
+    pattern text NULL,
+    min_value numeric NULL,
+    max_value numeric NULL,
+    max_length integer NULL,
+    display_order integer NOT NULL DEFAULT 0,
+    effective_date date NOT NULL,
+    end_date date NOT NULL DEFAULT DATE '9999-12-31',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_attribute_definitions_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_attribute_definitions_entity_type_check CHECK (entity_type IN ('org_node', 'org_position')),
+    CONSTRAINT org_attribute_definitions_value_type_check CHECK (value_type IN ('text', 'number', 'boolean', 'date', 'select')),
+    CONSTRAINT org_attribute_definitions_code_check CHECK (code ~ '^[a-z][a-z0-9_]*$'),
+    CONSTRAINT org_attribute_definitions_options_check CHECK (jsonb_typeof(options) = 'array'),
+    CONSTRAINT org_attribute_definitions_effective_check CHECK (effective_date <= end_date)
+);
+
+ALTER TABLE org_attribute_definitions
+    ADD CONSTRAINT org_attribute_definitions_no_overlap
+    EXCLUDE USING gist (tenant_id gist_uuid_ops WITH =, entity_type gist_text_ops WITH =, code gist_text_ops WITH =, daterange(effective_date, end_date + 1, '[)') WITH &&);
+
+CREATE INDEX org_attribute_definitions_tenant_entity_effective_idx ON org_attribute_definitions (tenant_id, entity_type, effective_date);
+
+CREATE TABLE org_freeze_periods (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    label text NOT NULL,
+    period_start date NOT NULL,
+    period_end date NOT NULL,
+    locks_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_freeze_periods_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_freeze_periods_label_check CHECK (btrim(label) <> ''),
+    CONSTRAINT org_freeze_periods_range_check CHECK (period_start <= period_end)
+);
+
+ALTER TABLE org_freeze_periods
+    ADD CONSTRAINT org_freeze_periods_no_overlap
+    EXCLUDE USING gist (tenant_id gist_uuid_ops WITH =, daterange(period_start, period_end + 1, '[)') WITH &&);
+
+CREATE INDEX org_freeze_periods_tenant_start_idx ON org_freeze_periods (tenant_id, period_start);
+
 CREATE TABLE org_roles (
     tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
     id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
@@ -759,6 +889,37 @@ CREATE INDEX org_role_assignments_tenant_node_effective_idx ON org_role_assignme
 
 CREATE INDEX org_role_assignments_tenant_subject_effective_idx ON org_role_assignments (tenant_id, subject_type, subject_id, effective_date);
 
+CREATE TABLE org_delegations (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    delegation_type text NOT NULL,
+    org_node_id uuid NOT NULL,
+    role_id uuid NULL,
+    delegator_subject_id uuid NULL,
+    delegate_user_id bigint NOT NULL,
+    delegate_subject_id uuid NOT NULL,
+    effective_date date NOT NULL,
+    end_date date NOT NULL,
+    reason_note text NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_delegations_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_delegations_effective_check CHECK (effective_date <= end_date),
+    CONSTRAINT org_delegations_type_check CHECK (delegation_type IN ('manager', 'role')),
+    CONSTRAINT org_delegations_role_check CHECK ((delegation_type = 'role') = (role_id IS NOT NULL)),
+    CONSTRAINT org_delegations_node_fk FOREIGN KEY (tenant_id, org_node_id) REFERENCES org_nodes (tenant_id, id) ON DELETE RESTRICT,
+    CONSTRAINT org_delegations_role_fk FOREIGN KEY (tenant_id, role_id) REFERENCES org_roles (tenant_id, id) ON DELETE RESTRICT
+);
+
+ALTER TABLE org_delegations
+    ADD CONSTRAINT org_delegations_acting_manager_no_overlap
+    EXCLUDE USING gist (tenant_id gist_uuid_ops WITH =, org_node_id gist_uuid_ops WITH =, daterange(effective_date, end_date + 1, '[)') WITH &&)
+    WHERE (delegation_type = 'manager');
+
+CREATE INDEX org_delegations_tenant_node_idx ON org_delegations (tenant_id, org_node_id, effective_date);
+
+CREATE INDEX org_delegations_tenant_delegate_idx ON org_delegations (tenant_id, delegate_user_id, effective_date);
+
 CREATE TABLE org_change_requests (
     tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
     id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
@@ -768,15 +929,100 @@ CREATE TABLE org_change_requests (
     payload_schema_version int NOT NULL DEFAULT 1,
     payload jsonb NOT NULL,
     notes text NULL,
+    reviewer_id uuid NULL,
+    review_comment text NULL,
+    reviewed_at timestamptz NULL,
+    applied_by uuid NULL,
+    applied_at timestamptz NULL,
+    scheduled_for timestamptz NULL,
+    execution_attempts int NOT NULL DEFAULT 0,
+    last_executed_at timestamptz NULL,
+    execution_result jsonb NULL,
+    execution_error jsonb NULL,
+    audit_request_id text NULL,
     created_at timestamptz NOT NULL DEFAULT now(),
     updated_at timestamptz NOT NULL DEFAULT now(),
     CONSTRAINT org_change_requests_tenant_id_id_key UNIQUE (tenant_id, id),
-    CONSTRAINT org_change_requests_status_check CHECK (status IN ('draft', 'submitted', 'approved', 'rejected', 'cancelled')),
+    CONSTRAINT org_change_requests_status_check CHECK (status IN ('draft', 'submitted', 'approved', 'rejected', 'applied', 'cancelled')),
     CONSTRAINT org_change_requests_tenant_id_request_id_key UNIQUE (tenant_id, request_id)
 );
 
 CREATE INDEX org_change_requests_tenant_requester_status_updated_idx ON org_change_requests (tenant_id, requester_id, status, updated_at DESC);
 
+CREATE INDEX org_change_requests_due_idx ON org_change_requests (scheduled_for, id)
+WHERE
+    status = 'approved' AND scheduled_for IS NOT NULL AND execution_error IS NULL;
+
+-- Change request approval chains: tenant chain templates + per-request step instances.
+CREATE TABLE org_approval_chain_steps (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    chain_code varchar(64) NOT NULL DEFAULT 'default',
+    step_order int NOT NULL,
+    role_code varchar(64) NOT NULL,
+    condition text NOT NULL DEFAULT 'always',
+    sla_hours int NOT NULL DEFAULT 72,
+    escalation_role_code varchar(64) NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_approval_chain_steps_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_approval_chain_steps_tenant_chain_order_key UNIQUE (tenant_id, chain_code, step_order),
+    CONSTRAINT org_approval_chain_steps_step_order_check CHECK (step_order >= 0),
+    CONSTRAINT org_approval_chain_steps_condition_check CHECK (condition IN ('always', 'fte_change', 'node_move', 'node_rescind')),
+    CONSTRAINT org_approval_chain_steps_sla_hours_check CHECK (sla_hours > 0)
+);
+
+CREATE TABLE org_change_request_approval_steps (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    change_request_id uuid NOT NULL,
+    step_order int NOT NULL,
+    role_code varchar(64) NOT NULL,
+    status text NOT NULL DEFAULT 'waiting',
+    approver_subjects jsonb NOT NULL DEFAULT '[]' ::jsonb,
+    sla_hours int NOT NULL DEFAULT 72,
+    due_at timestamptz NULL,
+    escalation_role_code varchar(64) NULL,
+    escalation_subjects jsonb NOT NULL DEFAULT '[]' ::jsonb,
+    escalated_at timestamptz NULL,
+    decided_by uuid NULL,
+    decided_at timestamptz NULL,
+    comment text NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_change_request_approval_steps_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_change_request_approval_steps_request_order_key UNIQUE (tenant_id, change_request_id, step_order),
+    CONSTRAINT org_change_request_approval_steps_status_check CHECK (status IN ('waiting', 'pending', 'approved', 'rejected', 'skipped')),
+    CONSTRAINT org_change_request_approval_steps_sla_hours_check CHECK (sla_hours > 0),
+    CONSTRAINT org_change_request_approval_steps_approver_subjects_is_array_check CHECK (jsonb_typeof(approver_subjects) = 'array'),
+    CONSTRAINT org_change_request_approval_steps_escalation_subjects_is_array_check CHECK (jsonb_typeof(escalation_subjects) = 'array'),
+    CONSTRAINT org_change_request_approval_steps_change_request_fk FOREIGN KEY (tenant_id, change_request_id) REFERENCES org_change_requests (tenant_id, id) ON DELETE CASCADE
+);
+
+CREATE INDEX org_change_request_approval_steps_tenant_status_due_idx ON org_change_request_approval_steps (tenant_id, status, due_at);
+
+-- What-if scenarios: staged batch commands evaluated without writing, convertible to change requests.
+CREATE TABLE org_scenarios (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    name varchar(255) NOT NULL,
+    description text NULL,
+    effective_date date NOT NULL,
+    commands jsonb NOT NULL DEFAULT '[]'::jsonb,
+    status text NOT NULL DEFAULT 'draft',
+    change_request_id uuid NULL,
+    created_by uuid NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_scenarios_tenant_id_id_key UNIQUE (tenant_id, id),
+    CONSTRAINT org_scenarios_status_check CHECK (status IN ('draft', 'converted')),
+    CONSTRAINT org_scenarios_commands_is_array_check CHECK (jsonb_typeof(commands) = 'array'),
+    CONSTRAINT org_scenarios_converted_check CHECK ((status = 'converted') = (change_request_id IS NOT NULL)),
+    CONSTRAINT org_scenarios_change_request_fk FOREIGN KEY (tenant_id, change_request_id) REFERENCES org_change_requests (tenant_id, id) ON DELETE RESTRICT
+);
+
+CREATE INDEX org_scenarios_tenant_updated_idx ON org_scenarios (tenant_id, updated_at DESC, id);
+
 -- DEV-PLAN-025: org_settings + org_audit_logs (schema SSOT; migrations/org applies the same DDL).
 CREATE TABLE org_settings (
     tenant_id uuid PRIMARY KEY REFERENCES tenants (id) ON DELETE CASCADE,
@@ -785,13 +1031,15 @@ CREATE TABLE org_settings (
     position_catalog_validation_mode text NOT NULL DEFAULT 'shadow',
     position_restrictions_validation_mode text NOT NULL DEFAULT 'shadow',
     reason_code_mode text NOT NULL DEFAULT 'shadow',
+    position_budget_validation_mode text NOT NULL DEFAULT 'disabled',
     created_at timestamptz NOT NULL DEFAULT now(),
     updated_at timestamptz NOT NULL DEFAULT now(),
     CONSTRAINT org_settings_freeze_mode_check CHECK (freeze_mode IN ('disabled', 'shadow', 'enforce')),
     CONSTRAINT org_settings_freeze_grace_days_check CHECK (freeze_grace_days >= 0 AND freeze_grace_days <= 31),
     CONSTRAINT org_settings_position_catalog_validation_mode_check CHECK (position_catalog_validation_mode IN ('disabled', 'shadow', 'enforce')),
     CONSTRAINT org_settings_position_restrictions_validation_mode_check CHECK (position_restrictions_validation_mode IN ('disabled', 'shadow', 'enforce')),
-    CONSTRAINT org_settings_reason_code_mode_check CHECK (reason_code_mode IN ('disabled', 'shadow', 'enforce'))
+    CONSTRAINT org_settings_reason_code_mode_check CHECK (reason_code_mode IN ('disabled', 'shadow', 'enforce')),
+    CONSTRAINT org_settings_position_budget_validation_mode_check CHECK (position_budget_validation_mode IN ('disabled', 'shadow', 'enforce'))
 );
 
 CREATE TABLE org_audit_logs (
@@ -810,7 +1058,7 @@ CREATE TABLE org_audit_logs (
     meta jsonb NOT NULL DEFAULT '{}' ::jsonb,
     created_at timestamptz NOT NULL DEFAULT now(),
     CONSTRAINT org_audit_logs_effective_check CHECK (effective_date <= end_date),
-    CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment'))
+    CONSTRAINT org_audit_logs_entity_type_check CHECK (entity_type IN ('org_node', 'org_edge', 'org_position', 'org_assignment', 'org_role', 'org_role_assignment', 'org_delegation', 'org_inheritance_rule', 'org_inheritance_break', 'org_attribute_definition', 'org_freeze_period'))
 );
 
 CREATE INDEX org_audit_logs_tenant_transaction_time_desc_idx ON org_audit_logs (tenant_id, transaction_time DESC);
@@ -917,7 +1165,7 @@ CREATE TABLE org_personnel_events (
     created_at timestamptz NOT NULL DEFAULT now(),
     updated_at timestamptz NOT NULL DEFAULT now(),
     CONSTRAINT org_personnel_events_request_not_blank CHECK (btrim(request_id) <> ''),
-    CONSTRAINT org_personnel_events_event_type_check CHECK (event_type IN ('hire', 'transfer', 'termination')),
+    CONSTRAINT org_personnel_events_event_type_check CHECK (event_type IN ('hire', 'transfer', 'termination', 'promotion', 'leave', 'return', 'rehire', 'fte_change')),
     CONSTRAINT org_personnel_events_pernr_not_blank CHECK (btrim(pernr) <> ''),
     CONSTRAINT org_personnel_events_reason_code_not_blank CHECK (btrim(reason_code) <> ''),
     CONSTRAINT org_personnel_events_payload_is_object CHECK (jsonb_typeof(payload) = 'object'),
@@ -1601,3 +1849,75 @@ CREATE CONSTRAINT TRIGGER org_job_profile_slices_gap_free
     EXECUTE FUNCTION org_job_profile_slices_gap_free_trigger ();
 
 -- EOF
+
+CREATE TABLE org_hierarchy_memberships (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    subject_type text NOT NULL,
+    subject_id uuid NOT NULL,
+    hierarchy_type text NOT NULL,
+    org_node_id uuid NOT NULL,
+    effective_date date NOT NULL,
+    end_date date NOT NULL DEFAULT DATE '9999-12-31',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_hierarchy_memberships_subject_type_check CHECK (subject_type IN ('position', 'assignment')),
+    CONSTRAINT org_hierarchy_memberships_hierarchy_type_check CHECK (hierarchy_type IN ('CostCenter', 'LegalEntity', 'Geographic', 'Project')),
+    CONSTRAINT org_hierarchy_memberships_effective_check CHECK (effective_date <= end_date),
+    CONSTRAINT org_hierarchy_memberships_node_fk FOREIGN KEY (tenant_id, org_node_id) REFERENCES org_nodes (tenant_id, id) ON DELETE RESTRICT,
+    CONSTRAINT org_hierarchy_memberships_subject_no_overlap EXCLUDE USING gist (tenant_id gist_uuid_ops WITH =, subject_type gist_text_ops WITH =, subject_id gist_uuid_ops WITH =, hierarchy_type gist_text_ops WITH =, daterange(effective_date, end_date + 1, '[)') WITH &&)
+);
+
+CREATE INDEX org_hierarchy_memberships_tenant_subject_idx ON org_hierarchy_memberships (tenant_id, subject_type, subject_id, effective_date);
+
+CREATE INDEX org_hierarchy_memberships_tenant_node_idx ON org_hierarchy_memberships (tenant_id, hierarchy_type, org_node_id, effective_date);
+
+CREATE OR REPLACE FUNCTION org_hierarchy_memberships_check_node_type ()
+    RETURNS TRIGGER
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    node_type text;
+BEGIN
+    SELECT
+        n.type INTO node_type
+    FROM
+        org_nodes n
+    WHERE
+        n.tenant_id = NEW.tenant_id
+        AND n.id = NEW.org_node_id;
+    IF node_type IS DISTINCT FROM NEW.hierarchy_type THEN
+        RAISE EXCEPTION 'org_hierarchy_memberships: node type % does not match hierarchy_type %', node_type, NEW.hierarchy_type
+            USING ERRCODE = 'check_violation';
+        END IF;
+        RETURN NEW;
+END;
+$$;
+
+CREATE TRIGGER org_hierarchy_memberships_before_write_check_node_type
+    BEFORE INSERT OR UPDATE OF hierarchy_type, org_node_id ON org_hierarchy_memberships
+    FOR EACH ROW
+    EXECUTE FUNCTION org_hierarchy_memberships_check_node_type ();
+
+CREATE TABLE org_headcount_budgets (
+    tenant_id uuid NOT NULL REFERENCES tenants (id) ON DELETE CASCADE,
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid (),
+    org_node_id uuid NOT NULL,
+    job_profile_id uuid NULL,
+    job_level_code varchar(64) NULL,
+    planned_headcount int NOT NULL,
+    planned_fte numeric(9, 2) NOT NULL,
+    planned_cost numeric(14, 2) NULL,
+    effective_date date NOT NULL,
+    end_date date NOT NULL DEFAULT DATE '9999-12-31',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT org_headcount_budgets_effective_check CHECK (effective_date <= end_date),
+    CONSTRAINT org_headcount_budgets_planned_headcount_check CHECK (planned_headcount >= 0),
+    CONSTRAINT org_headcount_budgets_planned_fte_check CHECK (planned_fte >= 0),
+    CONSTRAINT org_headcount_budgets_planned_cost_check CHECK (planned_cost IS NULL OR planned_cost >= 0),
+    CONSTRAINT org_headcount_budgets_node_fk FOREIGN KEY (tenant_id, org_node_id) REFERENCES org_nodes (tenant_id, id) ON DELETE RESTRICT,
+    CONSTRAINT org_headcount_budgets_line_no_overlap EXCLUDE USING gist (tenant_id gist_uuid_ops WITH =, org_node_id gist_uuid_ops WITH =, (COALESCE(job_profile_id, '00000000-0000-0000-0000-000000000000'::uuid)) gist_uuid_ops WITH =, (COALESCE(job_level_code, '')) gist_text_ops WITH =, daterange(effective_date, end_date + 1, '[)') WITH &&)
+);
+
+CREATE INDEX org_headcount_budgets_tenant_node_idx ON org_headcount_budgets (tenant_id, org_node_id, effective_date);
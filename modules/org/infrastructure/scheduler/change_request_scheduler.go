@@ -0,0 +1,104 @@
+package scheduler
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+type Options struct {
+	PollInterval time.Duration
+	BatchSize    int
+	Logger       *logrus.Entry
+}
+
+func (o *Options) setDefaults() {
+	if o.PollInterval <= 0 {
+		o.PollInterval = 30 * time.Second
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 50
+	}
+	if o.Logger == nil {
+		l := logrus.New()
+		l.SetOutput(io.Discard)
+		o.Logger = logrus.NewEntry(l)
+	}
+}
+
+// ChangeRequestScheduler polls for approved change requests whose scheduled_for has passed
+// and applies them through services.ChangeRequestExecutor, then escalates overdue approval
+// steps. Concurrent schedulers are safe: the apply transaction re-checks the status and only
+// one of them can move a request to applied.
+type ChangeRequestScheduler struct {
+	executor *services.ChangeRequestExecutor
+	pool     *pgxpool.Pool
+	opts     Options
+}
+
+func NewChangeRequestScheduler(executor *services.ChangeRequestExecutor, pool *pgxpool.Pool, opts Options) *ChangeRequestScheduler {
+	opts.setDefaults()
+	return &ChangeRequestScheduler{executor: executor, pool: pool, opts: opts}
+}
+
+func (s *ChangeRequestScheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.opts.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		if err := s.RunOnce(ctx, time.Now().UTC()); err != nil {
+			s.opts.Logger.WithError(err).Warn("org change request scheduler: tick failed")
+		}
+	}
+}
+
+// RunOnce executes one scheduler pass. Failures of individual requests are recorded on the
+// request and logged; only failures to list work are returned.
+func (s *ChangeRequestScheduler) RunOnce(ctx context.Context, now time.Time) error {
+	ctx = composables.WithPool(ctx, s.pool)
+
+	due, err := s.executor.ListDue(ctx, now, s.opts.BatchSize)
+	if err != nil {
+		return err
+	}
+	for _, ref := range due {
+		log := s.opts.Logger.WithField("tenant_id", ref.TenantID.String()).WithField("change_request_id", ref.ID.String())
+		applied, err := s.executor.ApplyDue(ctx, ref, now)
+		if err != nil {
+			log.WithError(err).Warn("org change request scheduler: apply failed")
+			continue
+		}
+		if applied {
+			log.Info("org change request scheduler: applied")
+		}
+	}
+
+	tenants, err := s.executor.ListTenantsWithOverdueApprovals(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, tenantID := range tenants {
+		log := s.opts.Logger.WithField("tenant_id", tenantID.String())
+		steps, err := s.executor.EscalateOverdueForTenant(ctx, tenantID, now, s.opts.BatchSize)
+		if err != nil {
+			log.WithError(err).Warn("org change request scheduler: escalation failed")
+			continue
+		}
+		if len(steps) > 0 {
+			log.WithField("escalated", len(steps)).Info("org change request scheduler: escalated overdue approval steps")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+-- name: ListOrgApprovalChainSteps :many
+SELECT
+    tenant_id,
+    id,
+    chain_code,
+    step_order,
+    role_code,
+    condition,
+    sla_hours,
+    escalation_role_code,
+    created_at,
+    updated_at
+FROM
+    org_approval_chain_steps
+WHERE
+    tenant_id = $1
+    AND chain_code = $2
+ORDER BY
+    step_order ASC;
+
+-- name: DeleteOrgApprovalChainSteps :exec
+DELETE FROM org_approval_chain_steps
+WHERE tenant_id = $1
+    AND chain_code = $2;
+
+-- name: InsertOrgApprovalChainStep :one
+INSERT INTO org_approval_chain_steps (tenant_id, chain_code, step_order, role_code, condition, sla_hours, escalation_role_code)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING
+    tenant_id, id, chain_code, step_order, role_code, condition, sla_hours, escalation_role_code, created_at, updated_at;
+
+-- name: ListOrgApprovalRoleHolders :many
+-- Holders of role_code on the nearest ancestor (depth 0 = the node itself) of each
+-- affected node, resolved through the active closure build. Users acting for a role
+-- holder through a role delegation count as holders while the delegation is in force.
+WITH grants AS (
+    SELECT
+        a.subject_type,
+        a.subject_id,
+        a.org_node_id
+    FROM
+        org_role_assignments a
+        JOIN org_roles r ON r.tenant_id = a.tenant_id
+            AND r.id = a.role_id
+            AND r.code = sqlc.arg(role_code)
+    WHERE
+        a.tenant_id = sqlc.arg(tenant_id)
+        AND a.effective_date <= sqlc.arg(as_of)::date
+        AND a.end_date >= sqlc.arg(as_of)::date
+    UNION ALL
+    SELECT
+        'user' AS subject_type,
+        d.delegate_subject_id AS subject_id,
+        d.org_node_id
+    FROM
+        org_delegations d
+        JOIN org_roles r ON r.tenant_id = d.tenant_id
+            AND r.id = d.role_id
+            AND r.code = sqlc.arg(role_code)
+    WHERE
+        d.tenant_id = sqlc.arg(tenant_id)
+        AND d.delegation_type = 'role'
+        AND d.effective_date <= sqlc.arg(as_of)::date
+        AND d.end_date >= sqlc.arg(as_of)::date
+),
+holders AS (
+    SELECT
+        g.subject_type,
+        g.subject_id,
+        c.depth,
+        min(c.depth) OVER (PARTITION BY c.descendant_node_id) AS nearest_depth
+    FROM
+        org_hierarchy_closure c
+        JOIN grants g ON g.org_node_id = c.ancestor_node_id
+    WHERE
+        c.tenant_id = sqlc.arg(tenant_id)
+        AND c.hierarchy_type = 'OrgUnit'
+        AND c.build_id = sqlc.arg(build_id)
+        AND c.descendant_node_id = ANY (sqlc.arg(node_ids)::uuid[])
+        AND c.effective_date <= sqlc.arg(as_of)::date
+        AND c.end_date >= sqlc.arg(as_of)::date
+)
+SELECT DISTINCT
+    subject_type,
+    subject_id
+FROM
+    holders
+WHERE
+    depth = nearest_depth
+ORDER BY
+    subject_type,
+    subject_id;
+
+-- name: InsertOrgChangeRequestApprovalStep :one
+INSERT INTO org_change_request_approval_steps (tenant_id, change_request_id, step_order, role_code, status, approver_subjects, sla_hours, due_at, escalation_role_code)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING
+    tenant_id, id, change_request_id, step_order, role_code, status, approver_subjects, sla_hours, due_at, escalation_role_code, escalation_subjects, escalated_at, decided_by, decided_at, comment, created_at, updated_at;
+
+-- name: ListOrgChangeRequestApprovalSteps :many
+SELECT
+    tenant_id,
+    id,
+    change_request_id,
+    step_order,
+    role_code,
+    status,
+    approver_subjects,
+    sla_hours,
+    due_at,
+    escalation_role_code,
+    escalation_subjects,
+    escalated_at,
+    decided_by,
+    decided_at,
+    comment,
+    created_at,
+    updated_at
+FROM
+    org_change_request_approval_steps
+WHERE
+    tenant_id = $1
+    AND change_request_id = $2
+ORDER BY
+    step_order ASC;
+
+-- name: DecideOrgChangeRequestApprovalStep :one
+UPDATE
+    org_change_request_approval_steps
+SET
+    status = sqlc.arg(to_status),
+    decided_by = sqlc.narg(decided_by),
+    decided_at = now(),
+    comment = sqlc.narg(comment),
+    updated_at = now()
+WHERE
+    tenant_id = sqlc.arg(tenant_id)
+    AND id = sqlc.arg(id)
+    AND status = sqlc.arg(from_status)
+RETURNING
+    tenant_id,
+    id,
+    change_request_id,
+    step_order,
+    role_code,
+    status,
+    approver_subjects,
+    sla_hours,
+    due_at,
+    escalation_role_code,
+    escalation_subjects,
+    escalated_at,
+    decided_by,
+    decided_at,
+    comment,
+    created_at,
+    updated_at;
+
+-- name: ActivateOrgChangeRequestApprovalStep :one
+UPDATE
+    org_change_request_approval_steps
+SET
+    status = 'pending',
+    due_at = $3,
+    updated_at = now()
+WHERE
+    tenant_id = $1
+    AND id = $2
+    AND status = 'waiting'
+RETURNING
+    tenant_id,
+    id,
+    change_request_id,
+    step_order,
+    role_code,
+    status,
+    approver_subjects,
+    sla_hours,
+    due_at,
+    escalation_role_code,
+    escalation_subjects,
+    escalated_at,
+    decided_by,
+    decided_at,
+    comment,
+    created_at,
+    updated_at;
+
+-- name: EscalateOrgChangeRequestApprovalStep :one
+UPDATE
+    org_change_request_approval_steps
+SET
+    escalation_subjects = $3,
+    escalated_at = now(),
+    updated_at = now()
+WHERE
+    tenant_id = $1
+    AND id = $2
+    AND status = 'pending'
+    AND escalated_at IS NULL
+RETURNING
+    tenant_id,
+    id,
+    change_request_id,
+    step_order,
+    role_code,
+    status,
+    approver_subjects,
+    sla_hours,
+    due_at,
+    escalation_role_code,
+    escalation_subjects,
+    escalated_at,
+    decided_by,
+    decided_at,
+    comment,
+    created_at,
+    updated_at;
+
+-- name: ListOverdueOrgChangeRequestApprovalSteps :many
+SELECT
+    tenant_id,
+    id,
+    change_request_id,
+    step_order,
+    role_code,
+    status,
+    approver_subjects,
+    sla_hours,
+    due_at,
+    escalation_role_code,
+    escalation_subjects,
+    escalated_at,
+    decided_by,
+    decided_at,
+    comment,
+    created_at,
+    updated_at
+FROM
+    org_change_request_approval_steps
+WHERE
+    tenant_id = $1
+    AND status = 'pending'
+    AND escalated_at IS NULL
+    AND due_at < $2
+ORDER BY
+    due_at ASC,
+    id ASC
+LIMIT $3;
+
+-- name: ListTenantsWithOverdueOrgApprovalSteps :many
+SELECT DISTINCT
+    tenant_id
+FROM
+    org_change_request_approval_steps
+WHERE
+    status = 'pending'
+    AND escalated_at IS NULL
+    AND due_at < $1
+ORDER BY
+    tenant_id;
@@ -8,6 +8,19 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type OrgApprovalChainStep struct {
+	TenantID           pgtype.UUID        `json:"tenant_id"`
+	ID                 pgtype.UUID        `json:"id"`
+	ChainCode          string             `json:"chain_code"`
+	StepOrder          int32              `json:"step_order"`
+	RoleCode           string             `json:"role_code"`
+	Condition          string             `json:"condition"`
+	SlaHours           int32              `json:"sla_hours"`
+	EscalationRoleCode *string            `json:"escalation_role_code"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+}
+
 type OrgAssignment struct {
 	TenantID         pgtype.UUID        `json:"tenant_id"`
 	ID               pgtype.UUID        `json:"id"`
@@ -64,10 +77,41 @@ type OrgChangeRequest struct {
 	PayloadSchemaVersion int32              `json:"payload_schema_version"`
 	Payload              []byte             `json:"payload"`
 	Notes                *string            `json:"notes"`
+	ReviewerID           pgtype.UUID        `json:"reviewer_id"`
+	ReviewComment        *string            `json:"review_comment"`
+	ReviewedAt           pgtype.Timestamptz `json:"reviewed_at"`
+	AppliedBy            pgtype.UUID        `json:"applied_by"`
+	AppliedAt            pgtype.Timestamptz `json:"applied_at"`
+	ScheduledFor         pgtype.Timestamptz `json:"scheduled_for"`
+	ExecutionAttempts    int32              `json:"execution_attempts"`
+	LastExecutedAt       pgtype.Timestamptz `json:"last_executed_at"`
+	ExecutionResult      []byte             `json:"execution_result"`
+	ExecutionError       []byte             `json:"execution_error"`
+	AuditRequestID       *string            `json:"audit_request_id"`
 	CreatedAt            pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt            pgtype.Timestamptz `json:"updated_at"`
 }
 
+type OrgChangeRequestApprovalStep struct {
+	TenantID           pgtype.UUID        `json:"tenant_id"`
+	ID                 pgtype.UUID        `json:"id"`
+	ChangeRequestID    pgtype.UUID        `json:"change_request_id"`
+	StepOrder          int32              `json:"step_order"`
+	RoleCode           string             `json:"role_code"`
+	Status             string             `json:"status"`
+	ApproverSubjects   []byte             `json:"approver_subjects"`
+	SlaHours           int32              `json:"sla_hours"`
+	DueAt              pgtype.Timestamptz `json:"due_at"`
+	EscalationRoleCode *string            `json:"escalation_role_code"`
+	EscalationSubjects []byte             `json:"escalation_subjects"`
+	EscalatedAt        pgtype.Timestamptz `json:"escalated_at"`
+	DecidedBy          pgtype.UUID        `json:"decided_by"`
+	DecidedAt          pgtype.Timestamptz `json:"decided_at"`
+	Comment            *string            `json:"comment"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt          pgtype.Timestamptz `json:"updated_at"`
+}
+
 type OrgEdge struct {
 	TenantID      pgtype.UUID        `json:"tenant_id"`
 	ID            pgtype.UUID        `json:"id"`
@@ -104,6 +148,19 @@ type OrgHierarchyClosureBuild struct {
 	Notes           *string            `json:"notes"`
 }
 
+type OrgHierarchyMembership struct {
+	TenantID      pgtype.UUID        `json:"tenant_id"`
+	ID            pgtype.UUID        `json:"id"`
+	SubjectType   string             `json:"subject_type"`
+	SubjectID     pgtype.UUID        `json:"subject_id"`
+	HierarchyType string             `json:"hierarchy_type"`
+	OrgNodeID     pgtype.UUID        `json:"org_node_id"`
+	EffectiveDate pgtype.Date        `json:"effective_date"`
+	EndDate       pgtype.Date        `json:"end_date"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+}
+
 type OrgHierarchySnapshot struct {
 	TenantID         pgtype.UUID `json:"tenant_id"`
 	HierarchyType    string      `json:"hierarchy_type"`
@@ -420,6 +477,7 @@ type OrgSetting struct {
 	PositionCatalogValidationMode      string             `json:"position_catalog_validation_mode"`
 	PositionRestrictionsValidationMode string             `json:"position_restrictions_validation_mode"`
 	ReasonCodeMode                     string             `json:"reason_code_mode"`
+	PositionBudgetValidationMode       string             `json:"position_budget_validation_mode"`
 	CreatedAt                          pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                          pgtype.Timestamptz `json:"updated_at"`
 }
@@ -5,7 +5,7 @@ ON CONFLICT (tenant_id, request_id)
     DO UPDATE SET
         requester_id = EXCLUDED.requester_id, status = EXCLUDED.status, payload_schema_version = EXCLUDED.payload_schema_version, payload = EXCLUDED.payload, notes = EXCLUDED.notes, updated_at = now()
     RETURNING
-        tenant_id, id, request_id, requester_id, status, payload_schema_version, payload, notes, created_at, updated_at;
+        tenant_id, id, request_id, requester_id, status, payload_schema_version, payload, notes, reviewer_id, review_comment, reviewed_at, applied_by, applied_at, scheduled_for, execution_attempts, last_executed_at, execution_result, execution_error, audit_request_id, created_at, updated_at;
 
 -- name: UpdateOrgChangeRequestDraftByID :one
 UPDATE
@@ -27,6 +27,17 @@ RETURNING
     payload_schema_version,
     payload,
     notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
     created_at,
     updated_at;
 
@@ -48,6 +59,88 @@ RETURNING
     payload_schema_version,
     payload,
     notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
+    created_at,
+    updated_at;
+
+-- name: UpdateOrgChangeRequestReviewByID :one
+UPDATE
+    org_change_requests
+SET
+    status = sqlc.arg(to_status),
+    reviewer_id = sqlc.arg(reviewer_id),
+    review_comment = sqlc.narg(review_comment),
+    reviewed_at = now(),
+    updated_at = now()
+WHERE
+    tenant_id = sqlc.arg(tenant_id)
+    AND id = sqlc.arg(id)
+    AND status = sqlc.arg(from_status)
+RETURNING
+    tenant_id,
+    id,
+    request_id,
+    requester_id,
+    status,
+    payload_schema_version,
+    payload,
+    notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
+    created_at,
+    updated_at;
+
+-- name: MarkOrgChangeRequestAppliedByID :one
+UPDATE
+    org_change_requests
+SET
+    status = 'applied',
+    applied_by = $3,
+    applied_at = now(),
+    updated_at = now()
+WHERE
+    tenant_id = $1
+    AND id = $2
+    AND status = 'approved'
+RETURNING
+    tenant_id,
+    id,
+    request_id,
+    requester_id,
+    status,
+    payload_schema_version,
+    payload,
+    notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
     created_at,
     updated_at;
 
@@ -61,6 +154,17 @@ SELECT
     payload_schema_version,
     payload,
     notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
     created_at,
     updated_at
 FROM
@@ -79,6 +183,17 @@ SELECT
     payload_schema_version,
     payload,
     notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
     created_at,
     updated_at
 FROM
@@ -97,6 +212,17 @@ SELECT
     payload_schema_version,
     payload,
     notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
     created_at,
     updated_at
 FROM
@@ -115,3 +241,90 @@ ORDER BY
     id DESC
 LIMIT $5;
 
+
+-- name: ScheduleOrgChangeRequestByID :one
+UPDATE
+    org_change_requests
+SET
+    scheduled_for = sqlc.narg(scheduled_for),
+    execution_error = NULL,
+    updated_at = now()
+WHERE
+    tenant_id = sqlc.arg(tenant_id)
+    AND id = sqlc.arg(id)
+    AND status IN ('draft', 'submitted', 'approved')
+RETURNING
+    tenant_id,
+    id,
+    request_id,
+    requester_id,
+    status,
+    payload_schema_version,
+    payload,
+    notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
+    created_at,
+    updated_at;
+
+-- name: RecordOrgChangeRequestExecutionByID :one
+UPDATE
+    org_change_requests
+SET
+    execution_attempts = execution_attempts + 1,
+    last_executed_at = now(),
+    execution_result = sqlc.narg(execution_result),
+    execution_error = sqlc.narg(execution_error),
+    audit_request_id = sqlc.narg(audit_request_id),
+    updated_at = now()
+WHERE
+    tenant_id = sqlc.arg(tenant_id)
+    AND id = sqlc.arg(id)
+RETURNING
+    tenant_id,
+    id,
+    request_id,
+    requester_id,
+    status,
+    payload_schema_version,
+    payload,
+    notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
+    created_at,
+    updated_at;
+
+-- name: ListDueOrgChangeRequests :many
+-- Cross-tenant scan for the scheduler; rows that failed stay parked until rescheduled.
+SELECT
+    tenant_id,
+    id
+FROM
+    org_change_requests
+WHERE
+    status = 'approved'
+    AND scheduled_for IS NOT NULL
+    AND scheduled_for <= $1
+    AND execution_error IS NULL
+ORDER BY
+    scheduled_for ASC,
+    id ASC
+LIMIT $2;
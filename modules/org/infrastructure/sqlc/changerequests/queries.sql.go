@@ -21,6 +21,17 @@ SELECT
     payload_schema_version,
     payload,
     notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
     created_at,
     updated_at
 FROM
@@ -47,6 +58,17 @@ func (q *Queries) GetOrgChangeRequestByID(ctx context.Context, arg GetOrgChangeR
 		&i.PayloadSchemaVersion,
 		&i.Payload,
 		&i.Notes,
+		&i.ReviewerID,
+		&i.ReviewComment,
+		&i.ReviewedAt,
+		&i.AppliedBy,
+		&i.AppliedAt,
+		&i.ScheduledFor,
+		&i.ExecutionAttempts,
+		&i.LastExecutedAt,
+		&i.ExecutionResult,
+		&i.ExecutionError,
+		&i.AuditRequestID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -63,6 +85,17 @@ SELECT
     payload_schema_version,
     payload,
     notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
     created_at,
     updated_at
 FROM
@@ -89,12 +122,71 @@ func (q *Queries) GetOrgChangeRequestByRequestID(ctx context.Context, arg GetOrg
 		&i.PayloadSchemaVersion,
 		&i.Payload,
 		&i.Notes,
+		&i.ReviewerID,
+		&i.ReviewComment,
+		&i.ReviewedAt,
+		&i.AppliedBy,
+		&i.AppliedAt,
+		&i.ScheduledFor,
+		&i.ExecutionAttempts,
+		&i.LastExecutedAt,
+		&i.ExecutionResult,
+		&i.ExecutionError,
+		&i.AuditRequestID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
 	return i, err
 }
 
+const listDueOrgChangeRequests = `-- name: ListDueOrgChangeRequests :many
+SELECT
+    tenant_id,
+    id
+FROM
+    org_change_requests
+WHERE
+    status = 'approved'
+    AND scheduled_for IS NOT NULL
+    AND scheduled_for <= $1
+    AND execution_error IS NULL
+ORDER BY
+    scheduled_for ASC,
+    id ASC
+LIMIT $2
+`
+
+type ListDueOrgChangeRequestsParams struct {
+	ScheduledFor pgtype.Timestamptz `json:"scheduled_for"`
+	Limit        int32              `json:"limit"`
+}
+
+type ListDueOrgChangeRequestsRow struct {
+	TenantID pgtype.UUID `json:"tenant_id"`
+	ID       pgtype.UUID `json:"id"`
+}
+
+// Cross-tenant scan for the scheduler; rows that failed stay parked until rescheduled.
+func (q *Queries) ListDueOrgChangeRequests(ctx context.Context, arg ListDueOrgChangeRequestsParams) ([]ListDueOrgChangeRequestsRow, error) {
+	rows, err := q.db.Query(ctx, listDueOrgChangeRequests, arg.ScheduledFor, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListDueOrgChangeRequestsRow{}
+	for rows.Next() {
+		var i ListDueOrgChangeRequestsRow
+		if err := rows.Scan(&i.TenantID, &i.ID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listOrgChangeRequests = `-- name: ListOrgChangeRequests :many
 SELECT
     tenant_id,
@@ -105,6 +197,17 @@ SELECT
     payload_schema_version,
     payload,
     notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
     created_at,
     updated_at
 FROM
@@ -156,6 +259,17 @@ func (q *Queries) ListOrgChangeRequests(ctx context.Context, arg ListOrgChangeRe
 			&i.PayloadSchemaVersion,
 			&i.Payload,
 			&i.Notes,
+			&i.ReviewerID,
+			&i.ReviewComment,
+			&i.ReviewedAt,
+			&i.AppliedBy,
+			&i.AppliedAt,
+			&i.ScheduledFor,
+			&i.ExecutionAttempts,
+			&i.LastExecutedAt,
+			&i.ExecutionResult,
+			&i.ExecutionError,
+			&i.AuditRequestID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 		); err != nil {
@@ -169,6 +283,227 @@ func (q *Queries) ListOrgChangeRequests(ctx context.Context, arg ListOrgChangeRe
 	return items, nil
 }
 
+const markOrgChangeRequestAppliedByID = `-- name: MarkOrgChangeRequestAppliedByID :one
+UPDATE
+    org_change_requests
+SET
+    status = 'applied',
+    applied_by = $3,
+    applied_at = now(),
+    updated_at = now()
+WHERE
+    tenant_id = $1
+    AND id = $2
+    AND status = 'approved'
+RETURNING
+    tenant_id,
+    id,
+    request_id,
+    requester_id,
+    status,
+    payload_schema_version,
+    payload,
+    notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
+    created_at,
+    updated_at
+`
+
+type MarkOrgChangeRequestAppliedByIDParams struct {
+	TenantID  pgtype.UUID `json:"tenant_id"`
+	ID        pgtype.UUID `json:"id"`
+	AppliedBy pgtype.UUID `json:"applied_by"`
+}
+
+func (q *Queries) MarkOrgChangeRequestAppliedByID(ctx context.Context, arg MarkOrgChangeRequestAppliedByIDParams) (OrgChangeRequest, error) {
+	row := q.db.QueryRow(ctx, markOrgChangeRequestAppliedByID, arg.TenantID, arg.ID, arg.AppliedBy)
+	var i OrgChangeRequest
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.RequestID,
+		&i.RequesterID,
+		&i.Status,
+		&i.PayloadSchemaVersion,
+		&i.Payload,
+		&i.Notes,
+		&i.ReviewerID,
+		&i.ReviewComment,
+		&i.ReviewedAt,
+		&i.AppliedBy,
+		&i.AppliedAt,
+		&i.ScheduledFor,
+		&i.ExecutionAttempts,
+		&i.LastExecutedAt,
+		&i.ExecutionResult,
+		&i.ExecutionError,
+		&i.AuditRequestID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const recordOrgChangeRequestExecutionByID = `-- name: RecordOrgChangeRequestExecutionByID :one
+UPDATE
+    org_change_requests
+SET
+    execution_attempts = execution_attempts + 1,
+    last_executed_at = now(),
+    execution_result = $1,
+    execution_error = $2,
+    audit_request_id = $3,
+    updated_at = now()
+WHERE
+    tenant_id = $4
+    AND id = $5
+RETURNING
+    tenant_id,
+    id,
+    request_id,
+    requester_id,
+    status,
+    payload_schema_version,
+    payload,
+    notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
+    created_at,
+    updated_at
+`
+
+type RecordOrgChangeRequestExecutionByIDParams struct {
+	ExecutionResult []byte      `json:"execution_result"`
+	ExecutionError  []byte      `json:"execution_error"`
+	AuditRequestID  *string     `json:"audit_request_id"`
+	TenantID        pgtype.UUID `json:"tenant_id"`
+	ID              pgtype.UUID `json:"id"`
+}
+
+func (q *Queries) RecordOrgChangeRequestExecutionByID(ctx context.Context, arg RecordOrgChangeRequestExecutionByIDParams) (OrgChangeRequest, error) {
+	row := q.db.QueryRow(ctx, recordOrgChangeRequestExecutionByID,
+		arg.ExecutionResult,
+		arg.ExecutionError,
+		arg.AuditRequestID,
+		arg.TenantID,
+		arg.ID,
+	)
+	var i OrgChangeRequest
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.RequestID,
+		&i.RequesterID,
+		&i.Status,
+		&i.PayloadSchemaVersion,
+		&i.Payload,
+		&i.Notes,
+		&i.ReviewerID,
+		&i.ReviewComment,
+		&i.ReviewedAt,
+		&i.AppliedBy,
+		&i.AppliedAt,
+		&i.ScheduledFor,
+		&i.ExecutionAttempts,
+		&i.LastExecutedAt,
+		&i.ExecutionResult,
+		&i.ExecutionError,
+		&i.AuditRequestID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const scheduleOrgChangeRequestByID = `-- name: ScheduleOrgChangeRequestByID :one
+UPDATE
+    org_change_requests
+SET
+    scheduled_for = $1,
+    execution_error = NULL,
+    updated_at = now()
+WHERE
+    tenant_id = $2
+    AND id = $3
+    AND status IN ('draft', 'submitted', 'approved')
+RETURNING
+    tenant_id,
+    id,
+    request_id,
+    requester_id,
+    status,
+    payload_schema_version,
+    payload,
+    notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
+    created_at,
+    updated_at
+`
+
+type ScheduleOrgChangeRequestByIDParams struct {
+	ScheduledFor pgtype.Timestamptz `json:"scheduled_for"`
+	TenantID     pgtype.UUID        `json:"tenant_id"`
+	ID           pgtype.UUID        `json:"id"`
+}
+
+func (q *Queries) ScheduleOrgChangeRequestByID(ctx context.Context, arg ScheduleOrgChangeRequestByIDParams) (OrgChangeRequest, error) {
+	row := q.db.QueryRow(ctx, scheduleOrgChangeRequestByID, arg.ScheduledFor, arg.TenantID, arg.ID)
+	var i OrgChangeRequest
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.RequestID,
+		&i.RequesterID,
+		&i.Status,
+		&i.PayloadSchemaVersion,
+		&i.Payload,
+		&i.Notes,
+		&i.ReviewerID,
+		&i.ReviewComment,
+		&i.ReviewedAt,
+		&i.AppliedBy,
+		&i.AppliedAt,
+		&i.ScheduledFor,
+		&i.ExecutionAttempts,
+		&i.LastExecutedAt,
+		&i.ExecutionResult,
+		&i.ExecutionError,
+		&i.AuditRequestID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const updateOrgChangeRequestDraftByID = `-- name: UpdateOrgChangeRequestDraftByID :one
 UPDATE
     org_change_requests
@@ -189,6 +524,17 @@ RETURNING
     payload_schema_version,
     payload,
     notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
     created_at,
     updated_at
 `
@@ -217,6 +563,99 @@ func (q *Queries) UpdateOrgChangeRequestDraftByID(ctx context.Context, arg Updat
 		&i.PayloadSchemaVersion,
 		&i.Payload,
 		&i.Notes,
+		&i.ReviewerID,
+		&i.ReviewComment,
+		&i.ReviewedAt,
+		&i.AppliedBy,
+		&i.AppliedAt,
+		&i.ScheduledFor,
+		&i.ExecutionAttempts,
+		&i.LastExecutedAt,
+		&i.ExecutionResult,
+		&i.ExecutionError,
+		&i.AuditRequestID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateOrgChangeRequestReviewByID = `-- name: UpdateOrgChangeRequestReviewByID :one
+UPDATE
+    org_change_requests
+SET
+    status = $1,
+    reviewer_id = $2,
+    review_comment = $3,
+    reviewed_at = now(),
+    updated_at = now()
+WHERE
+    tenant_id = $4
+    AND id = $5
+    AND status = $6
+RETURNING
+    tenant_id,
+    id,
+    request_id,
+    requester_id,
+    status,
+    payload_schema_version,
+    payload,
+    notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
+    created_at,
+    updated_at
+`
+
+type UpdateOrgChangeRequestReviewByIDParams struct {
+	ToStatus      string      `json:"to_status"`
+	ReviewerID    pgtype.UUID `json:"reviewer_id"`
+	ReviewComment *string     `json:"review_comment"`
+	TenantID      pgtype.UUID `json:"tenant_id"`
+	ID            pgtype.UUID `json:"id"`
+	FromStatus    string      `json:"from_status"`
+}
+
+func (q *Queries) UpdateOrgChangeRequestReviewByID(ctx context.Context, arg UpdateOrgChangeRequestReviewByIDParams) (OrgChangeRequest, error) {
+	row := q.db.QueryRow(ctx, updateOrgChangeRequestReviewByID,
+		arg.ToStatus,
+		arg.ReviewerID,
+		arg.ReviewComment,
+		arg.TenantID,
+		arg.ID,
+		arg.FromStatus,
+	)
+	var i OrgChangeRequest
+	err := row.Scan(
+		&i.TenantID,
+		&i.ID,
+		&i.RequestID,
+		&i.RequesterID,
+		&i.Status,
+		&i.PayloadSchemaVersion,
+		&i.Payload,
+		&i.Notes,
+		&i.ReviewerID,
+		&i.ReviewComment,
+		&i.ReviewedAt,
+		&i.AppliedBy,
+		&i.AppliedAt,
+		&i.ScheduledFor,
+		&i.ExecutionAttempts,
+		&i.LastExecutedAt,
+		&i.ExecutionResult,
+		&i.ExecutionError,
+		&i.AuditRequestID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -241,6 +680,17 @@ RETURNING
     payload_schema_version,
     payload,
     notes,
+    reviewer_id,
+    review_comment,
+    reviewed_at,
+    applied_by,
+    applied_at,
+    scheduled_for,
+    execution_attempts,
+    last_executed_at,
+    execution_result,
+    execution_error,
+    audit_request_id,
     created_at,
     updated_at
 `
@@ -263,6 +713,17 @@ func (q *Queries) UpdateOrgChangeRequestStatusByID(ctx context.Context, arg Upda
 		&i.PayloadSchemaVersion,
 		&i.Payload,
 		&i.Notes,
+		&i.ReviewerID,
+		&i.ReviewComment,
+		&i.ReviewedAt,
+		&i.AppliedBy,
+		&i.AppliedAt,
+		&i.ScheduledFor,
+		&i.ExecutionAttempts,
+		&i.LastExecutedAt,
+		&i.ExecutionResult,
+		&i.ExecutionError,
+		&i.AuditRequestID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -276,7 +737,7 @@ ON CONFLICT (tenant_id, request_id)
     DO UPDATE SET
         requester_id = EXCLUDED.requester_id, status = EXCLUDED.status, payload_schema_version = EXCLUDED.payload_schema_version, payload = EXCLUDED.payload, notes = EXCLUDED.notes, updated_at = now()
     RETURNING
-        tenant_id, id, request_id, requester_id, status, payload_schema_version, payload, notes, created_at, updated_at
+        tenant_id, id, request_id, requester_id, status, payload_schema_version, payload, notes, reviewer_id, review_comment, reviewed_at, applied_by, applied_at, scheduled_for, execution_attempts, last_executed_at, execution_result, execution_error, audit_request_id, created_at, updated_at
 `
 
 type UpsertOrgChangeRequestParams struct {
@@ -309,6 +770,17 @@ func (q *Queries) UpsertOrgChangeRequest(ctx context.Context, arg UpsertOrgChang
 		&i.PayloadSchemaVersion,
 		&i.Payload,
 		&i.Notes,
+		&i.ReviewerID,
+		&i.ReviewComment,
+		&i.ReviewedAt,
+		&i.AppliedBy,
+		&i.AppliedAt,
+		&i.ScheduledFor,
+		&i.ExecutionAttempts,
+		&i.LastExecutedAt,
+		&i.ExecutionResult,
+		&i.ExecutionError,
+		&i.AuditRequestID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 	)
@@ -27,6 +27,38 @@ var OrgLink = types.NavigationItem{
 			AuthzObject: "org.positions",
 			AuthzAction: "read",
 		},
+		{
+			Name:        "NavigationLinks.OrgBudgets",
+			Icon:        nil,
+			Href:        "/org/budgets",
+			Children:    nil,
+			AuthzObject: "org.budgets",
+			AuthzAction: "read",
+		},
+		{
+			Name:        "NavigationLinks.OrgInheritance",
+			Icon:        nil,
+			Href:        "/org/inheritance",
+			Children:    nil,
+			AuthzObject: "org.inheritance_rules",
+			AuthzAction: "read",
+		},
+		{
+			Name:        "NavigationLinks.OrgFreezeCalendar",
+			Icon:        nil,
+			Href:        "/org/freeze-calendar",
+			Children:    nil,
+			AuthzObject: "org.freeze_calendar",
+			AuthzAction: "read",
+		},
+		{
+			Name:        "NavigationLinks.OrgTrends",
+			Icon:        nil,
+			Href:        "/org/trends",
+			Children:    nil,
+			AuthzObject: "org.position_reports",
+			AuthzAction: "read",
+		},
 		{
 			Name:        "NavigationLinks.JobCatalog",
 			Icon:        nil,
@@ -35,6 +67,22 @@ var OrgLink = types.NavigationItem{
 			AuthzObject: "org.job_catalog",
 			AuthzAction: "read",
 		},
+		{
+			Name:        "NavigationLinks.OrgScenarios",
+			Icon:        nil,
+			Href:        "/org/scenarios",
+			Children:    nil,
+			AuthzObject: "org.scenarios",
+			AuthzAction: "read",
+		},
+		{
+			Name:        "NavigationLinks.OrgChangeRequests",
+			Icon:        nil,
+			Href:        "/org/change-requests",
+			Children:    nil,
+			AuthzObject: "org.change_requests",
+			AuthzAction: "read",
+		},
 	},
 }
 
@@ -26,12 +26,19 @@ var (
 	orgBatchAuthzObject                = authz.ObjectName("org", "batch")
 	orgChangeRequestsAuthzObj          = authz.ObjectName("org", "change_requests")
 	orgPreflightAuthzObject            = authz.ObjectName("org", "preflight")
+	orgScenariosAuthzObject            = authz.ObjectName("org", "scenarios")
 	orgRolesAuthzObject                = authz.ObjectName("org", "roles")
 	orgRoleAssignmentsAuthzObject      = authz.ObjectName("org", "role_assignments")
 	orgSecurityGroupMappingsObj        = authz.ObjectName("org", "security_group_mappings")
 	orgLinksAuthzObject                = authz.ObjectName("org", "links")
 	orgPermissionPreviewAuthzObj       = authz.ObjectName("org", "permission_preview")
 	orgOpsAuthzObject                  = authz.ObjectName("org", "ops")
+	orgAuditAuthzObject                = authz.ObjectName("org", "audit")
+	orgBudgetsAuthzObject              = authz.ObjectName("org", "budgets")
+	orgDelegationsAuthzObject          = authz.ObjectName("org", "delegations")
+	orgInheritanceRulesAuthzObject     = authz.ObjectName("org", "inheritance_rules")
+	orgAttributeDefinitionsAuthzObject = authz.ObjectName("org", "attribute_definitions")
+	orgFreezeCalendarAuthzObject       = authz.ObjectName("org", "freeze_calendar")
 )
 
 func ensureOrgAuthz(
@@ -284,6 +284,14 @@ func TestOrgAPIController_StaffingReports_RequirePositionReportsRead(t *testing.
 			},
 			url: "/org/api/reports/staffing:export?kind=summary&format=csv",
 		},
+		{
+			name: "hierarchy_staffing",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.GetHierarchyStaffing(rr, req)
+			},
+			url: "/org/api/reports/hierarchy-staffing?type=CostCenter",
+		},
 	}
 
 	for _, tc := range cases {
@@ -308,6 +316,386 @@ func TestOrgAPIController_StaffingReports_RequirePositionReportsRead(t *testing.
 	}
 }
 
+func TestOrgAPIController_Budgets_RequireBudgetsAuthz(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeEnforce)
+
+	tenantID := uuid.MustParse("00000000-0000-0000-0000-000000000054")
+	withOrgRolloutEnabled(t, tenantID)
+
+	u := coreuser.New(
+		"Viewer",
+		"User",
+		internet.MustParseEmail("viewer@example.com"),
+		coreuser.UILanguageEN,
+		coreuser.WithID(11),
+		coreuser.WithTenantID(tenantID),
+	)
+
+	withAuthzPolicy(t, []string{
+		"p, role:org.staffing.viewer, org.position_reports, read, *, allow",
+		"g, " + authzutil.SubjectForUser(tenantID, u) + ", role:org.staffing.viewer, " + authz.DomainFromTenant(tenantID),
+	})
+
+	cases := []struct {
+		name   string
+		method string
+		url    string
+		action string
+		fn     func(rr *httptest.ResponseRecorder, req *http.Request)
+	}{
+		{
+			name:   "variance",
+			method: http.MethodGet,
+			url:    "/org/api/reports/budget-variance",
+			action: "read",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.GetHeadcountBudgetVariance(rr, req)
+			},
+		},
+		{
+			name:   "set",
+			method: http.MethodPost,
+			url:    "/org/api/budgets",
+			action: "write",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.SetHeadcountBudget(rr, req)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newOrgAPIRequest(t, tc.method, tc.url, tenantID, u)
+			req.Header.Set("X-Request-ID", "req-org-budgets-deny-"+tc.name)
+
+			rr := httptest.NewRecorder()
+			tc.fn(rr, req)
+
+			require.Equal(t, http.StatusForbidden, rr.Code)
+
+			var payload authzutil.ForbiddenPayload
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &payload))
+			require.Equal(t, orgBudgetsAuthzObject, payload.Object)
+			require.Equal(t, tc.action, payload.Action)
+			require.Equal(t, "req-org-budgets-deny-"+tc.name, payload.RequestID)
+		})
+	}
+}
+
+func TestOrgAPIController_Delegations_RequireDelegationsAuthz(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeEnforce)
+
+	tenantID := uuid.MustParse("00000000-0000-0000-0000-000000000055")
+	withOrgRolloutEnabled(t, tenantID)
+
+	u := coreuser.New(
+		"Viewer",
+		"User",
+		internet.MustParseEmail("viewer@example.com"),
+		coreuser.UILanguageEN,
+		coreuser.WithID(12),
+		coreuser.WithTenantID(tenantID),
+	)
+
+	withAuthzPolicy(t, []string{
+		"p, role:org.orgchart.viewer, org.hierarchies, read, *, allow",
+		"g, " + authzutil.SubjectForUser(tenantID, u) + ", role:org.orgchart.viewer, " + authz.DomainFromTenant(tenantID),
+	})
+
+	cases := []struct {
+		name   string
+		method string
+		url    string
+		action string
+		fn     func(rr *httptest.ResponseRecorder, req *http.Request)
+	}{
+		{
+			name:   "list",
+			method: http.MethodGet,
+			url:    "/org/api/delegations",
+			action: "read",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.GetDelegations(rr, req)
+			},
+		},
+		{
+			name:   "create",
+			method: http.MethodPost,
+			url:    "/org/api/delegations",
+			action: "admin",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.CreateDelegation(rr, req)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newOrgAPIRequest(t, tc.method, tc.url, tenantID, u)
+			req.Header.Set("X-Request-ID", "req-org-delegations-deny-"+tc.name)
+
+			rr := httptest.NewRecorder()
+			tc.fn(rr, req)
+
+			require.Equal(t, http.StatusForbidden, rr.Code)
+
+			var payload authzutil.ForbiddenPayload
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &payload))
+			require.Equal(t, orgDelegationsAuthzObject, payload.Object)
+			require.Equal(t, tc.action, payload.Action)
+			require.Equal(t, "req-org-delegations-deny-"+tc.name, payload.RequestID)
+		})
+	}
+}
+
+func TestOrgAPIController_InheritanceRules_RequireInheritanceRulesAuthz(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeEnforce)
+
+	tenantID := uuid.MustParse("00000000-0000-0000-0000-000000000056")
+	withOrgRolloutEnabled(t, tenantID)
+
+	u := coreuser.New(
+		"Viewer",
+		"User",
+		internet.MustParseEmail("viewer@example.com"),
+		coreuser.UILanguageEN,
+		coreuser.WithID(13),
+		coreuser.WithTenantID(tenantID),
+	)
+
+	withAuthzPolicy(t, []string{
+		"p, role:org.inheritance.viewer, org.inheritance_rules, read, *, allow",
+		"g, " + authzutil.SubjectForUser(tenantID, u) + ", role:org.inheritance.viewer, " + authz.DomainFromTenant(tenantID),
+	})
+
+	cases := []struct {
+		name   string
+		method string
+		url    string
+		fn     func(rr *httptest.ResponseRecorder, req *http.Request)
+	}{
+		{
+			name:   "set",
+			method: http.MethodPost,
+			url:    "/org/api/inheritance-rules",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.SetInheritanceRule(rr, req)
+			},
+		},
+		{
+			name:   "break",
+			method: http.MethodPost,
+			url:    "/org/api/inheritance-breaks",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.CreateInheritanceBreak(rr, req)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newOrgAPIRequest(t, tc.method, tc.url, tenantID, u)
+			req.Header.Set("X-Request-ID", "req-org-inheritance-deny-"+tc.name)
+
+			rr := httptest.NewRecorder()
+			tc.fn(rr, req)
+
+			require.Equal(t, http.StatusForbidden, rr.Code)
+
+			var payload authzutil.ForbiddenPayload
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &payload))
+			require.Equal(t, orgInheritanceRulesAuthzObject, payload.Object)
+			require.Equal(t, "admin", payload.Action)
+			require.Equal(t, "req-org-inheritance-deny-"+tc.name, payload.RequestID)
+		})
+	}
+}
+
+func TestOrgAPIController_AttributeDefinitions_RequireAttributeDefinitionsAuthz(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeEnforce)
+
+	tenantID := uuid.MustParse("00000000-0000-0000-0000-000000000057")
+	withOrgRolloutEnabled(t, tenantID)
+
+	u := coreuser.New(
+		"Viewer",
+		"User",
+		internet.MustParseEmail("viewer@example.com"),
+		coreuser.UILanguageEN,
+		coreuser.WithID(14),
+		coreuser.WithTenantID(tenantID),
+	)
+
+	withAuthzPolicy(t, []string{
+		"p, role:org.attribute_definitions.viewer, org.attribute_definitions, read, *, allow",
+		"g, " + authzutil.SubjectForUser(tenantID, u) + ", role:org.attribute_definitions.viewer, " + authz.DomainFromTenant(tenantID),
+	})
+
+	id := uuid.New().String()
+	cases := []struct {
+		name   string
+		method string
+		url    string
+		fn     func(rr *httptest.ResponseRecorder, req *http.Request)
+	}{
+		{
+			name:   "set",
+			method: http.MethodPost,
+			url:    "/org/api/attribute-definitions",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.SetAttributeDefinition(rr, req)
+			},
+		},
+		{
+			name:   "end",
+			method: http.MethodPost,
+			url:    "/org/api/attribute-definitions/" + id + ":end",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.EndAttributeDefinition(rr, req)
+			},
+		},
+		{
+			name:   "rescind",
+			method: http.MethodPost,
+			url:    "/org/api/attribute-definitions/" + id + ":rescind",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.RescindAttributeDefinition(rr, req)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newOrgAPIRequest(t, tc.method, tc.url, tenantID, u)
+			req.Header.Set("X-Request-ID", "req-org-attribute-definitions-deny-"+tc.name)
+
+			rr := httptest.NewRecorder()
+			tc.fn(rr, req)
+
+			require.Equal(t, http.StatusForbidden, rr.Code)
+
+			var payload authzutil.ForbiddenPayload
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &payload))
+			require.Equal(t, orgAttributeDefinitionsAuthzObject, payload.Object)
+			require.Equal(t, "admin", payload.Action)
+			require.Equal(t, "req-org-attribute-definitions-deny-"+tc.name, payload.RequestID)
+		})
+	}
+}
+
+func TestOrgAPIController_FreezeCalendar_RequireFreezeCalendarAuthz(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeEnforce)
+
+	tenantID := uuid.MustParse("00000000-0000-0000-0000-000000000058")
+	withOrgRolloutEnabled(t, tenantID)
+
+	u := coreuser.New(
+		"Viewer",
+		"User",
+		internet.MustParseEmail("viewer@example.com"),
+		coreuser.UILanguageEN,
+		coreuser.WithID(15),
+		coreuser.WithTenantID(tenantID),
+	)
+
+	withAuthzPolicy(t, []string{
+		"p, role:org.freeze_calendar.viewer, org.freeze_calendar, read, *, allow",
+		"g, " + authzutil.SubjectForUser(tenantID, u) + ", role:org.freeze_calendar.viewer, " + authz.DomainFromTenant(tenantID),
+	})
+
+	id := uuid.New().String()
+	cases := []struct {
+		name   string
+		method string
+		url    string
+		fn     func(rr *httptest.ResponseRecorder, req *http.Request)
+	}{
+		{
+			name:   "create",
+			method: http.MethodPost,
+			url:    "/org/api/freeze-periods",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.CreateFreezePeriod(rr, req)
+			},
+		},
+		{
+			name:   "update",
+			method: http.MethodPatch,
+			url:    "/org/api/freeze-periods/" + id,
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.UpdateFreezePeriod(rr, req)
+			},
+		},
+		{
+			name:   "rescind",
+			method: http.MethodPost,
+			url:    "/org/api/freeze-periods/" + id + ":rescind",
+			fn: func(rr *httptest.ResponseRecorder, req *http.Request) {
+				c := &OrgAPIController{}
+				c.RescindFreezePeriod(rr, req)
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newOrgAPIRequest(t, tc.method, tc.url, tenantID, u)
+			req.Header.Set("X-Request-ID", "req-org-freeze-calendar-deny-"+tc.name)
+
+			rr := httptest.NewRecorder()
+			tc.fn(rr, req)
+
+			require.Equal(t, http.StatusForbidden, rr.Code)
+
+			var payload authzutil.ForbiddenPayload
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &payload))
+			require.Equal(t, orgFreezeCalendarAuthzObject, payload.Object)
+			require.Equal(t, "admin", payload.Action)
+			require.Equal(t, "req-org-freeze-calendar-deny-"+tc.name, payload.RequestID)
+		})
+	}
+
+	t.Run("override", func(t *testing.T) {
+		c := &OrgAPIController{}
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler must not run without the override action")
+		})
+
+		req := newOrgAPIRequest(t, http.MethodPost, "/org/api/nodes", tenantID, u)
+		req.Header.Set(FreezeOverrideReasonHeader, "  ")
+		rr := httptest.NewRecorder()
+		c.freezeOverrideMiddleware(next).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusBadRequest, rr.Code)
+
+		req = newOrgAPIRequest(t, http.MethodPost, "/org/api/nodes", tenantID, u)
+		req.Header.Set(FreezeOverrideReasonHeader, "late payroll correction")
+		rr = httptest.NewRecorder()
+		c.freezeOverrideMiddleware(next).ServeHTTP(rr, req)
+		require.Equal(t, http.StatusForbidden, rr.Code)
+
+		var payload authzutil.ForbiddenPayload
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &payload))
+		require.Equal(t, orgFreezeCalendarAuthzObject, payload.Object)
+		require.Equal(t, "override", payload.Action)
+	})
+}
+
 func setAuthzEnv(t *testing.T) {
 	t.Helper()
 
@@ -0,0 +1,208 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	coredtos "github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/modules/core/testhelpers"
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func TestOrgAPIController_ChangeRequests_ApprovalChain(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeDisabled)
+	withOrgChangeRequestsEnabled(t)
+
+	pool, tenantID := setupOrgTestDB(t, []string{
+		"00001_org_baseline.sql",
+		"20251218005114_org_placeholders_and_event_contracts.sql",
+		"20251218130000_org_settings_and_audit.sql",
+		"20251218150000_org_outbox.sql",
+		"20251219090000_org_hierarchy_closure_and_snapshots.sql",
+		"20251219195000_org_security_group_mappings_and_links.sql",
+		"20251219220000_org_reporting_nodes_and_view.sql",
+		"20251220160000_org_position_slices_and_fte.sql",
+		"20251220200000_org_job_catalog_profiles_and_validation_modes.sql",
+		"20251221090000_org_reason_code_mode.sql",
+		"20251222120000_org_personnel_events.sql",
+		"20251227090000_org_valid_time_day_granularity.sql",
+		"20251228120000_org_eliminate_effective_on_end_on.sql",
+		"20251228140000_org_assignment_employment_status.sql",
+		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20260105090000_org_change_request_review.sql",
+		"20260106090000_org_change_request_approval_chains.sql",
+		"20260107090000_org_change_request_scheduling.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
+	})
+	ensureOrgSettings(t, pool, tenantID)
+
+	withOrgRolloutEnabled(t, tenantID)
+	u := newTestOrgUser(tenantID)
+	reviewer := newTestOrgReviewer(tenantID)
+	reviewerID := authzutil.NormalizedUserUUID(tenantID, reviewer)
+	otherID := uuid.NewSHA1(uuid.NameSpaceOID, []byte("approval-chain:other"))
+
+	ctx := context.Background()
+	asOf := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	nodes := seedOrgTestTree(t, pool, tenantID, asOf, 3)
+	rootID, targetID := nodes[0].ID, nodes[1].ID
+
+	// The nearest holder wins: hrbp resolves to the reviewer on the target node, not the
+	// other user on the root.
+	lineManager := insertOrgRole(t, pool, tenantID, "line_manager")
+	hrbp := insertOrgRole(t, pool, tenantID, "hrbp")
+	insertOrgRoleAssignment(t, pool, tenantID, lineManager, otherID, rootID, asOf)
+	insertOrgRoleAssignment(t, pool, tenantID, hrbp, otherID, rootID, asOf)
+	insertOrgRoleAssignment(t, pool, tenantID, hrbp, reviewerID, targetID, asOf)
+
+	_, err := persistence.NewOrgRepository().BuildDeepReadClosure(composables.WithPool(ctx, pool), tenantID, "OrgUnit", true, "test")
+	require.NoError(t, err)
+
+	c := &OrgAPIController{
+		org:            orgsvc.NewOrgService(persistence.NewOrgRepository()),
+		changeRequests: orgsvc.NewChangeRequestService(persistence.NewChangeRequestRepository()),
+	}
+
+	actor := u
+	call := func(handler http.HandlerFunc, method, path, id, requestID string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if body != nil {
+			req = newOrgAPIRequestWithBody(t, method, path, tenantID, actor, body)
+		} else {
+			req = newOrgAPIRequest(t, method, path, tenantID, actor)
+		}
+		req = req.WithContext(composables.WithPool(req.Context(), pool))
+		if id != "" {
+			req = mux.SetURLVars(req, map[string]string{"id": id})
+		}
+		req.Header.Set("X-Request-ID", requestID)
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		return rr
+	}
+
+	chainRR := call(c.ReplaceApprovalChain, http.MethodPut, "/org/api/approval-chain", "", "req-org-chain-put", mustJSON(t, map[string]any{
+		"steps": []any{
+			map[string]any{"role_code": "line_manager", "sla_hours": 24, "escalation_role_code": "hrbp"},
+			map[string]any{"role_code": "hrbp"},
+			map[string]any{"role_code": "finance", "condition": "fte_change"},
+		},
+	}))
+	require.Equal(t, http.StatusOK, chainRR.Code)
+	var chain approvalChainResponse
+	require.NoError(t, json.Unmarshal(chainRR.Body.Bytes(), &chain))
+	require.Len(t, chain.Steps, 3)
+	require.Equal(t, "always", chain.Steps[0].Condition)
+	require.Equal(t, int32(72), chain.Steps[1].SLAHours)
+
+	createRR := call(c.CreateChangeRequest, http.MethodPost, "/org/api/change-requests", "", "req-org-cr-chain", mustJSON(t, map[string]any{
+		"payload": map[string]any{
+			"effective_date": "2025-02-01",
+			"commands": []any{
+				map[string]any{
+					"type":    "node.update",
+					"payload": map[string]any{"id": targetID.String(), "name": "Renamed"},
+				},
+			},
+		},
+	}))
+	require.Equal(t, http.StatusCreated, createRR.Code)
+	var created changeRequestSummaryResponse
+	require.NoError(t, json.Unmarshal(createRR.Body.Bytes(), &created))
+
+	submitRR := call(c.SubmitChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":submit", created.ID, "req-org-cr-chain-submit", nil)
+	require.Equal(t, http.StatusOK, submitRR.Code)
+
+	steps := getApprovalSteps(t, call(c.GetChangeRequestApprovals, http.MethodGet, "/org/api/change-requests/"+created.ID+"/approvals", created.ID, "req-org-cr-chain-get", nil))
+	require.Len(t, steps, 3)
+	require.Equal(t, "pending", steps[0].Status)
+	require.Equal(t, []string{"user:" + otherID.String()}, steps[0].ApproverSubjects)
+	require.NotNil(t, steps[0].DueAt)
+	require.Equal(t, "waiting", steps[1].Status)
+	require.Equal(t, []string{"user:" + reviewerID.String()}, steps[1].ApproverSubjects)
+	require.Equal(t, "skipped", steps[2].Status)
+
+	// The reviewer does not hold line_manager, so the first step is out of reach.
+	actor = reviewer
+	deniedRR := call(c.ApproveChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":approve", created.ID, "req-org-cr-chain-denied", nil)
+	require.Equal(t, http.StatusForbidden, deniedRR.Code)
+	var apiErr coredtos.APIError
+	require.NoError(t, json.Unmarshal(deniedRR.Body.Bytes(), &apiErr))
+	require.Equal(t, "ORG_CHANGE_REQUEST_NOT_APPROVER", apiErr.Code)
+
+	// Once the SLA lapses the step escalates to hrbp, which the reviewer holds.
+	_, err = pool.Exec(ctx, `UPDATE org_change_request_approval_steps SET due_at = now() - interval '1 hour' WHERE tenant_id=$1 AND step_order=0`, tenantID)
+	require.NoError(t, err)
+	escalateRR := call(c.EscalateOverdueApprovals, http.MethodPost, "/org/api/change-requests:escalate-overdue", "", "req-org-cr-chain-escalate", nil)
+	require.Equal(t, http.StatusOK, escalateRR.Code)
+
+	firstRR := call(c.ApproveChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":approve", created.ID, "req-org-cr-chain-approve-1", nil)
+	require.Equal(t, http.StatusOK, firstRR.Code)
+	var first changeRequestReviewResponse
+	require.NoError(t, json.Unmarshal(firstRR.Body.Bytes(), &first))
+	require.Equal(t, "submitted", first.Status)
+
+	secondRR := call(c.ApproveChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":approve", created.ID, "req-org-cr-chain-approve-2", mustJSON(t, map[string]any{"comment": "ok"}))
+	require.Equal(t, http.StatusOK, secondRR.Code)
+	var second changeRequestReviewResponse
+	require.NoError(t, json.Unmarshal(secondRR.Body.Bytes(), &second))
+	require.Equal(t, "approved", second.Status)
+
+	steps = getApprovalSteps(t, call(c.GetChangeRequestApprovals, http.MethodGet, "/org/api/change-requests/"+created.ID+"/approvals", created.ID, "req-org-cr-chain-get-2", nil))
+	require.Equal(t, "approved", steps[0].Status)
+	require.NotNil(t, steps[0].EscalatedAt)
+	require.Equal(t, []string{"user:" + reviewerID.String()}, steps[0].EscalationSubjects)
+	require.Equal(t, "approved", steps[1].Status)
+	require.Equal(t, "skipped", steps[2].Status)
+}
+
+func getApprovalSteps(t *testing.T, rr *httptest.ResponseRecorder) []changeRequestApprovalStepResponse {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rr.Code)
+	var body struct {
+		Steps []changeRequestApprovalStepResponse `json:"steps"`
+	}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &body))
+	return body.Steps
+}
+
+func insertOrgRole(tb testing.TB, pool *pgxpool.Pool, tenantID uuid.UUID, code string) uuid.UUID {
+	tb.Helper()
+	var id uuid.UUID
+	err := pool.QueryRow(context.Background(), `
+INSERT INTO org_roles (tenant_id, code, name, is_system)
+VALUES ($1, $2, $2, false)
+RETURNING id
+`, tenantID, code).Scan(&id)
+	require.NoError(tb, err)
+	return id
+}
+
+func insertOrgRoleAssignment(tb testing.TB, pool *pgxpool.Pool, tenantID, roleID, subjectID, orgNodeID uuid.UUID, asOf time.Time) {
+	tb.Helper()
+	_, err := pool.Exec(context.Background(), `
+INSERT INTO org_role_assignments (tenant_id, role_id, subject_type, subject_id, org_node_id, effective_date)
+VALUES ($1, $2, 'user', $3, $4, ($5 AT TIME ZONE 'UTC')::date)
+`, tenantID, roleID, subjectID, orgNodeID, asOf)
+	require.NoError(tb, err)
+}
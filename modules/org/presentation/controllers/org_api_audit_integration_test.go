@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/testhelpers"
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func TestOrgAPIController_GetAuditLogs_FiltersPagesAndDiffs(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeDisabled)
+
+	pool, tenantID := setupOrgTestDB(t, []string{
+		"00001_org_baseline.sql",
+		"20251218005114_org_placeholders_and_event_contracts.sql",
+		"20251218130000_org_settings_and_audit.sql",
+		"20251218150000_org_outbox.sql",
+		"20251219090000_org_hierarchy_closure_and_snapshots.sql",
+		"20251219195000_org_security_group_mappings_and_links.sql",
+		"20251219220000_org_reporting_nodes_and_view.sql",
+		"20251220160000_org_position_slices_and_fte.sql",
+		"20251220200000_org_job_catalog_profiles_and_validation_modes.sql",
+		"20251221090000_org_reason_code_mode.sql",
+		"20251222120000_org_personnel_events.sql",
+		"20251227090000_org_valid_time_day_granularity.sql",
+		"20251228120000_org_eliminate_effective_on_end_on.sql",
+		"20251228140000_org_assignment_employment_status.sql",
+		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20251230090000_org_job_architecture_workday_profiles.sql",
+		"20251231120000_org_remove_job_family_allocation_percent.sql",
+		"20260101020855_org_job_catalog_effective_dated_slices_phase_a.sql",
+		"20260101020930_org_job_catalog_effective_dated_slices_gates_and_backfill.sql",
+		"20260104100000_org_drop_job_profile_job_families_legacy.sql",
+		"20260104120000_org_drop_job_catalog_identity_legacy_columns.sql",
+		"20260109090000_org_hierarchy_types.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
+	})
+	ensureOrgSettings(t, pool, tenantID)
+	withOrgRolloutEnabled(t, tenantID)
+	u := newTestOrgUser(tenantID)
+
+	svc := orgsvc.NewOrgService(persistence.NewOrgRepository())
+	c := &OrgAPIController{org: svc}
+	ctx := composables.WithPool(t.Context(), pool)
+	initiatorID := uuid.New()
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	root, err := svc.CreateNode(ctx, tenantID, "req-audit-root", initiatorID, orgsvc.CreateNodeInput{Code: "ROOT", Name: "Company", EffectiveDate: jan})
+	require.NoError(t, err)
+	sales, err := svc.CreateNode(ctx, tenantID, "req-audit-sales", initiatorID, orgsvc.CreateNodeInput{Code: "SALES", Name: "Sales", ParentID: &root.NodeID, EffectiveDate: jan})
+	require.NoError(t, err)
+	renamed := "Sales EMEA"
+	_, err = svc.UpdateNode(ctx, tenantID, "req-audit-rename", uuid.New(), orgsvc.UpdateNodeInput{NodeID: sales.NodeID, EffectiveDate: jan.AddDate(0, 1, 0), Name: &renamed})
+	require.NoError(t, err)
+
+	type item struct {
+		ID          string `json:"id"`
+		RequestID   string `json:"request_id"`
+		InitiatorID string `json:"initiator_id"`
+		ChangeType  string `json:"change_type"`
+		EntityID    string `json:"entity_id"`
+		Changes     []struct {
+			Field string          `json:"field"`
+			Old   json.RawMessage `json:"old"`
+			New   json.RawMessage `json:"new"`
+		} `json:"changes"`
+	}
+	type response struct {
+		Items      []item  `json:"items"`
+		NextCursor *string `json:"next_cursor"`
+	}
+	list := func(q url.Values) (*httptest.ResponseRecorder, response) {
+		t.Helper()
+		req := newOrgAPIRequest(t, http.MethodGet, "/org/api/audit?"+q.Encode(), tenantID, u)
+		req = req.WithContext(composables.WithPool(req.Context(), pool))
+		rr := httptest.NewRecorder()
+		c.GetAuditLogs(rr, req)
+		var out response
+		if rr.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+		}
+		return rr, out
+	}
+
+	rr, res := list(url.Values{"entity_type": {"org_node"}, "entity_id": {sales.NodeID.String()}})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.Len(t, res.Items, 2)
+	require.Nil(t, res.NextCursor)
+	require.Equal(t, "node.updated", res.Items[0].ChangeType)
+	require.Equal(t, "node.created", res.Items[1].ChangeType)
+	var nameChange bool
+	for _, ch := range res.Items[0].Changes {
+		if ch.Field == "name" {
+			nameChange = true
+			require.JSONEq(t, `"Sales"`, string(ch.Old))
+			require.JSONEq(t, `"Sales EMEA"`, string(ch.New))
+		}
+	}
+	require.True(t, nameChange)
+
+	// One row per page, following next_cursor.
+	seen := []string{}
+	q := url.Values{"entity_id": {sales.NodeID.String()}, "limit": {"1"}}
+	for {
+		rr, res = list(q)
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		for _, it := range res.Items {
+			seen = append(seen, it.ChangeType)
+		}
+		if res.NextCursor == nil {
+			break
+		}
+		q.Set("cursor", *res.NextCursor)
+	}
+	require.Equal(t, []string{"node.updated", "node.created"}, seen)
+
+	rr, res = list(url.Values{"request_id": {"req-audit-rename"}})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.Len(t, res.Items, 1)
+
+	rr, res = list(url.Values{"initiator_id": {initiatorID.String()}, "change_type": {"node.created"}, "entity_type": {"org_node"}})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.Len(t, res.Items, 2)
+
+	rr, res = list(url.Values{"from": {time.Now().UTC().AddDate(0, 0, 1).Format(time.DateOnly)}})
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.Empty(t, res.Items)
+
+	for _, bad := range []url.Values{
+		{"entity_type": {"org_bogus"}},
+		{"entity_id": {"x"}},
+		{"cursor": {"effective_date:2025-01-01:id:" + uuid.NewString()}},
+		{"from": {"2025-02-01"}, "to": {"2025-01-01"}},
+		{"limit": {"many"}},
+	} {
+		rr, _ = list(bad)
+		require.Equal(t, http.StatusBadRequest, rr.Code, bad.Encode())
+	}
+}
@@ -28,6 +28,9 @@ func TestOrgAPIController_ChangeRequests_RequestIDIsIdempotent(t *testing.T) {
 	pool, tenantID := setupOrgTestDB(t, []string{
 		"00001_org_baseline.sql",
 		"20251218005114_org_placeholders_and_event_contracts.sql",
+		"20260105090000_org_change_request_review.sql",
+		"20260106090000_org_change_request_approval_chains.sql",
+		"20260107090000_org_change_request_scheduling.sql",
 	})
 
 	withOrgRolloutEnabled(t, tenantID)
@@ -87,6 +90,9 @@ func TestOrgAPIController_ChangeRequests_ImmutableAfterSubmit(t *testing.T) {
 	pool, tenantID := setupOrgTestDB(t, []string{
 		"00001_org_baseline.sql",
 		"20251218005114_org_placeholders_and_event_contracts.sql",
+		"20260105090000_org_change_request_review.sql",
+		"20260106090000_org_change_request_approval_chains.sql",
+		"20260107090000_org_change_request_scheduling.sql",
 	})
 
 	withOrgRolloutEnabled(t, tenantID)
@@ -147,6 +153,135 @@ func TestOrgAPIController_ChangeRequests_ImmutableAfterSubmit(t *testing.T) {
 	require.Equal(t, "ORG_CHANGE_REQUEST_IMMUTABLE", apiErr.Code)
 }
 
+func TestOrgAPIController_ChangeRequests_ApproveAndApply(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeDisabled)
+	withOrgChangeRequestsEnabled(t)
+
+	pool, tenantID := setupOrgTestDB(t, []string{
+		"00001_org_baseline.sql",
+		"20251218005114_org_placeholders_and_event_contracts.sql",
+		"20251218130000_org_settings_and_audit.sql",
+		"20251218150000_org_outbox.sql",
+		"20251219090000_org_hierarchy_closure_and_snapshots.sql",
+		"20251219195000_org_security_group_mappings_and_links.sql",
+		"20251219220000_org_reporting_nodes_and_view.sql",
+		"20251220160000_org_position_slices_and_fte.sql",
+		"20251220200000_org_job_catalog_profiles_and_validation_modes.sql",
+		"20251221090000_org_reason_code_mode.sql",
+		"20251222120000_org_personnel_events.sql",
+		"20251227090000_org_valid_time_day_granularity.sql",
+		"20251228120000_org_eliminate_effective_on_end_on.sql",
+		"20251228140000_org_assignment_employment_status.sql",
+		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20260105090000_org_change_request_review.sql",
+		"20260106090000_org_change_request_approval_chains.sql",
+		"20260107090000_org_change_request_scheduling.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
+	})
+	ensureOrgSettings(t, pool, tenantID)
+
+	withOrgRolloutEnabled(t, tenantID)
+	u := newTestOrgUser(tenantID)
+	reviewer := newTestOrgReviewer(tenantID)
+
+	c := &OrgAPIController{
+		org:            orgsvc.NewOrgService(persistence.NewOrgRepository()),
+		changeRequests: orgsvc.NewChangeRequestService(persistence.NewChangeRequestRepository()),
+	}
+
+	body := mustJSON(t, map[string]any{
+		"payload": map[string]any{
+			"effective_date": "2025-01-01",
+			"commands": []any{
+				map[string]any{
+					"type":    "node.create",
+					"payload": map[string]any{"code": "ROOT", "name": "Company"},
+				},
+			},
+		},
+	})
+
+	actor := u
+	call := func(handler http.HandlerFunc, method, path, id, requestID string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if body != nil {
+			req = newOrgAPIRequestWithBody(t, method, path, tenantID, actor, body)
+		} else {
+			req = newOrgAPIRequest(t, method, path, tenantID, actor)
+		}
+		req = req.WithContext(composables.WithPool(req.Context(), pool))
+		if id != "" {
+			req = mux.SetURLVars(req, map[string]string{"id": id})
+		}
+		req.Header.Set("X-Request-ID", requestID)
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		return rr
+	}
+
+	createRR := call(c.CreateChangeRequest, http.MethodPost, "/org/api/change-requests", "", "req-org-cr-apply", body)
+	require.Equal(t, http.StatusCreated, createRR.Code)
+	var created changeRequestSummaryResponse
+	require.NoError(t, json.Unmarshal(createRR.Body.Bytes(), &created))
+
+	// Drafts cannot be approved.
+	approveDraftRR := call(c.ApproveChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":approve", created.ID, "req-org-cr-approve-draft", nil)
+	require.Equal(t, http.StatusConflict, approveDraftRR.Code)
+
+	submitRR := call(c.SubmitChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":submit", created.ID, "req-org-cr-apply-submit", nil)
+	require.Equal(t, http.StatusOK, submitRR.Code)
+
+	// Requesters cannot review their own change requests.
+	selfApproveRR := call(c.ApproveChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":approve", created.ID, "req-org-cr-self-approve", mustJSON(t, map[string]any{"comment": "mine"}))
+	require.Equal(t, http.StatusForbidden, selfApproveRR.Code)
+	var selfErr coredtos.APIError
+	require.NoError(t, json.Unmarshal(selfApproveRR.Body.Bytes(), &selfErr))
+	require.Equal(t, "ORG_CHANGE_REQUEST_SELF_REVIEW", selfErr.Code)
+
+	actor = reviewer
+	// Rejection requires a reviewer comment.
+	rejectRR := call(c.RejectChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":reject", created.ID, "req-org-cr-reject", mustJSON(t, map[string]any{}))
+	require.Equal(t, http.StatusUnprocessableEntity, rejectRR.Code)
+
+	approveRR := call(c.ApproveChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":approve", created.ID, "req-org-cr-approve", mustJSON(t, map[string]any{"comment": "looks good"}))
+	require.Equal(t, http.StatusOK, approveRR.Code)
+	var approved changeRequestReviewResponse
+	require.NoError(t, json.Unmarshal(approveRR.Body.Bytes(), &approved))
+	require.Equal(t, "approved", approved.Status)
+	require.NotNil(t, approved.ReviewComment)
+	require.Equal(t, "looks good", *approved.ReviewComment)
+	require.NotNil(t, approved.Impact)
+	require.Equal(t, 1, approved.Impact.OrgNodes.Create)
+
+	ctx := context.Background()
+	require.Equal(t, int64(0), countRows(t, ctx, pool, "org_nodes", tenantID))
+
+	applyRR := call(c.ApplyChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":apply", created.ID, "req-org-cr-apply-run", nil)
+	require.Equal(t, http.StatusOK, applyRR.Code)
+	var applied changeRequestApplyResponse
+	require.NoError(t, json.Unmarshal(applyRR.Body.Bytes(), &applied))
+	require.Equal(t, "applied", applied.Status)
+	require.Len(t, applied.Results, 1)
+	require.Equal(t, int64(1), countRows(t, ctx, pool, "org_nodes", tenantID))
+
+	var auditRequestID string
+	require.NoError(t, pool.QueryRow(ctx, "SELECT request_id FROM org_audit_logs WHERE tenant_id=$1 LIMIT 1", tenantID).Scan(&auditRequestID))
+	require.Equal(t, "req-org-cr-apply", auditRequestID)
+
+	againRR := call(c.ApplyChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":apply", created.ID, "req-org-cr-apply-again", nil)
+	require.Equal(t, http.StatusConflict, againRR.Code)
+	var apiErr coredtos.APIError
+	require.NoError(t, json.Unmarshal(againRR.Body.Bytes(), &apiErr))
+	require.Equal(t, "ORG_CHANGE_REQUEST_NOT_APPROVED", apiErr.Code)
+}
+
 func TestOrgAPIController_Preflight_TooManyMovesReturnsTooLarge(t *testing.T) {
 	setAuthzEnv(t)
 	testhelpers.WithAuthzMode(t, authz.ModeDisabled)
@@ -236,6 +371,12 @@ func TestOrgAPIController_Preflight_SuccessHasNoSideEffects(t *testing.T) {
 		"20251228120000_org_eliminate_effective_on_end_on.sql",
 		"20251228140000_org_assignment_employment_status.sql",
 		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
 	})
 	ensureOrgSettings(t, pool, tenantID)
 
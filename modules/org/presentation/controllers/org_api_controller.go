@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -54,12 +55,16 @@ func (c *OrgAPIController) Register(r *mux.Router) {
 	api.Use(
 		middleware.Authorize(),
 		middleware.ProvideUser(),
+		c.freezeOverrideMiddleware,
 	)
 
 	api.HandleFunc("/ops/health", c.instrumentAPI("ops.health.get", c.GetOpsHealth)).Methods(http.MethodGet)
 
 	api.HandleFunc("/hierarchies", c.instrumentAPI("hierarchies.get", c.GetHierarchies)).Methods(http.MethodGet)
 	api.HandleFunc("/hierarchies:export", c.instrumentAPI("hierarchies.export.get", c.ExportHierarchies)).Methods(http.MethodGet)
+	api.HandleFunc("/hierarchies:chart", c.instrumentAPI("hierarchies.chart.get", c.GetOrgChart)).Methods(http.MethodGet)
+	api.HandleFunc("/hierarchies:diff", c.instrumentAPI("hierarchies.diff.get", c.DiffHierarchies)).Methods(http.MethodGet)
+	api.HandleFunc("/search", c.instrumentAPI("search.get", c.Search)).Methods(http.MethodGet)
 
 	api.HandleFunc("/nodes", c.instrumentAPI("nodes.create", c.CreateNode)).Methods(http.MethodPost)
 	api.HandleFunc("/nodes/{id}", c.instrumentAPI("nodes.update", c.UpdateNode)).Methods(http.MethodPatch)
@@ -76,6 +81,9 @@ func (c *OrgAPIController) Register(r *mux.Router) {
 
 	api.HandleFunc("/positions", c.instrumentAPI("positions.list", c.GetPositions)).Methods(http.MethodGet)
 	api.HandleFunc("/positions", c.instrumentAPI("positions.create", c.CreatePosition)).Methods(http.MethodPost)
+	api.HandleFunc("/positions:bulk-create", c.instrumentAPI("positions.bulk_create", c.BulkCreatePositions)).Methods(http.MethodPost)
+	api.HandleFunc("/positions:bulk-update", c.instrumentAPI("positions.bulk_update", c.BulkUpdatePositions)).Methods(http.MethodPost)
+	api.HandleFunc("/positions:bulk-transfer", c.instrumentAPI("positions.bulk_transfer", c.BulkTransferPositions)).Methods(http.MethodPost)
 	api.HandleFunc("/positions/{id}", c.instrumentAPI("positions.get", c.GetPosition)).Methods(http.MethodGet)
 	api.HandleFunc("/positions/{id}/timeline", c.instrumentAPI("positions.timeline", c.GetPositionTimeline)).Methods(http.MethodGet)
 	api.HandleFunc("/positions/{id}", c.instrumentAPI("positions.update", c.UpdatePosition)).Methods(http.MethodPatch)
@@ -112,36 +120,98 @@ func (c *OrgAPIController) Register(r *mux.Router) {
 	api.HandleFunc("/personnel-events/hire", c.instrumentAPI("personnel_events.hire", c.HirePersonnelEvent)).Methods(http.MethodPost)
 	api.HandleFunc("/personnel-events/transfer", c.instrumentAPI("personnel_events.transfer", c.TransferPersonnelEvent)).Methods(http.MethodPost)
 	api.HandleFunc("/personnel-events/termination", c.instrumentAPI("personnel_events.termination", c.TerminationPersonnelEvent)).Methods(http.MethodPost)
+	api.HandleFunc("/personnel-events/promotion", c.instrumentAPI("personnel_events.promotion", c.PromotionPersonnelEvent)).Methods(http.MethodPost)
+	api.HandleFunc("/personnel-events/leave", c.instrumentAPI("personnel_events.leave", c.LeavePersonnelEvent)).Methods(http.MethodPost)
+	api.HandleFunc("/personnel-events/return", c.instrumentAPI("personnel_events.return", c.ReturnPersonnelEvent)).Methods(http.MethodPost)
+	api.HandleFunc("/personnel-events/rehire", c.instrumentAPI("personnel_events.rehire", c.RehirePersonnelEvent)).Methods(http.MethodPost)
+	api.HandleFunc("/personnel-events/fte-change", c.instrumentAPI("personnel_events.fte_change", c.FTEChangePersonnelEvent)).Methods(http.MethodPost)
+	api.HandleFunc("/personnel-events:import-preview", c.instrumentAPI("personnel_events.import_preview", c.PreviewPersonnelEventImport)).Methods(http.MethodPost)
+	api.HandleFunc("/personnel-events:import", c.instrumentAPI("personnel_events.import", c.ApplyPersonnelEventImport)).Methods(http.MethodPost)
 
 	api.HandleFunc("/roles", c.instrumentAPI("roles.list", c.GetRoles)).Methods(http.MethodGet)
+	api.HandleFunc("/roles", c.instrumentAPI("roles.create", c.CreateRole)).Methods(http.MethodPost)
 	api.HandleFunc("/role-assignments", c.instrumentAPI("role_assignments.list", c.GetRoleAssignments)).Methods(http.MethodGet)
+	api.HandleFunc("/role-assignments", c.instrumentAPI("role_assignments.create", c.CreateRoleAssignment)).Methods(http.MethodPost)
+	api.HandleFunc("/role-assignments/{id}:end", c.instrumentAPI("role_assignments.end", c.EndRoleAssignment)).Methods(http.MethodPost)
+	api.HandleFunc("/role-assignments/{id}:rescind", c.instrumentAPI("role_assignments.rescind", c.RescindRoleAssignment)).Methods(http.MethodPost)
+	api.HandleFunc("/delegations", c.instrumentAPI("delegations.list", c.GetDelegations)).Methods(http.MethodGet)
+	api.HandleFunc("/delegations", c.instrumentAPI("delegations.create", c.CreateDelegation)).Methods(http.MethodPost)
+	api.HandleFunc("/delegations/{id}:end", c.instrumentAPI("delegations.end", c.EndDelegation)).Methods(http.MethodPost)
+
+	api.HandleFunc("/inheritance-rules", c.instrumentAPI("inheritance_rules.list", c.GetInheritanceRules)).Methods(http.MethodGet)
+	api.HandleFunc("/inheritance-rules", c.instrumentAPI("inheritance_rules.set", c.SetInheritanceRule)).Methods(http.MethodPost)
+	api.HandleFunc("/inheritance-rules:preview", c.instrumentAPI("inheritance_rules.preview", c.PreviewInheritance)).Methods(http.MethodPost)
+	api.HandleFunc("/inheritance-rules/{id}:end", c.instrumentAPI("inheritance_rules.end", c.EndInheritanceRule)).Methods(http.MethodPost)
+	api.HandleFunc("/inheritance-rules/{id}:rescind", c.instrumentAPI("inheritance_rules.rescind", c.RescindInheritanceRule)).Methods(http.MethodPost)
+	api.HandleFunc("/inheritance-breaks", c.instrumentAPI("inheritance_breaks.create", c.CreateInheritanceBreak)).Methods(http.MethodPost)
+	api.HandleFunc("/inheritance-breaks/{id}:end", c.instrumentAPI("inheritance_breaks.end", c.EndInheritanceBreak)).Methods(http.MethodPost)
+	api.HandleFunc("/inheritance-breaks/{id}:rescind", c.instrumentAPI("inheritance_breaks.rescind", c.RescindInheritanceBreak)).Methods(http.MethodPost)
+	api.HandleFunc("/attribute-definitions", c.instrumentAPI("attribute_definitions.list", c.GetAttributeDefinitions)).Methods(http.MethodGet)
+	api.HandleFunc("/attribute-definitions", c.instrumentAPI("attribute_definitions.set", c.SetAttributeDefinition)).Methods(http.MethodPost)
+	api.HandleFunc("/attribute-definitions/{id}:end", c.instrumentAPI("attribute_definitions.end", c.EndAttributeDefinition)).Methods(http.MethodPost)
+	api.HandleFunc("/attribute-definitions/{id}:rescind", c.instrumentAPI("attribute_definitions.rescind", c.RescindAttributeDefinition)).Methods(http.MethodPost)
+	api.HandleFunc("/freeze-periods", c.instrumentAPI("freeze_periods.list", c.GetFreezePeriods)).Methods(http.MethodGet)
+	api.HandleFunc("/freeze-periods", c.instrumentAPI("freeze_periods.create", c.CreateFreezePeriod)).Methods(http.MethodPost)
+	api.HandleFunc("/freeze-periods/{id}", c.instrumentAPI("freeze_periods.update", c.UpdateFreezePeriod)).Methods(http.MethodPatch)
+	api.HandleFunc("/freeze-periods/{id}:rescind", c.instrumentAPI("freeze_periods.rescind", c.RescindFreezePeriod)).Methods(http.MethodPost)
 
 	api.HandleFunc("/security-group-mappings", c.instrumentAPI("security_group_mappings.list", c.GetSecurityGroupMappings)).Methods(http.MethodGet)
 	api.HandleFunc("/security-group-mappings", c.instrumentAPI("security_group_mappings.create", c.CreateSecurityGroupMapping)).Methods(http.MethodPost)
 	api.HandleFunc("/security-group-mappings/{id}:rescind", c.instrumentAPI("security_group_mappings.rescind", c.RescindSecurityGroupMapping)).Methods(http.MethodPost)
 
+	api.HandleFunc("/hierarchy-memberships", c.instrumentAPI("hierarchy_memberships.list", c.GetHierarchyMemberships)).Methods(http.MethodGet)
+	api.HandleFunc("/hierarchy-memberships", c.instrumentAPI("hierarchy_memberships.set", c.SetHierarchyMembership)).Methods(http.MethodPost)
+	api.HandleFunc("/hierarchy-memberships/{id}:end", c.instrumentAPI("hierarchy_memberships.end", c.EndHierarchyMembership)).Methods(http.MethodPost)
+
+	api.HandleFunc("/budgets", c.instrumentAPI("budgets.list", c.GetHeadcountBudgets)).Methods(http.MethodGet)
+	api.HandleFunc("/budgets", c.instrumentAPI("budgets.set", c.SetHeadcountBudget)).Methods(http.MethodPost)
+	api.HandleFunc("/budgets/{id}:end", c.instrumentAPI("budgets.end", c.EndHeadcountBudget)).Methods(http.MethodPost)
+
 	api.HandleFunc("/links", c.instrumentAPI("links.list", c.GetLinks)).Methods(http.MethodGet)
 	api.HandleFunc("/links", c.instrumentAPI("links.create", c.CreateLink)).Methods(http.MethodPost)
 	api.HandleFunc("/links/{id}:rescind", c.instrumentAPI("links.rescind", c.RescindLink)).Methods(http.MethodPost)
 
 	api.HandleFunc("/permission-preview", c.instrumentAPI("permission_preview.get", c.GetPermissionPreview)).Methods(http.MethodGet)
 
+	api.HandleFunc("/audit", c.instrumentAPI("audit.list", c.GetAuditLogs)).Methods(http.MethodGet)
+
 	api.HandleFunc("/snapshot", c.instrumentAPI("snapshot.get", c.GetSnapshot)).Methods(http.MethodGet)
 	api.HandleFunc("/batch", c.instrumentAPI("batch.post", c.Batch)).Methods(http.MethodPost)
 	api.HandleFunc("/reports/person-path", c.instrumentAPI("reports.person_path.get", c.GetPersonPath)).Methods(http.MethodGet)
 	api.HandleFunc("/reports/staffing:summary", c.instrumentAPI("reports.staffing_summary.get", c.GetStaffingSummary)).Methods(http.MethodGet)
 	api.HandleFunc("/reports/staffing:vacancies", c.instrumentAPI("reports.staffing_vacancies.get", c.GetStaffingVacancies)).Methods(http.MethodGet)
 	api.HandleFunc("/reports/staffing:time-to-fill", c.instrumentAPI("reports.staffing_time_to_fill.get", c.GetStaffingTimeToFill)).Methods(http.MethodGet)
+	api.HandleFunc("/reports/trends", c.instrumentAPI("reports.trends.get", c.GetTrendReport)).Methods(http.MethodGet)
 	api.HandleFunc("/reports/staffing:export", c.instrumentAPI("reports.staffing_export.get", c.ExportStaffingReport)).Methods(http.MethodGet)
+	api.HandleFunc("/reports/hierarchy-staffing", c.instrumentAPI("reports.hierarchy_staffing.get", c.GetHierarchyStaffing)).Methods(http.MethodGet)
+	api.HandleFunc("/reports/budget-variance", c.instrumentAPI("reports.budget_variance.get", c.GetHeadcountBudgetVariance)).Methods(http.MethodGet)
 
 	api.HandleFunc("/change-requests", c.instrumentAPI("change_requests.create", c.CreateChangeRequest)).Methods(http.MethodPost)
 	api.HandleFunc("/change-requests", c.instrumentAPI("change_requests.list", c.ListChangeRequests)).Methods(http.MethodGet)
+	api.HandleFunc("/change-requests:escalate-overdue", c.instrumentAPI("change_requests.escalate_overdue", c.EscalateOverdueApprovals)).Methods(http.MethodPost)
 	api.HandleFunc("/change-requests/{id}", c.instrumentAPI("change_requests.get", c.GetChangeRequest)).Methods(http.MethodGet)
 	api.HandleFunc("/change-requests/{id}", c.instrumentAPI("change_requests.update", c.UpdateChangeRequest)).Methods(http.MethodPatch)
 	api.HandleFunc("/change-requests/{id}:submit", c.instrumentAPI("change_requests.submit", c.SubmitChangeRequest)).Methods(http.MethodPost)
 	api.HandleFunc("/change-requests/{id}:cancel", c.instrumentAPI("change_requests.cancel", c.CancelChangeRequest)).Methods(http.MethodPost)
+	api.HandleFunc("/change-requests/{id}:approve", c.instrumentAPI("change_requests.approve", c.ApproveChangeRequest)).Methods(http.MethodPost)
+	api.HandleFunc("/change-requests/{id}:reject", c.instrumentAPI("change_requests.reject", c.RejectChangeRequest)).Methods(http.MethodPost)
+	api.HandleFunc("/change-requests/{id}:apply", c.instrumentAPI("change_requests.apply", c.ApplyChangeRequest)).Methods(http.MethodPost)
+	api.HandleFunc("/change-requests/{id}:schedule", c.instrumentAPI("change_requests.schedule", c.ScheduleChangeRequest)).Methods(http.MethodPost)
+	api.HandleFunc("/change-requests/{id}/approvals", c.instrumentAPI("change_requests.approvals.get", c.GetChangeRequestApprovals)).Methods(http.MethodGet)
+
+	api.HandleFunc("/approval-chain", c.instrumentAPI("approval_chain.get", c.GetApprovalChain)).Methods(http.MethodGet)
+	api.HandleFunc("/approval-chain", c.instrumentAPI("approval_chain.replace", c.ReplaceApprovalChain)).Methods(http.MethodPut)
 
 	api.HandleFunc("/preflight", c.instrumentAPI("preflight.post", c.Preflight)).Methods(http.MethodPost)
+
+	api.HandleFunc("/scenarios", c.instrumentAPI("scenarios.list", c.ListScenarios)).Methods(http.MethodGet)
+	api.HandleFunc("/scenarios", c.instrumentAPI("scenarios.create", c.CreateScenario)).Methods(http.MethodPost)
+	api.HandleFunc("/scenarios:compare", c.instrumentAPI("scenarios.compare", c.CompareScenarios)).Methods(http.MethodGet)
+	api.HandleFunc("/scenarios/{id}", c.instrumentAPI("scenarios.get", c.GetScenario)).Methods(http.MethodGet)
+	api.HandleFunc("/scenarios/{id}", c.instrumentAPI("scenarios.update", c.UpdateScenario)).Methods(http.MethodPatch)
+	api.HandleFunc("/scenarios/{id}:delete", c.instrumentAPI("scenarios.delete", c.DeleteScenario)).Methods(http.MethodPost)
+	api.HandleFunc("/scenarios/{id}:evaluate", c.instrumentAPI("scenarios.evaluate", c.EvaluateScenario)).Methods(http.MethodGet)
+	api.HandleFunc("/scenarios/{id}:convert", c.instrumentAPI("scenarios.convert", c.ConvertScenario)).Methods(http.MethodPost)
 }
 
 type effectiveWindowResponse struct {
@@ -163,6 +233,10 @@ func (c *OrgAPIController) GetHierarchies(w http.ResponseWriter, r *http.Request
 		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "type is required")
 		return
 	}
+	if !services.IsValidHierarchyType(hType) {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "type is invalid")
+		return
+	}
 
 	asOf, err := parseEffectiveDate(r.URL.Query().Get("effective_date"))
 	if err != nil {
@@ -189,11 +263,6 @@ func (c *OrgAPIController) GetHierarchies(w http.ResponseWriter, r *http.Request
 	}
 
 	if includeResolved {
-		if hType != "OrgUnit" {
-			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "type is invalid")
-			return
-		}
-
 		nodes, effectiveDate, _, _, err := c.org.GetHierarchyResolvedAttributes(r.Context(), tenantID, hType, asOf)
 		if err != nil {
 			writeServiceError(w, requestID, err)
@@ -354,6 +423,7 @@ func (c *OrgAPIController) ExportHierarchies(w http.ResponseWriter, r *http.Requ
 		Status            string                    `json:"status"`
 		SecurityGroupKeys []string                  `json:"security_group_keys,omitempty"`
 		Links             []services.OrgLinkSummary `json:"links,omitempty"`
+		CustomAttributes  map[string]any            `json:"custom_attributes,omitempty"`
 	}
 	nodes := make([]exportNode, 0, len(res.Nodes))
 	for _, n := range res.Nodes {
@@ -371,6 +441,7 @@ func (c *OrgAPIController) ExportHierarchies(w http.ResponseWriter, r *http.Requ
 			Status:            n.Status,
 			SecurityGroupKeys: n.SecurityGroupKeys,
 			Links:             n.Links,
+			CustomAttributes:  n.CustomAttributes,
 		})
 	}
 
@@ -595,7 +666,7 @@ func (c *OrgAPIController) GetNodeResolvedAttributes(w http.ResponseWriter, r *h
 			if name == "" {
 				continue
 			}
-			if !isOrgInheritanceAttributeWhitelisted(name) {
+			if !services.IsInheritableAttribute(name) {
 				writeAPIError(w, http.StatusBadRequest, requestID, "ORG_UNKNOWN_ATTRIBUTE", "unknown attribute")
 				return
 			}
@@ -657,8 +728,18 @@ func (c *OrgAPIController) GetNodeResolvedAttributes(w http.ResponseWriter, r *h
 			resolved[name] = node.ResolvedAttributes.ManagerUserID
 			resolvedSources[name] = sources.ManagerUserID
 		default:
-			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_UNKNOWN_ATTRIBUTE", "unknown attribute")
-			return
+			code, ok := strings.CutPrefix(name, services.CustomAttributePrefix)
+			if !ok {
+				writeAPIError(w, http.StatusBadRequest, requestID, "ORG_UNKNOWN_ATTRIBUTE", "unknown attribute")
+				return
+			}
+			attrs[name] = node.Attributes.Custom[code]
+			resolved[name] = node.ResolvedAttributes.Custom[code]
+			if src, ok := sources.Custom[code]; ok {
+				resolvedSources[name] = &src
+			} else {
+				resolvedSources[name] = nil
+			}
 		}
 	}
 
@@ -682,15 +763,6 @@ func (c *OrgAPIController) GetNodeResolvedAttributes(w http.ResponseWriter, r *h
 	})
 }
 
-func isOrgInheritanceAttributeWhitelisted(name string) bool {
-	switch name {
-	case "legal_entity_id", "company_code", "location_id", "manager_user_id":
-		return true
-	default:
-		return false
-	}
-}
-
 func (c *OrgAPIController) GetRoles(w http.ResponseWriter, r *http.Request) {
 	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
 	if !ok {
@@ -1282,12 +1354,13 @@ type permissionPreviewSecurityGroupItem struct {
 }
 
 type permissionPreviewResponse struct {
-	TenantID       string                               `json:"tenant_id"`
-	OrgNodeID      string                               `json:"org_node_id"`
-	EffectiveDate  string                               `json:"effective_date"`
-	SecurityGroups []permissionPreviewSecurityGroupItem `json:"security_groups"`
-	Links          []services.PermissionPreviewLink     `json:"links"`
-	Warnings       []string                             `json:"warnings"`
+	TenantID       string                                 `json:"tenant_id"`
+	OrgNodeID      string                                 `json:"org_node_id"`
+	EffectiveDate  string                                 `json:"effective_date"`
+	SecurityGroups []permissionPreviewSecurityGroupItem   `json:"security_groups"`
+	Links          []services.PermissionPreviewLink       `json:"links"`
+	Delegations    []services.PermissionPreviewDelegation `json:"delegations"`
+	Warnings       []string                               `json:"warnings"`
 }
 
 func (c *OrgAPIController) GetPermissionPreview(w http.ResponseWriter, r *http.Request) {
@@ -1325,9 +1398,11 @@ func (c *OrgAPIController) GetPermissionPreview(w http.ResponseWriter, r *http.R
 	includeRaw := strings.TrimSpace(r.URL.Query().Get("include"))
 	includeSecurityGroups := true
 	includeLinks := true
+	includeDelegations := true
 	if includeRaw != "" {
 		includeSecurityGroups = false
 		includeLinks = false
+		includeDelegations = false
 		for _, part := range strings.Split(includeRaw, ",") {
 			part = strings.TrimSpace(part)
 			if part == "" {
@@ -1338,6 +1413,8 @@ func (c *OrgAPIController) GetPermissionPreview(w http.ResponseWriter, r *http.R
 				includeSecurityGroups = true
 			case "links":
 				includeLinks = true
+			case "delegations":
+				includeDelegations = true
 			default:
 				writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "include is invalid")
 				return
@@ -1366,6 +1443,7 @@ func (c *OrgAPIController) GetPermissionPreview(w http.ResponseWriter, r *http.R
 		EffectiveDate:         asOf,
 		IncludeSecurityGroups: includeSecurityGroups,
 		IncludeLinks:          includeLinks,
+		IncludeDelegations:    includeDelegations,
 		LimitLinks:            limitLinks,
 	})
 	if err != nil {
@@ -1389,11 +1467,13 @@ func (c *OrgAPIController) GetPermissionPreview(w http.ResponseWriter, r *http.R
 		EffectiveDate:  formatValidDate(res.EffectiveDate),
 		SecurityGroups: sg,
 		Links:          res.Links,
+		Delegations:    res.Delegations,
 		Warnings:       res.Warnings,
 	})
 }
 
 type createNodeRequest struct {
+	HierarchyType string            `json:"hierarchy_type"`
 	Code          string            `json:"code"`
 	Name          string            `json:"name"`
 	ParentID      *uuid.UUID        `json:"parent_id"`
@@ -1406,6 +1486,8 @@ type createNodeRequest struct {
 	LocationID    *uuid.UUID        `json:"location_id"`
 	ManagerUserID *int64            `json:"manager_user_id"`
 	ManagerEmail  *string           `json:"manager_email"`
+	// CustomAttributes holds values for the tenant's org_node attribute definitions, keyed by code.
+	CustomAttributes map[string]any `json:"custom_attributes"`
 }
 
 func (c *OrgAPIController) CreateNode(w http.ResponseWriter, r *http.Request) {
@@ -1442,17 +1524,19 @@ func (c *OrgAPIController) CreateNode(w http.ResponseWriter, r *http.Request) {
 
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
 	res, err := c.org.CreateNode(r.Context(), tenantID, requestID, initiatorID, services.CreateNodeInput{
-		Code:          req.Code,
-		Name:          req.Name,
-		ParentID:      req.ParentID,
-		EffectiveDate: effectiveDate,
-		I18nNames:     req.I18nNames,
-		Status:        req.Status,
-		DisplayOrder:  req.DisplayOrder,
-		LegalEntityID: req.LegalEntityID,
-		CompanyCode:   req.CompanyCode,
-		LocationID:    req.LocationID,
-		ManagerUserID: managerUserID,
+		HierarchyType:    req.HierarchyType,
+		Code:             req.Code,
+		Name:             req.Name,
+		ParentID:         req.ParentID,
+		EffectiveDate:    effectiveDate,
+		I18nNames:        req.I18nNames,
+		Status:           req.Status,
+		DisplayOrder:     req.DisplayOrder,
+		LegalEntityID:    req.LegalEntityID,
+		CompanyCode:      req.CompanyCode,
+		LocationID:       req.LocationID,
+		ManagerUserID:    managerUserID,
+		CustomAttributes: req.CustomAttributes,
 	})
 	if err != nil {
 		writeServiceError(w, requestID, err)
@@ -1548,6 +1632,8 @@ type updateNodeRequest struct {
 	CompanyCode   optionalString    `json:"company_code"`
 	LocationID    optionalUUID      `json:"location_id"`
 	ManagerUserID optionalInt64     `json:"manager_user_id"`
+	// CustomAttributes is merged into the current values; null or "" clears an attribute.
+	CustomAttributes map[string]any `json:"custom_attributes"`
 }
 
 func (c *OrgAPIController) UpdateNode(w http.ResponseWriter, r *http.Request) {
@@ -1604,16 +1690,17 @@ func (c *OrgAPIController) UpdateNode(w http.ResponseWriter, r *http.Request) {
 
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
 	res, err := c.org.UpdateNode(r.Context(), tenantID, requestID, initiatorID, services.UpdateNodeInput{
-		NodeID:        nodeID,
-		EffectiveDate: effectiveDate,
-		Name:          req.Name,
-		I18nNames:     req.I18nNames,
-		Status:        req.Status,
-		DisplayOrder:  req.DisplayOrder,
-		LegalEntityID: legalEntityID,
-		CompanyCode:   companyCode,
-		LocationID:    locationID,
-		ManagerUserID: managerUserID,
+		NodeID:           nodeID,
+		EffectiveDate:    effectiveDate,
+		Name:             req.Name,
+		I18nNames:        req.I18nNames,
+		Status:           req.Status,
+		DisplayOrder:     req.DisplayOrder,
+		LegalEntityID:    legalEntityID,
+		CompanyCode:      companyCode,
+		LocationID:       locationID,
+		ManagerUserID:    managerUserID,
+		CustomAttributes: req.CustomAttributes,
 	})
 	if err != nil {
 		writeServiceError(w, requestID, err)
@@ -1699,6 +1786,8 @@ type correctNodeRequest struct {
 	CompanyCode   optionalString    `json:"company_code"`
 	LocationID    optionalUUID      `json:"location_id"`
 	ManagerUserID optionalInt64     `json:"manager_user_id"`
+	// CustomAttributes is merged into the current values; null or "" clears an attribute.
+	CustomAttributes map[string]any `json:"custom_attributes"`
 }
 
 func (c *OrgAPIController) CorrectNode(w http.ResponseWriter, r *http.Request) {
@@ -1751,16 +1840,17 @@ func (c *OrgAPIController) CorrectNode(w http.ResponseWriter, r *http.Request) {
 
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
 	res, err := c.org.CorrectNode(r.Context(), tenantID, requestID, initiatorID, services.CorrectNodeInput{
-		NodeID:        nodeID,
-		AsOf:          asOf,
-		Name:          req.Name,
-		I18nNames:     req.I18nNames,
-		Status:        req.Status,
-		DisplayOrder:  req.DisplayOrder,
-		LegalEntityID: legalEntityID,
-		CompanyCode:   companyCode,
-		LocationID:    locationID,
-		ManagerUserID: managerUserID,
+		NodeID:           nodeID,
+		AsOf:             asOf,
+		Name:             req.Name,
+		I18nNames:        req.I18nNames,
+		Status:           req.Status,
+		DisplayOrder:     req.DisplayOrder,
+		LegalEntityID:    legalEntityID,
+		CompanyCode:      companyCode,
+		LocationID:       locationID,
+		ManagerUserID:    managerUserID,
+		CustomAttributes: req.CustomAttributes,
 	})
 	if err != nil {
 		writeServiceError(w, requestID, err)
@@ -2241,6 +2331,8 @@ type createPositionRequest struct {
 	Profile         json.RawMessage `json:"profile"`
 	ReasonCode      string          `json:"reason_code"`
 	ReasonNote      *string         `json:"reason_note"`
+	// CustomAttributes holds values for the tenant's org_position attribute definitions, keyed by code.
+	CustomAttributes map[string]any `json:"custom_attributes"`
 }
 
 func (c *OrgAPIController) CreatePosition(w http.ResponseWriter, r *http.Request) {
@@ -2257,42 +2349,14 @@ func (c *OrgAPIController) CreatePosition(w http.ResponseWriter, r *http.Request
 		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
 		return
 	}
-	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	in, err := createPositionInputOf(req)
 	if err != nil {
-		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", err.Error())
 		return
 	}
-	capacityFTE := 1.0
-	if req.CapacityFTE != nil {
-		capacityFTE = *req.CapacityFTE
-	}
-
-	var jobLevelCode *string
-	if req.JobLevelCode != nil {
-		v := strings.TrimSpace(*req.JobLevelCode)
-		if v != "" {
-			jobLevelCode = &v
-		}
-	}
 
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
-	res, err := c.org.CreatePosition(r.Context(), tenantID, requestID, initiatorID, services.CreatePositionInput{
-		Code:            req.Code,
-		OrgNodeID:       req.OrgNodeID,
-		EffectiveDate:   effectiveDate,
-		Title:           req.Title,
-		LifecycleStatus: req.LifecycleStatus,
-		PositionType:    req.PositionType,
-		EmploymentType:  req.EmploymentType,
-		CapacityFTE:     capacityFTE,
-		ReportsToID:     req.ReportsToID,
-		JobLevelCode:    jobLevelCode,
-		JobProfileID:    req.JobProfileID,
-		CostCenterCode:  req.CostCenterCode,
-		Profile:         req.Profile,
-		ReasonCode:      req.ReasonCode,
-		ReasonNote:      req.ReasonNote,
-	})
+	res, err := c.org.CreatePosition(r.Context(), tenantID, requestID, initiatorID, in)
 	if err != nil {
 		writeServiceError(w, requestID, err)
 		return
@@ -2449,6 +2513,8 @@ type updatePositionRequest struct {
 	JobProfileID    *uuid.UUID      `json:"job_profile_id"`
 	CostCenterCode  *string         `json:"cost_center_code"`
 	Profile         json.RawMessage `json:"profile"`
+	// CustomAttributes is merged into the current values; null or "" clears an attribute.
+	CustomAttributes map[string]any `json:"custom_attributes"`
 }
 
 func (c *OrgAPIController) UpdatePosition(w http.ResponseWriter, r *http.Request) {
@@ -2484,21 +2550,22 @@ func (c *OrgAPIController) UpdatePosition(w http.ResponseWriter, r *http.Request
 		profile = &tmp
 	}
 	res, err := c.org.UpdatePosition(r.Context(), tenantID, requestID, initiatorID, services.UpdatePositionInput{
-		PositionID:      positionID,
-		EffectiveDate:   effectiveDate,
-		ReasonCode:      req.ReasonCode,
-		ReasonNote:      req.ReasonNote,
-		OrgNodeID:       req.OrgNodeID,
-		Title:           req.Title,
-		LifecycleStatus: req.LifecycleStatus,
-		PositionType:    req.PositionType,
-		EmploymentType:  req.EmploymentType,
-		CapacityFTE:     req.CapacityFTE,
-		ReportsToID:     req.ReportsToID,
-		JobLevelCode:    req.JobLevelCode,
-		JobProfileID:    req.JobProfileID,
-		CostCenterCode:  req.CostCenterCode,
-		Profile:         profile,
+		PositionID:       positionID,
+		EffectiveDate:    effectiveDate,
+		ReasonCode:       req.ReasonCode,
+		ReasonNote:       req.ReasonNote,
+		OrgNodeID:        req.OrgNodeID,
+		Title:            req.Title,
+		LifecycleStatus:  req.LifecycleStatus,
+		PositionType:     req.PositionType,
+		EmploymentType:   req.EmploymentType,
+		CapacityFTE:      req.CapacityFTE,
+		ReportsToID:      req.ReportsToID,
+		JobLevelCode:     req.JobLevelCode,
+		JobProfileID:     req.JobProfileID,
+		CostCenterCode:   req.CostCenterCode,
+		Profile:          profile,
+		CustomAttributes: req.CustomAttributes,
 	})
 	if err != nil {
 		writeServiceError(w, requestID, err)
@@ -2535,6 +2602,8 @@ type correctPositionRequest struct {
 	JobProfileID    *uuid.UUID      `json:"job_profile_id"`
 	CostCenterCode  *string         `json:"cost_center_code"`
 	Profile         json.RawMessage `json:"profile"`
+	// CustomAttributes is merged into the current values; null or "" clears an attribute.
+	CustomAttributes map[string]any `json:"custom_attributes"`
 }
 
 func (c *OrgAPIController) CorrectPosition(w http.ResponseWriter, r *http.Request) {
@@ -2570,21 +2639,22 @@ func (c *OrgAPIController) CorrectPosition(w http.ResponseWriter, r *http.Reques
 		profile = &tmp
 	}
 	res, err := c.org.CorrectPosition(r.Context(), tenantID, requestID, initiatorID, services.CorrectPositionInput{
-		PositionID:     positionID,
-		AsOf:           effectiveDate,
-		ReasonCode:     req.ReasonCode,
-		ReasonNote:     req.ReasonNote,
-		OrgNodeID:      req.OrgNodeID,
-		Title:          req.Title,
-		Lifecycle:      req.LifecycleStatus,
-		PositionType:   req.PositionType,
-		EmploymentType: req.EmploymentType,
-		CapacityFTE:    req.CapacityFTE,
-		ReportsToID:    req.ReportsToID,
-		JobLevelCode:   req.JobLevelCode,
-		JobProfileID:   req.JobProfileID,
-		CostCenterCode: req.CostCenterCode,
-		Profile:        profile,
+		PositionID:       positionID,
+		AsOf:             effectiveDate,
+		ReasonCode:       req.ReasonCode,
+		ReasonNote:       req.ReasonNote,
+		OrgNodeID:        req.OrgNodeID,
+		Title:            req.Title,
+		Lifecycle:        req.LifecycleStatus,
+		PositionType:     req.PositionType,
+		EmploymentType:   req.EmploymentType,
+		CapacityFTE:      req.CapacityFTE,
+		ReportsToID:      req.ReportsToID,
+		JobLevelCode:     req.JobLevelCode,
+		JobProfileID:     req.JobProfileID,
+		CostCenterCode:   req.CostCenterCode,
+		Profile:          profile,
+		CustomAttributes: req.CustomAttributes,
 	})
 	if err != nil {
 		writeServiceError(w, requestID, err)
@@ -3450,21 +3520,9 @@ func (c *OrgAPIController) GetSnapshot(w http.ResponseWriter, r *http.Request) {
 }
 
 type batchRequest struct {
-	DryRun        bool           `json:"dry_run"`
-	EffectiveDate string         `json:"effective_date"`
-	Commands      []batchCommand `json:"commands"`
-}
-
-type batchCommand struct {
-	Type    string          `json:"type"`
-	Payload json.RawMessage `json:"payload"`
-}
-
-type batchCommandResult struct {
-	Index  int            `json:"index"`
-	Type   string         `json:"type"`
-	Ok     bool           `json:"ok"`
-	Result map[string]any `json:"result,omitempty"`
+	DryRun        bool               `json:"dry_run"`
+	EffectiveDate string             `json:"effective_date"`
+	Commands      []services.Command `json:"commands"`
 }
 
 func (c *OrgAPIController) Batch(w http.ResponseWriter, r *http.Request) {
@@ -3534,490 +3592,23 @@ func (c *OrgAPIController) Batch(w http.ResponseWriter, r *http.Request) {
 	}
 
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
-	results := make([]batchCommandResult, 0, len(req.Commands))
-	eventsEnqueued := 0
-
-	for i, cmd := range req.Commands {
-		cmdType := strings.TrimSpace(cmd.Type)
-		if cmdType == "" {
-			writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "type is required")
-			return
-		}
-		payload := cmd.Payload
-		if len(payload) == 0 {
-			writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is required")
-			return
-		}
-		payload, err = injectEffectiveDate(payload, globalEffective)
-		if err != nil {
-			writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-			return
-		}
-
-		switch cmdType {
-		case "node.create":
-			var body createNodeRequest
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.CreateNode(txCtx, tenantID, requestID, initiatorID, services.CreateNodeInput{
-				Code:          body.Code,
-				Name:          body.Name,
-				ParentID:      body.ParentID,
-				EffectiveDate: effectiveDate,
-				I18nNames:     body.I18nNames,
-				Status:        body.Status,
-				DisplayOrder:  body.DisplayOrder,
-				LegalEntityID: body.LegalEntityID,
-				CompanyCode:   body.CompanyCode,
-				LocationID:    body.LocationID,
-				ManagerUserID: body.ManagerUserID,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.NodeID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "node.update":
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				updateNodeRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.UpdateNode(txCtx, tenantID, requestID, initiatorID, services.UpdateNodeInput{
-				NodeID:        body.ID,
-				EffectiveDate: effectiveDate,
-				Name:          body.Name,
-				I18nNames:     body.I18nNames,
-				Status:        body.Status,
-				DisplayOrder:  body.DisplayOrder,
-				LegalEntityID: fieldIfSetUUID(body.LegalEntityID),
-				CompanyCode:   fieldIfSetString(body.CompanyCode),
-				LocationID:    fieldIfSetUUID(body.LocationID),
-				ManagerUserID: fieldIfSetInt64(body.ManagerUserID),
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.NodeID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "node.move":
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				moveNodeRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.MoveNode(txCtx, tenantID, requestID, initiatorID, services.MoveNodeInput{
-				NodeID:        body.ID,
-				NewParentID:   body.NewParentID,
-				EffectiveDate: effectiveDate,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.EdgeID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "node.correct":
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				correctNodeRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			asOf, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.CorrectNode(txCtx, tenantID, requestID, initiatorID, services.CorrectNodeInput{
-				NodeID:        body.ID,
-				AsOf:          asOf,
-				Name:          body.Name,
-				I18nNames:     body.I18nNames,
-				Status:        body.Status,
-				DisplayOrder:  body.DisplayOrder,
-				LegalEntityID: fieldIfSetUUID(body.LegalEntityID),
-				CompanyCode:   fieldIfSetString(body.CompanyCode),
-				LocationID:    fieldIfSetUUID(body.LocationID),
-				ManagerUserID: fieldIfSetInt64(body.ManagerUserID),
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.NodeID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "node.rescind":
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				rescindNodeRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.RescindNode(txCtx, tenantID, requestID, initiatorID, services.RescindNodeInput{
-				NodeID:        body.ID,
-				EffectiveDate: effectiveDate,
-				Reason:        body.Reason,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.NodeID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "node.shift_boundary":
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				shiftBoundaryNodeRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			target, err := parseRequiredValidDate("target_effective_date", body.TargetEffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "target_effective_date is required")
-				return
-			}
-			newStart, err := parseRequiredValidDate("new_effective_date", body.NewEffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "new_effective_date is required")
-				return
-			}
-			res, err := c.org.ShiftBoundaryNode(txCtx, tenantID, requestID, initiatorID, services.ShiftBoundaryNodeInput{
-				NodeID:              body.ID,
-				TargetEffectiveDate: target,
-				NewEffectiveDate:    newStart,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.NodeID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "node.correct_move":
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				correctMoveNodeRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.CorrectMoveNode(txCtx, tenantID, requestID, initiatorID, services.CorrectMoveNodeInput{
-				NodeID:        body.ID,
-				EffectiveDate: effectiveDate,
-				NewParentID:   body.NewParentID,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.NodeID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "assignment.create":
-			var body createAssignmentRequest
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.CreateAssignment(txCtx, tenantID, requestID, initiatorID, services.CreateAssignmentInput{
-				Pernr:          body.Pernr,
-				EffectiveDate:  effectiveDate,
-				AssignmentType: body.AssignmentType,
-				PositionID:     body.PositionID,
-				OrgNodeID:      body.OrgNodeID,
-				SubjectID:      body.SubjectID,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.AssignmentID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "assignment.update":
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				updateAssignmentRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			var positionID *uuid.UUID
-			if body.PositionID.Set && body.PositionID.Value != nil {
-				positionID = body.PositionID.Value
-			}
-			var orgNodeID *uuid.UUID
-			if body.OrgNodeID.Set && body.OrgNodeID.Value != nil {
-				orgNodeID = body.OrgNodeID.Value
-			}
-			res, err := c.org.UpdateAssignment(txCtx, tenantID, requestID, initiatorID, services.UpdateAssignmentInput{
-				AssignmentID:  body.ID,
-				EffectiveDate: effectiveDate,
-				PositionID:    positionID,
-				OrgNodeID:     orgNodeID,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.AssignmentID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "assignment.correct":
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				correctAssignmentRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			res, err := c.org.CorrectAssignment(txCtx, tenantID, requestID, initiatorID, services.CorrectAssignmentInput{
-				AssignmentID: body.ID,
-				Pernr:        body.Pernr,
-				PositionID:   body.PositionID,
-				SubjectID:    body.SubjectID,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.AssignmentID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "assignment.rescind":
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				rescindAssignmentRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.RescindAssignment(txCtx, tenantID, requestID, initiatorID, services.RescindAssignmentInput{
-				AssignmentID:  body.ID,
-				EffectiveDate: effectiveDate,
-				Reason:        body.Reason,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.AssignmentID.String()}})
-			if !req.DryRun {
-				eventsEnqueued += len(res.GeneratedEvents)
-			}
-
-		case "security_group_mapping.create":
-			if !configuration.Use().OrgSecurityGroupMappingsEnabled {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusNotFound, "ORG_NOT_FOUND", "not found")
-				return
-			}
-			var body createSecurityGroupMappingRequest
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.CreateSecurityGroupMapping(txCtx, tenantID, requestID, initiatorID, services.CreateSecurityGroupMappingInput{
-				OrgNodeID:        body.OrgNodeID,
-				SecurityGroupKey: body.SecurityGroupKey,
-				AppliesToSubtree: body.AppliesToSubtree,
-				EffectiveDate:    effectiveDate,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.ID.String()}})
-
-		case "security_group_mapping.rescind":
-			if !configuration.Use().OrgSecurityGroupMappingsEnabled {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusNotFound, "ORG_NOT_FOUND", "not found")
-				return
-			}
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				rescindSecurityGroupMappingRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.RescindSecurityGroupMapping(txCtx, tenantID, requestID, initiatorID, services.RescindSecurityGroupMappingInput{
-				ID:            body.ID,
-				EffectiveDate: effectiveDate,
-				Reason:        body.Reason,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.ID.String()}})
-
-		case "link.create":
-			if !configuration.Use().OrgLinksEnabled {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusNotFound, "ORG_NOT_FOUND", "not found")
-				return
-			}
-			var body createLinkRequest
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.CreateOrgLink(txCtx, tenantID, requestID, initiatorID, services.CreateOrgLinkInput{
-				OrgNodeID:     body.OrgNodeID,
-				ObjectType:    body.ObjectType,
-				ObjectKey:     body.ObjectKey,
-				LinkType:      body.LinkType,
-				Metadata:      body.Metadata,
-				EffectiveDate: effectiveDate,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.ID.String()}})
-
-		case "link.rescind":
-			if !configuration.Use().OrgLinksEnabled {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusNotFound, "ORG_NOT_FOUND", "not found")
-				return
-			}
-			var body struct {
-				ID uuid.UUID `json:"id"`
-				rescindLinkRequest
-			}
-			if err := json.Unmarshal(payload, &body); err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "payload is invalid")
-				return
-			}
-			effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-			if err != nil {
-				writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "effective_date is required")
-				return
-			}
-			res, err := c.org.RescindOrgLink(txCtx, tenantID, requestID, initiatorID, services.RescindOrgLinkInput{
-				ID:            body.ID,
-				EffectiveDate: effectiveDate,
-				Reason:        body.Reason,
-			})
-			if err != nil {
-				writeBatchServiceError(w, requestID, i, cmdType, err)
-				return
-			}
-			results = append(results, batchCommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": res.ID.String()}})
-
-		default:
-			writeBatchCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_BATCH_INVALID_COMMAND", "unknown type")
+	results, eventsEnqueued, err := c.org.ExecuteCommands(txCtx, tenantID, requestID, initiatorID, globalEffective, req.Commands, "ORG_BATCH_INVALID_COMMAND")
+	if err != nil {
+		var cmdErr *services.CommandError
+		if errors.As(err, &cmdErr) {
+			writeBatchServiceError(w, requestID, cmdErr.Index, cmdErr.Type, cmdErr.Err)
 			return
 		}
+		writeAPIError(w, http.StatusInternalServerError, requestID, "ORG_INTERNAL", err.Error())
+		return
 	}
 
 	if req.DryRun {
 		_ = tx.Rollback(r.Context())
 		type batchResponse struct {
-			DryRun         bool                 `json:"dry_run"`
-			Results        []batchCommandResult `json:"results"`
-			EventsEnqueued int                  `json:"events_enqueued"`
+			DryRun         bool                     `json:"dry_run"`
+			Results        []services.CommandResult `json:"results"`
+			EventsEnqueued int                      `json:"events_enqueued"`
 		}
 		writeJSON(w, http.StatusOK, batchResponse{
 			DryRun:         true,
@@ -4034,9 +3625,9 @@ func (c *OrgAPIController) Batch(w http.ResponseWriter, r *http.Request) {
 	c.org.InvalidateTenantCacheWithReason(tenantID, "write_commit")
 
 	type batchResponse struct {
-		DryRun         bool                 `json:"dry_run"`
-		Results        []batchCommandResult `json:"results"`
-		EventsEnqueued int                  `json:"events_enqueued"`
+		DryRun         bool                     `json:"dry_run"`
+		Results        []services.CommandResult `json:"results"`
+		EventsEnqueued int                      `json:"events_enqueued"`
 	}
 	writeJSON(w, http.StatusOK, batchResponse{
 		DryRun:         false,
@@ -4133,7 +3724,7 @@ func (c *OrgAPIController) ListChangeRequests(w http.ResponseWriter, r *http.Req
 
 	status := strings.TrimSpace(r.URL.Query().Get("status"))
 	switch status {
-	case "", "draft", "submitted", "cancelled":
+	case "", "draft", "submitted", "approved", "rejected", "applied", "cancelled":
 	default:
 		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "status is invalid")
 		return
@@ -4211,10 +3802,47 @@ type changeRequestDetailResponse struct {
 	PayloadSchemaVersion int32           `json:"payload_schema_version"`
 	Payload              json.RawMessage `json:"payload"`
 	Notes                *string         `json:"notes,omitempty"`
+	ReviewerID           *string         `json:"reviewer_id,omitempty"`
+	ReviewComment        *string         `json:"review_comment,omitempty"`
+	ReviewedAt           *string         `json:"reviewed_at,omitempty"`
+	AppliedBy            *string         `json:"applied_by,omitempty"`
+	AppliedAt            *string         `json:"applied_at,omitempty"`
+	ScheduledFor         *string         `json:"scheduled_for,omitempty"`
+	ExecutionAttempts    int32           `json:"execution_attempts"`
+	LastExecutedAt       *string         `json:"last_executed_at,omitempty"`
+	ExecutionResult      json.RawMessage `json:"execution_result,omitempty"`
+	ExecutionError       json.RawMessage `json:"execution_error,omitempty"`
+	AuditRequestID       *string         `json:"audit_request_id,omitempty"`
 	CreatedAt            string          `json:"created_at"`
 	UpdatedAt            string          `json:"updated_at"`
 }
 
+func toChangeRequestDetailResponse(cr *changerequest.ChangeRequest) changeRequestDetailResponse {
+	return changeRequestDetailResponse{
+		ID:                   cr.ID.String(),
+		TenantID:             cr.TenantID.String(),
+		RequestID:            cr.RequestID,
+		RequesterID:          cr.RequesterID.String(),
+		Status:               cr.Status,
+		PayloadSchemaVersion: cr.PayloadSchemaVersion,
+		Payload:              cr.Payload,
+		Notes:                cr.Notes,
+		ReviewerID:           optionalUUIDString(cr.ReviewerID),
+		ReviewComment:        cr.ReviewComment,
+		ReviewedAt:           optionalRFC3339(cr.ReviewedAt),
+		AppliedBy:            optionalUUIDString(cr.AppliedBy),
+		AppliedAt:            optionalRFC3339(cr.AppliedAt),
+		ScheduledFor:         optionalRFC3339(cr.ScheduledFor),
+		ExecutionAttempts:    cr.ExecutionAttempts,
+		LastExecutedAt:       optionalRFC3339(cr.LastExecutedAt),
+		ExecutionResult:      cr.ExecutionResult,
+		ExecutionError:       cr.ExecutionError,
+		AuditRequestID:       cr.AuditRequestID,
+		CreatedAt:            cr.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:            cr.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
 func (c *OrgAPIController) GetChangeRequest(w http.ResponseWriter, r *http.Request) {
 	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
 	if !ok {
@@ -4242,18 +3870,7 @@ func (c *OrgAPIController) GetChangeRequest(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	writeJSON(w, http.StatusOK, changeRequestDetailResponse{
-		ID:                   cr.ID.String(),
-		TenantID:             cr.TenantID.String(),
-		RequestID:            cr.RequestID,
-		RequesterID:          cr.RequesterID.String(),
-		Status:               cr.Status,
-		PayloadSchemaVersion: cr.PayloadSchemaVersion,
-		Payload:              cr.Payload,
-		Notes:                cr.Notes,
-		CreatedAt:            cr.CreatedAt.UTC().Format(time.RFC3339),
-		UpdatedAt:            cr.UpdatedAt.UTC().Format(time.RFC3339),
-	})
+	writeJSON(w, http.StatusOK, toChangeRequestDetailResponse(cr))
 }
 
 func (c *OrgAPIController) UpdateChangeRequest(w http.ResponseWriter, r *http.Request) {
@@ -4376,626 +3993,185 @@ func (c *OrgAPIController) CancelChangeRequest(w http.ResponseWriter, r *http.Re
 	})
 }
 
-type preflightRequest struct {
-	EffectiveDate string         `json:"effective_date"`
-	Commands      []batchCommand `json:"commands"`
-}
-
-type preflightResponse struct {
-	EffectiveDate string          `json:"effective_date"`
-	CommandsCount int             `json:"commands_count"`
-	Impact        preflightImpact `json:"impact"`
-	Warnings      []string        `json:"warnings"`
+type changeRequestReviewRequest struct {
+	Comment *string `json:"comment,omitempty"`
 }
 
-type preflightImpact struct {
-	OrgNodes       preflightCounters `json:"org_nodes"`
-	OrgAssignments preflightCounters `json:"org_assignments"`
-	Events         map[string]int    `json:"events"`
-	Affected       preflightAffected `json:"affected"`
+type changeRequestReviewResponse struct {
+	ID            string                    `json:"id"`
+	RequestID     string                    `json:"request_id"`
+	Status        string                    `json:"status"`
+	ReviewerID    *string                   `json:"reviewer_id,omitempty"`
+	ReviewComment *string                   `json:"review_comment,omitempty"`
+	ReviewedAt    *string                   `json:"reviewed_at,omitempty"`
+	Impact        *services.PreflightImpact `json:"impact,omitempty"`
+	UpdatedAt     string                    `json:"updated_at"`
 }
 
-type preflightCounters struct {
-	Create  int `json:"create"`
-	Update  int `json:"update"`
-	Move    int `json:"move"`
-	Rescind int `json:"rescind"`
+func (c *OrgAPIController) ApproveChangeRequest(w http.ResponseWriter, r *http.Request) {
+	c.reviewChangeRequest(w, r, true)
 }
 
-type preflightAffected struct {
-	OrgNodeIDsCount  int      `json:"org_node_ids_count"`
-	OrgNodeIDsSample []string `json:"org_node_ids_sample"`
+func (c *OrgAPIController) RejectChangeRequest(w http.ResponseWriter, r *http.Request) {
+	c.reviewChangeRequest(w, r, false)
 }
 
-func (c *OrgAPIController) Preflight(w http.ResponseWriter, r *http.Request) {
+func (c *OrgAPIController) reviewChangeRequest(w http.ResponseWriter, r *http.Request, approve bool) {
 	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
 	if !ok {
 		return
 	}
-	if !requireOrgPreflightEnabled(w, requestID) {
-		return
-	}
-	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgPreflightAuthzObject, "admin") {
-		return
-	}
-
-	var req preflightRequest
-	if err := decodeJSON(r.Body, &req); err != nil {
-		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_PREFLIGHT_INVALID_BODY", "invalid json body")
-		return
-	}
-	if len(req.Commands) < 1 || len(req.Commands) > 100 {
-		writeAPIError(w, http.StatusUnprocessableEntity, requestID, "ORG_PREFLIGHT_TOO_LARGE", "commands size is invalid")
+	if !requireOrgChangeRequestsEnabled(w, requestID) {
 		return
 	}
-
-	moves := 0
-	for _, cmd := range req.Commands {
-		switch strings.TrimSpace(cmd.Type) {
-		case "node.move", "node.correct_move":
-			moves++
-		}
-	}
-	if moves > 10 {
-		writeAPIError(w, http.StatusUnprocessableEntity, requestID, "ORG_PREFLIGHT_TOO_LARGE", "too many move commands")
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgChangeRequestsAuthzObj, "approve") {
 		return
 	}
 
-	globalEffective := strings.TrimSpace(req.EffectiveDate)
-	if globalEffective == "" {
-		globalEffective = formatValidDate(time.Now())
-	} else {
-		if _, err := parseEffectiveDate(globalEffective); err != nil {
-			writeAPIError(w, http.StatusUnprocessableEntity, requestID, "ORG_PREFLIGHT_INVALID_BODY", "effective_date is invalid")
-			return
-		}
-	}
-
-	pool, err := composables.UsePool(r.Context())
-	if err != nil {
-		writeAPIError(w, http.StatusInternalServerError, requestID, "ORG_INTERNAL", err.Error())
-		return
-	}
-	tx, err := pool.Begin(r.Context())
+	id, err := uuid.Parse(mux.Vars(r)["id"])
 	if err != nil {
-		writeAPIError(w, http.StatusInternalServerError, requestID, "ORG_INTERNAL", err.Error())
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
 		return
 	}
-	defer func() { _ = tx.Rollback(r.Context()) }()
 
-	txCtx := composables.WithTx(r.Context(), tx)
-	txCtx = composables.WithTenantID(txCtx, tenantID)
-	if err := composables.ApplyTenantRLS(txCtx, tx); err != nil {
-		writeAPIError(w, http.StatusInternalServerError, requestID, "ORG_INTERNAL", err.Error())
+	var req changeRequestReviewRequest
+	if err := decodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_CHANGE_REQUEST_INVALID_BODY", "invalid json body")
 		return
 	}
-	txCtx = services.WithSkipCacheInvalidation(txCtx)
-	txCtx = services.WithSkipOutboxEnqueue(txCtx)
 
-	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	reviewerID := authzutil.NormalizedUserUUID(tenantID, currentUser)
 
-	// Validation stage: execute commands in a rolled-back transaction.
-	for i, cmd := range req.Commands {
-		cmdType := strings.TrimSpace(cmd.Type)
-		if cmdType == "" {
-			writePreflightCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_PREFLIGHT_INVALID_COMMAND", "type is required")
+	// Approval re-validates the stored payload so reviewers never sign off on commands
+	// that no longer apply cleanly against the current hierarchy.
+	var impact *services.PreflightImpact
+	if approve {
+		existing, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+			return c.changeRequests.Get(txCtx, id)
+		})
+		if err != nil {
+			writeServiceError(w, requestID, err)
 			return
 		}
-		payload := cmd.Payload
-		if len(payload) == 0 {
-			writePreflightCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_PREFLIGHT_INVALID_COMMAND", "payload is required")
+		if existing.Status != changerequest.StatusSubmitted {
+			writeAPIError(w, http.StatusConflict, requestID, "ORG_CHANGE_REQUEST_NOT_SUBMITTED", "change request is not submitted")
 			return
 		}
-		payload, err = injectEffectiveDate(payload, globalEffective)
-		if err != nil {
-			writePreflightCommandError(w, requestID, i, cmdType, http.StatusUnprocessableEntity, "ORG_PREFLIGHT_INVALID_COMMAND", "payload is invalid")
+		if err := services.EnsureNotSelfReview(existing, reviewerID); err != nil {
+			writeServiceError(w, requestID, err)
 			return
 		}
-
-		if err := c.executePreflightCommand(txCtx, tenantID, requestID, initiatorID, cmdType, payload); err != nil {
-			var svcErr *services.ServiceError
-			if errors.As(err, &svcErr) {
-				writePreflightCommandError(w, requestID, i, cmdType, svcErr.Status, svcErr.Code, svcErr.Message)
-				return
-			}
-			writePreflightCommandError(w, requestID, i, cmdType, http.StatusInternalServerError, "ORG_INTERNAL", err.Error())
+		res, err := c.changeRequestExecutor().Preflight(r.Context(), tenantID, reviewerID, existing)
+		if err != nil {
+			writePreflightError(w, requestID, err)
 			return
 		}
+		impact = &res.Impact
 	}
 
-	// Always rollback (no writes).
-	_ = tx.Rollback(r.Context())
-
-	impact, err := c.analyzePreflightImpact(r.Context(), tenantID, globalEffective, req.Commands)
+	cr, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+		params := services.ReviewChangeRequestParams{
+			ID:               id,
+			ReviewerID:       reviewerID,
+			ReviewerSubjects: reviewerSubjects(reviewerID, currentUser),
+			Comment:          req.Comment,
+		}
+		if approve {
+			return c.changeRequests.Approve(txCtx, params)
+		}
+		return c.changeRequests.Reject(txCtx, params)
+	})
 	if err != nil {
 		writeServiceError(w, requestID, err)
 		return
 	}
 
-	asOf, _ := parseEffectiveDate(globalEffective)
-	writeJSON(w, http.StatusOK, preflightResponse{
-		EffectiveDate: formatValidDate(asOf),
-		CommandsCount: len(req.Commands),
+	writeJSON(w, http.StatusOK, changeRequestReviewResponse{
+		ID:            cr.ID.String(),
+		RequestID:     cr.RequestID,
+		Status:        cr.Status,
+		ReviewerID:    optionalUUIDString(cr.ReviewerID),
+		ReviewComment: cr.ReviewComment,
+		ReviewedAt:    optionalRFC3339(cr.ReviewedAt),
 		Impact:        impact,
-		Warnings:      []string{},
+		UpdatedAt:     cr.UpdatedAt.UTC().Format(time.RFC3339),
 	})
 }
 
-func (c *OrgAPIController) executePreflightCommand(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, cmdType string, payload json.RawMessage) error {
-	switch cmdType {
-	case "node.create":
-		var body createNodeRequest
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		_, err = c.org.CreateNode(ctx, tenantID, requestID, initiatorID, services.CreateNodeInput{
-			Code:          body.Code,
-			Name:          body.Name,
-			ParentID:      body.ParentID,
-			EffectiveDate: effectiveDate,
-			I18nNames:     body.I18nNames,
-			Status:        body.Status,
-			DisplayOrder:  body.DisplayOrder,
-			LegalEntityID: body.LegalEntityID,
-			CompanyCode:   body.CompanyCode,
-			LocationID:    body.LocationID,
-			ManagerUserID: body.ManagerUserID,
-		})
-		return err
-	case "node.update":
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			updateNodeRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		var legalEntityID **uuid.UUID
-		if body.LegalEntityID.Set {
-			legalEntityID = &body.LegalEntityID.Value
-		}
-		var companyCode **string
-		if body.CompanyCode.Set {
-			companyCode = &body.CompanyCode.Value
-		}
-		var locationID **uuid.UUID
-		if body.LocationID.Set {
-			locationID = &body.LocationID.Value
-		}
-		var managerUserID **int64
-		if body.ManagerUserID.Set {
-			managerUserID = &body.ManagerUserID.Value
-		}
-		_, err = c.org.UpdateNode(ctx, tenantID, requestID, initiatorID, services.UpdateNodeInput{
-			NodeID:        body.ID,
-			EffectiveDate: effectiveDate,
-			Name:          body.Name,
-			I18nNames:     body.I18nNames,
-			Status:        body.Status,
-			DisplayOrder:  body.DisplayOrder,
-			LegalEntityID: legalEntityID,
-			CompanyCode:   companyCode,
-			LocationID:    locationID,
-			ManagerUserID: managerUserID,
-		})
-		return err
-	case "node.move":
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			moveNodeRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		if body.NewParentID == uuid.Nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "new_parent_id is required"}
-		}
-		_, err = c.org.MoveNode(ctx, tenantID, requestID, initiatorID, services.MoveNodeInput{
-			NodeID:        body.ID,
-			NewParentID:   body.NewParentID,
-			EffectiveDate: effectiveDate,
-		})
-		return err
-	case "node.correct":
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			correctNodeRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		var legalEntityID **uuid.UUID
-		if body.LegalEntityID.Set {
-			legalEntityID = &body.LegalEntityID.Value
-		}
-		var companyCode **string
-		if body.CompanyCode.Set {
-			companyCode = &body.CompanyCode.Value
-		}
-		var locationID **uuid.UUID
-		if body.LocationID.Set {
-			locationID = &body.LocationID.Value
-		}
-		var managerUserID **int64
-		if body.ManagerUserID.Set {
-			managerUserID = &body.ManagerUserID.Value
-		}
-		_, err = c.org.CorrectNode(ctx, tenantID, requestID, initiatorID, services.CorrectNodeInput{
-			NodeID:        body.ID,
-			AsOf:          effectiveDate,
-			Name:          body.Name,
-			I18nNames:     body.I18nNames,
-			Status:        body.Status,
-			DisplayOrder:  body.DisplayOrder,
-			LegalEntityID: legalEntityID,
-			CompanyCode:   companyCode,
-			LocationID:    locationID,
-			ManagerUserID: managerUserID,
-		})
-		return err
-	case "node.rescind":
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			rescindNodeRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		_, err = c.org.RescindNode(ctx, tenantID, requestID, initiatorID, services.RescindNodeInput{
-			NodeID:        body.ID,
-			EffectiveDate: effectiveDate,
-			Reason:        body.Reason,
-		})
-		return err
-	case "node.shift_boundary":
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			shiftBoundaryNodeRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		targetDate, err := parseRequiredValidDate("target_effective_date", body.TargetEffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "target_effective_date is required", Cause: err}
-		}
-		newDate, err := parseRequiredValidDate("new_effective_date", body.NewEffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "new_effective_date is required", Cause: err}
-		}
-		_, err = c.org.ShiftBoundaryNode(ctx, tenantID, requestID, initiatorID, services.ShiftBoundaryNodeInput{
-			NodeID:              body.ID,
-			TargetEffectiveDate: targetDate,
-			NewEffectiveDate:    newDate,
-		})
-		return err
-	case "node.correct_move":
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			correctMoveNodeRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		if body.NewParentID == uuid.Nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "new_parent_id is required"}
-		}
-		_, err = c.org.CorrectMoveNode(ctx, tenantID, requestID, initiatorID, services.CorrectMoveNodeInput{
-			NodeID:        body.ID,
-			EffectiveDate: effectiveDate,
-			NewParentID:   body.NewParentID,
-		})
-		return err
-	case "assignment.create":
-		var body createAssignmentRequest
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		_, err = c.org.CreateAssignment(ctx, tenantID, requestID, initiatorID, services.CreateAssignmentInput{
-			Pernr:          body.Pernr,
-			EffectiveDate:  effectiveDate,
-			PositionID:     body.PositionID,
-			OrgNodeID:      body.OrgNodeID,
-			AssignmentType: body.AssignmentType,
-			SubjectID:      body.SubjectID,
-		})
-		return err
-	case "assignment.update":
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			updateAssignmentRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		var positionID *uuid.UUID
-		if body.PositionID.Set {
-			positionID = body.PositionID.Value
-		}
-		var orgNodeID *uuid.UUID
-		if body.OrgNodeID.Set {
-			orgNodeID = body.OrgNodeID.Value
-		}
-		_, err = c.org.UpdateAssignment(ctx, tenantID, requestID, initiatorID, services.UpdateAssignmentInput{
-			AssignmentID:  body.ID,
-			EffectiveDate: effectiveDate,
-			PositionID:    positionID,
-			OrgNodeID:     orgNodeID,
-		})
-		return err
-	case "assignment.correct":
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			correctAssignmentRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		_, err := c.org.CorrectAssignment(ctx, tenantID, requestID, initiatorID, services.CorrectAssignmentInput{
-			AssignmentID: body.ID,
-			Pernr:        body.Pernr,
-			PositionID:   body.PositionID,
-			SubjectID:    body.SubjectID,
-		})
-		return err
-	case "assignment.rescind":
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			rescindAssignmentRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		_, err = c.org.RescindAssignment(ctx, tenantID, requestID, initiatorID, services.RescindAssignmentInput{
-			AssignmentID:  body.ID,
-			EffectiveDate: effectiveDate,
-			Reason:        body.Reason,
-		})
-		return err
-	case "security_group_mapping.create":
-		if !configuration.Use().OrgSecurityGroupMappingsEnabled {
-			return &services.ServiceError{Status: http.StatusNotFound, Code: "ORG_NOT_FOUND", Message: "not found"}
-		}
-		var body createSecurityGroupMappingRequest
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		_, err = c.org.CreateSecurityGroupMapping(ctx, tenantID, requestID, initiatorID, services.CreateSecurityGroupMappingInput{
-			OrgNodeID:        body.OrgNodeID,
-			SecurityGroupKey: body.SecurityGroupKey,
-			AppliesToSubtree: body.AppliesToSubtree,
-			EffectiveDate:    effectiveDate,
-		})
-		return err
-	case "security_group_mapping.rescind":
-		if !configuration.Use().OrgSecurityGroupMappingsEnabled {
-			return &services.ServiceError{Status: http.StatusNotFound, Code: "ORG_NOT_FOUND", Message: "not found"}
-		}
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			rescindSecurityGroupMappingRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		_, err = c.org.RescindSecurityGroupMapping(ctx, tenantID, requestID, initiatorID, services.RescindSecurityGroupMappingInput{
-			ID:            body.ID,
-			EffectiveDate: effectiveDate,
-			Reason:        body.Reason,
-		})
-		return err
-	case "link.create":
-		if !configuration.Use().OrgLinksEnabled {
-			return &services.ServiceError{Status: http.StatusNotFound, Code: "ORG_NOT_FOUND", Message: "not found"}
-		}
-		var body createLinkRequest
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		_, err = c.org.CreateOrgLink(ctx, tenantID, requestID, initiatorID, services.CreateOrgLinkInput{
-			OrgNodeID:     body.OrgNodeID,
-			ObjectType:    body.ObjectType,
-			ObjectKey:     body.ObjectKey,
-			LinkType:      body.LinkType,
-			Metadata:      body.Metadata,
-			EffectiveDate: effectiveDate,
-		})
-		return err
-	case "link.rescind":
-		if !configuration.Use().OrgLinksEnabled {
-			return &services.ServiceError{Status: http.StatusNotFound, Code: "ORG_NOT_FOUND", Message: "not found"}
-		}
-		var body struct {
-			ID uuid.UUID `json:"id"`
-			rescindLinkRequest
-		}
-		if err := json.Unmarshal(payload, &body); err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "payload is invalid", Cause: err}
-		}
-		effectiveDate, err := parseRequiredEffectiveDate(body.EffectiveDate)
-		if err != nil {
-			return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "effective_date is required", Cause: err}
-		}
-		_, err = c.org.RescindOrgLink(ctx, tenantID, requestID, initiatorID, services.RescindOrgLinkInput{
-			ID:            body.ID,
-			EffectiveDate: effectiveDate,
-			Reason:        body.Reason,
-		})
-		return err
-	default:
-		return &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_INVALID_COMMAND", Message: "unknown type"}
-	}
+type changeRequestApplyResponse struct {
+	ID        string                   `json:"id"`
+	RequestID string                   `json:"request_id"`
+	Status    string                   `json:"status"`
+	AppliedBy *string                  `json:"applied_by,omitempty"`
+	AppliedAt *string                  `json:"applied_at,omitempty"`
+	Results   []services.CommandResult `json:"results"`
 }
 
-func (c *OrgAPIController) analyzePreflightImpact(ctx context.Context, tenantID uuid.UUID, effective string, commands []batchCommand) (preflightImpact, error) {
-	out := preflightImpact{
-		Events: map[string]int{
-			"org.changed.v1":            0,
-			"org.assignment.changed.v1": 0,
-		},
-		Affected: preflightAffected{
-			OrgNodeIDsCount:  0,
-			OrgNodeIDsSample: []string{},
-		},
+func (c *OrgAPIController) ApplyChangeRequest(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
 	}
-
-	asOf, err := parseEffectiveDate(effective)
-	if err != nil || asOf.IsZero() {
-		asOf = time.Now().UTC()
+	if !requireOrgChangeRequestsEnabled(w, requestID) {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgChangeRequestsAuthzObj, "admin") {
+		return
 	}
 
-	nodes, _, err := c.org.GetHierarchyAsOf(ctx, tenantID, "OrgUnit", asOf)
+	id, err := uuid.Parse(mux.Vars(r)["id"])
 	if err != nil {
-		return preflightImpact{}, err
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
 	}
 
-	children := map[uuid.UUID][]uuid.UUID{}
-	for _, n := range nodes {
-		if n.ParentID == nil {
-			continue
-		}
-		children[*n.ParentID] = append(children[*n.ParentID], n.ID)
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+
+	out, err := c.changeRequestExecutor().ApplyApproved(r.Context(), tenantID, id, initiatorID, time.Now().UTC())
+	if err != nil {
+		writePreflightError(w, requestID, err)
+		return
 	}
 
-	affected := map[uuid.UUID]struct{}{}
-	addAffected := func(id uuid.UUID) {
-		if id == uuid.Nil {
-			return
-		}
-		affected[id] = struct{}{}
+	writeJSON(w, http.StatusOK, changeRequestApplyResponse{
+		ID:        out.ChangeRequest.ID.String(),
+		RequestID: out.ChangeRequest.RequestID,
+		Status:    out.ChangeRequest.Status,
+		AppliedBy: optionalUUIDString(out.ChangeRequest.AppliedBy),
+		AppliedAt: optionalRFC3339(out.ChangeRequest.AppliedAt),
+		Results:   out.Results,
+	})
+}
+
+func (c *OrgAPIController) changeRequestExecutor() *services.ChangeRequestExecutor {
+	return services.NewChangeRequestExecutor(c.org, c.changeRequests)
+}
+
+func (c *OrgAPIController) Preflight(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !requireOrgPreflightEnabled(w, requestID) {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgPreflightAuthzObject, "admin") {
+		return
 	}
 
-	const maxSubtree = 5000
-	for _, cmd := range commands {
-		switch strings.TrimSpace(cmd.Type) {
-		case "node.create":
-			out.OrgNodes.Create++
-			out.Events["org.changed.v1"]++
-		case "node.update", "node.correct", "node.shift_boundary":
-			out.OrgNodes.Update++
-			out.Events["org.changed.v1"]++
-			var body struct {
-				ID uuid.UUID `json:"id"`
-			}
-			_ = json.Unmarshal(cmd.Payload, &body)
-			addAffected(body.ID)
-		case "node.rescind":
-			out.OrgNodes.Rescind++
-			out.Events["org.changed.v1"]++
-			var body struct {
-				ID uuid.UUID `json:"id"`
-			}
-			_ = json.Unmarshal(cmd.Payload, &body)
-			addAffected(body.ID)
-		case "node.move", "node.correct_move":
-			out.OrgNodes.Move++
-			out.Events["org.changed.v1"]++
-			var body struct {
-				ID uuid.UUID `json:"id"`
-			}
-			_ = json.Unmarshal(cmd.Payload, &body)
-			if body.ID != uuid.Nil {
-				// Count subtree (including self) at asOf.
-				count := 0
-				stack := []uuid.UUID{body.ID}
-				for len(stack) > 0 {
-					n := stack[len(stack)-1]
-					stack = stack[:len(stack)-1]
-					count++
-					if count > maxSubtree {
-						return preflightImpact{}, &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_PREFLIGHT_TOO_LARGE", Message: "subtree impact is too large"}
-					}
-					addAffected(n)
-					stack = append(stack, children[n]...)
-				}
-			}
-		case "assignment.create":
-			out.OrgAssignments.Create++
-			out.Events["org.assignment.changed.v1"]++
-			var body struct {
-				OrgNodeID *uuid.UUID `json:"org_node_id"`
-			}
-			_ = json.Unmarshal(cmd.Payload, &body)
-			if body.OrgNodeID != nil {
-				addAffected(*body.OrgNodeID)
-			}
-		case "assignment.update":
-			out.OrgAssignments.Update++
-			out.Events["org.assignment.changed.v1"]++
-			var body struct {
-				OrgNodeID *uuid.UUID `json:"org_node_id"`
-			}
-			_ = json.Unmarshal(cmd.Payload, &body)
-			if body.OrgNodeID != nil {
-				addAffected(*body.OrgNodeID)
-			}
-		case "assignment.correct":
-			out.OrgAssignments.Update++
-			out.Events["org.assignment.changed.v1"]++
-		case "assignment.rescind":
-			out.OrgAssignments.Rescind++
-			out.Events["org.assignment.changed.v1"]++
-		}
+	var req services.PreflightInput
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_PREFLIGHT_INVALID_BODY", "invalid json body")
+		return
 	}
 
-	sample := make([]string, 0, 20)
-	for id := range affected {
-		if len(sample) >= 20 {
-			break
-		}
-		sample = append(sample, id.String())
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.Preflight(r.Context(), tenantID, requestID, initiatorID, req)
+	if err != nil {
+		writePreflightError(w, requestID, err)
+		return
 	}
-	out.Affected.OrgNodeIDsCount = len(affected)
-	out.Affected.OrgNodeIDsSample = sample
-	return out, nil
+	writeJSON(w, http.StatusOK, res)
 }
 
 func validateChangeRequestPayload(raw json.RawMessage) error {
@@ -5003,8 +4179,8 @@ func validateChangeRequestPayload(raw json.RawMessage) error {
 		return fmt.Errorf("payload is required")
 	}
 	var payload struct {
-		EffectiveDate string         `json:"effective_date"`
-		Commands      []batchCommand `json:"commands"`
+		EffectiveDate string             `json:"effective_date"`
+		Commands      []services.Command `json:"commands"`
 	}
 	dec := json.NewDecoder(bytes.NewReader(raw))
 	dec.DisallowUnknownFields()
@@ -5034,19 +4210,16 @@ func validateChangeRequestPayload(raw json.RawMessage) error {
 	return nil
 }
 
+// batchCommandTypes are the command types accepted by /org/api/batch and change request payloads.
+var batchCommandTypes = []string{
+	"node.create", "node.update", "node.move", "node.correct", "node.rescind", "node.shift_boundary", "node.correct_move",
+	"assignment.create", "assignment.update", "assignment.correct", "assignment.rescind",
+	"security_group_mapping.create", "security_group_mapping.rescind",
+	"link.create", "link.rescind",
+}
+
 func isSupportedBatchCommandType(t string) bool {
-	switch t {
-	case "node.create", "node.update", "node.move", "node.correct", "node.rescind", "node.shift_boundary", "node.correct_move":
-		return true
-	case "assignment.create", "assignment.update", "assignment.correct", "assignment.rescind":
-		return true
-	case "security_group_mapping.create", "security_group_mapping.rescind":
-		return true
-	case "link.create", "link.rescind":
-		return true
-	default:
-		return false
-	}
+	return slices.Contains(batchCommandTypes, t)
 }
 
 func requireOrgChangeRequestsEnabled(w http.ResponseWriter, requestID string) bool {
@@ -5188,6 +4361,36 @@ func parseIDCursor(raw string) (uuid.UUID, bool, error) {
 	return id, true, nil
 }
 
+func writePreflightError(w http.ResponseWriter, requestID string, err error) {
+	var cmdErr *services.CommandError
+	if errors.As(err, &cmdErr) {
+		var svcErr *services.ServiceError
+		if errors.As(cmdErr.Err, &svcErr) {
+			writePreflightCommandError(w, requestID, cmdErr.Index, cmdErr.Type, svcErr.Status, svcErr.Code, svcErr.Message)
+			return
+		}
+		writePreflightCommandError(w, requestID, cmdErr.Index, cmdErr.Type, http.StatusInternalServerError, "ORG_INTERNAL", cmdErr.Err.Error())
+		return
+	}
+	writeServiceError(w, requestID, err)
+}
+
+func optionalUUIDString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	v := id.String()
+	return &v
+}
+
+func optionalRFC3339(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	v := t.UTC().Format(time.RFC3339)
+	return &v
+}
+
 func writePreflightCommandError(w http.ResponseWriter, requestID string, index int, cmdType string, status int, code string, message string) {
 	meta := map[string]string{
 		"command_index": strconv.Itoa(index),
@@ -5199,26 +4402,6 @@ func writePreflightCommandError(w http.ResponseWriter, requestID string, index i
 	writeJSON(w, status, coredtos.APIError{Code: code, Message: message, Meta: meta})
 }
 
-func injectEffectiveDate(payload json.RawMessage, effectiveDate string) (json.RawMessage, error) {
-	if strings.TrimSpace(effectiveDate) == "" {
-		return payload, nil
-	}
-	var obj map[string]any
-	dec := json.NewDecoder(bytes.NewReader(payload))
-	dec.UseNumber()
-	if err := dec.Decode(&obj); err != nil {
-		return nil, err
-	}
-	if _, ok := obj["effective_date"]; !ok {
-		obj["effective_date"] = effectiveDate
-	}
-	b, err := json.Marshal(obj)
-	if err != nil {
-		return nil, err
-	}
-	return b, nil
-}
-
 func writeBatchCommandError(w http.ResponseWriter, requestID string, commandIndex int, commandType string, status int, code, message string) {
 	meta := map[string]string{
 		"request_id":    requestID,
@@ -5250,13 +4433,6 @@ func writeBatchServiceError(w http.ResponseWriter, requestID string, commandInde
 	writeBatchCommandError(w, requestID, commandIndex, commandType, http.StatusInternalServerError, "ORG_INTERNAL", err.Error())
 }
 
-func fieldIfSetUUID(v optionalUUID) **uuid.UUID {
-	if !v.Set {
-		return nil
-	}
-	return &v.Value
-}
-
 func fieldIfSetString(v optionalString) **string {
 	if !v.Set {
 		return nil
@@ -5264,13 +4440,6 @@ func fieldIfSetString(v optionalString) **string {
 	return &v.Value
 }
 
-func fieldIfSetInt64(v optionalInt64) **int64 {
-	if !v.Set {
-		return nil
-	}
-	return &v.Value
-}
-
 func requireSessionAndTenant(w http.ResponseWriter, r *http.Request) (uuid.UUID, string, bool) {
 	requestID := ensureRequestID(r)
 
@@ -5397,14 +4566,21 @@ func decodeJSON(body io.ReadCloser, out any) error {
 func writeServiceError(w http.ResponseWriter, requestID string, err error) {
 	var svcErr *services.ServiceError
 	if errors.As(err, &svcErr) {
-		writeAPIError(w, svcErr.Status, requestID, svcErr.Code, svcErr.Message)
+		writeAPIErrorWithMeta(w, svcErr.Status, requestID, svcErr.Code, svcErr.Message, svcErr.Meta)
 		return
 	}
 	writeAPIError(w, http.StatusInternalServerError, requestID, "ORG_INTERNAL", err.Error())
 }
 
 func writeAPIError(w http.ResponseWriter, status int, requestID, code, message string) {
-	meta := map[string]string{}
+	writeAPIErrorWithMeta(w, status, requestID, code, message, nil)
+}
+
+func writeAPIErrorWithMeta(w http.ResponseWriter, status int, requestID, code, message string, extra map[string]string) {
+	meta := make(map[string]string, len(extra)+1)
+	for k, v := range extra {
+		meta[k] = v
+	}
 	if requestID != "" {
 		meta["request_id"] = requestID
 	}
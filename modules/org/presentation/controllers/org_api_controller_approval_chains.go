@@ -0,0 +1,209 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	coreuser "github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/org/domain/changerequest"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+type approvalChainStepResponse struct {
+	StepOrder          int32   `json:"step_order"`
+	RoleCode           string  `json:"role_code"`
+	Condition          string  `json:"condition"`
+	SLAHours           int32   `json:"sla_hours"`
+	EscalationRoleCode *string `json:"escalation_role_code,omitempty"`
+}
+
+type approvalChainResponse struct {
+	ChainCode string                      `json:"chain_code"`
+	Steps     []approvalChainStepResponse `json:"steps"`
+}
+
+type approvalChainRequest struct {
+	Steps []services.ApprovalChainStepInput `json:"steps"`
+}
+
+type changeRequestApprovalStepResponse struct {
+	ID                 string   `json:"id"`
+	StepOrder          int32    `json:"step_order"`
+	RoleCode           string   `json:"role_code"`
+	Status             string   `json:"status"`
+	ApproverSubjects   []string `json:"approver_subjects"`
+	SLAHours           int32    `json:"sla_hours"`
+	DueAt              *string  `json:"due_at,omitempty"`
+	EscalationRoleCode *string  `json:"escalation_role_code,omitempty"`
+	EscalationSubjects []string `json:"escalation_subjects"`
+	EscalatedAt        *string  `json:"escalated_at,omitempty"`
+	DecidedBy          *string  `json:"decided_by,omitempty"`
+	DecidedAt          *string  `json:"decided_at,omitempty"`
+	Comment            *string  `json:"comment,omitempty"`
+}
+
+func toApprovalChainResponse(steps []*changerequest.ApprovalChainStep) approvalChainResponse {
+	out := approvalChainResponse{
+		ChainCode: changerequest.DefaultApprovalChainCode,
+		Steps:     make([]approvalChainStepResponse, 0, len(steps)),
+	}
+	for _, s := range steps {
+		out.Steps = append(out.Steps, approvalChainStepResponse{
+			StepOrder:          s.StepOrder,
+			RoleCode:           s.RoleCode,
+			Condition:          s.Condition,
+			SLAHours:           s.SLAHours,
+			EscalationRoleCode: s.EscalationRoleCode,
+		})
+	}
+	return out
+}
+
+func toChangeRequestApprovalStepResponses(steps []*changerequest.ApprovalStep) []changeRequestApprovalStepResponse {
+	out := make([]changeRequestApprovalStepResponse, 0, len(steps))
+	for _, s := range steps {
+		out = append(out, changeRequestApprovalStepResponse{
+			ID:                 s.ID.String(),
+			StepOrder:          s.StepOrder,
+			RoleCode:           s.RoleCode,
+			Status:             s.Status,
+			ApproverSubjects:   s.ApproverSubjects,
+			SLAHours:           s.SLAHours,
+			DueAt:              optionalRFC3339(s.DueAt),
+			EscalationRoleCode: s.EscalationRoleCode,
+			EscalationSubjects: s.EscalationSubjects,
+			EscalatedAt:        optionalRFC3339(s.EscalatedAt),
+			DecidedBy:          optionalUUIDString(s.DecidedBy),
+			DecidedAt:          optionalRFC3339(s.DecidedAt),
+			Comment:            s.Comment,
+		})
+	}
+	return out
+}
+
+// reviewerSubjects lists the identities a reviewer can act as on an approval step, in the
+// subject format stored on org_role_assignments.
+func reviewerSubjects(reviewerID uuid.UUID, currentUser coreuser.User) []string {
+	out := []string{"user:" + reviewerID.String()}
+	for _, groupID := range currentUser.GroupIDs() {
+		out = append(out, "group:"+groupID.String())
+	}
+	return out
+}
+
+func (c *OrgAPIController) GetApprovalChain(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !requireOrgChangeRequestsEnabled(w, requestID) {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgChangeRequestsAuthzObj, "read") {
+		return
+	}
+
+	steps, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) ([]*changerequest.ApprovalChainStep, error) {
+		txCtx = composables.WithTenantID(txCtx, tenantID)
+		return c.changeRequests.GetApprovalChain(txCtx)
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toApprovalChainResponse(steps))
+}
+
+func (c *OrgAPIController) ReplaceApprovalChain(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !requireOrgChangeRequestsEnabled(w, requestID) {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgChangeRequestsAuthzObj, "admin") {
+		return
+	}
+
+	var req approvalChainRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_APPROVAL_CHAIN_INVALID_BODY", "invalid json body")
+		return
+	}
+
+	steps, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) ([]*changerequest.ApprovalChainStep, error) {
+		txCtx = composables.WithTenantID(txCtx, tenantID)
+		return c.changeRequests.ReplaceApprovalChain(txCtx, req.Steps)
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toApprovalChainResponse(steps))
+}
+
+func (c *OrgAPIController) GetChangeRequestApprovals(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !requireOrgChangeRequestsEnabled(w, requestID) {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgChangeRequestsAuthzObj, "read") {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	steps, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) ([]*changerequest.ApprovalStep, error) {
+		txCtx = composables.WithTenantID(txCtx, tenantID)
+		return c.changeRequests.ListApprovalSteps(txCtx, id)
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"change_request_id": id.String(),
+		"steps":             toChangeRequestApprovalStepResponses(steps),
+	})
+}
+
+// EscalateOverdueApprovals flags approval steps whose SLA has passed. It is idempotent, so
+// it can be driven by an external scheduler as well as by operators.
+func (c *OrgAPIController) EscalateOverdueApprovals(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !requireOrgChangeRequestsEnabled(w, requestID) {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgChangeRequestsAuthzObj, "admin") {
+		return
+	}
+
+	now := time.Now().UTC()
+	steps, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) ([]*changerequest.ApprovalStep, error) {
+		txCtx = composables.WithTenantID(txCtx, tenantID)
+		return c.changeRequests.EscalateOverdue(txCtx, now, 0)
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"escalated": toChangeRequestApprovalStepResponses(steps),
+	})
+}
@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type attributeDefinitionResponse struct {
+	ID              string                  `json:"id"`
+	EntityType      string                  `json:"entity_type"`
+	Code            string                  `json:"code"`
+	Label           string                  `json:"label"`
+	ValueType       string                  `json:"value_type"`
+	Options         []string                `json:"options"`
+	Required        bool                    `json:"required"`
+	Pattern         *string                 `json:"pattern,omitempty"`
+	MinValue        *float64                `json:"min_value,omitempty"`
+	MaxValue        *float64                `json:"max_value,omitempty"`
+	MaxLength       *int                    `json:"max_length,omitempty"`
+	DisplayOrder    int                     `json:"display_order"`
+	EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+}
+
+type attributeDefinitionWriteResponse struct {
+	ID              string                  `json:"id"`
+	EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+}
+
+func attributeDefinitionWriteResponseOf(res *services.AttributeDefinitionWriteResult) attributeDefinitionWriteResponse {
+	return attributeDefinitionWriteResponse{
+		ID: res.ID.String(),
+		EffectiveWindow: effectiveWindowResponse{
+			EffectiveDate: formatValidDate(res.EffectiveDate),
+			EndDate:       formatValidEndDateFromEndDate(res.EndDate),
+		},
+	}
+}
+
+func (c *OrgAPIController) GetAttributeDefinitions(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgAttributeDefinitionsAuthzObject, "read") {
+		return
+	}
+
+	q := r.URL.Query()
+	var filter services.AttributeDefinitionListFilter
+	if raw := strings.TrimSpace(q.Get("entity_type")); raw != "" {
+		filter.EntityType = &raw
+	}
+	asOf, err := parseEffectiveDate(q.Get("effective_date"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "effective_date is invalid")
+		return
+	}
+	if !asOf.IsZero() {
+		filter.AsOf = &asOf
+	}
+
+	rows, err := c.org.ListAttributeDefinitions(r.Context(), tenantID, filter)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		TenantID      string                        `json:"tenant_id"`
+		EffectiveDate *string                       `json:"effective_date"`
+		Definitions   []attributeDefinitionResponse `json:"definitions"`
+	}
+	out := response{
+		TenantID:    tenantID.String(),
+		Definitions: make([]attributeDefinitionResponse, 0, len(rows)),
+	}
+	if filter.AsOf != nil {
+		v := formatValidDate(*filter.AsOf)
+		out.EffectiveDate = &v
+	}
+	for _, row := range rows {
+		options := row.Options
+		if options == nil {
+			options = []string{}
+		}
+		out.Definitions = append(out.Definitions, attributeDefinitionResponse{
+			ID:           row.ID.String(),
+			EntityType:   row.EntityType,
+			Code:         row.Code,
+			Label:        row.Label,
+			ValueType:    row.ValueType,
+			Options:      options,
+			Required:     row.Required,
+			Pattern:      row.Pattern,
+			MinValue:     row.MinValue,
+			MaxValue:     row.MaxValue,
+			MaxLength:    row.MaxLength,
+			DisplayOrder: row.DisplayOrder,
+			EffectiveWindow: effectiveWindowResponse{
+				EffectiveDate: formatValidDate(row.EffectiveDate),
+				EndDate:       formatValidEndDateFromEndDate(row.EndDate),
+			},
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+type setAttributeDefinitionRequest struct {
+	EntityType    string   `json:"entity_type"`
+	Code          string   `json:"code"`
+	Label         string   `json:"label"`
+	ValueType     string   `json:"value_type"`
+	Options       []string `json:"options"`
+	Required      bool     `json:"required"`
+	Pattern       *string  `json:"pattern"`
+	MinValue      *float64 `json:"min_value"`
+	MaxValue      *float64 `json:"max_value"`
+	MaxLength     *int     `json:"max_length"`
+	DisplayOrder  int      `json:"display_order"`
+	EffectiveDate string   `json:"effective_date"`
+}
+
+func (c *OrgAPIController) SetAttributeDefinition(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgAttributeDefinitionsAuthzObject, "admin") {
+		return
+	}
+
+	var req setAttributeDefinitionRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.SetAttributeDefinition(r.Context(), tenantID, requestID, initiatorID, services.SetAttributeDefinitionInput{
+		EntityType:    req.EntityType,
+		Code:          req.Code,
+		Label:         req.Label,
+		ValueType:     req.ValueType,
+		Options:       req.Options,
+		Required:      req.Required,
+		Pattern:       req.Pattern,
+		MinValue:      req.MinValue,
+		MaxValue:      req.MaxValue,
+		MaxLength:     req.MaxLength,
+		DisplayOrder:  req.DisplayOrder,
+		EffectiveDate: effectiveDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, attributeDefinitionWriteResponseOf(res))
+}
+
+func (c *OrgAPIController) EndAttributeDefinition(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgAttributeDefinitionsAuthzObject, "admin") {
+		return
+	}
+	in, ok := decodeEndInheritanceRequest(w, r, requestID)
+	if !ok {
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.EndAttributeDefinition(r.Context(), tenantID, requestID, initiatorID, services.EndAttributeDefinitionInput{
+		ID:            in.ID,
+		EffectiveDate: in.EffectiveDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, attributeDefinitionWriteResponseOf(res))
+}
+
+func (c *OrgAPIController) RescindAttributeDefinition(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgAttributeDefinitionsAuthzObject, "admin") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.DeleteAttributeDefinition(r.Context(), tenantID, requestID, initiatorID, id)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, attributeDefinitionWriteResponseOf(res))
+}
@@ -0,0 +1,220 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type auditValueChangeResponse struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+type auditLogItem struct {
+	ID              string                     `json:"id"`
+	RequestID       string                     `json:"request_id"`
+	TransactionTime string                     `json:"transaction_time"`
+	InitiatorID     string                     `json:"initiator_id"`
+	InitiatorName   *string                    `json:"initiator_name"`
+	ChangeType      string                     `json:"change_type"`
+	EntityType      string                     `json:"entity_type"`
+	EntityID        string                     `json:"entity_id"`
+	EffectiveWindow effectiveWindowResponse    `json:"effective_window"`
+	OldValues       json.RawMessage            `json:"old_values"`
+	NewValues       json.RawMessage            `json:"new_values"`
+	Meta            json.RawMessage            `json:"meta"`
+	Changes         []auditValueChangeResponse `json:"changes"`
+}
+
+type listAuditLogsResponse struct {
+	TenantID   string         `json:"tenant_id"`
+	Items      []auditLogItem `json:"items"`
+	NextCursor *string        `json:"next_cursor"`
+}
+
+// GetAuditLogs lists org_audit_logs newest first. from/to accept RFC3339 timestamps or dates;
+// a date in "to" includes that whole day.
+func (c *OrgAPIController) GetAuditLogs(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgAuditAuthzObject, "read") {
+		return
+	}
+
+	q := r.URL.Query()
+	filter := services.AuditLogFilter{}
+	if raw := strings.TrimSpace(q.Get("entity_type")); raw != "" {
+		filter.EntityType = &raw
+	}
+	if raw := strings.TrimSpace(q.Get("entity_id")); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "entity_id is invalid")
+			return
+		}
+		filter.EntityID = &id
+	}
+	if raw := strings.TrimSpace(q.Get("request_id")); raw != "" {
+		filter.RequestID = &raw
+	}
+	if raw := strings.TrimSpace(q.Get("initiator_id")); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "initiator_id is invalid")
+			return
+		}
+		filter.InitiatorID = &id
+	}
+	if raw := strings.TrimSpace(q.Get("change_type")); raw != "" {
+		filter.ChangeType = &raw
+	}
+	if raw := strings.TrimSpace(q.Get("from")); raw != "" {
+		from, err := parseAuditTimeBound(raw, false)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "from is invalid")
+			return
+		}
+		filter.From = &from
+	}
+	if raw := strings.TrimSpace(q.Get("to")); raw != "" {
+		to, err := parseAuditTimeBound(raw, true)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "to is invalid")
+			return
+		}
+		filter.To = &to
+	}
+	if raw := strings.TrimSpace(q.Get("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "limit is invalid")
+			return
+		}
+		filter.Limit = n
+	}
+	if raw := strings.TrimSpace(q.Get("cursor")); raw != "" {
+		at, id, err := parseAuditCursor(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "cursor is invalid")
+			return
+		}
+		filter.CursorAt, filter.CursorID = at, id
+	}
+
+	res, err := c.org.ListAuditLogs(r.Context(), tenantID, filter)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	names := resolveAuditInitiators(r.Context(), c.users, tenantID, res.Items)
+	items := make([]auditLogItem, 0, len(res.Items))
+	for _, row := range res.Items {
+		changes := services.DiffAuditValues(row.OldValues, row.NewValues)
+		diff := make([]auditValueChangeResponse, 0, len(changes))
+		for _, ch := range changes {
+			diff = append(diff, auditValueChangeResponse{Field: ch.Field, Old: ch.Old, New: ch.New})
+		}
+		var initiatorName *string
+		if name, ok := names[row.InitiatorID]; ok {
+			initiatorName = &name
+		}
+		items = append(items, auditLogItem{
+			ID:              row.ID.String(),
+			RequestID:       row.RequestID,
+			TransactionTime: row.TransactionTime.UTC().Format(time.RFC3339Nano),
+			InitiatorID:     row.InitiatorID.String(),
+			InitiatorName:   initiatorName,
+			ChangeType:      row.ChangeType,
+			EntityType:      row.EntityType,
+			EntityID:        row.EntityID.String(),
+			EffectiveWindow: effectiveWindowResponse{
+				EffectiveDate: formatValidDate(row.EffectiveDate),
+				EndDate:       formatValidEndDateFromEndDate(row.EndDate),
+			},
+			OldValues: row.OldValues,
+			NewValues: row.NewValues,
+			Meta:      row.Meta,
+			Changes:   diff,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, listAuditLogsResponse{
+		TenantID:   tenantID.String(),
+		Items:      items,
+		NextCursor: res.NextCursor,
+	})
+}
+
+func parseAuditTimeBound(raw string, upper bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+		return t.UTC(), nil
+	}
+	d, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if upper {
+		d = d.AddDate(0, 0, 1)
+	}
+	return d.UTC(), nil
+}
+
+func parseAuditCursor(raw string) (*time.Time, *uuid.UUID, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(raw), "transaction_time:")
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid cursor")
+	}
+	atStr, idStr, ok := strings.Cut(rest, ":id:")
+	if !ok || strings.TrimSpace(atStr) == "" || strings.TrimSpace(idStr) == "" {
+		return nil, nil, fmt.Errorf("invalid cursor")
+	}
+	at, err := time.Parse(time.RFC3339Nano, atStr)
+	if err != nil {
+		return nil, nil, err
+	}
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		return nil, nil, err
+	}
+	at = at.UTC()
+	return &at, &id, nil
+}
+
+// resolveAuditInitiators maps audit initiator ids back to user names. Initiator ids are derived from
+// (tenant, user id) one way, so this hashes the tenant's users and is best-effort: unknown ids stay unnamed.
+func resolveAuditInitiators(ctx context.Context, users *coreservices.UserService, tenantID uuid.UUID, rows []services.AuditLogRow) map[uuid.UUID]string {
+	out := map[uuid.UUID]string{}
+	if users == nil || len(rows) == 0 {
+		return out
+	}
+	wanted := map[uuid.UUID]struct{}{}
+	for _, row := range rows {
+		wanted[row.InitiatorID] = struct{}{}
+	}
+	all, err := users.GetAll(ctx)
+	if err != nil {
+		return out
+	}
+	for _, u := range all {
+		id := authzutil.NormalizedUserUUID(tenantID, u)
+		if _, ok := wanted[id]; ok {
+			out[id] = strings.TrimSpace(u.FirstName() + " " + u.LastName())
+		}
+	}
+	return out
+}
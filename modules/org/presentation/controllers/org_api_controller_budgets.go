@@ -0,0 +1,327 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type headcountBudgetResponse struct {
+	ID               string   `json:"id"`
+	OrgNodeID        string   `json:"org_node_id"`
+	JobProfileID     *string  `json:"job_profile_id"`
+	JobLevelCode     *string  `json:"job_level_code"`
+	PlannedHeadcount int      `json:"planned_headcount"`
+	PlannedFTE       float64  `json:"planned_fte"`
+	PlannedCost      *float64 `json:"planned_cost"`
+	EffectiveDate    string   `json:"effective_date"`
+	EndDate          string   `json:"end_date"`
+}
+
+type setHeadcountBudgetRequest struct {
+	OrgNodeID        uuid.UUID  `json:"org_node_id"`
+	JobProfileID     *uuid.UUID `json:"job_profile_id"`
+	JobLevelCode     *string    `json:"job_level_code"`
+	PlannedHeadcount int        `json:"planned_headcount"`
+	PlannedFTE       float64    `json:"planned_fte"`
+	PlannedCost      *float64   `json:"planned_cost"`
+	EffectiveDate    string     `json:"effective_date"`
+}
+
+type endHeadcountBudgetRequest struct {
+	EffectiveDate string `json:"effective_date"`
+}
+
+func headcountBudgetResponseOf(row services.HeadcountBudgetRow) headcountBudgetResponse {
+	return headcountBudgetResponse{
+		ID:               row.ID.String(),
+		OrgNodeID:        row.OrgNodeID.String(),
+		JobProfileID:     optionalUUIDString(row.JobProfileID),
+		JobLevelCode:     row.JobLevelCode,
+		PlannedHeadcount: row.PlannedHeadcount,
+		PlannedFTE:       row.PlannedFTE,
+		PlannedCost:      row.PlannedCost,
+		EffectiveDate:    formatValidDate(row.EffectiveDate),
+		EndDate:          formatValidEndDateFromEndDate(row.EndDate),
+	}
+}
+
+func (c *OrgAPIController) GetHeadcountBudgets(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgBudgetsAuthzObject, "read") {
+		return
+	}
+
+	q := r.URL.Query()
+	filter := services.HeadcountBudgetListFilter{}
+	if raw := strings.TrimSpace(q.Get("org_node_id")); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "org_node_id is invalid")
+			return
+		}
+		filter.OrgNodeID = &id
+	}
+	if raw := strings.TrimSpace(q.Get("effective_date")); raw != "" {
+		asOf, err := parseEffectiveDate(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "effective_date is invalid")
+			return
+		}
+		filter.AsOf = &asOf
+	}
+	if raw := strings.TrimSpace(q.Get("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "limit is invalid")
+			return
+		}
+		filter.Limit = n
+	}
+
+	rows, err := c.org.ListHeadcountBudgets(r.Context(), tenantID, filter)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		TenantID string                    `json:"tenant_id"`
+		Items    []headcountBudgetResponse `json:"items"`
+	}
+	out := response{TenantID: tenantID.String(), Items: make([]headcountBudgetResponse, 0, len(rows))}
+	for _, row := range rows {
+		out.Items = append(out.Items, headcountBudgetResponseOf(row))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (c *OrgAPIController) SetHeadcountBudget(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgBudgetsAuthzObject, "write") {
+		return
+	}
+
+	var req setHeadcountBudgetRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+
+	res, err := c.org.SetHeadcountBudget(r.Context(), tenantID, services.SetHeadcountBudgetInput{
+		OrgNodeID:        req.OrgNodeID,
+		JobProfileID:     req.JobProfileID,
+		JobLevelCode:     req.JobLevelCode,
+		PlannedHeadcount: req.PlannedHeadcount,
+		PlannedFTE:       req.PlannedFTE,
+		PlannedCost:      req.PlannedCost,
+		EffectiveDate:    effectiveDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		ID              string                  `json:"id"`
+		EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+	}
+	writeJSON(w, http.StatusCreated, response{
+		ID: res.ID.String(),
+		EffectiveWindow: effectiveWindowResponse{
+			EffectiveDate: formatValidDate(res.EffectiveDate),
+			EndDate:       formatValidEndDateFromEndDate(res.EndDate),
+		},
+	})
+}
+
+func (c *OrgAPIController) EndHeadcountBudget(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgBudgetsAuthzObject, "write") {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+	var req endHeadcountBudgetRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+
+	res, err := c.org.EndHeadcountBudget(r.Context(), tenantID, services.EndHeadcountBudgetInput{
+		ID:            id,
+		EffectiveDate: effectiveDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		ID              string                  `json:"id"`
+		EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+	}
+	writeJSON(w, http.StatusOK, response{
+		ID: res.ID.String(),
+		EffectiveWindow: effectiveWindowResponse{
+			EffectiveDate: formatValidDate(res.EffectiveDate),
+			EndDate:       formatValidEndDateFromEndDate(res.EndDate),
+		},
+	})
+}
+
+type headcountBudgetFiguresResponse struct {
+	Budgeted          bool     `json:"budgeted"`
+	PlannedHeadcount  int      `json:"planned_headcount"`
+	PlannedFTE        float64  `json:"planned_fte"`
+	PlannedCost       *float64 `json:"planned_cost"`
+	PositionsTotal    int      `json:"positions_total"`
+	FilledPositions   int      `json:"filled_positions"`
+	VacantPositions   int      `json:"vacant_positions"`
+	CapacityFTE       float64  `json:"capacity_fte"`
+	OccupiedFTE       float64  `json:"occupied_fte"`
+	AvailableFTE      float64  `json:"available_fte"`
+	HeadcountVariance int      `json:"headcount_variance"`
+	FTEVariance       float64  `json:"fte_variance"`
+	OverBudget        bool     `json:"over_budget"`
+}
+
+func headcountBudgetFiguresResponseOf(f services.HeadcountBudgetFigures) headcountBudgetFiguresResponse {
+	return headcountBudgetFiguresResponse{
+		Budgeted:          f.Budgeted,
+		PlannedHeadcount:  f.PlannedHeadcount,
+		PlannedFTE:        f.PlannedFTE,
+		PlannedCost:       f.PlannedCost,
+		PositionsTotal:    f.PositionsTotal,
+		FilledPositions:   f.FilledPositions,
+		VacantPositions:   f.VacantPositions,
+		CapacityFTE:       f.CapacityFTE,
+		OccupiedFTE:       f.OccupiedFTE,
+		AvailableFTE:      f.AvailableFTE,
+		HeadcountVariance: f.HeadcountVariance,
+		FTEVariance:       f.FTEVariance,
+		OverBudget:        f.OverBudget,
+	}
+}
+
+type headcountBudgetVarianceLineResponse struct {
+	Budget  headcountBudgetResponse        `json:"budget"`
+	Figures headcountBudgetFiguresResponse `json:"figures"`
+}
+
+type headcountBudgetVarianceNodeResponse struct {
+	ID       string                                `json:"id"`
+	Code     string                                `json:"code"`
+	Name     string                                `json:"name"`
+	ParentID *string                               `json:"parent_id"`
+	Depth    int                                   `json:"depth"`
+	Lines    []headcountBudgetVarianceLineResponse `json:"lines"`
+	Direct   headcountBudgetFiguresResponse        `json:"direct"`
+	Total    headcountBudgetFiguresResponse        `json:"total"`
+}
+
+func (c *OrgAPIController) GetHeadcountBudgetVariance(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgBudgetsAuthzObject, "read") {
+		return
+	}
+
+	orgNodeID, hasOrgNodeID, err := parseOptionalUUID(r.URL.Query().Get("org_node_id"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "org_node_id is invalid")
+		return
+	}
+	var orgNodeIDPtr *uuid.UUID
+	if hasOrgNodeID {
+		orgNodeIDPtr = &orgNodeID
+	}
+	asOf, err := parseEffectiveDate(r.URL.Query().Get("effective_date"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "effective_date is invalid")
+		return
+	}
+	includeSystem := false
+	if raw := strings.TrimSpace(r.URL.Query().Get("include_system")); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "include_system is invalid")
+			return
+		}
+		includeSystem = v
+	}
+
+	res, err := c.org.GetHeadcountBudgetVariance(r.Context(), tenantID, services.HeadcountBudgetVarianceInput{
+		OrgNodeID:         orgNodeIDPtr,
+		EffectiveDate:     asOf,
+		LifecycleStatuses: splitCommaList(r.URL.Query().Get("lifecycle_statuses")),
+		IncludeSystem:     includeSystem,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		TenantID      string                                `json:"tenant_id"`
+		OrgNodeID     string                                `json:"org_node_id"`
+		EffectiveDate string                                `json:"effective_date"`
+		Nodes         []headcountBudgetVarianceNodeResponse `json:"nodes"`
+	}
+	out := response{
+		TenantID:      res.TenantID.String(),
+		OrgNodeID:     res.OrgNodeID.String(),
+		EffectiveDate: formatValidDate(res.EffectiveDate),
+		Nodes:         make([]headcountBudgetVarianceNodeResponse, 0, len(res.Nodes)),
+	}
+	for _, n := range res.Nodes {
+		node := headcountBudgetVarianceNodeResponse{
+			ID:       n.ID.String(),
+			Code:     n.Code,
+			Name:     n.Name,
+			ParentID: optionalUUIDString(n.ParentID),
+			Depth:    n.Depth,
+			Lines:    make([]headcountBudgetVarianceLineResponse, 0, len(n.Lines)),
+			Direct:   headcountBudgetFiguresResponseOf(n.Direct),
+			Total:    headcountBudgetFiguresResponseOf(n.Total),
+		}
+		for _, line := range n.Lines {
+			node.Lines = append(node.Lines, headcountBudgetVarianceLineResponse{
+				Budget:  headcountBudgetResponseOf(line.Budget),
+				Figures: headcountBudgetFiguresResponseOf(line.HeadcountBudgetFigures),
+			})
+		}
+		out.Nodes = append(out.Nodes, node)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
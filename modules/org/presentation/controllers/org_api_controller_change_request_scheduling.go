@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/org/domain/changerequest"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+type changeRequestScheduleRequest struct {
+	ScheduledFor *string `json:"scheduled_for"`
+}
+
+// ScheduleChangeRequest sets the moment an approved request goes live. A null scheduled_for
+// clears the schedule, leaving the request to be applied manually.
+func (c *OrgAPIController) ScheduleChangeRequest(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !requireOrgChangeRequestsEnabled(w, requestID) {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgChangeRequestsAuthzObj, "admin") {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	var req changeRequestScheduleRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_CHANGE_REQUEST_INVALID_BODY", "invalid json body")
+		return
+	}
+	var scheduledFor *time.Time
+	if req.ScheduledFor != nil && strings.TrimSpace(*req.ScheduledFor) != "" {
+		v, err := time.Parse(time.RFC3339, strings.TrimSpace(*req.ScheduledFor))
+		if err != nil {
+			writeAPIError(w, http.StatusUnprocessableEntity, requestID, "ORG_CHANGE_REQUEST_INVALID_BODY", "scheduled_for must be RFC3339")
+			return
+		}
+		scheduledFor = &v
+	}
+
+	cr, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+		txCtx = composables.WithTenantID(txCtx, tenantID)
+		return c.changeRequests.Schedule(txCtx, id, scheduledFor)
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toChangeRequestDetailResponse(cr))
+}
@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	coreuser "github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type delegationResponse struct {
+	DelegationID       string                  `json:"delegation_id"`
+	DelegationType     string                  `json:"delegation_type"`
+	OrgNodeID          string                  `json:"org_node_id"`
+	RoleID             *string                 `json:"role_id,omitempty"`
+	RoleCode           *string                 `json:"role_code,omitempty"`
+	DelegatorSubjectID *string                 `json:"delegator_subject_id,omitempty"`
+	DelegateUserID     int64                   `json:"delegate_user_id"`
+	DelegateSubjectID  string                  `json:"delegate_subject_id"`
+	EffectiveWindow    effectiveWindowResponse `json:"effective_window"`
+	ReasonNote         *string                 `json:"reason_note,omitempty"`
+}
+
+func delegationResponseOf(row services.DelegationRow) delegationResponse {
+	out := delegationResponse{
+		DelegationID:      row.ID.String(),
+		DelegationType:    row.DelegationType,
+		OrgNodeID:         row.OrgNodeID.String(),
+		RoleCode:          row.RoleCode,
+		DelegateUserID:    row.DelegateUserID,
+		DelegateSubjectID: row.DelegateSubjectID.String(),
+		EffectiveWindow: effectiveWindowResponse{
+			EffectiveDate: formatValidDate(row.EffectiveDate),
+			EndDate:       formatValidEndDateFromEndDate(row.EndDate),
+		},
+		ReasonNote: row.ReasonNote,
+	}
+	if row.RoleID != nil {
+		v := row.RoleID.String()
+		out.RoleID = &v
+	}
+	if row.DelegatorSubjectID != nil {
+		v := row.DelegatorSubjectID.String()
+		out.DelegatorSubjectID = &v
+	}
+	return out
+}
+
+type listDelegationsResponse struct {
+	TenantID      string               `json:"tenant_id"`
+	EffectiveDate *string              `json:"effective_date"`
+	Items         []delegationResponse `json:"items"`
+}
+
+func (c *OrgAPIController) GetDelegations(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgDelegationsAuthzObject, "read") {
+		return
+	}
+
+	filter := services.DelegationListFilter{}
+	if raw := strings.TrimSpace(r.URL.Query().Get("org_node_id")); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "org_node_id is invalid")
+			return
+		}
+		filter.OrgNodeID = &id
+	}
+	if raw := strings.TrimSpace(r.URL.Query().Get("delegate_user_id")); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || id <= 0 {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "delegate_user_id is invalid")
+			return
+		}
+		filter.DelegateUserID = &id
+	}
+	asOf, err := parseEffectiveDate(r.URL.Query().Get("effective_date"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "effective_date is invalid")
+		return
+	}
+	if !asOf.IsZero() {
+		filter.AsOf = &asOf
+	}
+	if raw := strings.TrimSpace(r.URL.Query().Get("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "limit is invalid")
+			return
+		}
+		filter.Limit = n
+	}
+
+	rows, err := c.org.ListDelegations(r.Context(), tenantID, filter)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	items := make([]delegationResponse, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, delegationResponseOf(row))
+	}
+	var effectiveDate *string
+	if filter.AsOf != nil {
+		v := formatValidDate(*filter.AsOf)
+		effectiveDate = &v
+	}
+	writeJSON(w, http.StatusOK, listDelegationsResponse{
+		TenantID:      tenantID.String(),
+		EffectiveDate: effectiveDate,
+		Items:         items,
+	})
+}
+
+type createDelegationRequest struct {
+	DelegationType     string     `json:"delegation_type"`
+	OrgNodeID          uuid.UUID  `json:"org_node_id"`
+	RoleID             *uuid.UUID `json:"role_id"`
+	DelegatorSubjectID *uuid.UUID `json:"delegator_subject_id"`
+	DelegateUserID     *int64     `json:"delegate_user_id"`
+	DelegateEmail      *string    `json:"delegate_email"`
+	EffectiveDate      string     `json:"effective_date"`
+	EndDate            string     `json:"end_date"`
+	ReasonNote         *string    `json:"reason_note"`
+}
+
+type delegationWriteResponse struct {
+	DelegationID    string                  `json:"delegation_id"`
+	EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+}
+
+func delegationWriteResponseOf(res *services.DelegationWriteResult) delegationWriteResponse {
+	return delegationWriteResponse{
+		DelegationID: res.DelegationID.String(),
+		EffectiveWindow: effectiveWindowResponse{
+			EffectiveDate: formatValidDate(res.EffectiveDate),
+			EndDate:       formatValidEndDateFromEndDate(res.EndDate),
+		},
+	}
+}
+
+func (c *OrgAPIController) CreateDelegation(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgDelegationsAuthzObject, "admin") {
+		return
+	}
+
+	var req createDelegationRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+	endDate, err := parseRequiredEffectiveDate(req.EndDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "end_date is required")
+		return
+	}
+
+	var delegate coreuser.User
+	switch {
+	case req.DelegateUserID != nil && *req.DelegateUserID > 0:
+		delegate, err = c.users.GetByID(r.Context(), uint(*req.DelegateUserID))
+	case req.DelegateEmail != nil && strings.TrimSpace(*req.DelegateEmail) != "":
+		delegate, err = c.users.GetByEmail(r.Context(), strings.TrimSpace(*req.DelegateEmail))
+	default:
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "delegate_user_id or delegate_email is required")
+		return
+	}
+	if err != nil || delegate == nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, requestID, "ORG_DELEGATE_NOT_FOUND", "delegate not found")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.CreateDelegation(r.Context(), tenantID, requestID, initiatorID, services.CreateDelegationInput{
+		DelegationType:     req.DelegationType,
+		OrgNodeID:          req.OrgNodeID,
+		RoleID:             req.RoleID,
+		DelegatorSubjectID: req.DelegatorSubjectID,
+		DelegateUserID:     int64(delegate.ID()),
+		DelegateSubjectID:  authzutil.NormalizedUserUUID(tenantID, delegate),
+		EffectiveDate:      effectiveDate,
+		EndDate:            endDate,
+		ReasonNote:         req.ReasonNote,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, delegationWriteResponseOf(res))
+}
+
+type endDelegationRequest struct {
+	EndDate string `json:"end_date"`
+}
+
+func (c *OrgAPIController) EndDelegation(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgDelegationsAuthzObject, "admin") {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+	var req endDelegationRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	endDate, err := parseRequiredEffectiveDate(req.EndDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "end_date is required")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.EndDelegation(r.Context(), tenantID, requestID, initiatorID, services.EndDelegationInput{
+		DelegationID: id,
+		EndDate:      endDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, delegationWriteResponseOf(res))
+}
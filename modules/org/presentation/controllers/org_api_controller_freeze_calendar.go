@@ -0,0 +1,217 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+// FreezeOverrideReasonHeader carries the reason for writing into a frozen window. Sending it
+// requires the org.freeze_calendar override action; the reason is recorded on the audit entries.
+const FreezeOverrideReasonHeader = "X-Org-Freeze-Override-Reason"
+
+// freezeOverrideMiddleware turns FreezeOverrideReasonHeader on a write request into a freeze
+// override for the handler. A blank reason or a caller without the override action is rejected
+// before the handler runs.
+func (c *OrgAPIController) freezeOverrideMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, present := r.Header[http.CanonicalHeaderKey(FreezeOverrideReasonHeader)]; !present || r.Method == http.MethodGet {
+			next.ServeHTTP(w, r)
+			return
+		}
+		tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+		if !ok {
+			return
+		}
+		reason := strings.TrimSpace(r.Header.Get(FreezeOverrideReasonHeader))
+		if reason == "" {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "freeze override reason is required")
+			return
+		}
+		if !ensureOrgAuthz(w, r, tenantID, currentUser, orgFreezeCalendarAuthzObject, "override") {
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(services.WithFreezeOverride(r.Context(), reason)))
+	})
+}
+
+type freezePeriodResponse struct {
+	ID          string `json:"id"`
+	Label       string `json:"label"`
+	PeriodStart string `json:"period_start"`
+	PeriodEnd   string `json:"period_end"`
+	LocksAt     string `json:"locks_at"`
+	Locked      bool   `json:"locked"`
+}
+
+func freezePeriodResponseOf(p services.FreezePeriod, now time.Time) freezePeriodResponse {
+	return freezePeriodResponse{
+		ID:          p.ID.String(),
+		Label:       p.Label,
+		PeriodStart: formatValidDate(p.PeriodStart),
+		PeriodEnd:   formatValidDate(p.PeriodEnd),
+		LocksAt:     p.LocksAt.UTC().Format(time.RFC3339),
+		Locked:      !now.Before(p.LocksAt),
+	}
+}
+
+// parseFreezeLocksAt accepts an RFC3339 timestamp or a date, which locks at 00:00 UTC.
+func parseFreezeLocksAt(v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, fmt.Errorf("locks_at is required")
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t.UTC(), nil
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("locks_at must be RFC3339 or YYYY-MM-DD")
+	}
+	return t.UTC(), nil
+}
+
+type freezePeriodRequest struct {
+	Label       string `json:"label"`
+	PeriodStart string `json:"period_start"`
+	PeriodEnd   string `json:"period_end"`
+	LocksAt     string `json:"locks_at"`
+}
+
+func decodeFreezePeriodRequest(w http.ResponseWriter, r *http.Request, requestID string) (services.FreezePeriodInput, bool) {
+	var req freezePeriodRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return services.FreezePeriodInput{}, false
+	}
+	start, err := parseRequiredValidDate("period_start", req.PeriodStart)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", err.Error())
+		return services.FreezePeriodInput{}, false
+	}
+	end, err := parseRequiredValidDate("period_end", req.PeriodEnd)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", err.Error())
+		return services.FreezePeriodInput{}, false
+	}
+	locksAt, err := parseFreezeLocksAt(req.LocksAt)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", err.Error())
+		return services.FreezePeriodInput{}, false
+	}
+	return services.FreezePeriodInput{
+		Label:       req.Label,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		LocksAt:     locksAt,
+	}, true
+}
+
+func (c *OrgAPIController) GetFreezePeriods(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgFreezeCalendarAuthzObject, "read") {
+		return
+	}
+
+	periods, err := c.org.ListFreezePeriods(r.Context(), tenantID)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		TenantID string                 `json:"tenant_id"`
+		Periods  []freezePeriodResponse `json:"periods"`
+	}
+	now := time.Now().UTC()
+	out := response{
+		TenantID: tenantID.String(),
+		Periods:  make([]freezePeriodResponse, 0, len(periods)),
+	}
+	for _, p := range periods {
+		out.Periods = append(out.Periods, freezePeriodResponseOf(p, now))
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (c *OrgAPIController) CreateFreezePeriod(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgFreezeCalendarAuthzObject, "admin") {
+		return
+	}
+	in, ok := decodeFreezePeriodRequest(w, r, requestID)
+	if !ok {
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.CreateFreezePeriod(r.Context(), tenantID, requestID, initiatorID, in)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, freezePeriodResponseOf(*res, time.Now().UTC()))
+}
+
+func (c *OrgAPIController) UpdateFreezePeriod(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgFreezeCalendarAuthzObject, "admin") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+	in, ok := decodeFreezePeriodRequest(w, r, requestID)
+	if !ok {
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.UpdateFreezePeriod(r.Context(), tenantID, requestID, initiatorID, id, in)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, freezePeriodResponseOf(*res, time.Now().UTC()))
+}
+
+func (c *OrgAPIController) RescindFreezePeriod(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgFreezeCalendarAuthzObject, "admin") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.DeleteFreezePeriod(r.Context(), tenantID, requestID, initiatorID, id)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, freezePeriodResponseOf(*res, time.Now().UTC()))
+}
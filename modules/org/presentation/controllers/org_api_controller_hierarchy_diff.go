@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type hierarchyDiffNodeResponse struct {
+	ID       string  `json:"id"`
+	Code     string  `json:"code"`
+	Name     string  `json:"name"`
+	ParentID *string `json:"parent_id,omitempty"`
+}
+
+type hierarchyDiffRenameResponse struct {
+	ID       string `json:"id"`
+	Code     string `json:"code"`
+	FromName string `json:"from_name"`
+	ToName   string `json:"to_name"`
+}
+
+type hierarchyDiffMoveResponse struct {
+	ID           string  `json:"id"`
+	Code         string  `json:"code"`
+	Name         string  `json:"name"`
+	FromParentID *string `json:"from_parent_id"`
+	ToParentID   *string `json:"to_parent_id"`
+}
+
+type hierarchyDiffPositionResponse struct {
+	PositionID string  `json:"position_id"`
+	Code       string  `json:"code"`
+	Title      *string `json:"title,omitempty"`
+	OrgNodeID  string  `json:"org_node_id"`
+}
+
+type hierarchyDiffAssignmentResponse struct {
+	Kind             string   `json:"kind"`
+	SubjectID        string   `json:"subject_id"`
+	Pernr            string   `json:"pernr"`
+	AssignmentType   string   `json:"assignment_type"`
+	FromPositionID   *string  `json:"from_position_id"`
+	FromPositionCode string   `json:"from_position_code,omitempty"`
+	ToPositionID     *string  `json:"to_position_id"`
+	ToPositionCode   string   `json:"to_position_code,omitempty"`
+	FromOrgNodeID    *string  `json:"from_org_node_id"`
+	ToOrgNodeID      *string  `json:"to_org_node_id"`
+	FromFTE          *float64 `json:"from_fte"`
+	ToFTE            *float64 `json:"to_fte"`
+}
+
+type hierarchyDiffCountsResponse struct {
+	NodesCreated      int `json:"nodes_created"`
+	NodesRetired      int `json:"nodes_retired"`
+	NodesRenamed      int `json:"nodes_renamed"`
+	NodesMoved        int `json:"nodes_moved"`
+	PositionsOpened   int `json:"positions_opened"`
+	PositionsClosed   int `json:"positions_closed"`
+	AssignmentChanges int `json:"assignment_changes"`
+}
+
+type hierarchyDiffGroupResponse struct {
+	Subtree           hierarchyDiffNodeResponse         `json:"subtree"`
+	Counts            hierarchyDiffCountsResponse       `json:"counts"`
+	NodesCreated      []hierarchyDiffNodeResponse       `json:"nodes_created"`
+	NodesRetired      []hierarchyDiffNodeResponse       `json:"nodes_retired"`
+	NodesRenamed      []hierarchyDiffRenameResponse     `json:"nodes_renamed"`
+	NodesMoved        []hierarchyDiffMoveResponse       `json:"nodes_moved"`
+	PositionsOpened   []hierarchyDiffPositionResponse   `json:"positions_opened"`
+	PositionsClosed   []hierarchyDiffPositionResponse   `json:"positions_closed"`
+	AssignmentChanges []hierarchyDiffAssignmentResponse `json:"assignment_changes"`
+}
+
+type hierarchyDiffResponse struct {
+	TenantID      string                       `json:"tenant_id"`
+	HierarchyType string                       `json:"hierarchy_type"`
+	From          string                       `json:"from"`
+	To            string                       `json:"to"`
+	RootNodeID    *string                      `json:"root_node_id,omitempty"`
+	Totals        hierarchyDiffCountsResponse  `json:"totals"`
+	Groups        []hierarchyDiffGroupResponse `json:"groups"`
+}
+
+// hierarchyDiffIncludes lists the optional sections of a diff and the object each one needs,
+// mirroring the list endpoints they summarize.
+var hierarchyDiffIncludes = map[string]string{
+	"positions":   orgPositionsAuthzObject,
+	"assignments": orgAssignmentsAuthzObject,
+}
+
+func (c *OrgAPIController) DiffHierarchies(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgHierarchiesAuthzObject, "read") {
+		return
+	}
+
+	q := r.URL.Query()
+	hType := strings.TrimSpace(q.Get("type"))
+	if hType != "" && !services.IsValidHierarchyType(hType) {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "type is invalid")
+		return
+	}
+	from, err := parseRequiredValidDate("from", q.Get("from"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", err.Error())
+		return
+	}
+	to, err := parseRequiredValidDate("to", q.Get("to"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", err.Error())
+		return
+	}
+	rootNodeID, hasRoot, err := parseOptionalUUID(q.Get("root_node_id"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "root_node_id is invalid")
+		return
+	}
+
+	includes := map[string]bool{"positions": true, "assignments": true}
+	if raw, set := q["include"]; set {
+		includes = map[string]bool{}
+		for _, part := range splitCommaList(strings.Join(raw, ",")) {
+			if _, known := hierarchyDiffIncludes[part]; !known {
+				writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "include is invalid")
+				return
+			}
+			includes[part] = true
+		}
+	}
+	for _, name := range []string{"positions", "assignments"} {
+		if includes[name] && !ensureOrgAuthz(w, r, tenantID, currentUser, hierarchyDiffIncludes[name], "read") {
+			return
+		}
+	}
+
+	params := services.HierarchyDiffParams{
+		HierarchyType:      hType,
+		From:               from,
+		To:                 to,
+		IncludePositions:   includes["positions"],
+		IncludeAssignments: includes["assignments"],
+	}
+	if hasRoot {
+		params.RootNodeID = &rootNodeID
+	}
+	res, err := c.org.DiffHierarchy(r.Context(), tenantID, params)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hierarchyDiffResponseOf(res))
+}
+
+func hierarchyDiffResponseOf(res *services.HierarchyDiffResult) hierarchyDiffResponse {
+	out := hierarchyDiffResponse{
+		TenantID:      res.TenantID.String(),
+		HierarchyType: res.HierarchyType,
+		From:          formatValidDate(res.From),
+		To:            formatValidDate(res.To),
+		RootNodeID:    optionalUUIDString(res.RootNodeID),
+		Totals:        hierarchyDiffCountsOf(res.Totals),
+		Groups:        make([]hierarchyDiffGroupResponse, 0, len(res.Groups)),
+	}
+	for _, g := range res.Groups {
+		group := hierarchyDiffGroupResponse{
+			Subtree:           hierarchyDiffNodeOf(g.Subtree),
+			Counts:            hierarchyDiffCountsOf(g.Counts),
+			NodesCreated:      make([]hierarchyDiffNodeResponse, 0, len(g.NodesCreated)),
+			NodesRetired:      make([]hierarchyDiffNodeResponse, 0, len(g.NodesRetired)),
+			NodesRenamed:      make([]hierarchyDiffRenameResponse, 0, len(g.NodesRenamed)),
+			NodesMoved:        make([]hierarchyDiffMoveResponse, 0, len(g.NodesMoved)),
+			PositionsOpened:   make([]hierarchyDiffPositionResponse, 0, len(g.PositionsOpened)),
+			PositionsClosed:   make([]hierarchyDiffPositionResponse, 0, len(g.PositionsClosed)),
+			AssignmentChanges: make([]hierarchyDiffAssignmentResponse, 0, len(g.AssignmentChanges)),
+		}
+		for _, n := range g.NodesCreated {
+			group.NodesCreated = append(group.NodesCreated, hierarchyDiffNodeOf(n))
+		}
+		for _, n := range g.NodesRetired {
+			group.NodesRetired = append(group.NodesRetired, hierarchyDiffNodeOf(n))
+		}
+		for _, n := range g.NodesRenamed {
+			group.NodesRenamed = append(group.NodesRenamed, hierarchyDiffRenameResponse{
+				ID:       n.ID.String(),
+				Code:     n.Code,
+				FromName: n.FromName,
+				ToName:   n.ToName,
+			})
+		}
+		for _, n := range g.NodesMoved {
+			group.NodesMoved = append(group.NodesMoved, hierarchyDiffMoveResponse{
+				ID:           n.ID.String(),
+				Code:         n.Code,
+				Name:         n.Name,
+				FromParentID: optionalUUIDString(n.FromParentID),
+				ToParentID:   optionalUUIDString(n.ToParentID),
+			})
+		}
+		for _, p := range g.PositionsOpened {
+			group.PositionsOpened = append(group.PositionsOpened, hierarchyDiffPositionOf(p))
+		}
+		for _, p := range g.PositionsClosed {
+			group.PositionsClosed = append(group.PositionsClosed, hierarchyDiffPositionOf(p))
+		}
+		for _, a := range g.AssignmentChanges {
+			group.AssignmentChanges = append(group.AssignmentChanges, hierarchyDiffAssignmentResponse{
+				Kind:             string(a.Kind),
+				SubjectID:        a.SubjectID.String(),
+				Pernr:            a.Pernr,
+				AssignmentType:   a.AssignmentType,
+				FromPositionID:   optionalUUIDString(a.FromPositionID),
+				FromPositionCode: a.FromPositionCode,
+				ToPositionID:     optionalUUIDString(a.ToPositionID),
+				ToPositionCode:   a.ToPositionCode,
+				FromOrgNodeID:    optionalUUIDString(a.FromOrgNodeID),
+				ToOrgNodeID:      optionalUUIDString(a.ToOrgNodeID),
+				FromFTE:          a.FromFTE,
+				ToFTE:            a.ToFTE,
+			})
+		}
+		out.Groups = append(out.Groups, group)
+	}
+	return out
+}
+
+func hierarchyDiffNodeOf(n services.HierarchyDiffNode) hierarchyDiffNodeResponse {
+	out := hierarchyDiffNodeResponse{Code: n.Code, Name: n.Name, ParentID: optionalUUIDString(n.ParentID)}
+	if n.ID != uuid.Nil {
+		out.ID = n.ID.String()
+	}
+	return out
+}
+
+func hierarchyDiffPositionOf(p services.HierarchyDiffPosition) hierarchyDiffPositionResponse {
+	return hierarchyDiffPositionResponse{
+		PositionID: p.PositionID.String(),
+		Code:       p.Code,
+		Title:      p.Title,
+		OrgNodeID:  p.OrgNodeID.String(),
+	}
+}
+
+func hierarchyDiffCountsOf(c services.HierarchyDiffCounts) hierarchyDiffCountsResponse {
+	return hierarchyDiffCountsResponse{
+		NodesCreated:      c.NodesCreated,
+		NodesRetired:      c.NodesRetired,
+		NodesRenamed:      c.NodesRenamed,
+		NodesMoved:        c.NodesMoved,
+		PositionsOpened:   c.PositionsOpened,
+		PositionsClosed:   c.PositionsClosed,
+		AssignmentChanges: c.AssignmentChanges,
+	}
+}
@@ -0,0 +1,293 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type hierarchyMembershipResponse struct {
+	ID            string `json:"id"`
+	SubjectType   string `json:"subject_type"`
+	SubjectID     string `json:"subject_id"`
+	HierarchyType string `json:"hierarchy_type"`
+	OrgNodeID     string `json:"org_node_id"`
+	EffectiveDate string `json:"effective_date"`
+	EndDate       string `json:"end_date"`
+}
+
+type setHierarchyMembershipRequest struct {
+	SubjectType   string    `json:"subject_type"`
+	SubjectID     uuid.UUID `json:"subject_id"`
+	HierarchyType string    `json:"hierarchy_type"`
+	OrgNodeID     uuid.UUID `json:"org_node_id"`
+	EffectiveDate string    `json:"effective_date"`
+}
+
+type endHierarchyMembershipRequest struct {
+	EffectiveDate string `json:"effective_date"`
+}
+
+// hierarchyMembershipWriteAuthz maps a membership subject to the object/action that already
+// governs writes to that subject.
+func hierarchyMembershipWriteAuthz(subjectType string) (string, string) {
+	if subjectType == services.HierarchyMembershipSubjectAssignment {
+		return orgAssignmentsAuthzObject, "assign"
+	}
+	return orgPositionsAuthzObject, "write"
+}
+
+func (c *OrgAPIController) GetHierarchyMemberships(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgHierarchiesAuthzObject, "read") {
+		return
+	}
+
+	q := r.URL.Query()
+	filter := services.HierarchyMembershipListFilter{}
+	if v := strings.TrimSpace(q.Get("subject_type")); v != "" {
+		filter.SubjectType = &v
+	}
+	if raw := strings.TrimSpace(q.Get("subject_id")); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "subject_id is invalid")
+			return
+		}
+		filter.SubjectID = &id
+	}
+	if v := strings.TrimSpace(q.Get("hierarchy_type")); v != "" {
+		filter.HierarchyType = &v
+	}
+	if raw := strings.TrimSpace(q.Get("org_node_id")); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "org_node_id is invalid")
+			return
+		}
+		filter.OrgNodeID = &id
+	}
+	if raw := strings.TrimSpace(q.Get("effective_date")); raw != "" {
+		asOf, err := parseEffectiveDate(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "effective_date is invalid")
+			return
+		}
+		filter.AsOf = &asOf
+	}
+	if raw := strings.TrimSpace(q.Get("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "limit is invalid")
+			return
+		}
+		filter.Limit = n
+	}
+
+	rows, err := c.org.ListHierarchyMemberships(r.Context(), tenantID, filter)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		TenantID string                        `json:"tenant_id"`
+		Items    []hierarchyMembershipResponse `json:"items"`
+	}
+	out := response{TenantID: tenantID.String(), Items: make([]hierarchyMembershipResponse, 0, len(rows))}
+	for _, row := range rows {
+		out.Items = append(out.Items, hierarchyMembershipResponse{
+			ID:            row.ID.String(),
+			SubjectType:   row.SubjectType,
+			SubjectID:     row.SubjectID.String(),
+			HierarchyType: row.HierarchyType,
+			OrgNodeID:     row.OrgNodeID.String(),
+			EffectiveDate: formatValidDate(row.EffectiveDate),
+			EndDate:       formatValidEndDateFromEndDate(row.EndDate),
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+func (c *OrgAPIController) SetHierarchyMembership(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+
+	var req setHierarchyMembershipRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	object, action := hierarchyMembershipWriteAuthz(strings.TrimSpace(req.SubjectType))
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, object, action) {
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+
+	res, err := c.org.SetHierarchyMembership(r.Context(), tenantID, services.SetHierarchyMembershipInput{
+		SubjectType:   req.SubjectType,
+		SubjectID:     req.SubjectID,
+		HierarchyType: req.HierarchyType,
+		OrgNodeID:     req.OrgNodeID,
+		EffectiveDate: effectiveDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		ID              string                  `json:"id"`
+		EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+	}
+	writeJSON(w, http.StatusCreated, response{
+		ID: res.ID.String(),
+		EffectiveWindow: effectiveWindowResponse{
+			EffectiveDate: formatValidDate(res.EffectiveDate),
+			EndDate:       formatValidEndDateFromEndDate(res.EndDate),
+		},
+	})
+}
+
+func (c *OrgAPIController) EndHierarchyMembership(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+	var req endHierarchyMembershipRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+
+	existing, err := c.org.ListHierarchyMemberships(r.Context(), tenantID, services.HierarchyMembershipListFilter{ID: &id, Limit: 1})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	if len(existing) == 0 {
+		writeAPIError(w, http.StatusNotFound, requestID, "ORG_NOT_FOUND", "hierarchy membership not found")
+		return
+	}
+	object, action := hierarchyMembershipWriteAuthz(existing[0].SubjectType)
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, object, action) {
+		return
+	}
+
+	res, err := c.org.EndHierarchyMembership(r.Context(), tenantID, services.EndHierarchyMembershipInput{
+		ID:            id,
+		EffectiveDate: effectiveDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		ID              string                  `json:"id"`
+		EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+	}
+	writeJSON(w, http.StatusOK, response{
+		ID: res.ID.String(),
+		EffectiveWindow: effectiveWindowResponse{
+			EffectiveDate: formatValidDate(res.EffectiveDate),
+			EndDate:       formatValidEndDateFromEndDate(res.EndDate),
+		},
+	})
+}
+
+type hierarchyStaffingNodeResponse struct {
+	ID              string  `json:"id"`
+	Code            string  `json:"code"`
+	Name            string  `json:"name"`
+	ParentID        *string `json:"parent_id"`
+	Depth           int     `json:"depth"`
+	DirectPositions int     `json:"direct_positions"`
+	DirectHeadcount int     `json:"direct_headcount"`
+	DirectFTE       float64 `json:"direct_fte"`
+	TotalPositions  int     `json:"total_positions"`
+	TotalHeadcount  int     `json:"total_headcount"`
+	TotalFTE        float64 `json:"total_fte"`
+}
+
+type hierarchyStaffingResponse struct {
+	TenantID      string                          `json:"tenant_id"`
+	HierarchyType string                          `json:"hierarchy_type"`
+	EffectiveDate string                          `json:"effective_date"`
+	Nodes         []hierarchyStaffingNodeResponse `json:"nodes"`
+}
+
+func (c *OrgAPIController) GetHierarchyStaffing(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgPositionReportsAuthzObject, "read") {
+		return
+	}
+
+	hType := strings.TrimSpace(r.URL.Query().Get("type"))
+	if hType == "" {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "type is required")
+		return
+	}
+	asOf, err := parseEffectiveDate(r.URL.Query().Get("effective_date"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "effective_date is invalid")
+		return
+	}
+
+	res, err := c.org.GetHierarchyStaffing(r.Context(), tenantID, hType, asOf)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	out := hierarchyStaffingResponse{
+		TenantID:      res.TenantID.String(),
+		HierarchyType: res.HierarchyType,
+		EffectiveDate: formatValidDate(res.EffectiveDate),
+		Nodes:         make([]hierarchyStaffingNodeResponse, 0, len(res.Nodes)),
+	}
+	for _, n := range res.Nodes {
+		out.Nodes = append(out.Nodes, hierarchyStaffingNodeResponse{
+			ID:              n.ID.String(),
+			Code:            n.Code,
+			Name:            n.Name,
+			ParentID:        optionalUUIDString(n.ParentID),
+			Depth:           n.Depth,
+			DirectPositions: n.DirectPositions,
+			DirectHeadcount: n.DirectHeadcount,
+			DirectFTE:       n.DirectFTE,
+			TotalPositions:  n.TotalPositions,
+			TotalHeadcount:  n.TotalHeadcount,
+			TotalFTE:        n.TotalFTE,
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
@@ -0,0 +1,381 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type inheritanceRuleResponse struct {
+	ID                       string                  `json:"id"`
+	HierarchyType            string                  `json:"hierarchy_type"`
+	AttributeName            string                  `json:"attribute_name"`
+	CanOverride              bool                    `json:"can_override"`
+	InheritanceBreakNodeType *string                 `json:"inheritance_break_node_type,omitempty"`
+	EffectiveWindow          effectiveWindowResponse `json:"effective_window"`
+}
+
+type inheritanceBreakResponse struct {
+	ID              string                  `json:"id"`
+	HierarchyType   string                  `json:"hierarchy_type"`
+	AttributeName   string                  `json:"attribute_name"`
+	OrgNodeID       string                  `json:"org_node_id"`
+	EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+}
+
+type inheritanceWriteResponse struct {
+	ID              string                  `json:"id"`
+	EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+}
+
+func inheritanceWriteResponseOf(res *services.InheritanceWriteResult) inheritanceWriteResponse {
+	return inheritanceWriteResponse{
+		ID: res.ID.String(),
+		EffectiveWindow: effectiveWindowResponse{
+			EffectiveDate: formatValidDate(res.EffectiveDate),
+			EndDate:       formatValidEndDateFromEndDate(res.EndDate),
+		},
+	}
+}
+
+func (c *OrgAPIController) GetInheritanceRules(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgInheritanceRulesAuthzObject, "read") {
+		return
+	}
+
+	q := r.URL.Query()
+	filter := services.InheritanceRuleListFilter{HierarchyType: strings.TrimSpace(q.Get("hierarchy_type"))}
+	if raw := strings.TrimSpace(q.Get("attribute_name")); raw != "" {
+		filter.AttributeName = &raw
+	}
+	asOf, err := parseEffectiveDate(q.Get("effective_date"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "effective_date is invalid")
+		return
+	}
+	if !asOf.IsZero() {
+		filter.AsOf = &asOf
+	}
+
+	rules, err := c.org.ListInheritanceRules(r.Context(), tenantID, filter)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	breaks, err := c.org.ListInheritanceBreaks(r.Context(), tenantID, filter)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		TenantID      string                     `json:"tenant_id"`
+		EffectiveDate *string                    `json:"effective_date"`
+		Rules         []inheritanceRuleResponse  `json:"rules"`
+		Breaks        []inheritanceBreakResponse `json:"breaks"`
+	}
+	out := response{
+		TenantID: tenantID.String(),
+		Rules:    make([]inheritanceRuleResponse, 0, len(rules)),
+		Breaks:   make([]inheritanceBreakResponse, 0, len(breaks)),
+	}
+	if filter.AsOf != nil {
+		v := formatValidDate(*filter.AsOf)
+		out.EffectiveDate = &v
+	}
+	for _, row := range rules {
+		out.Rules = append(out.Rules, inheritanceRuleResponse{
+			ID:                       row.ID.String(),
+			HierarchyType:            row.HierarchyType,
+			AttributeName:            row.AttributeName,
+			CanOverride:              row.CanOverride,
+			InheritanceBreakNodeType: row.InheritanceBreakNodeType,
+			EffectiveWindow: effectiveWindowResponse{
+				EffectiveDate: formatValidDate(row.EffectiveDate),
+				EndDate:       formatValidEndDateFromEndDate(row.EndDate),
+			},
+		})
+	}
+	for _, row := range breaks {
+		out.Breaks = append(out.Breaks, inheritanceBreakResponse{
+			ID:            row.ID.String(),
+			HierarchyType: row.HierarchyType,
+			AttributeName: row.AttributeName,
+			OrgNodeID:     row.OrgNodeID.String(),
+			EffectiveWindow: effectiveWindowResponse{
+				EffectiveDate: formatValidDate(row.EffectiveDate),
+				EndDate:       formatValidEndDateFromEndDate(row.EndDate),
+			},
+		})
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+type setInheritanceRuleRequest struct {
+	HierarchyType string `json:"hierarchy_type"`
+	AttributeName string `json:"attribute_name"`
+	CanOverride   bool   `json:"can_override"`
+	EffectiveDate string `json:"effective_date"`
+}
+
+func (c *OrgAPIController) SetInheritanceRule(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgInheritanceRulesAuthzObject, "admin") {
+		return
+	}
+
+	var req setInheritanceRuleRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.SetInheritanceRule(r.Context(), tenantID, requestID, initiatorID, services.SetInheritanceRuleInput{
+		HierarchyType: req.HierarchyType,
+		AttributeName: req.AttributeName,
+		CanOverride:   req.CanOverride,
+		EffectiveDate: effectiveDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, inheritanceWriteResponseOf(res))
+}
+
+type endInheritanceRequest struct {
+	EffectiveDate string `json:"effective_date"`
+}
+
+// decodeEndInheritanceRequest parses the {id} and effective_date shared by the rule and break
+// end endpoints.
+func decodeEndInheritanceRequest(w http.ResponseWriter, r *http.Request, requestID string) (services.EndInheritanceInput, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return services.EndInheritanceInput{}, false
+	}
+	var req endInheritanceRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return services.EndInheritanceInput{}, false
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return services.EndInheritanceInput{}, false
+	}
+	return services.EndInheritanceInput{ID: id, EffectiveDate: effectiveDate}, true
+}
+
+func (c *OrgAPIController) EndInheritanceRule(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgInheritanceRulesAuthzObject, "admin") {
+		return
+	}
+	in, ok := decodeEndInheritanceRequest(w, r, requestID)
+	if !ok {
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.EndInheritanceRule(r.Context(), tenantID, requestID, initiatorID, in)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, inheritanceWriteResponseOf(res))
+}
+
+func (c *OrgAPIController) RescindInheritanceRule(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgInheritanceRulesAuthzObject, "admin") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.DeleteInheritanceRule(r.Context(), tenantID, requestID, initiatorID, id)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, inheritanceWriteResponseOf(res))
+}
+
+type createInheritanceBreakRequest struct {
+	HierarchyType string    `json:"hierarchy_type"`
+	AttributeName string    `json:"attribute_name"`
+	OrgNodeID     uuid.UUID `json:"org_node_id"`
+	EffectiveDate string    `json:"effective_date"`
+}
+
+func (c *OrgAPIController) CreateInheritanceBreak(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgInheritanceRulesAuthzObject, "admin") {
+		return
+	}
+
+	var req createInheritanceBreakRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.CreateInheritanceBreak(r.Context(), tenantID, requestID, initiatorID, services.CreateInheritanceBreakInput{
+		HierarchyType: req.HierarchyType,
+		AttributeName: req.AttributeName,
+		OrgNodeID:     req.OrgNodeID,
+		EffectiveDate: effectiveDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, inheritanceWriteResponseOf(res))
+}
+
+func (c *OrgAPIController) EndInheritanceBreak(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgInheritanceRulesAuthzObject, "admin") {
+		return
+	}
+	in, ok := decodeEndInheritanceRequest(w, r, requestID)
+	if !ok {
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.EndInheritanceBreak(r.Context(), tenantID, requestID, initiatorID, in)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, inheritanceWriteResponseOf(res))
+}
+
+func (c *OrgAPIController) RescindInheritanceBreak(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgInheritanceRulesAuthzObject, "admin") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.DeleteInheritanceBreak(r.Context(), tenantID, requestID, initiatorID, id)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, inheritanceWriteResponseOf(res))
+}
+
+type previewInheritanceRuleRequest struct {
+	AttributeName string      `json:"attribute_name"`
+	CanOverride   bool        `json:"can_override"`
+	BreakNodeIDs  []uuid.UUID `json:"break_node_ids"`
+}
+
+type previewInheritanceRequest struct {
+	HierarchyType string `json:"hierarchy_type"`
+	EffectiveDate string `json:"effective_date"`
+	// Rules, when present, is the complete proposed rule set; omit it to preview stored rules.
+	Rules *[]previewInheritanceRuleRequest `json:"rules"`
+}
+
+func (c *OrgAPIController) PreviewInheritance(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgInheritanceRulesAuthzObject, "read") {
+		return
+	}
+
+	var req previewInheritanceRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	asOf, err := parseEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is invalid")
+		return
+	}
+
+	in := services.InheritancePreviewInput{HierarchyType: req.HierarchyType, EffectiveDate: asOf}
+	if req.Rules != nil {
+		in.Rules = make([]services.AttributeInheritanceRule, 0, len(*req.Rules))
+		for _, rule := range *req.Rules {
+			in.Rules = append(in.Rules, services.AttributeInheritanceRule{
+				AttributeName: rule.AttributeName,
+				CanOverride:   rule.CanOverride,
+				BreakNodeIDs:  rule.BreakNodeIDs,
+			})
+		}
+	}
+
+	preview, err := c.org.PreviewInheritance(r.Context(), tenantID, in)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	type response struct {
+		TenantID      string `json:"tenant_id"`
+		EffectiveDate string `json:"effective_date"`
+		*services.InheritancePreview
+	}
+	writeJSON(w, http.StatusOK, response{
+		TenantID:           tenantID.String(),
+		EffectiveDate:      formatValidDate(preview.EffectiveDate),
+		InheritancePreview: preview,
+	})
+}
@@ -0,0 +1,169 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	coreuser "github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/orgchart"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// maxOrgChartPhotoBytes skips avatars too large to embed in a chart.
+const maxOrgChartPhotoBytes = 2 << 20
+
+// GetOrgChart renders a subtree as of a date into a printable SVG or PDF org chart.
+func (c *OrgAPIController) GetOrgChart(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgHierarchiesAuthzObject, "read") {
+		return
+	}
+
+	q := r.URL.Query()
+	hType := strings.TrimSpace(q.Get("type"))
+	if hType != "" && !services.IsValidHierarchyType(hType) {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "type is invalid")
+		return
+	}
+	asOf, err := parseEffectiveDate(q.Get("effective_date"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "effective_date is invalid")
+		return
+	}
+	rootNodeID, hasRoot, err := parseOptionalUUID(q.Get("root_node_id"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "root_node_id is invalid")
+		return
+	}
+	params := services.OrgChartParams{HierarchyType: hType, AsOf: asOf}
+	if hasRoot {
+		params.RootNodeID = &rootNodeID
+	}
+	if raw := strings.TrimSpace(q.Get("max_depth")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "max_depth is invalid")
+			return
+		}
+		params.MaxDepth = &n
+	}
+	layout, ok := services.ParseOrgChartLayout(q.Get("layout"))
+	if !ok {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "layout is invalid")
+		return
+	}
+	params.Layout = layout
+	if raw := strings.TrimSpace(q.Get("photos")); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "photos is invalid")
+			return
+		}
+		params.IncludePhotos = v
+	}
+	format := strings.ToLower(strings.TrimSpace(q.Get("format")))
+	if format == "" {
+		format = "svg"
+	}
+	if format != "svg" && format != "pdf" {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "format is invalid")
+		return
+	}
+
+	chart, err := c.org.BuildOrgChart(r.Context(), tenantID, params)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	c.resolveOrgChartManagers(r.Context(), chart)
+
+	opts := orgchart.Options{Photos: loadOrgChartPhoto}
+	var buf bytes.Buffer
+	contentType := "image/svg+xml"
+	if format == "pdf" {
+		contentType = "application/pdf"
+		err = orgchart.RenderPDF(&buf, chart, opts)
+	} else {
+		err = orgchart.RenderSVG(&buf, chart, opts)
+	}
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, requestID, "ORG_INTERNAL", err.Error())
+		return
+	}
+
+	filename := fmt.Sprintf("org-chart-%s-%s.%s", strings.ToLower(chart.HierarchyType), formatValidDate(chart.EffectiveDate), format)
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": filename}))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// resolveOrgChartManagers fills manager names and, when photos are requested, avatar paths.
+// Managers that no longer resolve to a user are shown as having no manager.
+func (c *OrgAPIController) resolveOrgChartManagers(ctx context.Context, chart *services.OrgChart) {
+	if c.users == nil || chart.Root == nil {
+		return
+	}
+	users := map[int64]coreuser.User{}
+	var walk func(b *services.OrgChartBox)
+	walk = func(b *services.OrgChartBox) {
+		if b.ManagerUserID != nil && *b.ManagerUserID > 0 {
+			id := *b.ManagerUserID
+			u, seen := users[id]
+			if !seen {
+				found, err := c.users.GetByID(ctx, uint(id))
+				if err == nil {
+					u = found
+				}
+				users[id] = u
+			}
+			if u != nil {
+				b.ManagerName = strings.TrimSpace(u.FirstName() + " " + u.LastName())
+				if chart.IncludePhotos && u.Avatar() != nil {
+					b.ManagerPhotoPath = u.Avatar().Path()
+				}
+			}
+		}
+		for _, child := range b.Children {
+			walk(child)
+		}
+	}
+	walk(chart.Root)
+}
+
+// loadOrgChartPhoto reads a manager avatar from the uploads directory. Paths come from
+// uploads.path and must stay inside it.
+func loadOrgChartPhoto(path string) (orgchart.Photo, error) {
+	uploads := filepath.Clean(configuration.Use().UploadsPath)
+	clean := filepath.Clean(path)
+	if filepath.IsAbs(clean) || (clean != uploads && !strings.HasPrefix(clean, uploads+string(filepath.Separator))) {
+		return orgchart.Photo{}, fmt.Errorf("photo path %q is outside uploads", path)
+	}
+	info, err := os.Stat(clean)
+	if err != nil {
+		return orgchart.Photo{}, err
+	}
+	if info.Size() > maxOrgChartPhotoBytes {
+		return orgchart.Photo{}, fmt.Errorf("photo %q is too large", path)
+	}
+	data, err := os.ReadFile(clean)
+	if err != nil {
+		return orgchart.Photo{}, err
+	}
+	mimeType := mime.TypeByExtension(filepath.Ext(clean))
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	return orgchart.Photo{Data: data, MimeType: mimeType}, nil
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	"context"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+const personnelEventImportMaxUploadBytes = 20 << 20
+
+type personnelEventImportRowResponse struct {
+	Row           int        `json:"row"`
+	EventType     string     `json:"event_type"`
+	Pernr         string     `json:"pernr"`
+	EffectiveDate string     `json:"effective_date,omitempty"`
+	RequestID     string     `json:"request_id"`
+	Status        string     `json:"status"`
+	Column        string     `json:"column,omitempty"`
+	Code          string     `json:"code,omitempty"`
+	Message       string     `json:"message,omitempty"`
+	EventID       *uuid.UUID `json:"event_id,omitempty"`
+}
+
+type personnelEventImportResponse struct {
+	RequestIDPrefix string                            `json:"request_id_prefix"`
+	DryRun          bool                              `json:"dry_run"`
+	Total           int                               `json:"total"`
+	Valid           int                               `json:"valid"`
+	Invalid         int                               `json:"invalid"`
+	Applied         int                               `json:"applied"`
+	Replayed        int                               `json:"replayed"`
+	Rows            []personnelEventImportRowResponse `json:"rows"`
+}
+
+// PreviewPersonnelEventImport validates an uploaded CSV/XLSX file without writing anything.
+func (c *OrgAPIController) PreviewPersonnelEventImport(w http.ResponseWriter, r *http.Request) {
+	c.personnelEventImport(w, r, true)
+}
+
+// ApplyPersonnelEventImport applies the valid rows of an uploaded CSV/XLSX file in batches.
+func (c *OrgAPIController) ApplyPersonnelEventImport(w http.ResponseWriter, r *http.Request) {
+	c.personnelEventImport(w, r, false)
+}
+
+func (c *OrgAPIController) personnelEventImport(w http.ResponseWriter, r *http.Request, dryRun bool) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgAssignmentsAuthzObject, "assign") {
+		return
+	}
+
+	file, filename, err := openPersonnelEventImportUpload(w, r)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "multipart form with a file is required")
+		return
+	}
+	defer func() { _ = file.Close() }()
+	rows, err := services.ReadPersonnelEventImportFile(r.Context(), filename, file)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	batchSize := 0
+	if v := strings.TrimSpace(r.FormValue("batch_size")); v != "" {
+		batchSize, err = strconv.Atoi(v)
+		if err != nil || batchSize <= 0 {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "batch_size must be a positive integer")
+			return
+		}
+	}
+
+	report, err := runPersonnelEventImport(r.Context(), c.org, tenantID, authzutil.NormalizedUserUUID(tenantID, currentUser), r.FormValue("request_id_prefix"), rows, batchSize, dryRun)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, personnelEventImportResponseOf(report))
+}
+
+func runPersonnelEventImport(ctx context.Context, org *services.OrgService, tenantID, initiatorID uuid.UUID, prefix string, rows []services.PersonnelEventImportRow, batchSize int, dryRun bool) (*services.PersonnelEventImportReport, error) {
+	if dryRun {
+		return org.PreviewPersonnelEventImport(ctx, tenantID, prefix, initiatorID, rows)
+	}
+	return org.ApplyPersonnelEventImport(ctx, tenantID, prefix, initiatorID, rows, batchSize)
+}
+
+// openPersonnelEventImportUpload returns the multipart "file" field, capped at personnelEventImportMaxUploadBytes.
+func openPersonnelEventImportUpload(w http.ResponseWriter, r *http.Request) (multipart.File, string, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, personnelEventImportMaxUploadBytes)
+	if err := r.ParseMultipartForm(personnelEventImportMaxUploadBytes); err != nil {
+		return nil, "", err
+	}
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		return nil, "", err
+	}
+	return file, header.Filename, nil
+}
+
+func personnelEventImportResponseOf(report *services.PersonnelEventImportReport) personnelEventImportResponse {
+	out := personnelEventImportResponse{
+		RequestIDPrefix: report.RequestIDPrefix,
+		DryRun:          report.DryRun,
+		Total:           report.Total,
+		Valid:           report.Valid,
+		Invalid:         report.Invalid,
+		Applied:         report.Applied,
+		Replayed:        report.Replayed,
+		Rows:            make([]personnelEventImportRowResponse, 0, len(report.Rows)),
+	}
+	for _, row := range report.Rows {
+		out.Rows = append(out.Rows, personnelEventImportRowResponse{
+			Row:           row.Row,
+			EventType:     row.EventType,
+			Pernr:         row.Pernr,
+			EffectiveDate: row.EffectiveDate,
+			RequestID:     row.RequestID,
+			Status:        row.Status,
+			Column:        row.Column,
+			Code:          row.Code,
+			Message:       row.Message,
+			EventID:       row.EventID,
+		})
+	}
+	return out
+}
@@ -0,0 +1,171 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+// promotionPersonnelEventRequest is the body of promotion. position_id must be another position
+// on the same org node; allocated_fte defaults to the current FTE.
+type promotionPersonnelEventRequest struct {
+	Pernr         string    `json:"pernr"`
+	PositionID    uuid.UUID `json:"position_id"`
+	EffectiveDate string    `json:"effective_date"`
+	AllocatedFTE  *float64  `json:"allocated_fte"`
+	ReasonCode    string    `json:"reason_code"`
+}
+
+// statusPersonnelEventRequest is the body of leave and return.
+type statusPersonnelEventRequest struct {
+	Pernr         string `json:"pernr"`
+	EffectiveDate string `json:"effective_date"`
+	ReasonCode    string `json:"reason_code"`
+}
+
+type fteChangePersonnelEventRequest struct {
+	Pernr         string   `json:"pernr"`
+	EffectiveDate string   `json:"effective_date"`
+	AllocatedFTE  *float64 `json:"allocated_fte"`
+	ReasonCode    string   `json:"reason_code"`
+}
+
+func (c *OrgAPIController) PromotionPersonnelEvent(w http.ResponseWriter, r *http.Request) {
+	var req promotionPersonnelEventRequest
+	call, ok := beginPersonnelEvent(w, r, &req, func() string { return req.EffectiveDate })
+	if !ok {
+		return
+	}
+	res, err := c.org.PromotionPersonnelEvent(r.Context(), call.tenantID, call.requestID, call.initiatorID, services.PromotionPersonnelEventInput{
+		Pernr:         req.Pernr,
+		PositionID:    req.PositionID,
+		EffectiveDate: call.effectiveDate,
+		AllocatedFTE:  floatOrZero(req.AllocatedFTE),
+		ReasonCode:    req.ReasonCode,
+	})
+	writePersonnelEventResult(w, call.requestID, res, err)
+}
+
+func (c *OrgAPIController) LeavePersonnelEvent(w http.ResponseWriter, r *http.Request) {
+	var req statusPersonnelEventRequest
+	call, ok := beginPersonnelEvent(w, r, &req, func() string { return req.EffectiveDate })
+	if !ok {
+		return
+	}
+	res, err := c.org.LeavePersonnelEvent(r.Context(), call.tenantID, call.requestID, call.initiatorID, services.LeavePersonnelEventInput{
+		Pernr:         req.Pernr,
+		EffectiveDate: call.effectiveDate,
+		ReasonCode:    req.ReasonCode,
+	})
+	writePersonnelEventResult(w, call.requestID, res, err)
+}
+
+func (c *OrgAPIController) ReturnPersonnelEvent(w http.ResponseWriter, r *http.Request) {
+	var req statusPersonnelEventRequest
+	call, ok := beginPersonnelEvent(w, r, &req, func() string { return req.EffectiveDate })
+	if !ok {
+		return
+	}
+	res, err := c.org.ReturnPersonnelEvent(r.Context(), call.tenantID, call.requestID, call.initiatorID, services.ReturnPersonnelEventInput{
+		Pernr:         req.Pernr,
+		EffectiveDate: call.effectiveDate,
+		ReasonCode:    req.ReasonCode,
+	})
+	writePersonnelEventResult(w, call.requestID, res, err)
+}
+
+func (c *OrgAPIController) RehirePersonnelEvent(w http.ResponseWriter, r *http.Request) {
+	var req hirePersonnelEventRequest
+	call, ok := beginPersonnelEvent(w, r, &req, func() string { return req.EffectiveDate })
+	if !ok {
+		return
+	}
+	res, err := c.org.RehirePersonnelEvent(r.Context(), call.tenantID, call.requestID, call.initiatorID, services.RehirePersonnelEventInput{
+		Pernr:         req.Pernr,
+		OrgNodeID:     req.OrgNodeID,
+		PositionID:    req.PositionID,
+		EffectiveDate: call.effectiveDate,
+		AllocatedFTE:  floatOrZero(req.AllocatedFTE),
+		ReasonCode:    req.ReasonCode,
+	})
+	writePersonnelEventResult(w, call.requestID, res, err)
+}
+
+func (c *OrgAPIController) FTEChangePersonnelEvent(w http.ResponseWriter, r *http.Request) {
+	var req fteChangePersonnelEventRequest
+	call, ok := beginPersonnelEvent(w, r, &req, func() string { return req.EffectiveDate })
+	if !ok {
+		return
+	}
+	res, err := c.org.FTEChangePersonnelEvent(r.Context(), call.tenantID, call.requestID, call.initiatorID, services.FTEChangePersonnelEventInput{
+		Pernr:         req.Pernr,
+		EffectiveDate: call.effectiveDate,
+		AllocatedFTE:  floatOrZero(req.AllocatedFTE),
+		ReasonCode:    req.ReasonCode,
+	})
+	writePersonnelEventResult(w, call.requestID, res, err)
+}
+
+type personnelEventCall struct {
+	tenantID      uuid.UUID
+	requestID     string
+	initiatorID   uuid.UUID
+	effectiveDate time.Time
+}
+
+// beginPersonnelEvent authorizes the request, decodes body and parses its effective_date,
+// read through effectiveDate once the body is decoded.
+func beginPersonnelEvent(w http.ResponseWriter, r *http.Request, body any, effectiveDate func() string) (personnelEventCall, bool) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return personnelEventCall{}, false
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgAssignmentsAuthzObject, "assign") {
+		return personnelEventCall{}, false
+	}
+	if err := decodeJSON(r.Body, body); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return personnelEventCall{}, false
+	}
+	date, err := parseRequiredEffectiveDate(effectiveDate())
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return personnelEventCall{}, false
+	}
+	return personnelEventCall{
+		tenantID:      tenantID,
+		requestID:     requestID,
+		initiatorID:   authzutil.NormalizedUserUUID(tenantID, currentUser),
+		effectiveDate: date,
+	}, true
+}
+
+func writePersonnelEventResult(w http.ResponseWriter, requestID string, res *services.PersonnelEventApplyResult, err error) {
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	status := http.StatusCreated
+	if !res.Created {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, personnelEventResponse{
+		PersonnelEventID: res.Event.ID.String(),
+		EventType:        res.Event.EventType,
+		PersonUUID:       res.Event.PersonUUID.String(),
+		Pernr:            res.Event.Pernr,
+		EffectiveDate:    formatValidDate(res.Event.EffectiveDate),
+		ReasonCode:       res.Event.ReasonCode,
+	})
+}
+
+func floatOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
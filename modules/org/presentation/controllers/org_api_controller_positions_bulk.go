@@ -0,0 +1,259 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type bulkCreatePositionsRequest struct {
+	// Codes lists the new position codes; alternatively CodePrefix/StartNumber/Count generate them.
+	Codes       []string              `json:"codes"`
+	CodePrefix  string                `json:"code_prefix"`
+	StartNumber *int                  `json:"start_number"`
+	Count       int                   `json:"count"`
+	Template    createPositionRequest `json:"template"`
+}
+
+type bulkPositionFilterRequest struct {
+	OrgNodeID       *uuid.UUID `json:"org_node_id"`
+	Q               *string    `json:"q"`
+	LifecycleStatus *string    `json:"lifecycle_status"`
+	StaffingState   *string    `json:"staffing_state"`
+}
+
+type bulkUpdatePositionsRequest struct {
+	PositionIDs []uuid.UUID               `json:"position_ids"`
+	Filter      bulkPositionFilterRequest `json:"filter"`
+	Patch       updatePositionRequest     `json:"patch"`
+}
+
+type bulkTransferPositionsRequest struct {
+	PositionIDs     []uuid.UUID `json:"position_ids"`
+	TargetOrgNodeID uuid.UUID   `json:"target_org_node_id"`
+	EffectiveDate   string      `json:"effective_date"`
+	ReasonCode      string      `json:"reason_code"`
+	ReasonNote      *string     `json:"reason_note"`
+}
+
+type bulkPositionItemResponse struct {
+	PositionID      string                  `json:"position_id"`
+	Code            string                  `json:"code,omitempty"`
+	SliceID         string                  `json:"slice_id"`
+	EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+}
+
+type bulkPositionsResponse struct {
+	RequestID string                     `json:"request_id"`
+	Items     []bulkPositionItemResponse `json:"items"`
+}
+
+func (c *OrgAPIController) BulkCreatePositions(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgPositionsAuthzObject, "write") {
+		return
+	}
+
+	var req bulkCreatePositionsRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	codes := req.Codes
+	if len(codes) == 0 {
+		start := 1
+		if req.StartNumber != nil {
+			start = *req.StartNumber
+		}
+		if strings.TrimSpace(req.CodePrefix) == "" || req.Count <= 0 || start < 0 {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "codes or code_prefix/count are required")
+			return
+		}
+		if req.Count > services.MaxBulkPositions {
+			writeAPIError(w, http.StatusUnprocessableEntity, requestID, "ORG_BULK_TOO_LARGE", "count is too large")
+			return
+		}
+		codes = services.BulkPositionCodes(strings.TrimSpace(req.CodePrefix), start, req.Count)
+	}
+	template, err := createPositionInputOf(req.Template)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", err.Error())
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.BulkCreatePositions(r.Context(), tenantID, requestID, initiatorID, services.BulkCreatePositionsInput{
+		Template: template,
+		Codes:    codes,
+	})
+	if err != nil {
+		writeBulkPositionsError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, bulkPositionsResponseOf(res))
+}
+
+func (c *OrgAPIController) BulkUpdatePositions(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgPositionsAuthzObject, "write") {
+		return
+	}
+
+	var req bulkUpdatePositionsRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.Patch.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+	var profile *json.RawMessage
+	if req.Patch.Profile != nil {
+		tmp := req.Patch.Profile
+		profile = &tmp
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.BulkUpdatePositions(r.Context(), tenantID, requestID, initiatorID, services.BulkUpdatePositionsInput{
+		Filter: services.BulkPositionFilter{
+			PositionIDs:     req.PositionIDs,
+			OrgNodeID:       req.Filter.OrgNodeID,
+			Q:               req.Filter.Q,
+			LifecycleStatus: req.Filter.LifecycleStatus,
+			StaffingState:   req.Filter.StaffingState,
+		},
+		Patch: services.UpdatePositionInput{
+			EffectiveDate:   effectiveDate,
+			ReasonCode:      req.Patch.ReasonCode,
+			ReasonNote:      req.Patch.ReasonNote,
+			OrgNodeID:       req.Patch.OrgNodeID,
+			Title:           req.Patch.Title,
+			LifecycleStatus: req.Patch.LifecycleStatus,
+			PositionType:    req.Patch.PositionType,
+			EmploymentType:  req.Patch.EmploymentType,
+			CapacityFTE:     req.Patch.CapacityFTE,
+			ReportsToID:     req.Patch.ReportsToID,
+			JobLevelCode:    req.Patch.JobLevelCode,
+			JobProfileID:    req.Patch.JobProfileID,
+			CostCenterCode:  req.Patch.CostCenterCode,
+			Profile:         profile,
+		},
+	})
+	if err != nil {
+		writeBulkPositionsError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, bulkPositionsResponseOf(res))
+}
+
+func (c *OrgAPIController) BulkTransferPositions(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgPositionsAuthzObject, "write") {
+		return
+	}
+
+	var req bulkTransferPositionsRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.BulkTransferPositions(r.Context(), tenantID, requestID, initiatorID, services.BulkTransferPositionsInput{
+		PositionIDs:     req.PositionIDs,
+		TargetOrgNodeID: req.TargetOrgNodeID,
+		EffectiveDate:   effectiveDate,
+		ReasonCode:      req.ReasonCode,
+		ReasonNote:      req.ReasonNote,
+	})
+	if err != nil {
+		writeBulkPositionsError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, bulkPositionsResponseOf(res))
+}
+
+// createPositionInputOf applies the single-create defaults: capacity_fte 1 and a blank job level
+// treated as unset. Code is copied as-is; bulk create overwrites it per position.
+func createPositionInputOf(req createPositionRequest) (services.CreatePositionInput, error) {
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		return services.CreatePositionInput{}, errors.New("effective_date is required")
+	}
+	capacityFTE := 1.0
+	if req.CapacityFTE != nil {
+		capacityFTE = *req.CapacityFTE
+	}
+	var jobLevelCode *string
+	if req.JobLevelCode != nil {
+		v := strings.TrimSpace(*req.JobLevelCode)
+		if v != "" {
+			jobLevelCode = &v
+		}
+	}
+	return services.CreatePositionInput{
+		Code:             req.Code,
+		OrgNodeID:        req.OrgNodeID,
+		EffectiveDate:    effectiveDate,
+		Title:            req.Title,
+		LifecycleStatus:  req.LifecycleStatus,
+		PositionType:     req.PositionType,
+		EmploymentType:   req.EmploymentType,
+		CapacityFTE:      capacityFTE,
+		ReportsToID:      req.ReportsToID,
+		JobLevelCode:     jobLevelCode,
+		JobProfileID:     req.JobProfileID,
+		CostCenterCode:   req.CostCenterCode,
+		Profile:          req.Profile,
+		ReasonCode:       req.ReasonCode,
+		ReasonNote:       req.ReasonNote,
+		CustomAttributes: req.CustomAttributes,
+	}, nil
+}
+
+func writeBulkPositionsError(w http.ResponseWriter, requestID string, err error) {
+	var cmdErr *services.CommandError
+	if errors.As(err, &cmdErr) {
+		writeBatchServiceError(w, requestID, cmdErr.Index, cmdErr.Type, cmdErr.Err)
+		return
+	}
+	writeServiceError(w, requestID, err)
+}
+
+func bulkPositionsResponseOf(res *services.BulkPositionsResult) bulkPositionsResponse {
+	items := make([]bulkPositionItemResponse, 0, len(res.Items))
+	for _, item := range res.Items {
+		items = append(items, bulkPositionItemResponse{
+			PositionID: item.PositionID.String(),
+			Code:       item.Code,
+			SliceID:    item.SliceID.String(),
+			EffectiveWindow: effectiveWindowResponse{
+				EffectiveDate: formatValidDate(item.EffectiveDate),
+				EndDate:       formatValidEndDateFromEndDate(item.EndDate),
+			},
+		})
+	}
+	return bulkPositionsResponse{RequestID: res.RequestID, Items: items}
+}
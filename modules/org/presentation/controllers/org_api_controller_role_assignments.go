@@ -0,0 +1,180 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type createRoleRequest struct {
+	Code        string  `json:"code"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+}
+
+func (c *OrgAPIController) CreateRole(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgRolesAuthzObject, "admin") {
+		return
+	}
+
+	var req createRoleRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	role, err := c.org.CreateRole(r.Context(), tenantID, requestID, initiatorID, services.CreateRoleInput{
+		Code:        req.Code,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, role)
+}
+
+type createRoleAssignmentRequest struct {
+	RoleID        uuid.UUID `json:"role_id"`
+	SubjectType   string    `json:"subject_type"`
+	SubjectID     uuid.UUID `json:"subject_id"`
+	OrgNodeID     uuid.UUID `json:"org_node_id"`
+	EffectiveDate string    `json:"effective_date"`
+}
+
+type roleAssignmentWriteResponse struct {
+	AssignmentID    string                  `json:"assignment_id"`
+	EffectiveWindow effectiveWindowResponse `json:"effective_window"`
+}
+
+func roleAssignmentWriteResponseOf(res *services.RoleAssignmentWriteResult) roleAssignmentWriteResponse {
+	return roleAssignmentWriteResponse{
+		AssignmentID: res.AssignmentID.String(),
+		EffectiveWindow: effectiveWindowResponse{
+			EffectiveDate: formatValidDate(res.EffectiveDate),
+			EndDate:       formatValidEndDateFromEndDate(res.EndDate),
+		},
+	}
+}
+
+func (c *OrgAPIController) CreateRoleAssignment(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgRoleAssignmentsAuthzObject, "admin") {
+		return
+	}
+
+	var req createRoleAssignmentRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.CreateRoleAssignment(r.Context(), tenantID, requestID, initiatorID, services.CreateRoleAssignmentInput{
+		RoleID:        req.RoleID,
+		SubjectType:   req.SubjectType,
+		SubjectID:     req.SubjectID,
+		OrgNodeID:     req.OrgNodeID,
+		EffectiveDate: effectiveDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, roleAssignmentWriteResponseOf(res))
+}
+
+type endRoleAssignmentRequest struct {
+	EndDate string `json:"end_date"`
+}
+
+func (c *OrgAPIController) EndRoleAssignment(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgRoleAssignmentsAuthzObject, "admin") {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+	var req endRoleAssignmentRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+	endDate, err := parseRequiredEffectiveDate(req.EndDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "end_date is required")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.EndRoleAssignment(r.Context(), tenantID, requestID, initiatorID, services.EndRoleAssignmentInput{
+		AssignmentID: id,
+		EndDate:      endDate,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, roleAssignmentWriteResponseOf(res))
+}
+
+type rescindRoleAssignmentRequest struct {
+	ReasonNote *string `json:"reason_note"`
+}
+
+func (c *OrgAPIController) RescindRoleAssignment(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgRoleAssignmentsAuthzObject, "admin") {
+		return
+	}
+
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+	var req rescindRoleAssignmentRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return
+	}
+
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	res, err := c.org.RescindRoleAssignment(r.Context(), tenantID, requestID, initiatorID, services.RescindRoleAssignmentInput{
+		AssignmentID: id,
+		ReasonNote:   req.ReasonNote,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, roleAssignmentWriteResponseOf(res))
+}
@@ -0,0 +1,469 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/org/domain/changerequest"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+// scenarioMaxCompare bounds GET /scenarios:compare; each scenario is evaluated in its own
+// rolled-back transaction.
+const scenarioMaxCompare = 5
+
+type scenarioWriteRequest struct {
+	Name          string                     `json:"name"`
+	Description   *string                    `json:"description,omitempty"`
+	EffectiveDate string                     `json:"effective_date"`
+	Commands      []services.ScenarioCommand `json:"commands"`
+}
+
+type scenarioResponse struct {
+	ID              string                     `json:"id"`
+	Name            string                     `json:"name"`
+	Description     *string                    `json:"description,omitempty"`
+	EffectiveDate   string                     `json:"effective_date"`
+	Status          string                     `json:"status"`
+	ChangeRequestID *string                    `json:"change_request_id"`
+	Commands        []services.ScenarioCommand `json:"commands"`
+	CreatedBy       string                     `json:"created_by"`
+	CreatedAt       string                     `json:"created_at"`
+	UpdatedAt       string                     `json:"updated_at"`
+}
+
+type scenarioFiguresResponse struct {
+	Positions   int     `json:"positions"`
+	CapacityFTE float64 `json:"capacity_fte"`
+	Headcount   int     `json:"headcount"`
+	FTE         float64 `json:"fte"`
+}
+
+type scenarioTreeNodeResponse struct {
+	ID       string                  `json:"id"`
+	ParentID *string                 `json:"parent_id"`
+	Code     string                  `json:"code"`
+	Name     string                  `json:"name"`
+	Depth    int                     `json:"depth"`
+	Direct   scenarioFiguresResponse `json:"direct"`
+	Total    scenarioFiguresResponse `json:"total"`
+}
+
+type scenarioSubtreeResponse struct {
+	NodeID         string                   `json:"node_id"`
+	Code           string                   `json:"code"`
+	Name           string                   `json:"name"`
+	Live           *scenarioFiguresResponse `json:"live"`
+	Scenario       *scenarioFiguresResponse `json:"scenario"`
+	HeadcountDelta int                      `json:"headcount_delta"`
+	FTEDelta       float64                  `json:"fte_delta"`
+}
+
+type scenarioSummaryResponse struct {
+	PositionsTotal int     `json:"positions_total"`
+	CapacityFTE    float64 `json:"capacity_fte"`
+	OccupiedFTE    float64 `json:"occupied_fte"`
+	AvailableFTE   float64 `json:"available_fte"`
+	FillRate       float64 `json:"fill_rate"`
+}
+
+type scenarioEvaluationResponse struct {
+	Scenario        scenarioResponse           `json:"scenario"`
+	EffectiveDate   string                     `json:"effective_date"`
+	LiveSummary     *scenarioSummaryResponse   `json:"live_summary"`
+	ScenarioSummary *scenarioSummaryResponse   `json:"scenario_summary"`
+	Tree            []scenarioTreeNodeResponse `json:"tree"`
+	Subtrees        []scenarioSubtreeResponse  `json:"subtrees"`
+}
+
+func (c *OrgAPIController) ListScenarios(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgScenariosAuthzObject, "read") {
+		return
+	}
+
+	rows, err := c.org.ListScenarios(r.Context(), tenantID, 0)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	items := make([]scenarioResponse, 0, len(rows))
+	for i := range rows {
+		items = append(items, scenarioResponseOf(&rows[i]))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+func (c *OrgAPIController) CreateScenario(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgScenariosAuthzObject, "write") {
+		return
+	}
+
+	in, ok := decodeScenarioWriteRequest(w, r, requestID)
+	if !ok {
+		return
+	}
+	row, err := c.org.CreateScenario(r.Context(), tenantID, authzutil.NormalizedUserUUID(tenantID, currentUser), in)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, scenarioResponseOf(row))
+}
+
+func (c *OrgAPIController) GetScenario(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgScenariosAuthzObject, "read") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	row, err := c.org.GetScenario(r.Context(), tenantID, id)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, scenarioResponseOf(row))
+}
+
+func (c *OrgAPIController) UpdateScenario(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgScenariosAuthzObject, "write") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	in, ok := decodeScenarioWriteRequest(w, r, requestID)
+	if !ok {
+		return
+	}
+	row, err := c.org.UpdateScenario(r.Context(), tenantID, id, in)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, scenarioResponseOf(row))
+}
+
+func (c *OrgAPIController) DeleteScenario(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgScenariosAuthzObject, "write") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	if err := c.org.DeleteScenario(r.Context(), tenantID, id); err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"id": id.String(), "deleted": true})
+}
+
+func (c *OrgAPIController) EvaluateScenario(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgScenariosAuthzObject, "read") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	row, err := c.org.GetScenario(r.Context(), tenantID, id)
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	eval, err := c.org.EvaluateScenario(r.Context(), tenantID, authzutil.NormalizedUserUUID(tenantID, currentUser), row)
+	if err != nil {
+		writePreflightError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, scenarioEvaluationResponseOf(eval))
+}
+
+// CompareScenarios evaluates up to scenarioMaxCompare scenarios so planners can weigh
+// restructuring options side by side.
+func (c *OrgAPIController) CompareScenarios(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgScenariosAuthzObject, "read") {
+		return
+	}
+
+	ids, err := parseScenarioIDs(r.URL.Query().Get("ids"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", err.Error())
+		return
+	}
+	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	items := make([]scenarioEvaluationResponse, 0, len(ids))
+	for _, id := range ids {
+		row, err := c.org.GetScenario(r.Context(), tenantID, id)
+		if err != nil {
+			writeServiceError(w, requestID, err)
+			return
+		}
+		eval, err := c.org.EvaluateScenario(r.Context(), tenantID, initiatorID, row)
+		if err != nil {
+			writePreflightError(w, requestID, err)
+			return
+		}
+		items = append(items, scenarioEvaluationResponseOf(eval))
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"items": items})
+}
+
+type scenarioConvertResponse struct {
+	Scenario      scenarioResponse             `json:"scenario"`
+	ChangeRequest changeRequestSummaryResponse `json:"change_request"`
+}
+
+// ConvertScenario turns an accepted scenario into a draft change request with the same
+// commands. The scenario is evaluated first so only commands that currently apply convert.
+func (c *OrgAPIController) ConvertScenario(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !requireOrgChangeRequestsEnabled(w, requestID) {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgScenariosAuthzObject, "write") {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgChangeRequestsAuthzObj, "write") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "invalid id")
+		return
+	}
+
+	requestID = ensureRequestID(r)
+	requesterID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	scenario, cr, err := c.convertScenario(r.Context(), tenantID, requestID, requesterID, id)
+	if err != nil {
+		writePreflightError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, scenarioConvertResponse{
+		Scenario: scenarioResponseOf(scenario),
+		ChangeRequest: changeRequestSummaryResponse{
+			ID:        cr.ID.String(),
+			RequestID: cr.RequestID,
+			Status:    cr.Status,
+			CreatedAt: cr.CreatedAt.UTC().Format(time.RFC3339),
+			UpdatedAt: cr.UpdatedAt.UTC().Format(time.RFC3339),
+		},
+	})
+}
+
+func (c *OrgAPIController) convertScenario(ctx context.Context, tenantID uuid.UUID, requestID string, requesterID uuid.UUID, id uuid.UUID) (*services.ScenarioRow, *changerequest.ChangeRequest, error) {
+	row, err := c.org.GetScenario(ctx, tenantID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if row.Status != services.ScenarioStatusDraft {
+		return nil, nil, &services.ServiceError{Status: http.StatusConflict, Code: "ORG_SCENARIO_NOT_DRAFT", Message: "scenario is not draft"}
+	}
+	if len(row.Commands) == 0 {
+		return nil, nil, &services.ServiceError{Status: http.StatusUnprocessableEntity, Code: "ORG_SCENARIO_EMPTY", Message: "scenario has no commands"}
+	}
+	if _, err := c.org.EvaluateScenario(ctx, tenantID, requesterID, row); err != nil {
+		return nil, nil, err
+	}
+
+	payload, err := json.Marshal(row.Payload())
+	if err != nil {
+		return nil, nil, err
+	}
+	notes := "Scenario: " + row.Name
+	type converted struct {
+		scenario *services.ScenarioRow
+		cr       *changerequest.ChangeRequest
+	}
+	out, err := withOrgTx(ctx, tenantID, func(txCtx context.Context) (converted, error) {
+		cr, err := c.changeRequests.SaveDraft(txCtx, services.SaveDraftChangeRequestParams{
+			RequestID:   requestID,
+			RequesterID: requesterID,
+			Payload:     payload,
+			Notes:       &notes,
+		})
+		if err != nil {
+			return converted{}, err
+		}
+		scenario, err := c.org.MarkScenarioConverted(txCtx, tenantID, row.ID, cr.ID)
+		if err != nil {
+			return converted{}, err
+		}
+		return converted{scenario: scenario, cr: cr}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return out.scenario, out.cr, nil
+}
+
+func decodeScenarioWriteRequest(w http.ResponseWriter, r *http.Request, requestID string) (services.ScenarioInput, bool) {
+	var req scenarioWriteRequest
+	if err := decodeJSON(r.Body, &req); err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "invalid json body")
+		return services.ScenarioInput{}, false
+	}
+	effectiveDate, err := parseRequiredEffectiveDate(req.EffectiveDate)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_BODY", "effective_date is required")
+		return services.ScenarioInput{}, false
+	}
+	return services.ScenarioInput{
+		Name:          req.Name,
+		Description:   req.Description,
+		EffectiveDate: effectiveDate,
+		Commands:      req.Commands,
+	}, true
+}
+
+func parseScenarioIDs(raw string) ([]uuid.UUID, error) {
+	parts := splitCommaList(raw)
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("ids is required")
+	}
+	if len(parts) > scenarioMaxCompare {
+		return nil, fmt.Errorf("ids accepts at most %d scenarios", scenarioMaxCompare)
+	}
+	out := make([]uuid.UUID, 0, len(parts))
+	seen := map[uuid.UUID]bool{}
+	for _, part := range parts {
+		id, err := uuid.Parse(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("ids is invalid")
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+func scenarioResponseOf(row *services.ScenarioRow) scenarioResponse {
+	commands := row.Commands
+	if commands == nil {
+		commands = []services.ScenarioCommand{}
+	}
+	return scenarioResponse{
+		ID:              row.ID.String(),
+		Name:            row.Name,
+		Description:     row.Description,
+		EffectiveDate:   formatValidDate(row.EffectiveDate),
+		Status:          row.Status,
+		ChangeRequestID: optionalUUIDString(row.ChangeRequestID),
+		Commands:        commands,
+		CreatedBy:       row.CreatedBy.String(),
+		CreatedAt:       row.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:       row.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func scenarioFiguresOf(f services.SubtreeStaffingFigures) scenarioFiguresResponse {
+	return scenarioFiguresResponse{Positions: f.Positions, CapacityFTE: f.CapacityFTE, Headcount: f.Headcount, FTE: f.FTE}
+}
+
+func scenarioSummaryOf(report *services.SubtreeStaffingReport) *scenarioSummaryResponse {
+	if report == nil || report.Summary == nil {
+		return nil
+	}
+	t := report.Summary.Totals
+	return &scenarioSummaryResponse{
+		PositionsTotal: t.PositionsTotal,
+		CapacityFTE:    t.CapacityFTE,
+		OccupiedFTE:    t.OccupiedFTE,
+		AvailableFTE:   t.AvailableFTE,
+		FillRate:       t.FillRate,
+	}
+}
+
+func scenarioEvaluationResponseOf(eval *services.ScenarioEvaluation) scenarioEvaluationResponse {
+	out := scenarioEvaluationResponse{
+		Scenario:        scenarioResponseOf(eval.Scenario),
+		EffectiveDate:   formatValidDate(eval.Scenario.EffectiveDate),
+		LiveSummary:     scenarioSummaryOf(eval.Live),
+		ScenarioSummary: scenarioSummaryOf(eval.Staged),
+		Tree:            make([]scenarioTreeNodeResponse, 0, len(eval.Staged.Nodes)),
+		Subtrees:        make([]scenarioSubtreeResponse, 0, len(eval.Subtrees)),
+	}
+	for _, n := range eval.Staged.Nodes {
+		out.Tree = append(out.Tree, scenarioTreeNodeResponse{
+			ID:       n.ID.String(),
+			ParentID: optionalUUIDString(n.ParentID),
+			Code:     n.Code,
+			Name:     n.Name,
+			Depth:    n.Depth,
+			Direct:   scenarioFiguresOf(n.Direct),
+			Total:    scenarioFiguresOf(n.Total),
+		})
+	}
+	for _, s := range eval.Subtrees {
+		row := scenarioSubtreeResponse{NodeID: s.NodeID.String(), Code: s.Code, Name: s.Name}
+		var live, staged services.SubtreeStaffingFigures
+		if s.Live != nil {
+			live = *s.Live
+			f := scenarioFiguresOf(live)
+			row.Live = &f
+		}
+		if s.Scenario != nil {
+			staged = *s.Scenario
+			f := scenarioFiguresOf(staged)
+			row.Scenario = &f
+		}
+		row.HeadcountDelta = staged.Headcount - live.Headcount
+		row.FTEDelta = staged.FTE - live.FTE
+		out.Subtrees = append(out.Subtrees, row)
+	}
+	return out
+}
@@ -0,0 +1,150 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type searchPathNode struct {
+	ID    string `json:"id"`
+	Code  string `json:"code"`
+	Name  string `json:"name"`
+	Depth int    `json:"depth"`
+}
+
+type searchHitResponse struct {
+	Kind          string  `json:"kind"`
+	ID            string  `json:"id"`
+	Code          string  `json:"code"`
+	Name          string  `json:"name"`
+	MatchedField  string  `json:"matched_field"`
+	Score         float64 `json:"score"`
+	OrgNodeID     *string `json:"org_node_id,omitempty"`
+	HierarchyType string  `json:"hierarchy_type,omitempty"`
+	Path          struct {
+		Nodes []searchPathNode `json:"nodes"`
+	} `json:"path"`
+}
+
+type searchResponse struct {
+	TenantID      string              `json:"tenant_id"`
+	Query         string              `json:"q"`
+	EffectiveDate string              `json:"effective_date"`
+	Kinds         []string            `json:"kinds"`
+	HierarchyType string              `json:"type,omitempty"`
+	Hits          []searchHitResponse `json:"hits"`
+}
+
+// searchKindAuthzObjects maps each search kind to the object its plain list endpoint checks,
+// so search never reveals more than the caller could already read.
+var searchKindAuthzObjects = map[services.SearchKind]string{
+	services.SearchKindNode:       orgHierarchiesAuthzObject,
+	services.SearchKindPosition:   orgPositionsAuthzObject,
+	services.SearchKindJobProfile: orgJobProfilesAuthzObject,
+	services.SearchKindPerson:     orgAssignmentsAuthzObject,
+}
+
+func (c *OrgAPIController) Search(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	kinds := services.AllSearchKinds
+	if raw := strings.TrimSpace(q.Get("kinds")); raw != "" {
+		kinds = nil
+		seen := map[services.SearchKind]bool{}
+		for _, part := range strings.Split(raw, ",") {
+			if strings.TrimSpace(part) == "" {
+				continue
+			}
+			k, ok := services.ParseSearchKind(part)
+			if !ok {
+				writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "kinds is invalid")
+				return
+			}
+			if !seen[k] {
+				seen[k] = true
+				kinds = append(kinds, k)
+			}
+		}
+	}
+	for _, k := range kinds {
+		if !ensureOrgAuthz(w, r, tenantID, currentUser, searchKindAuthzObjects[k], "read") {
+			return
+		}
+	}
+
+	hType := strings.TrimSpace(q.Get("type"))
+	if hType != "" && !services.IsValidHierarchyType(hType) {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "type is invalid")
+		return
+	}
+
+	asOf, err := parseEffectiveDate(q.Get("effective_date"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "effective_date is invalid")
+		return
+	}
+
+	limit := 0
+	if raw := strings.TrimSpace(q.Get("limit")); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "limit is invalid")
+			return
+		}
+		limit = n
+	}
+
+	res, err := c.org.Search(r.Context(), tenantID, services.SearchParams{
+		Query:         q.Get("q"),
+		Kinds:         kinds,
+		HierarchyType: hType,
+		AsOf:          asOf,
+		Limit:         limit,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+
+	out := searchResponse{
+		TenantID:      res.TenantID.String(),
+		Query:         res.Query,
+		EffectiveDate: formatValidDate(res.EffectiveDate),
+		Kinds:         make([]string, 0, len(res.Kinds)),
+		HierarchyType: res.HierarchyType,
+		Hits:          make([]searchHitResponse, 0, len(res.Hits)),
+	}
+	for _, k := range res.Kinds {
+		out.Kinds = append(out.Kinds, string(k))
+	}
+	for _, h := range res.Hits {
+		hit := searchHitResponse{
+			Kind:          string(h.Kind),
+			ID:            h.ID.String(),
+			Code:          h.Code,
+			Name:          h.Name,
+			MatchedField:  h.MatchedField,
+			Score:         h.Score,
+			OrgNodeID:     optionalUUIDString(h.OrgNodeID),
+			HierarchyType: h.HierarchyType,
+		}
+		hit.Path.Nodes = make([]searchPathNode, 0, len(h.Path))
+		for _, n := range h.Path {
+			hit.Path.Nodes = append(hit.Path.Nodes, searchPathNode{
+				ID:    n.ID.String(),
+				Code:  n.Code,
+				Name:  n.Name,
+				Depth: n.Depth,
+			})
+		}
+		out.Hits = append(out.Hits, hit)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
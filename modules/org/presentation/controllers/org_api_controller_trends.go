@@ -0,0 +1,141 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+type trendPointResponse struct {
+	Label            string  `json:"label"`
+	Start            string  `json:"start"`
+	End              string  `json:"end"`
+	HeadcountStart   int     `json:"headcount_start"`
+	Headcount        int     `json:"headcount"`
+	Hires            int     `json:"hires"`
+	Terminations     int     `json:"terminations"`
+	TurnoverRate     float64 `json:"turnover_rate"`
+	PositionsTotal   int     `json:"positions_total"`
+	VacantPositions  int     `json:"vacant_positions"`
+	VacancyRate      float64 `json:"vacancy_rate"`
+	ManagerPositions int     `json:"manager_positions"`
+	DirectReports    int     `json:"direct_reports"`
+	AvgSpanOfControl float64 `json:"avg_span_of_control"`
+}
+
+type trendReportResponse struct {
+	TenantID  string               `json:"tenant_id"`
+	OrgNodeID string               `json:"org_node_id"`
+	From      string               `json:"from"`
+	To        string               `json:"to"`
+	Interval  string               `json:"interval"`
+	Scope     string               `json:"scope"`
+	Points    []trendPointResponse `json:"points"`
+	Source    struct {
+		DeepReadBackend string  `json:"deep_read_backend"`
+		SnapshotBuildID *string `json:"snapshot_build_id,omitempty"`
+	} `json:"source"`
+}
+
+func (c *OrgAPIController) GetTrendReport(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, requestID, ok := requireSessionTenantUser(w, r)
+	if !ok {
+		return
+	}
+	if !ensureOrgAuthz(w, r, tenantID, currentUser, orgPositionReportsAuthzObject, "read") {
+		return
+	}
+
+	orgNodeID, hasOrgNodeID, err := parseOptionalUUID(r.URL.Query().Get("org_node_id"))
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "org_node_id is invalid")
+		return
+	}
+	var orgNodeIDPtr *uuid.UUID
+	if hasOrgNodeID {
+		orgNodeIDPtr = &orgNodeID
+	}
+
+	fromRaw := strings.TrimSpace(r.URL.Query().Get("from"))
+	toRaw := strings.TrimSpace(r.URL.Query().Get("to"))
+	if fromRaw == "" || toRaw == "" {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "from/to are required")
+		return
+	}
+	from, err := time.Parse("2006-01-02", fromRaw)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "from is invalid")
+		return
+	}
+	to, err := time.Parse("2006-01-02", toRaw)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "to is invalid")
+		return
+	}
+
+	includeSystem := false
+	if raw := strings.TrimSpace(r.URL.Query().Get("include_system")); raw != "" {
+		v, err := strconv.ParseBool(raw)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, requestID, "ORG_INVALID_QUERY", "include_system is invalid")
+			return
+		}
+		includeSystem = v
+	}
+
+	res, err := c.org.GetTrendReport(r.Context(), tenantID, services.TrendReportInput{
+		OrgNodeID:         orgNodeIDPtr,
+		From:              from.UTC(),
+		To:                to.UTC(),
+		Interval:          services.TrendInterval(strings.TrimSpace(r.URL.Query().Get("interval"))),
+		Scope:             services.StaffingScope(strings.TrimSpace(r.URL.Query().Get("scope"))),
+		LifecycleStatuses: splitCommaList(r.URL.Query().Get("lifecycle_statuses")),
+		IncludeSystem:     includeSystem,
+	})
+	if err != nil {
+		writeServiceError(w, requestID, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, trendReportToResponse(res))
+}
+
+func trendReportToResponse(res *services.TrendReport) trendReportResponse {
+	out := trendReportResponse{
+		TenantID:  res.TenantID.String(),
+		OrgNodeID: res.OrgNodeID.String(),
+		From:      formatValidDate(res.From),
+		To:        formatValidDate(res.To),
+		Interval:  string(res.Interval),
+		Scope:     string(res.Scope),
+		Points:    make([]trendPointResponse, 0, len(res.Points)),
+	}
+	out.Source.DeepReadBackend = string(res.Source.DeepReadBackend)
+	if res.Source.SnapshotBuildID != nil && *res.Source.SnapshotBuildID != uuid.Nil {
+		id := res.Source.SnapshotBuildID.String()
+		out.Source.SnapshotBuildID = &id
+	}
+	for _, p := range res.Points {
+		out.Points = append(out.Points, trendPointResponse{
+			Label:            p.Label,
+			Start:            formatValidDate(p.Start),
+			End:              formatValidDate(p.End),
+			HeadcountStart:   p.HeadcountStart,
+			Headcount:        p.HeadcountEnd,
+			Hires:            p.Hires,
+			Terminations:     p.Terminations,
+			TurnoverRate:     p.TurnoverRate,
+			PositionsTotal:   p.PositionsTotal,
+			VacantPositions:  p.VacantPositions,
+			VacancyRate:      p.VacancyRate,
+			ManagerPositions: p.ManagerPositions,
+			DirectReports:    p.DirectReports,
+			AvgSpanOfControl: p.AvgSpanOfControl,
+		})
+	}
+	return out
+}
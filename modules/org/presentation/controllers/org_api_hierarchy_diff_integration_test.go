@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/testhelpers"
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func TestOrgAPIController_DiffHierarchies_GroupsChangesBySubtree(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeDisabled)
+
+	pool, tenantID := setupOrgTestDB(t, []string{
+		"00001_org_baseline.sql",
+		"20251218005114_org_placeholders_and_event_contracts.sql",
+		"20251218130000_org_settings_and_audit.sql",
+		"20251218150000_org_outbox.sql",
+		"20251219090000_org_hierarchy_closure_and_snapshots.sql",
+		"20251219195000_org_security_group_mappings_and_links.sql",
+		"20251219220000_org_reporting_nodes_and_view.sql",
+		"20251220160000_org_position_slices_and_fte.sql",
+		"20251220200000_org_job_catalog_profiles_and_validation_modes.sql",
+		"20251221090000_org_reason_code_mode.sql",
+		"20251222120000_org_personnel_events.sql",
+		"20251227090000_org_valid_time_day_granularity.sql",
+		"20251228120000_org_eliminate_effective_on_end_on.sql",
+		"20251228140000_org_assignment_employment_status.sql",
+		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20251230090000_org_job_architecture_workday_profiles.sql",
+		"20251231120000_org_remove_job_family_allocation_percent.sql",
+		"20260101020855_org_job_catalog_effective_dated_slices_phase_a.sql",
+		"20260101020930_org_job_catalog_effective_dated_slices_gates_and_backfill.sql",
+		"20260104100000_org_drop_job_profile_job_families_legacy.sql",
+		"20260104120000_org_drop_job_catalog_identity_legacy_columns.sql",
+		"20260109090000_org_hierarchy_types.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
+	})
+	ensureOrgSettings(t, pool, tenantID)
+	withOrgRolloutEnabled(t, tenantID)
+	u := newTestOrgUser(tenantID)
+
+	svc := orgsvc.NewOrgService(persistence.NewOrgRepository())
+	c := &OrgAPIController{org: svc}
+	ctx := composables.WithPool(t.Context(), pool)
+	initiatorID := uuid.New()
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mar := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	createNode := func(code, name string, parentID *uuid.UUID, effectiveDate time.Time) uuid.UUID {
+		t.Helper()
+		res, err := svc.CreateNode(ctx, tenantID, "req-diff-"+code, initiatorID, orgsvc.CreateNodeInput{
+			Code:          code,
+			Name:          name,
+			ParentID:      parentID,
+			EffectiveDate: effectiveDate,
+		})
+		require.NoError(t, err)
+		return res.NodeID
+	}
+	rootID := createNode("ROOT", "Company", nil, jan)
+	salesID := createNode("SALES", "Sales", &rootID, jan)
+	opsID := createNode("OPS", "Operations", &rootID, jan)
+	teamID := createNode("TEAM", "Team A", &opsID, jan)
+
+	createNode("EMEA", "EMEA Sales", &salesID, mar)
+	newName := "Operations & Logistics"
+	_, err := svc.UpdateNode(ctx, tenantID, "req-diff-rename", initiatorID, orgsvc.UpdateNodeInput{
+		NodeID:        opsID,
+		EffectiveDate: mar,
+		Name:          &newName,
+	})
+	require.NoError(t, err)
+	_, err = svc.MoveNode(ctx, tenantID, "req-diff-move", initiatorID, orgsvc.MoveNodeInput{
+		NodeID:        teamID,
+		NewParentID:   salesID,
+		EffectiveDate: mar,
+	})
+	require.NoError(t, err)
+
+	diff := func(query string) (int, hierarchyDiffResponse) {
+		t.Helper()
+		req := newOrgAPIRequest(t, http.MethodGet, "/org/api/hierarchies:diff?"+query, tenantID, u)
+		req = req.WithContext(composables.WithPool(req.Context(), pool))
+		rr := httptest.NewRecorder()
+		c.DiffHierarchies(rr, req)
+		var out hierarchyDiffResponse
+		if rr.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out), strings.TrimSpace(rr.Body.String()))
+		}
+		return rr.Code, out
+	}
+
+	code, res := diff("from=2025-02-01&to=2025-03-01")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 1, res.Totals.NodesCreated)
+	require.Equal(t, 1, res.Totals.NodesRenamed)
+	require.Equal(t, 1, res.Totals.NodesMoved)
+	require.Equal(t, 0, res.Totals.NodesRetired)
+
+	groups := map[string]hierarchyDiffGroupResponse{}
+	for _, g := range res.Groups {
+		groups[g.Subtree.Code] = g
+	}
+	require.Len(t, groups, 2)
+	require.Equal(t, "EMEA", groups["SALES"].NodesCreated[0].Code)
+	require.Equal(t, teamID.String(), groups["SALES"].NodesMoved[0].ID)
+	require.Equal(t, opsID.String(), *groups["SALES"].NodesMoved[0].FromParentID)
+	require.Equal(t, "Operations & Logistics", groups["OPS"].NodesRenamed[0].ToName)
+
+	// Scoped to one subtree, changes group under its direct children.
+	_, res = diff("from=2025-02-01&to=2025-03-01&root_node_id=" + salesID.String())
+	require.Equal(t, 1, res.Totals.NodesCreated)
+	require.Equal(t, 1, res.Totals.NodesMoved)
+	require.Equal(t, 0, res.Totals.NodesRenamed)
+
+	code, _ = diff("from=2025-03-01&to=2025-02-01")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = diff("from=2025-02-01")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = diff("from=2025-02-01&to=2025-03-01&include=budgets")
+	require.Equal(t, http.StatusBadRequest, code)
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/testhelpers"
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func TestOrgAPIController_GetOrgChart_RendersSVGAndPDF(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeDisabled)
+
+	pool, tenantID := setupOrgTestDB(t, []string{
+		"00001_org_baseline.sql",
+		"20251218005114_org_placeholders_and_event_contracts.sql",
+		"20251218130000_org_settings_and_audit.sql",
+		"20251218150000_org_outbox.sql",
+		"20251219090000_org_hierarchy_closure_and_snapshots.sql",
+		"20251219195000_org_security_group_mappings_and_links.sql",
+		"20251219220000_org_reporting_nodes_and_view.sql",
+		"20251220160000_org_position_slices_and_fte.sql",
+		"20251220200000_org_job_catalog_profiles_and_validation_modes.sql",
+		"20251221090000_org_reason_code_mode.sql",
+		"20251222120000_org_personnel_events.sql",
+		"20251227090000_org_valid_time_day_granularity.sql",
+		"20251228120000_org_eliminate_effective_on_end_on.sql",
+		"20251228140000_org_assignment_employment_status.sql",
+		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20251230090000_org_job_architecture_workday_profiles.sql",
+		"20251231120000_org_remove_job_family_allocation_percent.sql",
+		"20260101020855_org_job_catalog_effective_dated_slices_phase_a.sql",
+		"20260101020930_org_job_catalog_effective_dated_slices_gates_and_backfill.sql",
+		"20260104100000_org_drop_job_profile_job_families_legacy.sql",
+		"20260104120000_org_drop_job_catalog_identity_legacy_columns.sql",
+		"20260109090000_org_hierarchy_types.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
+	})
+	ensureOrgSettings(t, pool, tenantID)
+	withOrgRolloutEnabled(t, tenantID)
+	u := newTestOrgUser(tenantID)
+
+	svc := orgsvc.NewOrgService(persistence.NewOrgRepository())
+	c := &OrgAPIController{org: svc}
+	ctx := composables.WithPool(t.Context(), pool)
+	initiatorID := uuid.New()
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	createNode := func(code, name string, parentID *uuid.UUID) uuid.UUID {
+		t.Helper()
+		res, err := svc.CreateNode(ctx, tenantID, "req-chart-"+code, initiatorID, orgsvc.CreateNodeInput{
+			Code:          code,
+			Name:          name,
+			ParentID:      parentID,
+			EffectiveDate: jan,
+		})
+		require.NoError(t, err)
+		return res.NodeID
+	}
+	rootID := createNode("ROOT", "Company", nil)
+	salesID := createNode("SALES", "Sales & Marketing", &rootID)
+	createNode("EMEA", "EMEA Sales", &salesID)
+	createNode("OPS", "Operations", &rootID)
+
+	chart := func(query string) *httptest.ResponseRecorder {
+		t.Helper()
+		req := newOrgAPIRequest(t, http.MethodGet, "/org/api/hierarchies:chart?"+query, tenantID, u)
+		req = req.WithContext(composables.WithPool(req.Context(), pool))
+		rr := httptest.NewRecorder()
+		c.GetOrgChart(rr, req)
+		return rr
+	}
+
+	rr := chart("effective_date=2025-02-01")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.Equal(t, "image/svg+xml", rr.Header().Get("Content-Type"))
+	require.Contains(t, rr.Header().Get("Content-Disposition"), "org-chart-orgunit-2025-02-01.svg")
+	dec := xml.NewDecoder(bytes.NewReader(rr.Body.Bytes()))
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	body := rr.Body.String()
+	require.Equal(t, 4, strings.Count(body, "<g data-node-id="))
+	require.Contains(t, body, "Sales &amp; Marketing")
+	require.Contains(t, body, "No manager")
+
+	// Scoped to a subtree with a depth limit.
+	rr = chart("effective_date=2025-02-01&root_node_id=" + salesID.String() + "&max_depth=0&layout=left_right")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.Equal(t, 1, strings.Count(rr.Body.String(), "<g data-node-id="))
+	require.NotContains(t, rr.Body.String(), "EMEA Sales")
+
+	rr = chart("effective_date=2025-02-01&format=pdf&layout=compact")
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+	require.True(t, bytes.HasPrefix(rr.Body.Bytes(), []byte("%PDF-")))
+
+	// The root has to exist at the chart date.
+	rr = chart("effective_date=2024-01-01&root_node_id=" + rootID.String())
+	require.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+
+	for _, q := range []string{"layout=radial", "format=png", "photos=maybe", "max_depth=x", "type=bogus"} {
+		rr = chart("effective_date=2025-02-01&" + q)
+		require.Equal(t, http.StatusBadRequest, rr.Code, q)
+	}
+}
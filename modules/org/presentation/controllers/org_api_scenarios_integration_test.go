@@ -0,0 +1,161 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/testhelpers"
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func TestOrgAPIController_Scenarios_EvaluateWithoutWritingAndConvert(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeDisabled)
+	withOrgChangeRequestsEnabled(t)
+
+	pool, tenantID := setupOrgTestDB(t, []string{
+		"00001_org_baseline.sql",
+		"20251218005114_org_placeholders_and_event_contracts.sql",
+		"20251218130000_org_settings_and_audit.sql",
+		"20251218150000_org_outbox.sql",
+		"20251219090000_org_hierarchy_closure_and_snapshots.sql",
+		"20251219195000_org_security_group_mappings_and_links.sql",
+		"20251219220000_org_reporting_nodes_and_view.sql",
+		"20251220160000_org_position_slices_and_fte.sql",
+		"20251220200000_org_job_catalog_profiles_and_validation_modes.sql",
+		"20251221090000_org_reason_code_mode.sql",
+		"20251222120000_org_personnel_events.sql",
+		"20251227090000_org_valid_time_day_granularity.sql",
+		"20251228120000_org_eliminate_effective_on_end_on.sql",
+		"20251228140000_org_assignment_employment_status.sql",
+		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20251230090000_org_job_architecture_workday_profiles.sql",
+		"20251231120000_org_remove_job_family_allocation_percent.sql",
+		"20260101020855_org_job_catalog_effective_dated_slices_phase_a.sql",
+		"20260101020930_org_job_catalog_effective_dated_slices_gates_and_backfill.sql",
+		"20260104100000_org_drop_job_profile_job_families_legacy.sql",
+		"20260104120000_org_drop_job_catalog_identity_legacy_columns.sql",
+		"20260105090000_org_change_request_review.sql",
+		"20260106090000_org_change_request_approval_chains.sql",
+		"20260107090000_org_change_request_scheduling.sql",
+		"20260109090000_org_hierarchy_types.sql",
+		"20260110090000_org_scenarios.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
+	})
+	ensureOrgSettings(t, pool, tenantID)
+	withOrgRolloutEnabled(t, tenantID)
+	u := newTestOrgUser(tenantID)
+
+	svc := orgsvc.NewOrgService(persistence.NewOrgRepository())
+	c := &OrgAPIController{
+		org:            svc,
+		changeRequests: orgsvc.NewChangeRequestService(persistence.NewChangeRequestRepository()),
+	}
+	ctx := composables.WithPool(t.Context(), pool)
+	initiatorID := uuid.New()
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	createNode := func(code, name string, parentID *uuid.UUID) uuid.UUID {
+		t.Helper()
+		res, err := svc.CreateNode(ctx, tenantID, "req-scenario-"+code, initiatorID, orgsvc.CreateNodeInput{
+			Code:          code,
+			Name:          name,
+			ParentID:      parentID,
+			EffectiveDate: jan,
+		})
+		require.NoError(t, err)
+		return res.NodeID
+	}
+	rootID := createNode("ROOT", "Company", nil)
+	salesID := createNode("SALES", "Sales", &rootID)
+	opsID := createNode("OPS", "Operations", &rootID)
+
+	call := func(method, path string, body any, vars map[string]string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if body != nil {
+			req = newOrgAPIRequestWithBody(t, method, path, tenantID, u, mustJSON(t, body))
+		} else {
+			req = newOrgAPIRequest(t, method, path, tenantID, u)
+		}
+		req = req.WithContext(composables.WithPool(req.Context(), pool))
+		if vars != nil {
+			req = mux.SetURLVars(req, vars)
+		}
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		return rr
+	}
+
+	rr := call(http.MethodPost, "/org/api/scenarios", map[string]any{
+		"name":           "Merge ops into sales",
+		"effective_date": "2025-02-01",
+		"commands": []any{
+			map[string]any{"type": "node.move", "payload": map[string]any{"id": opsID, "new_parent_id": salesID}},
+			map[string]any{"type": "node.create", "payload": map[string]any{"code": "LAB", "name": "Lab", "parent_id": rootID}},
+		},
+	}, nil, c.CreateScenario)
+	require.Equal(t, http.StatusCreated, rr.Code, strings.TrimSpace(rr.Body.String()))
+	var created scenarioResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &created))
+	require.Equal(t, orgsvc.ScenarioStatusDraft, created.Status)
+	require.Len(t, created.Commands, 2)
+
+	rr = call(http.MethodGet, "/org/api/scenarios/"+created.ID+":evaluate", nil, map[string]string{"id": created.ID}, c.EvaluateScenario)
+	require.Equal(t, http.StatusOK, rr.Code, strings.TrimSpace(rr.Body.String()))
+	var eval scenarioEvaluationResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &eval))
+	subtrees := map[string]scenarioSubtreeResponse{}
+	for _, s := range eval.Subtrees {
+		subtrees[s.Code] = s
+	}
+	require.Equal(t, "ROOT", eval.Subtrees[0].Code)
+	require.NotNil(t, subtrees["OPS"].Live)
+	require.Nil(t, subtrees["OPS"].Scenario)
+	require.Nil(t, subtrees["LAB"].Live)
+	require.NotNil(t, subtrees["LAB"].Scenario)
+	require.Len(t, eval.Tree, 4)
+
+	// Evaluation rolls back: the live tree is unchanged.
+	nodes, _, err := svc.GetHierarchyAsOf(ctx, tenantID, orgsvc.HierarchyTypeOrgUnit, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, nodes, 3)
+	for _, n := range nodes {
+		if n.ID == opsID {
+			require.Equal(t, rootID, *n.ParentID)
+		}
+	}
+
+	rr = call(http.MethodGet, "/org/api/scenarios:compare?ids="+created.ID, nil, nil, c.CompareScenarios)
+	require.Equal(t, http.StatusOK, rr.Code, strings.TrimSpace(rr.Body.String()))
+
+	rr = call(http.MethodPost, "/org/api/scenarios/"+created.ID+":convert", nil, map[string]string{"id": created.ID}, c.ConvertScenario)
+	require.Equal(t, http.StatusCreated, rr.Code, strings.TrimSpace(rr.Body.String()))
+	var converted scenarioConvertResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &converted))
+	require.Equal(t, orgsvc.ScenarioStatusConverted, converted.Scenario.Status)
+	require.Equal(t, converted.ChangeRequest.ID, *converted.Scenario.ChangeRequestID)
+	require.Equal(t, "draft", converted.ChangeRequest.Status)
+
+	rr = call(http.MethodPatch, "/org/api/scenarios/"+created.ID, map[string]any{
+		"name":           "Too late",
+		"effective_date": "2025-02-01",
+	}, map[string]string{"id": created.ID}, c.UpdateScenario)
+	require.Equal(t, http.StatusConflict, rr.Code)
+}
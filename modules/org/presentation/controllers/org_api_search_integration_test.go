@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/core/testhelpers"
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func TestOrgAPIController_Search_RanksHitsWithPaths(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeDisabled)
+
+	pool, tenantID := setupOrgTestDB(t, []string{
+		"00001_org_baseline.sql",
+		"20251218005114_org_placeholders_and_event_contracts.sql",
+		"20251218130000_org_settings_and_audit.sql",
+		"20251218150000_org_outbox.sql",
+		"20251219090000_org_hierarchy_closure_and_snapshots.sql",
+		"20251219195000_org_security_group_mappings_and_links.sql",
+		"20251219220000_org_reporting_nodes_and_view.sql",
+		"20251220160000_org_position_slices_and_fte.sql",
+		"20251220200000_org_job_catalog_profiles_and_validation_modes.sql",
+		"20251221090000_org_reason_code_mode.sql",
+		"20251222120000_org_personnel_events.sql",
+		"20251227090000_org_valid_time_day_granularity.sql",
+		"20251228120000_org_eliminate_effective_on_end_on.sql",
+		"20251228140000_org_assignment_employment_status.sql",
+		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20251230090000_org_job_architecture_workday_profiles.sql",
+		"20251231120000_org_remove_job_family_allocation_percent.sql",
+		"20260101020855_org_job_catalog_effective_dated_slices_phase_a.sql",
+		"20260101020930_org_job_catalog_effective_dated_slices_gates_and_backfill.sql",
+		"20260104100000_org_drop_job_profile_job_families_legacy.sql",
+		"20260104120000_org_drop_job_catalog_identity_legacy_columns.sql",
+		"20260108090000_org_search_trgm.sql",
+		"20260109090000_org_hierarchy_types.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
+	})
+	ensureOrgSettings(t, pool, tenantID)
+
+	// persons table is owned by Person migrations; search only needs the minimal schema contract.
+	root := filepath.Clean("../../../../")
+	for _, f := range []string{"00001_person_baseline.sql", "00003_person_display_name_trgm.sql"} {
+		_, err := pool.Exec(context.Background(), readGooseUpSQL(t, filepath.Join(root, "migrations", "person", f)))
+		require.NoError(t, err, "failed migration %s", f)
+	}
+
+	withOrgRolloutEnabled(t, tenantID)
+	u := newTestOrgUser(tenantID)
+
+	c := &OrgAPIController{
+		org: orgsvc.NewOrgService(persistence.NewOrgRepository()),
+	}
+
+	createNode := func(body map[string]any, requestID string) uuid.UUID {
+		t.Helper()
+		body["effective_date"] = "2025-01-01"
+		req := newOrgAPIRequestWithBody(t, http.MethodPost, "/org/api/nodes", tenantID, u, mustJSON(t, body))
+		req = req.WithContext(composables.WithPool(req.Context(), pool))
+		req.Header.Set("X-Request-ID", requestID)
+		rr := httptest.NewRecorder()
+		c.CreateNode(rr, req)
+		require.Equal(t, http.StatusCreated, rr.Code, strings.TrimSpace(rr.Body.String()))
+		var res struct {
+			ID string `json:"id"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &res))
+		return uuid.MustParse(res.ID)
+	}
+	rootID := createNode(map[string]any{"code": "ROOT", "name": "Company"}, "req-org-search-root")
+	financeID := createNode(map[string]any{
+		"code":       "FIN",
+		"name":       "Finance Department",
+		"parent_id":  rootID,
+		"i18n_names": map[string]string{"ru": "Финансовый отдел"},
+	}, "req-org-search-fin")
+	createNode(map[string]any{"code": "FIN-OPS", "name": "Operations", "parent_id": financeID}, "req-org-search-ops")
+	costRootID := createNode(map[string]any{"hierarchy_type": "CostCenter", "code": "CC", "name": "Cost Centers"}, "req-org-search-cc-root")
+	costFinID := createNode(map[string]any{"hierarchy_type": "CostCenter", "code": "CC-FIN", "name": "Finance Costs", "parent_id": costRootID}, "req-org-search-cc-fin")
+
+	search := func(query string) (int, searchResponse) {
+		t.Helper()
+		req := newOrgAPIRequest(t, http.MethodGet, "/org/api/search?"+query, tenantID, u)
+		req = req.WithContext(composables.WithPool(req.Context(), pool))
+		rr := httptest.NewRecorder()
+		c.Search(rr, req)
+		var out searchResponse
+		if rr.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+		}
+		return rr.Code, out
+	}
+
+	// The exact code match outranks the prefix match on FIN-OPS.
+	code, res := search("q=fin&kinds=node&effective_date=2025-01-01")
+	require.Equal(t, http.StatusOK, code)
+	require.GreaterOrEqual(t, len(res.Hits), 2)
+	require.Equal(t, financeID.String(), res.Hits[0].ID)
+	require.Equal(t, "code", res.Hits[0].MatchedField)
+	require.InDelta(t, 1.0, res.Hits[0].Score, 0.0001)
+	require.Equal(t, "FIN-OPS", res.Hits[1].Code)
+	require.Len(t, res.Hits[1].Path.Nodes, 3)
+	require.Equal(t, rootID.String(), res.Hits[1].Path.Nodes[0].ID)
+	require.Equal(t, 2, res.Hits[1].Path.Nodes[2].Depth)
+
+	// Localized names and typos both resolve.
+	_, res = search("q=" + "%D1%84%D0%B8%D0%BD%D0%B0%D0%BD%D1%81" + "&kinds=node&effective_date=2025-01-01")
+	require.NotEmpty(t, res.Hits)
+	require.Equal(t, financeID.String(), res.Hits[0].ID)
+	require.Equal(t, "i18n_names", res.Hits[0].MatchedField)
+
+	_, res = search("q=Finanse+Department&effective_date=2025-01-01")
+	require.NotEmpty(t, res.Hits)
+	require.Equal(t, financeID.String(), res.Hits[0].ID)
+	require.Equal(t, "name", res.Hits[0].MatchedField)
+	require.Len(t, res.Hits[0].Path.Nodes, 2)
+
+	// Paths follow the hierarchy each node belongs to; type scopes hits to one hierarchy.
+	_, res = search("q=Finance+Costs&kinds=node&effective_date=2025-01-01")
+	require.NotEmpty(t, res.Hits)
+	require.Equal(t, costFinID.String(), res.Hits[0].ID)
+	require.Equal(t, "CostCenter", res.Hits[0].HierarchyType)
+	require.Len(t, res.Hits[0].Path.Nodes, 2)
+	require.Equal(t, costRootID.String(), res.Hits[0].Path.Nodes[0].ID)
+
+	_, res = search("q=fin&kinds=node&type=CostCenter&effective_date=2025-01-01")
+	require.Equal(t, "CostCenter", res.HierarchyType)
+	require.NotEmpty(t, res.Hits)
+	for _, h := range res.Hits {
+		require.Equal(t, "CostCenter", h.HierarchyType)
+	}
+	_, res = search("q=fin&kinds=node&type=OrgUnit&effective_date=2025-01-01")
+	require.NotEmpty(t, res.Hits)
+	for _, h := range res.Hits {
+		require.NotEqual(t, costFinID.String(), h.ID)
+	}
+
+	// Nothing exists before the nodes' effective date.
+	_, res = search("q=fin&kinds=node&effective_date=2024-12-31")
+	require.Empty(t, res.Hits)
+
+	code, _ = search("q=f")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = search("q=fin&kinds=unknown")
+	require.Equal(t, http.StatusBadRequest, code)
+	code, _ = search("q=fin&type=Unknown")
+	require.Equal(t, http.StatusBadRequest, code)
+}
@@ -63,6 +63,12 @@ func TestOrgAPIController_GetSnapshot_PaginatesWithCursor(t *testing.T) {
 		"20251228120000_org_eliminate_effective_on_end_on.sql",
 		"20251228140000_org_assignment_employment_status.sql",
 		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
 	})
 
 	asOf := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
@@ -150,6 +156,12 @@ func TestOrgAPIController_Batch_DryRunHasNoSideEffects(t *testing.T) {
 		"20251228120000_org_eliminate_effective_on_end_on.sql",
 		"20251228140000_org_assignment_employment_status.sql",
 		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
 	})
 	ensureOrgSettings(t, pool, tenantID)
 
@@ -213,6 +225,12 @@ func TestOrgAPIController_Batch_InvalidCommandReturnsCommandIndexMeta(t *testing
 		"20251228120000_org_eliminate_effective_on_end_on.sql",
 		"20251228140000_org_assignment_employment_status.sql",
 		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
 	})
 
 	withOrgRolloutEnabled(t, tenantID)
@@ -368,6 +386,17 @@ func newTestOrgUser(tenantID uuid.UUID) coreuser.User {
 	)
 }
 
+func newTestOrgReviewer(tenantID uuid.UUID) coreuser.User {
+	return coreuser.New(
+		"Review",
+		"User",
+		internet.MustParseEmail("reviewer@example.com"),
+		coreuser.UILanguageEN,
+		coreuser.WithID(2),
+		coreuser.WithTenantID(tenantID),
+	)
+}
+
 func mustJSON(tb testing.TB, v any) []byte {
 	tb.Helper()
 	b, err := json.Marshal(v)
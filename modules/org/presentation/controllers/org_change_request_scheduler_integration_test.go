@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	coredtos "github.com/iota-uz/iota-sdk/modules/core/presentation/controllers/dtos"
+	"github.com/iota-uz/iota-sdk/modules/core/testhelpers"
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgscheduler "github.com/iota-uz/iota-sdk/modules/org/infrastructure/scheduler"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+func TestChangeRequestScheduler_AppliesDueRequests(t *testing.T) {
+	setAuthzEnv(t)
+	testhelpers.WithAuthzMode(t, authz.ModeDisabled)
+	withOrgChangeRequestsEnabled(t)
+
+	pool, tenantID := setupOrgTestDB(t, []string{
+		"00001_org_baseline.sql",
+		"20251218005114_org_placeholders_and_event_contracts.sql",
+		"20251218130000_org_settings_and_audit.sql",
+		"20251218150000_org_outbox.sql",
+		"20251219090000_org_hierarchy_closure_and_snapshots.sql",
+		"20251219195000_org_security_group_mappings_and_links.sql",
+		"20251219220000_org_reporting_nodes_and_view.sql",
+		"20251220160000_org_position_slices_and_fte.sql",
+		"20251220200000_org_job_catalog_profiles_and_validation_modes.sql",
+		"20251221090000_org_reason_code_mode.sql",
+		"20251222120000_org_personnel_events.sql",
+		"20251227090000_org_valid_time_day_granularity.sql",
+		"20251228120000_org_eliminate_effective_on_end_on.sql",
+		"20251228140000_org_assignment_employment_status.sql",
+		"20251228150000_org_gap_free_constraint_triggers.sql",
+		"20260105090000_org_change_request_review.sql",
+		"20260106090000_org_change_request_approval_chains.sql",
+		"20260107090000_org_change_request_scheduling.sql",
+		"20260112090000_org_headcount_budgets.sql",
+		"20260113090000_org_role_assignment_audit.sql",
+		"20260114090000_org_delegations.sql",
+		"20260115090000_org_inheritance_breaks.sql",
+		"20260116090000_org_custom_attributes.sql",
+		"20260117090000_org_freeze_calendar.sql",
+	})
+	ensureOrgSettings(t, pool, tenantID)
+
+	withOrgRolloutEnabled(t, tenantID)
+	u := newTestOrgUser(tenantID)
+
+	c := &OrgAPIController{
+		org:            orgsvc.NewOrgService(persistence.NewOrgRepository()),
+		changeRequests: orgsvc.NewChangeRequestService(persistence.NewChangeRequestRepository()),
+	}
+	scheduler := orgscheduler.NewChangeRequestScheduler(orgsvc.NewChangeRequestExecutor(c.org, c.changeRequests), pool, orgscheduler.Options{})
+
+	reviewer := newTestOrgReviewer(tenantID)
+	actor := u
+	call := func(handler http.HandlerFunc, method, path, id, requestID string, body []byte) *httptest.ResponseRecorder {
+		t.Helper()
+		var req *http.Request
+		if body != nil {
+			req = newOrgAPIRequestWithBody(t, method, path, tenantID, actor, body)
+		} else {
+			req = newOrgAPIRequest(t, method, path, tenantID, actor)
+		}
+		req = req.WithContext(composables.WithPool(req.Context(), pool))
+		if id != "" {
+			req = mux.SetURLVars(req, map[string]string{"id": id})
+		}
+		req.Header.Set("X-Request-ID", requestID)
+		rr := httptest.NewRecorder()
+		handler(rr, req)
+		return rr
+	}
+
+	// Both requests create the same root, so whichever runs second fails on apply even
+	// though each passed preflight at approval time.
+	approvedRootRequest := func(requestID string) string {
+		t.Helper()
+		createRR := call(c.CreateChangeRequest, http.MethodPost, "/org/api/change-requests", "", requestID, mustJSON(t, map[string]any{
+			"payload": map[string]any{
+				"effective_date": "2025-01-01",
+				"commands": []any{
+					map[string]any{
+						"type":    "node.create",
+						"payload": map[string]any{"code": "ROOT", "name": "Company"},
+					},
+				},
+			},
+		}))
+		require.Equal(t, http.StatusCreated, createRR.Code)
+		var created changeRequestSummaryResponse
+		require.NoError(t, json.Unmarshal(createRR.Body.Bytes(), &created))
+
+		submitRR := call(c.SubmitChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":submit", created.ID, requestID+"-submit", nil)
+		require.Equal(t, http.StatusOK, submitRR.Code)
+		actor = reviewer
+		approveRR := call(c.ApproveChangeRequest, http.MethodPost, "/org/api/change-requests/"+created.ID+":approve", created.ID, requestID+"-approve", nil)
+		actor = u
+		require.Equal(t, http.StatusOK, approveRR.Code)
+		return created.ID
+	}
+	firstID := approvedRootRequest("req-org-cr-sched-1")
+	secondID := approvedRootRequest("req-org-cr-sched-2")
+
+	schedule := func(id string, at *time.Time) changeRequestDetailResponse {
+		t.Helper()
+		var value any
+		if at != nil {
+			value = at.UTC().Format(time.RFC3339)
+		}
+		rr := call(c.ScheduleChangeRequest, http.MethodPost, "/org/api/change-requests/"+id+":schedule", id, "req-org-cr-sched-set", mustJSON(t, map[string]any{"scheduled_for": value}))
+		require.Equal(t, http.StatusOK, rr.Code)
+		var out changeRequestDetailResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+		return out
+	}
+	get := func(id string) changeRequestDetailResponse {
+		t.Helper()
+		rr := call(c.GetChangeRequest, http.MethodGet, "/org/api/change-requests/"+id, id, "req-org-cr-sched-get", nil)
+		require.Equal(t, http.StatusOK, rr.Code)
+		var out changeRequestDetailResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &out))
+		return out
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	future := now.Add(24 * time.Hour)
+	scheduled := schedule(firstID, &future)
+	require.NotNil(t, scheduled.ScheduledFor)
+
+	// A request held for a future go-live cannot be applied early.
+	earlyRR := call(c.ApplyChangeRequest, http.MethodPost, "/org/api/change-requests/"+firstID+":apply", firstID, "req-org-cr-sched-early", nil)
+	require.Equal(t, http.StatusConflict, earlyRR.Code)
+	var apiErr coredtos.APIError
+	require.NoError(t, json.Unmarshal(earlyRR.Body.Bytes(), &apiErr))
+	require.Equal(t, "ORG_CHANGE_REQUEST_NOT_DUE", apiErr.Code)
+
+	ctx := context.Background()
+	require.NoError(t, scheduler.RunOnce(ctx, now))
+	require.Equal(t, "approved", get(firstID).Status)
+
+	firstAt := now.Add(-2 * time.Minute)
+	secondAt := now.Add(-time.Minute)
+	schedule(firstID, &firstAt)
+	schedule(secondID, &secondAt)
+	require.NoError(t, scheduler.RunOnce(ctx, now))
+
+	first := get(firstID)
+	require.Equal(t, "applied", first.Status)
+	require.Equal(t, int32(1), first.ExecutionAttempts)
+	require.NotNil(t, first.AuditRequestID)
+	require.Equal(t, "req-org-cr-sched-1", *first.AuditRequestID)
+	require.Empty(t, first.ExecutionError)
+	require.Equal(t, int64(1), countRows(t, ctx, pool, "org_nodes", tenantID))
+	var rootID string
+	require.NoError(t, pool.QueryRow(ctx, "SELECT id::text FROM org_nodes WHERE tenant_id = $1", tenantID).Scan(&rootID))
+	require.JSONEq(t, `{"results":[{"index":0,"type":"node.create","ok":true,"result":{"id":"`+rootID+`"}}]}`, string(first.ExecutionResult))
+
+	second := get(secondID)
+	require.Equal(t, "approved", second.Status)
+	require.Equal(t, int32(1), second.ExecutionAttempts)
+	require.NotEmpty(t, second.ExecutionError)
+	var execErr orgsvc.ChangeRequestExecutionError
+	require.NoError(t, json.Unmarshal(second.ExecutionError, &execErr))
+	require.NotNil(t, execErr.CommandIndex)
+	require.Equal(t, 0, *execErr.CommandIndex)
+	require.Equal(t, "node.create", execErr.CommandType)
+
+	// Failed requests are not retried until they are rescheduled.
+	require.NoError(t, scheduler.RunOnce(ctx, now))
+	require.Equal(t, int32(1), get(secondID).ExecutionAttempts)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
 	coreuser "github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
 	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	coreservices "github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/modules/org/presentation/mappers"
 	"github.com/iota-uz/iota-sdk/modules/org/presentation/templates/components/orgui"
 	orgtemplates "github.com/iota-uz/iota-sdk/modules/org/presentation/templates/pages/org"
@@ -37,6 +38,7 @@ import (
 type OrgUIController struct {
 	app      application.Application
 	org      *services.OrgService
+	users    *coreservices.UserService
 	basePath string
 }
 
@@ -44,6 +46,7 @@ func NewOrgUIController(app application.Application) application.Controller {
 	return &OrgUIController{
 		app:      app,
 		org:      app.Service(services.OrgService{}).(*services.OrgService),
+		users:    app.Service(coreservices.UserService{}).(*coreservices.UserService),
 		basePath: "/org",
 	}
 }
@@ -71,6 +74,24 @@ func (c *OrgUIController) Register(r *mux.Router) {
 	router.HandleFunc("/nodes", c.NodesPage).Methods(http.MethodGet)
 	router.HandleFunc("/assignments", c.AssignmentsPage).Methods(http.MethodGet)
 	router.HandleFunc("/hierarchies", c.HierarchyPartial).Methods(http.MethodGet)
+	router.HandleFunc("/diff", c.DiffPage).Methods(http.MethodGet)
+	router.HandleFunc("/scenarios", c.ScenariosPage).Methods(http.MethodGet)
+	router.HandleFunc("/scenarios", c.CreateScenarioUI).Methods(http.MethodPost)
+	router.HandleFunc("/scenarios/compare", c.CompareScenariosPage).Methods(http.MethodGet)
+	router.HandleFunc("/scenarios/{id}", c.ScenarioPage).Methods(http.MethodGet)
+	router.HandleFunc("/scenarios/{id}/commands", c.AddScenarioCommandUI).Methods(http.MethodPost)
+	router.HandleFunc("/scenarios/{id}/commands/{index}:remove", c.RemoveScenarioCommandUI).Methods(http.MethodPost)
+	router.HandleFunc("/scenarios/{id}:convert", c.ConvertScenarioUI).Methods(http.MethodPost)
+	router.HandleFunc("/change-requests", c.ChangeRequestsPage).Methods(http.MethodGet)
+	router.HandleFunc("/change-requests", c.CreateChangeRequestUI).Methods(http.MethodPost)
+	router.HandleFunc("/change-requests/{id}", c.ChangeRequestPage).Methods(http.MethodGet)
+	router.HandleFunc("/change-requests/{id}", c.UpdateChangeRequestUI).Methods(http.MethodPatch)
+	router.HandleFunc("/change-requests/{id}/commands", c.AddChangeRequestCommandUI).Methods(http.MethodPost)
+	router.HandleFunc("/change-requests/{id}/commands/{index}:remove", c.RemoveChangeRequestCommandUI).Methods(http.MethodPost)
+	router.HandleFunc("/change-requests/{id}:submit", c.SubmitChangeRequestUI).Methods(http.MethodPost)
+	router.HandleFunc("/change-requests/{id}:cancel", c.CancelChangeRequestUI).Methods(http.MethodPost)
+	router.HandleFunc("/change-requests/{id}:approve", c.ApproveChangeRequestUI).Methods(http.MethodPost)
+	router.HandleFunc("/change-requests/{id}:reject", c.RejectChangeRequestUI).Methods(http.MethodPost)
 	router.HandleFunc("/nodes/search", c.NodeSearchOptions).Methods(http.MethodGet)
 	router.HandleFunc("/nodes/new", c.NewNodeForm).Methods(http.MethodGet)
 	router.HandleFunc("/nodes", c.CreateNode).Methods(http.MethodPost)
@@ -81,7 +102,14 @@ func (c *OrgUIController) Register(r *mux.Router) {
 	router.HandleFunc("/nodes/{id}:delete-slice", c.DeleteNodeSliceAndStitch).Methods(http.MethodPost)
 	router.HandleFunc("/nodes/{id}:correct-move", c.CorrectMoveNode).Methods(http.MethodPost)
 	router.HandleFunc("/nodes/{id}:delete-edge-slice", c.DeleteEdgeSliceAndStitch).Methods(http.MethodPost)
-
+	router.HandleFunc("/nodes/{id}/roles", c.NodeRoles).Methods(http.MethodGet)
+	router.HandleFunc("/nodes/{id}/roles", c.CreateNodeRoleAssignmentUI).Methods(http.MethodPost)
+	router.HandleFunc("/nodes/{id}/roles/{assignment_id}:end", c.EndNodeRoleAssignmentUI).Methods(http.MethodPost)
+	router.HandleFunc("/nodes/{id}/roles/{assignment_id}:rescind", c.RescindNodeRoleAssignmentUI).Methods(http.MethodPost)
+
+	router.HandleFunc("/assignments/import", c.PersonnelEventImportPage).Methods(http.MethodGet)
+	router.HandleFunc("/assignments/import:preview", c.PreviewPersonnelEventImportUI).Methods(http.MethodPost)
+	router.HandleFunc("/assignments/import:apply", c.ApplyPersonnelEventImportUI).Methods(http.MethodPost)
 	router.HandleFunc("/assignments/form", c.AssignmentForm).Methods(http.MethodGet)
 	router.HandleFunc("/assignments", c.CreateAssignment).Methods(http.MethodPost)
 	router.HandleFunc("/assignments/{id}/transition", c.TransitionAssignmentForm).Methods(http.MethodGet)
@@ -94,10 +122,35 @@ func (c *OrgUIController) Register(r *mux.Router) {
 	router.HandleFunc("/positions/search", c.PositionSearchOptions).Methods(http.MethodGet)
 	router.HandleFunc("/positions/new", c.NewPositionForm).Methods(http.MethodGet)
 	router.HandleFunc("/positions", c.CreatePosition).Methods(http.MethodPost)
+	router.HandleFunc("/positions/bulk", c.PositionsBulkPage).Methods(http.MethodGet)
+	router.HandleFunc("/positions/bulk/create", c.BulkCreatePositionsUI).Methods(http.MethodPost)
+	router.HandleFunc("/positions/bulk/update", c.BulkUpdatePositionsUI).Methods(http.MethodPost)
+	router.HandleFunc("/positions/bulk/transfer", c.BulkTransferPositionsUI).Methods(http.MethodPost)
 	router.HandleFunc("/positions/{id}", c.PositionDetails).Methods(http.MethodGet)
 	router.HandleFunc("/positions/{id}/edit", c.EditPositionForm).Methods(http.MethodGet)
 	router.HandleFunc("/positions/{id}", c.UpdatePosition).Methods(http.MethodPatch)
 
+	router.HandleFunc("/audit/history", c.EntityHistory).Methods(http.MethodGet)
+
+	router.HandleFunc("/budgets", c.BudgetsPage).Methods(http.MethodGet)
+	router.HandleFunc("/budgets", c.SetHeadcountBudgetUI).Methods(http.MethodPost)
+	router.HandleFunc("/budgets/{id}:end", c.EndHeadcountBudgetUI).Methods(http.MethodPost)
+
+	router.HandleFunc("/inheritance", c.InheritancePage).Methods(http.MethodGet)
+	router.HandleFunc("/inheritance/rules", c.SetInheritanceRuleUI).Methods(http.MethodPost)
+	router.HandleFunc("/inheritance/rules/{id}:end", c.EndInheritanceRuleUI).Methods(http.MethodPost)
+	router.HandleFunc("/inheritance/rules/{id}:rescind", c.RescindInheritanceRuleUI).Methods(http.MethodPost)
+	router.HandleFunc("/inheritance/breaks", c.CreateInheritanceBreakUI).Methods(http.MethodPost)
+	router.HandleFunc("/inheritance/breaks/{id}:end", c.EndInheritanceBreakUI).Methods(http.MethodPost)
+	router.HandleFunc("/inheritance/breaks/{id}:rescind", c.RescindInheritanceBreakUI).Methods(http.MethodPost)
+
+	router.HandleFunc("/freeze-calendar", c.FreezeCalendarPage).Methods(http.MethodGet)
+	router.HandleFunc("/freeze-calendar", c.CreateFreezePeriodUI).Methods(http.MethodPost)
+	router.HandleFunc("/freeze-calendar/{id}", c.UpdateFreezePeriodUI).Methods(http.MethodPost)
+	router.HandleFunc("/freeze-calendar/{id}:rescind", c.RescindFreezePeriodUI).Methods(http.MethodPost)
+
+	router.HandleFunc("/trends", c.TrendsPage).Methods(http.MethodGet)
+
 	router.HandleFunc("/job-catalog", c.JobCatalogPage).Methods(http.MethodGet)
 	router.HandleFunc("/job-catalog/family-groups", c.CreateJobFamilyGroupUI).Methods(http.MethodPost)
 	router.HandleFunc("/job-catalog/family-groups/{id}", c.UpdateJobFamilyGroupUI).Methods(http.MethodPatch)
@@ -150,6 +203,8 @@ func (c *OrgUIController) NodesPage(w http.ResponseWriter, r *http.Request) {
 	ensureOrgPageCapabilities(r, orgEdgesAuthzObject, "write")
 	ensureOrgPageCapabilities(r, orgNodesAuthzObject, "admin")
 	ensureOrgPageCapabilities(r, orgEdgesAuthzObject, "admin")
+	ensureOrgPageCapabilities(r, orgAuditAuthzObject, "read")
+	ensureOrgPageCapabilities(r, orgRoleAssignmentsAuthzObject, "admin")
 
 	statusCode := http.StatusOK
 	var errs []string
@@ -163,6 +218,7 @@ func (c *OrgUIController) NodesPage(w http.ResponseWriter, r *http.Request) {
 		effectiveDate = time.Now().UTC()
 	}
 	effectiveDateStr := effectiveDate.UTC().Format("2006-01-02")
+	hierarchyType := hierarchyTypeFromQuery(r)
 
 	var selectedNodeID *uuid.UUID
 	if v := strings.TrimSpace(r.URL.Query().Get("node_id")); v != "" {
@@ -171,7 +227,7 @@ func (c *OrgUIController) NodesPage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	nodes, _, err := c.org.GetHierarchyAsOf(r.Context(), tenantID, "OrgUnit", effectiveDate)
+	nodes, _, err := c.org.GetHierarchyAsOf(r.Context(), tenantID, hierarchyType, effectiveDate)
 	if err != nil {
 		errs = append(errs, err.Error())
 	}
@@ -217,7 +273,7 @@ func (c *OrgUIController) NodesPage(w http.ResponseWriter, r *http.Request) {
 			nodeRecords = nodeSliceRecordsFromRows(slices, effectiveDate)
 		}
 
-		edges, err := c.org.ListEdgesTimelineAsChild(r.Context(), tenantID, "OrgUnit", *selectedNodeID)
+		edges, err := c.org.ListEdgesTimelineAsChild(r.Context(), tenantID, hierarchyType, *selectedNodeID)
 		if err != nil {
 			errs = append(errs, err.Error())
 		} else {
@@ -227,6 +283,7 @@ func (c *OrgUIController) NodesPage(w http.ResponseWriter, r *http.Request) {
 
 	props := orgtemplates.NodesPageProps{
 		EffectiveDate: effectiveDateStr,
+		HierarchyType: hierarchyType,
 		Tree:          tree,
 		SelectedNode:  selected,
 		NodeRecords:   nodeRecords,
@@ -267,7 +324,8 @@ func (c *OrgUIController) HierarchyPartial(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
-	nodes, _, err := c.org.GetHierarchyAsOf(r.Context(), tenantID, "OrgUnit", effectiveDate)
+	hierarchyType := hierarchyTypeFromQuery(r)
+	nodes, _, err := c.org.GetHierarchyAsOf(r.Context(), tenantID, hierarchyType, effectiveDate)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -277,6 +335,7 @@ func (c *OrgUIController) HierarchyPartial(w http.ResponseWriter, r *http.Reques
 		Tree:          tree,
 		EffectiveDate: effectiveDateStr,
 		SwapOOB:       true,
+		NodePushURL:   orgtemplates.NodesPushURL(hierarchyType),
 	}), templ.WithStreaming()).ServeHTTP(w, r)
 }
 
@@ -294,6 +353,7 @@ func (c *OrgUIController) AssignmentsPage(w http.ResponseWriter, r *http.Request
 	}
 	ensureOrgPageCapabilities(r, orgAssignmentsAuthzObject, "assign")
 	ensureOrgPageCapabilities(r, orgPositionsAuthzObject, "read")
+	ensureOrgPageCapabilities(r, orgAuditAuthzObject, "read")
 
 	statusCode := http.StatusOK
 	var pageErrs []string
@@ -473,6 +533,7 @@ func (c *OrgUIController) PositionsPage(w http.ResponseWriter, r *http.Request)
 	}
 	ensureOrgPageCapabilities(r, orgAssignmentsAuthzObject, "read")
 	ensureOrgPageCapabilities(r, orgPositionsAuthzObject, "write", "admin")
+	ensureOrgPageCapabilities(r, orgAuditAuthzObject, "read")
 
 	statusCode := http.StatusOK
 	q, qErrs := positionsQueryFromRequest(r)
@@ -565,6 +626,7 @@ func (c *OrgUIController) PositionsPanel(w http.ResponseWriter, r *http.Request)
 	}
 	ensureOrgPageCapabilities(r, orgAssignmentsAuthzObject, "read")
 	ensureOrgPageCapabilities(r, orgPositionsAuthzObject, "write", "admin")
+	ensureOrgPageCapabilities(r, orgAuditAuthzObject, "read")
 
 	statusCode := http.StatusOK
 	q, qErrs := positionsQueryFromRequest(r)
@@ -613,6 +675,7 @@ func (c *OrgUIController) PositionDetails(w http.ResponseWriter, r *http.Request
 		return
 	}
 	ensureOrgPageCapabilities(r, orgPositionsAuthzObject, "write", "admin")
+	ensureOrgPageCapabilities(r, orgAuditAuthzObject, "read")
 
 	positionID, err := uuid.Parse(mux.Vars(r)["id"])
 	if err != nil {
@@ -1954,22 +2017,23 @@ func (c *OrgUIController) NewPositionForm(w http.ResponseWriter, r *http.Request
 		nodeLabel = c.orgNodeLabelFor(r, tenantID, parsed, effectiveDate)
 	}
 	templ.Handler(orgui.PositionForm(orgui.PositionFormProps{
-		Mode:            orgui.PositionFormCreate,
-		EffectiveDate:   effectiveDateStr,
-		NodeID:          nodeID,
-		Code:            "",
-		OrgNodeID:       nodeID,
-		OrgNodeLabel:    nodeLabel,
-		LifecycleStatus: "active",
-		PositionType:    "regular",
-		EmploymentType:  "full_time",
-		CapacityFTE:     "1.00",
-		ReasonCode:      "create",
-		JobProfileID:    "",
-		JobProfileLabel: "",
-		JobLevelCode:    "",
-		JobLevelLabel:   "",
-		Errors:          map[string]string{},
+		Mode:             orgui.PositionFormCreate,
+		EffectiveDate:    effectiveDateStr,
+		NodeID:           nodeID,
+		Code:             "",
+		OrgNodeID:        nodeID,
+		OrgNodeLabel:     nodeLabel,
+		LifecycleStatus:  "active",
+		PositionType:     "regular",
+		EmploymentType:   "full_time",
+		CapacityFTE:      "1.00",
+		ReasonCode:       "create",
+		JobProfileID:     "",
+		JobProfileLabel:  "",
+		JobLevelCode:     "",
+		JobLevelLabel:    "",
+		Errors:           map[string]string{},
+		CustomAttributes: c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityPosition, effectiveDate, nil),
 	}), templ.WithStreaming()).ServeHTTP(w, r)
 }
 
@@ -1987,6 +2051,7 @@ func (c *OrgUIController) CreatePosition(w http.ResponseWriter, r *http.Request)
 	}
 	ensureOrgPageCapabilities(r, orgAssignmentsAuthzObject, "read")
 	ensureOrgPageCapabilities(r, orgPositionsAuthzObject, "read")
+	ensureOrgPageCapabilities(r, orgAuditAuthzObject, "read")
 
 	effectiveDate, err := effectiveDateFromWriteForm(r)
 	if err != nil || effectiveDate.IsZero() {
@@ -2067,24 +2132,25 @@ func (c *OrgUIController) CreatePosition(w http.ResponseWriter, r *http.Request)
 		}
 		_, _, levelLabel := c.jobCatalogLabelsFor(r, tenantID, effectiveDate, "", "", jobLevelCode)
 		templ.Handler(orgui.PositionForm(orgui.PositionFormProps{
-			Mode:            orgui.PositionFormCreate,
-			EffectiveDate:   effectiveDateStr,
-			NodeID:          orgNodeID.String(),
-			Code:            code,
-			OrgNodeID:       orgNodeID.String(),
-			OrgNodeLabel:    nodeLabel,
-			Title:           titleRaw,
-			LifecycleStatus: lifecycle,
-			PositionType:    positionType,
-			EmploymentType:  employmentType,
-			CapacityFTE:     fmt.Sprintf("%.2f", capacity),
-			ReasonCode:      reasonCode,
-			ReasonNote:      reasonNoteRaw,
-			JobProfileID:    jobProfileIDRaw,
-			JobProfileLabel: jobProfileLabel,
-			JobLevelCode:    jobLevelCode,
-			JobLevelLabel:   levelLabel,
-			Errors:          fieldErrs,
+			Mode:             orgui.PositionFormCreate,
+			EffectiveDate:    effectiveDateStr,
+			NodeID:           orgNodeID.String(),
+			Code:             code,
+			OrgNodeID:        orgNodeID.String(),
+			OrgNodeLabel:     nodeLabel,
+			Title:            titleRaw,
+			LifecycleStatus:  lifecycle,
+			PositionType:     positionType,
+			EmploymentType:   employmentType,
+			CapacityFTE:      fmt.Sprintf("%.2f", capacity),
+			ReasonCode:       reasonCode,
+			ReasonNote:       reasonNoteRaw,
+			JobProfileID:     jobProfileIDRaw,
+			JobProfileLabel:  jobProfileLabel,
+			JobLevelCode:     jobLevelCode,
+			JobLevelLabel:    levelLabel,
+			Errors:           fieldErrs,
+			CustomAttributes: c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityPosition, effectiveDate, nil),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	}
@@ -2096,19 +2162,20 @@ func (c *OrgUIController) CreatePosition(w http.ResponseWriter, r *http.Request)
 		jobLevelCodePtr = &jobLevelCode
 	}
 	res, err := c.org.CreatePosition(r.Context(), tenantID, requestID, initiatorID, services.CreatePositionInput{
-		Code:            code,
-		OrgNodeID:       orgNodeID,
-		EffectiveDate:   effectiveDate,
-		Title:           title,
-		LifecycleStatus: lifecycle,
-		PositionType:    positionType,
-		EmploymentType:  employmentType,
-		CapacityFTE:     capacity,
-		ReportsToID:     reportsTo,
-		JobProfileID:    jobProfileID,
-		JobLevelCode:    jobLevelCodePtr,
-		ReasonCode:      reasonCode,
-		ReasonNote:      reasonNote,
+		Code:             code,
+		OrgNodeID:        orgNodeID,
+		EffectiveDate:    effectiveDate,
+		Title:            title,
+		LifecycleStatus:  lifecycle,
+		PositionType:     positionType,
+		EmploymentType:   employmentType,
+		CapacityFTE:      capacity,
+		ReportsToID:      reportsTo,
+		JobProfileID:     jobProfileID,
+		JobLevelCode:     jobLevelCodePtr,
+		ReasonCode:       reasonCode,
+		ReasonNote:       reasonNote,
+		CustomAttributes: customAttributesFromForm(r),
 	})
 	if err != nil {
 		formErr, _, statusCode := mapServiceErrorToForm(err)
@@ -2121,25 +2188,26 @@ func (c *OrgUIController) CreatePosition(w http.ResponseWriter, r *http.Request)
 		}
 		_, _, levelLabel := c.jobCatalogLabelsFor(r, tenantID, effectiveDate, "", "", jobLevelCode)
 		templ.Handler(orgui.PositionForm(orgui.PositionFormProps{
-			Mode:            orgui.PositionFormCreate,
-			EffectiveDate:   effectiveDateStr,
-			NodeID:          orgNodeID.String(),
-			Code:            code,
-			OrgNodeID:       orgNodeID.String(),
-			OrgNodeLabel:    nodeLabel,
-			Title:           titleRaw,
-			LifecycleStatus: lifecycle,
-			PositionType:    positionType,
-			EmploymentType:  employmentType,
-			CapacityFTE:     fmt.Sprintf("%.2f", capacity),
-			ReasonCode:      reasonCode,
-			ReasonNote:      reasonNoteRaw,
-			JobProfileID:    jobProfileIDRaw,
-			JobProfileLabel: jobProfileLabel,
-			JobLevelCode:    jobLevelCode,
-			JobLevelLabel:   levelLabel,
-			Errors:          map[string]string{},
-			FormError:       formErr,
+			Mode:             orgui.PositionFormCreate,
+			EffectiveDate:    effectiveDateStr,
+			NodeID:           orgNodeID.String(),
+			Code:             code,
+			OrgNodeID:        orgNodeID.String(),
+			OrgNodeLabel:     nodeLabel,
+			Title:            titleRaw,
+			LifecycleStatus:  lifecycle,
+			PositionType:     positionType,
+			EmploymentType:   employmentType,
+			CapacityFTE:      fmt.Sprintf("%.2f", capacity),
+			ReasonCode:       reasonCode,
+			ReasonNote:       reasonNoteRaw,
+			JobProfileID:     jobProfileIDRaw,
+			JobProfileLabel:  jobProfileLabel,
+			JobLevelCode:     jobLevelCode,
+			JobLevelLabel:    levelLabel,
+			Errors:           map[string]string{},
+			FormError:        formErr,
+			CustomAttributes: c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityPosition, effectiveDate, nil),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	}
@@ -2256,27 +2324,28 @@ func (c *OrgUIController) EditPositionForm(w http.ResponseWriter, r *http.Reques
 	jobProfileLabel := c.jobProfileLabelFor(r, tenantID, effectiveDate, row.JobProfileID)
 	_, _, levelLabel := c.jobCatalogLabelsFor(r, tenantID, effectiveDate, "", "", jobLevelCode)
 	templ.Handler(orgui.PositionForm(orgui.PositionFormProps{
-		Mode:            orgui.PositionFormEdit,
-		EffectiveDate:   effectiveDateStr,
-		NodeID:          strings.TrimSpace(param(r, "node_id")),
-		PositionID:      positionID.String(),
-		Code:            row.Code,
-		OrgNodeID:       row.OrgNodeID.String(),
-		OrgNodeLabel:    nodeLabel,
-		Title:           title,
-		LifecycleStatus: row.LifecycleStatus,
-		PositionType:    positionType,
-		EmploymentType:  employmentType,
-		CapacityFTE:     fmt.Sprintf("%.2f", row.CapacityFTE),
-		ReportsToID:     reportsToID,
-		ReportsToLabel:  reportsToLabel,
-		ReasonCode:      "update",
-		ReasonNote:      "",
-		JobProfileID:    row.JobProfileID.String(),
-		JobProfileLabel: jobProfileLabel,
-		JobLevelCode:    jobLevelCode,
-		JobLevelLabel:   levelLabel,
-		Errors:          map[string]string{},
+		Mode:             orgui.PositionFormEdit,
+		EffectiveDate:    effectiveDateStr,
+		NodeID:           strings.TrimSpace(param(r, "node_id")),
+		PositionID:       positionID.String(),
+		Code:             row.Code,
+		OrgNodeID:        row.OrgNodeID.String(),
+		OrgNodeLabel:     nodeLabel,
+		Title:            title,
+		LifecycleStatus:  row.LifecycleStatus,
+		PositionType:     positionType,
+		EmploymentType:   employmentType,
+		CapacityFTE:      fmt.Sprintf("%.2f", row.CapacityFTE),
+		ReportsToID:      reportsToID,
+		ReportsToLabel:   reportsToLabel,
+		ReasonCode:       "update",
+		ReasonNote:       "",
+		JobProfileID:     row.JobProfileID.String(),
+		JobProfileLabel:  jobProfileLabel,
+		JobLevelCode:     jobLevelCode,
+		JobLevelLabel:    levelLabel,
+		Errors:           map[string]string{},
+		CustomAttributes: c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityPosition, effectiveDate, row.CustomAttributes),
 	}), templ.WithStreaming()).ServeHTTP(w, r)
 }
 
@@ -2294,6 +2363,7 @@ func (c *OrgUIController) UpdatePosition(w http.ResponseWriter, r *http.Request)
 	}
 	ensureOrgPageCapabilities(r, orgAssignmentsAuthzObject, "read")
 	ensureOrgPageCapabilities(r, orgPositionsAuthzObject, "read")
+	ensureOrgPageCapabilities(r, orgAuditAuthzObject, "read")
 
 	effectiveDate, err := effectiveDateFromWriteForm(r)
 	if err != nil || effectiveDate.IsZero() {
@@ -2390,27 +2460,28 @@ func (c *OrgUIController) UpdatePosition(w http.ResponseWriter, r *http.Request)
 		}
 		_, _, levelLabel := c.jobCatalogLabelsFor(r, tenantID, effectiveDate, "", "", jobLevelCodeRaw)
 		templ.Handler(orgui.PositionForm(orgui.PositionFormProps{
-			Mode:            orgui.PositionFormEdit,
-			EffectiveDate:   effectiveDateStr,
-			NodeID:          strings.TrimSpace(param(r, "node_id")),
-			PositionID:      positionID.String(),
-			Code:            strings.TrimSpace(param(r, "code")),
-			OrgNodeID:       orgNodeID.String(),
-			OrgNodeLabel:    nodeLabel,
-			Title:           titleRaw,
-			LifecycleStatus: lifecycleRaw,
-			PositionType:    positionTypeRaw,
-			EmploymentType:  employmentTypeRaw,
-			CapacityFTE:     capacityRaw,
-			ReportsToID:     strings.TrimSpace(param(r, "reports_to_position_id")),
-			ReportsToLabel:  reportsToLabel,
-			ReasonCode:      reasonCode,
-			ReasonNote:      reasonNoteRaw,
-			JobProfileID:    jobProfileIDRaw,
-			JobProfileLabel: jobProfileLabel,
-			JobLevelCode:    jobLevelCodeRaw,
-			JobLevelLabel:   levelLabel,
-			Errors:          fieldErrs,
+			Mode:             orgui.PositionFormEdit,
+			EffectiveDate:    effectiveDateStr,
+			NodeID:           strings.TrimSpace(param(r, "node_id")),
+			PositionID:       positionID.String(),
+			Code:             strings.TrimSpace(param(r, "code")),
+			OrgNodeID:        orgNodeID.String(),
+			OrgNodeLabel:     nodeLabel,
+			Title:            titleRaw,
+			LifecycleStatus:  lifecycleRaw,
+			PositionType:     positionTypeRaw,
+			EmploymentType:   employmentTypeRaw,
+			CapacityFTE:      capacityRaw,
+			ReportsToID:      strings.TrimSpace(param(r, "reports_to_position_id")),
+			ReportsToLabel:   reportsToLabel,
+			ReasonCode:       reasonCode,
+			ReasonNote:       reasonNoteRaw,
+			JobProfileID:     jobProfileIDRaw,
+			JobProfileLabel:  jobProfileLabel,
+			JobLevelCode:     jobLevelCodeRaw,
+			JobLevelLabel:    levelLabel,
+			Errors:           fieldErrs,
+			CustomAttributes: c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityPosition, effectiveDate, nil),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	}
@@ -2422,19 +2493,20 @@ func (c *OrgUIController) UpdatePosition(w http.ResponseWriter, r *http.Request)
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
 	requestID := ensureRequestID(r)
 	_, err = c.org.UpdatePosition(r.Context(), tenantID, requestID, initiatorID, services.UpdatePositionInput{
-		PositionID:      positionID,
-		EffectiveDate:   effectiveDate,
-		ReasonCode:      reasonCode,
-		ReasonNote:      reasonNote,
-		OrgNodeID:       &orgNodeID,
-		Title:           title,
-		LifecycleStatus: lifecycle,
-		PositionType:    &positionType,
-		EmploymentType:  &employmentType,
-		JobProfileID:    &jobProfileID,
-		JobLevelCode:    &jobLevelCode,
-		CapacityFTE:     capacity,
-		ReportsToID:     reportsTo,
+		PositionID:       positionID,
+		EffectiveDate:    effectiveDate,
+		ReasonCode:       reasonCode,
+		ReasonNote:       reasonNote,
+		OrgNodeID:        &orgNodeID,
+		Title:            title,
+		LifecycleStatus:  lifecycle,
+		PositionType:     &positionType,
+		EmploymentType:   &employmentType,
+		JobProfileID:     &jobProfileID,
+		JobLevelCode:     &jobLevelCode,
+		CapacityFTE:      capacity,
+		ReportsToID:      reportsTo,
+		CustomAttributes: customAttributesFromForm(r),
 	})
 	if err != nil {
 		formErr, _, statusCode := mapServiceErrorToForm(err)
@@ -2454,28 +2526,29 @@ func (c *OrgUIController) UpdatePosition(w http.ResponseWriter, r *http.Request)
 		}
 		_, _, levelLabel := c.jobCatalogLabelsFor(r, tenantID, effectiveDate, "", "", jobLevelCodeRaw)
 		templ.Handler(orgui.PositionForm(orgui.PositionFormProps{
-			Mode:            orgui.PositionFormEdit,
-			EffectiveDate:   effectiveDateStr,
-			NodeID:          strings.TrimSpace(param(r, "node_id")),
-			PositionID:      positionID.String(),
-			Code:            strings.TrimSpace(param(r, "code")),
-			OrgNodeID:       orgNodeID.String(),
-			OrgNodeLabel:    nodeLabel,
-			Title:           titleRaw,
-			LifecycleStatus: lifecycleRaw,
-			PositionType:    positionTypeRaw,
-			EmploymentType:  employmentTypeRaw,
-			CapacityFTE:     capacityRaw,
-			ReportsToID:     reportsToIDRaw,
-			ReportsToLabel:  reportsToLabel,
-			ReasonCode:      reasonCode,
-			ReasonNote:      reasonNoteRaw,
-			JobProfileID:    jobProfileIDRaw,
-			JobProfileLabel: jobProfileLabel,
-			JobLevelCode:    jobLevelCodeRaw,
-			JobLevelLabel:   levelLabel,
-			Errors:          map[string]string{},
-			FormError:       formErr,
+			Mode:             orgui.PositionFormEdit,
+			EffectiveDate:    effectiveDateStr,
+			NodeID:           strings.TrimSpace(param(r, "node_id")),
+			PositionID:       positionID.String(),
+			Code:             strings.TrimSpace(param(r, "code")),
+			OrgNodeID:        orgNodeID.String(),
+			OrgNodeLabel:     nodeLabel,
+			Title:            titleRaw,
+			LifecycleStatus:  lifecycleRaw,
+			PositionType:     positionTypeRaw,
+			EmploymentType:   employmentTypeRaw,
+			CapacityFTE:      capacityRaw,
+			ReportsToID:      reportsToIDRaw,
+			ReportsToLabel:   reportsToLabel,
+			ReasonCode:       reasonCode,
+			ReasonNote:       reasonNoteRaw,
+			JobProfileID:     jobProfileIDRaw,
+			JobProfileLabel:  jobProfileLabel,
+			JobLevelCode:     jobLevelCodeRaw,
+			JobLevelLabel:    levelLabel,
+			Errors:           map[string]string{},
+			FormError:        formErr,
+			CustomAttributes: c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityPosition, effectiveDate, nil),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	}
@@ -2895,12 +2968,13 @@ func (c *OrgUIController) CreateAssignment(w http.ResponseWriter, r *http.Reques
 	pernr := strings.TrimSpace(r.FormValue("pernr"))
 	orgNodeRaw := strings.TrimSpace(r.FormValue("org_node_id"))
 	positionRaw := strings.TrimSpace(r.FormValue("position_id"))
+	reasonCode := strings.TrimSpace(r.FormValue("reason_code"))
 
 	var orgNodeID *uuid.UUID
 	fieldErrs := map[string]string{}
 	if eventType == "" {
 		fieldErrs["event_type"] = "required"
-	} else if eventType != "hire" {
+	} else if eventType != services.PersonnelEventHire && eventType != services.PersonnelEventRehire {
 		fieldErrs["event_type"] = "invalid"
 	}
 	if pernr == "" {
@@ -2948,6 +3022,7 @@ func (c *OrgUIController) CreateAssignment(w http.ResponseWriter, r *http.Reques
 			OrgNodeLabel:   orgNodeLabel,
 			PositionID:     positionRaw,
 			PositionLabel:  positionLabel,
+			ReasonCode:     reasonCode,
 			IncludeSummary: includeSummary,
 			Errors:         fieldErrs,
 		}), templ.WithStreaming()).ServeHTTP(w, r)
@@ -2956,12 +3031,23 @@ func (c *OrgUIController) CreateAssignment(w http.ResponseWriter, r *http.Reques
 
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
 	requestID := ensureRequestID(r)
-	_, err = c.org.HirePersonnelEvent(r.Context(), tenantID, requestID, initiatorID, services.HirePersonnelEventInput{
-		Pernr:         pernr,
-		OrgNodeID:     *orgNodeID,
-		PositionID:    positionID,
-		EffectiveDate: effectiveDate,
-	})
+	if eventType == services.PersonnelEventRehire {
+		_, err = c.org.RehirePersonnelEvent(r.Context(), tenantID, requestID, initiatorID, services.RehirePersonnelEventInput{
+			Pernr:         pernr,
+			OrgNodeID:     *orgNodeID,
+			PositionID:    positionID,
+			EffectiveDate: effectiveDate,
+			ReasonCode:    reasonCode,
+		})
+	} else {
+		_, err = c.org.HirePersonnelEvent(r.Context(), tenantID, requestID, initiatorID, services.HirePersonnelEventInput{
+			Pernr:         pernr,
+			OrgNodeID:     *orgNodeID,
+			PositionID:    positionID,
+			EffectiveDate: effectiveDate,
+			ReasonCode:    reasonCode,
+		})
+	}
 	if err != nil {
 		formErr, _, statusCode := mapServiceErrorToForm(err)
 		w.WriteHeader(statusCode)
@@ -2984,6 +3070,7 @@ func (c *OrgUIController) CreateAssignment(w http.ResponseWriter, r *http.Reques
 			OrgNodeLabel:   orgNodeLabel,
 			PositionID:     positionRaw,
 			PositionLabel:  positionLabel,
+			ReasonCode:     reasonCode,
 			IncludeSummary: includeSummary,
 			Errors:         map[string]string{},
 			FormError:      formErr,
@@ -3203,12 +3290,17 @@ func (c *OrgUIController) TransitionAssignment(w http.ResponseWriter, r *http.Re
 	orgNodeRaw := strings.TrimSpace(r.FormValue("org_node_id"))
 	positionRaw := strings.TrimSpace(r.FormValue("position_id"))
 	reasonNote := strings.TrimSpace(r.FormValue("reason_note"))
+	reasonCode := strings.TrimSpace(r.FormValue("reason_code"))
+	allocatedFTERaw := strings.TrimSpace(r.FormValue("allocated_fte"))
 
 	var orgNodeID *uuid.UUID
 	fieldErrs := map[string]string{}
-	if eventType == "" {
+	switch eventType {
+	case "":
 		fieldErrs["event_type"] = "required"
-	} else if eventType != "transfer" && eventType != "termination" {
+	case services.PersonnelEventTransfer, services.PersonnelEventTermination, services.PersonnelEventPromotion,
+		services.PersonnelEventLeave, services.PersonnelEventReturn, services.PersonnelEventFTEChange:
+	default:
 		fieldErrs["event_type"] = "invalid"
 	}
 	if pernr == "" {
@@ -3217,6 +3309,20 @@ func (c *OrgUIController) TransitionAssignment(w http.ResponseWriter, r *http.Re
 	if strings.TrimSpace(eventType) == "transfer" && orgNodeRaw == "" {
 		fieldErrs["org_node_id"] = "required"
 	}
+	if eventType == services.PersonnelEventPromotion && positionRaw == "" {
+		fieldErrs["position_id"] = "required"
+	}
+	var allocatedFTE float64
+	if allocatedFTERaw != "" {
+		parsed, err := strconv.ParseFloat(allocatedFTERaw, 64)
+		if err != nil || parsed <= 0 {
+			fieldErrs["allocated_fte"] = "invalid"
+		} else {
+			allocatedFTE = parsed
+		}
+	} else if eventType == services.PersonnelEventFTEChange {
+		fieldErrs["allocated_fte"] = "required"
+	}
 	if orgNodeRaw != "" {
 		parsed, err := uuid.Parse(orgNodeRaw)
 		if err != nil {
@@ -3258,6 +3364,8 @@ func (c *OrgUIController) TransitionAssignment(w http.ResponseWriter, r *http.Re
 			OrgNodeLabel:   orgNodeLabel,
 			PositionID:     positionRaw,
 			PositionLabel:  positionLabel,
+			AllocatedFTE:   allocatedFTERaw,
+			ReasonCode:     reasonCode,
 			IncludeSummary: includeSummary,
 			Errors:         fieldErrs,
 		}), templ.WithStreaming()).ServeHTTP(w, r)
@@ -3271,14 +3379,45 @@ func (c *OrgUIController) TransitionAssignment(w http.ResponseWriter, r *http.Re
 
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
 	requestID := ensureRequestID(r)
-	_, err = c.org.TransitionAssignment(r.Context(), tenantID, requestID, initiatorID, services.TransitionAssignmentInput{
-		AssignmentID:  assignmentID,
-		EventType:     eventType,
-		EffectiveDate: effectiveDate,
-		OrgNodeID:     orgNodeID,
-		PositionID:    positionID,
-		ReasonNote:    reasonNotePtr,
-	})
+	switch eventType {
+	case services.PersonnelEventPromotion:
+		_, err = c.org.PromotionPersonnelEvent(r.Context(), tenantID, requestID, initiatorID, services.PromotionPersonnelEventInput{
+			Pernr:         pernr,
+			PositionID:    *positionID,
+			EffectiveDate: effectiveDate,
+			AllocatedFTE:  allocatedFTE,
+			ReasonCode:    reasonCode,
+		})
+	case services.PersonnelEventLeave:
+		_, err = c.org.LeavePersonnelEvent(r.Context(), tenantID, requestID, initiatorID, services.LeavePersonnelEventInput{
+			Pernr:         pernr,
+			EffectiveDate: effectiveDate,
+			ReasonCode:    reasonCode,
+		})
+	case services.PersonnelEventReturn:
+		_, err = c.org.ReturnPersonnelEvent(r.Context(), tenantID, requestID, initiatorID, services.ReturnPersonnelEventInput{
+			Pernr:         pernr,
+			EffectiveDate: effectiveDate,
+			ReasonCode:    reasonCode,
+		})
+	case services.PersonnelEventFTEChange:
+		_, err = c.org.FTEChangePersonnelEvent(r.Context(), tenantID, requestID, initiatorID, services.FTEChangePersonnelEventInput{
+			Pernr:         pernr,
+			EffectiveDate: effectiveDate,
+			AllocatedFTE:  allocatedFTE,
+			ReasonCode:    reasonCode,
+		})
+	default:
+		_, err = c.org.TransitionAssignment(r.Context(), tenantID, requestID, initiatorID, services.TransitionAssignmentInput{
+			AssignmentID:  assignmentID,
+			EventType:     eventType,
+			EffectiveDate: effectiveDate,
+			OrgNodeID:     orgNodeID,
+			PositionID:    positionID,
+			ReasonCode:    reasonCode,
+			ReasonNote:    reasonNotePtr,
+		})
+	}
 	if err != nil {
 		formErr, _, statusCode := mapServiceErrorToForm(err)
 		w.WriteHeader(statusCode)
@@ -3303,6 +3442,8 @@ func (c *OrgUIController) TransitionAssignment(w http.ResponseWriter, r *http.Re
 			OrgNodeLabel:   orgNodeLabel,
 			PositionID:     positionRaw,
 			PositionLabel:  positionLabel,
+			AllocatedFTE:   allocatedFTERaw,
+			ReasonCode:     reasonCode,
 			IncludeSummary: includeSummary,
 			Errors:         map[string]string{},
 			FormError:      formErr,
@@ -3550,6 +3691,7 @@ func (c *OrgUIController) UpdateAssignment(w http.ResponseWriter, r *http.Reques
 func (c *OrgUIController) writeAssignmentsFormWithOOBTimeline(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, effectiveDateStr string, pernr string, timeline *viewmodels.OrgAssignmentsTimeline) {
 	ensureOrgPageCapabilities(r, orgAssignmentsAuthzObject, "read")
 	ensureOrgPageCapabilities(r, orgPositionsAuthzObject, "read")
+	ensureOrgPageCapabilities(r, orgAuditAuthzObject, "read")
 
 	component := templ.ComponentFunc(func(ctx context.Context, ww io.Writer) error {
 		if err := orgtemplates.AssignmentsHeader(orgtemplates.AssignmentsHeaderProps{
@@ -3611,7 +3753,7 @@ func (c *OrgUIController) NodeSearchOptions(w http.ResponseWriter, r *http.Reque
 	}
 
 	q := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("q")))
-	nodes, _, err := c.org.GetHierarchyAsOf(r.Context(), tenantID, "OrgUnit", effectiveDate)
+	nodes, _, err := c.org.GetHierarchyAsOf(r.Context(), tenantID, hierarchyTypeFromQuery(r), effectiveDate)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -3676,13 +3818,16 @@ func (c *OrgUIController) NewNodeForm(w http.ResponseWriter, r *http.Request) {
 		parentLabel = c.orgNodeLabelFor(r, tenantID, *parentID, effectiveDate)
 	}
 
+	hierarchyType := hierarchyTypeFromQuery(r)
 	templ.Handler(orgui.NodeForm(orgui.NodeFormProps{
 		Mode:                 orgui.NodeFormCreate,
 		EffectiveDate:        effectiveDateStr,
 		ParentID:             parentID,
 		ParentLabel:          parentLabel,
 		Errors:               map[string]string{},
-		SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s", effectiveDateStr),
+		SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s&hierarchy_type=%s", effectiveDateStr, hierarchyType),
+		HierarchyType:        hierarchyType,
+		CustomAttributes:     c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityNode, effectiveDate, nil),
 	}), templ.WithStreaming()).ServeHTTP(w, r)
 }
 
@@ -3729,6 +3874,7 @@ func (c *OrgUIController) NodePanel(w http.ResponseWriter, r *http.Request) {
 			Node:                 details,
 			Errors:               map[string]string{},
 			SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s", effectiveDateStr),
+			CustomAttributes:     c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityNode, effectiveDate, details.CustomAttributes),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	case "move":
@@ -3763,6 +3909,7 @@ func (c *OrgUIController) NodePanel(w http.ResponseWriter, r *http.Request) {
 			Node:                 details,
 			Errors:               map[string]string{},
 			SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s", effectiveDateStr),
+			CustomAttributes:     c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityNode, effectiveDate, details.CustomAttributes),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	case "delete-slice":
@@ -3808,7 +3955,7 @@ func (c *OrgUIController) NodePanel(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
-		edgeRows, err := c.org.ListEdgesTimelineAsChild(r.Context(), tenantID, "OrgUnit", nodeID)
+		edgeRows, err := c.org.ListEdgesTimelineAsChild(r.Context(), tenantID, nodeDetailsHierarchyType(details), nodeID)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -3835,6 +3982,7 @@ func (c *OrgUIController) NodePanel(w http.ResponseWriter, r *http.Request) {
 		ensureOrgPageCapabilities(r, orgEdgesAuthzObject, "write")
 		ensureOrgPageCapabilities(r, orgNodesAuthzObject, "admin")
 		ensureOrgPageCapabilities(r, orgEdgesAuthzObject, "admin")
+		ensureOrgPageCapabilities(r, orgAuditAuthzObject, "read")
 		c.writeNodePanelWithOOBTree(w, r, tenantID, nodeID, effectiveDateStr, effectiveDate)
 		return
 	}
@@ -3869,6 +4017,10 @@ func (c *OrgUIController) CreateNode(w http.ResponseWriter, r *http.Request) {
 	name := strings.TrimSpace(r.FormValue("name"))
 	status := strings.TrimSpace(r.FormValue("status"))
 	displayOrder, _ := strconv.Atoi(strings.TrimSpace(r.FormValue("display_order")))
+	hierarchyType := strings.TrimSpace(r.FormValue("hierarchy_type"))
+	if !services.IsValidHierarchyType(hierarchyType) {
+		hierarchyType = services.HierarchyTypeOrgUnit
+	}
 
 	var parentID *uuid.UUID
 	if v := strings.TrimSpace(r.FormValue("parent_id")); v != "" {
@@ -3891,7 +4043,9 @@ func (c *OrgUIController) CreateNode(w http.ResponseWriter, r *http.Request) {
 			ParentID:             parentID,
 			ParentLabel:          parentLabel,
 			Errors:               map[string]string{"i18n_names": i18nErr.Error()},
-			SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s", effectiveDateStr),
+			SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s&hierarchy_type=%s", effectiveDateStr, hierarchyType),
+			HierarchyType:        hierarchyType,
+			CustomAttributes:     c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityNode, effectiveDate, nil),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	}
@@ -3899,13 +4053,15 @@ func (c *OrgUIController) CreateNode(w http.ResponseWriter, r *http.Request) {
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
 	requestID := ensureRequestID(r)
 	res, err := c.org.CreateNode(r.Context(), tenantID, requestID, initiatorID, services.CreateNodeInput{
-		Code:          code,
-		Name:          name,
-		ParentID:      parentID,
-		EffectiveDate: effectiveDate,
-		I18nNames:     i18nNames,
-		Status:        status,
-		DisplayOrder:  displayOrder,
+		HierarchyType:    hierarchyType,
+		Code:             code,
+		Name:             name,
+		ParentID:         parentID,
+		EffectiveDate:    effectiveDate,
+		I18nNames:        i18nNames,
+		Status:           status,
+		DisplayOrder:     displayOrder,
+		CustomAttributes: customAttributesFromForm(r),
 	})
 	if err != nil {
 		formErr, fieldErrs, statusCode := mapServiceErrorToForm(err)
@@ -3918,12 +4074,14 @@ func (c *OrgUIController) CreateNode(w http.ResponseWriter, r *http.Request) {
 			ParentLabel:          parentLabel,
 			Errors:               fieldErrs,
 			FormError:            formErr,
-			SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s", effectiveDateStr),
+			SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s&hierarchy_type=%s", effectiveDateStr, hierarchyType),
+			HierarchyType:        hierarchyType,
+			CustomAttributes:     c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityNode, effectiveDate, nil),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	}
 
-	htmx.PushUrl(w, fmt.Sprintf("/org/nodes?effective_date=%s&node_id=%s", effectiveDateStr, res.NodeID.String()))
+	htmx.PushUrl(w, orgtemplates.NodesPushURL(hierarchyType)(res.NodeID.String(), effectiveDateStr))
 	c.writeNodePanelWithOOBTree(w, r, tenantID, res.NodeID, effectiveDateStr, effectiveDate)
 }
 
@@ -3972,6 +4130,7 @@ func (c *OrgUIController) UpdateNode(w http.ResponseWriter, r *http.Request) {
 			Node:                 &viewmodels.OrgNodeDetails{ID: nodeID, Name: name, Status: status, DisplayOrder: displayOrder, I18nNamesJSON: i18nRaw},
 			Errors:               map[string]string{"i18n_names": i18nErr.Error()},
 			SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s", effectiveDateStr),
+			CustomAttributes:     c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityNode, effectiveDate, nil),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	}
@@ -3979,12 +4138,13 @@ func (c *OrgUIController) UpdateNode(w http.ResponseWriter, r *http.Request) {
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
 	requestID := ensureRequestID(r)
 	_, err = c.org.UpdateNode(r.Context(), tenantID, requestID, initiatorID, services.UpdateNodeInput{
-		NodeID:        nodeID,
-		EffectiveDate: effectiveDate,
-		Name:          &name,
-		I18nNames:     i18nNames,
-		Status:        &status,
-		DisplayOrder:  &displayOrder,
+		NodeID:           nodeID,
+		EffectiveDate:    effectiveDate,
+		Name:             &name,
+		I18nNames:        i18nNames,
+		Status:           &status,
+		DisplayOrder:     &displayOrder,
+		CustomAttributes: customAttributesFromForm(r),
 	})
 	if err != nil {
 		var svcErr *services.ServiceError
@@ -4003,6 +4163,7 @@ func (c *OrgUIController) UpdateNode(w http.ResponseWriter, r *http.Request) {
 					Errors:               map[string]string{},
 					FormError:            svcErr.Message,
 					SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s", effectiveDateStr),
+					CustomAttributes:     c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityNode, effectiveDate, nil),
 				}), templ.WithStreaming()).ServeHTTP(w, r)
 				return
 			}
@@ -4021,6 +4182,7 @@ func (c *OrgUIController) UpdateNode(w http.ResponseWriter, r *http.Request) {
 			Errors:               fieldErrs,
 			FormError:            formErr,
 			SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s", effectiveDateStr),
+			CustomAttributes:     c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityNode, effectiveDate, nil),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	}
@@ -4189,6 +4351,7 @@ func (c *OrgUIController) CorrectNode(w http.ResponseWriter, r *http.Request) {
 				Node:                 &viewmodels.OrgNodeDetails{ID: nodeID, Name: nameRaw, Status: statusRaw, DisplayOrder: displayOrder, I18nNamesJSON: i18nRaw},
 				Errors:               map[string]string{"i18n_names": i18nErr.Error()},
 				SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s", effectiveDateStr),
+				CustomAttributes:     c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityNode, effectiveDate, nil),
 			}), templ.WithStreaming()).ServeHTTP(w, r)
 			return
 		}
@@ -4211,12 +4374,13 @@ func (c *OrgUIController) CorrectNode(w http.ResponseWriter, r *http.Request) {
 	initiatorID := authzutil.NormalizedUserUUID(tenantID, currentUser)
 	requestID := ensureRequestID(r)
 	_, err = c.org.CorrectNode(r.Context(), tenantID, requestID, initiatorID, services.CorrectNodeInput{
-		NodeID:       nodeID,
-		AsOf:         effectiveDate,
-		Name:         name,
-		I18nNames:    i18nNames,
-		Status:       status,
-		DisplayOrder: displayOrderPtr,
+		NodeID:           nodeID,
+		AsOf:             effectiveDate,
+		Name:             name,
+		I18nNames:        i18nNames,
+		Status:           status,
+		DisplayOrder:     displayOrderPtr,
+		CustomAttributes: customAttributesFromForm(r),
 	})
 	if err != nil {
 		formErr, fieldErrs, statusCode := mapServiceErrorToForm(err)
@@ -4228,6 +4392,7 @@ func (c *OrgUIController) CorrectNode(w http.ResponseWriter, r *http.Request) {
 			Errors:               fieldErrs,
 			FormError:            formErr,
 			SearchParentEndpoint: fmt.Sprintf("/org/nodes/search?effective_date=%s", effectiveDateStr),
+			CustomAttributes:     c.customAttributeFieldsFor(r, tenantID, services.CustomAttributeEntityNode, effectiveDate, nil),
 		}), templ.WithStreaming()).ServeHTTP(w, r)
 		return
 	}
@@ -4504,6 +4669,8 @@ func (c *OrgUIController) writeNodePanelWithOOBTree(w http.ResponseWriter, r *ht
 	ensureOrgPageCapabilities(r, orgEdgesAuthzObject, "write")
 	ensureOrgPageCapabilities(r, orgNodesAuthzObject, "admin")
 	ensureOrgPageCapabilities(r, orgEdgesAuthzObject, "admin")
+	ensureOrgPageCapabilities(r, orgAuditAuthzObject, "read")
+	ensureOrgPageCapabilities(r, orgRoleAssignmentsAuthzObject, "admin")
 
 	details, err := c.getNodeDetails(r, tenantID, nodeID, effectiveDate)
 	if err != nil {
@@ -4519,14 +4686,15 @@ func (c *OrgUIController) writeNodePanelWithOOBTree(w http.ResponseWriter, r *ht
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	edgeRows, err := c.org.ListEdgesTimelineAsChild(r.Context(), tenantID, "OrgUnit", nodeID)
+	hierarchyType := nodeDetailsHierarchyType(details)
+	edgeRows, err := c.org.ListEdgesTimelineAsChild(r.Context(), tenantID, hierarchyType, nodeID)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 	nodeRecords := nodeSliceRecordsFromRows(nodeSliceRows, effectiveDate)
 	edgeRecords := edgeSliceRecordsFromRows(edgeRows, effectiveDate)
-	nodes, _, err := c.org.GetHierarchyAsOf(r.Context(), tenantID, "OrgUnit", effectiveDate)
+	nodes, _, err := c.org.GetHierarchyAsOf(r.Context(), tenantID, hierarchyType, effectiveDate)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -4553,6 +4721,7 @@ func (c *OrgUIController) writeNodePanelWithOOBTree(w http.ResponseWriter, r *ht
 	component := templ.ComponentFunc(func(ctx context.Context, ww io.Writer) error {
 		if err := orgtemplates.NodesHeader(orgtemplates.NodesHeaderProps{
 			EffectiveDate: effectiveDateStr,
+			HierarchyType: hierarchyType,
 			SwapOOB:       true,
 		}).Render(ctx, ww); err != nil {
 			return err
@@ -4565,7 +4734,12 @@ func (c *OrgUIController) writeNodePanelWithOOBTree(w http.ResponseWriter, r *ht
 		}).Render(ctx, ww); err != nil {
 			return err
 		}
-		return orgui.Tree(orgui.TreeProps{Tree: tree, EffectiveDate: effectiveDateStr, SwapOOB: true}).Render(ctx, ww)
+		return orgui.Tree(orgui.TreeProps{
+			Tree:          tree,
+			EffectiveDate: effectiveDateStr,
+			SwapOOB:       true,
+			NodePushURL:   orgtemplates.NodesPushURL(hierarchyType),
+		}).Render(ctx, ww)
 	})
 	templ.Handler(component, templ.WithStreaming()).ServeHTTP(w, r)
 }
@@ -4575,7 +4749,9 @@ func (c *OrgUIController) getNodeDetails(r *http.Request, tenantID uuid.UUID, no
 	if err != nil {
 		return nil, err
 	}
-	return mappers.NodeDetailsToViewModel(node), nil
+	details := mappers.NodeDetailsToViewModel(node)
+	c.fillNodeManager(r, tenantID, details, asOf)
+	return details, nil
 }
 
 func (c *OrgUIController) orgNodeLongNameFor(r *http.Request, tenantID uuid.UUID, nodeID uuid.UUID, asOf time.Time) string {
@@ -4813,6 +4989,23 @@ func effectiveDateFromQuery(r *http.Request) (time.Time, error) {
 	return t, nil
 }
 
+// hierarchyTypeFromQuery returns the hierarchy tab requested by the page; unknown values fall back
+// to OrgUnit so stale links keep working.
+func hierarchyTypeFromQuery(r *http.Request) string {
+	v := strings.TrimSpace(r.URL.Query().Get("hierarchy_type"))
+	if !services.IsValidHierarchyType(v) {
+		return services.HierarchyTypeOrgUnit
+	}
+	return v
+}
+
+func nodeDetailsHierarchyType(details *viewmodels.OrgNodeDetails) string {
+	if details == nil || !services.IsValidHierarchyType(details.HierarchyType) {
+		return services.HierarchyTypeOrgUnit
+	}
+	return details.HierarchyType
+}
+
 func effectiveDateFromWriteForm(r *http.Request) (time.Time, error) {
 	v := strings.TrimSpace(r.PostFormValue("effective_date"))
 	t, err := parseEffectiveDate(v)
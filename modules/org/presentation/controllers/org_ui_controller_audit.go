@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/templates/components/orgui"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+const entityHistoryPageSize = 20
+
+// EntityHistory renders the audit trail of one node, position or assignment for the panel History tab.
+func (c *OrgUIController) EntityHistory(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgAuditAuthzObject, "read")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgAuditAuthzObject, "read") {
+		return
+	}
+
+	entityType := strings.TrimSpace(r.URL.Query().Get("entity_type"))
+	entityID, err := uuid.Parse(strings.TrimSpace(r.URL.Query().Get("entity_id")))
+	if entityType == "" || err != nil {
+		http.Error(w, "entity_type and entity_id are required", http.StatusBadRequest)
+		return
+	}
+	filter := services.AuditLogFilter{
+		EntityType: &entityType,
+		EntityID:   &entityID,
+		Limit:      entityHistoryPageSize,
+	}
+	cursor := strings.TrimSpace(r.URL.Query().Get("cursor"))
+	if cursor != "" {
+		at, id, err := parseAuditCursor(cursor)
+		if err != nil {
+			http.Error(w, "cursor is invalid", http.StatusBadRequest)
+			return
+		}
+		filter.CursorAt, filter.CursorID = at, id
+	}
+
+	res, err := c.org.ListAuditLogs(r.Context(), tenantID, filter)
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		http.Error(w, msg, status)
+		return
+	}
+	names := resolveAuditInitiators(r.Context(), c.users, tenantID, res.Items)
+	templ.Handler(orgui.EntityHistory(orgui.EntityHistoryProps{
+		History:  mappers.AuditLogsToEntityHistory(entityType, entityID, res, names),
+		NextPage: cursor != "",
+	})).ServeHTTP(w, r)
+}
@@ -0,0 +1,202 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	orgtemplates "github.com/iota-uz/iota-sdk/modules/org/presentation/templates/pages/org"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func (c *OrgUIController) BudgetsPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgBudgetsAuthzObject, "read")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgBudgetsAuthzObject, "read") {
+		return
+	}
+	effectiveDate, err := effectiveDateFromQuery(r)
+	if err != nil {
+		http.Error(w, "effective_date is invalid", http.StatusBadRequest)
+		return
+	}
+	if effectiveDate.IsZero() {
+		effectiveDate = normalizeValidTimeDayUTC(time.Now().UTC())
+	}
+	var nodeID *uuid.UUID
+	if raw := strings.TrimSpace(r.URL.Query().Get("node_id")); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			http.Error(w, "invalid node_id", http.StatusBadRequest)
+			return
+		}
+		nodeID = &id
+	}
+	c.renderBudgetsPage(w, r, tenantID, effectiveDate, nodeID, http.StatusOK, nil)
+}
+
+func (c *OrgUIController) renderBudgetsPage(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, effectiveDate time.Time, nodeID *uuid.UUID, statusCode int, errs []string) {
+	ensureOrgPageCapabilities(r, orgBudgetsAuthzObject, "write")
+
+	props := orgtemplates.BudgetsPageProps{
+		EffectiveDate: effectiveDate.UTC().Format(time.DateOnly),
+		ProfileLabels: map[uuid.UUID]string{},
+		Errors:        errs,
+	}
+	if nodeID != nil {
+		props.NodeID = nodeID.String()
+		props.NodeLabel = c.orgNodeLabelFor(r, tenantID, *nodeID, effectiveDate)
+	}
+
+	report, err := c.org.GetHeadcountBudgetVariance(r.Context(), tenantID, services.HeadcountBudgetVarianceInput{
+		OrgNodeID:     nodeID,
+		EffectiveDate: effectiveDate,
+	})
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		props.Errors = append(props.Errors, msg)
+		if statusCode == http.StatusOK {
+			statusCode = status
+		}
+	} else {
+		props.Report = report
+	}
+	if profiles, err := c.org.ListJobProfiles(r.Context(), tenantID, effectiveDate); err == nil {
+		for _, p := range profiles {
+			props.ProfileLabels[p.ID] = fmt.Sprintf("%s (%s)", p.Name, p.Code)
+		}
+	}
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.BudgetsPage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// ensureBudgetsWrite runs the write guards shared by the budget forms.
+func (c *OrgUIController) ensureBudgetsWrite(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgBudgetsAuthzObject, "write")
+		return uuid.Nil, false
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return uuid.Nil, false
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgBudgetsAuthzObject, "write") {
+		return uuid.Nil, false
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+	return tenantID, true
+}
+
+func (c *OrgUIController) SetHeadcountBudgetUI(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := c.ensureBudgetsWrite(w, r)
+	if !ok {
+		return
+	}
+	effectiveDate, err := effectiveDateFromWriteForm(r)
+	if err != nil || effectiveDate.IsZero() {
+		c.renderBudgetsPage(w, r, tenantID, normalizeValidTimeDayUTC(time.Now().UTC()), nil, http.StatusUnprocessableEntity, []string{"effective_date is required"})
+		return
+	}
+	in, err := headcountBudgetFromForm(r, effectiveDate)
+	if err != nil {
+		c.renderBudgetsPage(w, r, tenantID, effectiveDate, nil, http.StatusUnprocessableEntity, []string{err.Error()})
+		return
+	}
+	if _, err := c.org.SetHeadcountBudget(r.Context(), tenantID, in); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderBudgetsPage(w, r, tenantID, effectiveDate, &in.OrgNodeID, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, budgetsPageURL(effectiveDate, &in.OrgNodeID))
+}
+
+func (c *OrgUIController) EndHeadcountBudgetUI(w http.ResponseWriter, r *http.Request) {
+	tenantID, ok := c.ensureBudgetsWrite(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	effectiveDate, err := effectiveDateFromWriteForm(r)
+	if err != nil || effectiveDate.IsZero() {
+		c.renderBudgetsPage(w, r, tenantID, normalizeValidTimeDayUTC(time.Now().UTC()), nil, http.StatusUnprocessableEntity, []string{"effective_date is required"})
+		return
+	}
+	if _, err := c.org.EndHeadcountBudget(r.Context(), tenantID, services.EndHeadcountBudgetInput{
+		ID:            id,
+		EffectiveDate: effectiveDate,
+	}); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderBudgetsPage(w, r, tenantID, effectiveDate, nil, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, budgetsPageURL(effectiveDate, nil))
+}
+
+func headcountBudgetFromForm(r *http.Request, effectiveDate time.Time) (services.SetHeadcountBudgetInput, error) {
+	orgNodeID, err := uuid.Parse(param(r, "org_node_id"))
+	if err != nil {
+		return services.SetHeadcountBudgetInput{}, errors.New("org_node_id is required")
+	}
+	in := services.SetHeadcountBudgetInput{
+		OrgNodeID:     orgNodeID,
+		JobLevelCode:  optionalFormString(r, "job_level_code"),
+		EffectiveDate: effectiveDate,
+	}
+	if raw := param(r, "job_profile_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return services.SetHeadcountBudgetInput{}, errors.New("invalid job_profile_id")
+		}
+		in.JobProfileID = &id
+	}
+	in.PlannedHeadcount, err = strconv.Atoi(param(r, "planned_headcount"))
+	if err != nil {
+		return services.SetHeadcountBudgetInput{}, errors.New("invalid planned_headcount")
+	}
+	in.PlannedFTE, err = strconv.ParseFloat(param(r, "planned_fte"), 64)
+	if err != nil {
+		return services.SetHeadcountBudgetInput{}, errors.New("invalid planned_fte")
+	}
+	if raw := param(r, "planned_cost"); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return services.SetHeadcountBudgetInput{}, errors.New("invalid planned_cost")
+		}
+		in.PlannedCost = &v
+	}
+	return in, nil
+}
+
+func budgetsPageURL(effectiveDate time.Time, nodeID *uuid.UUID) string {
+	q := url.Values{}
+	q.Set("effective_date", effectiveDate.UTC().Format(time.DateOnly))
+	if nodeID != nil {
+		q.Set("node_id", nodeID.String())
+	}
+	return "/org/budgets?" + q.Encode()
+}
@@ -0,0 +1,552 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	coreuser "github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	"github.com/iota-uz/iota-sdk/modules/org/domain/changerequest"
+	orgtemplates "github.com/iota-uz/iota-sdk/modules/org/presentation/templates/pages/org"
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// ensureChangeRequestsUI runs the shared guards of the change request pages. The pages 404 like
+// the API while change requests are disabled.
+func (c *OrgUIController) ensureChangeRequestsUI(w http.ResponseWriter, r *http.Request, action string) (uuid.UUID, coreuser.User, bool) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgChangeRequestsAuthzObj, action)
+		return uuid.Nil, nil, false
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return uuid.Nil, nil, false
+	}
+	if !configuration.Use().OrgChangeRequestsEnabled {
+		http.NotFound(w, r)
+		return uuid.Nil, nil, false
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgChangeRequestsAuthzObj, action) {
+		return uuid.Nil, nil, false
+	}
+	return tenantID, currentUser, true
+}
+
+func (c *OrgUIController) ChangeRequestsPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := c.ensureChangeRequestsUI(w, r, "read")
+	if !ok {
+		return
+	}
+	c.renderChangeRequestsPage(w, r, tenantID, currentUser, http.StatusOK, nil)
+}
+
+func (c *OrgUIController) renderChangeRequestsPage(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, currentUser coreuser.User, statusCode int, errs []string) {
+	ensureOrgPageCapabilities(r, orgChangeRequestsAuthzObj, "write", "approve")
+
+	userID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	subjects := reviewerSubjects(userID, currentUser)
+	props := orgtemplates.ChangeRequestsPageProps{
+		EffectiveDate: normalizeValidTimeDayUTC(time.Now().UTC()).Format(time.DateOnly),
+		CommandTypes:  batchCommandTypes,
+		Errors:        errs,
+	}
+
+	changeRequests := c.scenarioAPI().changeRequests
+	_, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) (struct{}, error) {
+		rows, err := changeRequests.List(txCtx, "", 200, nil, nil)
+		if err != nil {
+			return struct{}{}, err
+		}
+		for _, cr := range rows {
+			if cr.RequesterID != userID {
+				continue
+			}
+			if cr.Status == changerequest.StatusDraft {
+				props.Drafts = append(props.Drafts, changeRequestListItemOf(cr))
+			} else {
+				props.Requests = append(props.Requests, changeRequestListItemOf(cr))
+			}
+		}
+
+		submitted, err := changeRequests.List(txCtx, changerequest.StatusSubmitted, 200, nil, nil)
+		if err != nil {
+			return struct{}{}, err
+		}
+		for _, cr := range submitted {
+			if cr.RequesterID == userID {
+				continue
+			}
+			steps, err := changeRequests.ListApprovalSteps(txCtx, cr.ID)
+			if err != nil {
+				return struct{}{}, err
+			}
+			if canReviewChangeRequest(cr, userID, subjects, steps) {
+				props.ReviewQueue = append(props.ReviewQueue, changeRequestListItemOf(cr))
+			}
+		}
+		return struct{}{}, nil
+	})
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		props.Errors = append(props.Errors, msg)
+		statusCode = status
+	}
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.ChangeRequestsPage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *OrgUIController) CreateChangeRequestUI(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := c.ensureChangeRequestsUI(w, r, "write")
+	if !ok {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	effectiveDate, err := effectiveDateFromWriteForm(r)
+	if err != nil {
+		c.renderChangeRequestsPage(w, r, tenantID, currentUser, http.StatusUnprocessableEntity, []string{"effective_date is required"})
+		return
+	}
+	cmd, err := changeRequestCommandFromForm(r)
+	if err != nil {
+		c.renderChangeRequestsPage(w, r, tenantID, currentUser, http.StatusUnprocessableEntity, []string{err.Error()})
+		return
+	}
+	payload, err := changeRequestPayloadOf(services.PreflightInput{
+		EffectiveDate: effectiveDate.Format(time.DateOnly),
+		Commands:      []services.Command{cmd},
+	})
+	if err != nil {
+		c.renderChangeRequestsPage(w, r, tenantID, currentUser, http.StatusUnprocessableEntity, []string{err.Error()})
+		return
+	}
+
+	changeRequests := c.scenarioAPI().changeRequests
+	cr, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+		return changeRequests.SaveDraft(txCtx, services.SaveDraftChangeRequestParams{
+			RequestID:   ensureRequestID(r),
+			RequesterID: authzutil.NormalizedUserUUID(tenantID, currentUser),
+			Payload:     payload,
+			Notes:       optionalFormString(r, "notes"),
+		})
+	})
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderChangeRequestsPage(w, r, tenantID, currentUser, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, "/org/change-requests/"+cr.ID.String())
+}
+
+func (c *OrgUIController) ChangeRequestPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := c.ensureChangeRequestsUI(w, r, "read")
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	c.renderChangeRequestPage(w, r, tenantID, currentUser, id, http.StatusOK, nil)
+}
+
+func (c *OrgUIController) renderChangeRequestPage(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, currentUser coreuser.User, id uuid.UUID, statusCode int, errs []string) {
+	ensureOrgPageCapabilities(r, orgChangeRequestsAuthzObj, "write", "admin", "approve")
+
+	api := c.scenarioAPI()
+	userID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	var steps []*changerequest.ApprovalStep
+	cr, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+		cr, err := api.changeRequests.Get(txCtx, id)
+		if err != nil {
+			return nil, err
+		}
+		steps, err = api.changeRequests.ListApprovalSteps(txCtx, id)
+		return cr, err
+	})
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		http.Error(w, msg, status)
+		return
+	}
+
+	props := orgtemplates.ChangeRequestPageProps{
+		ChangeRequest: changeRequestDetailOf(cr, userID, steps),
+		CommandTypes:  batchCommandTypes,
+		CanReview:     canReviewChangeRequest(cr, userID, reviewerSubjects(userID, currentUser), steps),
+		Errors:        errs,
+	}
+	// Only open requests are worth re-validating; applied, rejected and cancelled payloads are history.
+	if cr.Status == changerequest.StatusDraft || cr.Status == changerequest.StatusSubmitted {
+		res, err := api.changeRequestExecutor().Preflight(r.Context(), tenantID, userID, cr)
+		if err != nil {
+			props.PreflightError = scenarioErrorMessage(err)
+		} else {
+			props.Impact = changeRequestImpactOf(res)
+		}
+	}
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.ChangeRequestPage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// UpdateChangeRequestUI saves the draft's effective date and notes.
+func (c *OrgUIController) UpdateChangeRequestUI(w http.ResponseWriter, r *http.Request) {
+	c.editChangeRequestDraft(w, r, func(draft *changeRequestDraft) error {
+		effectiveDate, err := effectiveDateFromWriteForm(r)
+		if err != nil {
+			return errors.New("effective_date is required")
+		}
+		draft.Payload.EffectiveDate = effectiveDate.Format(time.DateOnly)
+		draft.Notes = optionalFormString(r, "notes")
+		return nil
+	})
+}
+
+func (c *OrgUIController) AddChangeRequestCommandUI(w http.ResponseWriter, r *http.Request) {
+	c.editChangeRequestDraft(w, r, func(draft *changeRequestDraft) error {
+		cmd, err := changeRequestCommandFromForm(r)
+		if err != nil {
+			return err
+		}
+		draft.Payload.Commands = append(draft.Payload.Commands, cmd)
+		return nil
+	})
+}
+
+func (c *OrgUIController) RemoveChangeRequestCommandUI(w http.ResponseWriter, r *http.Request) {
+	c.editChangeRequestDraft(w, r, func(draft *changeRequestDraft) error {
+		commands := draft.Payload.Commands
+		index, err := strconv.Atoi(mux.Vars(r)["index"])
+		if err != nil || index < 0 || index >= len(commands) {
+			return errors.New("command index is invalid")
+		}
+		if len(commands) == 1 {
+			return errors.New("a change request needs at least one command; cancel it instead")
+		}
+		out := make([]services.Command, 0, len(commands)-1)
+		out = append(out, commands[:index]...)
+		draft.Payload.Commands = append(out, commands[index+1:]...)
+		return nil
+	})
+}
+
+type changeRequestDraft struct {
+	Payload services.PreflightInput
+	Notes   *string
+}
+
+func (c *OrgUIController) editChangeRequestDraft(w http.ResponseWriter, r *http.Request, edit func(*changeRequestDraft) error) {
+	tenantID, currentUser, ok := c.ensureChangeRequestsUI(w, r, "write")
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	changeRequests := c.scenarioAPI().changeRequests
+	_, err = withOrgTx(r.Context(), tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+		cr, err := changeRequests.Get(txCtx, id)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := services.DecodeChangeRequestPayload(cr)
+		if err != nil {
+			return nil, err
+		}
+		draft := &changeRequestDraft{Payload: payload, Notes: cr.Notes}
+		if err := edit(draft); err != nil {
+			return nil, err
+		}
+		raw, err := changeRequestPayloadOf(draft.Payload)
+		if err != nil {
+			return nil, err
+		}
+		return changeRequests.UpdateDraft(txCtx, services.UpdateDraftChangeRequestParams{
+			ID:      id,
+			Payload: raw,
+			Notes:   draft.Notes,
+		})
+	})
+	if err != nil {
+		c.renderChangeRequestPage(w, r, tenantID, currentUser, id, changeRequestUIErrorStatus(err), []string{scenarioErrorMessage(err)})
+		return
+	}
+	redirectUI(w, r, "/org/change-requests/"+id.String())
+}
+
+func (c *OrgUIController) SubmitChangeRequestUI(w http.ResponseWriter, r *http.Request) {
+	c.transitionChangeRequest(w, r, "admin", func(ctx context.Context, tenantID uuid.UUID, _ coreuser.User, cr *changerequest.ChangeRequest) error {
+		changeRequests := c.scenarioAPI().changeRequests
+		_, err := withOrgTx(ctx, tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+			return changeRequests.Submit(txCtx, cr.ID)
+		})
+		return err
+	})
+}
+
+func (c *OrgUIController) CancelChangeRequestUI(w http.ResponseWriter, r *http.Request) {
+	c.transitionChangeRequest(w, r, "admin", func(ctx context.Context, tenantID uuid.UUID, _ coreuser.User, cr *changerequest.ChangeRequest) error {
+		changeRequests := c.scenarioAPI().changeRequests
+		_, err := withOrgTx(ctx, tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+			return changeRequests.Cancel(txCtx, cr.ID)
+		})
+		return err
+	})
+}
+
+func (c *OrgUIController) ApproveChangeRequestUI(w http.ResponseWriter, r *http.Request) {
+	c.reviewChangeRequestUI(w, r, true)
+}
+
+func (c *OrgUIController) RejectChangeRequestUI(w http.ResponseWriter, r *http.Request) {
+	c.reviewChangeRequestUI(w, r, false)
+}
+
+// reviewChangeRequestUI mirrors the API review: approving re-runs preflight first so a reviewer
+// never signs off on commands that no longer apply.
+func (c *OrgUIController) reviewChangeRequestUI(w http.ResponseWriter, r *http.Request, approve bool) {
+	c.transitionChangeRequest(w, r, "approve", func(ctx context.Context, tenantID uuid.UUID, currentUser coreuser.User, cr *changerequest.ChangeRequest) error {
+		api := c.scenarioAPI()
+		reviewerID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+		if cr.Status != changerequest.StatusSubmitted {
+			return errors.New("change request is not submitted")
+		}
+		if err := services.EnsureNotSelfReview(cr, reviewerID); err != nil {
+			return err
+		}
+		if approve {
+			if _, err := api.changeRequestExecutor().Preflight(ctx, tenantID, reviewerID, cr); err != nil {
+				return err
+			}
+		}
+		_, err := withOrgTx(ctx, tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+			params := services.ReviewChangeRequestParams{
+				ID:               cr.ID,
+				ReviewerID:       reviewerID,
+				ReviewerSubjects: reviewerSubjects(reviewerID, currentUser),
+				Comment:          optionalFormString(r, "comment"),
+			}
+			if approve {
+				return api.changeRequests.Approve(txCtx, params)
+			}
+			return api.changeRequests.Reject(txCtx, params)
+		})
+		return err
+	})
+}
+
+// transitionChangeRequest loads the request and hands it to act, then re-renders the detail page
+// with act's error or redirects back to it.
+func (c *OrgUIController) transitionChangeRequest(w http.ResponseWriter, r *http.Request, action string, act func(context.Context, uuid.UUID, coreuser.User, *changerequest.ChangeRequest) error) {
+	tenantID, currentUser, ok := c.ensureChangeRequestsUI(w, r, action)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+
+	changeRequests := c.scenarioAPI().changeRequests
+	cr, err := withOrgTx(r.Context(), tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+		return changeRequests.Get(txCtx, id)
+	})
+	if err == nil {
+		err = act(r.Context(), tenantID, currentUser, cr)
+	}
+	if err != nil {
+		c.renderChangeRequestPage(w, r, tenantID, currentUser, id, changeRequestUIErrorStatus(err), []string{scenarioErrorMessage(err)})
+		return
+	}
+	redirectUI(w, r, "/org/change-requests/"+id.String())
+}
+
+// canReviewChangeRequest reports whether userID may decide the request now: it is submitted by
+// someone else and either has no approval chain or its pending step lists one of subjects.
+func canReviewChangeRequest(cr *changerequest.ChangeRequest, userID uuid.UUID, subjects []string, steps []*changerequest.ApprovalStep) bool {
+	if cr.Status != changerequest.StatusSubmitted || cr.RequesterID == userID {
+		return false
+	}
+	if len(steps) == 0 {
+		return true
+	}
+	for _, step := range steps {
+		if step.Status == changerequest.ApprovalStepPending {
+			return step.CanDecide(subjects)
+		}
+	}
+	return false
+}
+
+// changeRequestUIErrorStatus maps plain validation errors from the edit callbacks to 422.
+func changeRequestUIErrorStatus(err error) int {
+	var svcErr *services.ServiceError
+	if errors.As(err, &svcErr) {
+		return svcErr.Status
+	}
+	return http.StatusUnprocessableEntity
+}
+
+func optionalFormString(r *http.Request, key string) *string {
+	v := strings.TrimSpace(param(r, key))
+	if v == "" {
+		return nil
+	}
+	return &v
+}
+
+func changeRequestCommandFromForm(r *http.Request) (services.Command, error) {
+	t := strings.TrimSpace(param(r, "type"))
+	if !isSupportedBatchCommandType(t) {
+		return services.Command{}, errors.New("unknown command type")
+	}
+	var obj map[string]json.RawMessage
+	payload := strings.TrimSpace(param(r, "payload"))
+	if err := json.Unmarshal([]byte(payload), &obj); err != nil || obj == nil {
+		return services.Command{}, errors.New("payload must be a JSON object")
+	}
+	return services.Command{Type: t, Payload: json.RawMessage(payload)}, nil
+}
+
+// changeRequestPayloadOf marshals a draft payload and runs the same validation as the API.
+func changeRequestPayloadOf(in services.PreflightInput) (json.RawMessage, error) {
+	raw, err := json.Marshal(in)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateChangeRequestPayload(raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func changeRequestListItemOf(cr *changerequest.ChangeRequest) viewmodels.OrgChangeRequestListItem {
+	out := viewmodels.OrgChangeRequestListItem{
+		ID:        cr.ID.String(),
+		RequestID: cr.RequestID,
+		Status:    cr.Status,
+		UpdatedAt: cr.UpdatedAt.UTC().Format("2006-01-02 15:04"),
+	}
+	if cr.Notes != nil {
+		out.Notes = *cr.Notes
+	}
+	if payload, err := services.DecodeChangeRequestPayload(cr); err == nil {
+		out.EffectiveDate = payload.EffectiveDate
+		out.CommandsCount = len(payload.Commands)
+	}
+	return out
+}
+
+func changeRequestDetailOf(cr *changerequest.ChangeRequest, userID uuid.UUID, steps []*changerequest.ApprovalStep) viewmodels.OrgChangeRequestDetail {
+	out := viewmodels.OrgChangeRequestDetail{
+		OrgChangeRequestListItem: changeRequestListItemOf(cr),
+		Mine:                     cr.RequesterID == userID,
+	}
+	if cr.ReviewComment != nil {
+		out.ReviewComment = *cr.ReviewComment
+	}
+	if cr.ReviewedAt != nil {
+		out.ReviewedAt = cr.ReviewedAt.UTC().Format("2006-01-02 15:04")
+	}
+	if payload, err := services.DecodeChangeRequestPayload(cr); err == nil {
+		out.Commands = changeRequestCommandsOf(payload.Commands)
+	}
+	for _, step := range steps {
+		vm := viewmodels.OrgChangeRequestStep{
+			StepOrder: int(step.StepOrder),
+			RoleCode:  step.RoleCode,
+			Status:    step.Status,
+		}
+		if step.DueAt != nil {
+			vm.DueAt = step.DueAt.UTC().Format("2006-01-02 15:04")
+		}
+		if step.DecidedAt != nil {
+			vm.DecidedAt = step.DecidedAt.UTC().Format("2006-01-02 15:04")
+		}
+		if step.Comment != nil {
+			vm.Comment = *step.Comment
+		}
+		out.Steps = append(out.Steps, vm)
+	}
+	return out
+}
+
+// changeRequestCommandsOf turns command payloads into sorted field/value lists. Payloads that are
+// not JSON objects show as a single "payload" field.
+func changeRequestCommandsOf(commands []services.Command) []viewmodels.OrgChangeRequestCommand {
+	out := make([]viewmodels.OrgChangeRequestCommand, 0, len(commands))
+	for i, cmd := range commands {
+		vm := viewmodels.OrgChangeRequestCommand{Index: i, Type: cmd.Type}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(cmd.Payload, &obj); err != nil || obj == nil {
+			vm.Fields = []viewmodels.OrgChangeRequestField{{Name: "payload", Value: services.FormatAuditValue(cmd.Payload)}}
+			out = append(out, vm)
+			continue
+		}
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			vm.Fields = append(vm.Fields, viewmodels.OrgChangeRequestField{Name: k, Value: services.FormatAuditValue(obj[k])})
+		}
+		out = append(out, vm)
+	}
+	return out
+}
+
+func changeRequestImpactOf(res services.PreflightResult) *viewmodels.OrgChangeRequestImpact {
+	counters := func(c services.PreflightCounters) viewmodels.OrgChangeRequestCounters {
+		return viewmodels.OrgChangeRequestCounters{Create: c.Create, Update: c.Update, Move: c.Move, Rescind: c.Rescind}
+	}
+	out := &viewmodels.OrgChangeRequestImpact{
+		Nodes:         counters(res.Impact.OrgNodes),
+		Assignments:   counters(res.Impact.OrgAssignments),
+		AffectedNodes: res.Impact.Affected.OrgNodeIDsCount,
+		Warnings:      res.Warnings,
+	}
+	events := make([]string, 0, len(res.Impact.Events))
+	for k := range res.Impact.Events {
+		events = append(events, k)
+	}
+	sort.Strings(events)
+	for _, k := range events {
+		out.Events = append(out.Events, viewmodels.OrgChangeRequestField{Name: k, Value: strconv.Itoa(res.Impact.Events[k])})
+	}
+	return out
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/domain/changerequest"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func TestChangeRequestCommandsOf(t *testing.T) {
+	got := changeRequestCommandsOf([]services.Command{
+		{Type: "node.move", Payload: json.RawMessage(`{"new_parent_id":"p1","id":"n1","effective_date":null}`)},
+		{Type: "node.rescind", Payload: json.RawMessage(`["bad"]`)},
+	})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 commands, got %d", len(got))
+	}
+
+	move := got[0]
+	if move.Index != 0 || move.Type != "node.move" {
+		t.Fatalf("unexpected command: %+v", move)
+	}
+	wantFields := [][2]string{{"effective_date", "null"}, {"id", "n1"}, {"new_parent_id", "p1"}}
+	if len(move.Fields) != len(wantFields) {
+		t.Fatalf("expected %d fields, got %+v", len(wantFields), move.Fields)
+	}
+	for i, want := range wantFields {
+		if move.Fields[i].Name != want[0] || move.Fields[i].Value != want[1] {
+			t.Fatalf("field %d: expected %s=%s, got %+v", i, want[0], want[1], move.Fields[i])
+		}
+	}
+
+	if len(got[1].Fields) != 1 || got[1].Fields[0].Name != "payload" || got[1].Fields[0].Value != `["bad"]` {
+		t.Fatalf("expected raw payload field, got %+v", got[1].Fields)
+	}
+}
+
+func TestCanReviewChangeRequest(t *testing.T) {
+	requester := uuid.New()
+	reviewer := uuid.New()
+	subjects := []string{"user:" + reviewer.String()}
+	submitted := &changerequest.ChangeRequest{RequesterID: requester, Status: changerequest.StatusSubmitted}
+
+	if !canReviewChangeRequest(submitted, reviewer, subjects, nil) {
+		t.Fatal("expected a request without an approval chain to be reviewable")
+	}
+	if canReviewChangeRequest(submitted, requester, subjects, nil) {
+		t.Fatal("expected the requester not to review their own request")
+	}
+	draft := &changerequest.ChangeRequest{RequesterID: requester, Status: changerequest.StatusDraft}
+	if canReviewChangeRequest(draft, reviewer, subjects, nil) {
+		t.Fatal("expected drafts not to be reviewable")
+	}
+
+	steps := []*changerequest.ApprovalStep{
+		{StepOrder: 1, Status: changerequest.ApprovalStepApproved, ApproverSubjects: []string{"user:" + uuid.NewString()}},
+		{StepOrder: 2, Status: changerequest.ApprovalStepPending, ApproverSubjects: subjects},
+	}
+	if !canReviewChangeRequest(submitted, reviewer, subjects, steps) {
+		t.Fatal("expected the pending step's approver to review")
+	}
+	steps[1].ApproverSubjects = []string{"group:" + uuid.NewString()}
+	if canReviewChangeRequest(submitted, reviewer, subjects, steps) {
+		t.Fatal("expected a non-approver of the pending step not to review")
+	}
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/viewmodels"
+)
+
+// orgUserLabel is the display name of a core user, falling back to the numeric id when the
+// user no longer resolves.
+func (c *OrgUIController) orgUserLabel(ctx context.Context, userID int64) string {
+	if c.users != nil && userID > 0 {
+		if u, err := c.users.GetByID(ctx, uint(userID)); err == nil && u != nil {
+			if label := strings.TrimSpace(u.FirstName() + " " + u.LastName()); label != "" {
+				return label
+			}
+			return u.Email().Value()
+		}
+	}
+	return "#" + strconv.FormatInt(userID, 10)
+}
+
+// fillNodeManager resolves the manager label and the acting manager of the node at asOf. Both
+// are best effort: the panel still renders when a user or delegation cannot be read.
+func (c *OrgUIController) fillNodeManager(r *http.Request, tenantID uuid.UUID, details *viewmodels.OrgNodeDetails, asOf time.Time) {
+	if details == nil {
+		return
+	}
+	if details.ManagerUserID != nil && *details.ManagerUserID > 0 {
+		details.ManagerLabel = c.orgUserLabel(r.Context(), *details.ManagerUserID)
+	}
+	acting, err := c.org.ActingManagerAsOf(r.Context(), tenantID, details.ID, asOf)
+	if err != nil || acting == nil {
+		return
+	}
+	details.ActingManager = &viewmodels.OrgNodeActingManager{
+		UserID:  acting.DelegateUserID,
+		Label:   c.orgUserLabel(r.Context(), acting.DelegateUserID),
+		EndDate: acting.EndDate,
+	}
+}
@@ -0,0 +1,166 @@
+package controllers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	orgtemplates "github.com/iota-uz/iota-sdk/modules/org/presentation/templates/pages/org"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+const freezeCalendarPageURL = "/org/freeze-calendar"
+
+func (c *OrgUIController) FreezeCalendarPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgFreezeCalendarAuthzObject, "read")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgFreezeCalendarAuthzObject, "read") {
+		return
+	}
+	c.renderFreezeCalendarPage(w, r, tenantID, http.StatusOK, nil)
+}
+
+func (c *OrgUIController) renderFreezeCalendarPage(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, statusCode int, errs []string) {
+	ensureOrgPageCapabilities(r, orgFreezeCalendarAuthzObject, "admin", "override")
+
+	props := orgtemplates.FreezeCalendarPageProps{
+		Now:    time.Now().UTC(),
+		Errors: errs,
+	}
+	if periods, err := c.org.ListFreezePeriods(r.Context(), tenantID); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		props.Errors = append(props.Errors, msg)
+		if statusCode == http.StatusOK {
+			statusCode = status
+		}
+	} else {
+		props.Periods = periods
+	}
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.FreezeCalendarPage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// ensureFreezeCalendarWrite runs the write guards shared by the freeze calendar forms and returns
+// the request to continue with, the tenant and the initiator recorded in the audit log. A non-empty
+// override_reason additionally needs the override action and lets the write change a period that
+// has already locked; the returned request then carries the override.
+func (c *OrgUIController) ensureFreezeCalendarWrite(w http.ResponseWriter, r *http.Request) (*http.Request, uuid.UUID, uuid.UUID, bool) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgFreezeCalendarAuthzObject, "admin")
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgFreezeCalendarAuthzObject, "admin") {
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return nil, uuid.Nil, uuid.Nil, false
+	}
+	if reason := param(r, "override_reason"); reason != "" {
+		if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgFreezeCalendarAuthzObject, "override") {
+			return nil, uuid.Nil, uuid.Nil, false
+		}
+		r = r.WithContext(services.WithFreezeOverride(r.Context(), reason))
+	}
+	return r, tenantID, authzutil.NormalizedUserUUID(tenantID, currentUser), true
+}
+
+// freezePeriodFromForm reads a calendar entry; locks_on is a date and the period locks at 00:00 UTC.
+func freezePeriodFromForm(r *http.Request) (services.FreezePeriodInput, []string) {
+	var errs []string
+	start, err := parseRequiredValidDate("period_start", param(r, "period_start"))
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	end, err := parseRequiredValidDate("period_end", param(r, "period_end"))
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	locksAt, err := parseRequiredValidDate("locks_on", param(r, "locks_on"))
+	if err != nil {
+		errs = append(errs, err.Error())
+	}
+	return services.FreezePeriodInput{
+		Label:       param(r, "label"),
+		PeriodStart: start,
+		PeriodEnd:   end,
+		LocksAt:     locksAt,
+	}, errs
+}
+
+func (c *OrgUIController) CreateFreezePeriodUI(w http.ResponseWriter, r *http.Request) {
+	r, tenantID, initiatorID, ok := c.ensureFreezeCalendarWrite(w, r)
+	if !ok {
+		return
+	}
+	in, errs := freezePeriodFromForm(r)
+	if len(errs) > 0 {
+		c.renderFreezeCalendarPage(w, r, tenantID, http.StatusUnprocessableEntity, errs)
+		return
+	}
+	if _, err := c.org.CreateFreezePeriod(r.Context(), tenantID, ensureRequestID(r), initiatorID, in); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderFreezeCalendarPage(w, r, tenantID, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, freezeCalendarPageURL)
+}
+
+func (c *OrgUIController) UpdateFreezePeriodUI(w http.ResponseWriter, r *http.Request) {
+	r, tenantID, initiatorID, ok := c.ensureFreezeCalendarWrite(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	in, errs := freezePeriodFromForm(r)
+	if len(errs) > 0 {
+		c.renderFreezeCalendarPage(w, r, tenantID, http.StatusUnprocessableEntity, errs)
+		return
+	}
+	if _, err := c.org.UpdateFreezePeriod(r.Context(), tenantID, ensureRequestID(r), initiatorID, id, in); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderFreezeCalendarPage(w, r, tenantID, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, freezeCalendarPageURL)
+}
+
+func (c *OrgUIController) RescindFreezePeriodUI(w http.ResponseWriter, r *http.Request) {
+	r, tenantID, initiatorID, ok := c.ensureFreezeCalendarWrite(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.org.DeleteFreezePeriod(r.Context(), tenantID, ensureRequestID(r), initiatorID, id); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderFreezeCalendarPage(w, r, tenantID, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, freezeCalendarPageURL)
+}
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	orgtemplates "github.com/iota-uz/iota-sdk/modules/org/presentation/templates/pages/org"
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+)
+
+func (c *OrgUIController) DiffPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgHierarchiesAuthzObject, "read")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgHierarchiesAuthzObject, "read") {
+		return
+	}
+	ensureOrgPageCapabilities(r, orgPositionsAuthzObject, "read")
+	ensureOrgPageCapabilities(r, orgAssignmentsAuthzObject, "read")
+
+	statusCode := http.StatusOK
+	var errs []string
+	today := normalizeValidTimeDayUTC(time.Now().UTC())
+	to, err := parseOptionalValidDate("to", r.URL.Query().Get("to"))
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		errs = append(errs, err.Error())
+	}
+	if to.IsZero() {
+		to = today
+	}
+	from, err := parseOptionalValidDate("from", r.URL.Query().Get("from"))
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		errs = append(errs, err.Error())
+	}
+	if from.IsZero() {
+		from = to.AddDate(0, -1, 0)
+	}
+	hierarchyType := hierarchyTypeFromQuery(r)
+
+	props := orgtemplates.DiffPageProps{
+		From:          from.Format(time.DateOnly),
+		To:            to.Format(time.DateOnly),
+		HierarchyType: hierarchyType,
+	}
+
+	if len(errs) == 0 {
+		state := authz.ViewStateFromContext(r.Context())
+		res, err := c.org.DiffHierarchy(r.Context(), tenantID, services.HierarchyDiffParams{
+			HierarchyType:      hierarchyType,
+			From:               from,
+			To:                 to,
+			IncludePositions:   state != nil && state.Capability(authzutil.CapabilityKey(orgPositionsAuthzObject, "read")),
+			IncludeAssignments: state != nil && state.Capability(authzutil.CapabilityKey(orgAssignmentsAuthzObject, "read")),
+		})
+		if err != nil {
+			statusCode = http.StatusBadRequest
+			errs = append(errs, err.Error())
+		} else {
+			labels := map[uuid.UUID]string{}
+			for _, asOf := range []time.Time{res.From, res.To} {
+				nodes, _, err := c.org.GetHierarchyAsOf(r.Context(), tenantID, hierarchyType, asOf)
+				if err != nil {
+					errs = append(errs, err.Error())
+					continue
+				}
+				for id, label := range hierarchyNodeLabelMap(nodes) {
+					labels[id] = label
+				}
+			}
+			props.Diff = hierarchyDiffViewModel(res, labels)
+		}
+	}
+	props.Errors = errs
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.DiffPage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func hierarchyDiffViewModel(res *services.HierarchyDiffResult, labels map[uuid.UUID]string) *viewmodels.OrgHierarchyDiff {
+	label := func(id *uuid.UUID) string {
+		if id == nil {
+			return "—"
+		}
+		if l, ok := labels[*id]; ok {
+			return l
+		}
+		return id.String()
+	}
+	nodeLabel := func(n services.HierarchyDiffNode) string {
+		return fmt.Sprintf("%s (%s)", n.Name, n.Code)
+	}
+	positionLabel := func(p services.HierarchyDiffPosition) string {
+		if p.Title != nil && strings.TrimSpace(*p.Title) != "" {
+			return fmt.Sprintf("%s (%s)", *p.Title, p.Code)
+		}
+		return p.Code
+	}
+	fte := func(v *float64) string {
+		if v == nil {
+			return "—"
+		}
+		return floatToString(*v)
+	}
+
+	out := &viewmodels.OrgHierarchyDiff{
+		From:   formatValidDate(res.From),
+		To:     formatValidDate(res.To),
+		Totals: hierarchyDiffCountsViewModel(res.Totals),
+		Groups: make([]viewmodels.OrgHierarchyDiffGroup, 0, len(res.Groups)),
+	}
+	for _, g := range res.Groups {
+		group := viewmodels.OrgHierarchyDiffGroup{
+			SubtreeLabel: nodeLabel(g.Subtree),
+			Counts:       hierarchyDiffCountsViewModel(g.Counts),
+		}
+		for _, n := range g.NodesCreated {
+			group.Items = append(group.Items, viewmodels.OrgHierarchyDiffItem{Kind: "node_created", Label: nodeLabel(n), Detail: label(n.ParentID)})
+		}
+		for _, n := range g.NodesRetired {
+			group.Items = append(group.Items, viewmodels.OrgHierarchyDiffItem{Kind: "node_retired", Label: nodeLabel(n), Detail: label(n.ParentID)})
+		}
+		for _, n := range g.NodesRenamed {
+			group.Items = append(group.Items, viewmodels.OrgHierarchyDiffItem{Kind: "node_renamed", Label: n.Code, Detail: fmt.Sprintf("%s → %s", n.FromName, n.ToName)})
+		}
+		for _, n := range g.NodesMoved {
+			group.Items = append(group.Items, viewmodels.OrgHierarchyDiffItem{
+				Kind:   "node_moved",
+				Label:  fmt.Sprintf("%s (%s)", n.Name, n.Code),
+				Detail: fmt.Sprintf("%s → %s", label(n.FromParentID), label(n.ToParentID)),
+			})
+		}
+		for _, p := range g.PositionsOpened {
+			nodeID := p.OrgNodeID
+			group.Items = append(group.Items, viewmodels.OrgHierarchyDiffItem{Kind: "position_opened", Label: positionLabel(p), Detail: label(&nodeID)})
+		}
+		for _, p := range g.PositionsClosed {
+			nodeID := p.OrgNodeID
+			group.Items = append(group.Items, viewmodels.OrgHierarchyDiffItem{Kind: "position_closed", Label: positionLabel(p), Detail: label(&nodeID)})
+		}
+		for _, a := range g.AssignmentChanges {
+			detail := ""
+			switch a.Kind {
+			case services.HierarchyDiffAssignmentStarted:
+				detail = fmt.Sprintf("%s · %s", a.ToPositionCode, label(a.ToOrgNodeID))
+			case services.HierarchyDiffAssignmentEnded:
+				detail = fmt.Sprintf("%s · %s", a.FromPositionCode, label(a.FromOrgNodeID))
+			case services.HierarchyDiffAssignmentTransferred:
+				detail = fmt.Sprintf("%s · %s → %s · %s", a.FromPositionCode, label(a.FromOrgNodeID), a.ToPositionCode, label(a.ToOrgNodeID))
+			case services.HierarchyDiffAssignmentFTEChanged:
+				detail = fmt.Sprintf("%s: %s → %s", a.ToPositionCode, fte(a.FromFTE), fte(a.ToFTE))
+			}
+			group.Items = append(group.Items, viewmodels.OrgHierarchyDiffItem{
+				Kind:   "assignment_" + string(a.Kind),
+				Label:  fmt.Sprintf("%s (%s)", a.Pernr, a.AssignmentType),
+				Detail: detail,
+			})
+		}
+		out.Groups = append(out.Groups, group)
+	}
+	return out
+}
+
+func hierarchyDiffCountsViewModel(c services.HierarchyDiffCounts) viewmodels.OrgHierarchyDiffCounts {
+	return viewmodels.OrgHierarchyDiffCounts{
+		NodesCreated:      c.NodesCreated,
+		NodesRetired:      c.NodesRetired,
+		NodesRenamed:      c.NodesRenamed,
+		NodesMoved:        c.NodesMoved,
+		PositionsOpened:   c.PositionsOpened,
+		PositionsClosed:   c.PositionsClosed,
+		AssignmentChanges: c.AssignmentChanges,
+	}
+}
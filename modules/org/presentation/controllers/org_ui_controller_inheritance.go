@@ -0,0 +1,252 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	orgtemplates "github.com/iota-uz/iota-sdk/modules/org/presentation/templates/pages/org"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func (c *OrgUIController) InheritancePage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgInheritanceRulesAuthzObject, "read")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgInheritanceRulesAuthzObject, "read") {
+		return
+	}
+	effectiveDate, err := effectiveDateFromQuery(r)
+	if err != nil {
+		http.Error(w, "effective_date is invalid", http.StatusBadRequest)
+		return
+	}
+	if effectiveDate.IsZero() {
+		effectiveDate = normalizeValidTimeDayUTC(time.Now().UTC())
+	}
+	c.renderInheritancePage(w, r, tenantID, effectiveDate, http.StatusOK, nil)
+}
+
+// renderInheritancePage lists the rules and breaks in force on effectiveDate and previews the
+// values they resolve to.
+func (c *OrgUIController) renderInheritancePage(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, effectiveDate time.Time, statusCode int, errs []string) {
+	ensureOrgPageCapabilities(r, orgInheritanceRulesAuthzObject, "admin")
+
+	props := orgtemplates.InheritancePageProps{
+		EffectiveDate: effectiveDate.UTC().Format(time.DateOnly),
+		Attributes:    services.InheritableAttributes(),
+		NodeLabels:    map[uuid.UUID]string{},
+		Errors:        errs,
+	}
+	fail := func(err error) {
+		msg, _, status := mapServiceErrorToForm(err)
+		props.Errors = append(props.Errors, msg)
+		if statusCode == http.StatusOK {
+			statusCode = status
+		}
+	}
+
+	nodeEntity := services.CustomAttributeEntityNode
+	if defs, err := c.org.ListAttributeDefinitions(r.Context(), tenantID, services.AttributeDefinitionListFilter{EntityType: &nodeEntity, AsOf: &effectiveDate}); err != nil {
+		fail(err)
+	} else {
+		props.AttributeLabels = make(map[string]string, len(defs))
+		for _, d := range defs {
+			name := services.CustomAttributePrefix + d.Code
+			props.Attributes = append(props.Attributes, name)
+			props.AttributeLabels[name] = d.Label
+		}
+	}
+
+	filter := services.InheritanceRuleListFilter{AsOf: &effectiveDate}
+	if rules, err := c.org.ListInheritanceRules(r.Context(), tenantID, filter); err != nil {
+		fail(err)
+	} else {
+		props.Rules = rules
+	}
+	if breaks, err := c.org.ListInheritanceBreaks(r.Context(), tenantID, filter); err != nil {
+		fail(err)
+	} else {
+		props.Breaks = breaks
+	}
+	if preview, err := c.org.PreviewInheritance(r.Context(), tenantID, services.InheritancePreviewInput{EffectiveDate: effectiveDate}); err != nil {
+		fail(err)
+	} else {
+		props.Preview = preview
+		for _, n := range preview.Nodes {
+			props.NodeLabels[n.ID] = n.Name + " (" + n.Code + ")"
+		}
+	}
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.InheritancePage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// ensureInheritanceWrite runs the write guards shared by the inheritance forms and returns the
+// tenant and the initiator recorded in the audit log.
+func (c *OrgUIController) ensureInheritanceWrite(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgInheritanceRulesAuthzObject, "admin")
+		return uuid.Nil, uuid.Nil, false
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return uuid.Nil, uuid.Nil, false
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgInheritanceRulesAuthzObject, "admin") {
+		return uuid.Nil, uuid.Nil, false
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return tenantID, authzutil.NormalizedUserUUID(tenantID, currentUser), true
+}
+
+// inheritanceWriteDate reads the form's effective_date, re-rendering the page when it is missing.
+func (c *OrgUIController) inheritanceWriteDate(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID) (time.Time, bool) {
+	effectiveDate, err := effectiveDateFromWriteForm(r)
+	if err != nil || effectiveDate.IsZero() {
+		c.renderInheritancePage(w, r, tenantID, normalizeValidTimeDayUTC(time.Now().UTC()), http.StatusUnprocessableEntity, []string{"effective_date is required"})
+		return time.Time{}, false
+	}
+	return effectiveDate, true
+}
+
+func (c *OrgUIController) SetInheritanceRuleUI(w http.ResponseWriter, r *http.Request) {
+	tenantID, initiatorID, ok := c.ensureInheritanceWrite(w, r)
+	if !ok {
+		return
+	}
+	effectiveDate, ok := c.inheritanceWriteDate(w, r, tenantID)
+	if !ok {
+		return
+	}
+	if _, err := c.org.SetInheritanceRule(r.Context(), tenantID, ensureRequestID(r), initiatorID, services.SetInheritanceRuleInput{
+		AttributeName: param(r, "attribute_name"),
+		CanOverride:   param(r, "can_override") == "true",
+		EffectiveDate: effectiveDate,
+	}); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderInheritancePage(w, r, tenantID, effectiveDate, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, inheritancePageURL(effectiveDate))
+}
+
+func (c *OrgUIController) EndInheritanceRuleUI(w http.ResponseWriter, r *http.Request) {
+	c.endInheritanceUI(w, r, c.org.EndInheritanceRule)
+}
+
+func (c *OrgUIController) EndInheritanceBreakUI(w http.ResponseWriter, r *http.Request) {
+	c.endInheritanceUI(w, r, c.org.EndInheritanceBreak)
+}
+
+func (c *OrgUIController) endInheritanceUI(
+	w http.ResponseWriter,
+	r *http.Request,
+	end func(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in services.EndInheritanceInput) (*services.InheritanceWriteResult, error),
+) {
+	tenantID, initiatorID, ok := c.ensureInheritanceWrite(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	effectiveDate, ok := c.inheritanceWriteDate(w, r, tenantID)
+	if !ok {
+		return
+	}
+	if _, err := end(r.Context(), tenantID, ensureRequestID(r), initiatorID, services.EndInheritanceInput{
+		ID:            id,
+		EffectiveDate: effectiveDate,
+	}); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderInheritancePage(w, r, tenantID, effectiveDate, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, inheritancePageURL(effectiveDate))
+}
+
+func (c *OrgUIController) RescindInheritanceRuleUI(w http.ResponseWriter, r *http.Request) {
+	c.rescindInheritanceUI(w, r, c.org.DeleteInheritanceRule)
+}
+
+func (c *OrgUIController) RescindInheritanceBreakUI(w http.ResponseWriter, r *http.Request) {
+	c.rescindInheritanceUI(w, r, c.org.DeleteInheritanceBreak)
+}
+
+func (c *OrgUIController) rescindInheritanceUI(
+	w http.ResponseWriter,
+	r *http.Request,
+	rescind func(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, id uuid.UUID) (*services.InheritanceWriteResult, error),
+) {
+	tenantID, initiatorID, ok := c.ensureInheritanceWrite(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	effectiveDate, err := effectiveDateFromWriteForm(r)
+	if err != nil || effectiveDate.IsZero() {
+		effectiveDate = normalizeValidTimeDayUTC(time.Now().UTC())
+	}
+	if _, err := rescind(r.Context(), tenantID, ensureRequestID(r), initiatorID, id); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderInheritancePage(w, r, tenantID, effectiveDate, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, inheritancePageURL(effectiveDate))
+}
+
+func (c *OrgUIController) CreateInheritanceBreakUI(w http.ResponseWriter, r *http.Request) {
+	tenantID, initiatorID, ok := c.ensureInheritanceWrite(w, r)
+	if !ok {
+		return
+	}
+	effectiveDate, ok := c.inheritanceWriteDate(w, r, tenantID)
+	if !ok {
+		return
+	}
+	orgNodeID, err := uuid.Parse(param(r, "org_node_id"))
+	if err != nil {
+		c.renderInheritancePage(w, r, tenantID, effectiveDate, http.StatusUnprocessableEntity, []string{"org_node_id is required"})
+		return
+	}
+	if _, err := c.org.CreateInheritanceBreak(r.Context(), tenantID, ensureRequestID(r), initiatorID, services.CreateInheritanceBreakInput{
+		AttributeName: param(r, "attribute_name"),
+		OrgNodeID:     orgNodeID,
+		EffectiveDate: effectiveDate,
+	}); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderInheritancePage(w, r, tenantID, effectiveDate, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, inheritancePageURL(effectiveDate))
+}
+
+func inheritancePageURL(effectiveDate time.Time) string {
+	q := url.Values{}
+	q.Set("effective_date", effectiveDate.UTC().Format(time.DateOnly))
+	return "/org/inheritance?" + q.Encode()
+}
@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	orgtemplates "github.com/iota-uz/iota-sdk/modules/org/presentation/templates/pages/org"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func (c *OrgUIController) PersonnelEventImportPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgAssignmentsAuthzObject, "assign")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgAssignmentsAuthzObject, "assign") {
+		return
+	}
+	effectiveDate, err := effectiveDateFromQuery(r)
+	if err != nil {
+		effectiveDate = normalizeValidTimeDayUTC(time.Now().UTC())
+	}
+	templ.Handler(orgtemplates.PersonnelEventImportPage(orgtemplates.PersonnelEventImportPageProps{
+		EffectiveDate: effectiveDate.Format(time.DateOnly),
+	}), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *OrgUIController) PreviewPersonnelEventImportUI(w http.ResponseWriter, r *http.Request) {
+	c.personnelEventImportUI(w, r, true)
+}
+
+func (c *OrgUIController) ApplyPersonnelEventImportUI(w http.ResponseWriter, r *http.Request) {
+	c.personnelEventImportUI(w, r, false)
+}
+
+func (c *OrgUIController) personnelEventImportUI(w http.ResponseWriter, r *http.Request, dryRun bool) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgAssignmentsAuthzObject, "assign")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgAssignmentsAuthzObject, "assign") {
+		return
+	}
+
+	renderErr := func(status int, msg string) {
+		w.WriteHeader(status)
+		templ.Handler(orgtemplates.PersonnelEventImportResult(orgtemplates.PersonnelEventImportResultProps{
+			Errors: []string{msg},
+		})).ServeHTTP(w, r)
+	}
+
+	file, filename, err := openPersonnelEventImportUpload(w, r)
+	if err != nil {
+		renderErr(http.StatusUnprocessableEntity, "file is required")
+		return
+	}
+	defer func() { _ = file.Close() }()
+	rows, err := services.ReadPersonnelEventImportFile(r.Context(), filename, file)
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		renderErr(status, msg)
+		return
+	}
+	batchSize := 0
+	if v := strings.TrimSpace(r.FormValue("batch_size")); v != "" {
+		batchSize, err = strconv.Atoi(v)
+		if err != nil || batchSize <= 0 {
+			renderErr(http.StatusUnprocessableEntity, "batch_size must be a positive integer")
+			return
+		}
+	}
+
+	report, err := runPersonnelEventImport(r.Context(), c.org, tenantID, authzutil.NormalizedUserUUID(tenantID, currentUser), r.FormValue("request_id_prefix"), rows, batchSize, dryRun)
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		renderErr(status, msg)
+		return
+	}
+	templ.Handler(orgtemplates.PersonnelEventImportResult(orgtemplates.PersonnelEventImportResultProps{
+		Report: report,
+	})).ServeHTTP(w, r)
+}
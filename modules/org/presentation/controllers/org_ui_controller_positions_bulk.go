@@ -0,0 +1,381 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/mappers"
+	orgtemplates "github.com/iota-uz/iota-sdk/modules/org/presentation/templates/pages/org"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+// bulkPositionFields lists the position fields the bulk update form can set.
+var bulkPositionFields = []string{
+	"title",
+	"lifecycle_status",
+	"position_type",
+	"employment_type",
+	"capacity_fte",
+	"job_profile_id",
+	"job_level_code",
+	"cost_center_code",
+}
+
+type positionsBulkQuery struct {
+	effectiveDate   time.Time
+	nodeID          *uuid.UUID
+	q               string
+	lifecycleStatus string
+	staffingState   string
+}
+
+// positionsBulkQueryFromRequest reads the list filters. They always travel in the query string, also
+// on writes, so they never clash with posted fields such as the template's lifecycle_status.
+func positionsBulkQueryFromRequest(r *http.Request) (positionsBulkQuery, error) {
+	query := r.URL.Query()
+	effectiveDate, err := effectiveDateFromQuery(r)
+	if err != nil {
+		return positionsBulkQuery{}, errors.New("effective_date is invalid")
+	}
+	if effectiveDate.IsZero() {
+		effectiveDate = normalizeValidTimeDayUTC(time.Now().UTC())
+	}
+	q := positionsBulkQuery{
+		effectiveDate:   effectiveDate,
+		q:               strings.TrimSpace(query.Get("q")),
+		lifecycleStatus: strings.TrimSpace(query.Get("lifecycle_status")),
+		staffingState:   strings.TrimSpace(query.Get("staffing_state")),
+	}
+	if raw := strings.TrimSpace(query.Get("node_id")); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return positionsBulkQuery{}, errors.New("invalid node_id")
+		}
+		q.nodeID = &id
+	}
+	return q, nil
+}
+
+func (q positionsBulkQuery) filter() services.BulkPositionFilter {
+	out := services.BulkPositionFilter{OrgNodeID: q.nodeID}
+	if q.q != "" {
+		v := q.q
+		out.Q = &v
+	}
+	if q.lifecycleStatus != "" {
+		v := q.lifecycleStatus
+		out.LifecycleStatus = &v
+	}
+	if q.staffingState != "" {
+		v := q.staffingState
+		out.StaffingState = &v
+	}
+	return out
+}
+
+func (c *OrgUIController) PositionsBulkPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgPositionsAuthzObject, "read")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgPositionsAuthzObject, "read") {
+		return
+	}
+	q, err := positionsBulkQueryFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.renderPositionsBulkPage(w, r, tenantID, q, http.StatusOK, nil, nil)
+}
+
+func (c *OrgUIController) renderPositionsBulkPage(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, q positionsBulkQuery, statusCode int, result *services.BulkPositionsResult, errs []string) {
+	ensureOrgPageCapabilities(r, orgPositionsAuthzObject, "write")
+
+	props := orgtemplates.PositionsBulkPageProps{
+		EffectiveDate:   q.effectiveDate.UTC().Format(time.DateOnly),
+		Q:               q.q,
+		LifecycleStatus: q.lifecycleStatus,
+		StaffingState:   q.staffingState,
+		Fields:          bulkPositionFields,
+		MaxPositions:    services.MaxBulkPositions,
+		Errors:          errs,
+	}
+	if q.nodeID != nil {
+		props.NodeID = q.nodeID.String()
+		props.NodeLabel = c.orgNodeLabelFor(r, tenantID, *q.nodeID, q.effectiveDate)
+	}
+	if result != nil {
+		props.ResultCount = len(result.Items)
+		props.ResultRequestID = result.RequestID
+	}
+
+	filter := q.filter()
+	isAutoCreated := false
+	asOf := q.effectiveDate
+	rows, _, err := c.org.GetPositions(r.Context(), tenantID, services.GetPositionsInput{
+		AsOf:            &asOf,
+		OrgNodeID:       filter.OrgNodeID,
+		Q:               filter.Q,
+		LifecycleStatus: filter.LifecycleStatus,
+		StaffingState:   filter.StaffingState,
+		IsAutoCreated:   &isAutoCreated,
+		Limit:           200,
+	})
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		props.Errors = append(props.Errors, msg)
+		if statusCode == http.StatusOK {
+			statusCode = status
+		}
+	} else {
+		props.Positions = mappers.PositionsToViewModels(rows)
+	}
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.PositionsBulkPage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+// ensurePositionsBulkWrite runs the write guards and parses the filters carried by the form.
+func (c *OrgUIController) ensurePositionsBulkWrite(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, positionsBulkQuery, bool) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgPositionsAuthzObject, "write")
+		return uuid.Nil, uuid.Nil, positionsBulkQuery{}, false
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return uuid.Nil, uuid.Nil, positionsBulkQuery{}, false
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgPositionsAuthzObject, "write") {
+		return uuid.Nil, uuid.Nil, positionsBulkQuery{}, false
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, positionsBulkQuery{}, false
+	}
+	q, err := positionsBulkQueryFromRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, positionsBulkQuery{}, false
+	}
+	return tenantID, authzutil.NormalizedUserUUID(tenantID, currentUser), q, true
+}
+
+func (c *OrgUIController) renderPositionsBulkResult(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, q positionsBulkQuery, requestID string, res *services.BulkPositionsResult, err error) {
+	if err != nil {
+		_, _, status := mapServiceErrorToForm(err)
+		c.renderPositionsBulkPage(w, r, tenantID, q, status, nil, []string{attachRequestID(scenarioErrorMessage(err), requestID)})
+		return
+	}
+	c.renderPositionsBulkPage(w, r, tenantID, q, http.StatusOK, res, nil)
+}
+
+func (c *OrgUIController) BulkCreatePositionsUI(w http.ResponseWriter, r *http.Request) {
+	tenantID, initiatorID, q, ok := c.ensurePositionsBulkWrite(w, r)
+	if !ok {
+		return
+	}
+	effectiveDate, err := effectiveDateFromWriteForm(r)
+	if err != nil || effectiveDate.IsZero() {
+		c.renderPositionsBulkPage(w, r, tenantID, q, http.StatusUnprocessableEntity, nil, []string{"effective_date is required"})
+		return
+	}
+	template, codes, err := bulkCreatePositionsFromForm(r, effectiveDate)
+	if err != nil {
+		c.renderPositionsBulkPage(w, r, tenantID, q, http.StatusUnprocessableEntity, nil, []string{err.Error()})
+		return
+	}
+
+	requestID := ensureRequestID(r)
+	res, err := c.org.BulkCreatePositions(r.Context(), tenantID, requestID, initiatorID, services.BulkCreatePositionsInput{
+		Template: template,
+		Codes:    codes,
+	})
+	c.renderPositionsBulkResult(w, r, tenantID, q, requestID, res, err)
+}
+
+func (c *OrgUIController) BulkUpdatePositionsUI(w http.ResponseWriter, r *http.Request) {
+	tenantID, initiatorID, q, ok := c.ensurePositionsBulkWrite(w, r)
+	if !ok {
+		return
+	}
+	effectiveDate, err := effectiveDateFromWriteForm(r)
+	if err != nil || effectiveDate.IsZero() {
+		c.renderPositionsBulkPage(w, r, tenantID, q, http.StatusUnprocessableEntity, nil, []string{"effective_date is required"})
+		return
+	}
+	patch := services.UpdatePositionInput{
+		EffectiveDate: effectiveDate,
+		ReasonCode:    param(r, "reason_code"),
+		ReasonNote:    optionalFormString(r, "reason_note"),
+	}
+	if err := applyBulkPositionField(&patch, param(r, "field"), param(r, "value")); err != nil {
+		c.renderPositionsBulkPage(w, r, tenantID, q, http.StatusUnprocessableEntity, nil, []string{err.Error()})
+		return
+	}
+
+	filter := q.filter()
+	if param(r, "scope") != "filter" {
+		ids, err := positionIDsFromForm(r)
+		if err != nil {
+			c.renderPositionsBulkPage(w, r, tenantID, q, http.StatusUnprocessableEntity, nil, []string{err.Error()})
+			return
+		}
+		filter = services.BulkPositionFilter{PositionIDs: ids}
+	}
+
+	requestID := ensureRequestID(r)
+	res, err := c.org.BulkUpdatePositions(r.Context(), tenantID, requestID, initiatorID, services.BulkUpdatePositionsInput{
+		Filter: filter,
+		Patch:  patch,
+	})
+	c.renderPositionsBulkResult(w, r, tenantID, q, requestID, res, err)
+}
+
+func (c *OrgUIController) BulkTransferPositionsUI(w http.ResponseWriter, r *http.Request) {
+	tenantID, initiatorID, q, ok := c.ensurePositionsBulkWrite(w, r)
+	if !ok {
+		return
+	}
+	effectiveDate, err := effectiveDateFromWriteForm(r)
+	if err != nil || effectiveDate.IsZero() {
+		c.renderPositionsBulkPage(w, r, tenantID, q, http.StatusUnprocessableEntity, nil, []string{"effective_date is required"})
+		return
+	}
+	targetID, err := uuid.Parse(param(r, "target_org_node_id"))
+	if err != nil {
+		c.renderPositionsBulkPage(w, r, tenantID, q, http.StatusUnprocessableEntity, nil, []string{"target_org_node_id is required"})
+		return
+	}
+	ids, err := positionIDsFromForm(r)
+	if err != nil {
+		c.renderPositionsBulkPage(w, r, tenantID, q, http.StatusUnprocessableEntity, nil, []string{err.Error()})
+		return
+	}
+
+	requestID := ensureRequestID(r)
+	res, err := c.org.BulkTransferPositions(r.Context(), tenantID, requestID, initiatorID, services.BulkTransferPositionsInput{
+		PositionIDs:     ids,
+		TargetOrgNodeID: targetID,
+		EffectiveDate:   effectiveDate,
+		ReasonCode:      param(r, "reason_code"),
+		ReasonNote:      optionalFormString(r, "reason_note"),
+	})
+	c.renderPositionsBulkResult(w, r, tenantID, q, requestID, res, err)
+}
+
+// bulkCreatePositionsFromForm builds the create template and the generated codes. The form mirrors
+// the single create form, with code_prefix/start_number/count in place of code.
+func bulkCreatePositionsFromForm(r *http.Request, effectiveDate time.Time) (services.CreatePositionInput, []string, error) {
+	orgNodeID, err := uuid.Parse(param(r, "org_node_id"))
+	if err != nil {
+		return services.CreatePositionInput{}, nil, errors.New("org_node_id is required")
+	}
+	jobProfileID, err := uuid.Parse(param(r, "job_profile_id"))
+	if err != nil {
+		return services.CreatePositionInput{}, nil, errors.New("job_profile_id is required")
+	}
+	prefix := param(r, "code_prefix")
+	if prefix == "" {
+		return services.CreatePositionInput{}, nil, errors.New("code_prefix is required")
+	}
+	start := 1
+	if raw := param(r, "start_number"); raw != "" {
+		start, err = strconv.Atoi(raw)
+		if err != nil || start < 0 {
+			return services.CreatePositionInput{}, nil, errors.New("invalid start_number")
+		}
+	}
+	count, err := strconv.Atoi(param(r, "count"))
+	if err != nil || count <= 0 {
+		return services.CreatePositionInput{}, nil, errors.New("invalid count")
+	}
+	if count > services.MaxBulkPositions {
+		return services.CreatePositionInput{}, nil, errors.New("count is too large")
+	}
+	capacity := 1.0
+	if raw := param(r, "capacity_fte"); raw != "" {
+		capacity, err = strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return services.CreatePositionInput{}, nil, errors.New("invalid capacity_fte")
+		}
+	}
+
+	return services.CreatePositionInput{
+		OrgNodeID:       orgNodeID,
+		EffectiveDate:   effectiveDate,
+		Title:           optionalFormString(r, "title"),
+		LifecycleStatus: strings.TrimSpace(r.PostFormValue("lifecycle_status")),
+		PositionType:    param(r, "position_type"),
+		EmploymentType:  param(r, "employment_type"),
+		CapacityFTE:     capacity,
+		JobProfileID:    jobProfileID,
+		JobLevelCode:    optionalFormString(r, "job_level_code"),
+		ReasonCode:      param(r, "reason_code"),
+		ReasonNote:      optionalFormString(r, "reason_note"),
+	}, services.BulkPositionCodes(prefix, start, count), nil
+}
+
+// applyBulkPositionField sets one bulkPositionFields entry on patch from its form value.
+func applyBulkPositionField(patch *services.UpdatePositionInput, field, value string) error {
+	value = strings.TrimSpace(value)
+	switch field {
+	case "title":
+		patch.Title = &value
+	case "lifecycle_status":
+		patch.LifecycleStatus = &value
+	case "position_type":
+		patch.PositionType = &value
+	case "employment_type":
+		patch.EmploymentType = &value
+	case "capacity_fte":
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return errors.New("invalid capacity_fte")
+		}
+		patch.CapacityFTE = &v
+	case "job_profile_id":
+		id, err := uuid.Parse(value)
+		if err != nil {
+			return errors.New("invalid job_profile_id")
+		}
+		patch.JobProfileID = &id
+	case "job_level_code":
+		patch.JobLevelCode = &value
+	case "cost_center_code":
+		patch.CostCenterCode = &value
+	default:
+		return errors.New("unknown field")
+	}
+	return nil
+}
+
+func positionIDsFromForm(r *http.Request) ([]uuid.UUID, error) {
+	raw := r.Form["position_ids"]
+	if len(raw) == 0 {
+		return nil, errors.New("select at least one position")
+	}
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, v := range raw {
+		id, err := uuid.Parse(strings.TrimSpace(v))
+		if err != nil {
+			return nil, errors.New("invalid position_ids")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func TestApplyBulkPositionField(t *testing.T) {
+	for _, field := range bulkPositionFields {
+		value := "x"
+		switch field {
+		case "capacity_fte":
+			value = "0.5"
+		case "job_profile_id":
+			value = uuid.NewString()
+		}
+		var patch services.UpdatePositionInput
+		if err := applyBulkPositionField(&patch, field, value); err != nil {
+			t.Fatalf("field %s: unexpected error: %v", field, err)
+		}
+		if reflect.DeepEqual(patch, services.UpdatePositionInput{}) {
+			t.Fatalf("field %s: expected the patch to be set", field)
+		}
+	}
+
+	var patch services.UpdatePositionInput
+	if err := applyBulkPositionField(&patch, "capacity_fte", "abc"); err == nil {
+		t.Fatal("expected invalid capacity_fte to fail")
+	}
+	if err := applyBulkPositionField(&patch, "code", "X"); err == nil {
+		t.Fatal("expected unknown field to fail")
+	}
+}
+
+func TestPositionsBulkFiltersComeFromQuery(t *testing.T) {
+	nodeID := uuid.New()
+	form := url.Values{"lifecycle_status": {"planned"}, "position_ids": {uuid.NewString()}}
+	r := httptest.NewRequest("POST", "/org/positions/bulk/create?effective_date=2025-01-01&lifecycle_status=active&node_id="+nodeID.String(), strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := r.ParseForm(); err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := positionsBulkQueryFromRequest(r)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if q.lifecycleStatus != "active" || q.nodeID == nil || *q.nodeID != nodeID {
+		t.Fatalf("unexpected filters: %+v", q)
+	}
+	if got := q.effectiveDate.Format("2006-01-02"); got != "2025-01-01" {
+		t.Fatalf("unexpected effective date %s", got)
+	}
+	ids, err := positionIDsFromForm(r)
+	if err != nil || len(ids) != 1 {
+		t.Fatalf("expected one position id, got %v (%v)", ids, err)
+	}
+}
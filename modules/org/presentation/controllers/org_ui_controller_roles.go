@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/mappers"
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/templates/components/orgui"
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// nodeRolesRequest carries what the node panel Roles handlers share after their guards pass.
+type nodeRolesRequest struct {
+	tenantID      uuid.UUID
+	initiatorID   uuid.UUID
+	nodeID        uuid.UUID
+	effectiveDate time.Time
+}
+
+// ensureNodeRolesAccess runs the guards shared by the node panel Roles section and resolves
+// the node and the panel's as-of date.
+func (c *OrgUIController) ensureNodeRolesAccess(w http.ResponseWriter, r *http.Request) (nodeRolesRequest, bool) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgRoleAssignmentsAuthzObject, "admin")
+		return nodeRolesRequest{}, false
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return nodeRolesRequest{}, false
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgRoleAssignmentsAuthzObject, "admin") {
+		return nodeRolesRequest{}, false
+	}
+	nodeID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return nodeRolesRequest{}, false
+	}
+	effectiveDate, err := effectiveDateFromQuery(r)
+	if err != nil || effectiveDate.IsZero() {
+		http.Error(w, "effective_date is required", http.StatusBadRequest)
+		return nodeRolesRequest{}, false
+	}
+	if r.Method == http.MethodPost {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, "invalid form", http.StatusBadRequest)
+			return nodeRolesRequest{}, false
+		}
+	}
+	return nodeRolesRequest{
+		tenantID:      tenantID,
+		initiatorID:   authzutil.NormalizedUserUUID(tenantID, currentUser),
+		nodeID:        nodeID,
+		effectiveDate: effectiveDate,
+	}, true
+}
+
+func (c *OrgUIController) NodeRoles(w http.ResponseWriter, r *http.Request) {
+	req, ok := c.ensureNodeRolesAccess(w, r)
+	if !ok {
+		return
+	}
+	c.renderNodeRoles(w, r, req, orgui.NodeRolesProps{}, http.StatusOK)
+}
+
+// renderNodeRoles fills the section's assignments and form options into props and writes it.
+// The section stays empty while role reads are disabled.
+func (c *OrgUIController) renderNodeRoles(w http.ResponseWriter, r *http.Request, req nodeRolesRequest, props orgui.NodeRolesProps, statusCode int) {
+	if !configuration.Use().OrgRoleReadEnabled {
+		return
+	}
+	props.NodeID = req.nodeID.String()
+	props.EffectiveDate = formatValidDate(req.effectiveDate)
+	if props.Errors == nil {
+		props.Errors = map[string]string{}
+	}
+
+	roles, err := c.org.ListRoles(r.Context(), req.tenantID)
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		http.Error(w, msg, status)
+		return
+	}
+	props.Roles = mappers.RolesToOptions(roles)
+
+	subjectLabels := map[uuid.UUID]string{}
+	if c.users != nil {
+		if all, err := c.users.GetAll(r.Context()); err == nil {
+			for _, u := range all {
+				id := authzutil.NormalizedUserUUID(req.tenantID, u)
+				label := strings.TrimSpace(u.FirstName() + " " + u.LastName())
+				if label == "" {
+					label = u.Email().Value()
+				}
+				subjectLabels[id] = label
+				props.Users = append(props.Users, viewmodels.OrgRoleSubjectOption{ID: id, Label: label})
+			}
+		}
+	}
+	sort.SliceStable(props.Users, func(i, j int) bool { return props.Users[i].Label < props.Users[j].Label })
+
+	items, _, err := c.org.ListRoleAssignments(r.Context(), req.tenantID, req.nodeID, req.effectiveDate, true, nil, nil, nil)
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		props.FormError = msg
+		if statusCode == http.StatusOK {
+			statusCode = status
+		}
+	}
+	sourceLabels := map[uuid.UUID]string{}
+	for _, it := range items {
+		if it.SourceOrgNodeID == req.nodeID {
+			continue
+		}
+		if _, ok := sourceLabels[it.SourceOrgNodeID]; !ok {
+			sourceLabels[it.SourceOrgNodeID] = c.orgNodeLabelFor(r, req.tenantID, it.SourceOrgNodeID, req.effectiveDate)
+		}
+	}
+	props.Assignments = mappers.RoleAssignmentsToNodeRoles(req.nodeID, items, roles, subjectLabels, sourceLabels)
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgui.NodeRoles(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *OrgUIController) CreateNodeRoleAssignmentUI(w http.ResponseWriter, r *http.Request) {
+	req, ok := c.ensureNodeRolesAccess(w, r)
+	if !ok {
+		return
+	}
+
+	props := orgui.NodeRolesProps{
+		RoleID:    param(r, "role_id"),
+		SubjectID: param(r, "subject_id"),
+		Errors:    map[string]string{},
+	}
+	roleID, err := uuid.Parse(props.RoleID)
+	if err != nil {
+		props.Errors["role_id"] = "role_id is required"
+	}
+	subjectID, err := uuid.Parse(props.SubjectID)
+	if err != nil {
+		props.Errors["subject_id"] = "subject_id is required"
+	}
+	from, err := effectiveDateFromWriteForm(r)
+	if err != nil || from.IsZero() {
+		props.Errors["effective_date"] = "effective_date is required"
+	}
+	if len(props.Errors) > 0 {
+		c.renderNodeRoles(w, r, req, props, http.StatusUnprocessableEntity)
+		return
+	}
+
+	_, err = c.org.CreateRoleAssignment(r.Context(), req.tenantID, ensureRequestID(r), req.initiatorID, services.CreateRoleAssignmentInput{
+		RoleID:        roleID,
+		SubjectType:   param(r, "subject_type"),
+		SubjectID:     subjectID,
+		OrgNodeID:     req.nodeID,
+		EffectiveDate: from,
+	})
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		props.FormError = msg
+		c.renderNodeRoles(w, r, req, props, status)
+		return
+	}
+	c.renderNodeRoles(w, r, req, orgui.NodeRolesProps{}, http.StatusOK)
+}
+
+func (c *OrgUIController) EndNodeRoleAssignmentUI(w http.ResponseWriter, r *http.Request) {
+	req, ok := c.ensureNodeRolesAccess(w, r)
+	if !ok {
+		return
+	}
+
+	assignmentID, err := uuid.Parse(mux.Vars(r)["assignment_id"])
+	if err != nil {
+		http.Error(w, "invalid assignment_id", http.StatusBadRequest)
+		return
+	}
+	endDate, err := parseEffectiveDate(param(r, "end_date"))
+	if err != nil || endDate.IsZero() {
+		c.renderNodeRoles(w, r, req, orgui.NodeRolesProps{FormError: "end_date is required"}, http.StatusUnprocessableEntity)
+		return
+	}
+	if _, err := c.org.EndRoleAssignment(r.Context(), req.tenantID, ensureRequestID(r), req.initiatorID, services.EndRoleAssignmentInput{
+		AssignmentID: assignmentID,
+		EndDate:      endDate,
+	}); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderNodeRoles(w, r, req, orgui.NodeRolesProps{FormError: msg}, status)
+		return
+	}
+	c.renderNodeRoles(w, r, req, orgui.NodeRolesProps{}, http.StatusOK)
+}
+
+func (c *OrgUIController) RescindNodeRoleAssignmentUI(w http.ResponseWriter, r *http.Request) {
+	req, ok := c.ensureNodeRolesAccess(w, r)
+	if !ok {
+		return
+	}
+
+	assignmentID, err := uuid.Parse(mux.Vars(r)["assignment_id"])
+	if err != nil {
+		http.Error(w, "invalid assignment_id", http.StatusBadRequest)
+		return
+	}
+	if _, err := c.org.RescindRoleAssignment(r.Context(), req.tenantID, ensureRequestID(r), req.initiatorID, services.RescindRoleAssignmentInput{
+		AssignmentID: assignmentID,
+	}); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderNodeRoles(w, r, req, orgui.NodeRolesProps{FormError: msg}, status)
+		return
+	}
+	c.renderNodeRoles(w, r, req, orgui.NodeRolesProps{}, http.StatusOK)
+}
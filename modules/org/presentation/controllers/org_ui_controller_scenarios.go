@@ -0,0 +1,468 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/iota-uz/iota-sdk/modules/core/authzutil"
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	orgtemplates "github.com/iota-uz/iota-sdk/modules/org/presentation/templates/pages/org"
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// scenarioAPI borrows the API controller's conversion path so the UI converts scenarios exactly
+// like /org/api/scenarios does.
+func (c *OrgUIController) scenarioAPI() *OrgAPIController {
+	api := &OrgAPIController{app: c.app, org: c.org}
+	if c.app != nil {
+		api.changeRequests = c.app.Service(services.ChangeRequestService{}).(*services.ChangeRequestService)
+	}
+	return api
+}
+
+func (c *OrgUIController) ScenariosPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgScenariosAuthzObject, "read")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgScenariosAuthzObject, "read") {
+		return
+	}
+	c.renderScenariosPage(w, r, tenantID, http.StatusOK, nil)
+}
+
+func (c *OrgUIController) renderScenariosPage(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, statusCode int, errs []string) {
+	ensureOrgPageCapabilities(r, orgScenariosAuthzObject, "write")
+
+	props := orgtemplates.ScenariosPageProps{
+		EffectiveDate: normalizeValidTimeDayUTC(time.Now().UTC()).Format(time.DateOnly),
+		Errors:        errs,
+	}
+	rows, err := c.org.ListScenarios(r.Context(), tenantID, 0)
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		props.Errors = append(props.Errors, msg)
+		statusCode = status
+	}
+	for i := range rows {
+		props.Scenarios = append(props.Scenarios, scenarioListItemOf(&rows[i]))
+	}
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.ScenariosPage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *OrgUIController) CreateScenarioUI(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgScenariosAuthzObject, "write")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgScenariosAuthzObject, "write") {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	effectiveDate, err := effectiveDateFromWriteForm(r)
+	if err != nil {
+		c.renderScenariosPage(w, r, tenantID, http.StatusUnprocessableEntity, []string{"effective_date is required"})
+		return
+	}
+
+	var description *string
+	if v := strings.TrimSpace(param(r, "description")); v != "" {
+		description = &v
+	}
+	row, err := c.org.CreateScenario(r.Context(), tenantID, authzutil.NormalizedUserUUID(tenantID, currentUser), services.ScenarioInput{
+		Name:          param(r, "name"),
+		Description:   description,
+		EffectiveDate: effectiveDate,
+	})
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderScenariosPage(w, r, tenantID, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, "/org/scenarios/"+row.ID.String())
+}
+
+func (c *OrgUIController) ScenarioPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgScenariosAuthzObject, "read")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgScenariosAuthzObject, "read") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	c.renderScenarioPage(w, r, tenantID, authzutil.NormalizedUserUUID(tenantID, currentUser), id, http.StatusOK, nil)
+}
+
+func (c *OrgUIController) renderScenarioPage(w http.ResponseWriter, r *http.Request, tenantID uuid.UUID, userID uuid.UUID, id uuid.UUID, statusCode int, errs []string) {
+	ensureOrgPageCapabilities(r, orgScenariosAuthzObject, "write")
+	ensureOrgPageCapabilities(r, orgChangeRequestsAuthzObj, "write")
+
+	row, err := c.org.GetScenario(r.Context(), tenantID, id)
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		http.Error(w, msg, status)
+		return
+	}
+
+	props := orgtemplates.ScenarioPageProps{
+		Scenario:              scenarioDetailOf(row),
+		CommandTypes:          services.ScenarioCommandTypes,
+		ChangeRequestsEnabled: configuration.Use().OrgChangeRequestsEnabled,
+		Errors:                errs,
+	}
+	if len(row.Commands) > 0 {
+		eval, err := c.org.EvaluateScenario(r.Context(), tenantID, userID, row)
+		if err != nil {
+			props.EvaluationError = scenarioErrorMessage(err)
+		} else {
+			props.Evaluation = scenarioEvaluationViewModel(eval)
+		}
+	}
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.ScenarioPage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func (c *OrgUIController) AddScenarioCommandUI(w http.ResponseWriter, r *http.Request) {
+	c.editScenarioCommands(w, r, func(commands []services.ScenarioCommand) ([]services.ScenarioCommand, error) {
+		payload := strings.TrimSpace(param(r, "payload"))
+		if !json.Valid([]byte(payload)) {
+			return nil, errors.New("payload must be a JSON object")
+		}
+		return append(commands, services.ScenarioCommand{
+			Type:    strings.TrimSpace(param(r, "type")),
+			Payload: json.RawMessage(payload),
+		}), nil
+	})
+}
+
+func (c *OrgUIController) RemoveScenarioCommandUI(w http.ResponseWriter, r *http.Request) {
+	c.editScenarioCommands(w, r, func(commands []services.ScenarioCommand) ([]services.ScenarioCommand, error) {
+		index, err := strconv.Atoi(mux.Vars(r)["index"])
+		if err != nil || index < 0 || index >= len(commands) {
+			return nil, errors.New("command index is invalid")
+		}
+		out := make([]services.ScenarioCommand, 0, len(commands)-1)
+		out = append(out, commands[:index]...)
+		return append(out, commands[index+1:]...), nil
+	})
+}
+
+func (c *OrgUIController) editScenarioCommands(w http.ResponseWriter, r *http.Request, edit func([]services.ScenarioCommand) ([]services.ScenarioCommand, error)) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgScenariosAuthzObject, "write")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgScenariosAuthzObject, "write") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "invalid form", http.StatusBadRequest)
+		return
+	}
+	userID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+
+	row, err := c.org.GetScenario(r.Context(), tenantID, id)
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		http.Error(w, msg, status)
+		return
+	}
+	commands, err := edit(row.Commands)
+	if err != nil {
+		c.renderScenarioPage(w, r, tenantID, userID, id, http.StatusUnprocessableEntity, []string{err.Error()})
+		return
+	}
+	if _, err := c.org.UpdateScenario(r.Context(), tenantID, id, services.ScenarioInput{
+		Name:          row.Name,
+		Description:   row.Description,
+		EffectiveDate: row.EffectiveDate,
+		Commands:      commands,
+	}); err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		c.renderScenarioPage(w, r, tenantID, userID, id, status, []string{msg})
+		return
+	}
+	redirectUI(w, r, "/org/scenarios/"+id.String())
+}
+
+func (c *OrgUIController) ConvertScenarioUI(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgScenariosAuthzObject, "write")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !configuration.Use().OrgChangeRequestsEnabled {
+		http.NotFound(w, r)
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgScenariosAuthzObject, "write") {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgChangeRequestsAuthzObj, "write") {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		http.Error(w, "invalid id", http.StatusBadRequest)
+		return
+	}
+
+	userID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	if _, _, err := c.scenarioAPI().convertScenario(r.Context(), tenantID, ensureRequestID(r), userID, id); err != nil {
+		status := http.StatusUnprocessableEntity
+		var svcErr *services.ServiceError
+		if errors.As(err, &svcErr) {
+			status = svcErr.Status
+		}
+		c.renderScenarioPage(w, r, tenantID, userID, id, status, []string{scenarioErrorMessage(err)})
+		return
+	}
+	redirectUI(w, r, "/org/scenarios/"+id.String())
+}
+
+func (c *OrgUIController) CompareScenariosPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgScenariosAuthzObject, "read")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgScenariosAuthzObject, "read") {
+		return
+	}
+
+	statusCode := http.StatusOK
+	props := orgtemplates.ScenarioComparePageProps{Comparison: &viewmodels.OrgScenarioComparison{}}
+	// The list page submits one "ids" value per checked scenario; the API style comma list works too.
+	ids, err := parseScenarioIDs(strings.Join(r.URL.Query()["ids"], ","))
+	if err != nil {
+		statusCode = http.StatusBadRequest
+		props.Comparison.Errors = append(props.Comparison.Errors, err.Error())
+	}
+
+	userID := authzutil.NormalizedUserUUID(tenantID, currentUser)
+	var evals []*services.ScenarioEvaluation
+	for _, id := range ids {
+		row, err := c.org.GetScenario(r.Context(), tenantID, id)
+		if err != nil {
+			msg, _, status := mapServiceErrorToForm(err)
+			statusCode = status
+			props.Comparison.Errors = append(props.Comparison.Errors, msg)
+			continue
+		}
+		eval, err := c.org.EvaluateScenario(r.Context(), tenantID, userID, row)
+		if err != nil {
+			props.Comparison.Errors = append(props.Comparison.Errors, fmt.Sprintf("%s: %s", row.Name, scenarioErrorMessage(err)))
+			continue
+		}
+		evals = append(evals, eval)
+	}
+	props.Comparison = scenarioComparisonViewModel(evals, props.Comparison.Errors)
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.ScenarioComparePage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
+
+func scenarioErrorMessage(err error) string {
+	var cmdErr *services.CommandError
+	if errors.As(err, &cmdErr) {
+		msg, _, _ := mapServiceErrorToForm(cmdErr.Err)
+		return fmt.Sprintf("#%d %s: %s", cmdErr.Index+1, cmdErr.Type, msg)
+	}
+	msg, _, _ := mapServiceErrorToForm(err)
+	return msg
+}
+
+func scenarioListItemOf(row *services.ScenarioRow) viewmodels.OrgScenarioListItem {
+	out := viewmodels.OrgScenarioListItem{
+		ID:            row.ID.String(),
+		Name:          row.Name,
+		EffectiveDate: formatValidDate(row.EffectiveDate),
+		Status:        row.Status,
+		CommandsCount: len(row.Commands),
+		UpdatedAt:     row.UpdatedAt.UTC().Format("2006-01-02 15:04"),
+	}
+	if row.ChangeRequestID != nil {
+		out.ChangeRequestID = row.ChangeRequestID.String()
+	}
+	return out
+}
+
+func scenarioDetailOf(row *services.ScenarioRow) viewmodels.OrgScenarioDetail {
+	out := viewmodels.OrgScenarioDetail{
+		OrgScenarioListItem: scenarioListItemOf(row),
+	}
+	if row.Description != nil {
+		out.Description = *row.Description
+	}
+	for i, cmd := range row.Commands {
+		out.Commands = append(out.Commands, viewmodels.OrgScenarioCommand{Index: i, Type: cmd.Type, Payload: string(cmd.Payload)})
+	}
+	return out
+}
+
+func scenarioFiguresViewModel(f *services.SubtreeStaffingFigures) *viewmodels.OrgScenarioFigures {
+	if f == nil {
+		return nil
+	}
+	return &viewmodels.OrgScenarioFigures{
+		Positions:   f.Positions,
+		CapacityFTE: floatToString(f.CapacityFTE),
+		Headcount:   f.Headcount,
+		FTE:         floatToString(f.FTE),
+	}
+}
+
+func scenarioSummaryViewModel(report *services.SubtreeStaffingReport) *viewmodels.OrgScenarioSummary {
+	s := scenarioSummaryOf(report)
+	if s == nil {
+		return nil
+	}
+	return &viewmodels.OrgScenarioSummary{
+		PositionsTotal: s.PositionsTotal,
+		CapacityFTE:    floatToString(s.CapacityFTE),
+		OccupiedFTE:    floatToString(s.OccupiedFTE),
+		AvailableFTE:   floatToString(s.AvailableFTE),
+		FillRate:       strconv.FormatFloat(s.FillRate*100, 'f', 1, 64) + "%",
+	}
+}
+
+func scenarioSubtreeRowOf(cmp services.SubtreeStaffingComparison) viewmodels.OrgScenarioSubtreeRow {
+	row := viewmodels.OrgScenarioSubtreeRow{
+		Label:    strings.TrimSpace(cmp.Code + " " + cmp.Name),
+		Live:     scenarioFiguresViewModel(cmp.Live),
+		Scenario: scenarioFiguresViewModel(cmp.Scenario),
+	}
+	var live, scenario services.SubtreeStaffingFigures
+	if cmp.Live != nil {
+		live = *cmp.Live
+	}
+	if cmp.Scenario != nil {
+		scenario = *cmp.Scenario
+	}
+	hc := scenario.Headcount - live.Headcount
+	fte := scenario.FTE - live.FTE
+	row.HeadcountDelta = fmt.Sprintf("%+d", hc)
+	row.FTEDelta = strconv.FormatFloat(fte, 'f', -1, 64)
+	if fte >= 0 {
+		row.FTEDelta = "+" + row.FTEDelta
+	}
+	row.Changed = hc != 0 || fte != 0 || cmp.Live == nil || cmp.Scenario == nil
+	return row
+}
+
+func scenarioEvaluationViewModel(eval *services.ScenarioEvaluation) *viewmodels.OrgScenarioEvaluation {
+	out := &viewmodels.OrgScenarioEvaluation{
+		LiveSummary:     scenarioSummaryViewModel(eval.Live),
+		ScenarioSummary: scenarioSummaryViewModel(eval.Staged),
+	}
+	for _, n := range eval.Staged.Nodes {
+		total := n.Total
+		out.Tree = append(out.Tree, viewmodels.OrgScenarioTreeRow{
+			Depth: n.Depth,
+			Label: strings.TrimSpace(n.Code + " " + n.Name),
+			Total: *scenarioFiguresViewModel(&total),
+		})
+	}
+	for _, cmp := range eval.Subtrees {
+		out.Subtrees = append(out.Subtrees, scenarioSubtreeRowOf(cmp))
+	}
+	return out
+}
+
+func scenarioComparisonViewModel(evals []*services.ScenarioEvaluation, errs []string) *viewmodels.OrgScenarioComparison {
+	out := &viewmodels.OrgScenarioComparison{Errors: errs}
+	type rowKey struct {
+		id    uuid.UUID
+		label string
+		order int
+	}
+	rows := map[uuid.UUID]*viewmodels.OrgScenarioComparisonRow{}
+	var keys []rowKey
+	for col, eval := range evals {
+		out.Scenarios = append(out.Scenarios, scenarioListItemOf(eval.Scenario))
+		for i, cmp := range eval.Subtrees {
+			row, ok := rows[cmp.NodeID]
+			if !ok {
+				row = &viewmodels.OrgScenarioComparisonRow{
+					Label: strings.TrimSpace(cmp.Code + " " + cmp.Name),
+					Cells: make([]*viewmodels.OrgScenarioSubtreeRow, len(evals)),
+				}
+				rows[cmp.NodeID] = row
+				// The root row is first in every evaluation; keep it first here too.
+				order := 1
+				if i == 0 {
+					order = 0
+				}
+				keys = append(keys, rowKey{id: cmp.NodeID, label: row.Label, order: order})
+			}
+			cell := scenarioSubtreeRowOf(cmp)
+			row.Cells[col] = &cell
+		}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		if keys[i].order != keys[j].order {
+			return keys[i].order < keys[j].order
+		}
+		return keys[i].label < keys[j].label
+	})
+	for _, k := range keys {
+		out.Rows = append(out.Rows, *rows[k.id])
+	}
+	return out
+}
@@ -0,0 +1,247 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/a-h/templ"
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/core/presentation/templates/layouts"
+	orgtemplates "github.com/iota-uz/iota-sdk/modules/org/presentation/templates/pages/org"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/intl"
+	"github.com/iota-uz/iota-sdk/pkg/lens"
+	"github.com/iota-uz/iota-sdk/pkg/lens/builder"
+	"github.com/iota-uz/iota-sdk/pkg/lens/datasource"
+	"github.com/iota-uz/iota-sdk/pkg/lens/executor"
+)
+
+const (
+	orgTrendsDataSourceID = "org-trends"
+	// orgTrendsLatestPrefix selects the last bucket only, for metric cards.
+	orgTrendsLatestPrefix = "latest:"
+)
+
+// orgTrendsDataSource serves an already computed trend report to lens panels. Each panel query
+// names one metric ("headcount", "turnover_rate", ...); rows carry the bucket label and value.
+type orgTrendsDataSource struct {
+	points []services.TrendPoint
+}
+
+var _ datasource.DataSource = orgTrendsDataSource{}
+
+func orgTrendMetric(p services.TrendPoint, metric string) (float64, bool) {
+	switch metric {
+	case "headcount":
+		return float64(p.HeadcountEnd), true
+	case "hires":
+		return float64(p.Hires), true
+	case "terminations":
+		return float64(p.Terminations), true
+	case "turnover_rate":
+		return math.Round(p.TurnoverRate*10000) / 100, true
+	case "vacancy_rate":
+		return math.Round(p.VacancyRate*10000) / 100, true
+	case "avg_span_of_control":
+		return math.Round(p.AvgSpanOfControl*100) / 100, true
+	default:
+		return 0, false
+	}
+}
+
+func (ds orgTrendsDataSource) Query(_ context.Context, q datasource.Query) (*datasource.QueryResult, error) {
+	if err := ds.ValidateQuery(q); err != nil {
+		return nil, err
+	}
+	metric := strings.TrimSpace(q.Raw)
+	points := ds.points
+	if strings.HasPrefix(metric, orgTrendsLatestPrefix) {
+		metric = strings.TrimPrefix(metric, orgTrendsLatestPrefix)
+		if len(points) > 0 {
+			points = points[len(points)-1:]
+		}
+	}
+
+	data := make([]datasource.DataPoint, 0, len(points))
+	for _, p := range points {
+		v, _ := orgTrendMetric(p, metric)
+		data = append(data, datasource.DataPoint{
+			Timestamp: p.Start,
+			Value:     v,
+			Labels:    map[string]string{"label": p.Label},
+			Fields:    map[string]interface{}{"label": p.Label, "value": v},
+		})
+	}
+	return &datasource.QueryResult{
+		Data: data,
+		Columns: []datasource.ColumnInfo{
+			{Name: "label", Type: datasource.DataTypeString},
+			{Name: "value", Type: datasource.DataTypeNumber},
+		},
+		Metadata: datasource.ResultMetadata{
+			QueryID:    q.ID,
+			ExecutedAt: time.Now(),
+			RowCount:   len(data),
+			DataSource: orgTrendsDataSourceID,
+		},
+	}, nil
+}
+
+func (ds orgTrendsDataSource) TestConnection(context.Context) error { return nil }
+
+func (ds orgTrendsDataSource) GetMetadata() datasource.DataSourceMetadata {
+	return datasource.DataSourceMetadata{
+		ID:           orgTrendsDataSourceID,
+		Name:         "Org trends",
+		Type:         datasource.TypeJSON,
+		Capabilities: []datasource.Capability{datasource.CapabilityQuery, datasource.CapabilityMetrics},
+	}
+}
+
+func (ds orgTrendsDataSource) ValidateQuery(q datasource.Query) error {
+	metric := strings.TrimPrefix(strings.TrimSpace(q.Raw), orgTrendsLatestPrefix)
+	if _, ok := orgTrendMetric(services.TrendPoint{}, metric); !ok {
+		return &datasource.QueryError{
+			Code:    datasource.ErrorCodeSyntax,
+			Message: fmt.Sprintf("unknown trend metric %q", metric),
+			Query:   q.Raw,
+		}
+	}
+	return nil
+}
+
+func (ds orgTrendsDataSource) Close() error { return nil }
+
+// orgTrendsDashboard lays out the trend panels; titles are resolved with t.
+func orgTrendsDashboard(t func(string) string) lens.DashboardConfig {
+	metric := func(id, title, query, unit, color string, x int) lens.PanelConfig {
+		b := builder.MetricCard().
+			ID(id).
+			Title(title).
+			Position(x, 0).
+			Size(3, 2).
+			DataSource(orgTrendsDataSourceID).
+			Query(query).
+			Option("color", color)
+		if unit != "" {
+			b = b.Option("unit", unit)
+		}
+		return b.Build()
+	}
+	chart := func(pb builder.PanelBuilder, id, title, query, color string, x, y int) lens.PanelConfig {
+		return pb.
+			ID(id).
+			Title(title).
+			Position(x, y).
+			Size(6, 4).
+			DataSource(orgTrendsDataSourceID).
+			Query(query).
+			Option("colors", []string{color}).
+			Build()
+	}
+
+	return builder.NewDashboard().
+		ID("org-trends").
+		Title(t("Org.UI.Trends.Title")).
+		Grid(12, 120).
+		Panel(metric("org-trends-headcount-latest", t("Org.UI.Trends.Panels.Headcount"), orgTrendsLatestPrefix+"headcount", "", "#3b82f6", 0)).
+		Panel(metric("org-trends-turnover-latest", t("Org.UI.Trends.Panels.TurnoverRate"), orgTrendsLatestPrefix+"turnover_rate", "%", "#ef4444", 3)).
+		Panel(metric("org-trends-vacancy-latest", t("Org.UI.Trends.Panels.VacancyRate"), orgTrendsLatestPrefix+"vacancy_rate", "%", "#f59e0b", 6)).
+		Panel(metric("org-trends-span-latest", t("Org.UI.Trends.Panels.AvgSpanOfControl"), orgTrendsLatestPrefix+"avg_span_of_control", "", "#10b981", 9)).
+		Panel(chart(builder.LineChart(), "org-trends-headcount", t("Org.UI.Trends.Panels.Headcount"), "headcount", "#3b82f6", 0, 2)).
+		Panel(chart(builder.LineChart(), "org-trends-turnover", t("Org.UI.Trends.Panels.TurnoverRatePercent"), "turnover_rate", "#ef4444", 6, 2)).
+		Panel(chart(builder.BarChart(), "org-trends-hires", t("Org.UI.Trends.Panels.Hires"), "hires", "#10b981", 0, 6)).
+		Panel(chart(builder.BarChart(), "org-trends-terminations", t("Org.UI.Trends.Panels.Terminations"), "terminations", "#ef4444", 6, 6)).
+		Panel(chart(builder.LineChart(), "org-trends-vacancy", t("Org.UI.Trends.Panels.VacancyRatePercent"), "vacancy_rate", "#f59e0b", 0, 10)).
+		Panel(chart(builder.LineChart(), "org-trends-span", t("Org.UI.Trends.Panels.AvgSpanOfControl"), "avg_span_of_control", "#6366f1", 6, 10)).
+		Build()
+}
+
+func (c *OrgUIController) TrendsPage(w http.ResponseWriter, r *http.Request) {
+	tenantID, currentUser, ok := tenantAndUserFromContext(r)
+	if !ok {
+		layouts.WriteAuthzForbiddenResponse(w, r, orgPositionReportsAuthzObject, "read")
+		return
+	}
+	if !ensureOrgRolloutEnabled(w, r, tenantID) {
+		return
+	}
+	if !ensureOrgAuthzUI(w, r, tenantID, currentUser, orgPositionReportsAuthzObject, "read") {
+		return
+	}
+
+	query := r.URL.Query()
+	to, err := parseOptionalValidDate("to", query.Get("to"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if to.IsZero() {
+		to = normalizeValidTimeDayUTC(time.Now().UTC())
+	}
+	from, err := parseOptionalValidDate("from", query.Get("from"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if from.IsZero() {
+		from = time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -11, 0)
+	}
+	interval := services.TrendInterval(strings.TrimSpace(query.Get("interval")))
+	if interval == "" {
+		interval = services.TrendIntervalMonth
+	}
+
+	props := orgtemplates.TrendsPageProps{
+		From:     formatValidDate(from),
+		To:       formatValidDate(to),
+		Interval: string(interval),
+	}
+	var nodeID *uuid.UUID
+	if raw := strings.TrimSpace(query.Get("node_id")); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			http.Error(w, "invalid node_id", http.StatusBadRequest)
+			return
+		}
+		nodeID = &id
+		props.NodeID = id.String()
+		props.NodeLabel = c.orgNodeLabelFor(r, tenantID, id, to)
+	}
+
+	statusCode := http.StatusOK
+	report, err := c.org.GetTrendReport(r.Context(), tenantID, services.TrendReportInput{
+		OrgNodeID: nodeID,
+		From:      from,
+		To:        to,
+		Interval:  interval,
+	})
+	if err != nil {
+		msg, _, status := mapServiceErrorToForm(err)
+		props.Errors = append(props.Errors, msg)
+		statusCode = status
+	} else {
+		props.Dashboard = orgTrendsDashboard(func(key string) string { return intl.MustT(r.Context(), key) })
+		exec := executor.NewExecutor(nil, 10*time.Second)
+		if err := exec.RegisterDataSource(orgTrendsDataSourceID, orgTrendsDataSource{points: report.Points}); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		result, err := exec.ExecuteDashboard(r.Context(), props.Dashboard)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		props.DashboardResult = result
+	}
+
+	if statusCode != http.StatusOK {
+		w.WriteHeader(statusCode)
+	}
+	templ.Handler(orgtemplates.TrendsPage(props), templ.WithStreaming()).ServeHTTP(w, r)
+}
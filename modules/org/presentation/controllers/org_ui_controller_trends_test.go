@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/lens/datasource"
+)
+
+func TestOrgTrendsDataSource_Query(t *testing.T) {
+	ds := orgTrendsDataSource{points: []services.TrendPoint{
+		{TrendBucket: services.TrendBucket{Label: "2025-01"}, TrendBucketDBRow: services.TrendBucketDBRow{HeadcountEnd: 4}, TurnoverRate: 0.125},
+		{TrendBucket: services.TrendBucket{Label: "2025-02"}, TrendBucketDBRow: services.TrendBucketDBRow{HeadcountEnd: 5}, TurnoverRate: 0.2},
+	}}
+
+	res, err := ds.Query(context.Background(), datasource.Query{Raw: "headcount"})
+	require.NoError(t, err)
+	require.Len(t, res.Data, 2)
+	require.Equal(t, "2025-02", res.Data[1].Fields["label"])
+	require.InDelta(t, 5.0, res.Data[1].Value, 0.0001)
+
+	res, err = ds.Query(context.Background(), datasource.Query{Raw: orgTrendsLatestPrefix + "turnover_rate"})
+	require.NoError(t, err)
+	require.Len(t, res.Data, 1)
+	require.InDelta(t, 20.0, res.Data[0].Value, 0.0001)
+
+	_, err = ds.Query(context.Background(), datasource.Query{Raw: "SELECT 1"})
+	require.Error(t, err)
+}
+
+func TestOrgTrendsDashboard_PanelsQueryKnownMetrics(t *testing.T) {
+	cfg := orgTrendsDashboard(func(key string) string { return key })
+	require.NotEmpty(t, cfg.Panels)
+
+	ds := orgTrendsDataSource{}
+	for _, p := range cfg.Panels {
+		require.Equal(t, orgTrendsDataSourceID, p.DataSource.Ref, p.ID)
+		require.NoError(t, ds.ValidateQuery(datasource.Query{Raw: p.Query}), p.ID)
+	}
+}
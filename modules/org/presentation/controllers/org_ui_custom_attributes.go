@@ -0,0 +1,80 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/templates/components/orgui"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+// customAttributeFieldsFor lists the tenant's attribute definitions for entityType at asOf and
+// pairs them with values. A form submission takes precedence over stored values so that a
+// re-rendered form keeps what the user typed.
+func (c *OrgUIController) customAttributeFieldsFor(r *http.Request, tenantID uuid.UUID, entityType string, asOf time.Time, values map[string]any) []orgui.CustomAttributeField {
+	defs, err := c.org.ListAttributeDefinitions(r.Context(), tenantID, services.AttributeDefinitionListFilter{
+		EntityType: &entityType,
+		AsOf:       &asOf,
+	})
+	if err != nil || len(defs) == 0 {
+		return nil
+	}
+
+	submitted := customAttributesFromForm(r)
+	out := make([]orgui.CustomAttributeField, 0, len(defs))
+	for _, d := range defs {
+		value := formatCustomAttributeValue(values[d.Code])
+		if v, ok := submitted[d.Code]; ok {
+			value = formatCustomAttributeValue(v)
+		}
+		out = append(out, orgui.CustomAttributeField{
+			Code:      d.Code,
+			Label:     d.Label,
+			ValueType: d.ValueType,
+			Options:   d.Options,
+			Required:  d.Required,
+			Value:     value,
+		})
+	}
+	return out
+}
+
+// customAttributesFromForm collects custom.<code> form values. Empty values are kept so that the
+// service clears them on update; nil is returned when the form carries no custom fields.
+func customAttributesFromForm(r *http.Request) map[string]any {
+	if r.Form == nil {
+		return nil
+	}
+	var out map[string]any
+	for key, values := range r.Form {
+		code, ok := strings.CutPrefix(key, orgui.CustomAttributeFieldPrefix)
+		if !ok || code == "" || len(values) == 0 {
+			continue
+		}
+		if out == nil {
+			out = map[string]any{}
+		}
+		out[code] = strings.TrimSpace(values[0])
+	}
+	return out
+}
+
+func formatCustomAttributeValue(v any) string {
+	switch tv := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return tv
+	case bool:
+		return strconv.FormatBool(tv)
+	case float64:
+		return strconv.FormatFloat(tv, 'f', -1, 64)
+	default:
+		return fmt.Sprint(tv)
+	}
+}
@@ -0,0 +1,45 @@
+package mappers
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func AuditLogsToEntityHistory(entityType string, entityID uuid.UUID, res *services.ListAuditLogsResult, initiatorNames map[uuid.UUID]string) *viewmodels.OrgEntityHistory {
+	out := &viewmodels.OrgEntityHistory{
+		EntityType: entityType,
+		EntityID:   entityID,
+	}
+	if res == nil {
+		return out
+	}
+	if res.NextCursor != nil {
+		out.NextCursor = *res.NextCursor
+	}
+	out.Entries = make([]viewmodels.OrgAuditEntry, 0, len(res.Items))
+	for _, row := range res.Items {
+		changes := services.DiffAuditValues(row.OldValues, row.NewValues)
+		vmChanges := make([]viewmodels.OrgAuditChange, 0, len(changes))
+		for _, ch := range changes {
+			vmChanges = append(vmChanges, viewmodels.OrgAuditChange{
+				Field: ch.Field,
+				Old:   services.FormatAuditValue(ch.Old),
+				New:   services.FormatAuditValue(ch.New),
+			})
+		}
+		out.Entries = append(out.Entries, viewmodels.OrgAuditEntry{
+			ID:              row.ID,
+			RequestID:       row.RequestID,
+			TransactionTime: row.TransactionTime,
+			InitiatorName:   initiatorNames[row.InitiatorID],
+			InitiatorID:     row.InitiatorID,
+			ChangeType:      row.ChangeType,
+			EffectiveDate:   row.EffectiveDate,
+			EndDate:         row.EndDate,
+			Changes:         vmChanges,
+		})
+	}
+	return out
+}
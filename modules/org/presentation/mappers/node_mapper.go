@@ -18,19 +18,21 @@ func NodeDetailsToViewModel(n *services.NodeAsOf) *viewmodels.OrgNodeDetails {
 		}
 	}
 	return &viewmodels.OrgNodeDetails{
-		ID:            n.Node.ID,
-		Code:          n.Node.Code,
-		Name:          n.Slice.Name,
-		Status:        n.Slice.Status,
-		DisplayOrder:  n.Slice.DisplayOrder,
-		ParentHint:    n.Slice.ParentHint,
-		LegalEntityID: n.Slice.LegalEntityID,
-		CompanyCode:   n.Slice.CompanyCode,
-		LocationID:    n.Slice.LocationID,
-		ManagerUserID: n.Slice.ManagerUserID,
-		EffectiveDate: n.Slice.EffectiveDate,
-		EndDate:       n.Slice.EndDate,
-		I18nNamesJSON: i18n,
-		IsRoot:        n.Node.IsRoot,
+		ID:               n.Node.ID,
+		Code:             n.Node.Code,
+		Name:             n.Slice.Name,
+		Status:           n.Slice.Status,
+		DisplayOrder:     n.Slice.DisplayOrder,
+		ParentHint:       n.Slice.ParentHint,
+		LegalEntityID:    n.Slice.LegalEntityID,
+		CompanyCode:      n.Slice.CompanyCode,
+		LocationID:       n.Slice.LocationID,
+		ManagerUserID:    n.Slice.ManagerUserID,
+		EffectiveDate:    n.Slice.EffectiveDate,
+		EndDate:          n.Slice.EndDate,
+		I18nNamesJSON:    i18n,
+		IsRoot:           n.Node.IsRoot,
+		HierarchyType:    n.Node.Type,
+		CustomAttributes: n.Slice.CustomAttributes,
 	}
 }
@@ -0,0 +1,40 @@
+package mappers
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/presentation/viewmodels"
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func RolesToOptions(roles []services.OrgRole) []viewmodels.OrgRoleOption {
+	out := make([]viewmodels.OrgRoleOption, 0, len(roles))
+	for _, r := range roles {
+		out = append(out, viewmodels.OrgRoleOption{ID: r.ID, Code: r.Code, Name: r.Name})
+	}
+	return out
+}
+
+func RoleAssignmentsToNodeRoles(nodeID uuid.UUID, items []services.RoleAssignmentItem, roles []services.OrgRole, subjectLabels map[uuid.UUID]string, sourceLabels map[uuid.UUID]string) []viewmodels.OrgNodeRoleAssignment {
+	roleNames := make(map[uuid.UUID]string, len(roles))
+	for _, r := range roles {
+		roleNames[r.ID] = r.Name
+	}
+	out := make([]viewmodels.OrgNodeRoleAssignment, 0, len(items))
+	for _, it := range items {
+		out = append(out, viewmodels.OrgNodeRoleAssignment{
+			ID:              it.AssignmentID,
+			RoleCode:        it.RoleCode,
+			RoleName:        roleNames[it.RoleID],
+			SubjectType:     it.SubjectType,
+			SubjectID:       it.SubjectID,
+			SubjectLabel:    subjectLabels[it.SubjectID],
+			SourceOrgNodeID: it.SourceOrgNodeID,
+			SourceLabel:     sourceLabels[it.SourceOrgNodeID],
+			Inherited:       it.SourceOrgNodeID != nodeID,
+			EffectiveDate:   it.EffectiveDate,
+			EndDate:         it.EndDate,
+		})
+	}
+	return out
+}
@@ -0,0 +1,200 @@
+// Package orgchart lays out an org chart built by OrgService.BuildOrgChart and renders it as
+// SVG or PDF without any external dependencies.
+package orgchart
+
+import (
+	"math"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+// Geometry in abstract units: pixels for SVG, points for PDF.
+const (
+	BoxWidth     = 220.0
+	BoxHeight    = 72.0
+	PhotoSize    = 40.0
+	Margin       = 24.0
+	HeaderHeight = 36.0
+
+	siblingGap    = 20.0
+	levelGap      = 44.0
+	compactIndent = 28.0
+	compactGap    = 12.0
+)
+
+type Point struct {
+	X, Y float64
+}
+
+// Placement is a box's top-left corner.
+type Placement struct {
+	Box *services.OrgChartBox
+	X   float64
+	Y   float64
+}
+
+type Layout struct {
+	Width      float64
+	Height     float64
+	Boxes      []Placement
+	Connectors [][]Point
+}
+
+// Compute places every box of the chart. top_down grows children below their parent,
+// left_right to the right of it, and compact is top_down except that nodes whose children are
+// all leaves stack those children in an indented column, which keeps wide, flat teams narrow.
+func Compute(chart *services.OrgChart) Layout {
+	l := &layouter{layout: chart.Layout, breadth: map[*services.OrgChartBox]float64{}}
+	if chart.Root == nil {
+		return Layout{Width: 2*Margin + BoxWidth, Height: 2*Margin + HeaderHeight}
+	}
+	l.measure(chart.Root)
+	l.place(chart.Root, 0, 0)
+
+	maxX, maxY := 0.0, 0.0
+	for _, p := range l.out.Boxes {
+		maxX = math.Max(maxX, p.X+BoxWidth)
+		maxY = math.Max(maxY, p.Y+BoxHeight)
+	}
+	// Shift everything below the header and inside the margin.
+	dx, dy := Margin, Margin+HeaderHeight
+	for i := range l.out.Boxes {
+		l.out.Boxes[i].X += dx
+		l.out.Boxes[i].Y += dy
+	}
+	for _, c := range l.out.Connectors {
+		for i := range c {
+			c[i].X += dx
+			c[i].Y += dy
+		}
+	}
+	l.out.Width = maxX + 2*Margin
+	l.out.Height = maxY + 2*Margin + HeaderHeight
+	return l.out
+}
+
+type layouter struct {
+	layout  services.OrgChartLayout
+	breadth map[*services.OrgChartBox]float64
+	out     Layout
+}
+
+func (l *layouter) horizontal() bool {
+	return l.layout == services.OrgChartLayoutLeftRight
+}
+
+// boxBreadth is a box's extent across the levels; boxDepth its extent along them.
+func (l *layouter) boxBreadth() float64 {
+	if l.horizontal() {
+		return BoxHeight
+	}
+	return BoxWidth
+}
+
+func (l *layouter) boxDepth() float64 {
+	if l.horizontal() {
+		return BoxWidth
+	}
+	return BoxHeight
+}
+
+func (l *layouter) stacked(b *services.OrgChartBox) bool {
+	if l.layout != services.OrgChartLayoutCompact || len(b.Children) == 0 {
+		return false
+	}
+	for _, c := range b.Children {
+		if len(c.Children) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// measure records the breadth of every subtree.
+func (l *layouter) measure(b *services.OrgChartBox) float64 {
+	w := l.boxBreadth()
+	switch {
+	case len(b.Children) == 0:
+	case l.stacked(b):
+		w = compactIndent + BoxWidth
+	default:
+		sum := 0.0
+		for i, c := range b.Children {
+			if i > 0 {
+				sum += siblingGap
+			}
+			sum += l.measure(c)
+		}
+		w = math.Max(w, sum)
+	}
+	l.breadth[b] = w
+	return w
+}
+
+// place puts b's subtree into the band starting at offset (across levels) and level position
+// depthPos (along levels), both in layout-local coordinates.
+func (l *layouter) place(b *services.OrgChartBox, offset, depthPos float64) {
+	width := l.breadth[b]
+	boxOffset := offset + (width-l.boxBreadth())/2
+	if l.stacked(b) {
+		boxOffset = offset
+	}
+	l.put(b, boxOffset, depthPos)
+
+	if len(b.Children) == 0 {
+		return
+	}
+
+	if l.stacked(b) {
+		spineX := offset + compactIndent/2
+		top := depthPos + BoxHeight
+		spine := []Point{{X: spineX, Y: top}}
+		y := top + compactGap
+		for _, c := range b.Children {
+			l.put(c, offset+compactIndent, y)
+			mid := y + BoxHeight/2
+			spine = append(spine, Point{X: spineX, Y: mid})
+			l.out.Connectors = append(l.out.Connectors, []Point{{X: spineX, Y: mid}, {X: offset + compactIndent, Y: mid}})
+			y += BoxHeight + compactGap
+		}
+		l.out.Connectors = append(l.out.Connectors, []Point{spine[0], spine[len(spine)-1]})
+		return
+	}
+
+	childrenWidth := -siblingGap
+	for _, c := range b.Children {
+		childrenWidth += l.breadth[c] + siblingGap
+	}
+	parentCenter := boxOffset + l.boxBreadth()/2
+	parentEnd := depthPos + l.boxDepth()
+	childDepth := parentEnd + levelGap
+	mid := parentEnd + levelGap/2
+
+	cur := offset + (width-childrenWidth)/2
+	for _, c := range b.Children {
+		l.place(c, cur, childDepth)
+		childCenter := cur + l.breadth[c]/2
+		if l.stacked(c) {
+			childCenter = cur + l.boxBreadth()/2
+		}
+		l.out.Connectors = append(l.out.Connectors, []Point{
+			l.point(parentCenter, parentEnd),
+			l.point(parentCenter, mid),
+			l.point(childCenter, mid),
+			l.point(childCenter, childDepth),
+		})
+		cur += l.breadth[c] + siblingGap
+	}
+}
+
+func (l *layouter) put(b *services.OrgChartBox, offset, depthPos float64) {
+	p := l.point(offset, depthPos)
+	l.out.Boxes = append(l.out.Boxes, Placement{Box: b, X: p.X, Y: p.Y})
+}
+
+func (l *layouter) point(offset, depthPos float64) Point {
+	if l.horizontal() {
+		return Point{X: depthPos, Y: offset}
+	}
+	return Point{X: offset, Y: depthPos}
+}
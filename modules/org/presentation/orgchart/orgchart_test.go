@@ -0,0 +1,184 @@
+package orgchart
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func newTestChart(layout services.OrgChartLayout) *services.OrgChart {
+	box := func(code, name, manager string, children ...*services.OrgChartBox) *services.OrgChartBox {
+		b := &services.OrgChartBox{ID: uuid.New(), Code: code, Name: name, Children: children}
+		b.ManagerName = manager
+		b.PositionsTotal = 3
+		b.PositionsFilled = 2
+		for _, c := range children {
+			id := b.ID
+			c.ParentID = &id
+		}
+		return b
+	}
+	root := box("ROOT", "Company", "Ada Lovelace",
+		box("FIN", "Finance & Control", "Grace Hopper",
+			box("FIN-AP", "Payables", ""),
+			box("FIN-AR", "Receivables", ""),
+		),
+		box("ENG", "Engineering", "Alan Turing",
+			box("ENG-PLT", "Platform", "",
+				box("ENG-PLT-DB", "Databases", ""),
+			),
+		),
+	)
+	return &services.OrgChart{
+		HierarchyType: services.HierarchyTypeOrgUnit,
+		EffectiveDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Layout:        layout,
+		Root:          root,
+		NodesCount:    7,
+	}
+}
+
+func placementsByCode(l Layout) map[string]Placement {
+	out := map[string]Placement{}
+	for _, p := range l.Boxes {
+		out[p.Box.Code] = p
+	}
+	return out
+}
+
+func requireNoOverlap(t *testing.T, l Layout) {
+	t.Helper()
+	for i, a := range l.Boxes {
+		require.GreaterOrEqual(t, a.X, 0.0)
+		require.GreaterOrEqual(t, a.Y, HeaderHeight)
+		require.LessOrEqual(t, a.X+BoxWidth, l.Width)
+		require.LessOrEqual(t, a.Y+BoxHeight, l.Height)
+		for _, b := range l.Boxes[i+1:] {
+			overlap := a.X < b.X+BoxWidth && b.X < a.X+BoxWidth && a.Y < b.Y+BoxHeight && b.Y < a.Y+BoxHeight
+			require.False(t, overlap, "%s overlaps %s", a.Box.Code, b.Box.Code)
+		}
+	}
+}
+
+func TestCompute_TopDown(t *testing.T) {
+	l := Compute(newTestChart(services.OrgChartLayoutTopDown))
+	require.Len(t, l.Boxes, 7)
+	requireNoOverlap(t, l)
+
+	p := placementsByCode(l)
+	require.Less(t, p["ROOT"].Y, p["FIN"].Y)
+	require.Equal(t, p["FIN"].Y, p["ENG"].Y)
+	require.Less(t, p["FIN"].X, p["ENG"].X)
+	// Parents are centered over their children.
+	require.InDelta(t, (p["FIN-AP"].X+p["FIN-AR"].X)/2, p["FIN"].X, 0.001)
+	require.InDelta(t, p["ENG-PLT"].X, p["ENG-PLT-DB"].X, 0.001)
+	require.Len(t, l.Connectors, 6)
+}
+
+func TestCompute_LeftRight(t *testing.T) {
+	l := Compute(newTestChart(services.OrgChartLayoutLeftRight))
+	requireNoOverlap(t, l)
+
+	p := placementsByCode(l)
+	require.Less(t, p["ROOT"].X, p["FIN"].X)
+	require.Equal(t, p["FIN"].X, p["ENG"].X)
+	require.Less(t, p["FIN"].Y, p["ENG"].Y)
+}
+
+func TestCompute_CompactStacksLeafTeams(t *testing.T) {
+	topDown := Compute(newTestChart(services.OrgChartLayoutTopDown))
+	l := Compute(newTestChart(services.OrgChartLayoutCompact))
+	requireNoOverlap(t, l)
+	require.Less(t, l.Width, topDown.Width)
+
+	p := placementsByCode(l)
+	require.Equal(t, p["FIN-AP"].X, p["FIN-AR"].X)
+	require.Greater(t, p["FIN-AP"].X, p["FIN"].X)
+	require.Less(t, p["FIN-AP"].Y, p["FIN-AR"].Y)
+}
+
+func TestRenderSVG_IsWellFormedAndEscapesText(t *testing.T) {
+	chart := newTestChart(services.OrgChartLayoutTopDown)
+	chart.IncludePhotos = true
+	chart.Root.ManagerPhotoPath = "static/ada.png"
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderSVG(&buf, chart, Options{Photos: func(path string) (Photo, error) {
+		require.Equal(t, "static/ada.png", path)
+		return Photo{Data: testPNG(t), MimeType: "image/png"}, nil
+	}}))
+
+	dec := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for {
+		_, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	out := buf.String()
+	require.Contains(t, out, "Finance &amp; Control")
+	require.Contains(t, out, "No manager")
+	require.Contains(t, out, "data:image/png;base64,")
+	require.Contains(t, out, ">GH</text>")
+	require.Equal(t, 7, strings.Count(out, "<g data-node-id="))
+}
+
+func TestRenderPDF_XrefPointsAtObjects(t *testing.T) {
+	chart := newTestChart(services.OrgChartLayoutCompact)
+	chart.IncludePhotos = true
+	chart.Root.ManagerPhotoPath = "static/ada.png"
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderPDF(&buf, chart, Options{Photos: func(string) (Photo, error) {
+		return Photo{Data: testPNG(t), MimeType: "image/png"}, nil
+	}}))
+	out := buf.Bytes()
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	require.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	require.Len(t, entries, 7) // 6 fixed objects plus one photo
+	for i, e := range entries {
+		off, err := strconv.Atoi(string(e[1]))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(out[off:], []byte(strconv.Itoa(i+1)+" 0 obj")), "object %d", i+1)
+	}
+	require.Contains(t, string(out), "/XObject << /Im0 7 0 R >>")
+}
+
+func TestPDFString_EncodesWinAnsi(t *testing.T) {
+	require.Equal(t, []byte("Caf\xe9 \\(R&D\\) \\\\ \x85 ?"), []byte(pdfString("Café (R&D) \\ … Ж")))
+}
+
+func testPNG(t *testing.T) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for x := 0; x < 2; x++ {
+		for y := 0; y < 2; y++ {
+			img.Set(x, y, color.RGBA{R: 200, G: 100, B: 50, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
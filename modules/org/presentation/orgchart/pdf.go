@@ -0,0 +1,308 @@
+package orgchart
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	_ "image/png" // registers PNG avatars for image.Decode
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+// maxPDFPageSide is the largest page side PDF viewers accept (200 inches); bigger charts are
+// scaled down to fit on one page.
+const maxPDFPageSide = 14400.0
+
+// RenderPDF writes the chart as a single-page PDF sized to the layout. It uses the standard
+// Helvetica fonts, so text is limited to the WinAnsi (Latin-1) repertoire: other characters
+// print as "?". Use SVG for charts with Cyrillic or CJK names.
+func RenderPDF(w io.Writer, chart *services.OrgChart, opts Options) error {
+	layout := Compute(chart)
+	scale := math.Min(1, maxPDFPageSide/math.Max(layout.Width, layout.Height))
+	pageW, pageH := layout.Width*scale, layout.Height*scale
+
+	doc := &pdfDocument{}
+	images := map[int]string{}
+	var content bytes.Buffer
+	c := &pdfCanvas{buf: &content, height: layout.Height}
+
+	fmt.Fprintf(&content, "%s 0 0 %s 0 0 cm\n", pdfNum(scale), pdfNum(scale))
+	c.text(Margin, Margin+titleFontSize, titleFontSize, true, colorText, chartTitle(chart))
+
+	c.stroke(colorConnector)
+	for _, conn := range layout.Connectors {
+		for i, p := range conn {
+			op := "l"
+			if i == 0 {
+				op = "m"
+			}
+			fmt.Fprintf(&content, "%s %s %s\n", pdfNum(p.X), pdfNum(c.y(p.Y)), op)
+		}
+		content.WriteString("S\n")
+	}
+
+	for i, p := range layout.Boxes {
+		b := p.Box
+		fill := colorBoxFill
+		if b == chart.Root {
+			fill = colorRootFill
+		}
+		c.fill(fill)
+		c.stroke(colorBorder)
+		fmt.Fprintf(&content, "%s %s %s %s re B\n", pdfNum(p.X), pdfNum(c.y(p.Y+BoxHeight)), pdfNum(BoxWidth), pdfNum(BoxHeight))
+
+		textX := p.X + textPadding
+		if chart.IncludePhotos {
+			cx := p.X + textPadding + PhotoSize/2
+			cy := p.Y + BoxHeight/2
+			name := ""
+			if b.ManagerPhotoPath != "" && opts.Photos != nil {
+				if photo, err := opts.Photos(b.ManagerPhotoPath); err == nil {
+					if obj, err := doc.addImage(photo); err == nil {
+						name = fmt.Sprintf("Im%d", i)
+						images[obj] = name
+					}
+				}
+			}
+			if name != "" {
+				content.WriteString("q\n")
+				c.circle(cx, cy, PhotoSize/2)
+				content.WriteString("W n\n")
+				fmt.Fprintf(&content, "%s 0 0 %s %s %s cm /%s Do\nQ\n",
+					pdfNum(PhotoSize), pdfNum(PhotoSize), pdfNum(cx-PhotoSize/2), pdfNum(c.y(cy+PhotoSize/2)), name)
+			} else {
+				c.fill(colorAvatar)
+				c.circle(cx, cy, PhotoSize/2)
+				content.WriteString("f\n")
+				label := initials(b.ManagerName)
+				c.text(cx-textWidth(label, 14)/2, cy+5, 14, false, colorText, label)
+			}
+			textX += PhotoSize + textPadding
+		}
+		for _, line := range boxLines(b, chart.IncludePhotos) {
+			col := colorText
+			if line.Gray {
+				col = colorGray
+			}
+			c.text(textX, p.Y+line.Baseline, line.Size, line.Bold, col, line.Text)
+		}
+	}
+
+	return doc.write(w, pageW, pageH, content.Bytes(), images)
+}
+
+// pdfCanvas emits content stream operators in layout coordinates (y grows downwards).
+type pdfCanvas struct {
+	buf    *bytes.Buffer
+	height float64
+}
+
+func (c *pdfCanvas) y(v float64) float64 {
+	return c.height - v
+}
+
+func (c *pdfCanvas) fill(hex string) {
+	r, g, b := rgb(hex)
+	fmt.Fprintf(c.buf, "%s %s %s rg\n", pdfNum(r), pdfNum(g), pdfNum(b))
+}
+
+func (c *pdfCanvas) stroke(hex string) {
+	r, g, b := rgb(hex)
+	fmt.Fprintf(c.buf, "%s %s %s RG\n", pdfNum(r), pdfNum(g), pdfNum(b))
+}
+
+func (c *pdfCanvas) text(x, baseline, size float64, bold bool, hex, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	c.fill(hex)
+	fmt.Fprintf(c.buf, "BT /%s %s Tf %s %s Td (%s) Tj ET\n", font, pdfNum(size), pdfNum(x), pdfNum(c.y(baseline)), pdfString(s))
+}
+
+// circle appends a closed circle path built from four cubic Béziers.
+func (c *pdfCanvas) circle(cx, cy, r float64) {
+	const k = 0.5523
+	y := c.y(cy)
+	fmt.Fprintf(c.buf, "%s %s m\n", pdfNum(cx+r), pdfNum(y))
+	fmt.Fprintf(c.buf, "%s %s %s %s %s %s c\n", pdfNum(cx+r), pdfNum(y+k*r), pdfNum(cx+k*r), pdfNum(y+r), pdfNum(cx), pdfNum(y+r))
+	fmt.Fprintf(c.buf, "%s %s %s %s %s %s c\n", pdfNum(cx-k*r), pdfNum(y+r), pdfNum(cx-r), pdfNum(y+k*r), pdfNum(cx-r), pdfNum(y))
+	fmt.Fprintf(c.buf, "%s %s %s %s %s %s c\n", pdfNum(cx-r), pdfNum(y-k*r), pdfNum(cx-k*r), pdfNum(y-r), pdfNum(cx), pdfNum(y-r))
+	fmt.Fprintf(c.buf, "%s %s %s %s %s %s c h\n", pdfNum(cx+k*r), pdfNum(y-r), pdfNum(cx+r), pdfNum(y-k*r), pdfNum(cx+r), pdfNum(y))
+}
+
+// pdfDocument collects image XObjects; write lays out the fixed objects around them.
+type pdfDocument struct {
+	objects [][]byte
+}
+
+// Objects 1-4 are catalog, pages, page and content, 5-6 the two fonts; images follow.
+const firstImageObject = 7
+
+func (d *pdfDocument) addImage(photo Photo) (int, error) {
+	obj, err := pdfImageObject(photo)
+	if err != nil {
+		return 0, err
+	}
+	d.objects = append(d.objects, obj)
+	return firstImageObject + len(d.objects) - 1, nil
+}
+
+func (d *pdfDocument) write(w io.Writer, pageW, pageH float64, content []byte, images map[int]string) error {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(content); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+
+	var xobjects strings.Builder
+	for obj := firstImageObject; obj < firstImageObject+len(d.objects); obj++ {
+		if name, ok := images[obj]; ok {
+			fmt.Fprintf(&xobjects, " /%s %d 0 R", name, obj)
+		}
+	}
+	resources := "/Font << /F1 5 0 R /F2 6 0 R >>"
+	if xobjects.Len() > 0 {
+		resources += " /XObject <<" + xobjects.String() + " >>"
+	}
+
+	objects := [][]byte{
+		[]byte("<< /Type /Catalog /Pages 2 0 R >>"),
+		[]byte("<< /Type /Pages /Kids [3 0 R] /Count 1 >>"),
+		[]byte(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << %s >> /Contents 4 0 R >>", pdfNum(pageW), pdfNum(pageH), resources)),
+		pdfStream(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>", compressed.Len()), compressed.Bytes()),
+		[]byte("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>"),
+		[]byte("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>"),
+	}
+	objects = append(objects, d.objects...)
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n", i+1)
+		out.Write(obj)
+		out.WriteString("\nendobj\n")
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+func pdfStream(dict string, data []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString(dict)
+	b.WriteString("\nstream\n")
+	b.Write(data)
+	b.WriteString("\nendstream")
+	return b.Bytes()
+}
+
+// pdfImageObject embeds JPEGs as-is (DCTDecode) and re-encodes everything else the standard
+// library can decode as Flate-compressed RGB over a white background.
+func pdfImageObject(photo Photo) ([]byte, error) {
+	if cfg, err := jpeg.DecodeConfig(bytes.NewReader(photo.Data)); err == nil {
+		colorSpace := "/DeviceRGB"
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			colorSpace = "/DeviceGray"
+		case color.CMYKModel:
+			return nil, fmt.Errorf("orgchart: CMYK JPEG photos are not supported")
+		}
+		dict := fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter /DCTDecode /Length %d >>",
+			cfg.Width, cfg.Height, colorSpace, len(photo.Data))
+		return pdfStream(dict, photo.Data), nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(photo.Data))
+	if err != nil {
+		return nil, err
+	}
+	bounds := img.Bounds()
+	raw := make([]byte, 0, bounds.Dx()*bounds.Dy()*3)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			// Composite premultiplied color over white.
+			white := 0xffff - a
+			raw = append(raw, byte((r+white)>>8), byte((g+white)>>8), byte((b+white)>>8))
+		}
+	}
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(raw); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	dict := fmt.Sprintf("<< /Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode /Length %d >>",
+		bounds.Dx(), bounds.Dy(), compressed.Len())
+	return pdfStream(dict, compressed.Bytes()), nil
+}
+
+// winAnsiExtras maps the non-Latin-1 characters WinAnsiEncoding places in 0x80-0x9F.
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// pdfString encodes s as a WinAnsi literal string body.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		var c byte
+		switch {
+		case r < 0x80 || (r >= 0xA0 && r <= 0xFF):
+			c = byte(r)
+		default:
+			v, ok := winAnsiExtras[r]
+			if !ok {
+				v = '?'
+			}
+			c = v
+		}
+		switch c {
+		case '(', ')', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		default:
+			if c < 0x20 {
+				b.WriteByte(' ')
+				continue
+			}
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+func rgb(hex string) (float64, float64, float64) {
+	v, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil {
+		return 0, 0, 0
+	}
+	return float64(v>>16&0xff) / 255, float64(v>>8&0xff) / 255, float64(v&0xff) / 255
+}
+
+func pdfNum(v float64) string {
+	return num(v)
+}
@@ -0,0 +1,119 @@
+package orgchart
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+// Photo is an avatar image as stored in uploads.
+type Photo struct {
+	Data     []byte
+	MimeType string
+}
+
+// PhotoLoader resolves a ManagerPhotoPath to image bytes. Boxes whose photo cannot be loaded
+// show the manager's initials instead.
+type PhotoLoader func(path string) (Photo, error)
+
+type Options struct {
+	Photos PhotoLoader
+}
+
+const (
+	colorBorder    = "#94a3b8"
+	colorRootFill  = "#eef2ff"
+	colorBoxFill   = "#ffffff"
+	colorText      = "#0f172a"
+	colorGray      = "#64748b"
+	colorConnector = "#94a3b8"
+	colorAvatar    = "#cbd5e1"
+)
+
+// RenderSVG writes the chart as a standalone SVG document. Photos are embedded as data URIs
+// so the file renders the same when downloaded.
+func RenderSVG(w io.Writer, chart *services.OrgChart, opts Options) error {
+	layout := Compute(chart)
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %s %s" font-family="Helvetica, Arial, sans-serif">`+"\n",
+		num(layout.Width), num(layout.Height), num(layout.Width), num(layout.Height))
+	fmt.Fprintf(bw, `<rect x="0" y="0" width="%s" height="%s" fill="#ffffff"/>`+"\n", num(layout.Width), num(layout.Height))
+	fmt.Fprintf(bw, `<text x="%s" y="%s" font-size="%s" font-weight="bold" fill="%s">%s</text>`+"\n",
+		num(Margin), num(Margin+titleFontSize), num(titleFontSize), colorText, escapeXML(chartTitle(chart)))
+
+	for _, c := range layout.Connectors {
+		pts := make([]string, 0, len(c))
+		for _, p := range c {
+			pts = append(pts, num(p.X)+","+num(p.Y))
+		}
+		fmt.Fprintf(bw, `<polyline points="%s" fill="none" stroke="%s" stroke-width="1"/>`+"\n", strings.Join(pts, " "), colorConnector)
+	}
+
+	for i, p := range layout.Boxes {
+		b := p.Box
+		fill := colorBoxFill
+		if b == chart.Root {
+			fill = colorRootFill
+		}
+		fmt.Fprintf(bw, `<g data-node-id="%s">`+"\n", b.ID)
+		fmt.Fprintf(bw, `<rect x="%s" y="%s" width="%s" height="%s" rx="6" fill="%s" stroke="%s"/>`+"\n",
+			num(p.X), num(p.Y), num(BoxWidth), num(BoxHeight), fill, colorBorder)
+
+		textX := p.X + textPadding
+		if chart.IncludePhotos {
+			writeSVGPhoto(bw, i, b, p, opts.Photos)
+			textX += PhotoSize + textPadding
+		}
+		for _, line := range boxLines(b, chart.IncludePhotos) {
+			color := colorText
+			if line.Gray {
+				color = colorGray
+			}
+			weight := ""
+			if line.Bold {
+				weight = ` font-weight="bold"`
+			}
+			fmt.Fprintf(bw, `<text x="%s" y="%s" font-size="%s"%s fill="%s">%s</text>`+"\n",
+				num(textX), num(p.Y+line.Baseline), num(line.Size), weight, color, escapeXML(line.Text))
+		}
+		bw.WriteString("</g>\n")
+	}
+	bw.WriteString("</svg>\n")
+	return bw.Flush()
+}
+
+func writeSVGPhoto(bw *bufio.Writer, i int, b *services.OrgChartBox, p Placement, load PhotoLoader) {
+	cx := p.X + textPadding + PhotoSize/2
+	cy := p.Y + BoxHeight/2
+	if b.ManagerPhotoPath != "" && load != nil {
+		if photo, err := load(b.ManagerPhotoPath); err == nil && len(photo.Data) > 0 {
+			clipID := fmt.Sprintf("photo-%d", i)
+			fmt.Fprintf(bw, `<clipPath id="%s"><circle cx="%s" cy="%s" r="%s"/></clipPath>`+"\n", clipID, num(cx), num(cy), num(PhotoSize/2))
+			fmt.Fprintf(bw, `<image x="%s" y="%s" width="%s" height="%s" preserveAspectRatio="xMidYMid slice" clip-path="url(#%s)" href="data:%s;base64,%s"/>`+"\n",
+				num(cx-PhotoSize/2), num(cy-PhotoSize/2), num(PhotoSize), num(PhotoSize), clipID, escapeXML(photo.MimeType), base64.StdEncoding.EncodeToString(photo.Data))
+			return
+		}
+	}
+	fmt.Fprintf(bw, `<circle cx="%s" cy="%s" r="%s" fill="%s"/>`+"\n", num(cx), num(cy), num(PhotoSize/2), colorAvatar)
+	fmt.Fprintf(bw, `<text x="%s" y="%s" font-size="14" text-anchor="middle" fill="%s">%s</text>`+"\n",
+		num(cx), num(cy+5), colorText, escapeXML(initials(b.ManagerName)))
+}
+
+func escapeXML(s string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(s))
+	return sb.String()
+}
+
+// num formats a coordinate compactly: integers without decimals, otherwise two places.
+func num(v float64) string {
+	s := fmt.Sprintf("%.2f", v)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
@@ -0,0 +1,100 @@
+package orgchart
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+const (
+	nameFontSize    = 13.0
+	managerFontSize = 11.0
+	metaFontSize    = 10.0
+	titleFontSize   = 14.0
+	textPadding     = 10.0
+)
+
+type textLine struct {
+	Text string
+	Size float64
+	Bold bool
+	Gray bool
+	// Baseline relative to the box top.
+	Baseline float64
+}
+
+// boxLines is the text a box shows, truncated to the space left of/after the photo.
+func boxLines(b *services.OrgChartBox, withPhoto bool) []textLine {
+	avail := BoxWidth - 2*textPadding
+	if withPhoto {
+		avail -= PhotoSize + textPadding
+	}
+	manager := b.ManagerName
+	if manager == "" {
+		manager = "No manager"
+	}
+	meta := fmt.Sprintf("%s · %d positions · %d filled", b.Code, b.PositionsTotal, b.PositionsFilled)
+	return []textLine{
+		{Text: truncate(b.Name, nameFontSize, avail), Size: nameFontSize, Bold: true, Baseline: 24},
+		{Text: truncate(manager, managerFontSize, avail), Size: managerFontSize, Gray: b.ManagerName == "", Baseline: 42},
+		{Text: truncate(meta, metaFontSize, avail), Size: metaFontSize, Gray: true, Baseline: 58},
+	}
+}
+
+func chartTitle(chart *services.OrgChart) string {
+	return fmt.Sprintf("Org chart · %s · %s · %d nodes", chart.HierarchyType, chart.EffectiveDate.Format(time.DateOnly), chart.NodesCount)
+}
+
+// initials is the placeholder shown instead of a missing photo.
+func initials(name string) string {
+	var out []rune
+	for _, part := range strings.Fields(name) {
+		r, _ := utf8.DecodeRuneInString(part)
+		out = append(out, unicode.ToUpper(r))
+		if len(out) == 2 {
+			break
+		}
+	}
+	if len(out) == 0 {
+		return "?"
+	}
+	return string(out)
+}
+
+// textWidth approximates a sans-serif string width: wide (CJK) runes count double.
+func textWidth(s string, size float64) float64 {
+	w := 0.0
+	for _, r := range s {
+		switch {
+		case r >= 0x1100 && (unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hangul, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r)):
+			w += 1.0
+		case r == ' ' || r == '.' || r == ',' || r == 'i' || r == 'l' || r == '·':
+			w += 0.3
+		case unicode.IsUpper(r) || r == 'm' || r == 'w':
+			w += 0.7
+		default:
+			w += 0.55
+		}
+	}
+	return w * size
+}
+
+func truncate(s string, size, avail float64) string {
+	s = strings.TrimSpace(s)
+	if textWidth(s, size) <= avail {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if textWidth(candidate, size) <= avail {
+			return candidate
+		}
+	}
+	return "…"
+}
@@ -0,0 +1,32 @@
+package orgui
+
+import "strings"
+
+var personnelEventTypeKeys = map[string]string{
+	"hire":        "Hire",
+	"transfer":    "Transfer",
+	"termination": "Termination",
+	"promotion":   "Promotion",
+	"leave":       "Leave",
+	"return":      "Return",
+	"rehire":      "Rehire",
+	"fte_change":  "FTEChange",
+}
+
+// personnelEventTypeLabelKey returns the translation key of a personnel event type, or "" for
+// unknown codes.
+func personnelEventTypeLabelKey(code string) string {
+	key, ok := personnelEventTypeKeys[strings.TrimSpace(code)]
+	if !ok {
+		return ""
+	}
+	return "Org.UI.Assignments.EventType." + key
+}
+
+func personnelEventHintKey(code string) string {
+	key, ok := personnelEventTypeKeys[strings.TrimSpace(code)]
+	if !ok {
+		return ""
+	}
+	return "Org.UI.Assignments.EventHints." + key
+}
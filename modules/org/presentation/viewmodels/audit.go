@@ -0,0 +1,33 @@
+package viewmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrgAuditChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+type OrgAuditEntry struct {
+	ID              uuid.UUID
+	RequestID       string
+	TransactionTime time.Time
+	InitiatorName   string
+	InitiatorID     uuid.UUID
+	ChangeType      string
+	EffectiveDate   time.Time
+	EndDate         time.Time
+	Changes         []OrgAuditChange
+}
+
+// OrgEntityHistory is one page of audit entries for a single entity; NextCursor is empty on the last page.
+type OrgEntityHistory struct {
+	EntityType string
+	EntityID   uuid.UUID
+	Entries    []OrgAuditEntry
+	NextCursor string
+}
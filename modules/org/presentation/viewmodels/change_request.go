@@ -0,0 +1,56 @@
+package viewmodels
+
+type OrgChangeRequestListItem struct {
+	ID            string
+	RequestID     string
+	Status        string
+	EffectiveDate string
+	Notes         string
+	CommandsCount int
+	UpdatedAt     string
+}
+
+// OrgChangeRequestField is one top-level key of a command payload, formatted for display.
+type OrgChangeRequestField struct {
+	Name  string
+	Value string
+}
+
+type OrgChangeRequestCommand struct {
+	Index  int
+	Type   string
+	Fields []OrgChangeRequestField
+}
+
+type OrgChangeRequestStep struct {
+	StepOrder int
+	RoleCode  string
+	Status    string
+	DueAt     string
+	DecidedAt string
+	Comment   string
+}
+
+type OrgChangeRequestCounters struct {
+	Create  int
+	Update  int
+	Move    int
+	Rescind int
+}
+
+type OrgChangeRequestImpact struct {
+	Nodes         OrgChangeRequestCounters
+	Assignments   OrgChangeRequestCounters
+	Events        []OrgChangeRequestField
+	AffectedNodes int
+	Warnings      []string
+}
+
+type OrgChangeRequestDetail struct {
+	OrgChangeRequestListItem
+	Mine          bool
+	ReviewComment string
+	ReviewedAt    string
+	Commands      []OrgChangeRequestCommand
+	Steps         []OrgChangeRequestStep
+}
@@ -0,0 +1,33 @@
+package viewmodels
+
+// OrgHierarchyDiffItem is one rendered change line. Kind selects the badge/label
+// (node_created, node_retired, node_renamed, node_moved, position_opened, position_closed,
+// assignment_started, assignment_ended, assignment_transferred, assignment_fte_changed).
+type OrgHierarchyDiffItem struct {
+	Kind   string
+	Label  string
+	Detail string
+}
+
+type OrgHierarchyDiffCounts struct {
+	NodesCreated      int
+	NodesRetired      int
+	NodesRenamed      int
+	NodesMoved        int
+	PositionsOpened   int
+	PositionsClosed   int
+	AssignmentChanges int
+}
+
+type OrgHierarchyDiffGroup struct {
+	SubtreeLabel string
+	Counts       OrgHierarchyDiffCounts
+	Items        []OrgHierarchyDiffItem
+}
+
+type OrgHierarchyDiff struct {
+	From   string
+	To     string
+	Totals OrgHierarchyDiffCounts
+	Groups []OrgHierarchyDiffGroup
+}
@@ -19,8 +19,21 @@ type OrgNodeDetails struct {
 	CompanyCode   *string
 	LocationID    *uuid.UUID
 	ManagerUserID *int64
+	ManagerLabel  string
+	ActingManager *OrgNodeActingManager
 	EffectiveDate time.Time
 	EndDate       time.Time
 	I18nNamesJSON string
 	IsRoot        bool
+	HierarchyType string
+	// CustomAttributes holds tenant-defined attribute values keyed by definition code.
+	CustomAttributes map[string]any
+}
+
+// OrgNodeActingManager is the user covering for the node's manager through a delegation in
+// force at the panel's date.
+type OrgNodeActingManager struct {
+	UserID  int64
+	Label   string
+	EndDate time.Time
 }
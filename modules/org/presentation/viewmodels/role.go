@@ -0,0 +1,33 @@
+package viewmodels
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrgRoleOption struct {
+	ID   uuid.UUID
+	Code string
+	Name string
+}
+
+type OrgRoleSubjectOption struct {
+	ID    uuid.UUID
+	Label string
+}
+
+// OrgNodeRoleAssignment is a role holder at a node as of a date; Inherited rows are granted at an ancestor.
+type OrgNodeRoleAssignment struct {
+	ID              uuid.UUID
+	RoleCode        string
+	RoleName        string
+	SubjectType     string
+	SubjectID       uuid.UUID
+	SubjectLabel    string
+	SourceOrgNodeID uuid.UUID
+	SourceLabel     string
+	Inherited       bool
+	EffectiveDate   time.Time
+	EndDate         time.Time
+}
@@ -0,0 +1,75 @@
+package viewmodels
+
+type OrgScenarioListItem struct {
+	ID              string
+	Name            string
+	EffectiveDate   string
+	Status          string
+	CommandsCount   int
+	ChangeRequestID string
+	UpdatedAt       string
+}
+
+type OrgScenarioCommand struct {
+	Index   int
+	Type    string
+	Payload string
+}
+
+type OrgScenarioFigures struct {
+	Positions   int
+	CapacityFTE string
+	Headcount   int
+	FTE         string
+}
+
+type OrgScenarioSummary struct {
+	PositionsTotal int
+	CapacityFTE    string
+	OccupiedFTE    string
+	AvailableFTE   string
+	FillRate       string
+}
+
+type OrgScenarioTreeRow struct {
+	Depth int
+	Label string
+	Total OrgScenarioFigures
+}
+
+// OrgScenarioSubtreeRow compares one top-level subtree; Live or Scenario is nil when the subtree
+// only exists on the other side.
+type OrgScenarioSubtreeRow struct {
+	Label          string
+	Live           *OrgScenarioFigures
+	Scenario       *OrgScenarioFigures
+	HeadcountDelta string
+	FTEDelta       string
+	Changed        bool
+}
+
+type OrgScenarioEvaluation struct {
+	LiveSummary     *OrgScenarioSummary
+	ScenarioSummary *OrgScenarioSummary
+	Tree            []OrgScenarioTreeRow
+	Subtrees        []OrgScenarioSubtreeRow
+}
+
+type OrgScenarioDetail struct {
+	OrgScenarioListItem
+	Description string
+	Commands    []OrgScenarioCommand
+}
+
+// OrgScenarioComparison lays out several scenarios side by side: one column per scenario and
+// one row per top-level subtree found in any of them.
+type OrgScenarioComparison struct {
+	Scenarios []OrgScenarioListItem
+	Rows      []OrgScenarioComparisonRow
+	Errors    []string
+}
+
+type OrgScenarioComparisonRow struct {
+	Label string
+	Cells []*OrgScenarioSubtreeRow
+}
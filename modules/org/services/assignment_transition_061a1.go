@@ -72,7 +72,7 @@ func (s *OrgService) TransitionAssignment(ctx context.Context, tenantID uuid.UUI
 			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_INVALID_BODY", "anchor assignment must be primary", nil)
 		}
 
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "assignment.transitioned", "org_assignment", anchor.ID, in.EffectiveDate, freeze, err, nil)
 			return nil, err
@@ -14,26 +14,28 @@ type OrgSettings struct {
 	PositionCatalogValidationMode      string
 	PositionRestrictionsValidationMode string
 	ReasonCodeMode                     string
+	PositionBudgetValidationMode       string
 }
 
 type AuditLogInsert struct {
-	RequestID        string
-	TransactionTime  time.Time
-	InitiatorID      uuid.UUID
-	ChangeType       string
-	EntityType       string
-	EntityID         uuid.UUID
-	EffectiveDate    time.Time
-	EndDate          time.Time
-	OldValues        any
-	NewValues        any
-	Meta             map[string]any
-	FreezeMode       string
-	FreezeViolation  bool
-	FreezeCutoffUTC  time.Time
-	AffectedAtUTC    time.Time
-	Operation        string
-	OperationDetails map[string]any
+	RequestID            string
+	TransactionTime      time.Time
+	InitiatorID          uuid.UUID
+	ChangeType           string
+	EntityType           string
+	EntityID             uuid.UUID
+	EffectiveDate        time.Time
+	EndDate              time.Time
+	OldValues            any
+	NewValues            any
+	Meta                 map[string]any
+	FreezeMode           string
+	FreezeViolation      bool
+	FreezeCutoffUTC      time.Time
+	AffectedAtUTC        time.Time
+	FreezeOverrideReason string
+	Operation            string
+	OperationDetails     map[string]any
 }
 
 func (a AuditLogInsert) marshalJSON(v any) (string, error) {
@@ -89,6 +91,9 @@ func (a AuditLogInsert) MarshalMeta() (string, error) {
 		meta["freeze_violation"] = a.FreezeViolation
 		meta["affected_at_utc"] = a.AffectedAtUTC.UTC().Format(time.RFC3339)
 	}
+	if a.FreezeOverrideReason != "" {
+		meta["freeze_override_reason"] = a.FreezeOverrideReason
+	}
 
 	s, err := a.marshalJSON(meta)
 	if err != nil {
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEntityTypes lists the entity_type values org_audit_logs accepts.
+var AuditEntityTypes = []string{"org_node", "org_edge", "org_position", "org_assignment", "org_role", "org_role_assignment", "org_delegation", "org_inheritance_rule", "org_inheritance_break", "org_attribute_definition", "org_freeze_period"}
+
+type AuditLogFilter struct {
+	EntityType  *string
+	EntityID    *uuid.UUID
+	RequestID   *string
+	InitiatorID *uuid.UUID
+	ChangeType  *string
+	// From and To bound transaction_time as [From, To).
+	From     *time.Time
+	To       *time.Time
+	Limit    int
+	CursorAt *time.Time
+	CursorID *uuid.UUID
+}
+
+type AuditLogRow struct {
+	ID              uuid.UUID
+	RequestID       string
+	TransactionTime time.Time
+	InitiatorID     uuid.UUID
+	ChangeType      string
+	EntityType      string
+	EntityID        uuid.UUID
+	EffectiveDate   time.Time
+	EndDate         time.Time
+	OldValues       json.RawMessage
+	NewValues       json.RawMessage
+	Meta            json.RawMessage
+}
+
+// AuditValueChange is one top-level key that differs between old_values and new_values.
+// Old is nil when the key was added, New is nil when it was removed.
+type AuditValueChange struct {
+	Field string
+	Old   json.RawMessage
+	New   json.RawMessage
+}
+
+type ListAuditLogsResult struct {
+	Items      []AuditLogRow
+	NextCursor *string
+}
+
+func (s *OrgService) ListAuditLogs(ctx context.Context, tenantID uuid.UUID, filter AuditLogFilter) (*ListAuditLogsResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if filter.EntityType != nil && !slices.Contains(AuditEntityTypes, *filter.EntityType) {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "entity_type is invalid", nil)
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "from must be before to", nil)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 50
+	}
+	if filter.Limit > 500 {
+		filter.Limit = 500
+	}
+
+	rows, err := inTx(ctx, tenantID, func(txCtx context.Context) ([]AuditLogRow, error) {
+		return s.repo.ListAuditLogs(txCtx, tenantID, filter)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	nextCursor := (*string)(nil)
+	if len(rows) > filter.Limit {
+		last := rows[filter.Limit-1]
+		v := "transaction_time:" + last.TransactionTime.UTC().Format(time.RFC3339Nano) + ":id:" + last.ID.String()
+		nextCursor = &v
+		rows = rows[:filter.Limit]
+	}
+	return &ListAuditLogsResult{Items: rows, NextCursor: nextCursor}, nil
+}
+
+// DiffAuditValues compares the top-level keys of two audit value objects. Nested values are compared
+// as JSON, so a changed nested field reports its whole parent key. A nil or non-object side counts as empty.
+func DiffAuditValues(oldValues, newValues json.RawMessage) []AuditValueChange {
+	oldMap := auditValueObject(oldValues)
+	newMap := auditValueObject(newValues)
+
+	keys := make([]string, 0, len(oldMap)+len(newMap))
+	for k := range oldMap {
+		keys = append(keys, k)
+	}
+	for k := range newMap {
+		if _, ok := oldMap[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	out := make([]AuditValueChange, 0, len(keys))
+	for _, k := range keys {
+		o, inOld := oldMap[k]
+		n, inNew := newMap[k]
+		if inOld && inNew && auditJSONEqual(o, n) {
+			continue
+		}
+		out = append(out, AuditValueChange{Field: k, Old: o, New: n})
+	}
+	return out
+}
+
+func auditValueObject(raw json.RawMessage) map[string]json.RawMessage {
+	out := map[string]json.RawMessage{}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return out
+	}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return map[string]json.RawMessage{}
+	}
+	return out
+}
+
+func auditJSONEqual(a, b json.RawMessage) bool {
+	var av, bv any
+	if json.Unmarshal(a, &av) != nil || json.Unmarshal(b, &bv) != nil {
+		return bytes.Equal(bytes.TrimSpace(a), bytes.TrimSpace(b))
+	}
+	ab, _ := json.Marshal(av)
+	bb, _ := json.Marshal(bv)
+	return bytes.Equal(ab, bb)
+}
+
+// FormatAuditValue renders one side of an AuditValueChange for display: strings lose their quotes,
+// everything else stays JSON, and an absent value is empty.
+func FormatAuditValue(raw json.RawMessage) string {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return ""
+	}
+	var s string
+	if trimmed[0] == '"' && json.Unmarshal(trimmed, &s) == nil {
+		return s
+	}
+	return strings.TrimSpace(string(trimmed))
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffAuditValues(t *testing.T) {
+	t.Run("reports changed, added and removed keys sorted by field", func(t *testing.T) {
+		changes := DiffAuditValues(
+			json.RawMessage(`{"name":"Sales","status":"active","display_order":1,"slice_id":"a"}`),
+			json.RawMessage(`{"name":"Sales EMEA","status":"active","display_order":1.0,"parent_hint":null}`),
+		)
+		require.Equal(t, []AuditValueChange{
+			{Field: "name", Old: json.RawMessage(`"Sales"`), New: json.RawMessage(`"Sales EMEA"`)},
+			{Field: "parent_hint", New: json.RawMessage(`null`)},
+			{Field: "slice_id", Old: json.RawMessage(`"a"`)},
+		}, changes)
+	})
+
+	t.Run("nested values compare by content, not formatting", func(t *testing.T) {
+		changes := DiffAuditValues(
+			json.RawMessage(`{"i18n_names":{"en":"Ops","zh":"运营"}}`),
+			json.RawMessage(`{"i18n_names": {"zh":"运营", "en":"Ops"}}`),
+		)
+		require.Empty(t, changes)
+	})
+
+	t.Run("missing old values treat every key as added", func(t *testing.T) {
+		changes := DiffAuditValues(nil, json.RawMessage(`{"code":"ROOT"}`))
+		require.Equal(t, []AuditValueChange{{Field: "code", New: json.RawMessage(`"ROOT"`)}}, changes)
+	})
+}
+
+func TestFormatAuditValue(t *testing.T) {
+	require.Equal(t, "", FormatAuditValue(nil))
+	require.Equal(t, "Sales", FormatAuditValue(json.RawMessage(`"Sales"`)))
+	require.Equal(t, "1.5", FormatAuditValue(json.RawMessage(`1.5`)))
+	require.Equal(t, "null", FormatAuditValue(json.RawMessage(`null`)))
+	require.Equal(t, `{"en":"Ops"}`, FormatAuditValue(json.RawMessage(`{"en":"Ops"}`)))
+}
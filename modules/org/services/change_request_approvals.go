@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/modules/org/domain/changerequest"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const (
+	defaultApprovalStepSLAHours = 72
+	maxApprovalChainSteps       = 20
+)
+
+type ApprovalChainStepInput struct {
+	RoleCode           string  `json:"role_code"`
+	Condition          string  `json:"condition"`
+	SLAHours           *int32  `json:"sla_hours,omitempty"`
+	EscalationRoleCode *string `json:"escalation_role_code,omitempty"`
+}
+
+func (s *ChangeRequestService) GetApprovalChain(ctx context.Context) ([]*changerequest.ApprovalChainStep, error) {
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return nil, err
+	}
+	return s.Repo.ListChainSteps(ctx, changerequest.DefaultApprovalChainCode)
+}
+
+// ReplaceApprovalChain swaps the tenant's chain template. Requests that were already
+// submitted keep the steps they were instantiated with.
+func (s *ChangeRequestService) ReplaceApprovalChain(ctx context.Context, inputs []ApprovalChainStepInput) ([]*changerequest.ApprovalChainStep, error) {
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return nil, err
+	}
+	if len(inputs) > maxApprovalChainSteps {
+		return nil, newServiceError(422, "ORG_APPROVAL_CHAIN_INVALID_BODY", "too many steps", nil)
+	}
+
+	steps := make([]*changerequest.ApprovalChainStep, 0, len(inputs))
+	for i, in := range inputs {
+		roleCode := strings.TrimSpace(in.RoleCode)
+		if roleCode == "" {
+			return nil, newServiceError(422, "ORG_APPROVAL_CHAIN_INVALID_BODY", "role_code is required", nil)
+		}
+		condition := strings.TrimSpace(in.Condition)
+		if condition == "" {
+			condition = changerequest.ConditionAlways
+		}
+		if !changerequest.IsValidCondition(condition) {
+			return nil, newServiceError(422, "ORG_APPROVAL_CHAIN_INVALID_BODY", "condition is invalid", nil)
+		}
+		slaHours := int32(defaultApprovalStepSLAHours)
+		if in.SLAHours != nil {
+			slaHours = *in.SLAHours
+		}
+		if slaHours <= 0 {
+			return nil, newServiceError(422, "ORG_APPROVAL_CHAIN_INVALID_BODY", "sla_hours must be positive", nil)
+		}
+		var escalation *string
+		if in.EscalationRoleCode != nil {
+			if v := strings.TrimSpace(*in.EscalationRoleCode); v != "" {
+				escalation = &v
+			}
+		}
+		steps = append(steps, &changerequest.ApprovalChainStep{
+			StepOrder:          int32(i),
+			RoleCode:           roleCode,
+			Condition:          condition,
+			SLAHours:           slaHours,
+			EscalationRoleCode: escalation,
+		})
+	}
+
+	return s.Repo.ReplaceChainSteps(ctx, changerequest.DefaultApprovalChainCode, steps)
+}
+
+func (s *ChangeRequestService) ListApprovalSteps(ctx context.Context, id uuid.UUID) ([]*changerequest.ApprovalStep, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.Repo.ListApprovalSteps(ctx, id)
+}
+
+// startApprovalChain instantiates the tenant chain for a freshly submitted request. Steps
+// whose condition does not match the payload are recorded as skipped; the first matching
+// step becomes pending with its SLA clock running. A matching step that resolves no
+// approvers rejects the submit rather than leaving the request open to any reviewer.
+func (s *ChangeRequestService) startApprovalChain(ctx context.Context, cr *changerequest.ChangeRequest, now time.Time) error {
+	chain, err := s.Repo.ListChainSteps(ctx, changerequest.DefaultApprovalChainCode)
+	if err != nil {
+		return err
+	}
+	if len(chain) == 0 {
+		return nil
+	}
+
+	facts, err := changerequest.AnalyzePayload(cr.Payload)
+	if err != nil {
+		return newServiceError(422, "ORG_CHANGE_REQUEST_INVALID_BODY", "payload is invalid", err)
+	}
+
+	nodeIDs, err := s.affectedNodeIDs(ctx, facts, now)
+	if err != nil {
+		return err
+	}
+
+	activated := false
+	for _, tmpl := range chain {
+		step := &changerequest.ApprovalStep{
+			ChangeRequestID:    cr.ID,
+			StepOrder:          tmpl.StepOrder,
+			RoleCode:           tmpl.RoleCode,
+			Status:             changerequest.ApprovalStepSkipped,
+			SLAHours:           tmpl.SLAHours,
+			EscalationRoleCode: tmpl.EscalationRoleCode,
+		}
+		if facts.Matches(tmpl.Condition) {
+			approvers, err := s.resolveApprovers(ctx, tmpl.RoleCode, nodeIDs, now)
+			if err != nil {
+				return err
+			}
+			if len(approvers) == 0 {
+				return newServiceError(422, "ORG_APPROVAL_CHAIN_NO_APPROVERS", fmt.Sprintf("no holder of role %q covers the affected org nodes", tmpl.RoleCode), nil)
+			}
+			step.ApproverSubjects = approvers
+			step.Status = changerequest.ApprovalStepWaiting
+			if !activated {
+				dueAt := now.Add(time.Duration(tmpl.SLAHours) * time.Hour)
+				step.Status = changerequest.ApprovalStepPending
+				step.DueAt = &dueAt
+				activated = true
+			}
+		}
+		if _, err := s.Repo.InsertApprovalStep(ctx, step); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// affectedNodeIDs adds the org nodes of positions and assignments referenced by the payload,
+// resolved as of each command's effective date, to the nodes it names directly.
+func (s *ChangeRequestService) affectedNodeIDs(ctx context.Context, facts changerequest.PayloadFacts, now time.Time) ([]uuid.UUID, error) {
+	out := append([]uuid.UUID(nil), facts.AffectedNodeIDs...)
+	if len(facts.PositionRefs) == 0 {
+		return out, nil
+	}
+	refs := make([]changerequest.PositionRef, 0, len(facts.PositionRefs))
+	for _, ref := range facts.PositionRefs {
+		if ref.EffectiveDate.IsZero() {
+			ref.EffectiveDate = now
+		}
+		refs = append(refs, ref)
+	}
+	resolved, err := s.Repo.ResolvePositionOrgNodes(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[uuid.UUID]struct{}, len(out)+len(resolved))
+	for _, id := range out {
+		seen[id] = struct{}{}
+	}
+	for _, id := range resolved {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+func (s *ChangeRequestService) resolveApprovers(ctx context.Context, roleCode string, nodeIDs []uuid.UUID, asOf time.Time) ([]string, error) {
+	subjects, err := s.Repo.ResolveRoleHolders(ctx, roleCode, nodeIDs, asOf)
+	if err != nil {
+		if errors.Is(err, ErrOrgDeepReadBuildNotReady) {
+			return nil, newServiceError(409, "ORG_APPROVAL_CHAIN_BUILD_NOT_READY", "closure build is not ready; approvers cannot be resolved", err)
+		}
+		return nil, err
+	}
+	return subjects, nil
+}
+
+// decideApprovalStep records the reviewer's decision on the current pending step. It returns
+// handled=false when the request has no active chain so the caller falls back to a single
+// review, and final=true once the decision settles the whole request.
+func (s *ChangeRequestService) decideApprovalStep(ctx context.Context, params ReviewChangeRequestParams, toStatus string, comment *string, now time.Time) (handled bool, final bool, err error) {
+	steps, err := s.Repo.ListApprovalSteps(ctx, params.ID)
+	if err != nil {
+		return false, false, err
+	}
+
+	var current *changerequest.ApprovalStep
+	var next *changerequest.ApprovalStep
+	for _, step := range steps {
+		switch step.Status {
+		case changerequest.ApprovalStepPending:
+			if current == nil {
+				current = step
+			}
+		case changerequest.ApprovalStepWaiting:
+			if current != nil && next == nil {
+				next = step
+			}
+		}
+	}
+	if current == nil {
+		return false, false, nil
+	}
+	if !current.CanDecide(params.ReviewerSubjects) {
+		return true, false, newServiceError(403, "ORG_CHANGE_REQUEST_NOT_APPROVER", "reviewer is not an approver of the current step", nil)
+	}
+
+	stepStatus := changerequest.ApprovalStepApproved
+	if toStatus == changerequest.StatusRejected {
+		stepStatus = changerequest.ApprovalStepRejected
+	}
+	reviewerID := params.ReviewerID
+	if _, err := s.Repo.DecideApprovalStep(ctx, current.ID, changerequest.ApprovalStepPending, stepStatus, &reviewerID, comment); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return true, false, newServiceError(409, "ORG_APPROVAL_STEP_CONFLICT", "approval step was already decided", err)
+		}
+		return true, false, err
+	}
+
+	if stepStatus == changerequest.ApprovalStepRejected || next == nil {
+		return true, true, nil
+	}
+	if _, err := s.Repo.ActivateApprovalStep(ctx, next.ID, now.Add(time.Duration(next.SLAHours)*time.Hour)); err != nil {
+		return true, false, err
+	}
+	return true, false, nil
+}
+
+// EscalateOverdue flags pending steps whose SLA has passed and opens them to the holders of
+// the step's escalation role. Steps without an escalation role are only flagged.
+func (s *ChangeRequestService) EscalateOverdue(ctx context.Context, now time.Time, limit int) ([]*changerequest.ApprovalStep, error) {
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+	overdue, err := s.Repo.ListOverdueApprovalSteps(ctx, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*changerequest.ApprovalStep, 0, len(overdue))
+	for _, step := range overdue {
+		var subjects []string
+		if step.EscalationRoleCode != nil {
+			cr, err := s.Repo.GetByID(ctx, step.ChangeRequestID)
+			if err != nil {
+				return nil, err
+			}
+			facts, err := changerequest.AnalyzePayload(cr.Payload)
+			if err != nil {
+				return nil, newServiceError(422, "ORG_CHANGE_REQUEST_INVALID_BODY", "payload is invalid", err)
+			}
+			nodeIDs, err := s.affectedNodeIDs(ctx, facts, now)
+			if err != nil {
+				return nil, err
+			}
+			subjects, err = s.resolveApprovers(ctx, *step.EscalationRoleCode, nodeIDs, now)
+			if err != nil {
+				return nil, err
+			}
+		}
+		escalated, err := s.Repo.EscalateApprovalStep(ctx, step.ID, subjects)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return nil, err
+		}
+		out = append(out, escalated)
+	}
+	return out, nil
+}
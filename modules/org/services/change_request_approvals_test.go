@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/org/domain/changerequest"
+)
+
+// approvalChainRepoStub serves the chain template and role holders from memory; the
+// embedded interface panics on any method the approval chain does not touch.
+type approvalChainRepoStub struct {
+	changerequest.Repository
+
+	chain         []*changerequest.ApprovalChainStep
+	positionNodes map[uuid.UUID]uuid.UUID
+	holders       map[uuid.UUID][]string
+	positionRefs  []changerequest.PositionRef
+	inserted      []*changerequest.ApprovalStep
+}
+
+func (r *approvalChainRepoStub) ListChainSteps(context.Context, string) ([]*changerequest.ApprovalChainStep, error) {
+	return r.chain, nil
+}
+
+func (r *approvalChainRepoStub) ResolvePositionOrgNodes(_ context.Context, refs []changerequest.PositionRef) ([]uuid.UUID, error) {
+	r.positionRefs = append(r.positionRefs, refs...)
+	out := []uuid.UUID{}
+	for _, ref := range refs {
+		if ref.AssignmentID == nil {
+			continue
+		}
+		if nodeID, ok := r.positionNodes[*ref.AssignmentID]; ok {
+			out = append(out, nodeID)
+		}
+	}
+	return out, nil
+}
+
+func (r *approvalChainRepoStub) ResolveRoleHolders(_ context.Context, _ string, nodeIDs []uuid.UUID, _ time.Time) ([]string, error) {
+	out := []string{}
+	for _, id := range nodeIDs {
+		out = append(out, r.holders[id]...)
+	}
+	return out, nil
+}
+
+func (r *approvalChainRepoStub) InsertApprovalStep(_ context.Context, step *changerequest.ApprovalStep) (*changerequest.ApprovalStep, error) {
+	r.inserted = append(r.inserted, step)
+	return step, nil
+}
+
+func TestStartApprovalChain_RescindOnlyPayload(t *testing.T) {
+	assignmentID := uuid.New()
+	nodeID := uuid.New()
+	now := time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC)
+	cr := &changerequest.ChangeRequest{
+		ID: uuid.New(),
+		Payload: json.RawMessage(`{"effective_date":"2025-03-01","commands":[
+			{"type":"assignment.rescind","payload":{"id":"` + assignmentID.String() + `"}}
+		]}`),
+	}
+	chain := []*changerequest.ApprovalChainStep{{StepOrder: 0, RoleCode: "line_manager", Condition: changerequest.ConditionAlways, SLAHours: 24}}
+
+	t.Run("resolves approvers through the assignment's position", func(t *testing.T) {
+		repo := &approvalChainRepoStub{
+			chain:         chain,
+			positionNodes: map[uuid.UUID]uuid.UUID{assignmentID: nodeID},
+			holders:       map[uuid.UUID][]string{nodeID: {"user:manager"}},
+		}
+		svc := NewChangeRequestService(repo)
+
+		require.NoError(t, svc.startApprovalChain(context.Background(), cr, now))
+		require.Len(t, repo.positionRefs, 1)
+		require.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), repo.positionRefs[0].EffectiveDate)
+		require.Len(t, repo.inserted, 1)
+		require.Equal(t, changerequest.ApprovalStepPending, repo.inserted[0].Status)
+		require.Equal(t, []string{"user:manager"}, repo.inserted[0].ApproverSubjects)
+	})
+
+	t.Run("rejects the submit when nobody can approve", func(t *testing.T) {
+		repo := &approvalChainRepoStub{
+			chain:         chain,
+			positionNodes: map[uuid.UUID]uuid.UUID{assignmentID: nodeID},
+		}
+		svc := NewChangeRequestService(repo)
+
+		err := svc.startApprovalChain(context.Background(), cr, now)
+		var svcErr *ServiceError
+		require.ErrorAs(t, err, &svcErr)
+		require.Equal(t, 422, svcErr.Status)
+		require.Equal(t, "ORG_APPROVAL_CHAIN_NO_APPROVERS", svcErr.Code)
+		require.Empty(t, repo.inserted)
+	})
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/modules/org/domain/changerequest"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// ChangeRequestExecutor runs change request payloads through OrgService.ExecuteCommands, the
+// same executor /batch uses. POST /change-requests/{id}:apply and the scheduler both apply
+// through it, so a scheduled run records exactly what a manual apply would return.
+type ChangeRequestExecutor struct {
+	org            *OrgService
+	changeRequests *ChangeRequestService
+}
+
+func NewChangeRequestExecutor(org *OrgService, changeRequests *ChangeRequestService) *ChangeRequestExecutor {
+	return &ChangeRequestExecutor{org: org, changeRequests: changeRequests}
+}
+
+func DecodeChangeRequestPayload(cr *changerequest.ChangeRequest) (PreflightInput, error) {
+	var payload PreflightInput
+	if err := json.Unmarshal(cr.Payload, &payload); err != nil {
+		return PreflightInput{}, newServiceError(422, "ORG_CHANGE_REQUEST_INVALID_BODY", "payload is invalid", err)
+	}
+	return payload, nil
+}
+
+// Preflight runs OrgService.Preflight against the stored payload, using the change request's
+// request_id so validation errors line up with the eventual apply.
+func (e *ChangeRequestExecutor) Preflight(ctx context.Context, tenantID uuid.UUID, initiatorID uuid.UUID, cr *changerequest.ChangeRequest) (PreflightResult, error) {
+	payload, err := DecodeChangeRequestPayload(cr)
+	if err != nil {
+		return PreflightResult{}, err
+	}
+	return e.org.Preflight(ctx, tenantID, cr.RequestID, initiatorID, payload)
+}
+
+type ChangeRequestApplyResult struct {
+	ChangeRequest *changerequest.ChangeRequest
+	Results       []CommandResult
+}
+
+// ApplyApproved refuses requests whose scheduled_for is still in the future, executes the
+// payload in one transaction stamped with the change request's request_id, and records the
+// per-command results on the request.
+func (e *ChangeRequestExecutor) ApplyApproved(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, initiatorID uuid.UUID, now time.Time) (*ChangeRequestApplyResult, error) {
+	existing, err := inTx(ctx, tenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+		return e.changeRequests.GetDue(txCtx, id, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := e.Preflight(ctx, tenantID, initiatorID, existing); err != nil {
+		return nil, err
+	}
+
+	out, err := inTx(ctx, tenantID, func(txCtx context.Context) (*ChangeRequestApplyResult, error) {
+		txCtx = WithSkipCacheInvalidation(txCtx)
+		cr, err := e.changeRequests.GetDue(txCtx, id, now)
+		if err != nil {
+			return nil, err
+		}
+		payload, err := DecodeChangeRequestPayload(cr)
+		if err != nil {
+			return nil, err
+		}
+		effectiveDate := strings.TrimSpace(payload.EffectiveDate)
+		if effectiveDate == "" {
+			effectiveDate = normalizeValidTimeDayUTC(now).Format(time.DateOnly)
+		}
+		results, _, err := e.org.ExecuteCommands(txCtx, tenantID, cr.RequestID, initiatorID, effectiveDate, payload.Commands, "ORG_PREFLIGHT_INVALID_COMMAND")
+		if err != nil {
+			return nil, err
+		}
+		if _, err := e.changeRequests.MarkApplied(txCtx, id, initiatorID); err != nil {
+			return nil, err
+		}
+		auditRequestID := cr.RequestID
+		recorded, err := e.changeRequests.RecordExecution(txCtx, id, ChangeRequestExecution{
+			Result:         map[string]any{"results": results},
+			AuditRequestID: &auditRequestID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &ChangeRequestApplyResult{ChangeRequest: recorded, Results: results}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.org.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	return out, nil
+}
+
+// ApplyDue applies one request picked up by the scheduler. Scheduled runs are attributed to
+// the approver; requests approved without a reviewer fall back to the requester. A failed
+// run is recorded on the request. Losing a race to another actor is not an error; it reports
+// applied=false.
+func (e *ChangeRequestExecutor) ApplyDue(ctx context.Context, ref changerequest.DueRef, now time.Time) (bool, error) {
+	cr, err := inTx(ctx, ref.TenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+		return e.changeRequests.Get(txCtx, ref.ID)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	initiatorID := cr.RequesterID
+	if cr.ReviewerID != nil {
+		initiatorID = *cr.ReviewerID
+	}
+
+	_, applyErr := e.ApplyApproved(ctx, ref.TenantID, ref.ID, initiatorID, now)
+	if applyErr == nil {
+		return true, nil
+	}
+	if isChangeRequestApplyRace(applyErr) {
+		return false, nil
+	}
+
+	auditRequestID := cr.RequestID
+	if _, err := inTx(ctx, ref.TenantID, func(txCtx context.Context) (*changerequest.ChangeRequest, error) {
+		return e.changeRequests.RecordExecution(txCtx, ref.ID, ChangeRequestExecution{
+			Error:          NewChangeRequestExecutionError(applyErr),
+			AuditRequestID: &auditRequestID,
+		})
+	}); err != nil {
+		return false, errors.Join(applyErr, err)
+	}
+	return false, applyErr
+}
+
+// EscalateOverdueForTenant runs EscalateOverdue in its own transaction for one tenant.
+func (e *ChangeRequestExecutor) EscalateOverdueForTenant(ctx context.Context, tenantID uuid.UUID, now time.Time, limit int) ([]*changerequest.ApprovalStep, error) {
+	return inTx(ctx, tenantID, func(txCtx context.Context) ([]*changerequest.ApprovalStep, error) {
+		return e.changeRequests.EscalateOverdue(txCtx, now, limit)
+	})
+}
+
+// ListDue and ListTenantsWithOverdueApprovals scan across tenants, so they run in a
+// read-only transaction without tenant RLS.
+func (e *ChangeRequestExecutor) ListDue(ctx context.Context, now time.Time, limit int) ([]changerequest.DueRef, error) {
+	return withoutTenantTx(ctx, func(txCtx context.Context) ([]changerequest.DueRef, error) {
+		return e.changeRequests.ListDue(txCtx, now, limit)
+	})
+}
+
+func (e *ChangeRequestExecutor) ListTenantsWithOverdueApprovals(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	return withoutTenantTx(ctx, func(txCtx context.Context) ([]uuid.UUID, error) {
+		return e.changeRequests.ListTenantsWithOverdueApprovals(txCtx, now)
+	})
+}
+
+func withoutTenantTx[T any](ctx context.Context, fn func(txCtx context.Context) (T, error)) (T, error) {
+	var zero T
+	pool, err := composables.UsePool(ctx)
+	if err != nil {
+		return zero, err
+	}
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return zero, err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+	return fn(composables.WithTx(ctx, tx))
+}
+
+// isChangeRequestApplyRace reports errors caused by another actor changing the request between
+// listing and applying; there is nothing to record for those.
+func isChangeRequestApplyRace(err error) bool {
+	var svcErr *ServiceError
+	if !errors.As(err, &svcErr) {
+		return false
+	}
+	switch svcErr.Code {
+	case "ORG_CHANGE_REQUEST_NOT_FOUND", "ORG_CHANGE_REQUEST_NOT_APPROVED", "ORG_CHANGE_REQUEST_NOT_DUE":
+		return true
+	default:
+		return false
+	}
+}
+
+// ChangeRequestExecutionError is the execution_error body; it mirrors the API error a
+// manual apply would have returned.
+type ChangeRequestExecutionError struct {
+	Code         string `json:"code"`
+	Message      string `json:"message"`
+	CommandIndex *int   `json:"command_index,omitempty"`
+	CommandType  string `json:"command_type,omitempty"`
+}
+
+func NewChangeRequestExecutionError(err error) ChangeRequestExecutionError {
+	out := ChangeRequestExecutionError{Code: "ORG_INTERNAL", Message: err.Error()}
+	var cmdErr *CommandError
+	if errors.As(err, &cmdErr) {
+		index := cmdErr.Index
+		out.CommandIndex = &index
+		out.CommandType = cmdErr.Type
+		out.Message = cmdErr.Err.Error()
+	}
+	var svcErr *ServiceError
+	if errors.As(err, &svcErr) {
+		out.Code = svcErr.Code
+		out.Message = svcErr.Message
+	}
+	return out
+}
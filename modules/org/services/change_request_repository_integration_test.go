@@ -128,6 +128,9 @@ func newOrgTestDB(tb testing.TB, ctx context.Context, isCI bool) *pgxpool.Pool {
 
 	applyGooseUpSQL(tb, ctx, pool, filepath.Join("..", "..", "..", "migrations", "org", "00001_org_baseline.sql"))
 	applyGooseUpSQL(tb, ctx, pool, filepath.Join("..", "..", "..", "migrations", "org", "20251218005114_org_placeholders_and_event_contracts.sql"))
+	applyGooseUpSQL(tb, ctx, pool, filepath.Join("..", "..", "..", "migrations", "org", "20260105090000_org_change_request_review.sql"))
+	applyGooseUpSQL(tb, ctx, pool, filepath.Join("..", "..", "..", "migrations", "org", "20260106090000_org_change_request_approval_chains.sql"))
+	applyGooseUpSQL(tb, ctx, pool, filepath.Join("..", "..", "..", "migrations", "org", "20260107090000_org_change_request_scheduling.sql"))
 
 	// Keep DB around until pool closed.
 	tb.Cleanup(func() {
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/modules/org/domain/changerequest"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+// Schedule sets (or clears, when scheduledFor is nil) the go-live moment of a change request.
+// Rescheduling also clears a previous execution error so the scheduler picks the row up again.
+func (s *ChangeRequestService) Schedule(ctx context.Context, id uuid.UUID, scheduledFor *time.Time) (*changerequest.ChangeRequest, error) {
+	if id == uuid.Nil {
+		return nil, newServiceError(400, "ORG_CHANGE_REQUEST_INVALID_QUERY", "id is required", nil)
+	}
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return nil, err
+	}
+
+	var at *time.Time
+	if scheduledFor != nil {
+		v := scheduledFor.UTC()
+		at = &v
+	}
+
+	updated, err := s.Repo.ScheduleByID(ctx, id, at)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, getErr := s.Get(ctx, id); getErr != nil {
+				return nil, getErr
+			}
+			return nil, newServiceError(409, "ORG_CHANGE_REQUEST_NOT_SCHEDULABLE", "change request cannot be scheduled", err)
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+// GetDue is GetApproved plus the scheduled_for gate: a request held for a future go-live
+// cannot be applied early, neither manually nor by the scheduler.
+func (s *ChangeRequestService) GetDue(ctx context.Context, id uuid.UUID, now time.Time) (*changerequest.ChangeRequest, error) {
+	cr, err := s.GetApproved(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !cr.IsDue(now) {
+		return nil, newServiceError(409, "ORG_CHANGE_REQUEST_NOT_DUE", "change request is scheduled for a later time", nil)
+	}
+	return cr, nil
+}
+
+func (s *ChangeRequestService) ListDue(ctx context.Context, now time.Time, limit int) ([]changerequest.DueRef, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	return s.Repo.ListDue(ctx, now, limit)
+}
+
+func (s *ChangeRequestService) ListTenantsWithOverdueApprovals(ctx context.Context, now time.Time) ([]uuid.UUID, error) {
+	return s.Repo.ListTenantsWithOverdueApprovalSteps(ctx, now)
+}
+
+type ChangeRequestExecution struct {
+	Result         any
+	Error          any
+	AuditRequestID *string
+}
+
+// RecordExecution writes the outcome of an apply run back onto the change request.
+func (s *ChangeRequestService) RecordExecution(ctx context.Context, id uuid.UUID, exec ChangeRequestExecution) (*changerequest.ChangeRequest, error) {
+	if id == uuid.Nil {
+		return nil, newServiceError(400, "ORG_CHANGE_REQUEST_INVALID_QUERY", "id is required", nil)
+	}
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return nil, err
+	}
+
+	var result, execErr []byte
+	var err error
+	if exec.Result != nil {
+		if result, err = json.Marshal(exec.Result); err != nil {
+			return nil, err
+		}
+	}
+	if exec.Error != nil {
+		if execErr, err = json.Marshal(exec.Error); err != nil {
+			return nil, err
+		}
+	}
+
+	updated, err := s.Repo.RecordExecutionByID(ctx, id, result, execErr, exec.AuditRequestID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, newServiceError(404, "ORG_CHANGE_REQUEST_NOT_FOUND", "not found", err)
+		}
+		return nil, err
+	}
+	return updated, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -150,7 +151,14 @@ func (s *ChangeRequestService) Submit(ctx context.Context, id uuid.UUID) (*chang
 	if existing.Status != changerequest.StatusDraft {
 		return nil, newServiceError(409, "ORG_CHANGE_REQUEST_NOT_DRAFT", "change request is not draft", nil)
 	}
-	return s.Repo.UpdateStatusByID(ctx, id, changerequest.StatusSubmitted)
+	submitted, err := s.Repo.UpdateStatusByID(ctx, id, changerequest.StatusSubmitted)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.startApprovalChain(ctx, submitted, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	return submitted, nil
 }
 
 func (s *ChangeRequestService) Cancel(ctx context.Context, id uuid.UUID) (*changerequest.ChangeRequest, error) {
@@ -175,3 +183,123 @@ func (s *ChangeRequestService) Cancel(ctx context.Context, id uuid.UUID) (*chang
 		return nil, newServiceError(409, "ORG_CHANGE_REQUEST_NOT_CANCELLABLE", "change request cannot be cancelled", nil)
 	}
 }
+
+type ReviewChangeRequestParams struct {
+	ID         uuid.UUID `json:"id"`
+	ReviewerID uuid.UUID `json:"reviewer_id"`
+	// ReviewerSubjects are the reviewer's "user:<uuid>" / "group:<uuid>" identities, matched
+	// against the approvers of the current chain step.
+	ReviewerSubjects []string `json:"reviewer_subjects,omitempty"`
+	Comment          *string  `json:"comment,omitempty"`
+}
+
+// EnsureNotSelfReview rejects reviews by the requester; change requests replace a four-eyes
+// sign-off, so the person who asked for a change never approves or rejects it. Requesters
+// withdraw their own requests with Cancel instead.
+func EnsureNotSelfReview(cr *changerequest.ChangeRequest, reviewerID uuid.UUID) error {
+	if cr != nil && reviewerID != uuid.Nil && cr.RequesterID == reviewerID {
+		return newServiceError(403, "ORG_CHANGE_REQUEST_SELF_REVIEW", "requester cannot review their own change request", nil)
+	}
+	return nil
+}
+
+func (s *ChangeRequestService) Approve(ctx context.Context, params ReviewChangeRequestParams) (*changerequest.ChangeRequest, error) {
+	return s.review(ctx, params, changerequest.StatusApproved)
+}
+
+func (s *ChangeRequestService) Reject(ctx context.Context, params ReviewChangeRequestParams) (*changerequest.ChangeRequest, error) {
+	if params.Comment == nil || strings.TrimSpace(*params.Comment) == "" {
+		return nil, newServiceError(422, "ORG_CHANGE_REQUEST_INVALID_BODY", "comment is required", nil)
+	}
+	return s.review(ctx, params, changerequest.StatusRejected)
+}
+
+func (s *ChangeRequestService) review(ctx context.Context, params ReviewChangeRequestParams, toStatus string) (*changerequest.ChangeRequest, error) {
+	if params.ID == uuid.Nil {
+		return nil, newServiceError(400, "ORG_CHANGE_REQUEST_INVALID_QUERY", "id is required", nil)
+	}
+	if params.ReviewerID == uuid.Nil {
+		return nil, newServiceError(400, "ORG_CHANGE_REQUEST_INVALID_BODY", "reviewer_id is required", nil)
+	}
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return nil, err
+	}
+
+	var comment *string
+	if params.Comment != nil {
+		if v := strings.TrimSpace(*params.Comment); v != "" {
+			comment = &v
+		}
+	}
+
+	existing, err := s.Repo.GetByID(ctx, params.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, newServiceError(404, "ORG_CHANGE_REQUEST_NOT_FOUND", "not found", err)
+		}
+		return nil, err
+	}
+	if existing.Status != changerequest.StatusSubmitted {
+		return nil, newServiceError(409, "ORG_CHANGE_REQUEST_NOT_SUBMITTED", "change request is not submitted", nil)
+	}
+	if err := EnsureNotSelfReview(existing, params.ReviewerID); err != nil {
+		return nil, err
+	}
+
+	handled, final, err := s.decideApprovalStep(ctx, params, toStatus, comment, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if handled && !final {
+		return existing, nil
+	}
+
+	updated, err := s.Repo.UpdateReviewByID(ctx, params.ID, changerequest.StatusSubmitted, toStatus, params.ReviewerID, comment)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, newServiceError(409, "ORG_CHANGE_REQUEST_NOT_SUBMITTED", "change request is not submitted", err)
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+// GetApproved loads a change request that is ready to apply. Callers run the payload
+// commands and MarkApplied in the same transaction; MarkApplied only transitions
+// approved rows, so a concurrent apply fails with 409 and rolls back its writes.
+func (s *ChangeRequestService) GetApproved(ctx context.Context, id uuid.UUID) (*changerequest.ChangeRequest, error) {
+	if id == uuid.Nil {
+		return nil, newServiceError(400, "ORG_CHANGE_REQUEST_INVALID_QUERY", "id is required", nil)
+	}
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return nil, err
+	}
+	existing, err := s.Repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, newServiceError(404, "ORG_CHANGE_REQUEST_NOT_FOUND", "not found", err)
+		}
+		return nil, err
+	}
+	if existing.Status != changerequest.StatusApproved {
+		return nil, newServiceError(409, "ORG_CHANGE_REQUEST_NOT_APPROVED", "change request is not approved", nil)
+	}
+	return existing, nil
+}
+
+func (s *ChangeRequestService) MarkApplied(ctx context.Context, id uuid.UUID, appliedBy uuid.UUID) (*changerequest.ChangeRequest, error) {
+	if id == uuid.Nil {
+		return nil, newServiceError(400, "ORG_CHANGE_REQUEST_INVALID_QUERY", "id is required", nil)
+	}
+	if _, err := composables.UseTenantID(ctx); err != nil {
+		return nil, err
+	}
+	updated, err := s.Repo.MarkAppliedByID(ctx, id, appliedBy)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, newServiceError(409, "ORG_CHANGE_REQUEST_NOT_APPROVED", "change request is not approved", err)
+		}
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,564 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+// Command is one entry of a /batch, preflight, change request or scenario payload.
+type Command struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// CommandResult is the outcome of one executed command; Result carries the id it wrote.
+type CommandResult struct {
+	Index  int            `json:"index"`
+	Type   string         `json:"type"`
+	Ok     bool           `json:"ok"`
+	Result map[string]any `json:"result,omitempty"`
+}
+
+// CommandError pins a failed command to its position in the payload.
+type CommandError struct {
+	Index int
+	Type  string
+	Err   error
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("command %d (%s): %v", e.Index, e.Type, e.Err)
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// ExecuteCommands runs commands in order inside the caller's transaction. Commands without
+// their own effective_date inherit effectiveDate. Malformed commands are reported with
+// invalidCode, so each entry point keeps its own error vocabulary. It returns the
+// per-command results and the number of events the commands generated.
+func (s *OrgService) ExecuteCommands(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, effectiveDate string, commands []Command, invalidCode string) ([]CommandResult, int, error) {
+	results := make([]CommandResult, 0, len(commands))
+	eventsGenerated := 0
+	for i, cmd := range commands {
+		cmdType := strings.TrimSpace(cmd.Type)
+		if cmdType == "" {
+			return nil, 0, &CommandError{Index: i, Type: cmdType, Err: newServiceError(422, invalidCode, "type is required", nil)}
+		}
+		if len(cmd.Payload) == 0 {
+			return nil, 0, &CommandError{Index: i, Type: cmdType, Err: newServiceError(422, invalidCode, "payload is required", nil)}
+		}
+		payload, err := InjectEffectiveDate(cmd.Payload, effectiveDate)
+		if err != nil {
+			return nil, 0, &CommandError{Index: i, Type: cmdType, Err: newServiceError(422, invalidCode, "payload is invalid", err)}
+		}
+		id, generated, err := s.executeCommand(ctx, tenantID, requestID, initiatorID, cmdType, payload, invalidCode)
+		if err != nil {
+			return nil, 0, &CommandError{Index: i, Type: cmdType, Err: err}
+		}
+		results = append(results, CommandResult{Index: i, Type: cmdType, Ok: true, Result: map[string]any{"id": id.String()}})
+		eventsGenerated += generated
+	}
+	return results, eventsGenerated, nil
+}
+
+// InjectEffectiveDate sets effective_date on a command payload that does not carry one.
+func InjectEffectiveDate(payload json.RawMessage, effectiveDate string) (json.RawMessage, error) {
+	if strings.TrimSpace(effectiveDate) == "" {
+		return payload, nil
+	}
+	var obj map[string]any
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, err
+	}
+	if _, ok := obj["effective_date"]; !ok {
+		obj["effective_date"] = effectiveDate
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// commandField tells an explicit null apart from an absent key, for partial updates.
+type commandField[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (f *commandField[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Value = nil
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	f.Value = &v
+	return nil
+}
+
+func (f commandField[T]) ptr() **T {
+	if !f.Set {
+		return nil
+	}
+	return &f.Value
+}
+
+type nodeAttributesCommand struct {
+	Name          *string                 `json:"name"`
+	I18nNames     map[string]string       `json:"i18n_names"`
+	Status        *string                 `json:"status"`
+	DisplayOrder  *int                    `json:"display_order"`
+	LegalEntityID commandField[uuid.UUID] `json:"legal_entity_id"`
+	CompanyCode   commandField[string]    `json:"company_code"`
+	LocationID    commandField[uuid.UUID] `json:"location_id"`
+	ManagerUserID commandField[int64]     `json:"manager_user_id"`
+}
+
+func parseCommandDate(field, v string) (time.Time, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, fmt.Errorf("%s is required", field)
+	}
+	t, err := time.Parse(time.DateOnly, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s must be YYYY-MM-DD", field)
+	}
+	return normalizeValidTimeDayUTC(t), nil
+}
+
+// executeCommand dispatches one command to the matching OrgService write and returns the id
+// of the written record and the number of events the write generated.
+func (s *OrgService) executeCommand(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, cmdType string, payload json.RawMessage, invalidCode string) (uuid.UUID, int, error) {
+	invalid := func(message string, cause error) error {
+		return newServiceError(422, invalidCode, message, cause)
+	}
+	notFound := newServiceError(404, "ORG_NOT_FOUND", "not found", nil)
+
+	switch cmdType {
+	case "node.create":
+		var body struct {
+			HierarchyType string            `json:"hierarchy_type"`
+			Code          string            `json:"code"`
+			Name          string            `json:"name"`
+			ParentID      *uuid.UUID        `json:"parent_id"`
+			EffectiveDate string            `json:"effective_date"`
+			I18nNames     map[string]string `json:"i18n_names"`
+			Status        string            `json:"status"`
+			DisplayOrder  int               `json:"display_order"`
+			LegalEntityID *uuid.UUID        `json:"legal_entity_id"`
+			CompanyCode   *string           `json:"company_code"`
+			LocationID    *uuid.UUID        `json:"location_id"`
+			ManagerUserID *int64            `json:"manager_user_id"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.CreateNode(ctx, tenantID, requestID, initiatorID, CreateNodeInput{
+			HierarchyType: body.HierarchyType,
+			Code:          body.Code,
+			Name:          body.Name,
+			ParentID:      body.ParentID,
+			EffectiveDate: effectiveDate,
+			I18nNames:     body.I18nNames,
+			Status:        body.Status,
+			DisplayOrder:  body.DisplayOrder,
+			LegalEntityID: body.LegalEntityID,
+			CompanyCode:   body.CompanyCode,
+			LocationID:    body.LocationID,
+			ManagerUserID: body.ManagerUserID,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.NodeID, len(res.GeneratedEvents), nil
+
+	case "node.update":
+		var body struct {
+			ID            uuid.UUID `json:"id"`
+			EffectiveDate string    `json:"effective_date"`
+			nodeAttributesCommand
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.UpdateNode(ctx, tenantID, requestID, initiatorID, UpdateNodeInput{
+			NodeID:        body.ID,
+			EffectiveDate: effectiveDate,
+			Name:          body.Name,
+			I18nNames:     body.I18nNames,
+			Status:        body.Status,
+			DisplayOrder:  body.DisplayOrder,
+			LegalEntityID: body.LegalEntityID.ptr(),
+			CompanyCode:   body.CompanyCode.ptr(),
+			LocationID:    body.LocationID.ptr(),
+			ManagerUserID: body.ManagerUserID.ptr(),
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.NodeID, len(res.GeneratedEvents), nil
+
+	case "node.move":
+		var body struct {
+			ID            uuid.UUID `json:"id"`
+			EffectiveDate string    `json:"effective_date"`
+			NewParentID   uuid.UUID `json:"new_parent_id"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.MoveNode(ctx, tenantID, requestID, initiatorID, MoveNodeInput{
+			NodeID:        body.ID,
+			NewParentID:   body.NewParentID,
+			EffectiveDate: effectiveDate,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.EdgeID, len(res.GeneratedEvents), nil
+
+	case "node.correct":
+		var body struct {
+			ID            uuid.UUID `json:"id"`
+			EffectiveDate string    `json:"effective_date"`
+			nodeAttributesCommand
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		asOf, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.CorrectNode(ctx, tenantID, requestID, initiatorID, CorrectNodeInput{
+			NodeID:        body.ID,
+			AsOf:          asOf,
+			Name:          body.Name,
+			I18nNames:     body.I18nNames,
+			Status:        body.Status,
+			DisplayOrder:  body.DisplayOrder,
+			LegalEntityID: body.LegalEntityID.ptr(),
+			CompanyCode:   body.CompanyCode.ptr(),
+			LocationID:    body.LocationID.ptr(),
+			ManagerUserID: body.ManagerUserID.ptr(),
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.NodeID, len(res.GeneratedEvents), nil
+
+	case "node.rescind":
+		var body struct {
+			ID            uuid.UUID `json:"id"`
+			EffectiveDate string    `json:"effective_date"`
+			Reason        string    `json:"reason"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.RescindNode(ctx, tenantID, requestID, initiatorID, RescindNodeInput{
+			NodeID:        body.ID,
+			EffectiveDate: effectiveDate,
+			Reason:        body.Reason,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.NodeID, len(res.GeneratedEvents), nil
+
+	case "node.shift_boundary":
+		var body struct {
+			ID                  uuid.UUID `json:"id"`
+			TargetEffectiveDate string    `json:"target_effective_date"`
+			NewEffectiveDate    string    `json:"new_effective_date"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		target, err := parseCommandDate("target_effective_date", body.TargetEffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("target_effective_date is required", err)
+		}
+		newStart, err := parseCommandDate("new_effective_date", body.NewEffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("new_effective_date is required", err)
+		}
+		res, err := s.ShiftBoundaryNode(ctx, tenantID, requestID, initiatorID, ShiftBoundaryNodeInput{
+			NodeID:              body.ID,
+			TargetEffectiveDate: target,
+			NewEffectiveDate:    newStart,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.NodeID, len(res.GeneratedEvents), nil
+
+	case "node.correct_move":
+		var body struct {
+			ID            uuid.UUID `json:"id"`
+			EffectiveDate string    `json:"effective_date"`
+			NewParentID   uuid.UUID `json:"new_parent_id"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.CorrectMoveNode(ctx, tenantID, requestID, initiatorID, CorrectMoveNodeInput{
+			NodeID:        body.ID,
+			EffectiveDate: effectiveDate,
+			NewParentID:   body.NewParentID,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.NodeID, len(res.GeneratedEvents), nil
+
+	case "assignment.create":
+		var body struct {
+			Pernr          string     `json:"pernr"`
+			EffectiveDate  string     `json:"effective_date"`
+			AssignmentType string     `json:"assignment_type"`
+			PositionID     *uuid.UUID `json:"position_id"`
+			OrgNodeID      *uuid.UUID `json:"org_node_id"`
+			SubjectID      *uuid.UUID `json:"subject_id"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.CreateAssignment(ctx, tenantID, requestID, initiatorID, CreateAssignmentInput{
+			Pernr:          body.Pernr,
+			EffectiveDate:  effectiveDate,
+			AssignmentType: body.AssignmentType,
+			PositionID:     body.PositionID,
+			OrgNodeID:      body.OrgNodeID,
+			SubjectID:      body.SubjectID,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.AssignmentID, len(res.GeneratedEvents), nil
+
+	case "assignment.update":
+		var body struct {
+			ID            uuid.UUID               `json:"id"`
+			EffectiveDate string                  `json:"effective_date"`
+			PositionID    commandField[uuid.UUID] `json:"position_id"`
+			OrgNodeID     commandField[uuid.UUID] `json:"org_node_id"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.UpdateAssignment(ctx, tenantID, requestID, initiatorID, UpdateAssignmentInput{
+			AssignmentID:  body.ID,
+			EffectiveDate: effectiveDate,
+			PositionID:    body.PositionID.Value,
+			OrgNodeID:     body.OrgNodeID.Value,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.AssignmentID, len(res.GeneratedEvents), nil
+
+	case "assignment.correct":
+		var body struct {
+			ID         uuid.UUID  `json:"id"`
+			Pernr      *string    `json:"pernr"`
+			PositionID *uuid.UUID `json:"position_id"`
+			SubjectID  *uuid.UUID `json:"subject_id"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		res, err := s.CorrectAssignment(ctx, tenantID, requestID, initiatorID, CorrectAssignmentInput{
+			AssignmentID: body.ID,
+			Pernr:        body.Pernr,
+			PositionID:   body.PositionID,
+			SubjectID:    body.SubjectID,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.AssignmentID, len(res.GeneratedEvents), nil
+
+	case "assignment.rescind":
+		var body struct {
+			ID            uuid.UUID `json:"id"`
+			EffectiveDate string    `json:"effective_date"`
+			Reason        string    `json:"reason"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.RescindAssignment(ctx, tenantID, requestID, initiatorID, RescindAssignmentInput{
+			AssignmentID:  body.ID,
+			EffectiveDate: effectiveDate,
+			Reason:        body.Reason,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.AssignmentID, len(res.GeneratedEvents), nil
+
+	case "security_group_mapping.create":
+		if !configuration.Use().OrgSecurityGroupMappingsEnabled {
+			return uuid.Nil, 0, notFound
+		}
+		var body struct {
+			OrgNodeID        uuid.UUID `json:"org_node_id"`
+			SecurityGroupKey string    `json:"security_group_key"`
+			AppliesToSubtree bool      `json:"applies_to_subtree"`
+			EffectiveDate    string    `json:"effective_date"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.CreateSecurityGroupMapping(ctx, tenantID, requestID, initiatorID, CreateSecurityGroupMappingInput{
+			OrgNodeID:        body.OrgNodeID,
+			SecurityGroupKey: body.SecurityGroupKey,
+			AppliesToSubtree: body.AppliesToSubtree,
+			EffectiveDate:    effectiveDate,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.ID, 0, nil
+
+	case "security_group_mapping.rescind":
+		if !configuration.Use().OrgSecurityGroupMappingsEnabled {
+			return uuid.Nil, 0, notFound
+		}
+		var body struct {
+			ID            uuid.UUID `json:"id"`
+			EffectiveDate string    `json:"effective_date"`
+			Reason        string    `json:"reason"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.RescindSecurityGroupMapping(ctx, tenantID, requestID, initiatorID, RescindSecurityGroupMappingInput{
+			ID:            body.ID,
+			EffectiveDate: effectiveDate,
+			Reason:        body.Reason,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.ID, 0, nil
+
+	case "link.create":
+		if !configuration.Use().OrgLinksEnabled {
+			return uuid.Nil, 0, notFound
+		}
+		var body struct {
+			OrgNodeID     uuid.UUID      `json:"org_node_id"`
+			ObjectType    string         `json:"object_type"`
+			ObjectKey     string         `json:"object_key"`
+			LinkType      string         `json:"link_type"`
+			Metadata      map[string]any `json:"metadata"`
+			EffectiveDate string         `json:"effective_date"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.CreateOrgLink(ctx, tenantID, requestID, initiatorID, CreateOrgLinkInput{
+			OrgNodeID:     body.OrgNodeID,
+			ObjectType:    body.ObjectType,
+			ObjectKey:     body.ObjectKey,
+			LinkType:      body.LinkType,
+			Metadata:      body.Metadata,
+			EffectiveDate: effectiveDate,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.ID, 0, nil
+
+	case "link.rescind":
+		if !configuration.Use().OrgLinksEnabled {
+			return uuid.Nil, 0, notFound
+		}
+		var body struct {
+			ID            uuid.UUID `json:"id"`
+			EffectiveDate string    `json:"effective_date"`
+			Reason        string    `json:"reason"`
+		}
+		if err := json.Unmarshal(payload, &body); err != nil {
+			return uuid.Nil, 0, invalid("payload is invalid", err)
+		}
+		effectiveDate, err := parseCommandDate("effective_date", body.EffectiveDate)
+		if err != nil {
+			return uuid.Nil, 0, invalid("effective_date is required", err)
+		}
+		res, err := s.RescindOrgLink(ctx, tenantID, requestID, initiatorID, RescindOrgLinkInput{
+			ID:            body.ID,
+			EffectiveDate: effectiveDate,
+			Reason:        body.Reason,
+		})
+		if err != nil {
+			return uuid.Nil, 0, err
+		}
+		return res.ID, 0, nil
+
+	default:
+		return uuid.Nil, 0, invalid("unknown type", nil)
+	}
+}
@@ -4,6 +4,8 @@ import "context"
 
 type skipCacheInvalidationKey struct{}
 type skipOutboxEnqueueKey struct{}
+type skipReadCacheKey struct{}
+type freezeOverrideKey struct{}
 
 func WithSkipCacheInvalidation(ctx context.Context) context.Context {
 	return context.WithValue(ctx, skipCacheInvalidationKey{}, true)
@@ -13,6 +15,24 @@ func WithSkipOutboxEnqueue(ctx context.Context) context.Context {
 	return context.WithValue(ctx, skipOutboxEnqueueKey{}, true)
 }
 
+// WithSkipReadCache makes reads bypass the tenant cache in both directions. Scenario evaluation
+// reads uncommitted state inside a rolled-back transaction, which must neither hit nor populate it.
+func WithSkipReadCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, skipReadCacheKey{}, true)
+}
+
+// WithFreezeOverride lets writes made with ctx through a frozen window. Callers must have checked
+// that the initiator may override the freeze calendar; reason is recorded on each audit entry.
+func WithFreezeOverride(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, freezeOverrideKey{}, reason)
+}
+
+// FreezeOverrideReason returns the reason set by WithFreezeOverride, if any.
+func FreezeOverrideReason(ctx context.Context) (string, bool) {
+	reason, ok := ctx.Value(freezeOverrideKey{}).(string)
+	return reason, ok && reason != ""
+}
+
 func shouldSkipCacheInvalidation(ctx context.Context) bool {
 	v := ctx.Value(skipCacheInvalidationKey{})
 	skip, _ := v.(bool)
@@ -24,3 +44,9 @@ func shouldSkipOutboxEnqueue(ctx context.Context) bool {
 	skip, _ := v.(bool)
 	return skip
 }
+
+func shouldSkipReadCache(ctx context.Context) bool {
+	v := ctx.Value(skipReadCacheKey{})
+	skip, _ := v.(bool)
+	return skip
+}
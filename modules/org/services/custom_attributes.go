@@ -0,0 +1,669 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	CustomAttributeEntityNode     = "org_node"
+	CustomAttributeEntityPosition = "org_position"
+)
+
+const (
+	CustomAttributeTypeText    = "text"
+	CustomAttributeTypeNumber  = "number"
+	CustomAttributeTypeBoolean = "boolean"
+	CustomAttributeTypeDate    = "date"
+	CustomAttributeTypeSelect  = "select"
+)
+
+// CustomAttributePrefix namespaces custom node attributes in inheritance rules, e.g. "custom.region".
+const CustomAttributePrefix = "custom."
+
+var customAttributeCodePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,63}$`)
+
+// AttributeDefinitionRow is one effective-dated slice of a tenant-defined attribute on org nodes or
+// positions. Values are stored per slice under Code; EndDate is the last day in force.
+type AttributeDefinitionRow struct {
+	ID            uuid.UUID
+	EntityType    string
+	Code          string
+	Label         string
+	ValueType     string
+	Options       []string
+	Required      bool
+	Pattern       *string
+	MinValue      *float64
+	MaxValue      *float64
+	MaxLength     *int
+	DisplayOrder  int
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+type AttributeDefinitionInsert struct {
+	EntityType    string
+	Code          string
+	Label         string
+	ValueType     string
+	Options       []string
+	Required      bool
+	Pattern       *string
+	MinValue      *float64
+	MaxValue      *float64
+	MaxLength     *int
+	DisplayOrder  int
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+type AttributeDefinitionListFilter struct {
+	EntityType *string
+	AsOf       *time.Time
+}
+
+type AttributeDefinitionWriteResult struct {
+	ID            uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+func attributeDefinitionAuditValues(row AttributeDefinitionRow) map[string]any {
+	values := map[string]any{
+		"definition_id":  row.ID.String(),
+		"entity_type":    row.EntityType,
+		"code":           row.Code,
+		"label":          row.Label,
+		"value_type":     row.ValueType,
+		"options":        row.Options,
+		"required":       row.Required,
+		"display_order":  row.DisplayOrder,
+		"effective_date": row.EffectiveDate.UTC().Format(time.DateOnly),
+		"end_date":       row.EndDate.UTC().Format(time.DateOnly),
+	}
+	if row.Pattern != nil {
+		values["pattern"] = *row.Pattern
+	}
+	if row.MinValue != nil {
+		values["min_value"] = *row.MinValue
+	}
+	if row.MaxValue != nil {
+		values["max_value"] = *row.MaxValue
+	}
+	if row.MaxLength != nil {
+		values["max_length"] = *row.MaxLength
+	}
+	return values
+}
+
+func isValidCustomAttributeEntityType(v string) bool {
+	return v == CustomAttributeEntityNode || v == CustomAttributeEntityPosition
+}
+
+func isValidCustomAttributeType(v string) bool {
+	switch v {
+	case CustomAttributeTypeText, CustomAttributeTypeNumber, CustomAttributeTypeBoolean, CustomAttributeTypeDate, CustomAttributeTypeSelect:
+		return true
+	default:
+		return false
+	}
+}
+
+type SetAttributeDefinitionInput struct {
+	EntityType    string
+	Code          string
+	Label         string
+	ValueType     string
+	Options       []string
+	Required      bool
+	Pattern       *string
+	MinValue      *float64
+	MaxValue      *float64
+	MaxLength     *int
+	DisplayOrder  int
+	EffectiveDate time.Time
+}
+
+// normalizeAttributeDefinition validates a definition and returns it ready to store.
+func normalizeAttributeDefinition(in SetAttributeDefinitionInput) (AttributeDefinitionRow, error) {
+	out := AttributeDefinitionRow{
+		EntityType:    strings.TrimSpace(in.EntityType),
+		Code:          strings.TrimSpace(strings.ToLower(in.Code)),
+		Label:         strings.TrimSpace(in.Label),
+		ValueType:     strings.TrimSpace(strings.ToLower(in.ValueType)),
+		Required:      in.Required,
+		MinValue:      in.MinValue,
+		MaxValue:      in.MaxValue,
+		MaxLength:     in.MaxLength,
+		DisplayOrder:  in.DisplayOrder,
+		EffectiveDate: in.EffectiveDate,
+	}
+	if !isValidCustomAttributeEntityType(out.EntityType) {
+		return AttributeDefinitionRow{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "entity_type is invalid", nil)
+	}
+	if !customAttributeCodePattern.MatchString(out.Code) {
+		return AttributeDefinitionRow{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "code must be snake_case and at most 64 characters", nil)
+	}
+	if out.Label == "" {
+		out.Label = out.Code
+	}
+	if !isValidCustomAttributeType(out.ValueType) {
+		return AttributeDefinitionRow{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "value_type is invalid", nil)
+	}
+	if in.EffectiveDate.IsZero() {
+		return AttributeDefinitionRow{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "effective_date is required", nil)
+	}
+	out.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+
+	for _, o := range in.Options {
+		o = strings.TrimSpace(o)
+		if o == "" || slices.Contains(out.Options, o) {
+			continue
+		}
+		out.Options = append(out.Options, o)
+	}
+	if out.ValueType == CustomAttributeTypeSelect && len(out.Options) == 0 {
+		return AttributeDefinitionRow{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "options are required for select attributes", nil)
+	}
+	if out.ValueType != CustomAttributeTypeSelect {
+		out.Options = nil
+	}
+
+	if in.Pattern != nil && strings.TrimSpace(*in.Pattern) != "" {
+		pattern := strings.TrimSpace(*in.Pattern)
+		if _, err := regexp.Compile(pattern); err != nil {
+			return AttributeDefinitionRow{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "pattern is invalid", err)
+		}
+		out.Pattern = &pattern
+	}
+	if out.MinValue != nil && out.MaxValue != nil && *out.MinValue > *out.MaxValue {
+		return AttributeDefinitionRow{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "min_value must not exceed max_value", nil)
+	}
+	if out.MaxLength != nil && *out.MaxLength <= 0 {
+		return AttributeDefinitionRow{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "max_length must be positive", nil)
+	}
+	if out.ValueType != CustomAttributeTypeText {
+		out.Pattern = nil
+		out.MaxLength = nil
+	}
+	if out.ValueType != CustomAttributeTypeNumber {
+		out.MinValue = nil
+		out.MaxValue = nil
+	}
+	return out, nil
+}
+
+func attributeDefinitionInsert(row AttributeDefinitionRow) AttributeDefinitionInsert {
+	return AttributeDefinitionInsert{
+		EntityType:    row.EntityType,
+		Code:          row.Code,
+		Label:         row.Label,
+		ValueType:     row.ValueType,
+		Options:       row.Options,
+		Required:      row.Required,
+		Pattern:       row.Pattern,
+		MinValue:      row.MinValue,
+		MaxValue:      row.MaxValue,
+		MaxLength:     row.MaxLength,
+		DisplayOrder:  row.DisplayOrder,
+		EffectiveDate: row.EffectiveDate,
+		EndDate:       row.EndDate,
+	}
+}
+
+// SetAttributeDefinition defines or changes a custom attribute from effective_date on. A slice
+// starting on the same day is corrected in place; one that started earlier is split. Stored
+// values are not rewritten; they are validated against the definition on their next write.
+func (s *OrgService) SetAttributeDefinition(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in SetAttributeDefinitionInput) (*AttributeDefinitionWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	def, err := normalizeAttributeDefinition(in)
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*AttributeDefinitionWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, def.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "attribute_definition.set", "org_attribute_definition", uuid.Nil, def.EffectiveDate, freeze, err, logrus.Fields{
+				"code":      def.Code,
+				"operation": "Update",
+			})
+			return nil, err
+		}
+
+		audit := AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			EntityType:           "org_attribute_definition",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        def.EffectiveDate,
+		}
+		result := def
+
+		current, err := s.repo.LockAttributeDefinitionAt(txCtx, tenantID, def.EntityType, def.Code, def.EffectiveDate)
+		switch {
+		case err == nil && current.EffectiveDate.Equal(def.EffectiveDate):
+			result.ID = current.ID
+			result.EndDate = current.EndDate
+			if err := s.repo.UpdateAttributeDefinition(txCtx, tenantID, current.ID, attributeDefinitionInsert(result)); err != nil {
+				return nil, mapPgError(err)
+			}
+			audit.ChangeType = "attribute_definition.corrected"
+			audit.Operation = "Correct"
+			audit.OldValues = attributeDefinitionAuditValues(current)
+		case err == nil:
+			if err := s.repo.UpdateAttributeDefinitionEndDate(txCtx, tenantID, current.ID, truncateEndDateFromNewEffectiveDate(def.EffectiveDate)); err != nil {
+				return nil, mapPgError(err)
+			}
+			result.EndDate = current.EndDate
+			audit.ChangeType = "attribute_definition.updated"
+			audit.Operation = "Update"
+			audit.OldValues = attributeDefinitionAuditValues(current)
+		case errors.Is(err, pgx.ErrNoRows):
+			result.EndDate = endOfTime
+			next, err := s.repo.NextAttributeDefinitionStart(txCtx, tenantID, def.EntityType, def.Code, def.EffectiveDate)
+			if err != nil {
+				return nil, err
+			}
+			if next != nil {
+				result.EndDate = truncateEndDateFromNewEffectiveDate(*next)
+			}
+			audit.ChangeType = "attribute_definition.created"
+			audit.Operation = "Create"
+		default:
+			return nil, err
+		}
+
+		if result.ID == uuid.Nil {
+			id, err := s.repo.InsertAttributeDefinition(txCtx, tenantID, attributeDefinitionInsert(result))
+			if err != nil {
+				return nil, mapPgError(err)
+			}
+			result.ID = id
+		}
+
+		audit.EntityID = result.ID
+		audit.EffectiveDate = result.EffectiveDate
+		audit.EndDate = result.EndDate
+		audit.NewValues = attributeDefinitionAuditValues(result)
+		if _, err := s.repo.InsertAuditLog(txCtx, tenantID, audit); err != nil {
+			return nil, err
+		}
+		return &AttributeDefinitionWriteResult{ID: result.ID, EffectiveDate: result.EffectiveDate, EndDate: result.EndDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+type EndAttributeDefinitionInput struct {
+	ID uuid.UUID
+	// EffectiveDate is the first day the definition no longer applies.
+	EffectiveDate time.Time
+}
+
+// EndAttributeDefinition retires a custom attribute on effective_date (its last day is the day
+// before). Values stay on existing slices but are no longer accepted on new writes.
+func (s *OrgService) EndAttributeDefinition(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in EndAttributeDefinitionInput) (*AttributeDefinitionWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if in.ID == uuid.Nil || in.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "id/effective_date are required", nil)
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*AttributeDefinitionWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockAttributeDefinitionByID(txCtx, tenantID, in.ID)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		if !in.EffectiveDate.After(current.EffectiveDate) || in.EffectiveDate.After(current.EndDate) {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_INVALID_RESCIND_DATE", "effective_date must be within current window", nil)
+		}
+
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "attribute_definition.ended", "org_attribute_definition", current.ID, in.EffectiveDate, freeze, err, logrus.Fields{
+				"code":      current.Code,
+				"operation": "Update",
+			})
+			return nil, err
+		}
+
+		endDate := truncateEndDateFromNewEffectiveDate(in.EffectiveDate)
+		if err := s.repo.UpdateAttributeDefinitionEndDate(txCtx, tenantID, current.ID, endDate); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		updated := current
+		updated.EndDate = endDate
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "attribute_definition.ended",
+			EntityType:           "org_attribute_definition",
+			EntityID:             current.ID,
+			EffectiveDate:        current.EffectiveDate,
+			EndDate:              endDate,
+			OldValues:            attributeDefinitionAuditValues(current),
+			NewValues:            attributeDefinitionAuditValues(updated),
+			Operation:            "Update",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &AttributeDefinitionWriteResult{ID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: endDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+// DeleteAttributeDefinition removes a definition slice that was recorded in error, as if it never
+// existed. The audit log keeps the removed values.
+func (s *OrgService) DeleteAttributeDefinition(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, id uuid.UUID) (*AttributeDefinitionWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if id == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "id is required", nil)
+	}
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*AttributeDefinitionWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockAttributeDefinitionByID(txCtx, tenantID, id)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, current.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "attribute_definition.rescinded", "org_attribute_definition", current.ID, current.EffectiveDate, freeze, err, logrus.Fields{
+				"code":      current.Code,
+				"operation": "Rescind",
+			})
+			return nil, err
+		}
+
+		if err := s.repo.DeleteAttributeDefinition(txCtx, tenantID, current.ID); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "attribute_definition.rescinded",
+			EntityType:           "org_attribute_definition",
+			EntityID:             current.ID,
+			EffectiveDate:        current.EffectiveDate,
+			EndDate:              current.EndDate,
+			OldValues:            attributeDefinitionAuditValues(current),
+			Operation:            "Rescind",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        current.EffectiveDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &AttributeDefinitionWriteResult{ID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: current.EndDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+func (s *OrgService) ListAttributeDefinitions(ctx context.Context, tenantID uuid.UUID, filter AttributeDefinitionListFilter) ([]AttributeDefinitionRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if filter.EntityType != nil {
+		entityType := strings.TrimSpace(*filter.EntityType)
+		if !isValidCustomAttributeEntityType(entityType) {
+			return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "entity_type is invalid", nil)
+		}
+		filter.EntityType = &entityType
+	}
+	if filter.AsOf != nil {
+		asOf := normalizeValidDateUTC(*filter.AsOf)
+		filter.AsOf = &asOf
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) ([]AttributeDefinitionRow, error) {
+		return s.repo.ListAttributeDefinitions(txCtx, tenantID, filter)
+	})
+}
+
+// prepareCustomAttributes applies patch to current and validates the result against the
+// definitions for entityType in force on asOf. A nil or empty value in patch clears the attribute;
+// carried-over values whose definition is no longer in force are dropped.
+func (s *OrgService) prepareCustomAttributes(txCtx context.Context, tenantID uuid.UUID, entityType string, asOf time.Time, current, patch map[string]any) (map[string]any, error) {
+	defs, err := s.repo.ListAttributeDefinitions(txCtx, tenantID, AttributeDefinitionListFilter{EntityType: &entityType, AsOf: &asOf})
+	if err != nil {
+		return nil, err
+	}
+	return validateCustomAttributes(defs, mergeCustomAttributes(defs, current, patch))
+}
+
+// ensureCustomAttributeDefined rejects an inheritance rule or break for "custom.<code>" when no
+// org_node definition for code is in force on asOf. Built-in attribute names pass through.
+func (s *OrgService) ensureCustomAttributeDefined(txCtx context.Context, tenantID uuid.UUID, attributeName string, asOf time.Time) error {
+	code, ok := strings.CutPrefix(attributeName, CustomAttributePrefix)
+	if !ok {
+		return nil
+	}
+	_, err := s.repo.LockAttributeDefinitionAt(txCtx, tenantID, CustomAttributeEntityNode, code, asOf)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return newServiceError(http.StatusUnprocessableEntity, "ORG_UNKNOWN_ATTRIBUTE", "custom attribute is not defined at effective_date", nil)
+	}
+	return err
+}
+
+func mergeCustomAttributes(defs []AttributeDefinitionRow, current, patch map[string]any) map[string]any {
+	out := make(map[string]any, len(current)+len(patch))
+	for k, v := range current {
+		if slices.ContainsFunc(defs, func(d AttributeDefinitionRow) bool { return d.Code == k }) {
+			out[k] = v
+		}
+	}
+	for k, v := range patch {
+		out[strings.TrimSpace(k)] = v
+	}
+	return out
+}
+
+// validateCustomAttributes checks values against defs and returns them in their stored JSON form:
+// text, date and select as strings, numbers as float64 and booleans as bool. Empty values are
+// dropped; every required definition must end up with a value.
+func validateCustomAttributes(defs []AttributeDefinitionRow, values map[string]any) (map[string]any, error) {
+	byCode := make(map[string]AttributeDefinitionRow, len(defs))
+	for _, d := range defs {
+		byCode[d.Code] = d
+	}
+
+	out := make(map[string]any, len(values))
+	for code, raw := range values {
+		def, ok := byCode[code]
+		if !ok {
+			if isEmptyCustomAttributeValue(raw) {
+				continue
+			}
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_UNKNOWN_CUSTOM_ATTRIBUTE", fmt.Sprintf("custom attribute %s is not defined", code), nil)
+		}
+		if isEmptyCustomAttributeValue(raw) {
+			continue
+		}
+		v, err := normalizeCustomAttributeValue(def, raw)
+		if err != nil {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_INVALID_CUSTOM_ATTRIBUTE", fmt.Sprintf("custom attribute %s: %s", code, err.Error()), nil)
+		}
+		out[code] = v
+	}
+
+	for _, d := range defs {
+		if _, ok := out[d.Code]; d.Required && !ok {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_CUSTOM_ATTRIBUTE_REQUIRED", fmt.Sprintf("custom attribute %s is required", d.Code), nil)
+		}
+	}
+	return out, nil
+}
+
+func isEmptyCustomAttributeValue(v any) bool {
+	if v == nil {
+		return true
+	}
+	s, ok := v.(string)
+	return ok && strings.TrimSpace(s) == ""
+}
+
+func normalizeCustomAttributeValue(def AttributeDefinitionRow, raw any) (any, error) {
+	switch def.ValueType {
+	case CustomAttributeTypeText:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("must be a string")
+		}
+		s = strings.TrimSpace(s)
+		if def.MaxLength != nil && utf8.RuneCountInString(s) > *def.MaxLength {
+			return nil, fmt.Errorf("must be at most %d characters", *def.MaxLength)
+		}
+		if def.Pattern != nil {
+			re, err := regexp.Compile(`^(?:` + *def.Pattern + `)$`)
+			if err != nil || !re.MatchString(s) {
+				return nil, errors.New("does not match the required format")
+			}
+		}
+		return s, nil
+	case CustomAttributeTypeNumber:
+		var f float64
+		switch v := raw.(type) {
+		case float64:
+			f = v
+		case int:
+			f = float64(v)
+		case int64:
+			f = float64(v)
+		case json.Number:
+			parsed, err := v.Float64()
+			if err != nil {
+				return nil, errors.New("must be a number")
+			}
+			f = parsed
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return nil, errors.New("must be a number")
+			}
+			f = parsed
+		default:
+			return nil, errors.New("must be a number")
+		}
+		if def.MinValue != nil && f < *def.MinValue {
+			return nil, fmt.Errorf("must be at least %s", strconv.FormatFloat(*def.MinValue, 'f', -1, 64))
+		}
+		if def.MaxValue != nil && f > *def.MaxValue {
+			return nil, fmt.Errorf("must be at most %s", strconv.FormatFloat(*def.MaxValue, 'f', -1, 64))
+		}
+		return f, nil
+	case CustomAttributeTypeBoolean:
+		switch v := raw.(type) {
+		case bool:
+			return v, nil
+		case string:
+			switch strings.ToLower(strings.TrimSpace(v)) {
+			case "true", "on", "1":
+				return true, nil
+			case "false", "off", "0":
+				return false, nil
+			}
+		}
+		return nil, errors.New("must be true or false")
+	case CustomAttributeTypeDate:
+		s, ok := raw.(string)
+		if !ok {
+			return nil, errors.New("must be a date (YYYY-MM-DD)")
+		}
+		d, err := time.Parse(time.DateOnly, strings.TrimSpace(s))
+		if err != nil {
+			return nil, errors.New("must be a date (YYYY-MM-DD)")
+		}
+		return d.Format(time.DateOnly), nil
+	case CustomAttributeTypeSelect:
+		s, ok := raw.(string)
+		if !ok || !slices.Contains(def.Options, strings.TrimSpace(s)) {
+			return nil, errors.New("must be one of the defined options")
+		}
+		return strings.TrimSpace(s), nil
+	default:
+		return nil, errors.New("has an unsupported type")
+	}
+}
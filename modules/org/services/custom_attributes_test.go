@@ -0,0 +1,157 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAttributeDefinition(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	minValue, maxValue := 10.0, 1.0
+	badPattern := "("
+
+	cases := []struct {
+		name string
+		in   SetAttributeDefinitionInput
+	}{
+		{name: "unknown entity", in: SetAttributeDefinitionInput{EntityType: "org_assignment", Code: "region", ValueType: "text", EffectiveDate: from}},
+		{name: "bad code", in: SetAttributeDefinitionInput{EntityType: CustomAttributeEntityNode, Code: "Region-1", ValueType: "text", EffectiveDate: from}},
+		{name: "bad type", in: SetAttributeDefinitionInput{EntityType: CustomAttributeEntityNode, Code: "region", ValueType: "json", EffectiveDate: from}},
+		{name: "missing date", in: SetAttributeDefinitionInput{EntityType: CustomAttributeEntityNode, Code: "region", ValueType: "text"}},
+		{name: "select without options", in: SetAttributeDefinitionInput{EntityType: CustomAttributeEntityNode, Code: "region", ValueType: "select", Options: []string{" "}, EffectiveDate: from}},
+		{name: "bad pattern", in: SetAttributeDefinitionInput{EntityType: CustomAttributeEntityNode, Code: "region", ValueType: "text", Pattern: &badPattern, EffectiveDate: from}},
+		{name: "min above max", in: SetAttributeDefinitionInput{EntityType: CustomAttributeEntityPosition, Code: "grade", ValueType: "number", MinValue: &minValue, MaxValue: &maxValue, EffectiveDate: from}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := normalizeAttributeDefinition(tc.in)
+			var svcErr *ServiceError
+			require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+			require.Equal(t, "ORG_INVALID_BODY", svcErr.Code)
+		})
+	}
+
+	row, err := normalizeAttributeDefinition(SetAttributeDefinitionInput{
+		EntityType:    CustomAttributeEntityNode,
+		Code:          " Region ",
+		ValueType:     "SELECT",
+		Options:       []string{"north", " south", "north", ""},
+		MinValue:      &minValue,
+		EffectiveDate: from,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "region", row.Code)
+	require.Equal(t, "region", row.Label)
+	require.Equal(t, CustomAttributeTypeSelect, row.ValueType)
+	require.Equal(t, []string{"north", "south"}, row.Options)
+	require.Nil(t, row.MinValue)
+}
+
+func TestValidateCustomAttributes(t *testing.T) {
+	maxLength := 4
+	pattern := "[A-Z]+"
+	minValue := 0.0
+	defs := []AttributeDefinitionRow{
+		{Code: "cost_center", ValueType: CustomAttributeTypeText, Required: true, Pattern: &pattern, MaxLength: &maxLength},
+		{Code: "headcount_cap", ValueType: CustomAttributeTypeNumber, MinValue: &minValue},
+		{Code: "remote", ValueType: CustomAttributeTypeBoolean},
+		{Code: "opened_on", ValueType: CustomAttributeTypeDate},
+		{Code: "region", ValueType: CustomAttributeTypeSelect, Options: []string{"north", "south"}},
+	}
+
+	cases := []struct {
+		name   string
+		values map[string]any
+		code   string
+	}{
+		{name: "missing required", values: map[string]any{"region": "north"}, code: "ORG_CUSTOM_ATTRIBUTE_REQUIRED"},
+		{name: "unknown", values: map[string]any{"cost_center": "AB", "budget": "1"}, code: "ORG_UNKNOWN_CUSTOM_ATTRIBUTE"},
+		{name: "pattern is anchored", values: map[string]any{"cost_center": "AB1"}, code: "ORG_INVALID_CUSTOM_ATTRIBUTE"},
+		{name: "too long", values: map[string]any{"cost_center": "ABCDE"}, code: "ORG_INVALID_CUSTOM_ATTRIBUTE"},
+		{name: "below min", values: map[string]any{"cost_center": "AB", "headcount_cap": -1.0}, code: "ORG_INVALID_CUSTOM_ATTRIBUTE"},
+		{name: "not a boolean", values: map[string]any{"cost_center": "AB", "remote": "maybe"}, code: "ORG_INVALID_CUSTOM_ATTRIBUTE"},
+		{name: "not a date", values: map[string]any{"cost_center": "AB", "opened_on": "01/02/2025"}, code: "ORG_INVALID_CUSTOM_ATTRIBUTE"},
+		{name: "not an option", values: map[string]any{"cost_center": "AB", "region": "east"}, code: "ORG_INVALID_CUSTOM_ATTRIBUTE"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := validateCustomAttributes(defs, tc.values)
+			var svcErr *ServiceError
+			require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+			require.Equal(t, tc.code, svcErr.Code)
+		})
+	}
+
+	out, err := validateCustomAttributes(defs, map[string]any{
+		"cost_center":   " AB ",
+		"headcount_cap": "12.5",
+		"remote":        "on",
+		"opened_on":     "2025-01-02",
+		"region":        "",
+		"budget":        "",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{
+		"cost_center":   "AB",
+		"headcount_cap": 12.5,
+		"remote":        true,
+		"opened_on":     "2025-01-02",
+	}, out)
+}
+
+func TestMergeCustomAttributes(t *testing.T) {
+	defs := []AttributeDefinitionRow{
+		{Code: "region", ValueType: CustomAttributeTypeText},
+		{Code: "remote", ValueType: CustomAttributeTypeBoolean},
+	}
+	current := map[string]any{"region": "north", "remote": true, "retired": "x"}
+
+	merged := mergeCustomAttributes(defs, current, map[string]any{"remote": ""})
+	require.Equal(t, map[string]any{"region": "north", "remote": ""}, merged)
+
+	out, err := validateCustomAttributes(defs, merged)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"region": "north"}, out)
+}
+
+func TestAttributeDefinitionWrites_ValidateBeforeTx(t *testing.T) {
+	svc := &OrgService{}
+	tenantID := uuid.New()
+
+	cases := []struct {
+		name string
+		call func() error
+		code string
+	}{
+		{name: "no tenant", code: "ORG_NO_TENANT", call: func() error {
+			_, err := svc.SetAttributeDefinition(context.Background(), uuid.Nil, "", uuid.New(), SetAttributeDefinitionInput{})
+			return err
+		}},
+		{name: "invalid definition", code: "ORG_INVALID_BODY", call: func() error {
+			_, err := svc.SetAttributeDefinition(context.Background(), tenantID, "", uuid.New(), SetAttributeDefinitionInput{EntityType: CustomAttributeEntityNode, Code: "1x", ValueType: "text"})
+			return err
+		}},
+		{name: "end missing date", code: "ORG_INVALID_BODY", call: func() error {
+			_, err := svc.EndAttributeDefinition(context.Background(), tenantID, "", uuid.New(), EndAttributeDefinitionInput{ID: uuid.New()})
+			return err
+		}},
+		{name: "list invalid entity", code: "ORG_INVALID_QUERY", call: func() error {
+			entityType := "org_edge"
+			_, err := svc.ListAttributeDefinitions(context.Background(), tenantID, AttributeDefinitionListFilter{EntityType: &entityType})
+			return err
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call()
+			var svcErr *ServiceError
+			require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+			require.Equal(t, tc.code, svcErr.Code)
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	DelegationTypeManager = "manager"
+	DelegationTypeRole    = "role"
+)
+
+// DelegationRow is a time-bounded delegation. A manager delegation makes the delegate the
+// acting manager of OrgNodeID; a role delegation makes the delegate a holder of RoleID on
+// OrgNodeID (and, like a role assignment, on its subtree). EndDate is the last day in force.
+type DelegationRow struct {
+	ID                 uuid.UUID
+	DelegationType     string
+	OrgNodeID          uuid.UUID
+	RoleID             *uuid.UUID
+	RoleCode           *string
+	DelegatorSubjectID *uuid.UUID
+	DelegateUserID     int64
+	DelegateSubjectID  uuid.UUID
+	EffectiveDate      time.Time
+	EndDate            time.Time
+	ReasonNote         *string
+}
+
+type DelegationInsert struct {
+	DelegationType     string
+	OrgNodeID          uuid.UUID
+	RoleID             *uuid.UUID
+	DelegatorSubjectID *uuid.UUID
+	DelegateUserID     int64
+	DelegateSubjectID  uuid.UUID
+	EffectiveDate      time.Time
+	EndDate            time.Time
+	ReasonNote         *string
+}
+
+type DelegationListFilter struct {
+	OrgNodeID      *uuid.UUID
+	DelegateUserID *int64
+	AsOf           *time.Time
+	Limit          int
+}
+
+type CreateDelegationInput struct {
+	DelegationType     string
+	OrgNodeID          uuid.UUID
+	RoleID             *uuid.UUID
+	DelegatorSubjectID *uuid.UUID
+	DelegateUserID     int64
+	// DelegateSubjectID is the delegate's authz subject; callers derive it from DelegateUserID.
+	DelegateSubjectID uuid.UUID
+	EffectiveDate     time.Time
+	// EndDate is the last day the delegation is in force; delegations always expire.
+	EndDate    time.Time
+	ReasonNote *string
+}
+
+type DelegationWriteResult struct {
+	DelegationID  uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+func delegationAuditValues(row DelegationRow) map[string]any {
+	values := map[string]any{
+		"delegation_id":       row.ID.String(),
+		"delegation_type":     row.DelegationType,
+		"org_node_id":         row.OrgNodeID.String(),
+		"delegate_user_id":    row.DelegateUserID,
+		"delegate_subject_id": row.DelegateSubjectID.String(),
+		"effective_date":      row.EffectiveDate.UTC().Format(time.DateOnly),
+		"end_date":            row.EndDate.UTC().Format(time.DateOnly),
+		"reason_note":         derefString(row.ReasonNote),
+	}
+	if row.RoleID != nil {
+		values["role_id"] = row.RoleID.String()
+	}
+	if row.RoleCode != nil {
+		values["role_code"] = *row.RoleCode
+	}
+	if row.DelegatorSubjectID != nil {
+		values["delegator_subject_id"] = row.DelegatorSubjectID.String()
+	}
+	return values
+}
+
+// CreateDelegation records that a user acts for a node's manager or for a role holder on the
+// node between effective_date and end_date. Only one acting manager may cover a node at a time.
+func (s *OrgService) CreateDelegation(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in CreateDelegationInput) (*DelegationWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	delegationType := strings.TrimSpace(in.DelegationType)
+	switch delegationType {
+	case DelegationTypeManager:
+		if in.RoleID != nil {
+			return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "role_id is only allowed for role delegations", nil)
+		}
+	case DelegationTypeRole:
+		if in.RoleID == nil || *in.RoleID == uuid.Nil {
+			return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "role_id is required for role delegations", nil)
+		}
+	default:
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "delegation_type must be manager or role", nil)
+	}
+	if in.OrgNodeID == uuid.Nil || in.DelegateUserID <= 0 || in.DelegateSubjectID == uuid.Nil || in.EffectiveDate.IsZero() || in.EndDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "org_node_id/delegate_user_id/effective_date/end_date are required", nil)
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+	in.EndDate = normalizeValidDateUTC(in.EndDate)
+	if in.EndDate.Before(in.EffectiveDate) {
+		return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_INVALID_END_DATE", "end_date must not be before effective_date", nil)
+	}
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*DelegationWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "delegation.created", "org_delegation", uuid.Nil, in.EffectiveDate, freeze, err, logrus.Fields{
+				"org_node_id": in.OrgNodeID.String(),
+				"operation":   "Create",
+			})
+			return nil, err
+		}
+
+		var roleCode *string
+		if in.RoleID != nil {
+			role, err := s.repo.GetRoleByID(txCtx, tenantID, *in.RoleID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_ROLE_NOT_FOUND", "role_id not found", err)
+				}
+				return nil, err
+			}
+			roleCode = &role.Code
+		}
+
+		exists, err := s.repo.NodeExistsAt(txCtx, tenantID, in.OrgNodeID, HierarchyTypeOrgUnit, in.EffectiveDate)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_NODE_NOT_FOUND_AT_DATE", "org_node_id not found at effective_date", nil)
+		}
+
+		reasonNote := trimOptionalText(in.ReasonNote)
+		id, err := s.repo.InsertDelegation(txCtx, tenantID, DelegationInsert{
+			DelegationType:     delegationType,
+			OrgNodeID:          in.OrgNodeID,
+			RoleID:             in.RoleID,
+			DelegatorSubjectID: in.DelegatorSubjectID,
+			DelegateUserID:     in.DelegateUserID,
+			DelegateSubjectID:  in.DelegateSubjectID,
+			EffectiveDate:      in.EffectiveDate,
+			EndDate:            in.EndDate,
+			ReasonNote:         reasonNote,
+		})
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:       requestID,
+			TransactionTime: txTime,
+			InitiatorID:     initiatorID,
+			ChangeType:      "delegation.created",
+			EntityType:      "org_delegation",
+			EntityID:        id,
+			EffectiveDate:   in.EffectiveDate,
+			EndDate:         in.EndDate,
+			NewValues: delegationAuditValues(DelegationRow{
+				ID:                 id,
+				DelegationType:     delegationType,
+				OrgNodeID:          in.OrgNodeID,
+				RoleID:             in.RoleID,
+				RoleCode:           roleCode,
+				DelegatorSubjectID: in.DelegatorSubjectID,
+				DelegateUserID:     in.DelegateUserID,
+				DelegateSubjectID:  in.DelegateSubjectID,
+				EffectiveDate:      in.EffectiveDate,
+				EndDate:            in.EndDate,
+				ReasonNote:         reasonNote,
+			}),
+			Operation:            "Create",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &DelegationWriteResult{DelegationID: id, EffectiveDate: in.EffectiveDate, EndDate: in.EndDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+type EndDelegationInput struct {
+	DelegationID uuid.UUID
+	// EndDate is the new last day in force; it can only shorten the delegation.
+	EndDate time.Time
+}
+
+// EndDelegation cuts a delegation short, e.g. when the manager returns early.
+func (s *OrgService) EndDelegation(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in EndDelegationInput) (*DelegationWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if in.DelegationID == uuid.Nil || in.EndDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "delegation_id/end_date are required", nil)
+	}
+	in.EndDate = normalizeValidDateUTC(in.EndDate)
+	affectedAt := in.EndDate.AddDate(0, 0, 1)
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*DelegationWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockDelegationByID(txCtx, tenantID, in.DelegationID)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		if in.EndDate.Before(current.EffectiveDate) || !in.EndDate.Before(current.EndDate) {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_INVALID_END_DATE", "end_date must be within current window", nil)
+		}
+
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, affectedAt)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "delegation.ended", "org_delegation", current.ID, affectedAt, freeze, err, logrus.Fields{
+				"org_node_id": current.OrgNodeID.String(),
+				"operation":   "Update",
+			})
+			return nil, err
+		}
+
+		if err := s.repo.UpdateDelegationEndDate(txCtx, tenantID, current.ID, in.EndDate); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		updated := current
+		updated.EndDate = in.EndDate
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "delegation.ended",
+			EntityType:           "org_delegation",
+			EntityID:             current.ID,
+			EffectiveDate:        current.EffectiveDate,
+			EndDate:              in.EndDate,
+			OldValues:            delegationAuditValues(current),
+			NewValues:            delegationAuditValues(updated),
+			Operation:            "Update",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        affectedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &DelegationWriteResult{DelegationID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: in.EndDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+func (s *OrgService) ListDelegations(ctx context.Context, tenantID uuid.UUID, filter DelegationListFilter) ([]DelegationRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 200
+	}
+	if filter.Limit > 1000 {
+		filter.Limit = 1000
+	}
+	if filter.AsOf != nil {
+		if filter.AsOf.IsZero() {
+			filter.AsOf = nil
+		} else {
+			asOf := normalizeValidDateUTC(*filter.AsOf)
+			filter.AsOf = &asOf
+		}
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) ([]DelegationRow, error) {
+		return s.repo.ListDelegations(txCtx, tenantID, filter)
+	})
+}
+
+// ActingManagerAsOf returns the manager delegation covering orgNodeID on asOf, or nil. A
+// delegation stops applying the day after its end_date without any further write.
+func (s *OrgService) ActingManagerAsOf(ctx context.Context, tenantID uuid.UUID, orgNodeID uuid.UUID, asOf time.Time) (*DelegationRow, error) {
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	rows, err := s.ListDelegations(ctx, tenantID, DelegationListFilter{OrgNodeID: &orgNodeID, AsOf: &asOf})
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		if rows[i].DelegationType == DelegationTypeManager {
+			return &rows[i], nil
+		}
+	}
+	return nil, nil
+}
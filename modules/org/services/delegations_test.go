@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDelegation_ValidatesBeforeTx(t *testing.T) {
+	svc := &OrgService{}
+	tenantID := uuid.New()
+	roleID := uuid.New()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	base := CreateDelegationInput{
+		DelegationType:    DelegationTypeManager,
+		OrgNodeID:         uuid.New(),
+		DelegateUserID:    7,
+		DelegateSubjectID: uuid.New(),
+		EffectiveDate:     from,
+		EndDate:           from.AddDate(0, 0, 13),
+	}
+
+	cases := []struct {
+		name string
+		edit func(in *CreateDelegationInput)
+		code string
+	}{
+		{name: "unknown type", edit: func(in *CreateDelegationInput) { in.DelegationType = "proxy" }, code: "ORG_INVALID_BODY"},
+		{name: "manager with role", edit: func(in *CreateDelegationInput) { in.RoleID = &roleID }, code: "ORG_INVALID_BODY"},
+		{name: "role without role", edit: func(in *CreateDelegationInput) { in.DelegationType = DelegationTypeRole }, code: "ORG_INVALID_BODY"},
+		{name: "open ended", edit: func(in *CreateDelegationInput) { in.EndDate = time.Time{} }, code: "ORG_INVALID_BODY"},
+		{name: "missing delegate", edit: func(in *CreateDelegationInput) { in.DelegateUserID = 0 }, code: "ORG_INVALID_BODY"},
+		{name: "ends before start", edit: func(in *CreateDelegationInput) { in.EndDate = from.AddDate(0, 0, -1) }, code: "ORG_INVALID_END_DATE"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			in := base
+			tc.edit(&in)
+			_, err := svc.CreateDelegation(context.Background(), tenantID, "", uuid.New(), in)
+			var svcErr *ServiceError
+			require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+			require.Equal(t, tc.code, svcErr.Code)
+		})
+	}
+}
+
+func TestPermissionPreviewDelegations_ScopesManagerToNode(t *testing.T) {
+	root, node := uuid.New(), uuid.New()
+	code := "hrbp"
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	rows := []DelegationRow{
+		{ID: uuid.New(), DelegationType: DelegationTypeManager, OrgNodeID: root, DelegateUserID: 1, EffectiveDate: from, EndDate: from},
+		{ID: uuid.New(), DelegationType: DelegationTypeRole, OrgNodeID: root, RoleCode: &code, DelegateUserID: 2, EffectiveDate: from, EndDate: from},
+		{ID: uuid.New(), DelegationType: DelegationTypeManager, OrgNodeID: node, DelegateUserID: 3, EffectiveDate: from, EndDate: from},
+	}
+
+	got := permissionPreviewDelegations(rows, node, map[uuid.UUID]int{node: 0, root: 1})
+	require.Len(t, got, 2)
+	require.Equal(t, int64(3), got[0].DelegateUserID)
+	require.Equal(t, 0, got[0].Source.Depth)
+	require.Equal(t, int64(2), got[1].DelegateUserID)
+	require.Equal(t, root, got[1].Source.OrgNodeID)
+	require.Equal(t, "2025-03-01", got[1].EndDate)
+}
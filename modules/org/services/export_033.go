@@ -8,6 +8,8 @@ type OrgNodeAsOfRow struct {
 	Code     string
 	Name     string
 	Status   string
+	// CustomAttributes holds the slice's tenant-defined attribute values.
+	CustomAttributes map[string]any
 }
 
 type OrgLinkSummary struct {
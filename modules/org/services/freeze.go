@@ -1,9 +1,12 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 type FreezeCheckResult struct {
@@ -12,6 +15,31 @@ type FreezeCheckResult struct {
 	CutoffUTC     time.Time
 	Violation     bool
 	TransactionAt time.Time
+	// Period is the freeze calendar period that decided the check; nil when the tenant has no
+	// calendar and the rolling grace-day cutoff applies.
+	Period *FreezePeriod
+	// Overridden is set when an enforce-mode violation was let through by WithFreezeOverride;
+	// OverrideReason is the reason given, for the caller to record in the audit log.
+	Overridden     bool
+	OverrideReason string
+}
+
+// FreezePeriod is a payroll close period. PeriodEnd is the last day of the period (inclusive);
+// writes affecting a day inside the period are frozen from LocksAt on.
+type FreezePeriod struct {
+	ID          uuid.UUID
+	Label       string
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	LocksAt     time.Time
+}
+
+func (p FreezePeriod) lockedAt(txTime time.Time) bool {
+	return !txTime.Before(p.LocksAt)
+}
+
+func (p FreezePeriod) contains(t time.Time) bool {
+	return !t.Before(p.PeriodStart) && t.Before(p.PeriodEnd.AddDate(0, 0, 1))
 }
 
 func computeFreezeCutoffUTC(txTime time.Time, graceDays int) time.Time {
@@ -25,7 +53,62 @@ func computeFreezeCutoffUTC(txTime time.Time, graceDays int) time.Time {
 	return monthStart
 }
 
-func (s *OrgService) freezeCheck(settings OrgSettings, txTime time.Time, affectedAt time.Time) (FreezeCheckResult, error) {
+// checkFreezeCalendar decides a write against the tenant's freeze calendar. A day inside a period
+// is frozen once that period locks; a day outside every period is frozen when it falls before the
+// end of the latest locked period. It returns the cutoff (the day after the latest locked period,
+// zero when none has locked), whether affectedAt is frozen and the period responsible.
+func checkFreezeCalendar(periods []FreezePeriod, txTime time.Time, affectedAt time.Time) (time.Time, bool, *FreezePeriod) {
+	var latest *FreezePeriod
+	for i := range periods {
+		p := &periods[i]
+		if p.lockedAt(txTime) && (latest == nil || p.PeriodEnd.After(latest.PeriodEnd)) {
+			latest = p
+		}
+	}
+	var cutoff time.Time
+	if latest != nil {
+		cutoff = latest.PeriodEnd.AddDate(0, 0, 1)
+	}
+
+	for i := range periods {
+		p := periods[i]
+		if p.contains(affectedAt) {
+			return cutoff, p.lockedAt(txTime), &p
+		}
+	}
+	if latest != nil && affectedAt.Before(cutoff) {
+		p := *latest
+		return cutoff, true, &p
+	}
+	return cutoff, false, nil
+}
+
+func frozenWindowError(affectedAt time.Time, cutoff time.Time, period *FreezePeriod) *ServiceError {
+	if period == nil {
+		return newServiceError(http.StatusConflict, "ORG_FROZEN_WINDOW", fmt.Sprintf("affected_at=%s is before cutoff=%s", affectedAt.Format(time.RFC3339), cutoff.Format(time.RFC3339)), nil)
+	}
+	err := newServiceError(http.StatusConflict, "ORG_FROZEN_WINDOW", fmt.Sprintf(
+		"affected_at=%s is frozen by period %q (%s..%s, locked at %s)",
+		affectedAt.Format(time.RFC3339),
+		period.Label,
+		period.PeriodStart.Format(time.DateOnly),
+		period.PeriodEnd.Format(time.DateOnly),
+		period.LocksAt.UTC().Format(time.RFC3339),
+	), nil)
+	err.Meta = map[string]string{
+		"freeze_period_id":    period.ID.String(),
+		"freeze_period_label": period.Label,
+		"freeze_period_start": period.PeriodStart.Format(time.DateOnly),
+		"freeze_period_end":   period.PeriodEnd.Format(time.DateOnly),
+		"freeze_locks_at":     period.LocksAt.UTC().Format(time.RFC3339),
+	}
+	return err
+}
+
+// freezeCheck decides whether a write affecting affectedAt may go ahead at txTime. Unless freezing
+// is disabled it loads the tenant's freeze calendar, which replaces the rolling grace-day cutoff
+// when the tenant has any periods.
+func (s *OrgService) freezeCheck(ctx context.Context, tenantID uuid.UUID, settings OrgSettings, txTime time.Time, affectedAt time.Time) (FreezeCheckResult, error) {
 	txTime = txTime.UTC()
 	affectedAt = affectedAt.UTC()
 
@@ -38,20 +121,35 @@ func (s *OrgService) freezeCheck(settings OrgSettings, txTime time.Time, affecte
 		graceDays = 3
 	}
 
-	cutoff := computeFreezeCutoffUTC(txTime, graceDays)
-	violation := affectedAt.Before(cutoff)
+	periods, err := s.repo.ListFreezePeriods(ctx, tenantID)
+	if err != nil {
+		return FreezeCheckResult{}, err
+	}
+
+	var cutoff time.Time
+	var violation bool
+	var period *FreezePeriod
+	if len(periods) > 0 {
+		cutoff, violation, period = checkFreezeCalendar(periods, txTime, affectedAt)
+	} else {
+		cutoff = computeFreezeCutoffUTC(txTime, graceDays)
+		violation = affectedAt.Before(cutoff)
+	}
+	result := FreezeCheckResult{Mode: mode, GraceDays: graceDays, CutoffUTC: cutoff, Violation: violation, TransactionAt: txTime, Period: period}
 
 	switch mode {
-	case "disabled":
-		return FreezeCheckResult{Mode: mode, GraceDays: graceDays, CutoffUTC: cutoff, Violation: violation, TransactionAt: txTime}, nil
-	case "shadow":
-		return FreezeCheckResult{Mode: mode, GraceDays: graceDays, CutoffUTC: cutoff, Violation: violation, TransactionAt: txTime}, nil
+	case "disabled", "shadow":
+		return result, nil
 	case "enforce":
-		if violation {
-			return FreezeCheckResult{Mode: mode, GraceDays: graceDays, CutoffUTC: cutoff, Violation: true, TransactionAt: txTime},
-				newServiceError(http.StatusConflict, "ORG_FROZEN_WINDOW", fmt.Sprintf("affected_at=%s is before cutoff=%s", affectedAt.Format(time.RFC3339), cutoff.Format(time.RFC3339)), nil)
+		if !violation {
+			return result, nil
+		}
+		if reason, ok := FreezeOverrideReason(ctx); ok {
+			result.Overridden = true
+			result.OverrideReason = reason
+			return result, nil
 		}
-		return FreezeCheckResult{Mode: mode, GraceDays: graceDays, CutoffUTC: cutoff, Violation: false, TransactionAt: txTime}, nil
+		return result, frozenWindowError(affectedAt, cutoff, period)
 	default:
 		return FreezeCheckResult{}, fmt.Errorf("invalid freeze mode: %s", mode)
 	}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type FreezePeriodInput struct {
+	Label       string
+	PeriodStart time.Time
+	// PeriodEnd is the last day of the period.
+	PeriodEnd time.Time
+	LocksAt   time.Time
+}
+
+func freezePeriodAuditValues(p FreezePeriod) map[string]any {
+	return map[string]any{
+		"freeze_period_id": p.ID.String(),
+		"label":            p.Label,
+		"period_start":     p.PeriodStart.UTC().Format(time.DateOnly),
+		"period_end":       p.PeriodEnd.UTC().Format(time.DateOnly),
+		"locks_at":         p.LocksAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// normalizeFreezePeriod validates a calendar entry and returns it ready to store.
+func normalizeFreezePeriod(in FreezePeriodInput) (FreezePeriod, error) {
+	out := FreezePeriod{
+		Label:   strings.TrimSpace(in.Label),
+		LocksAt: in.LocksAt.UTC(),
+	}
+	if out.Label == "" {
+		return FreezePeriod{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "label is required", nil)
+	}
+	if in.PeriodStart.IsZero() || in.PeriodEnd.IsZero() || in.LocksAt.IsZero() {
+		return FreezePeriod{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "period_start/period_end/locks_at are required", nil)
+	}
+	out.PeriodStart = normalizeValidDateUTC(in.PeriodStart)
+	out.PeriodEnd = normalizeValidDateUTC(in.PeriodEnd)
+	if out.PeriodEnd.Before(out.PeriodStart) {
+		return FreezePeriod{}, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "period_end must not be before period_start", nil)
+	}
+	return out, nil
+}
+
+func (s *OrgService) ListFreezePeriods(ctx context.Context, tenantID uuid.UUID) ([]FreezePeriod, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) ([]FreezePeriod, error) {
+		return s.repo.ListFreezePeriods(txCtx, tenantID)
+	})
+}
+
+// CreateFreezePeriod adds a close period to the tenant's freeze calendar. The first period
+// switches the tenant from the rolling freeze_grace_days cutoff to the calendar.
+func (s *OrgService) CreateFreezePeriod(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in FreezePeriodInput) (*FreezePeriod, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	period, err := normalizeFreezePeriod(in)
+	if err != nil {
+		return nil, err
+	}
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*FreezePeriod, error) {
+		id, err := s.repo.InsertFreezePeriod(txCtx, tenantID, period)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		period.ID = id
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:       requestID,
+			TransactionTime: txTime,
+			InitiatorID:     initiatorID,
+			ChangeType:      "freeze_period.created",
+			EntityType:      "org_freeze_period",
+			EntityID:        period.ID,
+			EffectiveDate:   period.PeriodStart,
+			EndDate:         period.PeriodEnd,
+			NewValues:       freezePeriodAuditValues(period),
+			Operation:       "Create",
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &period, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+// lockedFreezePeriodCheck guards edits to the calendar itself: once a period has locked, changing
+// or removing it reopens a closed window and needs the same override as any write into it.
+func (s *OrgService) lockedFreezePeriodCheck(txCtx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, changeType string, current FreezePeriod, txTime time.Time) (FreezeCheckResult, error) {
+	settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+	if err != nil {
+		return FreezeCheckResult{}, err
+	}
+	freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, current.PeriodStart)
+	if err != nil {
+		maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, changeType, "org_freeze_period", current.ID, current.PeriodStart, freeze, err, logrus.Fields{
+			"label": current.Label,
+		})
+		return freeze, err
+	}
+	return freeze, nil
+}
+
+// UpdateFreezePeriod replaces a calendar entry. Changing a period that has already locked needs a
+// freeze override (see WithFreezeOverride).
+func (s *OrgService) UpdateFreezePeriod(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, id uuid.UUID, in FreezePeriodInput) (*FreezePeriod, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if id == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "id is required", nil)
+	}
+	period, err := normalizeFreezePeriod(in)
+	if err != nil {
+		return nil, err
+	}
+	period.ID = id
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*FreezePeriod, error) {
+		current, err := s.repo.LockFreezePeriodByID(txCtx, tenantID, id)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		freeze, err := s.lockedFreezePeriodCheck(txCtx, tenantID, requestID, initiatorID, "freeze_period.updated", current, txTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.repo.UpdateFreezePeriod(txCtx, tenantID, period); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "freeze_period.updated",
+			EntityType:           "org_freeze_period",
+			EntityID:             period.ID,
+			EffectiveDate:        period.PeriodStart,
+			EndDate:              period.PeriodEnd,
+			OldValues:            freezePeriodAuditValues(current),
+			NewValues:            freezePeriodAuditValues(period),
+			Operation:            "Update",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        current.PeriodStart,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &period, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+// DeleteFreezePeriod removes a calendar entry. Removing a period that has already locked needs a
+// freeze override (see WithFreezeOverride).
+func (s *OrgService) DeleteFreezePeriod(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, id uuid.UUID) (*FreezePeriod, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if id == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "id is required", nil)
+	}
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*FreezePeriod, error) {
+		current, err := s.repo.LockFreezePeriodByID(txCtx, tenantID, id)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		freeze, err := s.lockedFreezePeriodCheck(txCtx, tenantID, requestID, initiatorID, "freeze_period.rescinded", current, txTime)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := s.repo.DeleteFreezePeriod(txCtx, tenantID, current.ID); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "freeze_period.rescinded",
+			EntityType:           "org_freeze_period",
+			EntityID:             current.ID,
+			EffectiveDate:        current.PeriodStart,
+			EndDate:              current.PeriodEnd,
+			OldValues:            freezePeriodAuditValues(current),
+			Operation:            "Rescind",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        current.PeriodStart,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &current, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
@@ -20,6 +20,6 @@ func (s *OrgService) GetFreezeInfo(ctx context.Context, tenantID uuid.UUID, txTi
 		if err != nil {
 			return FreezeCheckResult{}, err
 		}
-		return s.freezeCheck(settings, txTime, txTime)
+		return s.freezeCheck(txCtx, tenantID, settings, txTime, txTime)
 	})
 }
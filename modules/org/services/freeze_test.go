@@ -1,12 +1,24 @@
 package services
 
 import (
+	"context"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
 
+// freezePeriodsRepo stubs the calendar lookup freezeCheck performs; any other repository call panics.
+type freezePeriodsRepo struct {
+	OrgRepository
+	periods []FreezePeriod
+}
+
+func (r freezePeriodsRepo) ListFreezePeriods(context.Context, uuid.UUID) ([]FreezePeriod, error) {
+	return r.periods, nil
+}
+
 func TestComputeFreezeCutoffUTC_WithinGrace_AllowsPreviousMonth(t *testing.T) {
 	txTime := time.Date(2025, 12, 2, 12, 0, 0, 0, time.UTC)
 	cutoff := computeFreezeCutoffUTC(txTime, 3)
@@ -20,14 +32,129 @@ func TestComputeFreezeCutoffUTC_AfterGrace_AllowsCurrentMonthOnly(t *testing.T)
 }
 
 func TestFreezeCheck_EnforceRejectsBeforeCutoff(t *testing.T) {
-	svc := &OrgService{}
+	svc := &OrgService{repo: freezePeriodsRepo{}}
 	settings := OrgSettings{FreezeMode: "enforce", FreezeGraceDays: 3}
 	txTime := time.Date(2025, 12, 10, 12, 0, 0, 0, time.UTC)
 	affectedAt := time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC)
 
-	_, err := svc.freezeCheck(settings, txTime, affectedAt)
+	_, err := svc.freezeCheck(context.Background(), uuid.New(), settings, txTime, affectedAt)
 	var svcErr *ServiceError
 	require.ErrorAs(t, err, &svcErr)
 	require.Equal(t, 409, svcErr.Status)
 	require.Equal(t, "ORG_FROZEN_WINDOW", svcErr.Code)
 }
+
+func TestFreezeCheck_Calendar(t *testing.T) {
+	jan := FreezePeriod{
+		ID:          uuid.New(),
+		Label:       "2025-01",
+		PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		LocksAt:     time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+	}
+	feb := FreezePeriod{
+		ID:          uuid.New(),
+		Label:       "2025-02",
+		PeriodStart: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC),
+		LocksAt:     time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC),
+	}
+	svc := &OrgService{repo: freezePeriodsRepo{periods: []FreezePeriod{jan, feb}}}
+	settings := OrgSettings{FreezeMode: "enforce", FreezeGraceDays: 3}
+
+	cases := []struct {
+		name       string
+		txTime     time.Time
+		affectedAt time.Time
+		wantPeriod *FreezePeriod
+		wantErr    bool
+	}{
+		{
+			name:       "period still open before locks_at",
+			txTime:     time.Date(2025, 2, 4, 23, 0, 0, 0, time.UTC),
+			affectedAt: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			wantPeriod: &jan,
+		},
+		{
+			name:       "locked period rejects",
+			txTime:     time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+			affectedAt: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			wantPeriod: &jan,
+			wantErr:    true,
+		},
+		{
+			name:       "open later period ignores rolling grace",
+			txTime:     time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC),
+			affectedAt: time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC),
+			wantPeriod: &feb,
+		},
+		{
+			name:       "day before the latest locked period is frozen by it",
+			txTime:     time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC),
+			affectedAt: time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC),
+			wantPeriod: &jan,
+			wantErr:    true,
+		},
+		{
+			name:       "day after every period is open",
+			txTime:     time.Date(2025, 4, 10, 0, 0, 0, 0, time.UTC),
+			affectedAt: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := svc.freezeCheck(context.Background(), uuid.New(), settings, tc.txTime, tc.affectedAt)
+			require.Equal(t, tc.wantErr, res.Violation)
+			if tc.wantPeriod == nil {
+				require.Nil(t, res.Period)
+			} else {
+				require.NotNil(t, res.Period)
+				require.Equal(t, tc.wantPeriod.ID, res.Period.ID)
+			}
+			if !tc.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			var svcErr *ServiceError
+			require.ErrorAs(t, err, &svcErr)
+			require.Equal(t, "ORG_FROZEN_WINDOW", svcErr.Code)
+			require.Contains(t, svcErr.Message, tc.wantPeriod.Label)
+			require.Equal(t, tc.wantPeriod.ID.String(), svcErr.Meta["freeze_period_id"])
+			require.Equal(t, "2025-01-31", svcErr.Meta["freeze_period_end"])
+		})
+	}
+}
+
+func TestFreezeCheck_CalendarOverride(t *testing.T) {
+	svc := &OrgService{repo: freezePeriodsRepo{periods: []FreezePeriod{{
+		ID:          uuid.New(),
+		Label:       "2025-01",
+		PeriodStart: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		LocksAt:     time.Date(2025, 2, 5, 0, 0, 0, 0, time.UTC),
+	}}}}
+	settings := OrgSettings{FreezeMode: "enforce"}
+	tenantID := uuid.New()
+	txTime := time.Date(2025, 2, 10, 0, 0, 0, 0, time.UTC)
+	affectedAt := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	ctx := WithFreezeOverride(context.Background(), "late payroll correction")
+	res, err := svc.freezeCheck(ctx, tenantID, settings, txTime, affectedAt)
+	require.NoError(t, err)
+	require.True(t, res.Violation)
+	require.True(t, res.Overridden)
+	require.Equal(t, "late payroll correction", res.OverrideReason)
+
+	_, err = svc.freezeCheck(WithFreezeOverride(context.Background(), ""), tenantID, settings, txTime, affectedAt)
+	require.Error(t, err)
+}
+
+func TestAuditLogInsert_MarshalMetaFreezeOverrideReason(t *testing.T) {
+	meta, err := AuditLogInsert{
+		FreezeMode:           "enforce",
+		FreezeViolation:      true,
+		FreezeOverrideReason: "late payroll correction",
+	}.MarshalMeta()
+	require.NoError(t, err)
+	require.Contains(t, meta, `"freeze_override_reason":"late payroll correction"`)
+}
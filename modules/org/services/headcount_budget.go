@@ -0,0 +1,565 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// headcountBudgetFTEEpsilon absorbs numeric(9,2) -> float8 rounding when comparing FTE sums.
+const headcountBudgetFTEEpsilon = 1e-6
+
+// HeadcountBudgetRow is one budget line: planned headcount/FTE/cost at an org node, optionally
+// narrowed to a job profile and/or job level.
+type HeadcountBudgetRow struct {
+	ID               uuid.UUID
+	OrgNodeID        uuid.UUID
+	JobProfileID     *uuid.UUID
+	JobLevelCode     *string
+	PlannedHeadcount int
+	PlannedFTE       float64
+	PlannedCost      *float64
+	EffectiveDate    time.Time
+	EndDate          time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// NodeWide reports whether the line covers every position at its node.
+func (b HeadcountBudgetRow) NodeWide() bool {
+	return b.JobProfileID == nil && b.JobLevelCode == nil
+}
+
+func (b HeadcountBudgetRow) matches(jobProfileID uuid.UUID, jobLevelCode *string) bool {
+	if b.JobProfileID != nil && *b.JobProfileID != jobProfileID {
+		return false
+	}
+	if b.JobLevelCode != nil && (jobLevelCode == nil || *jobLevelCode != *b.JobLevelCode) {
+		return false
+	}
+	return true
+}
+
+type HeadcountBudgetInsert struct {
+	OrgNodeID        uuid.UUID
+	JobProfileID     *uuid.UUID
+	JobLevelCode     *string
+	PlannedHeadcount int
+	PlannedFTE       float64
+	PlannedCost      *float64
+	EffectiveDate    time.Time
+	EndDate          time.Time
+}
+
+type HeadcountBudgetValues struct {
+	PlannedHeadcount int
+	PlannedFTE       float64
+	PlannedCost      *float64
+}
+
+type HeadcountBudgetListFilter struct {
+	ID        *uuid.UUID
+	OrgNodeID *uuid.UUID
+	AsOf      *time.Time
+	Limit     int
+}
+
+// HeadcountBudgetActualRow is the direct staffing at one node for one job profile/level
+// combination, using the same position/occupancy rules as the staffing summary.
+type HeadcountBudgetActualRow struct {
+	OrgNodeID    uuid.UUID
+	JobProfileID uuid.UUID
+	JobLevelCode *string
+	Positions    int
+	Filled       int
+	CapacityFTE  float64
+	OccupiedFTE  float64
+	AvailableFTE float64
+}
+
+type SetHeadcountBudgetInput struct {
+	OrgNodeID        uuid.UUID
+	JobProfileID     *uuid.UUID
+	JobLevelCode     *string
+	PlannedHeadcount int
+	PlannedFTE       float64
+	PlannedCost      *float64
+	EffectiveDate    time.Time
+}
+
+type SetHeadcountBudgetResult struct {
+	ID            uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+// SetHeadcountBudget plans headcount/FTE/cost for a budget line from effective_date on. A line
+// starting on the same day is corrected in place; one that started earlier is split, so the
+// approved history before effective_date is preserved.
+func (s *OrgService) SetHeadcountBudget(ctx context.Context, tenantID uuid.UUID, in SetHeadcountBudgetInput) (*SetHeadcountBudgetResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	txTime := time.Now().UTC()
+
+	if in.OrgNodeID == uuid.Nil || in.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "org_node_id/effective_date are required", nil)
+	}
+	if in.JobProfileID != nil && *in.JobProfileID == uuid.Nil {
+		in.JobProfileID = nil
+	}
+	in.JobLevelCode = trimOptionalText(in.JobLevelCode)
+	if in.PlannedHeadcount < 0 || in.PlannedFTE < 0 {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "planned_headcount/planned_fte must be >= 0", nil)
+	}
+	if in.PlannedCost != nil && *in.PlannedCost < 0 {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "planned_cost must be >= 0", nil)
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+	values := HeadcountBudgetValues{
+		PlannedHeadcount: in.PlannedHeadcount,
+		PlannedFTE:       in.PlannedFTE,
+		PlannedCost:      in.PlannedCost,
+	}
+
+	return inTx(ctx, tenantID, func(txCtx context.Context) (*SetHeadcountBudgetResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate); err != nil {
+			return nil, err
+		}
+
+		exists, err := s.repo.NodeExistsAt(txCtx, tenantID, in.OrgNodeID, HierarchyTypeOrgUnit, in.EffectiveDate)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_NODE_NOT_FOUND_AT_DATE", "org_node_id not found at effective_date", nil)
+		}
+
+		current, err := s.repo.LockHeadcountBudgetAt(txCtx, tenantID, in.OrgNodeID, in.JobProfileID, in.JobLevelCode, in.EffectiveDate)
+		switch {
+		case err == nil:
+			if current.EffectiveDate.Equal(in.EffectiveDate) {
+				if err := s.repo.UpdateHeadcountBudgetValues(txCtx, tenantID, current.ID, values); err != nil {
+					return nil, mapPgError(err)
+				}
+				return &SetHeadcountBudgetResult{ID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: current.EndDate}, nil
+			}
+			if err := s.repo.UpdateHeadcountBudgetEndDate(txCtx, tenantID, current.ID, truncateEndDateFromNewEffectiveDate(in.EffectiveDate)); err != nil {
+				return nil, mapPgError(err)
+			}
+			id, err := s.repo.InsertHeadcountBudget(txCtx, tenantID, headcountBudgetInsertOf(in, current.EndDate))
+			if err != nil {
+				return nil, mapPgError(err)
+			}
+			return &SetHeadcountBudgetResult{ID: id, EffectiveDate: in.EffectiveDate, EndDate: current.EndDate}, nil
+		case errors.Is(err, pgx.ErrNoRows):
+		default:
+			return nil, err
+		}
+
+		endDate := endOfTime
+		next, err := s.repo.NextHeadcountBudgetStart(txCtx, tenantID, in.OrgNodeID, in.JobProfileID, in.JobLevelCode, in.EffectiveDate)
+		if err != nil {
+			return nil, err
+		}
+		if next != nil {
+			endDate = truncateEndDateFromNewEffectiveDate(*next)
+		}
+		id, err := s.repo.InsertHeadcountBudget(txCtx, tenantID, headcountBudgetInsertOf(in, endDate))
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		return &SetHeadcountBudgetResult{ID: id, EffectiveDate: in.EffectiveDate, EndDate: endDate}, nil
+	})
+}
+
+func headcountBudgetInsertOf(in SetHeadcountBudgetInput, endDate time.Time) HeadcountBudgetInsert {
+	return HeadcountBudgetInsert{
+		OrgNodeID:        in.OrgNodeID,
+		JobProfileID:     in.JobProfileID,
+		JobLevelCode:     in.JobLevelCode,
+		PlannedHeadcount: in.PlannedHeadcount,
+		PlannedFTE:       in.PlannedFTE,
+		PlannedCost:      in.PlannedCost,
+		EffectiveDate:    in.EffectiveDate,
+		EndDate:          endDate,
+	}
+}
+
+type EndHeadcountBudgetInput struct {
+	ID            uuid.UUID
+	EffectiveDate time.Time
+}
+
+// EndHeadcountBudget closes a budget line on effective_date (the line's last day is the day
+// before).
+func (s *OrgService) EndHeadcountBudget(ctx context.Context, tenantID uuid.UUID, in EndHeadcountBudgetInput) (*SetHeadcountBudgetResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	txTime := time.Now().UTC()
+	if in.ID == uuid.Nil || in.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "id/effective_date are required", nil)
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+
+	return inTx(ctx, tenantID, func(txCtx context.Context) (*SetHeadcountBudgetResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate); err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockHeadcountBudgetByID(txCtx, tenantID, in.ID)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		if !in.EffectiveDate.After(current.EffectiveDate) || in.EffectiveDate.After(current.EndDate) {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_INVALID_RESCIND_DATE", "effective_date must be within current window", nil)
+		}
+		endDate := truncateEndDateFromNewEffectiveDate(in.EffectiveDate)
+		if err := s.repo.UpdateHeadcountBudgetEndDate(txCtx, tenantID, current.ID, endDate); err != nil {
+			return nil, mapPgError(err)
+		}
+		return &SetHeadcountBudgetResult{ID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: endDate}, nil
+	})
+}
+
+func (s *OrgService) ListHeadcountBudgets(ctx context.Context, tenantID uuid.UUID, filter HeadcountBudgetListFilter) ([]HeadcountBudgetRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 200
+	}
+	if filter.Limit > 1000 {
+		filter.Limit = 1000
+	}
+	if filter.AsOf != nil && !filter.AsOf.IsZero() {
+		asOf := normalizeValidDateUTC(*filter.AsOf)
+		filter.AsOf = &asOf
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) ([]HeadcountBudgetRow, error) {
+		return s.repo.ListHeadcountBudgets(txCtx, tenantID, filter)
+	})
+}
+
+// HeadcountBudgetFigures compares planned numbers with actual staffing. Variances are planned
+// minus actual, so a negative variance means the plan is exceeded.
+type HeadcountBudgetFigures struct {
+	Budgeted          bool
+	PlannedHeadcount  int
+	PlannedFTE        float64
+	PlannedCost       *float64
+	PositionsTotal    int
+	FilledPositions   int
+	VacantPositions   int
+	CapacityFTE       float64
+	OccupiedFTE       float64
+	AvailableFTE      float64
+	HeadcountVariance int
+	FTEVariance       float64
+	OverBudget        bool
+}
+
+func (f *HeadcountBudgetFigures) addPlan(b HeadcountBudgetRow) {
+	f.Budgeted = true
+	f.PlannedHeadcount += b.PlannedHeadcount
+	f.PlannedFTE += b.PlannedFTE
+	if b.PlannedCost != nil {
+		cost := *b.PlannedCost
+		if f.PlannedCost != nil {
+			cost += *f.PlannedCost
+		}
+		f.PlannedCost = &cost
+	}
+}
+
+func (f *HeadcountBudgetFigures) addActual(a HeadcountBudgetActualRow) {
+	f.PositionsTotal += a.Positions
+	f.FilledPositions += a.Filled
+	f.CapacityFTE += a.CapacityFTE
+	f.OccupiedFTE += a.OccupiedFTE
+	f.AvailableFTE += a.AvailableFTE
+}
+
+func (f *HeadcountBudgetFigures) add(o HeadcountBudgetFigures) {
+	if o.Budgeted {
+		f.Budgeted = true
+		f.PlannedHeadcount += o.PlannedHeadcount
+		f.PlannedFTE += o.PlannedFTE
+		if o.PlannedCost != nil {
+			cost := *o.PlannedCost
+			if f.PlannedCost != nil {
+				cost += *f.PlannedCost
+			}
+			f.PlannedCost = &cost
+		}
+	}
+	f.PositionsTotal += o.PositionsTotal
+	f.FilledPositions += o.FilledPositions
+	f.CapacityFTE += o.CapacityFTE
+	f.OccupiedFTE += o.OccupiedFTE
+	f.AvailableFTE += o.AvailableFTE
+}
+
+func (f *HeadcountBudgetFigures) finish() {
+	f.VacantPositions = f.PositionsTotal - f.FilledPositions
+	if !f.Budgeted {
+		return
+	}
+	f.HeadcountVariance = f.PlannedHeadcount - f.PositionsTotal
+	f.FTEVariance = f.PlannedFTE - f.CapacityFTE
+	f.OverBudget = f.HeadcountVariance < 0 || f.FTEVariance < -headcountBudgetFTEEpsilon
+}
+
+type HeadcountBudgetVarianceLine struct {
+	Budget HeadcountBudgetRow
+	HeadcountBudgetFigures
+}
+
+type HeadcountBudgetVarianceNode struct {
+	HierarchyNode
+	Lines  []HeadcountBudgetVarianceLine
+	Direct HeadcountBudgetFigures
+	Total  HeadcountBudgetFigures
+}
+
+type HeadcountBudgetVarianceInput struct {
+	OrgNodeID         *uuid.UUID
+	EffectiveDate     time.Time
+	LifecycleStatuses []string
+	IncludeSystem     bool
+}
+
+type HeadcountBudgetVarianceReport struct {
+	TenantID      uuid.UUID
+	OrgNodeID     uuid.UUID
+	EffectiveDate time.Time
+	Nodes         []HeadcountBudgetVarianceNode
+}
+
+// GetHeadcountBudgetVariance compares budget lines with actual positions below a node and rolls
+// both up the OrgUnit tree. Actuals follow GetStaffingSummary: planned+active positions by
+// default, filled meaning at least one active primary assignment.
+func (s *OrgService) GetHeadcountBudgetVariance(ctx context.Context, tenantID uuid.UUID, in HeadcountBudgetVarianceInput) (*HeadcountBudgetVarianceReport, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	asOf := in.EffectiveDate
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	asOf = normalizeValidDateUTC(asOf)
+
+	statuses, err := normalizeStaffingLifecycleStatuses(in.LifecycleStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	rootID, err := inTx(ctx, tenantID, func(txCtx context.Context) (uuid.UUID, error) {
+		return resolveOrgNodeID(txCtx, s.repo, tenantID, in.OrgNodeID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	nodes, _, err := s.GetHierarchyAsOf(ctx, tenantID, HierarchyTypeOrgUnit, asOf)
+	if err != nil {
+		return nil, err
+	}
+	nodes = headcountBudgetSubtree(nodes, rootID)
+	if len(nodes) == 0 {
+		return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_NODE_NOT_FOUND_AT_DATE", "org_node_id not found at effective_date", nil)
+	}
+	nodeIDs := make([]uuid.UUID, 0, len(nodes))
+	for _, n := range nodes {
+		nodeIDs = append(nodeIDs, n.ID)
+	}
+
+	type inputs struct {
+		budgets []HeadcountBudgetRow
+		actuals []HeadcountBudgetActualRow
+	}
+	loaded, err := inTx(ctx, tenantID, func(txCtx context.Context) (inputs, error) {
+		budgets, err := s.repo.ListHeadcountBudgetsAsOf(txCtx, tenantID, asOf, nodeIDs)
+		if err != nil {
+			return inputs{}, err
+		}
+		actuals, err := s.repo.ListHeadcountBudgetActualsAsOf(txCtx, tenantID, asOf, nodeIDs, statuses, in.IncludeSystem)
+		if err != nil {
+			return inputs{}, err
+		}
+		return inputs{budgets: budgets, actuals: actuals}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &HeadcountBudgetVarianceReport{
+		TenantID:      tenantID,
+		OrgNodeID:     rootID,
+		EffectiveDate: asOf,
+		Nodes:         rollupHeadcountBudgetVariance(nodes, loaded.budgets, loaded.actuals),
+	}, nil
+}
+
+// headcountBudgetSubtree keeps rootID and its descendants. Nodes come back ordered by depth, so
+// a parent is always seen before its children.
+func headcountBudgetSubtree(nodes []HierarchyNode, rootID uuid.UUID) []HierarchyNode {
+	keep := make(map[uuid.UUID]bool, len(nodes))
+	out := make([]HierarchyNode, 0, len(nodes))
+	for _, n := range nodes {
+		if n.ID == rootID || (n.ParentID != nil && keep[*n.ParentID]) {
+			keep[n.ID] = true
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// rollupHeadcountBudgetVariance builds per-line and per-node figures. A node's own plan is its
+// node-wide line when it has one and the sum of its narrower lines otherwise; totals add the
+// direct figures of every node in the subtree.
+func rollupHeadcountBudgetVariance(nodes []HierarchyNode, budgets []HeadcountBudgetRow, actuals []HeadcountBudgetActualRow) []HeadcountBudgetVarianceNode {
+	out := make([]HeadcountBudgetVarianceNode, len(nodes))
+	indexByID := make(map[uuid.UUID]int, len(nodes))
+	for i, n := range nodes {
+		out[i] = HeadcountBudgetVarianceNode{HierarchyNode: n}
+		indexByID[n.ID] = i
+	}
+
+	actualsByNode := make(map[uuid.UUID][]HeadcountBudgetActualRow, len(nodes))
+	for _, a := range actuals {
+		i, ok := indexByID[a.OrgNodeID]
+		if !ok {
+			continue
+		}
+		actualsByNode[a.OrgNodeID] = append(actualsByNode[a.OrgNodeID], a)
+		out[i].Direct.addActual(a)
+	}
+	for _, b := range budgets {
+		i, ok := indexByID[b.OrgNodeID]
+		if !ok {
+			continue
+		}
+		line := HeadcountBudgetVarianceLine{Budget: b}
+		line.addPlan(b)
+		for _, a := range actualsByNode[b.OrgNodeID] {
+			if b.matches(a.JobProfileID, a.JobLevelCode) {
+				line.addActual(a)
+			}
+		}
+		line.finish()
+		out[i].Lines = append(out[i].Lines, line)
+	}
+	for i := range out {
+		if len(out[i].Lines) == 0 {
+			continue
+		}
+		nodeWide := false
+		for _, line := range out[i].Lines {
+			if line.Budget.NodeWide() {
+				out[i].Direct.addPlan(line.Budget)
+				nodeWide = true
+			}
+		}
+		if !nodeWide {
+			for _, line := range out[i].Lines {
+				out[i].Direct.addPlan(line.Budget)
+			}
+		}
+	}
+
+	for i := range out {
+		out[i].Total = out[i].Direct
+		out[i].Direct.finish()
+	}
+	// Walking backwards folds every child into its parent before the parent is folded further up.
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i].Total.finish()
+		if out[i].ParentID == nil {
+			continue
+		}
+		p, ok := indexByID[*out[i].ParentID]
+		if !ok {
+			continue
+		}
+		out[p].Total.add(out[i].Total)
+	}
+	return out
+}
+
+func positionBudgetValidationMode(settings OrgSettings) string {
+	if strings.TrimSpace(settings.PositionBudgetValidationMode) == "" {
+		return "disabled"
+	}
+	return normalizeValidationMode(settings.PositionBudgetValidationMode)
+}
+
+// checkHeadcountBudget validates one more position against the budget lines at its node. Every
+// line the position falls under must still have room; a node without any lines is unbudgeted
+// and always passes, while a budgeted node with no matching line has no room at all.
+func checkHeadcountBudget(lines []HeadcountBudgetRow, actuals []HeadcountBudgetActualRow, jobProfileID uuid.UUID, jobLevelCode *string, capacityFTE float64) *ServiceError {
+	if len(lines) == 0 {
+		return nil
+	}
+	matched := false
+	for _, b := range lines {
+		if !b.matches(jobProfileID, jobLevelCode) {
+			continue
+		}
+		matched = true
+		var f HeadcountBudgetFigures
+		f.addPlan(b)
+		for _, a := range actuals {
+			if a.OrgNodeID == b.OrgNodeID && b.matches(a.JobProfileID, a.JobLevelCode) {
+				f.addActual(a)
+			}
+		}
+		f.addActual(HeadcountBudgetActualRow{Positions: 1, CapacityFTE: capacityFTE})
+		f.finish()
+		if f.OverBudget {
+			return newServiceError(http.StatusUnprocessableEntity, "ORG_POSITION_OVER_BUDGET", fmt.Sprintf("position exceeds budget %s (planned headcount %d, planned fte %.2f)", b.ID, b.PlannedHeadcount, b.PlannedFTE), nil)
+		}
+	}
+	if !matched {
+		return newServiceError(http.StatusUnprocessableEntity, "ORG_POSITION_OVER_BUDGET", "no budget line covers this job profile/level at org_node_id", nil)
+	}
+	return nil
+}
+
+// validatePositionBudget locks the node's budget lines for the rest of the transaction so
+// concurrent creates under the same budget serialize on the check.
+func (s *OrgService) validatePositionBudget(ctx context.Context, tenantID uuid.UUID, orgNodeID uuid.UUID, asOf time.Time, jobProfileID uuid.UUID, jobLevelCode *string, capacityFTE float64) error {
+	lines, err := s.repo.LockHeadcountBudgetsAt(ctx, tenantID, orgNodeID, asOf)
+	if err != nil {
+		return err
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	statuses, err := normalizeStaffingLifecycleStatuses(nil)
+	if err != nil {
+		return err
+	}
+	actuals, err := s.repo.ListHeadcountBudgetActualsAsOf(ctx, tenantID, asOf, []uuid.UUID{orgNodeID}, statuses, false)
+	if err != nil {
+		return err
+	}
+	if svcErr := checkHeadcountBudget(lines, actuals, jobProfileID, jobLevelCode, capacityFTE); svcErr != nil {
+		return svcErr
+	}
+	return nil
+}
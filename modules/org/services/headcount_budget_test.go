@@ -0,0 +1,107 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollupHeadcountBudgetVariance_RollsUpSubtree(t *testing.T) {
+	root, child, grandchild, other := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	profileA, profileB := uuid.New(), uuid.New()
+	cost := 1000.0
+
+	nodes := []HierarchyNode{
+		{ID: other, Depth: 0},
+		{ID: root, Depth: 0},
+		{ID: child, ParentID: &root, Depth: 1},
+		{ID: grandchild, ParentID: &child, Depth: 2},
+	}
+	nodes = headcountBudgetSubtree(nodes, root)
+	require.Len(t, nodes, 3)
+
+	budgets := []HeadcountBudgetRow{
+		{ID: uuid.New(), OrgNodeID: root, PlannedHeadcount: 2, PlannedFTE: 2, PlannedCost: &cost},
+		{ID: uuid.New(), OrgNodeID: root, JobProfileID: &profileA, PlannedHeadcount: 1, PlannedFTE: 1},
+		{ID: uuid.New(), OrgNodeID: grandchild, JobProfileID: &profileA, PlannedHeadcount: 1, PlannedFTE: 1},
+		{ID: uuid.New(), OrgNodeID: grandchild, JobProfileID: &profileB, PlannedHeadcount: 2, PlannedFTE: 1.5},
+	}
+	actuals := []HeadcountBudgetActualRow{
+		{OrgNodeID: root, JobProfileID: profileA, Positions: 2, Filled: 1, CapacityFTE: 2, OccupiedFTE: 1, AvailableFTE: 1},
+		{OrgNodeID: child, JobProfileID: profileB, Positions: 1, Filled: 1, CapacityFTE: 1, OccupiedFTE: 1},
+		{OrgNodeID: grandchild, JobProfileID: profileB, Positions: 1, CapacityFTE: 0.5, AvailableFTE: 0.5},
+		{OrgNodeID: other, JobProfileID: profileB, Positions: 9, CapacityFTE: 9},
+	}
+
+	got := rollupHeadcountBudgetVariance(nodes, budgets, actuals)
+	byID := map[uuid.UUID]HeadcountBudgetVarianceNode{}
+	for _, n := range got {
+		byID[n.ID] = n
+	}
+
+	// Root: the node-wide line is the node's plan; the profile line is over by one.
+	r := byID[root]
+	require.Len(t, r.Lines, 2)
+	require.Equal(t, 2, r.Direct.PlannedHeadcount)
+	require.Equal(t, 0, r.Direct.HeadcountVariance)
+	require.False(t, r.Direct.OverBudget)
+	require.True(t, r.Lines[1].OverBudget)
+	require.Equal(t, -1, r.Lines[1].HeadcountVariance)
+
+	// Child has positions but no budget.
+	c := byID[child]
+	require.False(t, c.Direct.Budgeted)
+	require.False(t, c.Direct.OverBudget)
+	require.Equal(t, 0, c.Direct.VacantPositions)
+
+	// Grandchild: narrower lines are summed into the node plan.
+	g := byID[grandchild]
+	require.Equal(t, 3, g.Direct.PlannedHeadcount)
+	require.InDelta(t, 2.5, g.Direct.PlannedFTE, 0.0001)
+	require.Equal(t, 1, g.Direct.VacantPositions)
+	require.Equal(t, 2, g.Direct.HeadcountVariance)
+
+	// Totals include unbudgeted actuals below a budgeted node.
+	require.Equal(t, 5, r.Total.PlannedHeadcount)
+	require.Equal(t, 4, r.Total.PositionsTotal)
+	require.Equal(t, 2, r.Total.FilledPositions)
+	require.Equal(t, 2, r.Total.VacantPositions)
+	require.InDelta(t, 3.5, r.Total.CapacityFTE, 0.0001)
+	require.InDelta(t, 1000, *r.Total.PlannedCost, 0.0001)
+	require.Equal(t, 1, r.Total.HeadcountVariance)
+	require.False(t, r.Total.OverBudget)
+}
+
+func TestCheckHeadcountBudget(t *testing.T) {
+	node := uuid.New()
+	profileA, profileB := uuid.New(), uuid.New()
+	level := "L1"
+
+	lines := []HeadcountBudgetRow{
+		{ID: uuid.New(), OrgNodeID: node, PlannedHeadcount: 3, PlannedFTE: 3},
+		{ID: uuid.New(), OrgNodeID: node, JobProfileID: &profileA, JobLevelCode: &level, PlannedHeadcount: 1, PlannedFTE: 1},
+	}
+	actuals := []HeadcountBudgetActualRow{
+		{OrgNodeID: node, JobProfileID: profileA, JobLevelCode: &level, Positions: 1, CapacityFTE: 1},
+		{OrgNodeID: node, JobProfileID: profileB, Positions: 1, CapacityFTE: 1},
+	}
+
+	require.Nil(t, checkHeadcountBudget(nil, actuals, profileA, &level, 1))
+	require.Nil(t, checkHeadcountBudget(lines, actuals, profileB, nil, 1))
+
+	err := checkHeadcountBudget(lines, actuals, profileA, &level, 1)
+	require.NotNil(t, err)
+	require.Equal(t, "ORG_POSITION_OVER_BUDGET", err.Code)
+
+	err = checkHeadcountBudget(lines, actuals, profileB, nil, 1.5)
+	require.NotNil(t, err, "node-wide FTE plan of 3 is exceeded by 2 + 1.5")
+
+	err = checkHeadcountBudget(lines[1:], actuals, profileB, nil, 1)
+	require.NotNil(t, err, "budgeted node without a matching line has no room")
+}
+
+func TestPositionBudgetValidationMode_DefaultsToDisabled(t *testing.T) {
+	require.Equal(t, "disabled", positionBudgetValidationMode(OrgSettings{}))
+	require.Equal(t, "enforce", positionBudgetValidationMode(OrgSettings{PositionBudgetValidationMode: "enforce"}))
+}
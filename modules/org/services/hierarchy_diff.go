@@ -0,0 +1,529 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PositionStateRow is the minimal view of a position slice used to compare two as-of dates.
+type PositionStateRow struct {
+	PositionID      uuid.UUID
+	Code            string
+	Title           *string
+	OrgNodeID       uuid.UUID
+	LifecycleStatus string
+	CapacityFTE     float64
+}
+
+// AssignmentStateRow is the minimal view of an active assignment used to compare two as-of
+// dates; OrgNodeID is the node of the assigned position as of the same date.
+type AssignmentStateRow struct {
+	AssignmentID   uuid.UUID
+	PositionID     uuid.UUID
+	PositionCode   string
+	OrgNodeID      uuid.UUID
+	SubjectID      uuid.UUID
+	Pernr          string
+	AssignmentType string
+	AllocatedFTE   float64
+}
+
+type HierarchyDiffParams struct {
+	HierarchyType string
+	From          time.Time
+	To            time.Time
+	// RootNodeID limits the diff to one subtree; changes are then grouped by its direct children.
+	RootNodeID *uuid.UUID
+	// IncludePositions/IncludeAssignments only apply to OrgUnit, the tree positions live in.
+	IncludePositions   bool
+	IncludeAssignments bool
+}
+
+type HierarchyDiffNode struct {
+	ID       uuid.UUID
+	Code     string
+	Name     string
+	ParentID *uuid.UUID
+}
+
+type HierarchyDiffRename struct {
+	ID       uuid.UUID
+	Code     string
+	FromName string
+	ToName   string
+}
+
+type HierarchyDiffMove struct {
+	ID           uuid.UUID
+	Code         string
+	Name         string
+	FromParentID *uuid.UUID
+	ToParentID   *uuid.UUID
+}
+
+type HierarchyDiffPosition struct {
+	PositionID uuid.UUID
+	Code       string
+	Title      *string
+	OrgNodeID  uuid.UUID
+}
+
+type HierarchyDiffAssignmentKind string
+
+const (
+	HierarchyDiffAssignmentStarted     HierarchyDiffAssignmentKind = "started"
+	HierarchyDiffAssignmentEnded       HierarchyDiffAssignmentKind = "ended"
+	HierarchyDiffAssignmentTransferred HierarchyDiffAssignmentKind = "transferred"
+	HierarchyDiffAssignmentFTEChanged  HierarchyDiffAssignmentKind = "fte_changed"
+)
+
+type HierarchyDiffAssignment struct {
+	Kind             HierarchyDiffAssignmentKind
+	SubjectID        uuid.UUID
+	Pernr            string
+	AssignmentType   string
+	FromPositionID   *uuid.UUID
+	FromPositionCode string
+	ToPositionID     *uuid.UUID
+	ToPositionCode   string
+	FromOrgNodeID    *uuid.UUID
+	ToOrgNodeID      *uuid.UUID
+	FromFTE          *float64
+	ToFTE            *float64
+}
+
+type HierarchyDiffCounts struct {
+	NodesCreated      int
+	NodesRetired      int
+	NodesRenamed      int
+	NodesMoved        int
+	PositionsOpened   int
+	PositionsClosed   int
+	AssignmentChanges int
+}
+
+// HierarchyDiffGroup collects the changes that fall under one subtree (a direct child of the
+// diff root, or the root itself for changes on the root node).
+type HierarchyDiffGroup struct {
+	Subtree           HierarchyDiffNode
+	Counts            HierarchyDiffCounts
+	NodesCreated      []HierarchyDiffNode
+	NodesRetired      []HierarchyDiffNode
+	NodesRenamed      []HierarchyDiffRename
+	NodesMoved        []HierarchyDiffMove
+	PositionsOpened   []HierarchyDiffPosition
+	PositionsClosed   []HierarchyDiffPosition
+	AssignmentChanges []HierarchyDiffAssignment
+}
+
+type HierarchyDiffResult struct {
+	TenantID      uuid.UUID
+	HierarchyType string
+	From          time.Time
+	To            time.Time
+	RootNodeID    *uuid.UUID
+	Totals        HierarchyDiffCounts
+	Groups        []HierarchyDiffGroup
+}
+
+// DiffHierarchy compares the hierarchy as of two dates and reports created, retired, renamed
+// and moved nodes plus (for OrgUnit) opened/closed positions and assignment changes, grouped
+// by the subtree they belong to. A node, position or assignment is attributed to its location
+// at `to`, or at `from` when it no longer exists.
+func (s *OrgService) DiffHierarchy(ctx context.Context, tenantID uuid.UUID, params HierarchyDiffParams) (*HierarchyDiffResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	hierarchyType, ok := normalizeHierarchyType(params.HierarchyType)
+	if !ok {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "type is invalid", nil)
+	}
+	if params.From.IsZero() || params.To.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "from/to are required", nil)
+	}
+	from := normalizeValidDateUTC(params.From)
+	to := normalizeValidDateUTC(params.To)
+	if !from.Before(to) {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "from must be before to", nil)
+	}
+
+	fromNodes, _, err := s.GetHierarchyAsOf(ctx, tenantID, hierarchyType, from)
+	if err != nil {
+		return nil, err
+	}
+	toNodes, _, err := s.GetHierarchyAsOf(ctx, tenantID, hierarchyType, to)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromPositions, toPositions []PositionStateRow
+	var fromAssignments, toAssignments []AssignmentStateRow
+	includePositions := params.IncludePositions && hierarchyType == HierarchyTypeOrgUnit
+	includeAssignments := params.IncludeAssignments && hierarchyType == HierarchyTypeOrgUnit
+	if includePositions || includeAssignments {
+		type states struct {
+			fromPositions, toPositions     []PositionStateRow
+			fromAssignments, toAssignments []AssignmentStateRow
+		}
+		st, err := inTx(ctx, tenantID, func(txCtx context.Context) (states, error) {
+			var out states
+			var err error
+			if includePositions {
+				if out.fromPositions, err = s.repo.ListPositionStatesAsOf(txCtx, tenantID, from); err != nil {
+					return out, err
+				}
+				if out.toPositions, err = s.repo.ListPositionStatesAsOf(txCtx, tenantID, to); err != nil {
+					return out, err
+				}
+			}
+			if includeAssignments {
+				if out.fromAssignments, err = s.repo.ListAssignmentStatesAsOf(txCtx, tenantID, from); err != nil {
+					return out, err
+				}
+				if out.toAssignments, err = s.repo.ListAssignmentStatesAsOf(txCtx, tenantID, to); err != nil {
+					return out, err
+				}
+			}
+			return out, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		fromPositions, toPositions = st.fromPositions, st.toPositions
+		fromAssignments, toAssignments = st.fromAssignments, st.toAssignments
+	}
+
+	fromByID := hierarchyNodesByID(fromNodes)
+	toByID := hierarchyNodesByID(toNodes)
+
+	rootID, err := hierarchyDiffRoot(fromNodes, toNodes, fromByID, toByID, params.RootNodeID)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &hierarchyDiffBuilder{
+		rootID: rootID,
+		from:   fromByID,
+		to:     toByID,
+		groups: map[uuid.UUID]*HierarchyDiffGroup{},
+	}
+
+	for _, n := range toNodes {
+		prev, existed := fromByID[n.ID]
+		if !existed {
+			b.add(n.ID, func(g *HierarchyDiffGroup) {
+				g.NodesCreated = append(g.NodesCreated, hierarchyDiffNodeOf(n))
+			})
+			continue
+		}
+		if prev.Status == "active" && n.Status != "active" {
+			b.add(n.ID, func(g *HierarchyDiffGroup) {
+				g.NodesRetired = append(g.NodesRetired, hierarchyDiffNodeOf(n))
+			})
+		}
+		if prev.Name != n.Name {
+			b.add(n.ID, func(g *HierarchyDiffGroup) {
+				g.NodesRenamed = append(g.NodesRenamed, HierarchyDiffRename{ID: n.ID, Code: n.Code, FromName: prev.Name, ToName: n.Name})
+			})
+		}
+		if !sameOptionalUUID(prev.ParentID, n.ParentID) {
+			b.add(n.ID, func(g *HierarchyDiffGroup) {
+				g.NodesMoved = append(g.NodesMoved, HierarchyDiffMove{ID: n.ID, Code: n.Code, Name: n.Name, FromParentID: prev.ParentID, ToParentID: n.ParentID})
+			})
+		}
+	}
+	for _, n := range fromNodes {
+		if _, ok := toByID[n.ID]; ok || n.Status != "active" {
+			continue
+		}
+		b.add(n.ID, func(g *HierarchyDiffGroup) {
+			g.NodesRetired = append(g.NodesRetired, hierarchyDiffNodeOf(n))
+		})
+	}
+
+	fromOpen := openPositionsByID(fromPositions)
+	toOpen := openPositionsByID(toPositions)
+	for _, p := range toPositions {
+		if _, ok := toOpen[p.PositionID]; !ok {
+			continue
+		}
+		if _, ok := fromOpen[p.PositionID]; ok {
+			continue
+		}
+		b.add(p.OrgNodeID, func(g *HierarchyDiffGroup) {
+			g.PositionsOpened = append(g.PositionsOpened, hierarchyDiffPositionOf(p))
+		})
+	}
+	for _, p := range fromPositions {
+		if _, ok := fromOpen[p.PositionID]; !ok {
+			continue
+		}
+		if _, ok := toOpen[p.PositionID]; ok {
+			continue
+		}
+		b.add(p.OrgNodeID, func(g *HierarchyDiffGroup) {
+			g.PositionsClosed = append(g.PositionsClosed, hierarchyDiffPositionOf(p))
+		})
+	}
+
+	for _, ch := range diffAssignmentStates(fromAssignments, toAssignments) {
+		nodeID := uuid.Nil
+		if ch.ToOrgNodeID != nil {
+			nodeID = *ch.ToOrgNodeID
+		} else if ch.FromOrgNodeID != nil {
+			nodeID = *ch.FromOrgNodeID
+		}
+		b.add(nodeID, func(g *HierarchyDiffGroup) {
+			g.AssignmentChanges = append(g.AssignmentChanges, ch)
+		})
+	}
+
+	res := &HierarchyDiffResult{
+		TenantID:      tenantID,
+		HierarchyType: hierarchyType,
+		From:          from,
+		To:            to,
+		RootNodeID:    params.RootNodeID,
+		Groups:        b.result(),
+	}
+	for _, g := range res.Groups {
+		res.Totals.NodesCreated += g.Counts.NodesCreated
+		res.Totals.NodesRetired += g.Counts.NodesRetired
+		res.Totals.NodesRenamed += g.Counts.NodesRenamed
+		res.Totals.NodesMoved += g.Counts.NodesMoved
+		res.Totals.PositionsOpened += g.Counts.PositionsOpened
+		res.Totals.PositionsClosed += g.Counts.PositionsClosed
+		res.Totals.AssignmentChanges += g.Counts.AssignmentChanges
+	}
+	return res, nil
+}
+
+type hierarchyDiffBuilder struct {
+	rootID uuid.UUID
+	from   map[uuid.UUID]HierarchyNode
+	to     map[uuid.UUID]HierarchyNode
+	groups map[uuid.UUID]*HierarchyDiffGroup
+}
+
+// add attributes a change on nodeID to its subtree group; changes outside the diff root are
+// dropped.
+func (b *hierarchyDiffBuilder) add(nodeID uuid.UUID, fn func(g *HierarchyDiffGroup)) {
+	key, ok := b.subtreeOf(nodeID)
+	if !ok {
+		return
+	}
+	g, ok := b.groups[key]
+	if !ok {
+		n, found := b.to[key]
+		if !found {
+			n = b.from[key]
+		}
+		g = &HierarchyDiffGroup{Subtree: hierarchyDiffNodeOf(n)}
+		b.groups[key] = g
+	}
+	fn(g)
+}
+
+// subtreeOf walks up from nodeID (in the `to` tree, falling back to `from`) and returns the
+// ancestor that is a direct child of the diff root.
+func (b *hierarchyDiffBuilder) subtreeOf(nodeID uuid.UUID) (uuid.UUID, bool) {
+	if nodeID == b.rootID {
+		return nodeID, true
+	}
+	for _, tree := range []map[uuid.UUID]HierarchyNode{b.to, b.from} {
+		cur := nodeID
+		for i := 0; i <= len(tree); i++ {
+			n, ok := tree[cur]
+			if !ok || n.ParentID == nil {
+				break
+			}
+			if *n.ParentID == b.rootID {
+				return cur, true
+			}
+			cur = *n.ParentID
+		}
+	}
+	return uuid.Nil, false
+}
+
+func (b *hierarchyDiffBuilder) result() []HierarchyDiffGroup {
+	out := make([]HierarchyDiffGroup, 0, len(b.groups))
+	for _, g := range b.groups {
+		sort.SliceStable(g.NodesCreated, func(i, j int) bool { return g.NodesCreated[i].Code < g.NodesCreated[j].Code })
+		sort.SliceStable(g.NodesRetired, func(i, j int) bool { return g.NodesRetired[i].Code < g.NodesRetired[j].Code })
+		sort.SliceStable(g.NodesRenamed, func(i, j int) bool { return g.NodesRenamed[i].Code < g.NodesRenamed[j].Code })
+		sort.SliceStable(g.NodesMoved, func(i, j int) bool { return g.NodesMoved[i].Code < g.NodesMoved[j].Code })
+		sort.SliceStable(g.PositionsOpened, func(i, j int) bool { return g.PositionsOpened[i].Code < g.PositionsOpened[j].Code })
+		sort.SliceStable(g.PositionsClosed, func(i, j int) bool { return g.PositionsClosed[i].Code < g.PositionsClosed[j].Code })
+		sort.SliceStable(g.AssignmentChanges, func(i, j int) bool { return g.AssignmentChanges[i].Pernr < g.AssignmentChanges[j].Pernr })
+		g.Counts = HierarchyDiffCounts{
+			NodesCreated:      len(g.NodesCreated),
+			NodesRetired:      len(g.NodesRetired),
+			NodesRenamed:      len(g.NodesRenamed),
+			NodesMoved:        len(g.NodesMoved),
+			PositionsOpened:   len(g.PositionsOpened),
+			PositionsClosed:   len(g.PositionsClosed),
+			AssignmentChanges: len(g.AssignmentChanges),
+		}
+		out = append(out, *g)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if (out[i].Subtree.ID == b.rootID) != (out[j].Subtree.ID == b.rootID) {
+			return out[i].Subtree.ID == b.rootID
+		}
+		return out[i].Subtree.Code < out[j].Subtree.Code
+	})
+	return out
+}
+
+func hierarchyDiffRoot(fromNodes, toNodes []HierarchyNode, fromByID, toByID map[uuid.UUID]HierarchyNode, requested *uuid.UUID) (uuid.UUID, error) {
+	if requested != nil {
+		if _, ok := toByID[*requested]; ok {
+			return *requested, nil
+		}
+		if _, ok := fromByID[*requested]; ok {
+			return *requested, nil
+		}
+		return uuid.Nil, newServiceError(http.StatusNotFound, "ORG_NODE_NOT_FOUND_AT_DATE", "root_node_id not found at from/to", nil)
+	}
+	for _, nodes := range [][]HierarchyNode{toNodes, fromNodes} {
+		for _, n := range nodes {
+			if n.ParentID == nil {
+				return n.ID, nil
+			}
+		}
+	}
+	return uuid.Nil, nil
+}
+
+func hierarchyNodesByID(nodes []HierarchyNode) map[uuid.UUID]HierarchyNode {
+	out := make(map[uuid.UUID]HierarchyNode, len(nodes))
+	for _, n := range nodes {
+		out[n.ID] = n
+	}
+	return out
+}
+
+func hierarchyDiffNodeOf(n HierarchyNode) HierarchyDiffNode {
+	return HierarchyDiffNode{ID: n.ID, Code: n.Code, Name: n.Name, ParentID: n.ParentID}
+}
+
+func hierarchyDiffPositionOf(p PositionStateRow) HierarchyDiffPosition {
+	return HierarchyDiffPosition{PositionID: p.PositionID, Code: p.Code, Title: p.Title, OrgNodeID: p.OrgNodeID}
+}
+
+func sameOptionalUUID(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// openPositionsByID keeps positions that count as open (planned or active) at their date.
+func openPositionsByID(rows []PositionStateRow) map[uuid.UUID]PositionStateRow {
+	out := make(map[uuid.UUID]PositionStateRow, len(rows))
+	for _, p := range rows {
+		if p.LifecycleStatus == "planned" || p.LifecycleStatus == "active" {
+			out[p.PositionID] = p
+		}
+	}
+	return out
+}
+
+// diffAssignmentStates pairs assignments per (subject, assignment_type): a position change
+// is a transfer, same position with a different FTE is an FTE change, and unmatched rows are
+// starts/ends.
+func diffAssignmentStates(fromRows, toRows []AssignmentStateRow) []HierarchyDiffAssignment {
+	type key struct {
+		subjectID      uuid.UUID
+		assignmentType string
+	}
+	group := func(rows []AssignmentStateRow) map[key][]AssignmentStateRow {
+		out := map[key][]AssignmentStateRow{}
+		for _, r := range rows {
+			k := key{subjectID: r.SubjectID, assignmentType: r.AssignmentType}
+			out[k] = append(out[k], r)
+		}
+		return out
+	}
+	fromByKey := group(fromRows)
+	toByKey := group(toRows)
+
+	keys := make([]key, 0, len(fromByKey)+len(toByKey))
+	seen := map[key]bool{}
+	for _, rows := range [][]AssignmentStateRow{toRows, fromRows} {
+		for _, r := range rows {
+			k := key{subjectID: r.SubjectID, assignmentType: r.AssignmentType}
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	out := make([]HierarchyDiffAssignment, 0)
+	for _, k := range keys {
+		before := fromByKey[k]
+		after := toByKey[k]
+
+		var unmatchedBefore, unmatchedAfter []AssignmentStateRow
+		afterByPosition := make(map[uuid.UUID]AssignmentStateRow, len(after))
+		for _, a := range after {
+			afterByPosition[a.PositionID] = a
+		}
+		matched := map[uuid.UUID]bool{}
+		for _, b := range before {
+			a, ok := afterByPosition[b.PositionID]
+			if !ok {
+				unmatchedBefore = append(unmatchedBefore, b)
+				continue
+			}
+			matched[b.PositionID] = true
+			if a.AllocatedFTE != b.AllocatedFTE {
+				out = append(out, assignmentDiffOf(HierarchyDiffAssignmentFTEChanged, &b, &a))
+			}
+		}
+		for _, a := range after {
+			if !matched[a.PositionID] {
+				unmatchedAfter = append(unmatchedAfter, a)
+			}
+		}
+
+		n := min(len(unmatchedBefore), len(unmatchedAfter))
+		for i := 0; i < n; i++ {
+			out = append(out, assignmentDiffOf(HierarchyDiffAssignmentTransferred, &unmatchedBefore[i], &unmatchedAfter[i]))
+		}
+		for i := n; i < len(unmatchedBefore); i++ {
+			out = append(out, assignmentDiffOf(HierarchyDiffAssignmentEnded, &unmatchedBefore[i], nil))
+		}
+		for i := n; i < len(unmatchedAfter); i++ {
+			out = append(out, assignmentDiffOf(HierarchyDiffAssignmentStarted, nil, &unmatchedAfter[i]))
+		}
+	}
+	return out
+}
+
+func assignmentDiffOf(kind HierarchyDiffAssignmentKind, before, after *AssignmentStateRow) HierarchyDiffAssignment {
+	out := HierarchyDiffAssignment{Kind: kind}
+	if before != nil {
+		out.SubjectID = before.SubjectID
+		out.Pernr = before.Pernr
+		out.AssignmentType = before.AssignmentType
+		positionID, orgNodeID, fte := before.PositionID, before.OrgNodeID, before.AllocatedFTE
+		out.FromPositionID, out.FromOrgNodeID, out.FromFTE = &positionID, &orgNodeID, &fte
+		out.FromPositionCode = before.PositionCode
+	}
+	if after != nil {
+		out.SubjectID = after.SubjectID
+		out.Pernr = after.Pernr
+		out.AssignmentType = after.AssignmentType
+		positionID, orgNodeID, fte := after.PositionID, after.OrgNodeID, after.AllocatedFTE
+		out.ToPositionID, out.ToOrgNodeID, out.ToFTE = &positionID, &orgNodeID, &fte
+		out.ToPositionCode = after.PositionCode
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffAssignmentStates_ClassifiesChanges(t *testing.T) {
+	nodeA, nodeB := uuid.New(), uuid.New()
+	posA, posB, posC := uuid.New(), uuid.New(), uuid.New()
+	mover, stayer, leaver, joiner := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	from := []AssignmentStateRow{
+		{PositionID: posA, OrgNodeID: nodeA, SubjectID: mover, Pernr: "001", AssignmentType: "primary", AllocatedFTE: 1},
+		{PositionID: posB, OrgNodeID: nodeB, SubjectID: stayer, Pernr: "002", AssignmentType: "primary", AllocatedFTE: 1},
+		{PositionID: posC, OrgNodeID: nodeB, SubjectID: leaver, Pernr: "003", AssignmentType: "primary", AllocatedFTE: 1},
+	}
+	to := []AssignmentStateRow{
+		{PositionID: posB, OrgNodeID: nodeB, SubjectID: mover, Pernr: "001", AssignmentType: "primary", AllocatedFTE: 1},
+		{PositionID: posB, OrgNodeID: nodeB, SubjectID: stayer, Pernr: "002", AssignmentType: "primary", AllocatedFTE: 0.5},
+		{PositionID: posC, OrgNodeID: nodeB, SubjectID: joiner, Pernr: "004", AssignmentType: "primary", AllocatedFTE: 1},
+	}
+
+	got := map[string]HierarchyDiffAssignment{}
+	for _, ch := range diffAssignmentStates(from, to) {
+		got[ch.Pernr] = ch
+	}
+	require.Len(t, got, 4)
+
+	require.Equal(t, HierarchyDiffAssignmentTransferred, got["001"].Kind)
+	require.Equal(t, posA, *got["001"].FromPositionID)
+	require.Equal(t, posB, *got["001"].ToPositionID)
+	require.Equal(t, nodeB, *got["001"].ToOrgNodeID)
+
+	require.Equal(t, HierarchyDiffAssignmentFTEChanged, got["002"].Kind)
+	require.InDelta(t, 1, *got["002"].FromFTE, 0.0001)
+	require.InDelta(t, 0.5, *got["002"].ToFTE, 0.0001)
+
+	require.Equal(t, HierarchyDiffAssignmentEnded, got["003"].Kind)
+	require.Nil(t, got["003"].ToPositionID)
+
+	require.Equal(t, HierarchyDiffAssignmentStarted, got["004"].Kind)
+	require.Nil(t, got["004"].FromPositionID)
+}
+
+func TestHierarchyDiffBuilder_GroupsBySubtree(t *testing.T) {
+	root, sales, emea, ops := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	from := hierarchyNodesByID([]HierarchyNode{
+		{ID: root, Code: "ROOT"},
+		{ID: sales, Code: "SALES", ParentID: &root},
+		{ID: ops, Code: "OPS", ParentID: &root},
+	})
+	to := hierarchyNodesByID([]HierarchyNode{
+		{ID: root, Code: "ROOT"},
+		{ID: sales, Code: "SALES", ParentID: &root},
+		{ID: emea, Code: "EMEA", ParentID: &sales},
+	})
+
+	b := &hierarchyDiffBuilder{rootID: root, from: from, to: to, groups: map[uuid.UUID]*HierarchyDiffGroup{}}
+	b.add(emea, func(g *HierarchyDiffGroup) { g.NodesCreated = append(g.NodesCreated, hierarchyDiffNodeOf(to[emea])) })
+	b.add(ops, func(g *HierarchyDiffGroup) { g.NodesRetired = append(g.NodesRetired, hierarchyDiffNodeOf(from[ops])) })
+	b.add(root, func(g *HierarchyDiffGroup) {
+		g.NodesRenamed = append(g.NodesRenamed, HierarchyDiffRename{ID: root, Code: "ROOT"})
+	})
+	b.add(uuid.New(), func(g *HierarchyDiffGroup) { t.Fatal("changes outside the tree must be dropped") })
+
+	groups := b.result()
+	require.Len(t, groups, 3)
+	require.Equal(t, root, groups[0].Subtree.ID)
+	require.Equal(t, 1, groups[0].Counts.NodesRenamed)
+	require.Equal(t, "OPS", groups[1].Subtree.Code)
+	require.Equal(t, 1, groups[1].Counts.NodesRetired)
+	require.Equal(t, "SALES", groups[2].Subtree.Code)
+	require.Equal(t, 1, groups[2].Counts.NodesCreated)
+}
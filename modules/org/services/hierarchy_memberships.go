@@ -0,0 +1,332 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	HierarchyMembershipSubjectPosition   = "position"
+	HierarchyMembershipSubjectAssignment = "assignment"
+)
+
+type HierarchyMembershipRow struct {
+	ID            uuid.UUID
+	SubjectType   string
+	SubjectID     uuid.UUID
+	HierarchyType string
+	OrgNodeID     uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type HierarchyMembershipInsert struct {
+	SubjectType   string
+	SubjectID     uuid.UUID
+	HierarchyType string
+	OrgNodeID     uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+type HierarchyMembershipListFilter struct {
+	ID            *uuid.UUID
+	SubjectType   *string
+	SubjectID     *uuid.UUID
+	HierarchyType *string
+	OrgNodeID     *uuid.UUID
+	AsOf          *time.Time
+	Limit         int
+}
+
+// HierarchyMembershipNodeTotals is the direct (non-rolled-up) staffing attached to one node of a
+// parallel hierarchy as of a date.
+type HierarchyMembershipNodeTotals struct {
+	OrgNodeID uuid.UUID
+	Positions int
+	Headcount int
+	FTE       float64
+}
+
+type SetHierarchyMembershipInput struct {
+	SubjectType   string
+	SubjectID     uuid.UUID
+	HierarchyType string
+	OrgNodeID     uuid.UUID
+	EffectiveDate time.Time
+}
+
+type SetHierarchyMembershipResult struct {
+	ID            uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+func validateHierarchyMembershipKeys(subjectType, hierarchyType string) error {
+	if subjectType != HierarchyMembershipSubjectPosition && subjectType != HierarchyMembershipSubjectAssignment {
+		return newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "subject_type must be position or assignment", nil)
+	}
+	if hierarchyType == HierarchyTypeOrgUnit {
+		return newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "OrgUnit placement is owned by positions, not memberships", nil)
+	}
+	if !IsValidHierarchyType(hierarchyType) {
+		return newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "hierarchy_type is invalid", nil)
+	}
+	return nil
+}
+
+// SetHierarchyMembership places a position or assignment under a node of a parallel hierarchy
+// from effective_date on. A membership starting on the same day is corrected in place; one
+// that started earlier is split, so history before effective_date is preserved.
+func (s *OrgService) SetHierarchyMembership(ctx context.Context, tenantID uuid.UUID, in SetHierarchyMembershipInput) (*SetHierarchyMembershipResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	txTime := time.Now().UTC()
+
+	in.SubjectType = strings.TrimSpace(in.SubjectType)
+	in.HierarchyType = strings.TrimSpace(in.HierarchyType)
+	if in.SubjectType == "" || in.SubjectID == uuid.Nil || in.HierarchyType == "" || in.OrgNodeID == uuid.Nil || in.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "subject_type/subject_id/hierarchy_type/org_node_id/effective_date are required", nil)
+	}
+	if err := validateHierarchyMembershipKeys(in.SubjectType, in.HierarchyType); err != nil {
+		return nil, err
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*SetHierarchyMembershipResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate); err != nil {
+			return nil, err
+		}
+
+		subjectExists, err := s.repo.HierarchyMembershipSubjectExistsAt(txCtx, tenantID, in.SubjectType, in.SubjectID, in.EffectiveDate)
+		if err != nil {
+			return nil, err
+		}
+		if !subjectExists {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_SUBJECT_NOT_FOUND_AT_DATE", "subject_id not found at effective_date", nil)
+		}
+		nodeExists, err := s.repo.NodeExistsAt(txCtx, tenantID, in.OrgNodeID, in.HierarchyType, in.EffectiveDate)
+		if err != nil {
+			return nil, err
+		}
+		if !nodeExists {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_NODE_NOT_FOUND_AT_DATE", "org_node_id not found in hierarchy at effective_date", nil)
+		}
+
+		current, err := s.repo.LockHierarchyMembershipAt(txCtx, tenantID, in.SubjectType, in.SubjectID, in.HierarchyType, in.EffectiveDate)
+		switch {
+		case err == nil:
+			if current.EffectiveDate.Equal(in.EffectiveDate) {
+				if err := s.repo.UpdateHierarchyMembershipNode(txCtx, tenantID, current.ID, in.OrgNodeID); err != nil {
+					return nil, mapPgError(err)
+				}
+				return &SetHierarchyMembershipResult{ID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: current.EndDate}, nil
+			}
+			if err := s.repo.UpdateHierarchyMembershipEndDate(txCtx, tenantID, current.ID, truncateEndDateFromNewEffectiveDate(in.EffectiveDate)); err != nil {
+				return nil, mapPgError(err)
+			}
+			id, err := s.repo.InsertHierarchyMembership(txCtx, tenantID, HierarchyMembershipInsert{
+				SubjectType:   in.SubjectType,
+				SubjectID:     in.SubjectID,
+				HierarchyType: in.HierarchyType,
+				OrgNodeID:     in.OrgNodeID,
+				EffectiveDate: in.EffectiveDate,
+				EndDate:       current.EndDate,
+			})
+			if err != nil {
+				return nil, mapPgError(err)
+			}
+			return &SetHierarchyMembershipResult{ID: id, EffectiveDate: in.EffectiveDate, EndDate: current.EndDate}, nil
+		case errors.Is(err, pgx.ErrNoRows):
+		default:
+			return nil, err
+		}
+
+		endDate := endOfTime
+		next, err := s.repo.NextHierarchyMembershipStart(txCtx, tenantID, in.SubjectType, in.SubjectID, in.HierarchyType, in.EffectiveDate)
+		if err != nil {
+			return nil, err
+		}
+		if next != nil {
+			endDate = truncateEndDateFromNewEffectiveDate(*next)
+		}
+		id, err := s.repo.InsertHierarchyMembership(txCtx, tenantID, HierarchyMembershipInsert{
+			SubjectType:   in.SubjectType,
+			SubjectID:     in.SubjectID,
+			HierarchyType: in.HierarchyType,
+			OrgNodeID:     in.OrgNodeID,
+			EffectiveDate: in.EffectiveDate,
+			EndDate:       endDate,
+		})
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		return &SetHierarchyMembershipResult{ID: id, EffectiveDate: in.EffectiveDate, EndDate: endDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return written, nil
+}
+
+type EndHierarchyMembershipInput struct {
+	ID            uuid.UUID
+	EffectiveDate time.Time
+}
+
+// EndHierarchyMembership detaches the subject from the node on effective_date (the membership's
+// last day is the day before).
+func (s *OrgService) EndHierarchyMembership(ctx context.Context, tenantID uuid.UUID, in EndHierarchyMembershipInput) (*SetHierarchyMembershipResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	txTime := time.Now().UTC()
+	if in.ID == uuid.Nil || in.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "id/effective_date are required", nil)
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+
+	return inTx(ctx, tenantID, func(txCtx context.Context) (*SetHierarchyMembershipResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate); err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockHierarchyMembershipByID(txCtx, tenantID, in.ID)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		if !in.EffectiveDate.After(current.EffectiveDate) || in.EffectiveDate.After(current.EndDate) {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_INVALID_RESCIND_DATE", "effective_date must be within current window", nil)
+		}
+		endDate := truncateEndDateFromNewEffectiveDate(in.EffectiveDate)
+		if err := s.repo.UpdateHierarchyMembershipEndDate(txCtx, tenantID, current.ID, endDate); err != nil {
+			return nil, mapPgError(err)
+		}
+		return &SetHierarchyMembershipResult{ID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: endDate}, nil
+	})
+}
+
+func (s *OrgService) ListHierarchyMemberships(ctx context.Context, tenantID uuid.UUID, filter HierarchyMembershipListFilter) ([]HierarchyMembershipRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if filter.HierarchyType != nil && !IsValidHierarchyType(*filter.HierarchyType) {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "hierarchy_type is invalid", nil)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = 200
+	}
+	if filter.Limit > 1000 {
+		filter.Limit = 1000
+	}
+	if filter.AsOf != nil && !filter.AsOf.IsZero() {
+		asOf := normalizeValidDateUTC(*filter.AsOf)
+		filter.AsOf = &asOf
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) ([]HierarchyMembershipRow, error) {
+		return s.repo.ListHierarchyMemberships(txCtx, tenantID, filter)
+	})
+}
+
+type HierarchyStaffingNode struct {
+	HierarchyNode
+	DirectPositions int
+	DirectHeadcount int
+	DirectFTE       float64
+	TotalPositions  int
+	TotalHeadcount  int
+	TotalFTE        float64
+}
+
+type HierarchyStaffingReport struct {
+	TenantID      uuid.UUID
+	HierarchyType string
+	EffectiveDate time.Time
+	Nodes         []HierarchyStaffingNode
+}
+
+// GetHierarchyStaffing rolls positions, headcount and FTE up a parallel hierarchy (e.g. a
+// cost-center tree). An assignment counts under its own membership when it has one and under
+// its position's membership otherwise.
+func (s *OrgService) GetHierarchyStaffing(ctx context.Context, tenantID uuid.UUID, hierarchyType string, asOf time.Time) (*HierarchyStaffingReport, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if hierarchyType == HierarchyTypeOrgUnit || !IsValidHierarchyType(hierarchyType) {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "type is invalid", nil)
+	}
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	asOf = normalizeValidDateUTC(asOf)
+
+	nodes, _, err := s.GetHierarchyAsOf(ctx, tenantID, hierarchyType, asOf)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := inTx(ctx, tenantID, func(txCtx context.Context) ([]HierarchyMembershipNodeTotals, error) {
+		return s.repo.ListHierarchyMembershipTotalsAsOf(txCtx, tenantID, hierarchyType, asOf)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]HierarchyStaffingNode, len(nodes))
+	indexByID := make(map[uuid.UUID]int, len(nodes))
+	for i, n := range nodes {
+		out[i] = HierarchyStaffingNode{HierarchyNode: n}
+		indexByID[n.ID] = i
+	}
+	for _, t := range totals {
+		i, ok := indexByID[t.OrgNodeID]
+		if !ok {
+			continue
+		}
+		out[i].DirectPositions = t.Positions
+		out[i].DirectHeadcount = t.Headcount
+		out[i].DirectFTE = t.FTE
+	}
+	// Nodes come back ordered by depth, so walking backwards folds every child into its
+	// parent before the parent itself is folded further up.
+	for i := range out {
+		out[i].TotalPositions = out[i].DirectPositions
+		out[i].TotalHeadcount = out[i].DirectHeadcount
+		out[i].TotalFTE = out[i].DirectFTE
+	}
+	for i := len(out) - 1; i >= 0; i-- {
+		if out[i].ParentID == nil {
+			continue
+		}
+		p, ok := indexByID[*out[i].ParentID]
+		if !ok {
+			continue
+		}
+		out[p].TotalPositions += out[i].TotalPositions
+		out[p].TotalHeadcount += out[i].TotalHeadcount
+		out[p].TotalFTE += out[i].TotalFTE
+	}
+
+	return &HierarchyStaffingReport{
+		TenantID:      tenantID,
+		HierarchyType: hierarchyType,
+		EffectiveDate: asOf,
+		Nodes:         out,
+	}, nil
+}
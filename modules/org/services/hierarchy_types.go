@@ -0,0 +1,43 @@
+package services
+
+import "strings"
+
+// Hierarchy types. Every node belongs to exactly one hierarchy (org_nodes.type), and edges,
+// closure/snapshot builds and reporting nodes are partitioned by it. OrgUnit is the line
+// organization that positions and assignments live in; the other trees are parallel views
+// that positions/assignments join through memberships.
+const (
+	HierarchyTypeOrgUnit     = "OrgUnit"
+	HierarchyTypeCostCenter  = "CostCenter"
+	HierarchyTypeLegalEntity = "LegalEntity"
+	HierarchyTypeGeographic  = "Geographic"
+	HierarchyTypeProject     = "Project"
+)
+
+// HierarchyTypes lists the supported hierarchy types in display order.
+var HierarchyTypes = []string{
+	HierarchyTypeOrgUnit,
+	HierarchyTypeCostCenter,
+	HierarchyTypeLegalEntity,
+	HierarchyTypeGeographic,
+	HierarchyTypeProject,
+}
+
+func IsValidHierarchyType(v string) bool {
+	for _, t := range HierarchyTypes {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeHierarchyType defaults an empty value to OrgUnit and reports whether the result is
+// supported.
+func normalizeHierarchyType(v string) (string, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return HierarchyTypeOrgUnit, true
+	}
+	return v, IsValidHierarchyType(v)
+}
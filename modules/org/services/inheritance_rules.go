@@ -0,0 +1,855 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// InheritanceRuleRow is one effective-dated slice of an attribute inheritance rule. A rule makes
+// AttributeName flow from parent to child in HierarchyType; CanOverride lets a child's own value
+// win over the inherited one. EndDate is the last day in force.
+type InheritanceRuleRow struct {
+	ID                       uuid.UUID
+	HierarchyType            string
+	AttributeName            string
+	CanOverride              bool
+	InheritanceBreakNodeType *string
+	EffectiveDate            time.Time
+	EndDate                  time.Time
+}
+
+type InheritanceRuleInsert struct {
+	HierarchyType            string
+	AttributeName            string
+	CanOverride              bool
+	InheritanceBreakNodeType *string
+	EffectiveDate            time.Time
+	EndDate                  time.Time
+}
+
+// InheritanceBreakRow stops OrgNodeID from inheriting AttributeName from its parent; the node's
+// own value (or none) becomes the inherited value for its subtree. EndDate is the last day in force.
+type InheritanceBreakRow struct {
+	ID            uuid.UUID
+	HierarchyType string
+	AttributeName string
+	OrgNodeID     uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+type InheritanceBreakInsert struct {
+	HierarchyType string
+	AttributeName string
+	OrgNodeID     uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+type InheritanceRuleListFilter struct {
+	HierarchyType string
+	AttributeName *string
+	AsOf          *time.Time
+}
+
+type InheritanceWriteResult struct {
+	ID            uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+func inheritanceRuleAuditValues(row InheritanceRuleRow) map[string]any {
+	values := map[string]any{
+		"rule_id":        row.ID.String(),
+		"hierarchy_type": row.HierarchyType,
+		"attribute_name": row.AttributeName,
+		"can_override":   row.CanOverride,
+		"effective_date": row.EffectiveDate.UTC().Format(time.DateOnly),
+		"end_date":       row.EndDate.UTC().Format(time.DateOnly),
+	}
+	if row.InheritanceBreakNodeType != nil {
+		values["inheritance_break_node_type"] = *row.InheritanceBreakNodeType
+	}
+	return values
+}
+
+func inheritanceBreakAuditValues(row InheritanceBreakRow) map[string]any {
+	return map[string]any{
+		"break_id":       row.ID.String(),
+		"hierarchy_type": row.HierarchyType,
+		"attribute_name": row.AttributeName,
+		"org_node_id":    row.OrgNodeID.String(),
+		"effective_date": row.EffectiveDate.UTC().Format(time.DateOnly),
+		"end_date":       row.EndDate.UTC().Format(time.DateOnly),
+	}
+}
+
+// normalizeInheritanceTarget validates the hierarchy type and attribute a rule or break applies to.
+func normalizeInheritanceTarget(hierarchyType, attributeName string) (string, string, error) {
+	hierarchyType = strings.TrimSpace(hierarchyType)
+	if hierarchyType == "" {
+		hierarchyType = HierarchyTypeOrgUnit
+	}
+	if !IsValidHierarchyType(hierarchyType) {
+		return "", "", newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "hierarchy_type is invalid", nil)
+	}
+	attributeName = strings.TrimSpace(strings.ToLower(attributeName))
+	if !IsInheritableAttribute(attributeName) {
+		return "", "", newServiceError(http.StatusBadRequest, "ORG_UNKNOWN_ATTRIBUTE", "unknown attribute", nil)
+	}
+	return hierarchyType, attributeName, nil
+}
+
+type SetInheritanceRuleInput struct {
+	HierarchyType string
+	AttributeName string
+	CanOverride   bool
+	EffectiveDate time.Time
+}
+
+// SetInheritanceRule makes an attribute inherit from effective_date on. A rule slice starting on
+// the same day is corrected in place; one that started earlier is split, so resolved values
+// before effective_date do not change.
+func (s *OrgService) SetInheritanceRule(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in SetInheritanceRuleInput) (*InheritanceWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	hierarchyType, attributeName, err := normalizeInheritanceTarget(in.HierarchyType, in.AttributeName)
+	if err != nil {
+		return nil, err
+	}
+	if in.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "effective_date is required", nil)
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*InheritanceWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "inheritance_rule.set", "org_inheritance_rule", uuid.Nil, in.EffectiveDate, freeze, err, logrus.Fields{
+				"attribute_name": attributeName,
+				"operation":      "Update",
+			})
+			return nil, err
+		}
+		if err := s.ensureCustomAttributeDefined(txCtx, tenantID, attributeName, in.EffectiveDate); err != nil {
+			return nil, err
+		}
+
+		audit := AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			EntityType:           "org_inheritance_rule",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
+		}
+		var result InheritanceRuleRow
+
+		current, err := s.repo.LockInheritanceRuleAt(txCtx, tenantID, hierarchyType, attributeName, in.EffectiveDate)
+		switch {
+		case err == nil && current.EffectiveDate.Equal(in.EffectiveDate):
+			if err := s.repo.UpdateInheritanceRuleCanOverride(txCtx, tenantID, current.ID, in.CanOverride); err != nil {
+				return nil, mapPgError(err)
+			}
+			result = current
+			result.CanOverride = in.CanOverride
+			audit.ChangeType = "inheritance_rule.corrected"
+			audit.Operation = "Correct"
+			audit.OldValues = inheritanceRuleAuditValues(current)
+		case err == nil:
+			if err := s.repo.UpdateInheritanceRuleEndDate(txCtx, tenantID, current.ID, truncateEndDateFromNewEffectiveDate(in.EffectiveDate)); err != nil {
+				return nil, mapPgError(err)
+			}
+			result = InheritanceRuleRow{
+				HierarchyType:            hierarchyType,
+				AttributeName:            attributeName,
+				CanOverride:              in.CanOverride,
+				InheritanceBreakNodeType: current.InheritanceBreakNodeType,
+				EffectiveDate:            in.EffectiveDate,
+				EndDate:                  current.EndDate,
+			}
+			audit.ChangeType = "inheritance_rule.updated"
+			audit.Operation = "Update"
+			audit.OldValues = inheritanceRuleAuditValues(current)
+		case errors.Is(err, pgx.ErrNoRows):
+			endDate := endOfTime
+			next, err := s.repo.NextInheritanceRuleStart(txCtx, tenantID, hierarchyType, attributeName, in.EffectiveDate)
+			if err != nil {
+				return nil, err
+			}
+			if next != nil {
+				endDate = truncateEndDateFromNewEffectiveDate(*next)
+			}
+			result = InheritanceRuleRow{
+				HierarchyType: hierarchyType,
+				AttributeName: attributeName,
+				CanOverride:   in.CanOverride,
+				EffectiveDate: in.EffectiveDate,
+				EndDate:       endDate,
+			}
+			audit.ChangeType = "inheritance_rule.created"
+			audit.Operation = "Create"
+		default:
+			return nil, err
+		}
+
+		if result.ID == uuid.Nil {
+			id, err := s.repo.InsertInheritanceRule(txCtx, tenantID, InheritanceRuleInsert{
+				HierarchyType:            result.HierarchyType,
+				AttributeName:            result.AttributeName,
+				CanOverride:              result.CanOverride,
+				InheritanceBreakNodeType: result.InheritanceBreakNodeType,
+				EffectiveDate:            result.EffectiveDate,
+				EndDate:                  result.EndDate,
+			})
+			if err != nil {
+				return nil, mapPgError(err)
+			}
+			result.ID = id
+		}
+
+		audit.EntityID = result.ID
+		audit.EffectiveDate = result.EffectiveDate
+		audit.EndDate = result.EndDate
+		audit.NewValues = inheritanceRuleAuditValues(result)
+		if _, err := s.repo.InsertAuditLog(txCtx, tenantID, audit); err != nil {
+			return nil, err
+		}
+		return &InheritanceWriteResult{ID: result.ID, EffectiveDate: result.EffectiveDate, EndDate: result.EndDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+type EndInheritanceInput struct {
+	ID uuid.UUID
+	// EffectiveDate is the first day the rule or break no longer applies.
+	EffectiveDate time.Time
+}
+
+// EndInheritanceRule stops an attribute from inheriting on effective_date (the rule's last day
+// is the day before).
+func (s *OrgService) EndInheritanceRule(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in EndInheritanceInput) (*InheritanceWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if in.ID == uuid.Nil || in.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "id/effective_date are required", nil)
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*InheritanceWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockInheritanceRuleByID(txCtx, tenantID, in.ID)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		if !in.EffectiveDate.After(current.EffectiveDate) || in.EffectiveDate.After(current.EndDate) {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_INVALID_RESCIND_DATE", "effective_date must be within current window", nil)
+		}
+
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "inheritance_rule.ended", "org_inheritance_rule", current.ID, in.EffectiveDate, freeze, err, logrus.Fields{
+				"attribute_name": current.AttributeName,
+				"operation":      "Update",
+			})
+			return nil, err
+		}
+
+		endDate := truncateEndDateFromNewEffectiveDate(in.EffectiveDate)
+		if err := s.repo.UpdateInheritanceRuleEndDate(txCtx, tenantID, current.ID, endDate); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		updated := current
+		updated.EndDate = endDate
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "inheritance_rule.ended",
+			EntityType:           "org_inheritance_rule",
+			EntityID:             current.ID,
+			EffectiveDate:        current.EffectiveDate,
+			EndDate:              endDate,
+			OldValues:            inheritanceRuleAuditValues(current),
+			NewValues:            inheritanceRuleAuditValues(updated),
+			Operation:            "Update",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &InheritanceWriteResult{ID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: endDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+// DeleteInheritanceRule removes a rule slice that was recorded in error, as if it never existed.
+// The audit log keeps the removed values.
+func (s *OrgService) DeleteInheritanceRule(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, id uuid.UUID) (*InheritanceWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if id == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "id is required", nil)
+	}
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*InheritanceWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockInheritanceRuleByID(txCtx, tenantID, id)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, current.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "inheritance_rule.rescinded", "org_inheritance_rule", current.ID, current.EffectiveDate, freeze, err, logrus.Fields{
+				"attribute_name": current.AttributeName,
+				"operation":      "Rescind",
+			})
+			return nil, err
+		}
+
+		if err := s.repo.DeleteInheritanceRule(txCtx, tenantID, current.ID); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "inheritance_rule.rescinded",
+			EntityType:           "org_inheritance_rule",
+			EntityID:             current.ID,
+			EffectiveDate:        current.EffectiveDate,
+			EndDate:              current.EndDate,
+			OldValues:            inheritanceRuleAuditValues(current),
+			Operation:            "Rescind",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        current.EffectiveDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &InheritanceWriteResult{ID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: current.EndDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+type CreateInheritanceBreakInput struct {
+	HierarchyType string
+	AttributeName string
+	OrgNodeID     uuid.UUID
+	EffectiveDate time.Time
+}
+
+// CreateInheritanceBreak stops a node inheriting an attribute from its parent from
+// effective_date on. The break stays in force until it is ended.
+func (s *OrgService) CreateInheritanceBreak(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in CreateInheritanceBreakInput) (*InheritanceWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	hierarchyType, attributeName, err := normalizeInheritanceTarget(in.HierarchyType, in.AttributeName)
+	if err != nil {
+		return nil, err
+	}
+	if in.OrgNodeID == uuid.Nil || in.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "org_node_id/effective_date are required", nil)
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*InheritanceWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "inheritance_break.created", "org_inheritance_break", uuid.Nil, in.EffectiveDate, freeze, err, logrus.Fields{
+				"org_node_id":    in.OrgNodeID.String(),
+				"attribute_name": attributeName,
+				"operation":      "Create",
+			})
+			return nil, err
+		}
+
+		if err := s.ensureCustomAttributeDefined(txCtx, tenantID, attributeName, in.EffectiveDate); err != nil {
+			return nil, err
+		}
+		exists, err := s.repo.NodeExistsAt(txCtx, tenantID, in.OrgNodeID, hierarchyType, in.EffectiveDate)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_NODE_NOT_FOUND_AT_DATE", "org_node_id not found at effective_date", nil)
+		}
+
+		row := InheritanceBreakRow{
+			HierarchyType: hierarchyType,
+			AttributeName: attributeName,
+			OrgNodeID:     in.OrgNodeID,
+			EffectiveDate: in.EffectiveDate,
+			EndDate:       endOfTime,
+		}
+		row.ID, err = s.repo.InsertInheritanceBreak(txCtx, tenantID, InheritanceBreakInsert{
+			HierarchyType: row.HierarchyType,
+			AttributeName: row.AttributeName,
+			OrgNodeID:     row.OrgNodeID,
+			EffectiveDate: row.EffectiveDate,
+			EndDate:       row.EndDate,
+		})
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "inheritance_break.created",
+			EntityType:           "org_inheritance_break",
+			EntityID:             row.ID,
+			EffectiveDate:        row.EffectiveDate,
+			EndDate:              row.EndDate,
+			NewValues:            inheritanceBreakAuditValues(row),
+			Operation:            "Create",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        row.EffectiveDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &InheritanceWriteResult{ID: row.ID, EffectiveDate: row.EffectiveDate, EndDate: row.EndDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+// EndInheritanceBreak lets the node inherit the attribute again from effective_date on.
+func (s *OrgService) EndInheritanceBreak(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in EndInheritanceInput) (*InheritanceWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if in.ID == uuid.Nil || in.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "id/effective_date are required", nil)
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*InheritanceWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockInheritanceBreakByID(txCtx, tenantID, in.ID)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		if !in.EffectiveDate.After(current.EffectiveDate) || in.EffectiveDate.After(current.EndDate) {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_INVALID_RESCIND_DATE", "effective_date must be within current window", nil)
+		}
+
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "inheritance_break.ended", "org_inheritance_break", current.ID, in.EffectiveDate, freeze, err, logrus.Fields{
+				"org_node_id":    current.OrgNodeID.String(),
+				"attribute_name": current.AttributeName,
+				"operation":      "Update",
+			})
+			return nil, err
+		}
+
+		endDate := truncateEndDateFromNewEffectiveDate(in.EffectiveDate)
+		if err := s.repo.UpdateInheritanceBreakEndDate(txCtx, tenantID, current.ID, endDate); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		updated := current
+		updated.EndDate = endDate
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "inheritance_break.ended",
+			EntityType:           "org_inheritance_break",
+			EntityID:             current.ID,
+			EffectiveDate:        current.EffectiveDate,
+			EndDate:              endDate,
+			OldValues:            inheritanceBreakAuditValues(current),
+			NewValues:            inheritanceBreakAuditValues(updated),
+			Operation:            "Update",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &InheritanceWriteResult{ID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: endDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+// DeleteInheritanceBreak removes a break that was recorded in error. The audit log keeps the
+// removed values.
+func (s *OrgService) DeleteInheritanceBreak(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, id uuid.UUID) (*InheritanceWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if id == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "id is required", nil)
+	}
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*InheritanceWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockInheritanceBreakByID(txCtx, tenantID, id)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, current.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "inheritance_break.rescinded", "org_inheritance_break", current.ID, current.EffectiveDate, freeze, err, logrus.Fields{
+				"org_node_id":    current.OrgNodeID.String(),
+				"attribute_name": current.AttributeName,
+				"operation":      "Rescind",
+			})
+			return nil, err
+		}
+
+		if err := s.repo.DeleteInheritanceBreak(txCtx, tenantID, current.ID); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "inheritance_break.rescinded",
+			EntityType:           "org_inheritance_break",
+			EntityID:             current.ID,
+			EffectiveDate:        current.EffectiveDate,
+			EndDate:              current.EndDate,
+			OldValues:            inheritanceBreakAuditValues(current),
+			Operation:            "Rescind",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        current.EffectiveDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &InheritanceWriteResult{ID: current.ID, EffectiveDate: current.EffectiveDate, EndDate: current.EndDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+func normalizeInheritanceListFilter(filter InheritanceRuleListFilter) (InheritanceRuleListFilter, error) {
+	filter.HierarchyType = strings.TrimSpace(filter.HierarchyType)
+	if filter.HierarchyType == "" {
+		filter.HierarchyType = HierarchyTypeOrgUnit
+	}
+	if !IsValidHierarchyType(filter.HierarchyType) {
+		return filter, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "hierarchy_type is invalid", nil)
+	}
+	if filter.AttributeName != nil {
+		name := strings.TrimSpace(strings.ToLower(*filter.AttributeName))
+		if name == "" {
+			filter.AttributeName = nil
+		} else {
+			filter.AttributeName = &name
+		}
+	}
+	if filter.AsOf != nil {
+		if filter.AsOf.IsZero() {
+			filter.AsOf = nil
+		} else {
+			asOf := normalizeValidDateUTC(*filter.AsOf)
+			filter.AsOf = &asOf
+		}
+	}
+	return filter, nil
+}
+
+// ListInheritanceRules returns rule slices ordered by attribute and start date; AsOf narrows the
+// list to the slices in force on that day.
+func (s *OrgService) ListInheritanceRules(ctx context.Context, tenantID uuid.UUID, filter InheritanceRuleListFilter) ([]InheritanceRuleRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	filter, err := normalizeInheritanceListFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) ([]InheritanceRuleRow, error) {
+		return s.repo.ListInheritanceRules(txCtx, tenantID, filter)
+	})
+}
+
+func (s *OrgService) ListInheritanceBreaks(ctx context.Context, tenantID uuid.UUID, filter InheritanceRuleListFilter) ([]InheritanceBreakRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	filter, err := normalizeInheritanceListFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) ([]InheritanceBreakRow, error) {
+		return s.repo.ListInheritanceBreaks(txCtx, tenantID, filter)
+	})
+}
+
+type InheritancePreviewInput struct {
+	HierarchyType string
+	EffectiveDate time.Time
+	// Rules, when non-nil, replaces the rules and breaks in force on EffectiveDate, so a change
+	// can be checked before it is saved.
+	Rules []AttributeInheritanceRule
+}
+
+type InheritancePreviewNode struct {
+	HierarchyNode
+	Attributes         OrgNodeAttributes       `json:"attributes"`
+	ResolvedAttributes OrgNodeAttributes       `json:"resolved_attributes"`
+	ResolvedSources    OrgNodeAttributeSources `json:"resolved_sources"`
+	// Changed lists the attributes whose resolved value differs from the one the stored rules give.
+	Changed []string `json:"changed,omitempty"`
+}
+
+type InheritancePreview struct {
+	HierarchyType  string                   `json:"hierarchy_type"`
+	EffectiveDate  time.Time                `json:"-"`
+	RuleAttributes []string                 `json:"rule_attributes"`
+	Nodes          []InheritancePreviewNode `json:"nodes"`
+}
+
+// PreviewInheritance resolves every node's attributes as of a date, either with the stored rules
+// or with a proposed rule set. It bypasses the resolver cache and works while the resolver is
+// disabled, so rules can be set up before ORG_INHERITANCE_ENABLED is switched on.
+func (s *OrgService) PreviewInheritance(ctx context.Context, tenantID uuid.UUID, in InheritancePreviewInput) (*InheritancePreview, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	hierarchyType := strings.TrimSpace(in.HierarchyType)
+	if hierarchyType == "" {
+		hierarchyType = HierarchyTypeOrgUnit
+	}
+	if !IsValidHierarchyType(hierarchyType) {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "hierarchy_type is invalid", nil)
+	}
+	asOf := in.EffectiveDate
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	asOf = normalizeValidDateUTC(asOf)
+
+	var proposed map[string]AttributeInheritanceRule
+	var proposedAttributes []string
+	if in.Rules != nil {
+		seen := make(map[string]struct{}, len(in.Rules))
+		for _, r := range in.Rules {
+			_, name, err := normalizeInheritanceTarget(hierarchyType, r.AttributeName)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := seen[name]; ok {
+				return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "attribute_name is duplicated", nil)
+			}
+			seen[name] = struct{}{}
+		}
+		proposed, proposedAttributes = inheritanceRulesByAttribute(in.Rules)
+	}
+
+	return inTx(ctx, tenantID, func(txCtx context.Context) (*InheritancePreview, error) {
+		nodes, err := s.repo.ListHierarchyAsOf(txCtx, tenantID, hierarchyType, asOf)
+		if err != nil {
+			return nil, err
+		}
+		ids := make([]uuid.UUID, 0, len(nodes))
+		for _, n := range nodes {
+			ids = append(ids, n.ID)
+		}
+		explicit, err := s.repo.ListNodeAttributesAsOf(txCtx, tenantID, ids, asOf)
+		if err != nil {
+			return nil, err
+		}
+		rules, err := s.repo.ListAttributeInheritanceRulesAsOf(txCtx, tenantID, hierarchyType, asOf)
+		if err != nil {
+			return nil, err
+		}
+		stored, storedAttributes := inheritanceRulesByAttribute(rules)
+		current, currentSources := resolveOrgAttributes(nodes, explicit, stored)
+
+		out := &InheritancePreview{
+			HierarchyType:  hierarchyType,
+			EffectiveDate:  asOf,
+			RuleAttributes: storedAttributes,
+			Nodes:          make([]InheritancePreviewNode, 0, len(nodes)),
+		}
+		resolved, sources := current, currentSources
+		if proposed != nil {
+			out.RuleAttributes = proposedAttributes
+			resolved, sources = resolveOrgAttributes(nodes, explicit, proposed)
+		}
+		for _, n := range nodes {
+			out.Nodes = append(out.Nodes, InheritancePreviewNode{
+				HierarchyNode:      n,
+				Attributes:         explicit[n.ID],
+				ResolvedAttributes: resolved[n.ID],
+				ResolvedSources:    sources[n.ID],
+				Changed:            changedOrgAttributes(current[n.ID], resolved[n.ID]),
+			})
+		}
+		return out, nil
+	})
+}
+
+func samePtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// changedOrgAttributes lists the attributes whose values differ between a and b.
+func changedOrgAttributes(a, b OrgNodeAttributes) []string {
+	var out []string
+	if !samePtr(a.LegalEntityID, b.LegalEntityID) {
+		out = append(out, "legal_entity_id")
+	}
+	if !samePtr(a.CompanyCode, b.CompanyCode) {
+		out = append(out, "company_code")
+	}
+	if !samePtr(a.LocationID, b.LocationID) {
+		out = append(out, "location_id")
+	}
+	if !samePtr(a.ManagerUserID, b.ManagerUserID) {
+		out = append(out, "manager_user_id")
+	}
+	codes := make(map[string]struct{}, len(a.Custom)+len(b.Custom))
+	for code := range a.Custom {
+		codes[code] = struct{}{}
+	}
+	for code := range b.Custom {
+		codes[code] = struct{}{}
+	}
+	custom := make([]string, 0, len(codes))
+	for code := range codes {
+		av, aok := a.Custom[code]
+		bv, bok := b.Custom[code]
+		if aok != bok || !reflect.DeepEqual(av, bv) {
+			custom = append(custom, CustomAttributePrefix+code)
+		}
+	}
+	sort.Strings(custom)
+	return append(out, custom...)
+}
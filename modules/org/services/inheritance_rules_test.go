@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInheritanceWrites_ValidateBeforeTx(t *testing.T) {
+	svc := &OrgService{}
+	tenantID := uuid.New()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name string
+		call func() error
+		code string
+	}{
+		{name: "rule unknown attribute", code: "ORG_UNKNOWN_ATTRIBUTE", call: func() error {
+			_, err := svc.SetInheritanceRule(context.Background(), tenantID, "", uuid.New(), SetInheritanceRuleInput{AttributeName: "cost_center", EffectiveDate: from})
+			return err
+		}},
+		{name: "rule invalid custom code", code: "ORG_UNKNOWN_ATTRIBUTE", call: func() error {
+			_, err := svc.SetInheritanceRule(context.Background(), tenantID, "", uuid.New(), SetInheritanceRuleInput{AttributeName: "custom.Bad-Code", EffectiveDate: from})
+			return err
+		}},
+		{name: "rule invalid hierarchy", code: "ORG_INVALID_BODY", call: func() error {
+			_, err := svc.SetInheritanceRule(context.Background(), tenantID, "", uuid.New(), SetInheritanceRuleInput{HierarchyType: "Matrix", AttributeName: "company_code", EffectiveDate: from})
+			return err
+		}},
+		{name: "rule missing date", code: "ORG_INVALID_BODY", call: func() error {
+			_, err := svc.SetInheritanceRule(context.Background(), tenantID, "", uuid.New(), SetInheritanceRuleInput{AttributeName: "company_code"})
+			return err
+		}},
+		{name: "end missing date", code: "ORG_INVALID_BODY", call: func() error {
+			_, err := svc.EndInheritanceRule(context.Background(), tenantID, "", uuid.New(), EndInheritanceInput{ID: uuid.New()})
+			return err
+		}},
+		{name: "break missing node", code: "ORG_INVALID_BODY", call: func() error {
+			_, err := svc.CreateInheritanceBreak(context.Background(), tenantID, "", uuid.New(), CreateInheritanceBreakInput{AttributeName: "location_id", EffectiveDate: from})
+			return err
+		}},
+		{name: "preview duplicate rule", code: "ORG_INVALID_BODY", call: func() error {
+			_, err := svc.PreviewInheritance(context.Background(), tenantID, InheritancePreviewInput{
+				EffectiveDate: from,
+				Rules: []AttributeInheritanceRule{
+					{AttributeName: "company_code"},
+					{AttributeName: "company_code", CanOverride: true},
+				},
+			})
+			return err
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call()
+			var svcErr *ServiceError
+			require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+			require.Equal(t, tc.code, svcErr.Code)
+		})
+	}
+}
+
+func TestChangedOrgAttributes(t *testing.T) {
+	locationID := uuid.New()
+	a := OrgNodeAttributes{CompanyCode: strPtr("ACME"), LocationID: &locationID}
+	b := OrgNodeAttributes{CompanyCode: strPtr("ACME")}
+
+	require.Empty(t, changedOrgAttributes(a, a))
+	require.Equal(t, []string{"location_id"}, changedOrgAttributes(a, b))
+
+	b.CompanyCode = strPtr("FOO")
+	require.Equal(t, []string{"company_code", "location_id"}, changedOrgAttributes(a, b))
+
+	a.Custom = map[string]any{"region": "north", "remote": true}
+	b = OrgNodeAttributes{CompanyCode: a.CompanyCode, LocationID: &locationID, Custom: map[string]any{"region": "south", "remote": true, "grade": 3.0}}
+	require.Equal(t, []string{"custom.grade", "custom.region"}, changedOrgAttributes(a, b))
+}
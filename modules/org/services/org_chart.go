@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrgChartLayout string
+
+const (
+	OrgChartLayoutTopDown   OrgChartLayout = "top_down"
+	OrgChartLayoutLeftRight OrgChartLayout = "left_right"
+	OrgChartLayoutCompact   OrgChartLayout = "compact"
+)
+
+func ParseOrgChartLayout(v string) (OrgChartLayout, bool) {
+	switch l := OrgChartLayout(strings.TrimSpace(strings.ToLower(v))); l {
+	case "":
+		return OrgChartLayoutTopDown, true
+	case OrgChartLayoutTopDown, OrgChartLayoutLeftRight, OrgChartLayoutCompact:
+		return l, true
+	default:
+		return "", false
+	}
+}
+
+// orgChartMaxNodes bounds a rendered chart; beyond this the boxes are unreadable on one page
+// and callers should narrow the subtree (root_node_id/max_depth) instead.
+const orgChartMaxNodes = 1000
+
+type OrgChartParams struct {
+	HierarchyType string
+	AsOf          time.Time
+	RootNodeID    *uuid.UUID
+	MaxDepth      *int
+	Layout        OrgChartLayout
+	IncludePhotos bool
+}
+
+// OrgChartNodeDetails is what a chart box shows beyond the exported node: the node manager
+// and direct position counts as of the chart date. The manager is a core user, so
+// ManagerName and ManagerPhotoPath (the avatar's uploads path) are left for the caller to
+// resolve from ManagerUserID.
+type OrgChartNodeDetails struct {
+	DisplayOrder     int
+	ManagerUserID    *int64
+	ManagerName      string
+	ManagerPhotoPath string
+	PositionsTotal   int
+	PositionsFilled  int
+}
+
+type OrgChartBox struct {
+	ID       uuid.UUID
+	ParentID *uuid.UUID
+	Code     string
+	Name     string
+	Depth    int
+	OrgChartNodeDetails
+	Children []*OrgChartBox
+}
+
+type OrgChart struct {
+	TenantID      uuid.UUID
+	HierarchyType string
+	EffectiveDate time.Time
+	Layout        OrgChartLayout
+	IncludePhotos bool
+	Root          *OrgChartBox
+	NodesCount    int
+}
+
+// BuildOrgChart loads a subtree through ExportHierarchy (the data behind /hierarchies:export),
+// decorates every node with its manager and position counts, and returns it as a tree ordered
+// by display_order then name. Rendering is left to the presentation layer.
+func (s *OrgService) BuildOrgChart(ctx context.Context, tenantID uuid.UUID, params OrgChartParams) (*OrgChart, error) {
+	hierarchyType, ok := normalizeHierarchyType(params.HierarchyType)
+	if !ok {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "type is invalid", nil)
+	}
+	layout := params.Layout
+	if layout == "" {
+		layout = OrgChartLayoutTopDown
+	}
+	asOf := params.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+
+	nodes := make([]HierarchyExportNode, 0, 64)
+	var rootID uuid.UUID
+	var afterID *uuid.UUID
+	for {
+		res, err := s.ExportHierarchy(ctx, tenantID, hierarchyType, asOf, params.RootNodeID, params.MaxDepth, false, false, false, orgChartMaxNodes+1, afterID)
+		if err != nil {
+			return nil, err
+		}
+		rootID = res.RootNodeID
+		nodes = append(nodes, res.Nodes...)
+		if len(nodes) > orgChartMaxNodes {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_CHART_TOO_LARGE", "subtree has too many nodes for a chart; narrow root_node_id or max_depth", nil)
+		}
+		if res.NextCursorID == nil {
+			break
+		}
+		afterID = res.NextCursorID
+	}
+
+	nodeIDs := make([]uuid.UUID, 0, len(nodes))
+	for _, n := range nodes {
+		nodeIDs = append(nodeIDs, n.ID)
+	}
+	details, err := inTx(ctx, tenantID, func(txCtx context.Context) (map[uuid.UUID]OrgChartNodeDetails, error) {
+		return s.repo.ListOrgChartNodeDetailsAsOf(txCtx, tenantID, nodeIDs, asOf)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	boxes := make(map[uuid.UUID]*OrgChartBox, len(nodes))
+	for _, n := range nodes {
+		boxes[n.ID] = &OrgChartBox{
+			ID:                  n.ID,
+			ParentID:            n.ParentID,
+			Code:                n.Code,
+			Name:                n.Name,
+			Depth:               n.Depth,
+			OrgChartNodeDetails: details[n.ID],
+		}
+	}
+	root, ok := boxes[rootID]
+	if !ok {
+		return nil, newServiceError(http.StatusInternalServerError, "ORG_INTERNAL", "chart root missing from export", nil)
+	}
+	for _, n := range nodes {
+		if n.ID == rootID || n.ParentID == nil {
+			continue
+		}
+		if parent, ok := boxes[*n.ParentID]; ok {
+			parent.Children = append(parent.Children, boxes[n.ID])
+		}
+	}
+	for _, b := range boxes {
+		sort.SliceStable(b.Children, func(i, j int) bool {
+			a, c := b.Children[i], b.Children[j]
+			if a.DisplayOrder != c.DisplayOrder {
+				return a.DisplayOrder < c.DisplayOrder
+			}
+			if a.Name != c.Name {
+				return a.Name < c.Name
+			}
+			return a.ID.String() < c.ID.String()
+		})
+	}
+
+	return &OrgChart{
+		TenantID:      tenantID,
+		HierarchyType: hierarchyType,
+		EffectiveDate: asOf.UTC(),
+		Layout:        layout,
+		IncludePhotos: params.IncludePhotos,
+		Root:          root,
+		NodesCount:    len(nodes),
+	}, nil
+}
@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func TestOrgCustomAttributes_DefineValidateAndInherit(t *testing.T) {
+	ctx, _, tenantID, rootNodeID, asOf, svc := setupOrg053DB(t)
+	jobProfileID := seedOrg053JobProfile(t, ctx, tenantID, svc)
+	initiatorID := uuid.New()
+
+	_, err := svc.SetAttributeDefinition(ctx, tenantID, "req-ca-1", initiatorID, orgsvc.SetAttributeDefinitionInput{
+		EntityType:    orgsvc.CustomAttributeEntityNode,
+		Code:          "region",
+		Label:         "Region",
+		ValueType:     orgsvc.CustomAttributeTypeSelect,
+		Options:       []string{"north", "south"},
+		EffectiveDate: asOf,
+	})
+	require.NoError(t, err)
+	minGrade := 1.0
+	_, err = svc.SetAttributeDefinition(ctx, tenantID, "req-ca-2", initiatorID, orgsvc.SetAttributeDefinitionInput{
+		EntityType:    orgsvc.CustomAttributeEntityPosition,
+		Code:          "grade",
+		ValueType:     orgsvc.CustomAttributeTypeNumber,
+		Required:      true,
+		MinValue:      &minGrade,
+		EffectiveDate: asOf,
+	})
+	require.NoError(t, err)
+
+	later := asOf.AddDate(0, 1, 0)
+	var svcErr *orgsvc.ServiceError
+	_, err = svc.UpdateNode(ctx, tenantID, "req-ca-3", initiatorID, orgsvc.UpdateNodeInput{
+		NodeID:           rootNodeID,
+		EffectiveDate:    later,
+		CustomAttributes: map[string]any{"region": "east"},
+	})
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_INVALID_CUSTOM_ATTRIBUTE", svcErr.Code)
+
+	_, err = svc.UpdateNode(ctx, tenantID, "req-ca-4", initiatorID, orgsvc.UpdateNodeInput{
+		NodeID:           rootNodeID,
+		EffectiveDate:    later,
+		CustomAttributes: map[string]any{"region": "north"},
+	})
+	require.NoError(t, err)
+	root, err := svc.GetNodeAsOf(ctx, tenantID, rootNodeID, later)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"region": "north"}, root.Slice.CustomAttributes)
+	root, err = svc.GetNodeAsOf(ctx, tenantID, rootNodeID, asOf)
+	require.NoError(t, err)
+	require.Empty(t, root.Slice.CustomAttributes)
+
+	base := orgsvc.CreatePositionInput{
+		Code:           "POS-CA",
+		OrgNodeID:      rootNodeID,
+		EffectiveDate:  asOf,
+		PositionType:   "regular",
+		EmploymentType: "full_time",
+		JobProfileID:   jobProfileID,
+		JobLevelCode:   ptr("L1"),
+		CapacityFTE:    1.0,
+		ReasonCode:     "create",
+	}
+	_, err = svc.CreatePosition(ctx, tenantID, "req-ca-6", initiatorID, base)
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_CUSTOM_ATTRIBUTE_REQUIRED", svcErr.Code)
+
+	base.CustomAttributes = map[string]any{"grade": "3"}
+	pos, err := svc.CreatePosition(ctx, tenantID, "req-ca-7", initiatorID, base)
+	require.NoError(t, err)
+	got, _, err := svc.GetPosition(ctx, tenantID, pos.PositionID, &asOf)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"grade": 3.0}, got.CustomAttributes)
+
+	_, err = svc.SetInheritanceRule(ctx, tenantID, "req-ca-8", initiatorID, orgsvc.SetInheritanceRuleInput{
+		AttributeName: "custom.undefined",
+		EffectiveDate: asOf,
+	})
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_UNKNOWN_ATTRIBUTE", svcErr.Code)
+
+	_, err = svc.SetInheritanceRule(ctx, tenantID, "req-ca-9", initiatorID, orgsvc.SetInheritanceRuleInput{
+		AttributeName: "custom.region",
+		EffectiveDate: asOf,
+	})
+	require.NoError(t, err)
+	preview, err := svc.PreviewInheritance(ctx, tenantID, orgsvc.InheritancePreviewInput{EffectiveDate: later})
+	require.NoError(t, err)
+	for _, n := range preview.Nodes {
+		require.Equal(t, "north", n.ResolvedAttributes.Custom["region"], "node %s", n.Code)
+		require.Equal(t, rootNodeID, n.ResolvedSources.Custom["region"], "node %s", n.Code)
+	}
+
+	snapshot, err := svc.GetSnapshot(ctx, tenantID, later, []string{"nodes"}, 0, "")
+	require.NoError(t, err)
+	found := false
+	for _, item := range snapshot.Items {
+		if item.EntityID == rootNodeID {
+			require.Contains(t, string(item.NewValues), `"custom_attributes": {"region": "north"}`)
+			found = true
+		}
+	}
+	require.True(t, found)
+}
@@ -0,0 +1,81 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func TestOrgDelegations_ActingManagerExpiresAndIsPreviewed(t *testing.T) {
+	ctx, pool, tenantID, rootNodeID, asOf, svc := setupOrg053DB(t)
+	initiatorID := uuid.New()
+	delegateSubjectID := uuid.New()
+	lastDay := asOf.AddDate(0, 0, 13)
+
+	created, err := svc.CreateDelegation(ctx, tenantID, "req-dlg-1", initiatorID, orgsvc.CreateDelegationInput{
+		DelegationType:    orgsvc.DelegationTypeManager,
+		OrgNodeID:         rootNodeID,
+		DelegateUserID:    42,
+		DelegateSubjectID: delegateSubjectID,
+		EffectiveDate:     asOf,
+		EndDate:           lastDay,
+		ReasonNote:        ptr("annual leave"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, lastDay, created.EndDate)
+
+	_, err = svc.CreateDelegation(ctx, tenantID, "req-dlg-2", initiatorID, orgsvc.CreateDelegationInput{
+		DelegationType:    orgsvc.DelegationTypeManager,
+		OrgNodeID:         rootNodeID,
+		DelegateUserID:    43,
+		DelegateSubjectID: uuid.New(),
+		EffectiveDate:     lastDay,
+		EndDate:           lastDay.AddDate(0, 0, 7),
+	})
+	var svcErr *orgsvc.ServiceError
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_DELEGATION_OVERLAP", svcErr.Code)
+
+	acting, err := svc.ActingManagerAsOf(ctx, tenantID, rootNodeID, lastDay)
+	require.NoError(t, err)
+	require.NotNil(t, acting)
+	require.Equal(t, int64(42), acting.DelegateUserID)
+
+	acting, err = svc.ActingManagerAsOf(ctx, tenantID, rootNodeID, lastDay.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Nil(t, acting)
+
+	preview, err := svc.PermissionPreview(ctx, tenantID, orgsvc.PermissionPreviewInput{
+		OrgNodeID:          rootNodeID,
+		EffectiveDate:      asOf,
+		IncludeDelegations: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, preview.Delegations, 1)
+	require.Equal(t, created.DelegationID, preview.Delegations[0].DelegationID)
+	require.Equal(t, delegateSubjectID, preview.Delegations[0].DelegateSubjectID)
+
+	returned := asOf.AddDate(0, 0, 4)
+	ended, err := svc.EndDelegation(ctx, tenantID, "req-dlg-3", initiatorID, orgsvc.EndDelegationInput{
+		DelegationID: created.DelegationID,
+		EndDate:      returned,
+	})
+	require.NoError(t, err)
+	require.Equal(t, returned, ended.EndDate)
+
+	acting, err = svc.ActingManagerAsOf(ctx, tenantID, rootNodeID, returned.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	require.Nil(t, acting)
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, `
+SELECT count(*)
+FROM org_audit_logs
+WHERE tenant_id=$1 AND entity_type='org_delegation' AND entity_id=$2
+`, tenantID, created.DelegationID).Scan(&count))
+	require.Equal(t, 2, count)
+}
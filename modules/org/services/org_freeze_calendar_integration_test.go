@@ -0,0 +1,100 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func TestOrgFreezeCalendar_LocksPeriodAndAllowsAuditedOverride(t *testing.T) {
+	ctx, pool, tenantID, rootNodeID, asOf, svc := setupOrg053DB(t)
+	initiatorID := uuid.New()
+
+	_, err := pool.Exec(ctx, `UPDATE org_settings SET freeze_mode='enforce', freeze_grace_days=3 WHERE tenant_id=$1`, tenantID)
+	require.NoError(t, err)
+
+	closed, err := svc.CreateFreezePeriod(ctx, tenantID, "req-fc-1", initiatorID, orgsvc.FreezePeriodInput{
+		Label:       "2025-01",
+		PeriodStart: asOf,
+		PeriodEnd:   asOf.AddDate(0, 1, -1),
+		LocksAt:     time.Now().UTC().Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	open, err := svc.CreateFreezePeriod(ctx, tenantID, "req-fc-2", initiatorID, orgsvc.FreezePeriodInput{
+		Label:       "2025-02",
+		PeriodStart: asOf.AddDate(0, 1, 0),
+		PeriodEnd:   asOf.AddDate(0, 2, -1),
+		LocksAt:     time.Now().UTC().AddDate(1, 0, 0),
+	})
+	require.NoError(t, err)
+
+	var svcErr *orgsvc.ServiceError
+	_, err = svc.CreateFreezePeriod(ctx, tenantID, "req-fc-3", initiatorID, orgsvc.FreezePeriodInput{
+		Label:       "overlap",
+		PeriodStart: asOf.AddDate(0, 0, 20),
+		PeriodEnd:   asOf.AddDate(0, 1, 10),
+		LocksAt:     time.Now().UTC(),
+	})
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_FREEZE_PERIOD_OVERLAP", svcErr.Code)
+
+	name := "Renamed in January"
+	_, err = svc.UpdateNode(ctx, tenantID, "req-fc-4", initiatorID, orgsvc.UpdateNodeInput{
+		NodeID:        rootNodeID,
+		EffectiveDate: asOf.AddDate(0, 0, 10),
+		Name:          &name,
+	})
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_FROZEN_WINDOW", svcErr.Code)
+	require.Equal(t, closed.ID.String(), svcErr.Meta["freeze_period_id"])
+	require.Equal(t, "2025-01", svcErr.Meta["freeze_period_label"])
+
+	// February has not locked yet, even though the rolling grace-day cutoff has long passed.
+	name = "Renamed in February"
+	_, err = svc.UpdateNode(ctx, tenantID, "req-fc-5", initiatorID, orgsvc.UpdateNodeInput{
+		NodeID:        rootNodeID,
+		EffectiveDate: open.PeriodStart.AddDate(0, 0, 3),
+		Name:          &name,
+	})
+	require.NoError(t, err)
+
+	name = "Renamed in January"
+	_, err = svc.UpdateNode(orgsvc.WithFreezeOverride(ctx, "payroll correction"), tenantID, "req-fc-6", initiatorID, orgsvc.UpdateNodeInput{
+		NodeID:        rootNodeID,
+		EffectiveDate: asOf.AddDate(0, 0, 10),
+		Name:          &name,
+	})
+	require.NoError(t, err)
+
+	var reason string
+	require.NoError(t, pool.QueryRow(ctx, `
+SELECT meta->>'freeze_override_reason'
+FROM org_audit_logs
+WHERE tenant_id=$1 AND request_id='req-fc-6'
+`, tenantID).Scan(&reason))
+	require.Equal(t, "payroll correction", reason)
+
+	_, err = svc.DeleteFreezePeriod(ctx, tenantID, "req-fc-7", initiatorID, closed.ID)
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_FROZEN_WINDOW", svcErr.Code)
+
+	_, err = svc.DeleteFreezePeriod(orgsvc.WithFreezeOverride(ctx, "calendar entered in error"), tenantID, "req-fc-8", initiatorID, closed.ID)
+	require.NoError(t, err)
+	periods, err := svc.ListFreezePeriods(ctx, tenantID)
+	require.NoError(t, err)
+	require.Len(t, periods, 1)
+	require.Equal(t, open.ID, periods[0].ID)
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, `
+SELECT count(*)
+FROM org_audit_logs
+WHERE tenant_id=$1 AND entity_type='org_freeze_period'
+`, tenantID).Scan(&count))
+	require.Equal(t, 3, count)
+}
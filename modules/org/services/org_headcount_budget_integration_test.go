@@ -0,0 +1,106 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func TestOrgHeadcountBudget_EnforceBlocksOverBudgetPosition(t *testing.T) {
+	ctx, pool, tenantID, rootNodeID, asOf, svc := setupOrg053DB(t)
+	jobProfileID := seedOrg053JobProfile(t, ctx, tenantID, svc)
+	initiatorID := uuid.New()
+
+	_, err := svc.SetHeadcountBudget(ctx, tenantID, orgsvc.SetHeadcountBudgetInput{
+		OrgNodeID:        rootNodeID,
+		PlannedHeadcount: 1,
+		PlannedFTE:       1,
+		EffectiveDate:    asOf,
+	})
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `UPDATE org_settings SET position_budget_validation_mode='enforce' WHERE tenant_id=$1`, tenantID)
+	require.NoError(t, err)
+
+	in := orgsvc.CreatePositionInput{
+		Code:           "BUD-001",
+		OrgNodeID:      rootNodeID,
+		EffectiveDate:  asOf,
+		PositionType:   "regular",
+		EmploymentType: "full_time",
+		JobProfileID:   jobProfileID,
+		JobLevelCode:   ptr("L1"),
+		CapacityFTE:    1.0,
+		ReasonCode:     "create",
+	}
+	_, err = svc.CreatePosition(ctx, tenantID, "req-budget-1", initiatorID, in)
+	require.NoError(t, err)
+
+	in.Code = "BUD-002"
+	_, err = svc.CreatePosition(ctx, tenantID, "req-budget-2", initiatorID, in)
+	var svcErr *orgsvc.ServiceError
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_POSITION_OVER_BUDGET", svcErr.Code)
+
+	_, err = pool.Exec(ctx, `UPDATE org_settings SET position_budget_validation_mode='shadow' WHERE tenant_id=$1`, tenantID)
+	require.NoError(t, err)
+	_, err = svc.CreatePosition(ctx, tenantID, "req-budget-3", initiatorID, in)
+	require.NoError(t, err)
+
+	var mode, code string
+	require.NoError(t, pool.QueryRow(ctx, `
+SELECT meta->>'position_budget_validation_mode', meta->>'position_budget_validation_error_code'
+FROM org_audit_logs
+WHERE tenant_id=$1 AND request_id='req-budget-3'
+`, tenantID).Scan(&mode, &code))
+	require.Equal(t, "shadow", mode)
+	require.Equal(t, "ORG_POSITION_OVER_BUDGET", code)
+
+	report, err := svc.GetHeadcountBudgetVariance(ctx, tenantID, orgsvc.HeadcountBudgetVarianceInput{EffectiveDate: asOf})
+	require.NoError(t, err)
+	require.NotEmpty(t, report.Nodes)
+	require.Equal(t, rootNodeID, report.Nodes[0].ID)
+	require.Equal(t, 2, report.Nodes[0].Total.PositionsTotal)
+	require.Equal(t, 2, report.Nodes[0].Total.VacantPositions)
+	require.Equal(t, -1, report.Nodes[0].Total.HeadcountVariance)
+	require.True(t, report.Nodes[0].Total.OverBudget)
+}
+
+func TestOrgHeadcountBudget_SetSplitsAndEndCloses(t *testing.T) {
+	ctx, _, tenantID, rootNodeID, asOf, svc := setupOrg053DB(t)
+
+	first, err := svc.SetHeadcountBudget(ctx, tenantID, orgsvc.SetHeadcountBudgetInput{
+		OrgNodeID:        rootNodeID,
+		PlannedHeadcount: 5,
+		PlannedFTE:       5,
+		EffectiveDate:    asOf,
+	})
+	require.NoError(t, err)
+
+	mid := time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC)
+	second, err := svc.SetHeadcountBudget(ctx, tenantID, orgsvc.SetHeadcountBudgetInput{
+		OrgNodeID:        rootNodeID,
+		PlannedHeadcount: 8,
+		PlannedFTE:       7.5,
+		EffectiveDate:    mid,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, first.ID, second.ID)
+
+	rows, err := svc.ListHeadcountBudgets(ctx, tenantID, orgsvc.HeadcountBudgetListFilter{OrgNodeID: &rootNodeID})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, mid.AddDate(0, 0, -1), rows[0].EndDate)
+	require.Equal(t, 8, rows[1].PlannedHeadcount)
+
+	ended, err := svc.EndHeadcountBudget(ctx, tenantID, orgsvc.EndHeadcountBudgetInput{
+		ID:            second.ID,
+		EffectiveDate: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC), ended.EndDate)
+}
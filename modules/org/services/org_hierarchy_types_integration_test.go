@@ -0,0 +1,179 @@
+package services_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/itf"
+)
+
+func TestOrgHierarchyTypes_CostCenterTreeAndStaffingRollup(t *testing.T) {
+	ctx := context.Background()
+	isCI := strings.TrimSpace(getenvDefault("CI", "")) != "" || strings.EqualFold(strings.TrimSpace(getenvDefault("GITHUB_ACTIONS", "")), "true")
+
+	if !canDialPostgres(t) {
+		if isCI {
+			t.Fatalf("postgres is not reachable (DB_HOST/DB_PORT).")
+		}
+		t.Skip("postgres is not reachable; skipping org hierarchy types test")
+	}
+
+	dbName := t.Name()
+	if !safeCreateDB(t, dbName) {
+		return
+	}
+
+	pool := newPoolWithQueryTracer(t, itf.DbOpts(dbName), &queryCountTracer{})
+	t.Cleanup(pool.Close)
+	applyAllOrgMigrationsFor058(t, ctx, pool)
+	_, err := pool.Exec(ctx, readGooseUpSQL(t, filepath.Clean(filepath.Join("..", "..", "..", "migrations", "org", "20260109090000_org_hierarchy_types.sql"))))
+	require.NoError(t, err)
+
+	tenantID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	ensureTenant(t, ctx, pool, tenantID)
+	seedPerson(t, ctx, pool, tenantID, uuid.New(), "000123", "Test Person 000123")
+	seedPerson(t, ctx, pool, tenantID, uuid.New(), "000124", "Test Person 000124")
+	_, err = pool.Exec(ctx, `
+INSERT INTO org_settings (tenant_id, freeze_mode, freeze_grace_days)
+VALUES ($1,'disabled',0)
+ON CONFLICT (tenant_id) DO UPDATE SET freeze_mode=excluded.freeze_mode, freeze_grace_days=excluded.freeze_grace_days
+`, tenantID)
+	require.NoError(t, err)
+
+	asOf := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	repo := persistence.NewOrgRepository()
+	svc := orgsvc.NewOrgService(repo)
+	reqCtx := composables.WithPool(ctx, pool)
+	initiatorID := uuid.New()
+
+	createNode := func(in orgsvc.CreateNodeInput) (uuid.UUID, error) {
+		t.Helper()
+		in.EffectiveDate = asOf
+		res, err := svc.CreateNode(reqCtx, tenantID, "req-"+in.Code, initiatorID, in)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return res.NodeID, nil
+	}
+
+	// Each hierarchy has its own root.
+	lineRoot, err := createNode(orgsvc.CreateNodeInput{Code: "ROOT", Name: "Company"})
+	require.NoError(t, err)
+	ccRoot, err := createNode(orgsvc.CreateNodeInput{HierarchyType: orgsvc.HierarchyTypeCostCenter, Code: "CC", Name: "All cost centers"})
+	require.NoError(t, err)
+	ccOps, err := createNode(orgsvc.CreateNodeInput{HierarchyType: orgsvc.HierarchyTypeCostCenter, Code: "CC-OPS", Name: "Operations", ParentID: &ccRoot})
+	require.NoError(t, err)
+
+	_, err = createNode(orgsvc.CreateNodeInput{HierarchyType: orgsvc.HierarchyTypeCostCenter, Code: "CC-2", Name: "Second root"})
+	var svcErr *orgsvc.ServiceError
+	require.ErrorAs(t, err, &svcErr)
+	require.Equal(t, "ORG_OVERLAP", svcErr.Code)
+
+	// Edges never cross hierarchies.
+	_, err = createNode(orgsvc.CreateNodeInput{HierarchyType: orgsvc.HierarchyTypeCostCenter, Code: "CC-X", Name: "Cross", ParentID: &lineRoot})
+	require.ErrorAs(t, err, &svcErr)
+	require.Equal(t, "ORG_PARENT_NOT_FOUND", svcErr.Code)
+
+	lineNodes, _, err := svc.GetHierarchyAsOf(reqCtx, tenantID, orgsvc.HierarchyTypeOrgUnit, asOf)
+	require.NoError(t, err)
+	require.Len(t, lineNodes, 1)
+	ccNodes, _, err := svc.GetHierarchyAsOf(reqCtx, tenantID, orgsvc.HierarchyTypeCostCenter, asOf)
+	require.NoError(t, err)
+	require.Len(t, ccNodes, 2)
+
+	// Positions live in the line org and are attached to cost centers via memberships.
+	posA := uuid.New()
+	posB := uuid.New()
+	seedOrgPosition(t, ctx, pool, tenantID, lineRoot, posA, "POS-A", 1.0, asOf, endDate)
+	seedOrgPosition(t, ctx, pool, tenantID, lineRoot, posB, "POS-B", 1.0, asOf, endDate)
+
+	_, err = svc.CreateAssignment(reqCtx, tenantID, "req-a1", initiatorID, orgsvc.CreateAssignmentInput{
+		Pernr:          "000123",
+		EffectiveDate:  asOf,
+		AssignmentType: "primary",
+		AllocatedFTE:   1.0,
+		PositionID:     &posA,
+	})
+	require.NoError(t, err)
+	a2, err := svc.CreateAssignment(reqCtx, tenantID, "req-a2", initiatorID, orgsvc.CreateAssignmentInput{
+		Pernr:          "000124",
+		EffectiveDate:  asOf,
+		AssignmentType: "primary",
+		AllocatedFTE:   0.5,
+		PositionID:     &posB,
+	})
+	require.NoError(t, err)
+
+	setMembership := func(subjectType string, subjectID, nodeID uuid.UUID, effectiveDate time.Time) *orgsvc.SetHierarchyMembershipResult {
+		t.Helper()
+		res, err := svc.SetHierarchyMembership(reqCtx, tenantID, orgsvc.SetHierarchyMembershipInput{
+			SubjectType:   subjectType,
+			SubjectID:     subjectID,
+			HierarchyType: orgsvc.HierarchyTypeCostCenter,
+			OrgNodeID:     nodeID,
+			EffectiveDate: effectiveDate,
+		})
+		require.NoError(t, err)
+		return res
+	}
+	setMembership(orgsvc.HierarchyMembershipSubjectPosition, posA, ccOps, asOf)
+	setMembership(orgsvc.HierarchyMembershipSubjectPosition, posB, ccRoot, asOf)
+	// The second person is charged to operations even though their position sits at the top.
+	setMembership(orgsvc.HierarchyMembershipSubjectAssignment, a2.AssignmentID, ccOps, asOf)
+
+	_, err = svc.SetHierarchyMembership(reqCtx, tenantID, orgsvc.SetHierarchyMembershipInput{
+		SubjectType:   orgsvc.HierarchyMembershipSubjectPosition,
+		SubjectID:     posA,
+		HierarchyType: orgsvc.HierarchyTypeOrgUnit,
+		OrgNodeID:     lineRoot,
+		EffectiveDate: asOf,
+	})
+	require.ErrorAs(t, err, &svcErr)
+	require.Equal(t, 400, svcErr.Status)
+
+	report, err := svc.GetHierarchyStaffing(reqCtx, tenantID, orgsvc.HierarchyTypeCostCenter, asOf)
+	require.NoError(t, err)
+	byID := make(map[uuid.UUID]orgsvc.HierarchyStaffingNode, len(report.Nodes))
+	for _, n := range report.Nodes {
+		byID[n.ID] = n
+	}
+	require.Equal(t, 1, byID[ccOps].DirectPositions)
+	require.Equal(t, 2, byID[ccOps].DirectHeadcount)
+	require.InDelta(t, 1.5, byID[ccOps].DirectFTE, 0.0001)
+	require.Equal(t, 1, byID[ccRoot].DirectPositions)
+	require.Equal(t, 0, byID[ccRoot].DirectHeadcount)
+	require.Equal(t, 2, byID[ccRoot].TotalPositions)
+	require.Equal(t, 2, byID[ccRoot].TotalHeadcount)
+	require.InDelta(t, 1.5, byID[ccRoot].TotalFTE, 0.0001)
+
+	// Moving a position later splits its membership and keeps history.
+	moveDate := asOf.AddDate(0, 0, 10)
+	setMembership(orgsvc.HierarchyMembershipSubjectPosition, posA, ccRoot, moveDate)
+	subjectType := orgsvc.HierarchyMembershipSubjectPosition
+	rows, err := svc.ListHierarchyMemberships(reqCtx, tenantID, orgsvc.HierarchyMembershipListFilter{SubjectType: &subjectType, SubjectID: &posA})
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, ccOps, rows[0].OrgNodeID)
+	require.True(t, rows[0].EndDate.Equal(moveDate.AddDate(0, 0, -1)))
+	require.Equal(t, ccRoot, rows[1].OrgNodeID)
+
+	// A node that still anchors memberships cannot be rescinded.
+	_, err = svc.RescindNode(reqCtx, tenantID, "req-rescind", initiatorID, orgsvc.RescindNodeInput{
+		NodeID:        ccOps,
+		EffectiveDate: asOf.AddDate(0, 0, 5),
+		Reason:        "rescind",
+	})
+	require.ErrorAs(t, err, &svcErr)
+	require.Equal(t, "ORG_NODE_NOT_EMPTY", svcErr.Code)
+}
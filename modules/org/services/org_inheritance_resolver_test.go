@@ -111,6 +111,72 @@ func TestResolveOrgAttributes_NoRule_NoInheritance(t *testing.T) {
 	require.Nil(t, sources[childID].CompanyCode)
 }
 
+func TestResolveOrgAttributes_BreakStopsInheritanceForSubtree(t *testing.T) {
+	rootID := uuid.New()
+	midID := uuid.New()
+	leafID := uuid.New()
+
+	nodes := []HierarchyNode{
+		{ID: rootID, ParentID: nil},
+		{ID: midID, ParentID: &rootID},
+		{ID: leafID, ParentID: &midID},
+	}
+
+	explicit := map[uuid.UUID]OrgNodeAttributes{
+		rootID: {CompanyCode: strPtr("ACME")},
+	}
+	rules := map[string]AttributeInheritanceRule{
+		"company_code": {AttributeName: "company_code", CanOverride: false, BreakNodeIDs: []uuid.UUID{midID}},
+	}
+
+	resolved, sources := resolveOrgAttributes(nodes, explicit, rules)
+
+	require.Nil(t, resolved[midID].CompanyCode)
+	require.Nil(t, resolved[leafID].CompanyCode)
+
+	explicit[midID] = OrgNodeAttributes{CompanyCode: strPtr("FOO")}
+	resolved, sources = resolveOrgAttributes(nodes, explicit, rules)
+
+	require.Equal(t, "FOO", requirePtr(t, resolved[midID].CompanyCode))
+	require.Equal(t, midID, requireUUIDPtr(t, sources[midID].CompanyCode))
+	require.Equal(t, "FOO", requirePtr(t, resolved[leafID].CompanyCode))
+	require.Equal(t, midID, requireUUIDPtr(t, sources[leafID].CompanyCode))
+}
+
+func TestResolveOrgAttributes_CustomAttributes(t *testing.T) {
+	rootID := uuid.New()
+	midID := uuid.New()
+	leafID := uuid.New()
+
+	nodes := []HierarchyNode{
+		{ID: rootID, ParentID: nil},
+		{ID: midID, ParentID: &rootID},
+		{ID: leafID, ParentID: &midID},
+	}
+
+	explicit := map[uuid.UUID]OrgNodeAttributes{
+		rootID: {Custom: map[string]any{"region": "north", "remote": true}},
+		leafID: {Custom: map[string]any{"region": "south"}},
+	}
+	rules := map[string]AttributeInheritanceRule{
+		"custom.region": {AttributeName: "custom.region", CanOverride: true},
+	}
+
+	resolved, sources := resolveOrgAttributes(nodes, explicit, rules)
+
+	require.Equal(t, "north", resolved[midID].Custom["region"])
+	require.Equal(t, rootID, sources[midID].Custom["region"])
+	require.Equal(t, "south", resolved[leafID].Custom["region"])
+	require.Equal(t, leafID, sources[leafID].Custom["region"])
+	require.NotContains(t, resolved[midID].Custom, "remote")
+
+	rules["custom.region"] = AttributeInheritanceRule{AttributeName: "custom.region", CanOverride: false}
+	resolved, sources = resolveOrgAttributes(nodes, explicit, rules)
+
+	require.Equal(t, "north", resolved[leafID].Custom["region"])
+	require.Equal(t, rootID, sources[leafID].Custom["region"])
+}
+
 func strPtr(v string) *string { return &v }
 
 func requirePtr(tb testing.TB, v *string) string {
@@ -0,0 +1,112 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func TestOrgInheritanceRules_SetBreakAndPreview(t *testing.T) {
+	ctx, pool, tenantID, rootNodeID, asOf, svc := setupOrg053DB(t)
+	initiatorID := uuid.New()
+	midNodeID := buildPerfNodes(t, tenantID, 3, "deep", 53)[1].ID
+
+	_, err := pool.Exec(ctx, `
+UPDATE org_node_slices SET company_code='ACME'
+WHERE tenant_id=$1 AND org_node_id=$2
+`, tenantID, rootNodeID)
+	require.NoError(t, err)
+
+	created, err := svc.SetInheritanceRule(ctx, tenantID, "req-inh-1", initiatorID, orgsvc.SetInheritanceRuleInput{
+		AttributeName: "company_code",
+		EffectiveDate: asOf,
+	})
+	require.NoError(t, err)
+
+	preview, err := svc.PreviewInheritance(ctx, tenantID, orgsvc.InheritancePreviewInput{EffectiveDate: asOf})
+	require.NoError(t, err)
+	require.Equal(t, []string{"company_code"}, preview.RuleAttributes)
+	for _, n := range preview.Nodes {
+		require.NotNil(t, n.ResolvedAttributes.CompanyCode, "node %s", n.Code)
+		require.Equal(t, "ACME", *n.ResolvedAttributes.CompanyCode)
+		require.Empty(t, n.Changed)
+	}
+
+	// A proposed break is previewed without being stored.
+	preview, err = svc.PreviewInheritance(ctx, tenantID, orgsvc.InheritancePreviewInput{
+		EffectiveDate: asOf,
+		Rules: []orgsvc.AttributeInheritanceRule{
+			{AttributeName: "company_code", BreakNodeIDs: []uuid.UUID{midNodeID}},
+		},
+	})
+	require.NoError(t, err)
+	for _, n := range preview.Nodes {
+		if n.ID == rootNodeID {
+			require.Empty(t, n.Changed)
+			continue
+		}
+		require.Nil(t, n.ResolvedAttributes.CompanyCode, "node %s", n.Code)
+		require.Equal(t, []string{"company_code"}, n.Changed)
+	}
+
+	later := asOf.AddDate(0, 1, 0)
+	brk, err := svc.CreateInheritanceBreak(ctx, tenantID, "req-inh-2", initiatorID, orgsvc.CreateInheritanceBreakInput{
+		AttributeName: "company_code",
+		OrgNodeID:     midNodeID,
+		EffectiveDate: later,
+	})
+	require.NoError(t, err)
+
+	_, err = svc.CreateInheritanceBreak(ctx, tenantID, "req-inh-3", initiatorID, orgsvc.CreateInheritanceBreakInput{
+		AttributeName: "company_code",
+		OrgNodeID:     midNodeID,
+		EffectiveDate: later.AddDate(0, 0, 5),
+	})
+	var svcErr *orgsvc.ServiceError
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_INHERITANCE_BREAK_OVERLAP", svcErr.Code)
+
+	preview, err = svc.PreviewInheritance(ctx, tenantID, orgsvc.InheritancePreviewInput{EffectiveDate: later})
+	require.NoError(t, err)
+	for _, n := range preview.Nodes {
+		if n.ID == rootNodeID {
+			require.NotNil(t, n.ResolvedAttributes.CompanyCode)
+			continue
+		}
+		require.Nil(t, n.ResolvedAttributes.CompanyCode, "node %s", n.Code)
+	}
+
+	// Splitting the rule keeps the earlier window and allows overrides from the new date.
+	updated, err := svc.SetInheritanceRule(ctx, tenantID, "req-inh-4", initiatorID, orgsvc.SetInheritanceRuleInput{
+		AttributeName: "company_code",
+		CanOverride:   true,
+		EffectiveDate: later,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, created.ID, updated.ID)
+
+	rules, err := svc.ListInheritanceRules(ctx, tenantID, orgsvc.InheritanceRuleListFilter{})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	require.Equal(t, later.AddDate(0, 0, -1), rules[0].EndDate)
+	require.False(t, rules[0].CanOverride)
+	require.True(t, rules[1].CanOverride)
+
+	_, err = svc.DeleteInheritanceBreak(ctx, tenantID, "req-inh-5", initiatorID, brk.ID)
+	require.NoError(t, err)
+	breaks, err := svc.ListInheritanceBreaks(ctx, tenantID, orgsvc.InheritanceRuleListFilter{})
+	require.NoError(t, err)
+	require.Empty(t, breaks)
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, `
+SELECT count(*)
+FROM org_audit_logs
+WHERE tenant_id=$1 AND entity_type IN ('org_inheritance_rule','org_inheritance_break')
+`, tenantID).Scan(&count))
+	require.Equal(t, 4, count)
+}
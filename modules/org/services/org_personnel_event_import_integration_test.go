@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/itf"
+)
+
+func TestOrgPersonnelEventImport_PreviewThenApply(t *testing.T) {
+	ctx := context.Background()
+	isCI := strings.TrimSpace(getenvDefault("CI", "")) != "" || strings.EqualFold(strings.TrimSpace(getenvDefault("GITHUB_ACTIONS", "")), "true")
+
+	if !canDialPostgres(t) {
+		if isCI {
+			t.Fatalf("postgres is not reachable (DB_HOST/DB_PORT).")
+		}
+		t.Skip("postgres is not reachable; skipping personnel event import test")
+	}
+
+	dbName := t.Name()
+	if !safeCreateDB(t, dbName) {
+		return
+	}
+
+	pool := newPoolWithQueryTracer(t, itf.DbOpts(dbName), &queryCountTracer{})
+	t.Cleanup(pool.Close)
+	applyAllOrgMigrationsForExtendedPersonnelEvents(t, ctx, pool)
+
+	tenantID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	ensureTenant(t, ctx, pool, tenantID)
+	seedPerson(t, ctx, pool, tenantID, uuid.New(), "000101", "Test Person 000101")
+	seedPerson(t, ctx, pool, tenantID, uuid.New(), "000102", "Test Person 000102")
+	_, err := pool.Exec(ctx, `
+INSERT INTO org_settings (tenant_id, freeze_mode, freeze_grace_days, reason_code_mode)
+VALUES ($1,'disabled',0,'disabled')
+ON CONFLICT (tenant_id) DO UPDATE SET freeze_mode=excluded.freeze_mode, freeze_grace_days=excluded.freeze_grace_days, reason_code_mode=excluded.reason_code_mode
+`, tenantID)
+	require.NoError(t, err)
+
+	asOf := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+	rootID := uuid.New()
+	_, err = pool.Exec(ctx, `
+INSERT INTO org_nodes (tenant_id, id, type, code, is_root)
+VALUES ($1,$2,'OrgUnit','ROOT',true)
+`, tenantID, rootID)
+	require.NoError(t, err)
+	pos1, pos2, pos3 := uuid.New(), uuid.New(), uuid.New()
+	seedOrgPosition(t, ctx, pool, tenantID, rootID, pos1, "POS-1", 1.0, asOf, endDate)
+	seedOrgPosition(t, ctx, pool, tenantID, rootID, pos2, "POS-2", 1.0, asOf, endDate)
+	seedOrgPosition(t, ctx, pool, tenantID, rootID, pos3, "POS-3", 1.0, asOf, endDate)
+
+	node := rootID.String()
+	rows, err := orgsvc.ParsePersonnelEventImport(ctx, [][]string{
+		orgsvc.PersonnelEventImportColumns,
+		{"hire", "000101", "2025-01-01", node, pos1.String(), "", ""},
+		{"transfer", "000101", "2025-02-01", node, pos2.String(), "", ""},
+		{"hire", "999999", "2025-01-01", node, pos3.String(), "", ""},
+		{"termination", "000102", "2025-03-01", "", "", "", ""},
+		{"hire", "000102", "2025-13-01", node, "", "", ""},
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 5)
+
+	svc := orgsvc.NewOrgService(persistence.NewOrgRepository())
+	reqCtx := composables.WithPool(ctx, pool)
+	initiatorID := uuid.New()
+	statuses := func(report *orgsvc.PersonnelEventImportReport) []string {
+		out := make([]string, 0, len(report.Rows))
+		for _, row := range report.Rows {
+			out = append(out, row.Status)
+		}
+		return out
+	}
+	countEvents := func() int {
+		var n int
+		require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM org_personnel_events WHERE tenant_id = $1`, tenantID).Scan(&n))
+		return n
+	}
+
+	preview, err := svc.PreviewPersonnelEventImport(reqCtx, tenantID, "cycle-2025", initiatorID, rows)
+	require.NoError(t, err)
+	require.True(t, preview.DryRun)
+	// The transfer on row 3 sees the hire from row 2; row 4 has an unknown pernr and 000102 was never hired.
+	require.Equal(t, []string{"valid", "valid", "invalid", "invalid", "invalid"}, statuses(preview))
+	require.Equal(t, "ORG_PERSON_NOT_FOUND", preview.Rows[2].Code)
+	require.Equal(t, "C", preview.Rows[4].Column)
+	require.Equal(t, 2, preview.Valid)
+	require.Equal(t, 3, preview.Invalid)
+	require.Equal(t, 0, countEvents())
+
+	applied, err := svc.ApplyPersonnelEventImport(reqCtx, tenantID, preview.RequestIDPrefix, initiatorID, rows, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"applied", "applied", "invalid", "invalid", "invalid"}, statuses(applied))
+	require.Equal(t, "cycle-2025:2", applied.Rows[0].RequestID)
+	require.NotNil(t, applied.Rows[0].EventID)
+	require.Equal(t, 2, countEvents())
+
+	replayed, err := svc.ApplyPersonnelEventImport(reqCtx, tenantID, "cycle-2025", initiatorID, rows, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, replayed.Replayed)
+	require.Equal(t, 0, replayed.Applied)
+	require.Equal(t, 2, countEvents())
+}
@@ -0,0 +1,412 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/iota-uz/iota-sdk/modules/org/domain/events"
+)
+
+const (
+	PersonnelEventHire        = "hire"
+	PersonnelEventTransfer    = "transfer"
+	PersonnelEventTermination = "termination"
+	PersonnelEventPromotion   = "promotion"
+	PersonnelEventLeave       = "leave"
+	PersonnelEventReturn      = "return"
+	PersonnelEventRehire      = "rehire"
+	PersonnelEventFTEChange   = "fte_change"
+)
+
+// PersonnelEventTypes lists every event type accepted by org_personnel_events.event_type.
+var PersonnelEventTypes = []string{
+	PersonnelEventHire,
+	PersonnelEventTransfer,
+	PersonnelEventTermination,
+	PersonnelEventPromotion,
+	PersonnelEventLeave,
+	PersonnelEventReturn,
+	PersonnelEventRehire,
+	PersonnelEventFTEChange,
+}
+
+// PromotionPersonnelEventInput moves the primary assignment to another position of the same
+// org node whose job profile or job level differs. Job profile and level belong to the position,
+// so a change within the current position is a position update, not a promotion. AllocatedFTE
+// replaces the assignment's FTE; zero keeps it.
+type PromotionPersonnelEventInput struct {
+	Pernr         string
+	PositionID    uuid.UUID
+	EffectiveDate time.Time
+	AllocatedFTE  float64
+	ReasonCode    string
+}
+
+// LeavePersonnelEventInput and ReturnPersonnelEventInput flip the primary assignment's
+// employment status without touching its position.
+type LeavePersonnelEventInput struct {
+	Pernr         string
+	EffectiveDate time.Time
+	ReasonCode    string
+}
+
+type ReturnPersonnelEventInput struct {
+	Pernr         string
+	EffectiveDate time.Time
+	ReasonCode    string
+}
+
+// RehirePersonnelEventInput starts a new primary assignment for a pernr whose latest event is a
+// termination.
+type RehirePersonnelEventInput struct {
+	Pernr         string
+	OrgNodeID     uuid.UUID
+	PositionID    *uuid.UUID
+	EffectiveDate time.Time
+	AllocatedFTE  float64
+	ReasonCode    string
+}
+
+type FTEChangePersonnelEventInput struct {
+	Pernr         string
+	EffectiveDate time.Time
+	AllocatedFTE  float64
+	ReasonCode    string
+}
+
+// personnelEventPlan is what an event type contributes to applyPersonnelEvent: the payload
+// stored on the event row and the assignment writes to run once the row is created.
+type personnelEventPlan struct {
+	payload map[string]any
+	apply   func(txCtx context.Context, reasonCode string) error
+}
+
+func (s *OrgService) PromotionPersonnelEvent(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in PromotionPersonnelEventInput) (*PersonnelEventApplyResult, error) {
+	if in.PositionID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "pernr/position_id/effective_date are required", nil)
+	}
+	if in.AllocatedFTE < 0 {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "allocated_fte must not be negative", nil)
+	}
+	return s.applyPersonnelEvent(ctx, tenantID, requestID, initiatorID, PersonnelEventPromotion, in.Pernr, in.EffectiveDate, in.ReasonCode,
+		func(txCtx context.Context, personUUID uuid.UUID) (*personnelEventPlan, error) {
+			primary, err := s.findActivePrimaryAt(txCtx, tenantID, personUUID, in.EffectiveDate)
+			if err != nil {
+				return nil, err
+			}
+			if primary.PositionID == in.PositionID {
+				return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_PROMOTION_SAME_POSITION", "position_id is already the primary position; change the position's job profile or level with a position update instead", nil)
+			}
+			from, err := s.repo.GetPositionSliceAt(txCtx, tenantID, primary.PositionID, in.EffectiveDate)
+			if err != nil {
+				return nil, mapPgError(err)
+			}
+			to, err := s.repo.GetPositionSliceAt(txCtx, tenantID, in.PositionID, in.EffectiveDate)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_POSITION_NOT_FOUND_AT_DATE", "position_id not found at effective_date", nil)
+				}
+				return nil, mapPgError(err)
+			}
+			if to.OrgNodeID != from.OrgNodeID {
+				return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_PROMOTION_NODE_CHANGED", "promotion cannot change org node; use transfer", nil)
+			}
+			if to.JobProfileID == from.JobProfileID && textOrEmpty(to.JobLevelCode) == textOrEmpty(from.JobLevelCode) {
+				return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_PROMOTION_NO_JOB_CHANGE", "promotion must change job profile or job level", nil)
+			}
+			fte := primary.AllocatedFTE
+			if in.AllocatedFTE > 0 {
+				fte = in.AllocatedFTE
+			}
+			return &personnelEventPlan{
+				payload: map[string]any{
+					"anchor_assignment_id": primary.ID.String(),
+					"from_position_id":     primary.PositionID.String(),
+					"position_id":          in.PositionID.String(),
+					"org_node_id":          to.OrgNodeID.String(),
+					"from_job_profile_id":  from.JobProfileID.String(),
+					"job_profile_id":       to.JobProfileID.String(),
+					"from_job_level_code":  textOrEmpty(from.JobLevelCode),
+					"job_level_code":       textOrEmpty(to.JobLevelCode),
+					"allocated_fte":        fte,
+				},
+				apply: func(txCtx context.Context, reasonCode string) error {
+					_, err := s.UpdateAssignment(txCtx, tenantID, requestID, initiatorID, UpdateAssignmentInput{
+						AssignmentID:  primary.ID,
+						EffectiveDate: in.EffectiveDate,
+						ReasonCode:    reasonCode,
+						AllocatedFTE:  &fte,
+						PositionID:    &in.PositionID,
+					})
+					return err
+				},
+			}, nil
+		})
+}
+
+func (s *OrgService) LeavePersonnelEvent(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in LeavePersonnelEventInput) (*PersonnelEventApplyResult, error) {
+	return s.applyPersonnelEvent(ctx, tenantID, requestID, initiatorID, PersonnelEventLeave, in.Pernr, in.EffectiveDate, in.ReasonCode,
+		func(txCtx context.Context, personUUID uuid.UUID) (*personnelEventPlan, error) {
+			primary, err := s.findActivePrimaryAt(txCtx, tenantID, personUUID, in.EffectiveDate)
+			if err != nil {
+				return nil, err
+			}
+			return s.employmentStatusPlan(tenantID, requestID, initiatorID, primary, in.EffectiveDate, "inactive"), nil
+		})
+}
+
+func (s *OrgService) ReturnPersonnelEvent(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in ReturnPersonnelEventInput) (*PersonnelEventApplyResult, error) {
+	return s.applyPersonnelEvent(ctx, tenantID, requestID, initiatorID, PersonnelEventReturn, in.Pernr, in.EffectiveDate, in.ReasonCode,
+		func(txCtx context.Context, personUUID uuid.UUID) (*personnelEventPlan, error) {
+			if err := s.requireLatestPersonnelEvent(txCtx, tenantID, personUUID, in.EffectiveDate, PersonnelEventLeave,
+				"ORG_RETURN_NOT_ON_LEAVE", "return requires the latest personnel event to be a leave"); err != nil {
+				return nil, err
+			}
+			primary, err := s.findPrimaryAssignmentAt(txCtx, tenantID, personUUID, in.EffectiveDate)
+			if err != nil {
+				return nil, err
+			}
+			if employmentStatusOf(primary) != "inactive" {
+				return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_RETURN_NOT_ON_LEAVE", "primary assignment is not on leave at effective_date", nil)
+			}
+			return s.employmentStatusPlan(tenantID, requestID, initiatorID, primary, in.EffectiveDate, "active"), nil
+		})
+}
+
+func (s *OrgService) RehirePersonnelEvent(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in RehirePersonnelEventInput) (*PersonnelEventApplyResult, error) {
+	if in.OrgNodeID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "pernr/org_node_id/effective_date are required", nil)
+	}
+	allocatedFTE := in.AllocatedFTE
+	if allocatedFTE <= 0 {
+		allocatedFTE = 1.0
+	}
+	return s.applyPersonnelEvent(ctx, tenantID, requestID, initiatorID, PersonnelEventRehire, in.Pernr, in.EffectiveDate, in.ReasonCode,
+		func(txCtx context.Context, personUUID uuid.UUID) (*personnelEventPlan, error) {
+			if err := s.requireLatestPersonnelEvent(txCtx, tenantID, personUUID, in.EffectiveDate, PersonnelEventTermination,
+				"ORG_REHIRE_NOT_TERMINATED", "rehire requires the latest personnel event to be a termination"); err != nil {
+				return nil, err
+			}
+			return &personnelEventPlan{
+				payload: map[string]any{
+					"org_node_id":   in.OrgNodeID.String(),
+					"position_id":   uuidOrEmpty(in.PositionID),
+					"allocated_fte": allocatedFTE,
+				},
+				apply: func(txCtx context.Context, reasonCode string) error {
+					_, err := s.CreateAssignment(txCtx, tenantID, requestID, initiatorID, CreateAssignmentInput{
+						Pernr:         strings.TrimSpace(in.Pernr),
+						EffectiveDate: in.EffectiveDate,
+						ReasonCode:    reasonCode,
+						AllocatedFTE:  allocatedFTE,
+						PositionID:    in.PositionID,
+						OrgNodeID:     &in.OrgNodeID,
+					})
+					return err
+				},
+			}, nil
+		})
+}
+
+func (s *OrgService) FTEChangePersonnelEvent(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in FTEChangePersonnelEventInput) (*PersonnelEventApplyResult, error) {
+	if in.AllocatedFTE <= 0 {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "allocated_fte must be > 0", nil)
+	}
+	return s.applyPersonnelEvent(ctx, tenantID, requestID, initiatorID, PersonnelEventFTEChange, in.Pernr, in.EffectiveDate, in.ReasonCode,
+		func(txCtx context.Context, personUUID uuid.UUID) (*personnelEventPlan, error) {
+			primary, err := s.findActivePrimaryAt(txCtx, tenantID, personUUID, in.EffectiveDate)
+			if err != nil {
+				return nil, err
+			}
+			if math.Abs(primary.AllocatedFTE-in.AllocatedFTE) < 1e-9 {
+				return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_FTE_UNCHANGED", "allocated_fte equals the current value", nil)
+			}
+			fte := in.AllocatedFTE
+			positionID := primary.PositionID
+			return &personnelEventPlan{
+				payload: map[string]any{
+					"anchor_assignment_id": primary.ID.String(),
+					"position_id":          positionID.String(),
+					"from_allocated_fte":   primary.AllocatedFTE,
+					"allocated_fte":        fte,
+				},
+				apply: func(txCtx context.Context, reasonCode string) error {
+					_, err := s.UpdateAssignment(txCtx, tenantID, requestID, initiatorID, UpdateAssignmentInput{
+						AssignmentID:  primary.ID,
+						EffectiveDate: in.EffectiveDate,
+						ReasonCode:    reasonCode,
+						AllocatedFTE:  &fte,
+						PositionID:    &positionID,
+					})
+					return err
+				},
+			}, nil
+		})
+}
+
+// applyPersonnelEvent is the shared flow of the extended event types: normalize the reason
+// code, resolve the pernr, validate and build the payload, record the event idempotently by
+// request_id, apply the assignment writes and enqueue personnel_event.created.
+func (s *OrgService) applyPersonnelEvent(
+	ctx context.Context,
+	tenantID uuid.UUID,
+	requestID string,
+	initiatorID uuid.UUID,
+	eventType string,
+	pernr string,
+	effectiveDate time.Time,
+	reasonCodeIn string,
+	plan func(txCtx context.Context, personUUID uuid.UUID) (*personnelEventPlan, error),
+) (*PersonnelEventApplyResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	pernr = strings.TrimSpace(pernr)
+	if pernr == "" || effectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "pernr/effective_date are required", nil)
+	}
+
+	return inTx(ctx, tenantID, func(txCtx context.Context) (*PersonnelEventApplyResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		reasonCode, reasonInfo, svcErr := normalizeReasonCode(settings, reasonCodeIn)
+		if svcErr != nil {
+			logReasonCodeRejected(txCtx, tenantID, requestID, "personnel_event."+eventType, reasonInfo, svcErr)
+			return nil, svcErr
+		}
+		if reasonInfo.OriginalMissing && strings.TrimSpace(reasonCode) == "" {
+			reasonCode = "legacy"
+		}
+
+		personUUID, err := s.repo.ResolvePersonUUIDByPernr(txCtx, tenantID, pernr)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, newServiceError(http.StatusNotFound, "ORG_PERSON_NOT_FOUND", "pernr not found", nil)
+			}
+			return nil, err
+		}
+
+		p, err := plan(txCtx, personUUID)
+		if err != nil {
+			return nil, err
+		}
+		p.payload["pernr"] = pernr
+		p.payload["effective_date"] = effectiveDate.UTC().Format(time.RFC3339Nano)
+		p.payload["reason_code"] = reasonCode
+		payload, err := json.Marshal(p.payload)
+		if err != nil {
+			return nil, newServiceError(http.StatusInternalServerError, "ORG_INVALID_BODY", "failed to encode payload", err)
+		}
+
+		row, created, err := s.repo.UpsertPersonnelEvent(txCtx, tenantID, PersonnelEventInsert{
+			RequestID:     requestID,
+			InitiatorID:   initiatorID,
+			EventType:     eventType,
+			PersonUUID:    personUUID,
+			Pernr:         pernr,
+			EffectiveDate: effectiveDate,
+			ReasonCode:    reasonCode,
+			Payload:       payload,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !created {
+			return &PersonnelEventApplyResult{Event: row, Created: false}, nil
+		}
+
+		if err := p.apply(txCtx, reasonCode); err != nil {
+			return nil, err
+		}
+
+		ev := buildEventV1(requestID, tenantID, initiatorID, txTime, "personnel_event.created", "org_personnel_event", row.ID, effectiveDate, endOfTime)
+		ev.NewValues = mustMarshalJSON(map[string]any{
+			"personnel_event_id": row.ID.String(),
+			"event_type":         row.EventType,
+			"person_uuid":        row.PersonUUID.String(),
+			"pernr":              row.Pernr,
+			"effective_date":     row.EffectiveDate.UTC().Format(time.RFC3339Nano),
+			"reason_code":        row.ReasonCode,
+		})
+		if err := s.enqueueOutboxEvents(txCtx, tenantID, []events.OrgEventV1{ev}); err != nil {
+			return nil, err
+		}
+		return &PersonnelEventApplyResult{Event: row, Created: true}, nil
+	})
+}
+
+func (s *OrgService) employmentStatusPlan(tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, primary AssignmentViewRow, effectiveDate time.Time, status string) *personnelEventPlan {
+	positionID := primary.PositionID
+	return &personnelEventPlan{
+		payload: map[string]any{
+			"anchor_assignment_id": primary.ID.String(),
+			"position_id":          positionID.String(),
+			"employment_status":    status,
+		},
+		apply: func(txCtx context.Context, reasonCode string) error {
+			_, err := s.UpdateAssignment(txCtx, tenantID, requestID, initiatorID, UpdateAssignmentInput{
+				AssignmentID:     primary.ID,
+				EffectiveDate:    effectiveDate,
+				ReasonCode:       reasonCode,
+				PositionID:       &positionID,
+				EmploymentStatus: &status,
+			})
+			return err
+		},
+	}
+}
+
+func (s *OrgService) findActivePrimaryAt(ctx context.Context, tenantID uuid.UUID, personUUID uuid.UUID, asOf time.Time) (AssignmentViewRow, error) {
+	primary, err := s.findPrimaryAssignmentAt(ctx, tenantID, personUUID, asOf)
+	if err != nil {
+		return AssignmentViewRow{}, err
+	}
+	if employmentStatusOf(primary) != "active" {
+		return AssignmentViewRow{}, newServiceError(http.StatusUnprocessableEntity, "ORG_PRIMARY_NOT_ACTIVE", "primary assignment is not active at effective_date", nil)
+	}
+	return primary, nil
+}
+
+func (s *OrgService) requireLatestPersonnelEvent(ctx context.Context, tenantID uuid.UUID, personUUID uuid.UUID, before time.Time, eventType, code, msg string) error {
+	latest, err := s.repo.LatestPersonnelEventBefore(ctx, tenantID, personUUID, before)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return newServiceError(http.StatusUnprocessableEntity, code, msg, nil)
+		}
+		return err
+	}
+	if latest.EventType != eventType {
+		return newServiceError(http.StatusUnprocessableEntity, code, msg, nil)
+	}
+	return nil
+}
+
+func employmentStatusOf(row AssignmentViewRow) string {
+	status := strings.TrimSpace(row.EmploymentStatus)
+	if status == "" {
+		return "active"
+	}
+	return status
+}
+
+func textOrEmpty(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return strings.TrimSpace(*v)
+}
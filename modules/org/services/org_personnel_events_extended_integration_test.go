@@ -0,0 +1,199 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/itf"
+)
+
+func applyAllOrgMigrationsForExtendedPersonnelEvents(tb testing.TB, ctx context.Context, pool *pgxpool.Pool) {
+	tb.Helper()
+
+	applyAllOrgMigrationsFor061A1(tb, ctx, pool)
+
+	sql := readGooseUpSQL(tb, filepath.Clean(filepath.Join("..", "..", "..", "migrations", "org", "20260111090000_org_personnel_event_types.sql")))
+	_, err := pool.Exec(ctx, sql)
+	require.NoError(tb, err)
+}
+
+func TestOrgExtendedPersonnelEvents_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	isCI := strings.TrimSpace(getenvDefault("CI", "")) != "" || strings.EqualFold(strings.TrimSpace(getenvDefault("GITHUB_ACTIONS", "")), "true")
+
+	if !canDialPostgres(t) {
+		if isCI {
+			t.Fatalf("postgres is not reachable (DB_HOST/DB_PORT).")
+		}
+		t.Skip("postgres is not reachable; skipping extended personnel events test")
+	}
+
+	dbName := t.Name()
+	if !safeCreateDB(t, dbName) {
+		return
+	}
+
+	pool := newPoolWithQueryTracer(t, itf.DbOpts(dbName), &queryCountTracer{})
+	t.Cleanup(pool.Close)
+	applyAllOrgMigrationsForExtendedPersonnelEvents(t, ctx, pool)
+
+	tenantID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	ensureTenant(t, ctx, pool, tenantID)
+
+	personUUID := uuid.New()
+	seedPerson(t, ctx, pool, tenantID, personUUID, "000321", "Test Person 000321")
+	_, err := pool.Exec(ctx, `
+INSERT INTO org_settings (tenant_id, freeze_mode, freeze_grace_days, reason_code_mode)
+VALUES ($1,'disabled',0,'disabled')
+ON CONFLICT (tenant_id) DO UPDATE SET freeze_mode=excluded.freeze_mode, freeze_grace_days=excluded.freeze_grace_days, reason_code_mode=excluded.reason_code_mode
+`, tenantID)
+	require.NoError(t, err)
+
+	jan := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	rootID := uuid.New()
+	otherNodeID := uuid.New()
+	_, err = pool.Exec(ctx, `
+INSERT INTO org_nodes (tenant_id, id, type, code, is_root)
+VALUES ($1,$2,'OrgUnit','ROOT',true), ($1,$3,'OrgUnit','OTHER',false)
+`, tenantID, rootID, otherNodeID)
+	require.NoError(t, err)
+
+	analyst := uuid.New()
+	seniorAnalyst := uuid.New()
+	sameLevel := uuid.New()
+	elsewhere := uuid.New()
+	seedOrgPosition(t, ctx, pool, tenantID, rootID, analyst, "ANALYST", 1.0, jan, endDate)
+	seedOrgPosition(t, ctx, pool, tenantID, rootID, seniorAnalyst, "SENIOR-ANALYST", 1.0, jan, endDate)
+	seedOrgPosition(t, ctx, pool, tenantID, rootID, sameLevel, "ANALYST-2", 1.0, jan, endDate)
+	seedOrgPosition(t, ctx, pool, tenantID, otherNodeID, elsewhere, "ELSEWHERE", 1.0, jan, endDate)
+	_, err = pool.Exec(ctx, `UPDATE org_position_slices SET job_level_code = 'L2' WHERE tenant_id = $1 AND position_id IN ($2, $3)`, tenantID, seniorAnalyst, elsewhere)
+	require.NoError(t, err)
+
+	svc := orgsvc.NewOrgService(persistence.NewOrgRepository())
+	reqCtx := composables.WithPool(ctx, pool)
+	initiatorID := uuid.New()
+
+	primaryAt := func(asOf time.Time) orgsvc.AssignmentViewRow {
+		t.Helper()
+		_, rows, _, err := svc.GetAssignments(reqCtx, tenantID, "person:000321", &asOf)
+		require.NoError(t, err)
+		for _, row := range rows {
+			if row.IsPrimary {
+				return row
+			}
+		}
+		t.Fatalf("no primary assignment at %s", asOf)
+		return orgsvc.AssignmentViewRow{}
+	}
+	requireCode := func(err error, status int, code string) {
+		t.Helper()
+		var svcErr *orgsvc.ServiceError
+		require.ErrorAs(t, err, &svcErr)
+		require.Equal(t, status, svcErr.Status)
+		require.Equal(t, code, svcErr.Code)
+	}
+	date := func(month int) time.Time { return time.Date(2025, time.Month(month), 1, 0, 0, 0, 0, time.UTC) }
+
+	_, err = svc.HirePersonnelEvent(reqCtx, tenantID, "req-hire", initiatorID, orgsvc.HirePersonnelEventInput{
+		Pernr: "000321", OrgNodeID: rootID, PositionID: &analyst, EffectiveDate: jan,
+	})
+	require.NoError(t, err)
+
+	// Promotion stays within the org node and must change the job.
+	_, err = svc.PromotionPersonnelEvent(reqCtx, tenantID, "req-promo-node", initiatorID, orgsvc.PromotionPersonnelEventInput{
+		Pernr: "000321", PositionID: elsewhere, EffectiveDate: date(2),
+	})
+	requireCode(err, http.StatusUnprocessableEntity, "ORG_PROMOTION_NODE_CHANGED")
+	_, err = svc.PromotionPersonnelEvent(reqCtx, tenantID, "req-promo-same", initiatorID, orgsvc.PromotionPersonnelEventInput{
+		Pernr: "000321", PositionID: sameLevel, EffectiveDate: date(2),
+	})
+	requireCode(err, http.StatusUnprocessableEntity, "ORG_PROMOTION_NO_JOB_CHANGE")
+	res, err := svc.PromotionPersonnelEvent(reqCtx, tenantID, "req-promo", initiatorID, orgsvc.PromotionPersonnelEventInput{
+		Pernr: "000321", PositionID: seniorAnalyst, EffectiveDate: date(2), ReasonCode: "merit",
+	})
+	require.NoError(t, err)
+	require.True(t, res.Created)
+	require.Equal(t, "promotion", res.Event.EventType)
+	require.Equal(t, "merit", res.Event.ReasonCode)
+	require.Equal(t, seniorAnalyst, primaryAt(date(2)).PositionID)
+
+	_, err = svc.FTEChangePersonnelEvent(reqCtx, tenantID, "req-fte-same", initiatorID, orgsvc.FTEChangePersonnelEventInput{
+		Pernr: "000321", EffectiveDate: date(3), AllocatedFTE: 1.0,
+	})
+	requireCode(err, http.StatusUnprocessableEntity, "ORG_FTE_UNCHANGED")
+	_, err = svc.FTEChangePersonnelEvent(reqCtx, tenantID, "req-fte", initiatorID, orgsvc.FTEChangePersonnelEventInput{
+		Pernr: "000321", EffectiveDate: date(3), AllocatedFTE: 0.5,
+	})
+	require.NoError(t, err)
+	require.InDelta(t, 0.5, primaryAt(date(3)).AllocatedFTE, 0.001)
+
+	// Return needs a leave first.
+	_, err = svc.ReturnPersonnelEvent(reqCtx, tenantID, "req-return-early", initiatorID, orgsvc.ReturnPersonnelEventInput{
+		Pernr: "000321", EffectiveDate: date(4),
+	})
+	requireCode(err, http.StatusUnprocessableEntity, "ORG_RETURN_NOT_ON_LEAVE")
+
+	_, err = svc.LeavePersonnelEvent(reqCtx, tenantID, "req-leave", initiatorID, orgsvc.LeavePersonnelEventInput{
+		Pernr: "000321", EffectiveDate: date(4),
+	})
+	require.NoError(t, err)
+	onLeave := primaryAt(date(4))
+	require.Equal(t, "inactive", onLeave.EmploymentStatus)
+	require.Equal(t, seniorAnalyst, onLeave.PositionID)
+
+	_, err = svc.FTEChangePersonnelEvent(reqCtx, tenantID, "req-fte-leave", initiatorID, orgsvc.FTEChangePersonnelEventInput{
+		Pernr: "000321", EffectiveDate: date(5), AllocatedFTE: 1.0,
+	})
+	requireCode(err, http.StatusUnprocessableEntity, "ORG_PRIMARY_NOT_ACTIVE")
+
+	_, err = svc.ReturnPersonnelEvent(reqCtx, tenantID, "req-return", initiatorID, orgsvc.ReturnPersonnelEventInput{
+		Pernr: "000321", EffectiveDate: date(6),
+	})
+	require.NoError(t, err)
+	back := primaryAt(date(6))
+	require.Equal(t, "active", back.EmploymentStatus)
+	require.InDelta(t, 0.5, back.AllocatedFTE, 0.001)
+
+	// Rehire only follows a termination.
+	_, err = svc.RehirePersonnelEvent(reqCtx, tenantID, "req-rehire-early", initiatorID, orgsvc.RehirePersonnelEventInput{
+		Pernr: "000321", OrgNodeID: rootID, PositionID: &analyst, EffectiveDate: date(7),
+	})
+	requireCode(err, http.StatusUnprocessableEntity, "ORG_REHIRE_NOT_TERMINATED")
+
+	_, err = svc.TerminationPersonnelEvent(reqCtx, tenantID, "req-termination", initiatorID, orgsvc.TerminationPersonnelEventInput{
+		Pernr: "000321", EffectiveDate: date(7),
+	})
+	require.NoError(t, err)
+	res, err = svc.RehirePersonnelEvent(reqCtx, tenantID, "req-rehire", initiatorID, orgsvc.RehirePersonnelEventInput{
+		Pernr: "000321", OrgNodeID: rootID, PositionID: &analyst, EffectiveDate: date(9),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "rehire", res.Event.EventType)
+	rehired := primaryAt(date(9))
+	require.Equal(t, analyst, rehired.PositionID)
+	require.Equal(t, "active", rehired.EmploymentStatus)
+	require.NotNil(t, rehired.StartEventType)
+	require.Equal(t, "rehire", *rehired.StartEventType)
+
+	var outboxCount int
+	err = pool.QueryRow(ctx, `
+SELECT count(*)
+FROM org_outbox
+WHERE tenant_id = $1 AND payload->>'change_type' = 'personnel_event.created'
+`, tenantID).Scan(&outboxCount)
+	require.NoError(t, err)
+	require.Equal(t, 7, outboxCount)
+}
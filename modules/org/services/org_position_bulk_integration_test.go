@@ -0,0 +1,111 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+)
+
+func TestOrgPositionBulk_CreateUpdateTransferShareRequestID(t *testing.T) {
+	ctx, pool, tenantID, rootNodeID, asOf, svc := setupOrg053DB(t)
+	jobProfileID := seedOrg053JobProfile(t, ctx, tenantID, svc)
+	targetNodeID := buildPerfNodes(t, tenantID, 3, "deep", 53)[1].ID
+	initiatorID := uuid.New()
+
+	created, err := svc.BulkCreatePositions(ctx, tenantID, "req-bulk-create", initiatorID, orgsvc.BulkCreatePositionsInput{
+		Template: orgsvc.CreatePositionInput{
+			OrgNodeID:      rootNodeID,
+			EffectiveDate:  asOf,
+			PositionType:   "regular",
+			EmploymentType: "full_time",
+			JobProfileID:   jobProfileID,
+			JobLevelCode:   ptr("L1"),
+			CapacityFTE:    1.0,
+			ReasonCode:     "create",
+		},
+		Codes: orgsvc.BulkPositionCodes("BULK-", 1, 3),
+	})
+	require.NoError(t, err)
+	require.Len(t, created.Items, 3)
+	require.Equal(t, "BULK-001", created.Items[0].Code)
+
+	var auditCount int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM org_audit_logs WHERE tenant_id=$1 AND request_id=$2`, tenantID, "req-bulk-create").Scan(&auditCount))
+	require.Equal(t, 3, auditCount)
+
+	updateDate := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	q := "BULK-"
+	updated, err := svc.BulkUpdatePositions(ctx, tenantID, "req-bulk-update", initiatorID, orgsvc.BulkUpdatePositionsInput{
+		Filter: orgsvc.BulkPositionFilter{OrgNodeID: &rootNodeID, Q: &q},
+		Patch: orgsvc.UpdatePositionInput{
+			EffectiveDate: updateDate,
+			Title:         ptr("Analyst"),
+			ReasonCode:    "update",
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, updated.Items, 3)
+
+	transferDate := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	ids := []uuid.UUID{created.Items[0].PositionID, created.Items[1].PositionID}
+	_, err = svc.BulkTransferPositions(ctx, tenantID, "req-bulk-transfer", initiatorID, orgsvc.BulkTransferPositionsInput{
+		PositionIDs:     ids,
+		TargetOrgNodeID: targetNodeID,
+		EffectiveDate:   transferDate,
+		ReasonCode:      "transfer",
+	})
+	require.NoError(t, err)
+
+	var moved int
+	require.NoError(t, pool.QueryRow(ctx, `
+SELECT count(*)
+FROM org_position_slices
+WHERE tenant_id=$1 AND position_id = ANY($2) AND org_node_id=$3 AND effective_date=$4
+`, tenantID, ids, targetNodeID, transferDate).Scan(&moved))
+	require.Equal(t, 2, moved)
+}
+
+func TestOrgPositionBulk_FailingItemRollsBackBatch(t *testing.T) {
+	ctx, pool, tenantID, rootNodeID, asOf, svc := setupOrg053DB(t)
+	jobProfileID := seedOrg053JobProfile(t, ctx, tenantID, svc)
+	initiatorID := uuid.New()
+
+	template := orgsvc.CreatePositionInput{
+		OrgNodeID:      rootNodeID,
+		EffectiveDate:  asOf,
+		PositionType:   "regular",
+		EmploymentType: "full_time",
+		JobProfileID:   jobProfileID,
+		JobLevelCode:   ptr("L1"),
+		CapacityFTE:    1.0,
+		ReasonCode:     "create",
+	}
+	first := template
+	first.Code = "DUP-002"
+	_, err := svc.CreatePosition(ctx, tenantID, "req-bulk-seed", initiatorID, first)
+	require.NoError(t, err)
+
+	_, err = svc.BulkCreatePositions(ctx, tenantID, "req-bulk-dup", initiatorID, orgsvc.BulkCreatePositionsInput{
+		Template: template,
+		Codes:    []string{"DUP-001", "DUP-002", "DUP-003"},
+	})
+	var cmdErr *orgsvc.CommandError
+	require.True(t, errors.As(err, &cmdErr), "expected CommandError, got %v", err)
+	require.Equal(t, 1, cmdErr.Index)
+	require.Equal(t, "position.create", cmdErr.Type)
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT count(*) FROM org_positions WHERE tenant_id=$1 AND code LIKE 'DUP-%'`, tenantID).Scan(&count))
+	require.Equal(t, 1, count)
+}
+
+func TestBulkPositionCodes(t *testing.T) {
+	require.Equal(t, []string{"FIN-001", "FIN-002", "FIN-003"}, orgsvc.BulkPositionCodes("FIN-", 1, 3))
+	require.Equal(t, []string{"P-0999", "P-1000"}, orgsvc.BulkPositionCodes("P-", 999, 2))
+	require.Nil(t, orgsvc.BulkPositionCodes("X", 1, 0))
+}
@@ -0,0 +1,100 @@
+package services_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+func TestOrgRoleAssignments_CreateEndRescind(t *testing.T) {
+	ctx, pool, tenantID, rootNodeID, asOf, svc := setupOrg053DB(t)
+	initiatorID := uuid.New()
+	subjectID := uuid.New()
+
+	cfg := configuration.Use()
+	prevRoleReadEnabled := cfg.OrgRoleReadEnabled
+	cfg.OrgRoleReadEnabled = true
+	t.Cleanup(func() { cfg.OrgRoleReadEnabled = prevRoleReadEnabled })
+
+	role, err := svc.CreateRole(ctx, tenantID, "req-role-1", initiatorID, orgsvc.CreateRoleInput{Code: "hrbp", Name: "HR business partner"})
+	require.NoError(t, err)
+	require.False(t, role.IsSystem)
+
+	_, err = svc.CreateRole(ctx, tenantID, "req-role-2", initiatorID, orgsvc.CreateRoleInput{Code: "hrbp", Name: "Duplicate"})
+	var svcErr *orgsvc.ServiceError
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_ROLE_CODE_CONFLICT", svcErr.Code)
+
+	created, err := svc.CreateRoleAssignment(ctx, tenantID, "req-ra-1", initiatorID, orgsvc.CreateRoleAssignmentInput{
+		RoleID:        role.ID,
+		SubjectID:     subjectID,
+		OrgNodeID:     rootNodeID,
+		EffectiveDate: asOf,
+	})
+	require.NoError(t, err)
+
+	items, _, err := svc.ListRoleAssignments(ctx, tenantID, rootNodeID, asOf, false, nil, nil, nil)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, created.AssignmentID, items[0].AssignmentID)
+	require.Equal(t, "hrbp", items[0].RoleCode)
+
+	lastDay := asOf.AddDate(0, 1, 0)
+	ended, err := svc.EndRoleAssignment(ctx, tenantID, "req-ra-2", initiatorID, orgsvc.EndRoleAssignmentInput{
+		AssignmentID: created.AssignmentID,
+		EndDate:      lastDay,
+	})
+	require.NoError(t, err)
+	require.Equal(t, lastDay, ended.EndDate)
+
+	items, _, err = svc.ListRoleAssignments(ctx, tenantID, rootNodeID, lastDay.AddDate(0, 0, 1), false, nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, items)
+
+	_, err = svc.EndRoleAssignment(ctx, tenantID, "req-ra-3", initiatorID, orgsvc.EndRoleAssignmentInput{
+		AssignmentID: created.AssignmentID,
+		EndDate:      asOf.AddDate(0, 0, -1),
+	})
+	require.True(t, errors.As(err, &svcErr), "expected ServiceError, got %v", err)
+	require.Equal(t, "ORG_INVALID_END_DATE", svcErr.Code)
+
+	_, err = svc.RescindRoleAssignment(ctx, tenantID, "req-ra-4", initiatorID, orgsvc.RescindRoleAssignmentInput{
+		AssignmentID: created.AssignmentID,
+		ReasonNote:   ptr("assigned by mistake"),
+	})
+	require.NoError(t, err)
+
+	items, _, err = svc.ListRoleAssignments(ctx, tenantID, rootNodeID, asOf, false, nil, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, items)
+
+	var changeTypes []string
+	rows, err := pool.Query(ctx, `
+SELECT change_type
+FROM org_audit_logs
+WHERE tenant_id=$1 AND request_id IN ('req-role-1','req-ra-1','req-ra-2','req-ra-4')
+ORDER BY transaction_time, id
+`, tenantID)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var changeType string
+		require.NoError(t, rows.Scan(&changeType))
+		changeTypes = append(changeTypes, changeType)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []string{"role.created", "role_assignment.created", "role_assignment.ended", "role_assignment.rescinded"}, changeTypes)
+
+	var reason string
+	require.NoError(t, pool.QueryRow(ctx, `
+SELECT meta->>'reason_note'
+FROM org_audit_logs
+WHERE tenant_id=$1 AND request_id='req-ra-4'
+`, tenantID).Scan(&reason))
+	require.Equal(t, "assigned by mistake", reason)
+}
@@ -35,10 +35,47 @@ type OrgRepository interface {
 	// DEV-PLAN-028: inheritance read side helpers.
 	ListNodeAttributesAsOf(ctx context.Context, tenantID uuid.UUID, nodeIDs []uuid.UUID, asOf time.Time) (map[uuid.UUID]OrgNodeAttributes, error)
 	ListAttributeInheritanceRulesAsOf(ctx context.Context, tenantID uuid.UUID, hierarchyType string, asOf time.Time) ([]AttributeInheritanceRule, error)
+	ListInheritanceRules(ctx context.Context, tenantID uuid.UUID, filter InheritanceRuleListFilter) ([]InheritanceRuleRow, error)
+	LockInheritanceRuleAt(ctx context.Context, tenantID uuid.UUID, hierarchyType string, attributeName string, asOf time.Time) (InheritanceRuleRow, error)
+	LockInheritanceRuleByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (InheritanceRuleRow, error)
+	NextInheritanceRuleStart(ctx context.Context, tenantID uuid.UUID, hierarchyType string, attributeName string, after time.Time) (*time.Time, error)
+	InsertInheritanceRule(ctx context.Context, tenantID uuid.UUID, in InheritanceRuleInsert) (uuid.UUID, error)
+	UpdateInheritanceRuleCanOverride(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, canOverride bool) error
+	UpdateInheritanceRuleEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error
+	DeleteInheritanceRule(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error
+	ListInheritanceBreaks(ctx context.Context, tenantID uuid.UUID, filter InheritanceRuleListFilter) ([]InheritanceBreakRow, error)
+	LockInheritanceBreakByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (InheritanceBreakRow, error)
+	InsertInheritanceBreak(ctx context.Context, tenantID uuid.UUID, in InheritanceBreakInsert) (uuid.UUID, error)
+	UpdateInheritanceBreakEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error
+	DeleteInheritanceBreak(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error
+	ListAttributeDefinitions(ctx context.Context, tenantID uuid.UUID, filter AttributeDefinitionListFilter) ([]AttributeDefinitionRow, error)
+	LockAttributeDefinitionAt(ctx context.Context, tenantID uuid.UUID, entityType string, code string, asOf time.Time) (AttributeDefinitionRow, error)
+	LockAttributeDefinitionByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (AttributeDefinitionRow, error)
+	NextAttributeDefinitionStart(ctx context.Context, tenantID uuid.UUID, entityType string, code string, after time.Time) (*time.Time, error)
+	InsertAttributeDefinition(ctx context.Context, tenantID uuid.UUID, in AttributeDefinitionInsert) (uuid.UUID, error)
+	UpdateAttributeDefinition(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, in AttributeDefinitionInsert) error
+	UpdateAttributeDefinitionEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error
+	DeleteAttributeDefinition(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error
+	ListFreezePeriods(ctx context.Context, tenantID uuid.UUID) ([]FreezePeriod, error)
+	LockFreezePeriodByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (FreezePeriod, error)
+	InsertFreezePeriod(ctx context.Context, tenantID uuid.UUID, in FreezePeriod) (uuid.UUID, error)
+	UpdateFreezePeriod(ctx context.Context, tenantID uuid.UUID, in FreezePeriod) error
+	DeleteFreezePeriod(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error
 
 	// DEV-PLAN-028: role read side helpers.
 	ListRoles(ctx context.Context, tenantID uuid.UUID) ([]OrgRole, error)
 	ListRoleAssignmentsAsOf(ctx context.Context, tenantID uuid.UUID, hierarchyType string, orgNodeID uuid.UUID, asOf time.Time, includeInherited bool, backend DeepReadBackend, roleCode *string, subjectType *string, subjectID *uuid.UUID) ([]RoleAssignmentRow, error)
+	GetRoleByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (OrgRole, error)
+	InsertRole(ctx context.Context, tenantID uuid.UUID, in RoleInsert) (uuid.UUID, error)
+	InsertRoleAssignment(ctx context.Context, tenantID uuid.UUID, in RoleAssignmentInsert) (uuid.UUID, error)
+	LockRoleAssignmentByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (RoleAssignmentRow, error)
+	UpdateRoleAssignmentEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error
+	DeleteRoleAssignment(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error
+	InsertDelegation(ctx context.Context, tenantID uuid.UUID, in DelegationInsert) (uuid.UUID, error)
+	LockDelegationByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (DelegationRow, error)
+	UpdateDelegationEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error
+	ListDelegations(ctx context.Context, tenantID uuid.UUID, filter DelegationListFilter) ([]DelegationRow, error)
+	ListDelegationsForNodesAsOf(ctx context.Context, tenantID uuid.UUID, orgNodeIDs []uuid.UUID, asOf time.Time) ([]DelegationRow, error)
 
 	// DEV-PLAN-032: security group mappings + business object links.
 	ListSecurityGroupMappings(ctx context.Context, tenantID uuid.UUID, filter SecurityGroupMappingListFilter) ([]SecurityGroupMappingRow, error)
@@ -72,18 +109,20 @@ type OrgRepository interface {
 	GetStaffingSummaryReport(ctx context.Context, tenantID uuid.UUID, asOf time.Time, orgNodeIDs []uuid.UUID, lifecycleStatuses []string, includeSystem bool, groupBy StaffingGroupBy) (StaffingSummaryDBResult, error)
 	ListStaffingVacanciesReport(ctx context.Context, tenantID uuid.UUID, asOf time.Time, orgNodeIDs []uuid.UUID, lifecycleStatuses []string, includeSystem bool, limit int, cursor *uuid.UUID) (StaffingVacanciesDBResult, error)
 	GetStaffingTimeToFillReport(ctx context.Context, tenantID uuid.UUID, from time.Time, to time.Time, orgNodeIDs []uuid.UUID, lifecycleStatuses []string, includeSystem bool, groupBy StaffingGroupBy) (StaffingTimeToFillDBResult, error)
+	GetTrendReport(ctx context.Context, tenantID uuid.UUID, buckets []TrendBucket, orgNodeIDs []uuid.UUID, lifecycleStatuses []string, includeSystem bool) ([]TrendBucketDBRow, error)
 
 	GetOrgSettings(ctx context.Context, tenantID uuid.UUID) (OrgSettings, error)
 	InsertAuditLog(ctx context.Context, tenantID uuid.UUID, log AuditLogInsert) (uuid.UUID, error)
+	ListAuditLogs(ctx context.Context, tenantID uuid.UUID, filter AuditLogFilter) ([]AuditLogRow, error)
 
-	HasRoot(ctx context.Context, tenantID uuid.UUID) (bool, error)
+	HasRoot(ctx context.Context, tenantID uuid.UUID, hierarchyType string) (bool, error)
 	InsertNode(ctx context.Context, tenantID uuid.UUID, nodeType, code string, isRoot bool) (uuid.UUID, error)
 	InsertNodeSlice(ctx context.Context, tenantID uuid.UUID, nodeID uuid.UUID, slice NodeSliceInsert) (uuid.UUID, error)
 	InsertEdge(ctx context.Context, tenantID uuid.UUID, hierarchyType string, parentID *uuid.UUID, childID uuid.UUID, effectiveDate, endDate time.Time) (uuid.UUID, error)
 	NodeExistsAt(ctx context.Context, tenantID uuid.UUID, nodeID uuid.UUID, hierarchyType string, asOf time.Time) (bool, error)
 	GetNode(ctx context.Context, tenantID uuid.UUID, nodeID uuid.UUID) (NodeRow, error)
 	GetNodeIsRoot(ctx context.Context, tenantID uuid.UUID, nodeID uuid.UUID) (bool, error)
-	GetTenantRootNodeID(ctx context.Context, tenantID uuid.UUID) (uuid.UUID, error)
+	GetTenantRootNodeID(ctx context.Context, tenantID uuid.UUID, hierarchyType string) (uuid.UUID, error)
 
 	// DEV-PLAN-033: Export/path helpers.
 	ListOrgNodesAsOf(ctx context.Context, tenantID uuid.UUID, hierarchyType string, nodeIDs []uuid.UUID, asOf time.Time) ([]OrgNodeAsOfRow, error)
@@ -91,6 +130,49 @@ type OrgRepository interface {
 	ResolveSecurityGroupKeysForNodesAsOf(ctx context.Context, tenantID uuid.UUID, hierarchyType string, nodeIDs []uuid.UUID, asOf time.Time, backend DeepReadBackend) (map[uuid.UUID][]string, error)
 	ListOrgLinkSummariesForNodesAsOf(ctx context.Context, tenantID uuid.UUID, nodeIDs []uuid.UUID, asOf time.Time) (map[uuid.UUID][]OrgLinkSummary, error)
 
+	// Org chart: per-node box details (manager, position counts) for a rendered subtree.
+	ListOrgChartNodeDetailsAsOf(ctx context.Context, tenantID uuid.UUID, nodeIDs []uuid.UUID, asOf time.Time) (map[uuid.UUID]OrgChartNodeDetails, error)
+
+	// Search: ranked lookup plus batched root-first paths for the hits.
+	SearchAsOf(ctx context.Context, tenantID uuid.UUID, query string, kinds []SearchKind, hierarchyType string, asOf time.Time, limit int) ([]SearchHitRow, error)
+	ListNodePathsAsOf(ctx context.Context, tenantID uuid.UUID, hierarchyType string, nodeIDs []uuid.UUID, asOf time.Time) (map[uuid.UUID][]NodePathNode, error)
+
+	// Hierarchy diff: lightweight position/assignment state for comparing two as-of dates.
+	ListPositionStatesAsOf(ctx context.Context, tenantID uuid.UUID, asOf time.Time) ([]PositionStateRow, error)
+	ListAssignmentStatesAsOf(ctx context.Context, tenantID uuid.UUID, asOf time.Time) ([]AssignmentStateRow, error)
+
+	// Scenarios: what-if command sets staged against a rolled-back copy of the hierarchy.
+	InsertScenario(ctx context.Context, tenantID uuid.UUID, createdBy uuid.UUID, in ScenarioInput) (ScenarioRow, error)
+	GetScenario(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (ScenarioRow, error)
+	ListScenarios(ctx context.Context, tenantID uuid.UUID, limit int) ([]ScenarioRow, error)
+	UpdateScenarioDraft(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, in ScenarioInput) (ScenarioRow, error)
+	DeleteScenarioDraft(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error
+	MarkScenarioConverted(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, changeRequestID uuid.UUID) (ScenarioRow, error)
+
+	// Hierarchy memberships: positions/assignments placed in parallel (non-OrgUnit) hierarchies.
+	HierarchyMembershipSubjectExistsAt(ctx context.Context, tenantID uuid.UUID, subjectType string, subjectID uuid.UUID, asOf time.Time) (bool, error)
+	InsertHierarchyMembership(ctx context.Context, tenantID uuid.UUID, in HierarchyMembershipInsert) (uuid.UUID, error)
+	LockHierarchyMembershipAt(ctx context.Context, tenantID uuid.UUID, subjectType string, subjectID uuid.UUID, hierarchyType string, asOf time.Time) (HierarchyMembershipRow, error)
+	LockHierarchyMembershipByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (HierarchyMembershipRow, error)
+	NextHierarchyMembershipStart(ctx context.Context, tenantID uuid.UUID, subjectType string, subjectID uuid.UUID, hierarchyType string, after time.Time) (*time.Time, error)
+	UpdateHierarchyMembershipNode(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, orgNodeID uuid.UUID) error
+	UpdateHierarchyMembershipEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error
+	ListHierarchyMemberships(ctx context.Context, tenantID uuid.UUID, filter HierarchyMembershipListFilter) ([]HierarchyMembershipRow, error)
+	ListHierarchyMembershipTotalsAsOf(ctx context.Context, tenantID uuid.UUID, hierarchyType string, asOf time.Time) ([]HierarchyMembershipNodeTotals, error)
+	HasHierarchyMembershipsAt(ctx context.Context, tenantID uuid.UUID, orgNodeID uuid.UUID, asOf time.Time) (bool, error)
+
+	// Headcount budgets: effective-dated planned headcount/FTE/cost lines per org node.
+	InsertHeadcountBudget(ctx context.Context, tenantID uuid.UUID, in HeadcountBudgetInsert) (uuid.UUID, error)
+	LockHeadcountBudgetAt(ctx context.Context, tenantID uuid.UUID, orgNodeID uuid.UUID, jobProfileID *uuid.UUID, jobLevelCode *string, asOf time.Time) (HeadcountBudgetRow, error)
+	LockHeadcountBudgetByID(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (HeadcountBudgetRow, error)
+	LockHeadcountBudgetsAt(ctx context.Context, tenantID uuid.UUID, orgNodeID uuid.UUID, asOf time.Time) ([]HeadcountBudgetRow, error)
+	NextHeadcountBudgetStart(ctx context.Context, tenantID uuid.UUID, orgNodeID uuid.UUID, jobProfileID *uuid.UUID, jobLevelCode *string, after time.Time) (*time.Time, error)
+	UpdateHeadcountBudgetValues(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, values HeadcountBudgetValues) error
+	UpdateHeadcountBudgetEndDate(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, endDate time.Time) error
+	ListHeadcountBudgets(ctx context.Context, tenantID uuid.UUID, filter HeadcountBudgetListFilter) ([]HeadcountBudgetRow, error)
+	ListHeadcountBudgetsAsOf(ctx context.Context, tenantID uuid.UUID, asOf time.Time, orgNodeIDs []uuid.UUID) ([]HeadcountBudgetRow, error)
+	ListHeadcountBudgetActualsAsOf(ctx context.Context, tenantID uuid.UUID, asOf time.Time, orgNodeIDs []uuid.UUID, lifecycleStatuses []string, includeSystem bool) ([]HeadcountBudgetActualRow, error)
+
 	GetNodeSliceAt(ctx context.Context, tenantID uuid.UUID, nodeID uuid.UUID, asOf time.Time) (NodeSliceRow, error)
 	LockNodeSliceAt(ctx context.Context, tenantID uuid.UUID, nodeID uuid.UUID, asOf time.Time) (NodeSliceRow, error)
 	TruncateNodeSlice(ctx context.Context, tenantID uuid.UUID, sliceID uuid.UUID, endDate time.Time) error
@@ -203,6 +285,7 @@ type OrgRepository interface {
 	DeleteAssignmentByID(ctx context.Context, tenantID uuid.UUID, assignmentID uuid.UUID) error
 
 	UpsertPersonnelEvent(ctx context.Context, tenantID uuid.UUID, in PersonnelEventInsert) (PersonnelEventRow, bool, error)
+	LatestPersonnelEventBefore(ctx context.Context, tenantID uuid.UUID, personUUID uuid.UUID, before time.Time) (PersonnelEventRow, error)
 }
 
 type HierarchyNode struct {
@@ -223,32 +306,34 @@ type NodeRow struct {
 }
 
 type NodeSliceInsert struct {
-	Name          string
-	I18nNames     map[string]string
-	Status        string
-	LegalEntityID *uuid.UUID
-	CompanyCode   *string
-	LocationID    *uuid.UUID
-	DisplayOrder  int
-	ParentHint    *uuid.UUID
-	ManagerUserID *int64
-	EffectiveDate time.Time
-	EndDate       time.Time
+	Name             string
+	I18nNames        map[string]string
+	Status           string
+	LegalEntityID    *uuid.UUID
+	CompanyCode      *string
+	LocationID       *uuid.UUID
+	DisplayOrder     int
+	ParentHint       *uuid.UUID
+	ManagerUserID    *int64
+	CustomAttributes map[string]any
+	EffectiveDate    time.Time
+	EndDate          time.Time
 }
 
 type NodeSliceRow struct {
-	ID            uuid.UUID
-	Name          string
-	I18nNames     map[string]string
-	Status        string
-	LegalEntityID *uuid.UUID
-	CompanyCode   *string
-	LocationID    *uuid.UUID
-	DisplayOrder  int
-	ParentHint    *uuid.UUID
-	ManagerUserID *int64
-	EffectiveDate time.Time
-	EndDate       time.Time
+	ID               uuid.UUID
+	Name             string
+	I18nNames        map[string]string
+	Status           string
+	LegalEntityID    *uuid.UUID
+	CompanyCode      *string
+	LocationID       *uuid.UUID
+	DisplayOrder     int
+	ParentHint       *uuid.UUID
+	ManagerUserID    *int64
+	CustomAttributes map[string]any
+	EffectiveDate    time.Time
+	EndDate          time.Time
 }
 
 type NodeSliceInPlacePatch struct {
@@ -261,6 +346,8 @@ type NodeSliceInPlacePatch struct {
 	DisplayOrder  *int
 	ParentHint    **uuid.UUID
 	ManagerUserID **int64
+	// CustomAttributes, when non-nil, replaces the stored values.
+	CustomAttributes map[string]any
 }
 
 type EdgeRow struct {
@@ -358,6 +445,8 @@ type ServiceError struct {
 	Code    string
 	Message string
 	Cause   error
+	// Meta carries structured details surfaced to API clients next to the message.
+	Meta map[string]string
 }
 
 func (e *ServiceError) Error() string {
@@ -389,7 +478,7 @@ func (s *OrgService) GetHierarchyAsOf(ctx context.Context, tenantID uuid.UUID, h
 	if tenantID == uuid.Nil {
 		return nil, time.Time{}, fmt.Errorf("tenant_id is required")
 	}
-	if hierarchyType != "OrgUnit" {
+	if !IsValidHierarchyType(hierarchyType) {
 		return nil, time.Time{}, fmt.Errorf("unsupported hierarchy type: %s", hierarchyType)
 	}
 	if asOf.IsZero() {
@@ -398,7 +487,7 @@ func (s *OrgService) GetHierarchyAsOf(ctx context.Context, tenantID uuid.UUID, h
 	asOf = normalizeValidDateUTC(asOf)
 
 	cacheKey := repo.CacheKey("org", "hierarchy", tenantID, hierarchyType, asOf.UTC().Format(time.RFC3339Nano))
-	if s != nil && s.cache != nil {
+	if s != nil && s.cache != nil && !shouldSkipReadCache(ctx) {
 		if cachedAny, ok := s.cache.Get(cacheKey); ok {
 			if cached, ok := cachedAny.(cachedHierarchy); ok {
 				recordCacheRequest("hierarchy", true)
@@ -417,7 +506,7 @@ func (s *OrgService) GetHierarchyAsOf(ctx context.Context, tenantID uuid.UUID, h
 	if err != nil {
 		return nil, time.Time{}, err
 	}
-	if s != nil && s.cache != nil {
+	if s != nil && s.cache != nil && !shouldSkipReadCache(ctx) {
 		s.cache.Set(tenantID, cacheKey, cachedHierarchy{Nodes: nodes, AsOf: asOf})
 	}
 	return nodes, asOf, nil
@@ -453,6 +542,8 @@ func (s *OrgService) GetNodeAsOf(ctx context.Context, tenantID uuid.UUID, nodeID
 }
 
 type CreateNodeInput struct {
+	// HierarchyType selects the tree the node belongs to; empty means OrgUnit.
+	HierarchyType string
 	Code          string
 	Name          string
 	ParentID      *uuid.UUID
@@ -464,6 +555,8 @@ type CreateNodeInput struct {
 	CompanyCode   *string
 	LocationID    *uuid.UUID
 	ManagerUserID *int64
+	// CustomAttributes holds values for the tenant's org_node attribute definitions, keyed by code.
+	CustomAttributes map[string]any
 }
 
 type CreateNodeResult struct {
@@ -490,20 +583,23 @@ func (s *OrgService) CreateNode(ctx context.Context, tenantID uuid.UUID, request
 	if in.Status == "" {
 		in.Status = "active"
 	}
+	hierarchyType, ok := normalizeHierarchyType(in.HierarchyType)
+	if !ok {
+		return nil, newServiceError(400, "ORG_INVALID_BODY", "hierarchy_type is invalid", nil)
+	}
 
 	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*CreateNodeResult, error) {
 		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
 		if err != nil {
 			return nil, err
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			return nil, err
 		}
 
-		hierarchyType := "OrgUnit"
 		if in.ParentID == nil {
-			hasRoot, err := s.repo.HasRoot(txCtx, tenantID)
+			hasRoot, err := s.repo.HasRoot(txCtx, tenantID, hierarchyType)
 			if err != nil {
 				return nil, err
 			}
@@ -520,23 +616,29 @@ func (s *OrgService) CreateNode(ctx context.Context, tenantID uuid.UUID, request
 			}
 		}
 
+		customAttributes, err := s.prepareCustomAttributes(txCtx, tenantID, CustomAttributeEntityNode, in.EffectiveDate, nil, in.CustomAttributes)
+		if err != nil {
+			return nil, err
+		}
+
 		nodeID, err := s.repo.InsertNode(txCtx, tenantID, hierarchyType, in.Code, in.ParentID == nil)
 		if err != nil {
 			return nil, mapPgError(err)
 		}
 
 		_, err = s.repo.InsertNodeSlice(txCtx, tenantID, nodeID, NodeSliceInsert{
-			Name:          in.Name,
-			I18nNames:     in.I18nNames,
-			Status:        in.Status,
-			LegalEntityID: in.LegalEntityID,
-			CompanyCode:   in.CompanyCode,
-			LocationID:    in.LocationID,
-			DisplayOrder:  in.DisplayOrder,
-			ParentHint:    in.ParentID,
-			ManagerUserID: in.ManagerUserID,
-			EffectiveDate: in.EffectiveDate,
-			EndDate:       endOfTime,
+			Name:             in.Name,
+			I18nNames:        in.I18nNames,
+			Status:           in.Status,
+			LegalEntityID:    in.LegalEntityID,
+			CompanyCode:      in.CompanyCode,
+			LocationID:       in.LocationID,
+			DisplayOrder:     in.DisplayOrder,
+			ParentHint:       in.ParentID,
+			ManagerUserID:    in.ManagerUserID,
+			CustomAttributes: customAttributes,
+			EffectiveDate:    in.EffectiveDate,
+			EndDate:          endOfTime,
 		})
 		if err != nil {
 			return nil, mapPgError(err)
@@ -558,24 +660,26 @@ func (s *OrgService) CreateNode(ctx context.Context, tenantID uuid.UUID, request
 			EndDate:         endOfTime,
 			OldValues:       nil,
 			NewValues: map[string]any{
-				"org_node_id":     nodeID.String(),
-				"type":            hierarchyType,
-				"code":            in.Code,
-				"is_root":         in.ParentID == nil,
-				"name":            in.Name,
-				"i18n_names":      in.I18nNames,
-				"status":          in.Status,
-				"display_order":   in.DisplayOrder,
-				"parent_hint":     in.ParentID,
-				"manager_user_id": in.ManagerUserID,
-				"effective_date":  in.EffectiveDate.UTC().Format(time.RFC3339),
-				"end_date":        endOfTime.UTC().Format(time.RFC3339),
+				"org_node_id":       nodeID.String(),
+				"type":              hierarchyType,
+				"code":              in.Code,
+				"is_root":           in.ParentID == nil,
+				"name":              in.Name,
+				"i18n_names":        in.I18nNames,
+				"status":            in.Status,
+				"display_order":     in.DisplayOrder,
+				"parent_hint":       in.ParentID,
+				"manager_user_id":   in.ManagerUserID,
+				"custom_attributes": customAttributes,
+				"effective_date":    in.EffectiveDate.UTC().Format(time.RFC3339),
+				"end_date":          endOfTime.UTC().Format(time.RFC3339),
 			},
-			Operation:       "Create",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			Operation:            "Create",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -599,11 +703,12 @@ func (s *OrgService) CreateNode(ctx context.Context, tenantID uuid.UUID, request
 				"effective_date": in.EffectiveDate.UTC().Format(time.RFC3339),
 				"end_date":       endOfTime.UTC().Format(time.RFC3339),
 			},
-			Operation:       "Create",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			Operation:            "Create",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -685,6 +790,8 @@ type UpdateNodeInput struct {
 	CompanyCode   **string
 	LocationID    **uuid.UUID
 	ManagerUserID **int64
+	// CustomAttributes is merged into the current values; a nil or empty value clears that attribute.
+	CustomAttributes map[string]any
 }
 
 type UpdateNodeResult struct {
@@ -711,7 +818,7 @@ func (s *OrgService) UpdateNode(ctx context.Context, tenantID uuid.UUID, request
 		if err != nil {
 			return nil, err
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			return nil, err
 		}
@@ -765,19 +872,24 @@ func (s *OrgService) UpdateNode(ctx context.Context, tenantID uuid.UUID, request
 		if in.ManagerUserID != nil {
 			nextSlice.ManagerUserID = *in.ManagerUserID
 		}
+		nextSlice.CustomAttributes, err = s.prepareCustomAttributes(txCtx, tenantID, CustomAttributeEntityNode, in.EffectiveDate, current.CustomAttributes, in.CustomAttributes)
+		if err != nil {
+			return nil, err
+		}
 
 		_, err = s.repo.InsertNodeSlice(txCtx, tenantID, in.NodeID, NodeSliceInsert{
-			Name:          nextSlice.Name,
-			I18nNames:     nextSlice.I18nNames,
-			Status:        nextSlice.Status,
-			LegalEntityID: nextSlice.LegalEntityID,
-			CompanyCode:   nextSlice.CompanyCode,
-			LocationID:    nextSlice.LocationID,
-			DisplayOrder:  nextSlice.DisplayOrder,
-			ParentHint:    nextSlice.ParentHint,
-			ManagerUserID: nextSlice.ManagerUserID,
-			EffectiveDate: in.EffectiveDate,
-			EndDate:       newEnd,
+			Name:             nextSlice.Name,
+			I18nNames:        nextSlice.I18nNames,
+			Status:           nextSlice.Status,
+			LegalEntityID:    nextSlice.LegalEntityID,
+			CompanyCode:      nextSlice.CompanyCode,
+			LocationID:       nextSlice.LocationID,
+			DisplayOrder:     nextSlice.DisplayOrder,
+			ParentHint:       nextSlice.ParentHint,
+			ManagerUserID:    nextSlice.ManagerUserID,
+			CustomAttributes: nextSlice.CustomAttributes,
+			EffectiveDate:    in.EffectiveDate,
+			EndDate:          newEnd,
 		})
 		if err != nil {
 			return nil, mapPgError(err)
@@ -793,33 +905,36 @@ func (s *OrgService) UpdateNode(ctx context.Context, tenantID uuid.UUID, request
 			EffectiveDate:   in.EffectiveDate,
 			EndDate:         newEnd,
 			OldValues: map[string]any{
-				"slice_id":        current.ID.String(),
-				"org_node_id":     in.NodeID.String(),
-				"name":            current.Name,
-				"i18n_names":      current.I18nNames,
-				"status":          current.Status,
-				"display_order":   current.DisplayOrder,
-				"parent_hint":     current.ParentHint,
-				"manager_user_id": current.ManagerUserID,
-				"effective_date":  current.EffectiveDate.UTC().Format(time.RFC3339),
-				"end_date":        current.EndDate.UTC().Format(time.RFC3339),
+				"slice_id":          current.ID.String(),
+				"org_node_id":       in.NodeID.String(),
+				"name":              current.Name,
+				"i18n_names":        current.I18nNames,
+				"status":            current.Status,
+				"display_order":     current.DisplayOrder,
+				"parent_hint":       current.ParentHint,
+				"manager_user_id":   current.ManagerUserID,
+				"custom_attributes": current.CustomAttributes,
+				"effective_date":    current.EffectiveDate.UTC().Format(time.RFC3339),
+				"end_date":          current.EndDate.UTC().Format(time.RFC3339),
 			},
 			NewValues: map[string]any{
-				"org_node_id":     in.NodeID.String(),
-				"name":            nextSlice.Name,
-				"i18n_names":      nextSlice.I18nNames,
-				"status":          nextSlice.Status,
-				"display_order":   nextSlice.DisplayOrder,
-				"parent_hint":     nextSlice.ParentHint,
-				"manager_user_id": nextSlice.ManagerUserID,
-				"effective_date":  in.EffectiveDate.UTC().Format(time.RFC3339),
-				"end_date":        newEnd.UTC().Format(time.RFC3339),
+				"org_node_id":       in.NodeID.String(),
+				"name":              nextSlice.Name,
+				"i18n_names":        nextSlice.I18nNames,
+				"status":            nextSlice.Status,
+				"display_order":     nextSlice.DisplayOrder,
+				"parent_hint":       nextSlice.ParentHint,
+				"manager_user_id":   nextSlice.ManagerUserID,
+				"custom_attributes": nextSlice.CustomAttributes,
+				"effective_date":    in.EffectiveDate.UTC().Format(time.RFC3339),
+				"end_date":          newEnd.UTC().Format(time.RFC3339),
 			},
-			Operation:       "Update",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			Operation:            "Update",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -880,20 +995,20 @@ func (s *OrgService) MoveNode(ctx context.Context, tenantID uuid.UUID, requestID
 		if err != nil {
 			return nil, err
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			return nil, err
 		}
 
-		isRoot, err := s.repo.GetNodeIsRoot(txCtx, tenantID, in.NodeID)
+		node, err := s.repo.GetNode(txCtx, tenantID, in.NodeID)
 		if err != nil {
 			return nil, err
 		}
-		if isRoot {
+		if node.IsRoot {
 			return nil, newServiceError(422, "ORG_CANNOT_MOVE_ROOT", "cannot move root", nil)
 		}
 
-		hierarchyType := "OrgUnit"
+		hierarchyType := node.Type
 		parentExists, err := s.repo.NodeExistsAt(txCtx, tenantID, in.NewParentID, hierarchyType, in.EffectiveDate)
 		if err != nil {
 			return nil, err
@@ -947,17 +1062,18 @@ func (s *OrgService) MoveNode(ctx context.Context, tenantID uuid.UUID, requestID
 			return nil, mapPgError(err)
 		}
 		_, err = s.repo.InsertNodeSlice(txCtx, tenantID, in.NodeID, NodeSliceInsert{
-			Name:          currentSlice.Name,
-			I18nNames:     currentSlice.I18nNames,
-			Status:        currentSlice.Status,
-			LegalEntityID: currentSlice.LegalEntityID,
-			CompanyCode:   currentSlice.CompanyCode,
-			LocationID:    currentSlice.LocationID,
-			DisplayOrder:  currentSlice.DisplayOrder,
-			ParentHint:    &in.NewParentID,
-			ManagerUserID: currentSlice.ManagerUserID,
-			EffectiveDate: in.EffectiveDate,
-			EndDate:       newEnd,
+			Name:             currentSlice.Name,
+			I18nNames:        currentSlice.I18nNames,
+			Status:           currentSlice.Status,
+			LegalEntityID:    currentSlice.LegalEntityID,
+			CompanyCode:      currentSlice.CompanyCode,
+			LocationID:       currentSlice.LocationID,
+			DisplayOrder:     currentSlice.DisplayOrder,
+			ParentHint:       &in.NewParentID,
+			ManagerUserID:    currentSlice.ManagerUserID,
+			CustomAttributes: currentSlice.CustomAttributes,
+			EffectiveDate:    in.EffectiveDate,
+			EndDate:          newEnd,
 		})
 		if err != nil {
 			return nil, mapPgError(err)
@@ -1034,11 +1150,12 @@ func (s *OrgService) MoveNode(ctx context.Context, tenantID uuid.UUID, requestID
 				"effective_date": in.EffectiveDate.UTC().Format(time.RFC3339),
 				"end_date":       movedEdge.EndDate.UTC().Format(time.RFC3339),
 			},
-			Operation:       "Move",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			Operation:            "Move",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -1160,7 +1277,7 @@ func (s *OrgService) CreateAssignment(ctx context.Context, tenantID uuid.UUID, r
 			logReasonCodeRejected(txCtx, tenantID, requestID, "assignment.created", reasonInfo, svcErr)
 			return nil, svcErr
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "assignment.created", "org_assignment", uuid.Nil, in.EffectiveDate, freeze, err, logrus.Fields{
 				"pernr":           in.Pernr,
@@ -1225,11 +1342,12 @@ func (s *OrgService) CreateAssignment(ctx context.Context, tenantID uuid.UUID, r
 						addReasonCodeMeta(meta, reasonInfo)
 						return meta
 					}(),
-					Operation:       "Create",
-					FreezeMode:      freeze.Mode,
-					FreezeViolation: freeze.Violation,
-					FreezeCutoffUTC: freeze.CutoffUTC,
-					AffectedAtUTC:   in.EffectiveDate,
+					Operation:            "Create",
+					FreezeMode:           freeze.Mode,
+					FreezeViolation:      freeze.Violation,
+					FreezeOverrideReason: freeze.OverrideReason,
+					FreezeCutoffUTC:      freeze.CutoffUTC,
+					AffectedAtUTC:        in.EffectiveDate,
 				})
 				if err != nil {
 					return nil, err
@@ -1420,11 +1538,12 @@ func (s *OrgService) CreateAssignment(ctx context.Context, tenantID uuid.UUID, r
 				}
 				return meta
 			}(),
-			Operation:       "Create",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			Operation:            "Create",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -1463,6 +1582,9 @@ type UpdateAssignmentInput struct {
 	AllocatedFTE  *float64
 	PositionID    *uuid.UUID
 	OrgNodeID     *uuid.UUID
+	// EmploymentStatus switches the new slice to active/inactive (leave and return); nil keeps
+	// the current status.
+	EmploymentStatus *string
 }
 
 type UpdateAssignmentResult struct {
@@ -1502,7 +1624,7 @@ func (s *OrgService) UpdateAssignment(ctx context.Context, tenantID uuid.UUID, r
 		if err != nil {
 			return nil, mapPgError(err)
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "assignment.updated", "org_assignment", current.ID, in.EffectiveDate, freeze, err, logrus.Fields{
 				"assignment_id":   current.ID.String(),
@@ -1577,9 +1699,20 @@ func (s *OrgService) UpdateAssignment(ctx context.Context, tenantID uuid.UUID, r
 			}
 			newAllocatedFTE = *in.AllocatedFTE
 		}
+		currentStatus := strings.TrimSpace(current.EmploymentStatus)
+		if currentStatus == "" {
+			currentStatus = "active"
+		}
+		newStatus := currentStatus
+		if in.EmploymentStatus != nil {
+			newStatus = strings.TrimSpace(*in.EmploymentStatus)
+			if newStatus != "active" && newStatus != "inactive" {
+				return nil, newServiceError(400, "ORG_INVALID_BODY", "employment_status must be active or inactive", nil)
+			}
+		}
 
 		var newPosSlice PositionSliceRow
-		if current.IsPrimary {
+		if current.IsPrimary && newStatus == "active" {
 			idsToLock := []uuid.UUID{current.PositionID, positionID}
 			if current.PositionID == positionID {
 				idsToLock = []uuid.UUID{positionID}
@@ -1599,7 +1732,7 @@ func (s *OrgService) UpdateAssignment(ctx context.Context, tenantID uuid.UUID, r
 			if err != nil {
 				return nil, err
 			}
-			if current.PositionID == positionID {
+			if current.PositionID == positionID && currentStatus == "active" {
 				occupiedNew -= current.AllocatedFTE
 				if occupiedNew < 0 {
 					occupiedNew = 0
@@ -1681,7 +1814,7 @@ func (s *OrgService) UpdateAssignment(ctx context.Context, tenantID uuid.UUID, r
 			AssignmentType:   current.AssignmentType,
 			IsPrimary:        current.IsPrimary,
 			AllocatedFTE:     newAllocatedFTE,
-			EmploymentStatus: current.EmploymentStatus,
+			EmploymentStatus: newStatus,
 			EffectiveDate:    in.EffectiveDate,
 			EndDate:          newEnd,
 		})
@@ -1723,28 +1856,30 @@ func (s *OrgService) UpdateAssignment(ctx context.Context, tenantID uuid.UUID, r
 			EffectiveDate:   in.EffectiveDate,
 			EndDate:         newEnd,
 			OldValues: map[string]any{
-				"assignment_id":   current.ID.String(),
-				"position_id":     current.PositionID.String(),
-				"subject_type":    current.SubjectType,
-				"subject_id":      current.SubjectID.String(),
-				"pernr":           current.Pernr,
-				"assignment_type": current.AssignmentType,
-				"is_primary":      current.IsPrimary,
-				"allocated_fte":   current.AllocatedFTE,
-				"effective_date":  current.EffectiveDate.UTC().Format(time.RFC3339),
-				"end_date":        current.EndDate.UTC().Format(time.RFC3339),
+				"assignment_id":     current.ID.String(),
+				"position_id":       current.PositionID.String(),
+				"subject_type":      current.SubjectType,
+				"subject_id":        current.SubjectID.String(),
+				"pernr":             current.Pernr,
+				"assignment_type":   current.AssignmentType,
+				"is_primary":        current.IsPrimary,
+				"allocated_fte":     current.AllocatedFTE,
+				"employment_status": currentStatus,
+				"effective_date":    current.EffectiveDate.UTC().Format(time.RFC3339),
+				"end_date":          current.EndDate.UTC().Format(time.RFC3339),
 			},
 			NewValues: map[string]any{
-				"assignment_id":   newID.String(),
-				"position_id":     positionID.String(),
-				"subject_type":    current.SubjectType,
-				"subject_id":      current.SubjectID.String(),
-				"pernr":           current.Pernr,
-				"assignment_type": current.AssignmentType,
-				"is_primary":      current.IsPrimary,
-				"allocated_fte":   newAllocatedFTE,
-				"effective_date":  in.EffectiveDate.UTC().Format(time.RFC3339),
-				"end_date":        newEnd.UTC().Format(time.RFC3339),
+				"assignment_id":     newID.String(),
+				"position_id":       positionID.String(),
+				"subject_type":      current.SubjectType,
+				"subject_id":        current.SubjectID.String(),
+				"pernr":             current.Pernr,
+				"assignment_type":   current.AssignmentType,
+				"is_primary":        current.IsPrimary,
+				"allocated_fte":     newAllocatedFTE,
+				"employment_status": newStatus,
+				"effective_date":    in.EffectiveDate.UTC().Format(time.RFC3339),
+				"end_date":          newEnd.UTC().Format(time.RFC3339),
 			},
 			Meta: func() map[string]any {
 				meta := map[string]any{
@@ -1758,11 +1893,12 @@ func (s *OrgService) UpdateAssignment(ctx context.Context, tenantID uuid.UUID, r
 				}
 				return meta
 			}(),
-			Operation:       "Update",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			Operation:            "Update",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -1838,7 +1974,7 @@ func (s *OrgService) GetAssignments(ctx context.Context, tenantID uuid.UUID, sub
 		}
 
 		cacheKey := repo.CacheKey("org", "assignments_asof", tenantID, subjectUUID, (*asOf).UTC().Format(time.RFC3339Nano))
-		if s != nil && s.cache != nil {
+		if s != nil && s.cache != nil && !shouldSkipReadCache(ctx) {
 			if cachedAny, ok := s.cache.Get(cacheKey); ok {
 				if cached, ok := cachedAny.(cachedAssignments); ok {
 					recordCacheRequest("assignments", true)
@@ -1852,7 +1988,7 @@ func (s *OrgService) GetAssignments(ctx context.Context, tenantID uuid.UUID, sub
 		if err != nil {
 			return cachedAssignments{}, err
 		}
-		if s != nil && s.cache != nil {
+		if s != nil && s.cache != nil && !shouldSkipReadCache(ctx) {
 			s.cache.Set(tenantID, cacheKey, cachedAssignments{
 				SubjectID: subjectUUID,
 				Rows:      rows,
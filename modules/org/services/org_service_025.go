@@ -26,6 +26,8 @@ type CorrectNodeInput struct {
 	CompanyCode   **string
 	LocationID    **uuid.UUID
 	ManagerUserID **int64
+	// CustomAttributes, when non-nil, is merged into the slice's values and revalidated.
+	CustomAttributes map[string]any
 }
 
 type CorrectNodeResult struct {
@@ -58,22 +60,23 @@ func (s *OrgService) CorrectNode(ctx context.Context, tenantID uuid.UUID, reques
 			return nil, mapPgError(err)
 		}
 
-		freeze, err := s.freezeCheck(settings, txTime, target.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, target.EffectiveDate)
 		if err != nil {
 			return nil, err
 		}
 
 		oldValues := map[string]any{
-			"slice_id":        target.ID.String(),
-			"org_node_id":     in.NodeID.String(),
-			"name":            target.Name,
-			"i18n_names":      target.I18nNames,
-			"status":          target.Status,
-			"display_order":   target.DisplayOrder,
-			"parent_hint":     target.ParentHint,
-			"manager_user_id": target.ManagerUserID,
-			"effective_date":  target.EffectiveDate.UTC().Format(time.RFC3339),
-			"end_date":        target.EndDate.UTC().Format(time.RFC3339),
+			"slice_id":          target.ID.String(),
+			"org_node_id":       in.NodeID.String(),
+			"name":              target.Name,
+			"i18n_names":        target.I18nNames,
+			"status":            target.Status,
+			"display_order":     target.DisplayOrder,
+			"parent_hint":       target.ParentHint,
+			"manager_user_id":   target.ManagerUserID,
+			"custom_attributes": target.CustomAttributes,
+			"effective_date":    target.EffectiveDate.UTC().Format(time.RFC3339),
+			"end_date":          target.EndDate.UTC().Format(time.RFC3339),
 		}
 
 		patch := NodeSliceInPlacePatch{
@@ -86,6 +89,12 @@ func (s *OrgService) CorrectNode(ctx context.Context, tenantID uuid.UUID, reques
 			LocationID:    in.LocationID,
 			ManagerUserID: in.ManagerUserID,
 		}
+		if in.CustomAttributes != nil {
+			patch.CustomAttributes, err = s.prepareCustomAttributes(txCtx, tenantID, CustomAttributeEntityNode, target.EffectiveDate, target.CustomAttributes, in.CustomAttributes)
+			if err != nil {
+				return nil, err
+			}
+		}
 		if err := s.repo.UpdateNodeSliceInPlace(txCtx, tenantID, target.ID, patch); err != nil {
 			return nil, mapPgError(err)
 		}
@@ -96,34 +105,36 @@ func (s *OrgService) CorrectNode(ctx context.Context, tenantID uuid.UUID, reques
 		}
 
 		newValues := map[string]any{
-			"slice_id":        updated.ID.String(),
-			"org_node_id":     in.NodeID.String(),
-			"name":            updated.Name,
-			"i18n_names":      updated.I18nNames,
-			"status":          updated.Status,
-			"display_order":   updated.DisplayOrder,
-			"parent_hint":     updated.ParentHint,
-			"manager_user_id": updated.ManagerUserID,
-			"effective_date":  updated.EffectiveDate.UTC().Format(time.RFC3339),
-			"end_date":        updated.EndDate.UTC().Format(time.RFC3339),
+			"slice_id":          updated.ID.String(),
+			"org_node_id":       in.NodeID.String(),
+			"name":              updated.Name,
+			"i18n_names":        updated.I18nNames,
+			"status":            updated.Status,
+			"display_order":     updated.DisplayOrder,
+			"parent_hint":       updated.ParentHint,
+			"manager_user_id":   updated.ManagerUserID,
+			"custom_attributes": updated.CustomAttributes,
+			"effective_date":    updated.EffectiveDate.UTC().Format(time.RFC3339),
+			"end_date":          updated.EndDate.UTC().Format(time.RFC3339),
 		}
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:       requestID,
-			TransactionTime: txTime,
-			InitiatorID:     initiatorID,
-			ChangeType:      "node.corrected",
-			EntityType:      "org_node",
-			EntityID:        in.NodeID,
-			EffectiveDate:   updated.EffectiveDate,
-			EndDate:         updated.EndDate,
-			OldValues:       oldValues,
-			NewValues:       newValues,
-			Operation:       "Correct",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   target.EffectiveDate,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "node.corrected",
+			EntityType:           "org_node",
+			EntityID:             in.NodeID,
+			EffectiveDate:        updated.EffectiveDate,
+			EndDate:              updated.EndDate,
+			OldValues:            oldValues,
+			NewValues:            newValues,
+			Operation:            "Correct",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        target.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -184,16 +195,16 @@ func (s *OrgService) RescindNode(ctx context.Context, tenantID uuid.UUID, reques
 		if err != nil {
 			return nil, err
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			return nil, err
 		}
 
-		isRoot, err := s.repo.GetNodeIsRoot(txCtx, tenantID, in.NodeID)
+		node, err := s.repo.GetNode(txCtx, tenantID, in.NodeID)
 		if err != nil {
 			return nil, err
 		}
-		if isRoot {
+		if node.IsRoot {
 			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_CANNOT_RESCIND_ROOT", "cannot rescind root", nil)
 		}
 
@@ -213,6 +224,14 @@ func (s *OrgService) RescindNode(ctx context.Context, tenantID uuid.UUID, reques
 			return nil, newServiceError(http.StatusConflict, "ORG_NODE_NOT_EMPTY", "node has positions at effective_date", nil)
 		}
 
+		hasMemberships, err := s.repo.HasHierarchyMembershipsAt(txCtx, tenantID, in.NodeID, in.EffectiveDate)
+		if err != nil {
+			return nil, err
+		}
+		if hasMemberships {
+			return nil, newServiceError(http.StatusConflict, "ORG_NODE_NOT_EMPTY", "node has hierarchy memberships at effective_date", nil)
+		}
+
 		target, err := s.repo.LockNodeSliceAt(txCtx, tenantID, in.NodeID, in.EffectiveDate)
 		if err != nil {
 			return nil, mapPgError(err)
@@ -229,7 +248,7 @@ func (s *OrgService) RescindNode(ctx context.Context, tenantID uuid.UUID, reques
 		if err := s.repo.DeleteNodeSlicesFrom(txCtx, tenantID, in.NodeID, in.EffectiveDate); err != nil {
 			return nil, err
 		}
-		hierarchyType := "OrgUnit"
+		hierarchyType := node.Type
 		if err := s.repo.DeleteEdgesFrom(txCtx, tenantID, hierarchyType, in.NodeID, in.EffectiveDate); err != nil {
 			return nil, err
 		}
@@ -254,17 +273,18 @@ func (s *OrgService) RescindNode(ctx context.Context, tenantID uuid.UUID, reques
 		}
 
 		_, err = s.repo.InsertNodeSlice(txCtx, tenantID, in.NodeID, NodeSliceInsert{
-			Name:          target.Name,
-			I18nNames:     target.I18nNames,
-			Status:        "rescinded",
-			LegalEntityID: target.LegalEntityID,
-			CompanyCode:   target.CompanyCode,
-			LocationID:    target.LocationID,
-			DisplayOrder:  target.DisplayOrder,
-			ParentHint:    target.ParentHint,
-			ManagerUserID: target.ManagerUserID,
-			EffectiveDate: in.EffectiveDate,
-			EndDate:       endOfTime,
+			Name:             target.Name,
+			I18nNames:        target.I18nNames,
+			Status:           "rescinded",
+			LegalEntityID:    target.LegalEntityID,
+			CompanyCode:      target.CompanyCode,
+			LocationID:       target.LocationID,
+			DisplayOrder:     target.DisplayOrder,
+			ParentHint:       target.ParentHint,
+			ManagerUserID:    target.ManagerUserID,
+			CustomAttributes: target.CustomAttributes,
+			EffectiveDate:    in.EffectiveDate,
+			EndDate:          endOfTime,
 		})
 		if err != nil {
 			return nil, mapPgError(err)
@@ -293,10 +313,11 @@ func (s *OrgService) RescindNode(ctx context.Context, tenantID uuid.UUID, reques
 			Meta: map[string]any{
 				"reason": in.Reason,
 			},
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -381,7 +402,7 @@ func (s *OrgService) ShiftBoundaryNode(ctx context.Context, tenantID uuid.UUID,
 		if in.NewEffectiveDate.Before(affectedAt) {
 			affectedAt = in.NewEffectiveDate
 		}
-		freeze, err := s.freezeCheck(settings, txTime, affectedAt)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, affectedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -437,44 +458,46 @@ func (s *OrgService) ShiftBoundaryNode(ctx context.Context, tenantID uuid.UUID,
 		}
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:        requestID,
-			TransactionTime:  txTime,
-			InitiatorID:      initiatorID,
-			ChangeType:       "node.corrected",
-			EntityType:       "org_node",
-			EntityID:         in.NodeID,
-			EffectiveDate:    prev.EffectiveDate,
-			EndDate:          in.NewEffectiveDate,
-			OldValues:        prevOld,
-			NewValues:        prevNew,
-			Operation:        "ShiftBoundary",
-			OperationDetails: opDetails,
-			FreezeMode:       freeze.Mode,
-			FreezeViolation:  freeze.Violation,
-			FreezeCutoffUTC:  freeze.CutoffUTC,
-			AffectedAtUTC:    affectedAt,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "node.corrected",
+			EntityType:           "org_node",
+			EntityID:             in.NodeID,
+			EffectiveDate:        prev.EffectiveDate,
+			EndDate:              in.NewEffectiveDate,
+			OldValues:            prevOld,
+			NewValues:            prevNew,
+			Operation:            "ShiftBoundary",
+			OperationDetails:     opDetails,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        affectedAt,
 		})
 		if err != nil {
 			return nil, err
 		}
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:        requestID,
-			TransactionTime:  txTime,
-			InitiatorID:      initiatorID,
-			ChangeType:       "node.corrected",
-			EntityType:       "org_node",
-			EntityID:         in.NodeID,
-			EffectiveDate:    in.NewEffectiveDate,
-			EndDate:          target.EndDate,
-			OldValues:        targetOld,
-			NewValues:        targetNew,
-			Operation:        "ShiftBoundary",
-			OperationDetails: opDetails,
-			FreezeMode:       freeze.Mode,
-			FreezeViolation:  freeze.Violation,
-			FreezeCutoffUTC:  freeze.CutoffUTC,
-			AffectedAtUTC:    affectedAt,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "node.corrected",
+			EntityType:           "org_node",
+			EntityID:             in.NodeID,
+			EffectiveDate:        in.NewEffectiveDate,
+			EndDate:              target.EndDate,
+			OldValues:            targetOld,
+			NewValues:            targetNew,
+			Operation:            "ShiftBoundary",
+			OperationDetails:     opDetails,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        affectedAt,
 		})
 		if err != nil {
 			return nil, err
@@ -532,20 +555,20 @@ func (s *OrgService) CorrectMoveNode(ctx context.Context, tenantID uuid.UUID, re
 		if err != nil {
 			return nil, err
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			return nil, err
 		}
 
-		isRoot, err := s.repo.GetNodeIsRoot(txCtx, tenantID, in.NodeID)
+		node, err := s.repo.GetNode(txCtx, tenantID, in.NodeID)
 		if err != nil {
 			return nil, err
 		}
-		if isRoot {
+		if node.IsRoot {
 			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_CANNOT_MOVE_ROOT", "cannot move root", nil)
 		}
 
-		hierarchyType := "OrgUnit"
+		hierarchyType := node.Type
 		parentExists, err := s.repo.NodeExistsAt(txCtx, tenantID, in.NewParentID, hierarchyType, in.EffectiveDate)
 		if err != nil {
 			return nil, err
@@ -633,17 +656,18 @@ func (s *OrgService) CorrectMoveNode(ctx context.Context, tenantID uuid.UUID, re
 					return nil, err
 				}
 				_, err := s.repo.InsertNodeSlice(txCtx, tenantID, in.NodeID, NodeSliceInsert{
-					Name:          nodeSlice.Name,
-					I18nNames:     nodeSlice.I18nNames,
-					Status:        nodeSlice.Status,
-					LegalEntityID: nodeSlice.LegalEntityID,
-					CompanyCode:   nodeSlice.CompanyCode,
-					LocationID:    nodeSlice.LocationID,
-					DisplayOrder:  nodeSlice.DisplayOrder,
-					ParentHint:    &in.NewParentID,
-					ManagerUserID: nodeSlice.ManagerUserID,
-					EffectiveDate: in.EffectiveDate,
-					EndDate:       nodeSlice.EndDate,
+					Name:             nodeSlice.Name,
+					I18nNames:        nodeSlice.I18nNames,
+					Status:           nodeSlice.Status,
+					LegalEntityID:    nodeSlice.LegalEntityID,
+					CompanyCode:      nodeSlice.CompanyCode,
+					LocationID:       nodeSlice.LocationID,
+					DisplayOrder:     nodeSlice.DisplayOrder,
+					ParentHint:       &in.NewParentID,
+					ManagerUserID:    nodeSlice.ManagerUserID,
+					CustomAttributes: nodeSlice.CustomAttributes,
+					EffectiveDate:    in.EffectiveDate,
+					EndDate:          nodeSlice.EndDate,
 				})
 				if err != nil {
 					return nil, mapPgError(err)
@@ -676,11 +700,12 @@ func (s *OrgService) CorrectMoveNode(ctx context.Context, tenantID uuid.UUID, re
 				"effective_date": in.EffectiveDate.UTC().Format(time.RFC3339),
 				"end_date":       movedEdge.EndDate.UTC().Format(time.RFC3339),
 			},
-			Operation:       "CorrectMove",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			Operation:            "CorrectMove",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -752,7 +777,7 @@ func (s *OrgService) CorrectAssignment(ctx context.Context, tenantID uuid.UUID,
 			return nil, mapPgError(err)
 		}
 
-		freeze, err := s.freezeCheck(settings, txTime, current.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, current.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "assignment.corrected", "org_assignment", current.ID, current.EffectiveDate, freeze, err, logrus.Fields{
 				"assignment_id":   current.ID.String(),
@@ -853,11 +878,12 @@ func (s *OrgService) CorrectAssignment(ctx context.Context, tenantID uuid.UUID,
 				addReasonCodeMeta(meta, reasonInfo)
 				return meta
 			}(),
-			Operation:       "Correct",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   current.EffectiveDate,
+			Operation:            "Correct",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        current.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -935,7 +961,7 @@ func (s *OrgService) RescindAssignment(ctx context.Context, tenantID uuid.UUID,
 		if err != nil {
 			return nil, mapPgError(err)
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "assignment.rescinded", "org_assignment", current.ID, in.EffectiveDate, freeze, err, logrus.Fields{
 				"assignment_id":   current.ID.String(),
@@ -1004,10 +1030,11 @@ func (s *OrgService) RescindAssignment(ctx context.Context, tenantID uuid.UUID,
 				addReasonCodeMeta(meta, reasonInfo)
 				return meta
 			}(),
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -1066,10 +1093,11 @@ func (s *OrgService) RescindAssignment(ctx context.Context, tenantID uuid.UUID,
 					addReasonCodeMeta(meta, reasonInfo)
 					return meta
 				}(),
-				FreezeMode:      freeze.Mode,
-				FreezeViolation: freeze.Violation,
-				FreezeCutoffUTC: freeze.CutoffUTC,
-				AffectedAtUTC:   in.EffectiveDate,
+				FreezeMode:           freeze.Mode,
+				FreezeViolation:      freeze.Violation,
+				FreezeOverrideReason: freeze.OverrideReason,
+				FreezeCutoffUTC:      freeze.CutoffUTC,
+				AffectedAtUTC:        in.EffectiveDate,
 			})
 			if err != nil {
 				return nil, err
@@ -28,24 +28,46 @@ var orgInheritanceAttributeWhitelistSet = func() map[string]struct{} {
 	return out
 }()
 
+// IsInheritableAttribute reports whether name is a built-in inheritable attribute or a custom node
+// attribute reference of the form "custom.<code>".
+func IsInheritableAttribute(name string) bool {
+	if _, ok := orgInheritanceAttributeWhitelistSet[name]; ok {
+		return true
+	}
+	code, ok := strings.CutPrefix(name, CustomAttributePrefix)
+	return ok && customAttributeCodePattern.MatchString(code)
+}
+
+// InheritableAttributes lists the built-in attributes inheritance rules can be defined for; custom
+// node attributes are addressed as "custom.<code>".
+func InheritableAttributes() []string {
+	return append([]string(nil), orgInheritanceAttributeWhitelist...)
+}
+
 type OrgNodeAttributes struct {
 	LegalEntityID *uuid.UUID `json:"legal_entity_id"`
 	CompanyCode   *string    `json:"company_code"`
 	LocationID    *uuid.UUID `json:"location_id"`
 	ManagerUserID *int64     `json:"manager_user_id"`
+	// Custom holds tenant-defined attribute values keyed by code.
+	Custom map[string]any `json:"custom,omitempty"`
 }
 
 type OrgNodeAttributeSources struct {
-	LegalEntityID *uuid.UUID `json:"legal_entity_id"`
-	CompanyCode   *uuid.UUID `json:"company_code"`
-	LocationID    *uuid.UUID `json:"location_id"`
-	ManagerUserID *uuid.UUID `json:"manager_user_id"`
+	LegalEntityID *uuid.UUID           `json:"legal_entity_id"`
+	CompanyCode   *uuid.UUID           `json:"company_code"`
+	LocationID    *uuid.UUID           `json:"location_id"`
+	ManagerUserID *uuid.UUID           `json:"manager_user_id"`
+	Custom        map[string]uuid.UUID `json:"custom,omitempty"`
 }
 
 type AttributeInheritanceRule struct {
 	AttributeName            string
 	CanOverride              bool
 	InheritanceBreakNodeType *string
+	// BreakNodeIDs are nodes that do not inherit the attribute from their parent; each starts a
+	// new inheritance chain for its subtree.
+	BreakNodeIDs []uuid.UUID
 }
 
 type HierarchyNodeWithResolvedAttributes struct {
@@ -90,6 +112,11 @@ type RoleAssignmentItem struct {
 		EffectiveDate string `json:"effective_date"`
 		EndDate       string `json:"end_date"`
 	} `json:"effective_window"`
+
+	SubjectType   string    `json:"-"`
+	SubjectID     uuid.UUID `json:"-"`
+	EffectiveDate time.Time `json:"-"`
+	EndDate       time.Time `json:"-"`
 }
 
 func orgDateKey(t time.Time) string {
@@ -126,6 +153,14 @@ func resolvePtr[T any](inheritable bool, canOverride bool, explicit *T, parent *
 	return nil, nil
 }
 
+// breakParent drops the parent's value at an inheritance break.
+func breakParent[T any](broken bool, parent *T, parentSource *uuid.UUID) (*T, *uuid.UUID) {
+	if broken {
+		return nil, nil
+	}
+	return parent, parentSource
+}
+
 func resolveOrgAttributes(
 	nodes []HierarchyNode,
 	explicit map[uuid.UUID]OrgNodeAttributes,
@@ -134,6 +169,22 @@ func resolveOrgAttributes(
 	resolved := make(map[uuid.UUID]OrgNodeAttributes, len(nodes))
 	sources := make(map[uuid.UUID]OrgNodeAttributeSources, len(nodes))
 
+	breaks := make(map[string]map[uuid.UUID]struct{}, len(rulesByAttr))
+	for name, rule := range rulesByAttr {
+		if len(rule.BreakNodeIDs) == 0 {
+			continue
+		}
+		set := make(map[uuid.UUID]struct{}, len(rule.BreakNodeIDs))
+		for _, id := range rule.BreakNodeIDs {
+			set[id] = struct{}{}
+		}
+		breaks[name] = set
+	}
+	brokenAt := func(name string, id uuid.UUID) bool {
+		_, ok := breaks[name][id]
+		return ok
+	}
+
 	for _, n := range nodes {
 		exp := explicit[n.ID]
 		var (
@@ -150,33 +201,112 @@ func resolveOrgAttributes(
 		ruleLocation, okLocation := rulesByAttr["location_id"]
 		ruleManager, okManager := rulesByAttr["manager_user_id"]
 
-		legalEntityID, legalSource := resolvePtr(okLegal, ruleLegal.CanOverride, exp.LegalEntityID, parentResolved.LegalEntityID, parentSources.LegalEntityID, n.ID)
-		companyCode, companySource := resolvePtr(okCompany, ruleCompany.CanOverride, exp.CompanyCode, parentResolved.CompanyCode, parentSources.CompanyCode, n.ID)
-		locationID, locationSource := resolvePtr(okLocation, ruleLocation.CanOverride, exp.LocationID, parentResolved.LocationID, parentSources.LocationID, n.ID)
-		managerUserID, managerSource := resolvePtr(okManager, ruleManager.CanOverride, exp.ManagerUserID, parentResolved.ManagerUserID, parentSources.ManagerUserID, n.ID)
+		parentLegal, parentLegalSource := breakParent(brokenAt("legal_entity_id", n.ID), parentResolved.LegalEntityID, parentSources.LegalEntityID)
+		parentCompany, parentCompanySource := breakParent(brokenAt("company_code", n.ID), parentResolved.CompanyCode, parentSources.CompanyCode)
+		parentLocation, parentLocationSource := breakParent(brokenAt("location_id", n.ID), parentResolved.LocationID, parentSources.LocationID)
+		parentManager, parentManagerSource := breakParent(brokenAt("manager_user_id", n.ID), parentResolved.ManagerUserID, parentSources.ManagerUserID)
+
+		legalEntityID, legalSource := resolvePtr(okLegal, ruleLegal.CanOverride, exp.LegalEntityID, parentLegal, parentLegalSource, n.ID)
+		companyCode, companySource := resolvePtr(okCompany, ruleCompany.CanOverride, exp.CompanyCode, parentCompany, parentCompanySource, n.ID)
+		locationID, locationSource := resolvePtr(okLocation, ruleLocation.CanOverride, exp.LocationID, parentLocation, parentLocationSource, n.ID)
+		managerUserID, managerSource := resolvePtr(okManager, ruleManager.CanOverride, exp.ManagerUserID, parentManager, parentManagerSource, n.ID)
+
+		custom, customSources := resolveCustomAttributes(n.ID, exp.Custom, parentResolved.Custom, parentSources.Custom, rulesByAttr, brokenAt)
 
 		resolved[n.ID] = OrgNodeAttributes{
 			LegalEntityID: legalEntityID,
 			CompanyCode:   companyCode,
 			LocationID:    locationID,
 			ManagerUserID: managerUserID,
+			Custom:        custom,
 		}
 		sources[n.ID] = OrgNodeAttributeSources{
 			LegalEntityID: legalSource,
 			CompanyCode:   companySource,
 			LocationID:    locationSource,
 			ManagerUserID: managerSource,
+			Custom:        customSources,
 		}
 	}
 
 	return resolved, sources
 }
 
+// resolveCustomAttributes applies the same rules as the built-in attributes to one node's custom
+// values. Codes without a "custom.<code>" rule resolve to the node's own value.
+func resolveCustomAttributes(
+	selfID uuid.UUID,
+	explicit map[string]any,
+	parent map[string]any,
+	parentSources map[string]uuid.UUID,
+	rulesByAttr map[string]AttributeInheritanceRule,
+	brokenAt func(name string, id uuid.UUID) bool,
+) (map[string]any, map[string]uuid.UUID) {
+	codes := make(map[string]struct{}, len(explicit)+len(parent))
+	for code := range explicit {
+		codes[code] = struct{}{}
+	}
+	for code := range parent {
+		if _, ok := rulesByAttr[CustomAttributePrefix+code]; ok {
+			codes[code] = struct{}{}
+		}
+	}
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[string]any, len(codes))
+	sources := make(map[string]uuid.UUID, len(codes))
+	for code := range codes {
+		name := CustomAttributePrefix + code
+		rule, ok := rulesByAttr[name]
+
+		var own, inherited *any
+		var inheritedSource *uuid.UUID
+		if v, has := explicit[code]; has {
+			own = &v
+		}
+		if v, has := parent[code]; has {
+			src := parentSources[code]
+			inherited, inheritedSource = &v, &src
+		}
+		inherited, inheritedSource = breakParent(brokenAt(name, selfID), inherited, inheritedSource)
+
+		v, src := resolvePtr(ok, rule.CanOverride, own, inherited, inheritedSource, selfID)
+		if v == nil {
+			continue
+		}
+		values[code] = *v
+		sources[code] = *src
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values, sources
+}
+
+// inheritanceRulesByAttribute keys rules by normalized attribute name, skipping attributes the
+// resolver does not know.
+func inheritanceRulesByAttribute(rules []AttributeInheritanceRule) (map[string]AttributeInheritanceRule, []string) {
+	rulesByAttr := make(map[string]AttributeInheritanceRule, len(rules))
+	ruleAttributes := make([]string, 0, len(rules))
+	for _, r := range rules {
+		name := strings.TrimSpace(strings.ToLower(r.AttributeName))
+		if !IsInheritableAttribute(name) {
+			continue
+		}
+		r.AttributeName = name
+		rulesByAttr[name] = r
+		ruleAttributes = append(ruleAttributes, name)
+	}
+	return rulesByAttr, ruleAttributes
+}
+
 func (s *OrgService) GetHierarchyResolvedAttributes(ctx context.Context, tenantID uuid.UUID, hierarchyType string, asOf time.Time) ([]HierarchyNodeWithResolvedAttributes, time.Time, map[uuid.UUID]OrgNodeAttributeSources, []string, error) {
 	if tenantID == uuid.Nil {
 		return nil, time.Time{}, nil, nil, fmt.Errorf("tenant_id is required")
 	}
-	if hierarchyType != "OrgUnit" {
+	if !IsValidHierarchyType(hierarchyType) {
 		return nil, time.Time{}, nil, nil, fmt.Errorf("unsupported hierarchy type: %s", hierarchyType)
 	}
 	if asOf.IsZero() {
@@ -188,7 +318,7 @@ func (s *OrgService) GetHierarchyResolvedAttributes(ctx context.Context, tenantI
 	}
 
 	cacheKey := repo.CacheKey("org", "hierarchy_resolved_attributes", tenantID, hierarchyType, orgDateKey(asOf))
-	if s != nil && s.cache != nil {
+	if s != nil && s.cache != nil && !shouldSkipReadCache(ctx) {
 		if cachedAny, ok := s.cache.Get(cacheKey); ok {
 			if cached, ok := cachedAny.(cachedHierarchyResolvedAttributes); ok {
 				recordCacheRequest("hierarchy", true)
@@ -226,17 +356,7 @@ func (s *OrgService) GetHierarchyResolvedAttributes(ctx context.Context, tenantI
 		if err != nil {
 			return cachedHierarchyResolvedAttributes{}, err
 		}
-		rulesByAttr := make(map[string]AttributeInheritanceRule, len(rules))
-		ruleAttributes := make([]string, 0, len(rules))
-		for _, r := range rules {
-			name := strings.TrimSpace(strings.ToLower(r.AttributeName))
-			if _, ok := orgInheritanceAttributeWhitelistSet[name]; !ok {
-				continue
-			}
-			r.AttributeName = name
-			rulesByAttr[name] = r
-			ruleAttributes = append(ruleAttributes, name)
-		}
+		rulesByAttr, ruleAttributes := inheritanceRulesByAttribute(rules)
 
 		resolved, sources := resolveOrgAttributes(nodes, explicit, rulesByAttr)
 		out := make([]HierarchyNodeWithResolvedAttributes, 0, len(nodes))
@@ -258,7 +378,7 @@ func (s *OrgService) GetHierarchyResolvedAttributes(ctx context.Context, tenantI
 		return nil, time.Time{}, nil, nil, err
 	}
 
-	if s != nil && s.cache != nil {
+	if s != nil && s.cache != nil && !shouldSkipReadCache(ctx) {
 		s.cache.Set(tenantID, cacheKey, written)
 	}
 
@@ -274,7 +394,7 @@ func (s *OrgService) ListRoles(ctx context.Context, tenantID uuid.UUID) ([]OrgRo
 	}
 
 	cacheKey := repo.CacheKey("org", "roles", tenantID)
-	if s != nil && s.cache != nil {
+	if s != nil && s.cache != nil && !shouldSkipReadCache(ctx) {
 		if cachedAny, ok := s.cache.Get(cacheKey); ok {
 			if cached, ok := cachedAny.([]OrgRole); ok {
 				recordCacheRequest("snapshot", true)
@@ -290,7 +410,7 @@ func (s *OrgService) ListRoles(ctx context.Context, tenantID uuid.UUID) ([]OrgRo
 	if err != nil {
 		return nil, err
 	}
-	if s != nil && s.cache != nil {
+	if s != nil && s.cache != nil && !shouldSkipReadCache(ctx) {
 		s.cache.Set(tenantID, cacheKey, roles)
 	}
 	return roles, nil
@@ -336,7 +456,7 @@ func (s *OrgService) ListRoleAssignments(
 		strings.TrimSpace(derefString(subjectType)),
 		derefUUID(subjectID),
 	)
-	if s != nil && s.cache != nil {
+	if s != nil && s.cache != nil && !shouldSkipReadCache(ctx) {
 		if cachedAny, ok := s.cache.Get(cacheKey); ok {
 			if cached, ok := cachedAny.(cachedRoleAssignments); ok {
 				recordCacheRequest("snapshot", true)
@@ -372,6 +492,10 @@ func (s *OrgService) ListRoleAssignments(
 		it.SourceOrgNodeID = r.SourceOrgNodeID
 		it.EffectiveWindow.EffectiveDate = r.EffectiveDate.UTC().Format(time.RFC3339)
 		it.EffectiveWindow.EndDate = r.EndDate.UTC().Format(time.RFC3339)
+		it.SubjectType = r.SubjectType
+		it.SubjectID = r.SubjectID
+		it.EffectiveDate = r.EffectiveDate
+		it.EndDate = r.EndDate
 		items = append(items, it)
 	}
 
@@ -388,7 +512,7 @@ func (s *OrgService) ListRoleAssignments(
 		return items[i].AssignmentID.String() < items[j].AssignmentID.String()
 	})
 
-	if s != nil && s.cache != nil {
+	if s != nil && s.cache != nil && !shouldSkipReadCache(ctx) {
 		s.cache.Set(tenantID, cacheKey, cachedRoleAssignments{Items: items, AsOf: asOf})
 	}
 
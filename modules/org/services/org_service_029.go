@@ -23,7 +23,7 @@ func (s *OrgService) ListAncestorsAsOf(ctx context.Context, tenantID uuid.UUID,
 
 	const hierarchyType = "OrgUnit"
 	cacheKey := repo.CacheKey("org", "ancestors", tenantID, hierarchyType, orgNodeID, orgDateKey(asOf), OrgDeepReadBackendForTenant(tenantID))
-	if s != nil && s.cache != nil && OrgCacheEnabled() {
+	if s != nil && s.cache != nil && OrgCacheEnabled() && !shouldSkipReadCache(ctx) {
 		if cachedAny, ok := s.cache.Get(cacheKey); ok {
 			if cached, ok := cachedAny.(cachedRelations); ok {
 				recordCacheRequest("snapshot", true)
@@ -47,7 +47,7 @@ func (s *OrgService) ListAncestorsAsOf(ctx context.Context, tenantID uuid.UUID,
 		return nil, time.Time{}, err
 	}
 
-	if s != nil && s.cache != nil && OrgCacheEnabled() {
+	if s != nil && s.cache != nil && OrgCacheEnabled() && !shouldSkipReadCache(ctx) {
 		s.cache.Set(tenantID, cacheKey, cachedRelations{Items: out, AsOf: asOf})
 	}
 	return out, asOf, nil
@@ -66,7 +66,7 @@ func (s *OrgService) ListDescendantsAsOf(ctx context.Context, tenantID uuid.UUID
 
 	const hierarchyType = "OrgUnit"
 	cacheKey := repo.CacheKey("org", "descendants", tenantID, hierarchyType, orgNodeID, orgDateKey(asOf), OrgDeepReadBackendForTenant(tenantID))
-	if s != nil && s.cache != nil && OrgCacheEnabled() {
+	if s != nil && s.cache != nil && OrgCacheEnabled() && !shouldSkipReadCache(ctx) {
 		if cachedAny, ok := s.cache.Get(cacheKey); ok {
 			if cached, ok := cachedAny.(cachedRelations); ok {
 				recordCacheRequest("snapshot", true)
@@ -90,7 +90,7 @@ func (s *OrgService) ListDescendantsAsOf(ctx context.Context, tenantID uuid.UUID
 		return nil, time.Time{}, err
 	}
 
-	if s != nil && s.cache != nil && OrgCacheEnabled() {
+	if s != nil && s.cache != nil && OrgCacheEnabled() && !shouldSkipReadCache(ctx) {
 		s.cache.Set(tenantID, cacheKey, cachedRelations{Items: out, AsOf: asOf})
 	}
 	return out, asOf, nil
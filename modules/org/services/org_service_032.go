@@ -101,7 +101,7 @@ func (s *OrgService) CreateSecurityGroupMapping(ctx context.Context, tenantID uu
 		if err != nil {
 			return nil, err
 		}
-		if _, err := s.freezeCheck(settings, txTime, in.EffectiveDate); err != nil {
+		if _, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate); err != nil {
 			return nil, err
 		}
 
@@ -159,7 +159,7 @@ func (s *OrgService) RescindSecurityGroupMapping(ctx context.Context, tenantID u
 		if err != nil {
 			return nil, err
 		}
-		if _, err := s.freezeCheck(settings, txTime, in.EffectiveDate); err != nil {
+		if _, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate); err != nil {
 			return nil, err
 		}
 
@@ -290,7 +290,7 @@ func (s *OrgService) CreateOrgLink(ctx context.Context, tenantID uuid.UUID, requ
 		if err != nil {
 			return nil, err
 		}
-		if _, err := s.freezeCheck(settings, txTime, in.EffectiveDate); err != nil {
+		if _, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate); err != nil {
 			return nil, err
 		}
 
@@ -350,7 +350,7 @@ func (s *OrgService) RescindOrgLink(ctx context.Context, tenantID uuid.UUID, req
 		if err != nil {
 			return nil, err
 		}
-		if _, err := s.freezeCheck(settings, txTime, in.EffectiveDate); err != nil {
+		if _, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate); err != nil {
 			return nil, err
 		}
 
@@ -449,12 +449,31 @@ type PermissionPreviewLink struct {
 	Source     PermissionPreviewLinkSource `json:"source"`
 }
 
+type PermissionPreviewDelegationSource struct {
+	OrgNodeID uuid.UUID `json:"org_node_id"`
+	Depth     int       `json:"depth"`
+}
+
+// PermissionPreviewDelegation is a delegation in force for the node: the acting manager of the
+// node itself, or an acting role holder on the node or one of its ancestors.
+type PermissionPreviewDelegation struct {
+	DelegationID      uuid.UUID                         `json:"delegation_id"`
+	DelegationType    string                            `json:"delegation_type"`
+	RoleCode          *string                           `json:"role_code,omitempty"`
+	DelegateUserID    int64                             `json:"delegate_user_id"`
+	DelegateSubjectID uuid.UUID                         `json:"delegate_subject_id"`
+	EffectiveDate     string                            `json:"effective_date"`
+	EndDate           string                            `json:"end_date"`
+	Source            PermissionPreviewDelegationSource `json:"source"`
+}
+
 type PermissionPreviewResult struct {
 	TenantID       uuid.UUID
 	OrgNodeID      uuid.UUID
 	EffectiveDate  time.Time
 	SecurityGroups []PermissionPreviewSecurityGroup
 	Links          []PermissionPreviewLink
+	Delegations    []PermissionPreviewDelegation
 	Warnings       []string
 }
 
@@ -463,6 +482,7 @@ type PermissionPreviewInput struct {
 	EffectiveDate         time.Time
 	IncludeSecurityGroups bool
 	IncludeLinks          bool
+	IncludeDelegations    bool
 	LimitLinks            int
 }
 
@@ -490,6 +510,7 @@ func (s *OrgService) PermissionPreview(ctx context.Context, tenantID uuid.UUID,
 			EffectiveDate:  in.EffectiveDate.UTC(),
 			SecurityGroups: []PermissionPreviewSecurityGroup{},
 			Links:          []PermissionPreviewLink{},
+			Delegations:    []PermissionPreviewDelegation{},
 			Warnings:       []string{},
 		}
 
@@ -504,7 +525,7 @@ func (s *OrgService) PermissionPreview(ctx context.Context, tenantID uuid.UUID,
 
 		var depths map[uuid.UUID]int
 		var ancestorIDs []uuid.UUID
-		if in.IncludeSecurityGroups {
+		if in.IncludeSecurityGroups || in.IncludeDelegations {
 			anc, _, err := s.ListAncestorsAsOf(txCtx, tenantID, in.OrgNodeID, in.EffectiveDate)
 			if err != nil {
 				return nil, err
@@ -515,7 +536,9 @@ func (s *OrgService) PermissionPreview(ctx context.Context, tenantID uuid.UUID,
 				depths[r.NodeID] = r.Depth
 				ancestorIDs = append(ancestorIDs, r.NodeID)
 			}
+		}
 
+		if in.IncludeSecurityGroups {
 			rows, err := s.repo.ListSecurityGroupMappingsForNodesAsOf(txCtx, tenantID, ancestorIDs, in.EffectiveDate)
 			if err != nil {
 				return nil, err
@@ -585,6 +608,14 @@ func (s *OrgService) PermissionPreview(ctx context.Context, tenantID uuid.UUID,
 			res.Links = items
 		}
 
+		if in.IncludeDelegations {
+			rows, err := s.repo.ListDelegationsForNodesAsOf(txCtx, tenantID, ancestorIDs, in.EffectiveDate)
+			if err != nil {
+				return nil, err
+			}
+			res.Delegations = permissionPreviewDelegations(rows, in.OrgNodeID, depths)
+		}
+
 		return res, nil
 	})
 	if err != nil {
@@ -592,3 +623,38 @@ func (s *OrgService) PermissionPreview(ctx context.Context, tenantID uuid.UUID,
 	}
 	return out, nil
 }
+
+// permissionPreviewDelegations keeps role delegations from the node and its ancestors and the
+// manager delegation of the node itself, nearest source first.
+func permissionPreviewDelegations(rows []DelegationRow, orgNodeID uuid.UUID, depths map[uuid.UUID]int) []PermissionPreviewDelegation {
+	items := make([]PermissionPreviewDelegation, 0, len(rows))
+	for _, row := range rows {
+		d, ok := depths[row.OrgNodeID]
+		if !ok {
+			continue
+		}
+		if row.DelegationType == DelegationTypeManager && row.OrgNodeID != orgNodeID {
+			continue
+		}
+		items = append(items, PermissionPreviewDelegation{
+			DelegationID:      row.ID,
+			DelegationType:    row.DelegationType,
+			RoleCode:          row.RoleCode,
+			DelegateUserID:    row.DelegateUserID,
+			DelegateSubjectID: row.DelegateSubjectID,
+			EffectiveDate:     row.EffectiveDate.UTC().Format(time.DateOnly),
+			EndDate:           row.EndDate.UTC().Format(time.DateOnly),
+			Source:            PermissionPreviewDelegationSource{OrgNodeID: row.OrgNodeID, Depth: d},
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Source.Depth != items[j].Source.Depth {
+			return items[i].Source.Depth < items[j].Source.Depth
+		}
+		if items[i].DelegationType != items[j].DelegationType {
+			return items[i].DelegationType < items[j].DelegationType
+		}
+		return items[i].DelegationID.String() < items[j].DelegationID.String()
+	})
+	return items
+}
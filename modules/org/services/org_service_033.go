@@ -179,6 +179,7 @@ type HierarchyExportNode struct {
 	Status            string
 	SecurityGroupKeys []string
 	Links             []OrgLinkSummary
+	CustomAttributes  map[string]any
 }
 
 type HierarchyExportResult struct {
@@ -211,7 +212,7 @@ func (s *OrgService) ExportHierarchy(
 	if strings.TrimSpace(hierarchyType) == "" {
 		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "type is required", nil)
 	}
-	if hierarchyType != "OrgUnit" {
+	if !IsValidHierarchyType(hierarchyType) {
 		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "type is invalid", nil)
 	}
 	if asOf.IsZero() {
@@ -232,7 +233,7 @@ func (s *OrgService) ExportHierarchy(
 		return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_EXPORT_TOO_LARGE", "limit exceeds maximum", nil)
 	}
 
-	const ht = "OrgUnit"
+	ht := hierarchyType
 	backend := OrgDeepReadBackendForTenant(tenantID)
 
 	includes := []string{"nodes"}
@@ -251,7 +252,7 @@ func (s *OrgService) ExportHierarchy(
 		if rootNodeID != nil && *rootNodeID != uuid.Nil {
 			rootID = *rootNodeID
 		} else {
-			id, err := s.repo.GetTenantRootNodeID(txCtx, tenantID)
+			id, err := s.repo.GetTenantRootNodeID(txCtx, tenantID, ht)
 			if err != nil {
 				return nil, err
 			}
@@ -323,6 +324,7 @@ func (s *OrgService) ExportHierarchy(
 				Depth:             rel.Depth,
 				SecurityGroupKeys: sgKeys[row.ID],
 				Links:             links[row.ID],
+				CustomAttributes:  row.CustomAttributes,
 			})
 		}
 
@@ -34,6 +34,8 @@ type CreatePositionInput struct {
 	Profile         json.RawMessage
 	ReasonCode      string
 	ReasonNote      *string
+	// CustomAttributes holds values for the tenant's org_position attribute definitions, keyed by code.
+	CustomAttributes map[string]any
 }
 
 type CreatePositionResult struct {
@@ -100,7 +102,7 @@ func (s *OrgService) CreatePosition(ctx context.Context, tenantID uuid.UUID, req
 			logReasonCodeRejected(txCtx, tenantID, requestID, "position.created", reasonInfo, svcErr)
 			return nil, svcErr
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "position.created", "org_position", uuid.Nil, in.EffectiveDate, freeze, err, logrus.Fields{
 				"org_node_id": in.OrgNodeID.String(),
@@ -140,6 +142,25 @@ func (s *OrgService) CreatePosition(ctx context.Context, tenantID uuid.UUID, req
 			}
 		}
 
+		budgetMode := positionBudgetValidationMode(settings)
+		var budgetShadowErr *ServiceError
+		if budgetMode != "disabled" && (lifecycle == "planned" || lifecycle == "active") {
+			if err := s.validatePositionBudget(txCtx, tenantID, in.OrgNodeID, in.EffectiveDate, jobProfileID, jobLevelCode, in.CapacityFTE); err != nil {
+				var svcErr *ServiceError
+				if !errors.As(err, &svcErr) {
+					return nil, err
+				}
+				if budgetMode == "enforce" {
+					maybeLogModeRejected(txCtx, "org.position_budget.rejected", tenantID, requestID, initiatorID, "position.created", "org_position", uuid.Nil, in.EffectiveDate, budgetMode, err, logrus.Fields{
+						"org_node_id": in.OrgNodeID.String(),
+						"operation":   "Create",
+					})
+					return nil, err
+				}
+				budgetShadowErr = svcErr
+			}
+		}
+
 		restrictionsJSON, err := extractRestrictionsFromProfile(profile)
 		if err != nil {
 			return nil, err
@@ -156,6 +177,11 @@ func (s *OrgService) CreatePosition(ctx context.Context, tenantID uuid.UUID, req
 			return nil, err
 		}
 
+		customAttributes, err := s.prepareCustomAttributes(txCtx, tenantID, CustomAttributeEntityPosition, in.EffectiveDate, nil, in.CustomAttributes)
+		if err != nil {
+			return nil, err
+		}
+
 		positionID := uuid.New()
 		legacyStatus := "active"
 		if lifecycle == "inactive" {
@@ -194,6 +220,7 @@ func (s *OrgService) CreatePosition(ctx context.Context, tenantID uuid.UUID, req
 			JobProfileID:        jobProfileID,
 			CostCenterCode:      costCenterCode,
 			Profile:             profile,
+			CustomAttributes:    customAttributes,
 			EffectiveDate:       in.EffectiveDate,
 			EndDate:             endOfTime,
 		})
@@ -217,6 +244,7 @@ func (s *OrgService) CreatePosition(ctx context.Context, tenantID uuid.UUID, req
 			"end_date":          endOfTime.UTC().Format(time.RFC3339),
 			"reports_to_id":     in.ReportsToID,
 			"position_slice_id": sliceID.String(),
+			"custom_attributes": customAttributes,
 		}
 
 		meta := map[string]any{
@@ -227,25 +255,30 @@ func (s *OrgService) CreatePosition(ctx context.Context, tenantID uuid.UUID, req
 			meta["position_catalog_validation_mode"] = catalogMode
 			meta["position_catalog_validation_error_code"] = catalogShadowErr.Code
 		}
+		if budgetShadowErr != nil {
+			meta["position_budget_validation_mode"] = budgetMode
+			meta["position_budget_validation_error_code"] = budgetShadowErr.Code
+		}
 		addReasonCodeMeta(meta, reasonInfo)
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:       requestID,
-			TransactionTime: txTime,
-			InitiatorID:     initiatorID,
-			ChangeType:      "position.created",
-			EntityType:      "org_position",
-			EntityID:        positionID,
-			EffectiveDate:   in.EffectiveDate,
-			EndDate:         endOfTime,
-			OldValues:       nil,
-			NewValues:       newValues,
-			Meta:            meta,
-			Operation:       "Create",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "position.created",
+			EntityType:           "org_position",
+			EntityID:             positionID,
+			EffectiveDate:        in.EffectiveDate,
+			EndDate:              endOfTime,
+			OldValues:            nil,
+			NewValues:            newValues,
+			Meta:                 meta,
+			Operation:            "Create",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -386,7 +419,7 @@ func (s *OrgService) ShiftBoundaryPosition(ctx context.Context, tenantID uuid.UU
 		if in.NewEffectiveDate.Before(affectedAt) {
 			affectedAt = in.NewEffectiveDate
 		}
-		freeze, err := s.freezeCheck(settings, txTime, affectedAt)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, affectedAt)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "position.shifted", "org_position", in.PositionID, affectedAt, freeze, err, logrus.Fields{
 				"position_id": in.PositionID.String(),
@@ -448,46 +481,48 @@ func (s *OrgService) ShiftBoundaryPosition(ctx context.Context, tenantID uuid.UU
 		addReasonCodeMeta(meta, reasonInfo)
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:        requestID,
-			TransactionTime:  txTime,
-			InitiatorID:      initiatorID,
-			ChangeType:       "position.corrected",
-			EntityType:       "org_position",
-			EntityID:         in.PositionID,
-			EffectiveDate:    prev.EffectiveDate,
-			EndDate:          in.NewEffectiveDate,
-			OldValues:        prevOld,
-			NewValues:        prevNew,
-			Meta:             meta,
-			Operation:        "ShiftBoundary",
-			OperationDetails: opDetails,
-			FreezeMode:       freeze.Mode,
-			FreezeViolation:  freeze.Violation,
-			FreezeCutoffUTC:  freeze.CutoffUTC,
-			AffectedAtUTC:    affectedAt,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "position.corrected",
+			EntityType:           "org_position",
+			EntityID:             in.PositionID,
+			EffectiveDate:        prev.EffectiveDate,
+			EndDate:              in.NewEffectiveDate,
+			OldValues:            prevOld,
+			NewValues:            prevNew,
+			Meta:                 meta,
+			Operation:            "ShiftBoundary",
+			OperationDetails:     opDetails,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        affectedAt,
 		})
 		if err != nil {
 			return nil, err
 		}
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:        requestID,
-			TransactionTime:  txTime,
-			InitiatorID:      initiatorID,
-			ChangeType:       "position.corrected",
-			EntityType:       "org_position",
-			EntityID:         in.PositionID,
-			EffectiveDate:    in.NewEffectiveDate,
-			EndDate:          target.EndDate,
-			OldValues:        targetOld,
-			NewValues:        targetNew,
-			Meta:             meta,
-			Operation:        "ShiftBoundary",
-			OperationDetails: opDetails,
-			FreezeMode:       freeze.Mode,
-			FreezeViolation:  freeze.Violation,
-			FreezeCutoffUTC:  freeze.CutoffUTC,
-			AffectedAtUTC:    affectedAt,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "position.corrected",
+			EntityType:           "org_position",
+			EntityID:             in.PositionID,
+			EffectiveDate:        in.NewEffectiveDate,
+			EndDate:              target.EndDate,
+			OldValues:            targetOld,
+			NewValues:            targetNew,
+			Meta:                 meta,
+			Operation:            "ShiftBoundary",
+			OperationDetails:     opDetails,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        affectedAt,
 		})
 		if err != nil {
 			return nil, err
@@ -624,6 +659,8 @@ type UpdatePositionInput struct {
 	JobProfileID    *uuid.UUID
 	CostCenterCode  *string
 	Profile         *json.RawMessage
+	// CustomAttributes is merged into the current values; a nil or empty value clears that attribute.
+	CustomAttributes map[string]any
 }
 
 type UpdatePositionResult struct {
@@ -656,7 +693,7 @@ func (s *OrgService) UpdatePosition(ctx context.Context, tenantID uuid.UUID, req
 			logReasonCodeRejected(txCtx, tenantID, requestID, "position.updated", reasonInfo, svcErr)
 			return nil, svcErr
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "position.updated", "org_position", in.PositionID, in.EffectiveDate, freeze, err, logrus.Fields{
 				"position_id": in.PositionID.String(),
@@ -805,6 +842,11 @@ func (s *OrgService) UpdatePosition(ctx context.Context, tenantID uuid.UUID, req
 			return nil, err
 		}
 
+		customAttributes, err := s.prepareCustomAttributes(txCtx, tenantID, CustomAttributeEntityPosition, in.EffectiveDate, current.CustomAttributes, in.CustomAttributes)
+		if err != nil {
+			return nil, err
+		}
+
 		hierarchyType := "OrgUnit"
 		nodeExists, err := s.repo.NodeExistsAt(txCtx, tenantID, orgNodeID, hierarchyType, in.EffectiveDate)
 		if err != nil {
@@ -842,6 +884,7 @@ func (s *OrgService) UpdatePosition(ctx context.Context, tenantID uuid.UUID, req
 			JobProfileID:        jobProfileID,
 			CostCenterCode:      costCenterCode,
 			Profile:             profile,
+			CustomAttributes:    customAttributes,
 			EffectiveDate:       in.EffectiveDate,
 			EndDate:             newEnd,
 		})
@@ -868,24 +911,26 @@ func (s *OrgService) UpdatePosition(ctx context.Context, tenantID uuid.UUID, req
 			EffectiveDate:   in.EffectiveDate,
 			EndDate:         newEnd,
 			OldValues: map[string]any{
-				"position_id":      in.PositionID.String(),
-				"org_node_id":      current.OrgNodeID.String(),
-				"lifecycle_status": current.LifecycleStatus,
-				"capacity_fte":     current.CapacityFTE,
-				"job_profile_id":   current.JobProfileID.String(),
-				"job_level_code":   derefString(current.JobLevelCode),
-				"effective_date":   current.EffectiveDate.UTC().Format(time.RFC3339),
-				"end_date":         current.EndDate.UTC().Format(time.RFC3339),
+				"position_id":       in.PositionID.String(),
+				"org_node_id":       current.OrgNodeID.String(),
+				"lifecycle_status":  current.LifecycleStatus,
+				"capacity_fte":      current.CapacityFTE,
+				"job_profile_id":    current.JobProfileID.String(),
+				"job_level_code":    derefString(current.JobLevelCode),
+				"custom_attributes": current.CustomAttributes,
+				"effective_date":    current.EffectiveDate.UTC().Format(time.RFC3339),
+				"end_date":          current.EndDate.UTC().Format(time.RFC3339),
 			},
 			NewValues: map[string]any{
-				"position_id":      in.PositionID.String(),
-				"org_node_id":      orgNodeID.String(),
-				"lifecycle_status": lifecycle,
-				"capacity_fte":     capacity,
-				"job_profile_id":   jobProfileID.String(),
-				"job_level_code":   derefString(jobLevelCode),
-				"effective_date":   in.EffectiveDate.UTC().Format(time.RFC3339),
-				"end_date":         newEnd.UTC().Format(time.RFC3339),
+				"position_id":       in.PositionID.String(),
+				"org_node_id":       orgNodeID.String(),
+				"lifecycle_status":  lifecycle,
+				"capacity_fte":      capacity,
+				"job_profile_id":    jobProfileID.String(),
+				"job_level_code":    derefString(jobLevelCode),
+				"custom_attributes": customAttributes,
+				"effective_date":    in.EffectiveDate.UTC().Format(time.RFC3339),
+				"end_date":          newEnd.UTC().Format(time.RFC3339),
 			},
 			Meta: func() map[string]any {
 				meta := map[string]any{
@@ -899,11 +944,12 @@ func (s *OrgService) UpdatePosition(ctx context.Context, tenantID uuid.UUID, req
 				addReasonCodeMeta(meta, reasonInfo)
 				return meta
 			}(),
-			Operation:       "Update",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			Operation:            "Update",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -967,6 +1013,8 @@ type CorrectPositionInput struct {
 	JobProfileID   *uuid.UUID
 	CostCenterCode *string
 	Profile        *json.RawMessage
+	// CustomAttributes, when non-nil, is merged into the slice's values and revalidated.
+	CustomAttributes map[string]any
 }
 
 type CorrectPositionResult struct {
@@ -1008,7 +1056,7 @@ func (s *OrgService) CorrectPosition(ctx context.Context, tenantID uuid.UUID, re
 			return nil, mapPgError(err)
 		}
 
-		freeze, err := s.freezeCheck(settings, txTime, target.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, target.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "position.corrected", "org_position", in.PositionID, target.EffectiveDate, freeze, err, logrus.Fields{
 				"position_id":     in.PositionID.String(),
@@ -1019,14 +1067,15 @@ func (s *OrgService) CorrectPosition(ctx context.Context, tenantID uuid.UUID, re
 		}
 
 		oldValues := map[string]any{
-			"slice_id":         target.ID.String(),
-			"position_id":      in.PositionID.String(),
-			"org_node_id":      target.OrgNodeID.String(),
-			"title":            target.Title,
-			"lifecycle_status": target.LifecycleStatus,
-			"capacity_fte":     target.CapacityFTE,
-			"effective_date":   target.EffectiveDate.UTC().Format(time.RFC3339),
-			"end_date":         target.EndDate.UTC().Format(time.RFC3339),
+			"slice_id":          target.ID.String(),
+			"position_id":       in.PositionID.String(),
+			"org_node_id":       target.OrgNodeID.String(),
+			"title":             target.Title,
+			"lifecycle_status":  target.LifecycleStatus,
+			"capacity_fte":      target.CapacityFTE,
+			"custom_attributes": target.CustomAttributes,
+			"effective_date":    target.EffectiveDate.UTC().Format(time.RFC3339),
+			"end_date":          target.EndDate.UTC().Format(time.RFC3339),
 		}
 
 		if in.CapacityFTE != nil && *in.CapacityFTE <= 0 {
@@ -1169,6 +1218,12 @@ func (s *OrgService) CorrectPosition(ctx context.Context, tenantID uuid.UUID, re
 			CostCenterCode:      costCenterCodePatch,
 			Profile:             profilePatch,
 		}
+		if in.CustomAttributes != nil {
+			patch.CustomAttributes, err = s.prepareCustomAttributes(txCtx, tenantID, CustomAttributeEntityPosition, target.EffectiveDate, target.CustomAttributes, in.CustomAttributes)
+			if err != nil {
+				return nil, err
+			}
+		}
 		if err := s.repo.UpdatePositionSliceInPlace(txCtx, tenantID, target.ID, patch); err != nil {
 			return nil, mapPgError(err)
 		}
@@ -1179,14 +1234,15 @@ func (s *OrgService) CorrectPosition(ctx context.Context, tenantID uuid.UUID, re
 		}
 
 		newValues := map[string]any{
-			"slice_id":         updated.ID.String(),
-			"position_id":      in.PositionID.String(),
-			"org_node_id":      updated.OrgNodeID.String(),
-			"title":            updated.Title,
-			"lifecycle_status": updated.LifecycleStatus,
-			"capacity_fte":     updated.CapacityFTE,
-			"effective_date":   updated.EffectiveDate.UTC().Format(time.RFC3339),
-			"end_date":         updated.EndDate.UTC().Format(time.RFC3339),
+			"slice_id":          updated.ID.String(),
+			"position_id":       in.PositionID.String(),
+			"org_node_id":       updated.OrgNodeID.String(),
+			"title":             updated.Title,
+			"lifecycle_status":  updated.LifecycleStatus,
+			"capacity_fte":      updated.CapacityFTE,
+			"custom_attributes": updated.CustomAttributes,
+			"effective_date":    updated.EffectiveDate.UTC().Format(time.RFC3339),
+			"end_date":          updated.EndDate.UTC().Format(time.RFC3339),
 		}
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
@@ -1208,11 +1264,12 @@ func (s *OrgService) CorrectPosition(ctx context.Context, tenantID uuid.UUID, re
 				addReasonCodeMeta(meta, reasonInfo)
 				return meta
 			}(),
-			Operation:       "Correct",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   updated.EffectiveDate,
+			Operation:            "Correct",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        updated.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -1295,7 +1352,7 @@ func (s *OrgService) RescindPosition(ctx context.Context, tenantID uuid.UUID, re
 			logReasonCodeRejected(txCtx, tenantID, requestID, "position.rescinded", reasonInfo, svcErr)
 			return nil, svcErr
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "position.rescinded", "org_position", in.PositionID, in.EffectiveDate, freeze, err, logrus.Fields{
 				"position_id": in.PositionID.String(),
@@ -1371,6 +1428,7 @@ func (s *OrgService) RescindPosition(ctx context.Context, tenantID uuid.UUID, re
 				JobProfileID:        target.JobProfileID,
 				CostCenterCode:      target.CostCenterCode,
 				Profile:             target.Profile,
+				CustomAttributes:    target.CustomAttributes,
 				EffectiveDate:       in.EffectiveDate,
 				EndDate:             endOfTime,
 			})
@@ -1411,11 +1469,12 @@ func (s *OrgService) RescindPosition(ctx context.Context, tenantID uuid.UUID, re
 				addReasonCodeMeta(meta, reasonInfo)
 				return meta
 			}(),
-			Operation:       "Rescind",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			Operation:            "Rescind",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -27,6 +27,9 @@ type StaffingSummaryInput struct {
 	LifecycleStatuses  []string
 	IncludeSystem      bool
 	MaxScopeNodesLimit int
+	// Backend overrides the tenant's deep-read backend; scenarios force edges because
+	// closure/snapshot builds cannot see staged writes.
+	Backend DeepReadBackend
 }
 
 type StaffingVacanciesInput struct {
@@ -92,6 +95,9 @@ func (s *OrgService) GetStaffingSummary(ctx context.Context, tenantID uuid.UUID,
 	}
 
 	backend := OrgDeepReadBackendForTenant(tenantID)
+	if in.Backend != "" {
+		backend = in.Backend
+	}
 
 	out, err := inTx(ctx, tenantID, func(txCtx context.Context) (*StaffingSummaryResult, error) {
 		rootID, err := resolveOrgNodeID(txCtx, s.repo, tenantID, in.OrgNodeID)
@@ -379,7 +385,7 @@ func resolveOrgNodeID(ctx context.Context, repo OrgRepository, tenantID uuid.UUI
 	if orgNodeID != nil && *orgNodeID != uuid.Nil {
 		return *orgNodeID, nil
 	}
-	id, err := repo.GetTenantRootNodeID(ctx, tenantID)
+	id, err := repo.GetTenantRootNodeID(ctx, tenantID, staffingScopeHierarchy)
 	if err != nil {
 		return uuid.Nil, err
 	}
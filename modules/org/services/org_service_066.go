@@ -61,7 +61,7 @@ func (s *OrgService) DeleteNodeSliceAndStitch(ctx context.Context, tenantID uuid
 			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_NOT_FOUND_AT_DATE", "target slice not found", err)
 		}
 
-		freeze, err := s.freezeCheck(settings, txTime, target.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, target.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "node.corrected", "org_node", in.NodeID, target.EffectiveDate, freeze, err, logrus.Fields{
 				"node_id":    in.NodeID.String(),
@@ -125,23 +125,24 @@ func (s *OrgService) DeleteNodeSliceAndStitch(ctx context.Context, tenantID uuid
 
 		if hasPrev {
 			_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-				RequestID:        requestID,
-				TransactionTime:  txTime,
-				InitiatorID:      initiatorID,
-				ChangeType:       "node.corrected",
-				EntityType:       "org_node",
-				EntityID:         in.NodeID,
-				EffectiveDate:    prev.EffectiveDate,
-				EndDate:          target.EndDate,
-				OldValues:        prevOld,
-				NewValues:        prevNew,
-				Meta:             buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
-				Operation:        "DeleteSliceAndStitch",
-				OperationDetails: opDetail,
-				FreezeMode:       freeze.Mode,
-				FreezeViolation:  freeze.Violation,
-				FreezeCutoffUTC:  freeze.CutoffUTC,
-				AffectedAtUTC:    target.EffectiveDate,
+				RequestID:            requestID,
+				TransactionTime:      txTime,
+				InitiatorID:          initiatorID,
+				ChangeType:           "node.corrected",
+				EntityType:           "org_node",
+				EntityID:             in.NodeID,
+				EffectiveDate:        prev.EffectiveDate,
+				EndDate:              target.EndDate,
+				OldValues:            prevOld,
+				NewValues:            prevNew,
+				Meta:                 buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
+				Operation:            "DeleteSliceAndStitch",
+				OperationDetails:     opDetail,
+				FreezeMode:           freeze.Mode,
+				FreezeViolation:      freeze.Violation,
+				FreezeOverrideReason: freeze.OverrideReason,
+				FreezeCutoffUTC:      freeze.CutoffUTC,
+				AffectedAtUTC:        target.EffectiveDate,
 			})
 			if err != nil {
 				return nil, err
@@ -149,23 +150,24 @@ func (s *OrgService) DeleteNodeSliceAndStitch(ctx context.Context, tenantID uuid
 		}
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:        requestID,
-			TransactionTime:  txTime,
-			InitiatorID:      initiatorID,
-			ChangeType:       "node.corrected",
-			EntityType:       "org_node",
-			EntityID:         in.NodeID,
-			EffectiveDate:    target.EffectiveDate,
-			EndDate:          target.EndDate,
-			OldValues:        targetOld,
-			NewValues:        map[string]any{"deleted": true},
-			Meta:             buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
-			Operation:        "DeleteSliceAndStitch",
-			OperationDetails: opDetail,
-			FreezeMode:       freeze.Mode,
-			FreezeViolation:  freeze.Violation,
-			FreezeCutoffUTC:  freeze.CutoffUTC,
-			AffectedAtUTC:    target.EffectiveDate,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "node.corrected",
+			EntityType:           "org_node",
+			EntityID:             in.NodeID,
+			EffectiveDate:        target.EffectiveDate,
+			EndDate:              target.EndDate,
+			OldValues:            targetOld,
+			NewValues:            map[string]any{"deleted": true},
+			Meta:                 buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
+			Operation:            "DeleteSliceAndStitch",
+			OperationDetails:     opDetail,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        target.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -242,7 +244,7 @@ func (s *OrgService) DeletePositionSliceAndStitch(ctx context.Context, tenantID
 			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_POSITION_NOT_FOUND_AT_DATE", "target slice not found", err)
 		}
 
-		freeze, err := s.freezeCheck(settings, txTime, target.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, target.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "position.corrected", "org_position", in.PositionID, target.EffectiveDate, freeze, err, logrus.Fields{
 				"position_id": in.PositionID.String(),
@@ -300,23 +302,24 @@ func (s *OrgService) DeletePositionSliceAndStitch(ctx context.Context, tenantID
 			}
 
 			_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-				RequestID:        requestID,
-				TransactionTime:  txTime,
-				InitiatorID:      initiatorID,
-				ChangeType:       "position.corrected",
-				EntityType:       "org_position",
-				EntityID:         in.PositionID,
-				EffectiveDate:    prev.EffectiveDate,
-				EndDate:          target.EndDate,
-				OldValues:        prevOld,
-				NewValues:        prevNew,
-				Meta:             buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
-				Operation:        "DeleteSliceAndStitch",
-				OperationDetails: opDetail,
-				FreezeMode:       freeze.Mode,
-				FreezeViolation:  freeze.Violation,
-				FreezeCutoffUTC:  freeze.CutoffUTC,
-				AffectedAtUTC:    target.EffectiveDate,
+				RequestID:            requestID,
+				TransactionTime:      txTime,
+				InitiatorID:          initiatorID,
+				ChangeType:           "position.corrected",
+				EntityType:           "org_position",
+				EntityID:             in.PositionID,
+				EffectiveDate:        prev.EffectiveDate,
+				EndDate:              target.EndDate,
+				OldValues:            prevOld,
+				NewValues:            prevNew,
+				Meta:                 buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
+				Operation:            "DeleteSliceAndStitch",
+				OperationDetails:     opDetail,
+				FreezeMode:           freeze.Mode,
+				FreezeViolation:      freeze.Violation,
+				FreezeOverrideReason: freeze.OverrideReason,
+				FreezeCutoffUTC:      freeze.CutoffUTC,
+				AffectedAtUTC:        target.EffectiveDate,
 			})
 			if err != nil {
 				return nil, err
@@ -324,23 +327,24 @@ func (s *OrgService) DeletePositionSliceAndStitch(ctx context.Context, tenantID
 		}
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:        requestID,
-			TransactionTime:  txTime,
-			InitiatorID:      initiatorID,
-			ChangeType:       "position.corrected",
-			EntityType:       "org_position",
-			EntityID:         in.PositionID,
-			EffectiveDate:    target.EffectiveDate,
-			EndDate:          target.EndDate,
-			OldValues:        targetOld,
-			NewValues:        map[string]any{"deleted": true},
-			Meta:             buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
-			Operation:        "DeleteSliceAndStitch",
-			OperationDetails: opDetail,
-			FreezeMode:       freeze.Mode,
-			FreezeViolation:  freeze.Violation,
-			FreezeCutoffUTC:  freeze.CutoffUTC,
-			AffectedAtUTC:    target.EffectiveDate,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "position.corrected",
+			EntityType:           "org_position",
+			EntityID:             in.PositionID,
+			EffectiveDate:        target.EffectiveDate,
+			EndDate:              target.EndDate,
+			OldValues:            targetOld,
+			NewValues:            map[string]any{"deleted": true},
+			Meta:                 buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
+			Operation:            "DeleteSliceAndStitch",
+			OperationDetails:     opDetail,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        target.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -418,7 +422,7 @@ func (s *OrgService) DeleteEdgeSliceAndStitch(ctx context.Context, tenantID uuid
 			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_EDGE_NOT_FOUND_AT_DATE", "target slice not found", err)
 		}
 
-		freeze, err := s.freezeCheck(settings, txTime, target.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, target.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "edge.corrected", "org_edge", target.ID, target.EffectiveDate, freeze, err, logrus.Fields{
 				"edge_id":       target.ID.String(),
@@ -526,23 +530,24 @@ func (s *OrgService) DeleteEdgeSliceAndStitch(ctx context.Context, tenantID uuid
 			"end_date":       target.EndDate.UTC().Format(time.RFC3339),
 		}
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:        requestID,
-			TransactionTime:  txTime,
-			InitiatorID:      initiatorID,
-			ChangeType:       "edge.corrected",
-			EntityType:       "org_edge",
-			EntityID:         prev.ID,
-			EffectiveDate:    prev.EffectiveDate,
-			EndDate:          target.EndDate,
-			OldValues:        prevOld,
-			NewValues:        prevNew,
-			Meta:             buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
-			Operation:        "DeleteSliceAndStitch",
-			OperationDetails: opDetail,
-			FreezeMode:       freeze.Mode,
-			FreezeViolation:  freeze.Violation,
-			FreezeCutoffUTC:  freeze.CutoffUTC,
-			AffectedAtUTC:    target.EffectiveDate,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "edge.corrected",
+			EntityType:           "org_edge",
+			EntityID:             prev.ID,
+			EffectiveDate:        prev.EffectiveDate,
+			EndDate:              target.EndDate,
+			OldValues:            prevOld,
+			NewValues:            prevNew,
+			Meta:                 buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
+			Operation:            "DeleteSliceAndStitch",
+			OperationDetails:     opDetail,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        target.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -550,23 +555,24 @@ func (s *OrgService) DeleteEdgeSliceAndStitch(ctx context.Context, tenantID uuid
 		stitchedTo = &prev.ID
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:        requestID,
-			TransactionTime:  txTime,
-			InitiatorID:      initiatorID,
-			ChangeType:       "edge.corrected",
-			EntityType:       "org_edge",
-			EntityID:         target.ID,
-			EffectiveDate:    target.EffectiveDate,
-			EndDate:          target.EndDate,
-			OldValues:        targetOld,
-			NewValues:        map[string]any{"deleted": true},
-			Meta:             buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
-			Operation:        "DeleteSliceAndStitch",
-			OperationDetails: opDetail,
-			FreezeMode:       freeze.Mode,
-			FreezeViolation:  freeze.Violation,
-			FreezeCutoffUTC:  freeze.CutoffUTC,
-			AffectedAtUTC:    target.EffectiveDate,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "edge.corrected",
+			EntityType:           "org_edge",
+			EntityID:             target.ID,
+			EffectiveDate:        target.EffectiveDate,
+			EndDate:              target.EndDate,
+			OldValues:            targetOld,
+			NewValues:            map[string]any{"deleted": true},
+			Meta:                 buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
+			Operation:            "DeleteSliceAndStitch",
+			OperationDetails:     opDetail,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        target.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -646,7 +652,7 @@ func (s *OrgService) DeleteAssignmentAndStitch(ctx context.Context, tenantID uui
 			return nil, err
 		}
 
-		freeze, err := s.freezeCheck(settings, txTime, target.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, target.EffectiveDate)
 		if err != nil {
 			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "assignment.corrected", "org_assignment", target.ID, target.EffectiveDate, freeze, err, logrus.Fields{
 				"assignment_id":   target.ID.String(),
@@ -704,23 +710,24 @@ func (s *OrgService) DeleteAssignmentAndStitch(ctx context.Context, tenantID uui
 			}
 
 			_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-				RequestID:        requestID,
-				TransactionTime:  txTime,
-				InitiatorID:      initiatorID,
-				ChangeType:       "assignment.corrected",
-				EntityType:       "org_assignment",
-				EntityID:         prev.ID,
-				EffectiveDate:    prev.EffectiveDate,
-				EndDate:          target.EndDate,
-				OldValues:        prevOld,
-				NewValues:        prevNew,
-				Meta:             buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
-				Operation:        "DeleteSliceAndStitch",
-				OperationDetails: opDetail,
-				FreezeMode:       freeze.Mode,
-				FreezeViolation:  freeze.Violation,
-				FreezeCutoffUTC:  freeze.CutoffUTC,
-				AffectedAtUTC:    target.EffectiveDate,
+				RequestID:            requestID,
+				TransactionTime:      txTime,
+				InitiatorID:          initiatorID,
+				ChangeType:           "assignment.corrected",
+				EntityType:           "org_assignment",
+				EntityID:             prev.ID,
+				EffectiveDate:        prev.EffectiveDate,
+				EndDate:              target.EndDate,
+				OldValues:            prevOld,
+				NewValues:            prevNew,
+				Meta:                 buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
+				Operation:            "DeleteSliceAndStitch",
+				OperationDetails:     opDetail,
+				FreezeMode:           freeze.Mode,
+				FreezeViolation:      freeze.Violation,
+				FreezeOverrideReason: freeze.OverrideReason,
+				FreezeCutoffUTC:      freeze.CutoffUTC,
+				AffectedAtUTC:        target.EffectiveDate,
 			})
 			if err != nil {
 				return nil, err
@@ -729,23 +736,24 @@ func (s *OrgService) DeleteAssignmentAndStitch(ctx context.Context, tenantID uui
 		}
 
 		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
-			RequestID:        requestID,
-			TransactionTime:  txTime,
-			InitiatorID:      initiatorID,
-			ChangeType:       "assignment.corrected",
-			EntityType:       "org_assignment",
-			EntityID:         target.ID,
-			EffectiveDate:    target.EffectiveDate,
-			EndDate:          target.EndDate,
-			OldValues:        targetOld,
-			NewValues:        map[string]any{"deleted": true},
-			Meta:             buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
-			Operation:        "DeleteSliceAndStitch",
-			OperationDetails: opDetail,
-			FreezeMode:       freeze.Mode,
-			FreezeViolation:  freeze.Violation,
-			FreezeCutoffUTC:  freeze.CutoffUTC,
-			AffectedAtUTC:    target.EffectiveDate,
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "assignment.corrected",
+			EntityType:           "org_assignment",
+			EntityID:             target.ID,
+			EffectiveDate:        target.EffectiveDate,
+			EndDate:              target.EndDate,
+			OldValues:            targetOld,
+			NewValues:            map[string]any{"deleted": true},
+			Meta:                 buildReasonMeta(reasonCode, in.ReasonNote, reasonInfo),
+			Operation:            "DeleteSliceAndStitch",
+			OperationDetails:     opDetail,
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        target.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
@@ -39,7 +39,7 @@ func (s *OrgService) ListEdgesTimelineAsChild(ctx context.Context, tenantID uuid
 	if hierarchyType == "" {
 		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "hierarchy_type is required", nil)
 	}
-	if hierarchyType != "OrgUnit" {
+	if !IsValidHierarchyType(hierarchyType) {
 		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "unsupported hierarchy_type", nil)
 	}
 	if childNodeID == uuid.Nil {
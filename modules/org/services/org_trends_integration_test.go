@@ -0,0 +1,121 @@
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/modules/org/infrastructure/persistence"
+	orgsvc "github.com/iota-uz/iota-sdk/modules/org/services"
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	"github.com/iota-uz/iota-sdk/pkg/itf"
+)
+
+func TestOrgTrendReport_MonthlyBuckets(t *testing.T) {
+	ctx := context.Background()
+	isCI := strings.TrimSpace(getenvDefault("CI", "")) != "" || strings.EqualFold(strings.TrimSpace(getenvDefault("GITHUB_ACTIONS", "")), "true")
+
+	if !canDialPostgres(t) {
+		if isCI {
+			t.Fatalf("postgres is not reachable (DB_HOST/DB_PORT).")
+		}
+		t.Skip("postgres is not reachable; skipping org trends test")
+	}
+
+	dbName := t.Name()
+	if !safeCreateDB(t, dbName) {
+		return
+	}
+
+	pool := newPoolWithQueryTracer(t, itf.DbOpts(dbName), &queryCountTracer{})
+	t.Cleanup(pool.Close)
+	applyAllOrgMigrationsFor061A1(t, ctx, pool)
+
+	tenantID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	ensureTenant(t, ctx, pool, tenantID)
+
+	personUUID := uuid.New()
+	seedPerson(t, ctx, pool, tenantID, personUUID, "000321", "Test Person 000321")
+	_, err := pool.Exec(ctx, `
+INSERT INTO org_settings (tenant_id, freeze_mode, freeze_grace_days, reason_code_mode)
+VALUES ($1,'disabled',0,'disabled')
+ON CONFLICT (tenant_id) DO UPDATE SET freeze_mode=excluded.freeze_mode, freeze_grace_days=excluded.freeze_grace_days, reason_code_mode=excluded.reason_code_mode
+`, tenantID)
+	require.NoError(t, err)
+
+	asOf := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	orgNodeID := uuid.New()
+	_, err = pool.Exec(ctx, `
+INSERT INTO org_nodes (tenant_id, id, type, code, is_root)
+VALUES ($1,$2,'OrgUnit','ROOT',true)
+`, tenantID, orgNodeID)
+	require.NoError(t, err)
+
+	managerPos := uuid.New()
+	reportPos := uuid.New()
+	seedOrgPosition(t, ctx, pool, tenantID, orgNodeID, managerPos, "POS-MGR", 1.0, asOf, endDate)
+	seedOrgPosition(t, ctx, pool, tenantID, orgNodeID, reportPos, "POS-REP", 1.0, asOf, endDate)
+	_, err = pool.Exec(ctx, `
+UPDATE org_position_slices SET reports_to_position_id = $3 WHERE tenant_id = $1 AND position_id = $2
+`, tenantID, reportPos, managerPos)
+	require.NoError(t, err)
+
+	repo := persistence.NewOrgRepository()
+	svc := orgsvc.NewOrgService(repo)
+	reqCtx := composables.WithPool(ctx, pool)
+	initiatorID := uuid.New()
+
+	_, err = svc.CreateAssignment(reqCtx, tenantID, "req-trend-primary", initiatorID, orgsvc.CreateAssignmentInput{
+		Pernr:          "000321",
+		EffectiveDate:  asOf,
+		AssignmentType: "primary",
+		AllocatedFTE:   1.0,
+		PositionID:     &managerPos,
+	})
+	require.NoError(t, err)
+	_, err = pool.Exec(ctx, `
+INSERT INTO org_personnel_events (tenant_id, request_id, initiator_id, event_type, person_uuid, pernr, effective_date, reason_code, payload)
+VALUES ($1,'req-trend-hire',$2,'hire',$3,'000321',$4,'legacy',jsonb_build_object('org_node_id', $5::text))
+`, tenantID, initiatorID, personUUID, asOf, orgNodeID.String())
+	require.NoError(t, err)
+
+	_, err = svc.TerminationPersonnelEvent(reqCtx, tenantID, "req-trend-termination", initiatorID, orgsvc.TerminationPersonnelEventInput{
+		Pernr:         "000321",
+		EffectiveDate: time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	res, err := svc.GetTrendReport(reqCtx, tenantID, orgsvc.TrendReportInput{
+		OrgNodeID: &orgNodeID,
+		From:      asOf,
+		To:        time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Points, 3)
+
+	jan, feb, mar := res.Points[0], res.Points[1], res.Points[2]
+	require.Equal(t, "2025-01", jan.Label)
+	require.Equal(t, 1, jan.HeadcountStart)
+	require.Equal(t, 1, jan.HeadcountEnd)
+	require.Equal(t, 1, jan.Hires)
+	require.Equal(t, 2, jan.PositionsTotal)
+	require.Equal(t, 1, jan.VacantPositions)
+	require.InDelta(t, 0.5, jan.VacancyRate, 0.0001)
+	require.Equal(t, 1, jan.ManagerPositions)
+	require.InDelta(t, 1.0, jan.AvgSpanOfControl, 0.0001)
+
+	require.Equal(t, 1, feb.HeadcountStart)
+	require.Equal(t, 0, feb.HeadcountEnd)
+	require.Equal(t, 1, feb.Terminations)
+	require.InDelta(t, 2.0, feb.TurnoverRate, 0.0001)
+	require.Equal(t, 2, feb.VacantPositions)
+
+	require.Equal(t, 0, mar.HeadcountEnd)
+	require.Equal(t, 0, mar.Terminations)
+}
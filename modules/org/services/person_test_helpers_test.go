@@ -16,6 +16,7 @@ func applyAllPersonMigrations(tb testing.TB, ctx context.Context, pool *pgxpool.
 	files := []string{
 		"00001_person_baseline.sql",
 		"00002_person_migration_smoke.sql",
+		"00003_person_display_name_trgm.sql",
 	}
 	for _, f := range files {
 		sql := readGooseUpSQL(tb, filepath.Clean(filepath.Join("..", "..", "..", "migrations", "person", f)))
@@ -0,0 +1,472 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+	importpkg "github.com/iota-uz/iota-sdk/pkg/import"
+)
+
+const (
+	PersonnelEventImportDefaultBatchSize = 200
+	PersonnelEventImportMaxBatchSize     = 1000
+	PersonnelEventImportMaxRows          = 20000
+)
+
+const (
+	PersonnelEventImportRowValid    = "valid"
+	PersonnelEventImportRowInvalid  = "invalid"
+	PersonnelEventImportRowApplied  = "applied"
+	PersonnelEventImportRowReplayed = "replayed"
+)
+
+// PersonnelEventImportColumns is the header every import file must start with, in this order.
+var PersonnelEventImportColumns = []string{
+	"event_type",
+	"pernr",
+	"effective_date",
+	"org_node_id",
+	"position_id",
+	"allocated_fte",
+	"reason_code",
+}
+
+const (
+	importColEventType = iota
+	importColPernr
+	importColEffectiveDate
+	importColOrgNodeID
+	importColPositionID
+	importColAllocatedFTE
+	importColReasonCode
+)
+
+// PersonnelEventImportRow is one parsed data row. Row is the 1-based line in the file (the header is row 1);
+// Err holds the first cell-level problem, in which case the other fields are not trustworthy.
+type PersonnelEventImportRow struct {
+	Row           int
+	EventType     string
+	Pernr         string
+	EffectiveDate time.Time
+	OrgNodeID     uuid.UUID
+	PositionID    *uuid.UUID
+	AllocatedFTE  float64
+	ReasonCode    string
+	Err           error
+}
+
+type PersonnelEventImportRowResult struct {
+	Row           int
+	EventType     string
+	Pernr         string
+	EffectiveDate string
+	RequestID     string
+	Status        string
+	Column        string
+	Code          string
+	Message       string
+	EventID       *uuid.UUID
+}
+
+type PersonnelEventImportReport struct {
+	RequestIDPrefix string
+	DryRun          bool
+	Total           int
+	Valid           int
+	Invalid         int
+	Applied         int
+	Replayed        int
+	Rows            []PersonnelEventImportRowResult
+}
+
+// PersonnelEventImportHandler turns raw spreadsheet rows into PersonnelEventImportRow values.
+// It implements importpkg.ExcelRowHandler: ValidateRow checks cell formats, ProcessRow collects the parsed row.
+type PersonnelEventImportHandler struct {
+	errs      importpkg.ErrorFactory
+	required  importpkg.Validator
+	date      importpkg.Validator
+	eventType importpkg.Validator
+	numeric   importpkg.Validator
+
+	Rows []PersonnelEventImportRow
+}
+
+var _ importpkg.ExcelRowHandler = (*PersonnelEventImportHandler)(nil)
+
+func NewPersonnelEventImportHandler() *PersonnelEventImportHandler {
+	errs := importpkg.NewDefaultErrorFactory()
+	return &PersonnelEventImportHandler{
+		errs:      errs,
+		required:  importpkg.NewRequiredValidator(errs),
+		date:      importpkg.NewDateValidator(errs, time.DateOnly),
+		eventType: importpkg.NewOneOfValidator(errs, []string{PersonnelEventHire, PersonnelEventTransfer, PersonnelEventTermination}),
+		numeric:   importpkg.NewNumericValidator(errs),
+	}
+}
+
+func (h *PersonnelEventImportHandler) ExpectedColumnCount() int {
+	return len(PersonnelEventImportColumns)
+}
+
+func (h *PersonnelEventImportHandler) GetColumnName(index int) string {
+	if index < 0 || index >= len(PersonnelEventImportColumns) {
+		return ""
+	}
+	return PersonnelEventImportColumns[index]
+}
+
+func (h *PersonnelEventImportHandler) ValidateRow(rowIndex int, row []string) error {
+	rowNum := uint(rowIndex + 1)
+	if len(row) > h.ExpectedColumnCount() {
+		return h.errs.NewValidationError(importColumnLetter(h.ExpectedColumnCount()), row[h.ExpectedColumnCount()], rowNum, "unexpected extra column")
+	}
+	cell := func(i int) string { return importCell(row, i) }
+
+	for _, i := range []int{importColEventType, importColPernr, importColEffectiveDate} {
+		if err := h.required.Validate(cell(i), importColumnLetter(i), rowNum); err != nil {
+			return err
+		}
+	}
+	if err := h.eventType.Validate(cell(importColEventType), importColumnLetter(importColEventType), rowNum); err != nil {
+		return h.errs.NewValidationError(importColumnLetter(importColEventType), cell(importColEventType), rowNum, "event_type must be hire, transfer or termination")
+	}
+	if err := h.date.Validate(cell(importColEffectiveDate), importColumnLetter(importColEffectiveDate), rowNum); err != nil {
+		return h.errs.NewValidationError(importColumnLetter(importColEffectiveDate), cell(importColEffectiveDate), rowNum, "effective_date must be YYYY-MM-DD")
+	}
+
+	eventType := strings.ToLower(cell(importColEventType))
+	if eventType == PersonnelEventTermination {
+		return nil
+	}
+	if cell(importColOrgNodeID) == "" {
+		return h.errs.NewValidationError(importColumnLetter(importColOrgNodeID), "", rowNum, "org_node_id is required for "+eventType)
+	}
+	for _, i := range []int{importColOrgNodeID, importColPositionID} {
+		if v := cell(i); v != "" {
+			if _, err := uuid.Parse(v); err != nil {
+				return h.errs.NewValidationError(importColumnLetter(i), v, rowNum, PersonnelEventImportColumns[i]+" must be a UUID")
+			}
+		}
+	}
+	if v := cell(importColAllocatedFTE); v != "" {
+		if err := h.numeric.Validate(v, importColumnLetter(importColAllocatedFTE), rowNum); err != nil {
+			return h.errs.NewValidationError(importColumnLetter(importColAllocatedFTE), v, rowNum, "allocated_fte must be a number")
+		}
+		if fte, _ := strconv.ParseFloat(v, 64); fte <= 0 || fte > 1 {
+			return h.errs.NewValidationError(importColumnLetter(importColAllocatedFTE), v, rowNum, "allocated_fte must be in (0, 1]")
+		}
+	}
+	return nil
+}
+
+func (h *PersonnelEventImportHandler) ProcessRow(_ context.Context, rowIndex int, row []string) error {
+	cell := func(i int) string { return importCell(row, i) }
+	parsed := PersonnelEventImportRow{
+		Row:        rowIndex + 1,
+		EventType:  strings.ToLower(cell(importColEventType)),
+		Pernr:      cell(importColPernr),
+		ReasonCode: cell(importColReasonCode),
+	}
+	parsed.EffectiveDate, _ = time.Parse(time.DateOnly, cell(importColEffectiveDate))
+	if parsed.EventType != PersonnelEventTermination {
+		parsed.OrgNodeID, _ = uuid.Parse(cell(importColOrgNodeID))
+		if v := cell(importColPositionID); v != "" {
+			id, _ := uuid.Parse(v)
+			parsed.PositionID = &id
+		}
+		if v := cell(importColAllocatedFTE); v != "" {
+			parsed.AllocatedFTE, _ = strconv.ParseFloat(v, 64)
+		}
+	}
+	h.Rows = append(h.Rows, parsed)
+	return nil
+}
+
+// ParsePersonnelEventImport checks the header and parses every data row. Rows that fail cell validation are
+// kept with Err set so the preview can show them next to the valid ones; blank lines are skipped.
+func ParsePersonnelEventImport(ctx context.Context, rows [][]string) ([]PersonnelEventImportRow, error) {
+	if len(rows) == 0 {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_IMPORT_EMPTY", "import file is empty", nil)
+	}
+	header := rows[0]
+	for i, name := range PersonnelEventImportColumns {
+		if !strings.EqualFold(importCell(header, i), name) {
+			return nil, newServiceError(http.StatusBadRequest, "ORG_IMPORT_INVALID_HEADER",
+				fmt.Sprintf("column %s must be %q", importColumnLetter(i), name), nil)
+		}
+	}
+	if len(rows)-1 > PersonnelEventImportMaxRows {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_IMPORT_TOO_LARGE",
+			fmt.Sprintf("import is limited to %d rows", PersonnelEventImportMaxRows), nil)
+	}
+
+	h := NewPersonnelEventImportHandler()
+	for i := 1; i < len(rows); i++ {
+		if importRowBlank(rows[i]) {
+			continue
+		}
+		if err := h.ValidateRow(i, rows[i]); err != nil {
+			h.Rows = append(h.Rows, PersonnelEventImportRow{
+				Row:       i + 1,
+				EventType: strings.ToLower(importCell(rows[i], importColEventType)),
+				Pernr:     importCell(rows[i], importColPernr),
+				Err:       err,
+			})
+			continue
+		}
+		if err := h.ProcessRow(ctx, i, rows[i]); err != nil {
+			return nil, err
+		}
+	}
+	if len(h.Rows) == 0 {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_IMPORT_EMPTY", "import file has no data rows", nil)
+	}
+	return h.Rows, nil
+}
+
+// ReadPersonnelEventImportFile reads a .csv or .xlsx upload and parses it with ParsePersonnelEventImport.
+// The importpkg readers work on paths, so src is spooled to a temporary file that keeps the extension.
+func ReadPersonnelEventImportFile(ctx context.Context, filename string, src io.Reader) ([]PersonnelEventImportRow, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if ext != ".csv" && ext != ".xlsx" {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_IMPORT_UNSUPPORTED_FILE", "file must be .csv or .xlsx", nil)
+	}
+
+	tmp, err := os.CreateTemp("", "org-personnel-events-*"+ext)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := io.Copy(tmp, src); err != nil {
+		_ = tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, err
+	}
+
+	raw, err := importpkg.NewExtensionFileReader().ReadExcelRows(tmp.Name())
+	if err != nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_IMPORT_UNREADABLE", "cannot read "+filepath.Base(filename), err)
+	}
+	return ParsePersonnelEventImport(ctx, raw)
+}
+
+// PreviewPersonnelEventImport runs every row through the personnel event rules inside one transaction that is
+// always rolled back, so later rows see the effect of earlier ones (e.g. a hire followed by a transfer).
+func (s *OrgService) PreviewPersonnelEventImport(ctx context.Context, tenantID uuid.UUID, requestIDPrefix string, initiatorID uuid.UUID, rows []PersonnelEventImportRow) (*PersonnelEventImportReport, error) {
+	report, err := newPersonnelEventImportReport(tenantID, requestIDPrefix, rows, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.runPersonnelEventImportBatch(ctx, tenantID, initiatorID, rows, report.Rows, false); err != nil {
+		return nil, err
+	}
+	report.tally()
+	return report, nil
+}
+
+// ApplyPersonnelEventImport applies the rows in batches, each batch in its own transaction. Every row uses the
+// request_id "<prefix>:<row>", so re-running an import with the same prefix replays rows applied before instead
+// of duplicating them. Rows that fail validation are reported and skipped; the rest of their batch still commits.
+func (s *OrgService) ApplyPersonnelEventImport(ctx context.Context, tenantID uuid.UUID, requestIDPrefix string, initiatorID uuid.UUID, rows []PersonnelEventImportRow, batchSize int) (*PersonnelEventImportReport, error) {
+	if batchSize <= 0 {
+		batchSize = PersonnelEventImportDefaultBatchSize
+	}
+	if batchSize > PersonnelEventImportMaxBatchSize {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY",
+			fmt.Sprintf("batch_size must be <= %d", PersonnelEventImportMaxBatchSize), nil)
+	}
+	report, err := newPersonnelEventImportReport(tenantID, requestIDPrefix, rows, false)
+	if err != nil {
+		return nil, err
+	}
+	for start := 0; start < len(rows); start += batchSize {
+		end := min(start+batchSize, len(rows))
+		if err := s.runPersonnelEventImportBatch(ctx, tenantID, initiatorID, rows[start:end], report.Rows[start:end], true); err != nil {
+			return nil, err
+		}
+	}
+	report.tally()
+	return report, nil
+}
+
+func newPersonnelEventImportReport(tenantID uuid.UUID, requestIDPrefix string, rows []PersonnelEventImportRow, dryRun bool) (*PersonnelEventImportReport, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	prefix := strings.TrimSpace(requestIDPrefix)
+	if prefix == "" {
+		prefix = "import-" + uuid.NewString()
+	}
+	report := &PersonnelEventImportReport{
+		RequestIDPrefix: prefix,
+		DryRun:          dryRun,
+		Total:           len(rows),
+		Rows:            make([]PersonnelEventImportRowResult, len(rows)),
+	}
+	for i, row := range rows {
+		res := PersonnelEventImportRowResult{
+			Row:       row.Row,
+			EventType: row.EventType,
+			Pernr:     row.Pernr,
+			RequestID: fmt.Sprintf("%s:%d", prefix, row.Row),
+		}
+		if !row.EffectiveDate.IsZero() {
+			res.EffectiveDate = row.EffectiveDate.Format(time.DateOnly)
+		}
+		if row.Err != nil {
+			res.Status = PersonnelEventImportRowInvalid
+			res.Code = "ORG_IMPORT_INVALID_CELL"
+			res.Message = row.Err.Error()
+			var cellErr *importpkg.ValidationError
+			var missingErr *importpkg.InvalidCellError
+			switch {
+			case errors.As(row.Err, &cellErr):
+				res.Column = cellErr.Col
+			case errors.As(row.Err, &missingErr):
+				res.Column = missingErr.Col
+				res.Message = "required value is missing"
+			}
+		}
+		report.Rows[i] = res
+	}
+	return report, nil
+}
+
+func (r *PersonnelEventImportReport) tally() {
+	for _, row := range r.Rows {
+		switch row.Status {
+		case PersonnelEventImportRowInvalid:
+			r.Invalid++
+		case PersonnelEventImportRowValid:
+			r.Valid++
+		case PersonnelEventImportRowApplied:
+			r.Valid++
+			r.Applied++
+		case PersonnelEventImportRowReplayed:
+			r.Valid++
+			r.Replayed++
+		}
+	}
+}
+
+// runPersonnelEventImportBatch executes rows in one transaction, giving each row its own savepoint so a rejected
+// row is rolled back without aborting the others. The transaction commits only when commit is true.
+func (s *OrgService) runPersonnelEventImportBatch(ctx context.Context, tenantID uuid.UUID, initiatorID uuid.UUID, rows []PersonnelEventImportRow, results []PersonnelEventImportRowResult, commit bool) error {
+	pool, err := composables.UsePool(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for i, row := range rows {
+		if row.Err != nil {
+			continue
+		}
+		sp, err := tx.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		res, err := s.applyPersonnelEventImportRow(composables.WithTx(ctx, sp), tenantID, results[i].RequestID, initiatorID, row)
+		if err != nil {
+			if rbErr := sp.Rollback(ctx); rbErr != nil {
+				return rbErr
+			}
+			var svcErr *ServiceError
+			if !errors.As(mapPgErrorToServiceError(err), &svcErr) {
+				return err
+			}
+			results[i].Status = PersonnelEventImportRowInvalid
+			results[i].Code = svcErr.Code
+			results[i].Message = svcErr.Message
+			continue
+		}
+		if err := sp.Commit(ctx); err != nil {
+			return err
+		}
+
+		eventID := res.Event.ID
+		switch {
+		case !res.Created:
+			results[i].Status = PersonnelEventImportRowReplayed
+			results[i].EventID = &eventID
+		case commit:
+			results[i].Status = PersonnelEventImportRowApplied
+			results[i].EventID = &eventID
+		default:
+			results[i].Status = PersonnelEventImportRowValid
+		}
+	}
+
+	if !commit {
+		return nil
+	}
+	return tx.Commit(ctx)
+}
+
+func (s *OrgService) applyPersonnelEventImportRow(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, row PersonnelEventImportRow) (*PersonnelEventApplyResult, error) {
+	switch row.EventType {
+	case PersonnelEventHire:
+		return s.HirePersonnelEvent(ctx, tenantID, requestID, initiatorID, HirePersonnelEventInput{
+			Pernr:         row.Pernr,
+			OrgNodeID:     row.OrgNodeID,
+			PositionID:    row.PositionID,
+			EffectiveDate: row.EffectiveDate,
+			AllocatedFTE:  row.AllocatedFTE,
+			ReasonCode:    row.ReasonCode,
+		})
+	case PersonnelEventTransfer:
+		return s.TransferPersonnelEvent(ctx, tenantID, requestID, initiatorID, TransferPersonnelEventInput{
+			Pernr:         row.Pernr,
+			OrgNodeID:     row.OrgNodeID,
+			PositionID:    row.PositionID,
+			EffectiveDate: row.EffectiveDate,
+			AllocatedFTE:  row.AllocatedFTE,
+			ReasonCode:    row.ReasonCode,
+		})
+	case PersonnelEventTermination:
+		return s.TerminationPersonnelEvent(ctx, tenantID, requestID, initiatorID, TerminationPersonnelEventInput{
+			Pernr:         row.Pernr,
+			EffectiveDate: row.EffectiveDate,
+			ReasonCode:    row.ReasonCode,
+		})
+	default:
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "unsupported event_type", nil)
+	}
+}
+
+func importCell(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+func importRowBlank(row []string) bool {
+	for _, v := range row {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func importColumnLetter(i int) string {
+	return string(rune('A' + i))
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePersonnelEventImport(t *testing.T) {
+	nodeID, positionID := uuid.New(), uuid.New()
+	header := []string{"event_type", "pernr", "effective_date", "org_node_id", "position_id", "allocated_fte", "reason_code"}
+
+	rows, err := ParsePersonnelEventImport(context.Background(), [][]string{
+		header,
+		{"Hire", "000001", "2025-01-01", nodeID.String(), positionID.String(), "0.5", "new"},
+		{"termination", "000002", "2025-02-01"},
+		{"", "", ""},
+		{"transfer", "000003", "2025-03-01"},
+		{"hire", "000004", "01/02/2025", nodeID.String()},
+		{"promotion", "000005", "2025-01-01", nodeID.String()},
+		{"hire", "000006", "2025-01-01", nodeID.String(), "", "2"},
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 6)
+
+	require.NoError(t, rows[0].Err)
+	require.Equal(t, 2, rows[0].Row)
+	require.Equal(t, PersonnelEventHire, rows[0].EventType)
+	require.Equal(t, nodeID, rows[0].OrgNodeID)
+	require.Equal(t, positionID, *rows[0].PositionID)
+	require.InDelta(t, 0.5, rows[0].AllocatedFTE, 0.0001)
+	require.Equal(t, "new", rows[0].ReasonCode)
+
+	require.NoError(t, rows[1].Err)
+	require.Equal(t, PersonnelEventTermination, rows[1].EventType)
+	require.Equal(t, "2025-02-01", rows[1].EffectiveDate.Format("2006-01-02"))
+
+	for i, wantRow := range map[int]int{2: 5, 3: 6, 4: 7, 5: 8} {
+		require.Error(t, rows[i].Err, "row %d", wantRow)
+		require.Equal(t, wantRow, rows[i].Row)
+	}
+
+	report, err := newPersonnelEventImportReport(uuid.New(), "cycle-2025", rows, true)
+	require.NoError(t, err)
+	require.Equal(t, "cycle-2025:2", report.Rows[0].RequestID)
+	require.Equal(t, "D", report.Rows[2].Column)
+	require.Equal(t, "C", report.Rows[3].Column)
+	require.Equal(t, "A", report.Rows[4].Column)
+	require.Equal(t, "F", report.Rows[5].Column)
+	require.Equal(t, PersonnelEventImportRowInvalid, report.Rows[5].Status)
+}
+
+func TestParsePersonnelEventImport_RejectsBadHeader(t *testing.T) {
+	_, err := ParsePersonnelEventImport(context.Background(), [][]string{{"pernr", "event_type"}, {"000001", "hire"}})
+	var svcErr *ServiceError
+	require.True(t, errors.As(err, &svcErr))
+	require.Equal(t, "ORG_IMPORT_INVALID_HEADER", svcErr.Code)
+}
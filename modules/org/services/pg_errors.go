@@ -39,6 +39,8 @@ func mapPgErrorToServiceError(err error) error {
 			return newServiceError(http.StatusConflict, "ORG_JOB_CATALOG_CODE_CONFLICT", "job catalog code already exists", err)
 		case "org_job_profiles_tenant_id_code_key":
 			return newServiceError(http.StatusConflict, "ORG_JOB_PROFILE_CODE_CONFLICT", "job profile code already exists", err)
+		case "org_roles_tenant_id_code_key":
+			return newServiceError(http.StatusConflict, "ORG_ROLE_CODE_CONFLICT", "role code already exists", err)
 		case "org_nodes_tenant_root_unique":
 			return newServiceError(http.StatusConflict, "ORG_OVERLAP", "root already exists", err)
 		default:
@@ -49,6 +51,18 @@ func mapPgErrorToServiceError(err error) error {
 		if strings.Contains(pgErr.ConstraintName, "org_assignments_primary_unique_in_time") {
 			return newServiceError(http.StatusConflict, "ORG_PRIMARY_CONFLICT", "primary assignment conflict", err)
 		}
+		if pgErr.ConstraintName == "org_delegations_acting_manager_no_overlap" {
+			return newServiceError(http.StatusConflict, "ORG_DELEGATION_OVERLAP", "another acting manager overlaps this window", err)
+		}
+		if pgErr.ConstraintName == "org_attribute_inheritance_breaks_no_overlap" {
+			return newServiceError(http.StatusConflict, "ORG_INHERITANCE_BREAK_OVERLAP", "an inheritance break for this node and attribute overlaps this window", err)
+		}
+		if pgErr.ConstraintName == "org_attribute_definitions_no_overlap" {
+			return newServiceError(http.StatusConflict, "ORG_ATTRIBUTE_DEFINITION_OVERLAP", "a definition for this attribute overlaps this window", err)
+		}
+		if pgErr.ConstraintName == "org_freeze_periods_no_overlap" {
+			return newServiceError(http.StatusConflict, "ORG_FREEZE_PERIOD_OVERLAP", "another freeze period overlaps this range", err)
+		}
 		return newServiceError(http.StatusConflict, "ORG_OVERLAP", "time window overlap", err)
 	case "23503": // foreign_key_violation
 		recordWriteConflict("foreign_key")
@@ -30,6 +30,7 @@ type PositionSliceInsert struct {
 	JobProfileID        uuid.UUID
 	CostCenterCode      *string
 	Profile             json.RawMessage
+	CustomAttributes    map[string]any
 	EffectiveDate       time.Time
 	EndDate             time.Time
 }
@@ -46,6 +47,8 @@ type PositionSliceInPlacePatch struct {
 	JobProfileID        *uuid.UUID
 	CostCenterCode      *string
 	Profile             *json.RawMessage
+	// CustomAttributes, when non-nil, replaces the stored values.
+	CustomAttributes map[string]any
 }
 
 type PositionListFilter struct {
@@ -78,6 +81,7 @@ type PositionViewRow struct {
 	JobProfileID        uuid.UUID       `json:"job_profile_id"`
 	CostCenterCode      *string         `json:"cost_center_code,omitempty"`
 	Profile             json.RawMessage `json:"profile,omitempty"`
+	CustomAttributes    map[string]any  `json:"custom_attributes,omitempty"`
 	EffectiveDate       time.Time       `json:"effective_date"`
 	EndDate             time.Time       `json:"end_date"`
 }
@@ -96,6 +100,7 @@ type PositionSliceRow struct {
 	JobProfileID        uuid.UUID
 	CostCenterCode      *string
 	Profile             json.RawMessage
+	CustomAttributes    map[string]any
 	EffectiveDate       time.Time
 	EndDate             time.Time
 }
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBulkPositions caps how many positions one bulk call may write.
+const MaxBulkPositions = 500
+
+// positionListPageSize matches the repository's ListPositionsAsOf cap.
+const positionListPageSize = 200
+
+// BulkCreatePositionsInput creates one position per code from Template. Template.Code is ignored.
+type BulkCreatePositionsInput struct {
+	Template CreatePositionInput
+	Codes    []string
+}
+
+// BulkPositionFilter selects positions by explicit ids or, when PositionIDs is empty, by the
+// position list filters evaluated at the write's effective date. At least one criterion is required.
+// Filters skip system (auto-created) positions.
+type BulkPositionFilter struct {
+	PositionIDs     []uuid.UUID
+	OrgNodeID       *uuid.UUID
+	Q               *string
+	LifecycleStatus *string
+	StaffingState   *string
+}
+
+// BulkUpdatePositionsInput applies Patch to every selected position. Patch.PositionID is ignored.
+type BulkUpdatePositionsInput struct {
+	Filter BulkPositionFilter
+	Patch  UpdatePositionInput
+}
+
+type BulkTransferPositionsInput struct {
+	PositionIDs     []uuid.UUID
+	TargetOrgNodeID uuid.UUID
+	EffectiveDate   time.Time
+	ReasonCode      string
+	ReasonNote      *string
+}
+
+type BulkPositionResult struct {
+	PositionID    uuid.UUID
+	Code          string
+	SliceID       uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+type BulkPositionsResult struct {
+	RequestID string
+	Items     []BulkPositionResult
+}
+
+// BulkPositionCodes numbers count codes from start, e.g. ("FIN-", 1, 3) gives FIN-001..FIN-003.
+// Numbers are zero-padded to at least three digits.
+func BulkPositionCodes(prefix string, start, count int) []string {
+	if count <= 0 {
+		return nil
+	}
+	width := len(strconv.Itoa(start + count - 1))
+	if width < 3 {
+		width = 3
+	}
+	out := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		out = append(out, fmt.Sprintf("%s%0*d", prefix, width, start+i))
+	}
+	return out
+}
+
+// BulkCreatePositions creates all positions in one transaction under one request_id. The first
+// failing position aborts the batch and is reported as a *CommandError indexed into Codes.
+func (s *OrgService) BulkCreatePositions(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in BulkCreatePositionsInput) (*BulkPositionsResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	codes := make([]string, 0, len(in.Codes))
+	seen := make(map[string]struct{}, len(in.Codes))
+	for _, code := range in.Codes {
+		code = strings.TrimSpace(code)
+		if code == "" {
+			return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "codes must not be empty", nil)
+		}
+		if _, ok := seen[code]; ok {
+			return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "codes must be unique", nil)
+		}
+		seen[code] = struct{}{}
+		codes = append(codes, code)
+	}
+	if err := checkBulkPositionsSize(len(codes)); err != nil {
+		return nil, err
+	}
+
+	out, err := inTx(ctx, tenantID, func(txCtx context.Context) (*BulkPositionsResult, error) {
+		txCtx = WithSkipCacheInvalidation(txCtx)
+		res := &BulkPositionsResult{RequestID: requestID, Items: make([]BulkPositionResult, 0, len(codes))}
+		for i, code := range codes {
+			item := in.Template
+			item.Code = code
+			created, err := s.CreatePosition(txCtx, tenantID, requestID, initiatorID, item)
+			if err != nil {
+				return nil, &CommandError{Index: i, Type: "position.create", Err: err}
+			}
+			res.Items = append(res.Items, BulkPositionResult{
+				PositionID:    created.PositionID,
+				Code:          code,
+				SliceID:       created.SliceID,
+				EffectiveDate: created.EffectiveDate,
+				EndDate:       created.EndDate,
+			})
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	return out, nil
+}
+
+// BulkUpdatePositions resolves the filter at Patch.EffectiveDate and updates every match in one
+// transaction under one request_id.
+func (s *OrgService) BulkUpdatePositions(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in BulkUpdatePositionsInput) (*BulkPositionsResult, error) {
+	return s.bulkUpdatePositions(ctx, tenantID, requestID, initiatorID, "position.update", in.Filter, in.Patch)
+}
+
+// BulkTransferPositions moves the positions to TargetOrgNodeID as of EffectiveDate.
+func (s *OrgService) BulkTransferPositions(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in BulkTransferPositionsInput) (*BulkPositionsResult, error) {
+	if in.TargetOrgNodeID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "target_org_node_id is required", nil)
+	}
+	if len(in.PositionIDs) == 0 {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "position_ids are required", nil)
+	}
+	target := in.TargetOrgNodeID
+	return s.bulkUpdatePositions(ctx, tenantID, requestID, initiatorID, "position.transfer", BulkPositionFilter{PositionIDs: in.PositionIDs}, UpdatePositionInput{
+		EffectiveDate: in.EffectiveDate,
+		ReasonCode:    in.ReasonCode,
+		ReasonNote:    in.ReasonNote,
+		OrgNodeID:     &target,
+	})
+}
+
+func (s *OrgService) bulkUpdatePositions(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, commandType string, filter BulkPositionFilter, patch UpdatePositionInput) (*BulkPositionsResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	if patch.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "effective_date is required", nil)
+	}
+
+	out, err := inTx(ctx, tenantID, func(txCtx context.Context) (*BulkPositionsResult, error) {
+		txCtx = WithSkipCacheInvalidation(txCtx)
+		ids, err := s.resolveBulkPositionIDs(txCtx, tenantID, patch.EffectiveDate, filter)
+		if err != nil {
+			return nil, err
+		}
+		res := &BulkPositionsResult{RequestID: requestID, Items: make([]BulkPositionResult, 0, len(ids))}
+		for i, id := range ids {
+			item := patch
+			item.PositionID = id
+			updated, err := s.UpdatePosition(txCtx, tenantID, requestID, initiatorID, item)
+			if err != nil {
+				return nil, &CommandError{Index: i, Type: commandType, Err: err}
+			}
+			res.Items = append(res.Items, BulkPositionResult{
+				PositionID:    updated.PositionID,
+				SliceID:       updated.SliceID,
+				EffectiveDate: updated.EffectiveDate,
+				EndDate:       updated.EndDate,
+			})
+		}
+		return res, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	return out, nil
+}
+
+func (s *OrgService) resolveBulkPositionIDs(ctx context.Context, tenantID uuid.UUID, asOf time.Time, filter BulkPositionFilter) ([]uuid.UUID, error) {
+	if len(filter.PositionIDs) > 0 {
+		ids := make([]uuid.UUID, 0, len(filter.PositionIDs))
+		seen := make(map[uuid.UUID]struct{}, len(filter.PositionIDs))
+		for _, id := range filter.PositionIDs {
+			if id == uuid.Nil {
+				return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "position_ids are invalid", nil)
+			}
+			if _, ok := seen[id]; ok {
+				continue
+			}
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+		return ids, checkBulkPositionsSize(len(ids))
+	}
+
+	q := trimOptionalText(filter.Q)
+	if filter.OrgNodeID == nil && q == nil && filter.LifecycleStatus == nil && filter.StaffingState == nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "position_ids or a filter is required", nil)
+	}
+	// System positions are read-only, so a filter never selects them.
+	isAutoCreated := false
+	var ids []uuid.UUID
+	for offset := 0; ; offset += positionListPageSize {
+		rows, err := s.repo.ListPositionsAsOf(ctx, tenantID, asOf, PositionListFilter{
+			OrgNodeID:       filter.OrgNodeID,
+			Q:               q,
+			LifecycleStatus: filter.LifecycleStatus,
+			StaffingState:   filter.StaffingState,
+			IsAutoCreated:   &isAutoCreated,
+			Limit:           positionListPageSize,
+			Offset:          offset,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			ids = append(ids, row.PositionID)
+		}
+		if len(ids) > MaxBulkPositions {
+			return nil, checkBulkPositionsSize(len(ids))
+		}
+		if len(rows) < positionListPageSize {
+			break
+		}
+	}
+	return ids, checkBulkPositionsSize(len(ids))
+}
+
+func checkBulkPositionsSize(n int) error {
+	if n == 0 {
+		return newServiceError(http.StatusUnprocessableEntity, "ORG_BULK_EMPTY", "no positions selected", nil)
+	}
+	if n > MaxBulkPositions {
+		return newServiceError(http.StatusUnprocessableEntity, "ORG_BULK_TOO_LARGE", fmt.Sprintf("at most %d positions per bulk operation", MaxBulkPositions), nil)
+	}
+	return nil
+}
@@ -84,7 +84,7 @@ func (s *OrgService) SetPositionRestrictions(ctx context.Context, tenantID uuid.
 			logReasonCodeRejected(txCtx, tenantID, requestID, "position.restrictions.updated", reasonInfo, svcErr)
 			return nil, svcErr
 		}
-		freeze, err := s.freezeCheck(settings, txTime, in.EffectiveDate)
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
 		if err != nil {
 			return nil, err
 		}
@@ -135,6 +135,7 @@ func (s *OrgService) SetPositionRestrictions(ctx context.Context, tenantID uuid.
 			JobProfileID:        current.JobProfileID,
 			CostCenterCode:      current.CostCenterCode,
 			Profile:             nextProfile,
+			CustomAttributes:    current.CustomAttributes,
 			EffectiveDate:       in.EffectiveDate,
 			EndDate:             newEnd,
 		})
@@ -172,11 +173,12 @@ func (s *OrgService) SetPositionRestrictions(ctx context.Context, tenantID uuid.
 				addReasonCodeMeta(meta, reasonInfo)
 				return meta
 			}(),
-			Operation:       "Update",
-			FreezeMode:      freeze.Mode,
-			FreezeViolation: freeze.Violation,
-			FreezeCutoffUTC: freeze.CutoffUTC,
-			AffectedAtUTC:   in.EffectiveDate,
+			Operation:            "Update",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
 		})
 		if err != nil {
 			return nil, err
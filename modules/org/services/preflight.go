@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/iota-uz/iota-sdk/pkg/composables"
+)
+
+const (
+	maxPreflightCommands     = 100
+	maxPreflightMoves        = 10
+	maxPreflightSubtreeNodes = 5000
+)
+
+// PreflightInput is the command list shared by preflight, change request and scenario payloads.
+type PreflightInput struct {
+	EffectiveDate string    `json:"effective_date"`
+	Commands      []Command `json:"commands"`
+}
+
+type PreflightResult struct {
+	EffectiveDate string          `json:"effective_date"`
+	CommandsCount int             `json:"commands_count"`
+	Impact        PreflightImpact `json:"impact"`
+	Warnings      []string        `json:"warnings"`
+}
+
+type PreflightImpact struct {
+	OrgNodes       PreflightCounters `json:"org_nodes"`
+	OrgAssignments PreflightCounters `json:"org_assignments"`
+	Events         map[string]int    `json:"events"`
+	Affected       PreflightAffected `json:"affected"`
+}
+
+type PreflightCounters struct {
+	Create  int `json:"create"`
+	Update  int `json:"update"`
+	Move    int `json:"move"`
+	Rescind int `json:"rescind"`
+}
+
+type PreflightAffected struct {
+	OrgNodeIDsCount  int      `json:"org_node_ids_count"`
+	OrgNodeIDsSample []string `json:"org_node_ids_sample"`
+}
+
+// Preflight validates commands by executing them in a rolled-back transaction and
+// summarizes their impact. It never writes.
+func (s *OrgService) Preflight(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in PreflightInput) (PreflightResult, error) {
+	if len(in.Commands) < 1 || len(in.Commands) > maxPreflightCommands {
+		return PreflightResult{}, newServiceError(422, "ORG_PREFLIGHT_TOO_LARGE", "commands size is invalid", nil)
+	}
+
+	moves := 0
+	for _, cmd := range in.Commands {
+		switch strings.TrimSpace(cmd.Type) {
+		case "node.move", "node.correct_move":
+			moves++
+		}
+	}
+	if moves > maxPreflightMoves {
+		return PreflightResult{}, newServiceError(422, "ORG_PREFLIGHT_TOO_LARGE", "too many move commands", nil)
+	}
+
+	globalEffective := strings.TrimSpace(in.EffectiveDate)
+	asOf := normalizeValidTimeDayUTC(time.Now().UTC())
+	if globalEffective != "" {
+		parsed, err := parseCommandDate("effective_date", globalEffective)
+		if err != nil {
+			return PreflightResult{}, newServiceError(422, "ORG_PREFLIGHT_INVALID_BODY", "effective_date is invalid", err)
+		}
+		asOf = parsed
+	}
+	globalEffective = asOf.Format(time.DateOnly)
+
+	if err := inRolledBackTx(ctx, tenantID, func(txCtx context.Context) error {
+		_, _, err := s.ExecuteCommands(txCtx, tenantID, requestID, initiatorID, globalEffective, in.Commands, "ORG_PREFLIGHT_INVALID_COMMAND")
+		return err
+	}); err != nil {
+		return PreflightResult{}, err
+	}
+
+	impact, err := s.analyzePreflightImpact(ctx, tenantID, asOf, in.Commands)
+	if err != nil {
+		return PreflightResult{}, err
+	}
+
+	return PreflightResult{
+		EffectiveDate: globalEffective,
+		CommandsCount: len(in.Commands),
+		Impact:        impact,
+		Warnings:      []string{},
+	}, nil
+}
+
+// inRolledBackTx runs fn in a tenant-scoped transaction that is always rolled back, with cache
+// invalidation and outbox enqueueing switched off. It is how commands are replayed to see their
+// effect without writing anything.
+func inRolledBackTx(ctx context.Context, tenantID uuid.UUID, fn func(txCtx context.Context) error) error {
+	pool, err := composables.UsePool(ctx)
+	if err != nil {
+		return err
+	}
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	txCtx := composables.WithTx(ctx, tx)
+	txCtx = composables.WithTenantID(txCtx, tenantID)
+	if err := composables.ApplyTenantRLS(txCtx, tx); err != nil {
+		return err
+	}
+	txCtx = WithSkipCacheInvalidation(txCtx)
+	txCtx = WithSkipOutboxEnqueue(txCtx)
+	return fn(txCtx)
+}
+
+func (s *OrgService) analyzePreflightImpact(ctx context.Context, tenantID uuid.UUID, asOf time.Time, commands []Command) (PreflightImpact, error) {
+	out := PreflightImpact{
+		Events: map[string]int{
+			"org.changed.v1":            0,
+			"org.assignment.changed.v1": 0,
+		},
+		Affected: PreflightAffected{
+			OrgNodeIDsCount:  0,
+			OrgNodeIDsSample: []string{},
+		},
+	}
+
+	nodes, _, err := s.GetHierarchyAsOf(ctx, tenantID, HierarchyTypeOrgUnit, asOf)
+	if err != nil {
+		return PreflightImpact{}, err
+	}
+
+	children := map[uuid.UUID][]uuid.UUID{}
+	for _, n := range nodes {
+		if n.ParentID == nil {
+			continue
+		}
+		children[*n.ParentID] = append(children[*n.ParentID], n.ID)
+	}
+
+	affected := map[uuid.UUID]struct{}{}
+	addAffected := func(id uuid.UUID) {
+		if id == uuid.Nil {
+			return
+		}
+		affected[id] = struct{}{}
+	}
+
+	for _, cmd := range commands {
+		var body struct {
+			ID        uuid.UUID  `json:"id"`
+			OrgNodeID *uuid.UUID `json:"org_node_id"`
+		}
+		_ = json.Unmarshal(cmd.Payload, &body)
+
+		switch strings.TrimSpace(cmd.Type) {
+		case "node.create":
+			out.OrgNodes.Create++
+			out.Events["org.changed.v1"]++
+		case "node.update", "node.correct", "node.shift_boundary":
+			out.OrgNodes.Update++
+			out.Events["org.changed.v1"]++
+			addAffected(body.ID)
+		case "node.rescind":
+			out.OrgNodes.Rescind++
+			out.Events["org.changed.v1"]++
+			addAffected(body.ID)
+		case "node.move", "node.correct_move":
+			out.OrgNodes.Move++
+			out.Events["org.changed.v1"]++
+			if body.ID != uuid.Nil {
+				// Count subtree (including self) at asOf.
+				count := 0
+				stack := []uuid.UUID{body.ID}
+				for len(stack) > 0 {
+					n := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					count++
+					if count > maxPreflightSubtreeNodes {
+						return PreflightImpact{}, newServiceError(422, "ORG_PREFLIGHT_TOO_LARGE", "subtree impact is too large", nil)
+					}
+					addAffected(n)
+					stack = append(stack, children[n]...)
+				}
+			}
+		case "assignment.create":
+			out.OrgAssignments.Create++
+			out.Events["org.assignment.changed.v1"]++
+			if body.OrgNodeID != nil {
+				addAffected(*body.OrgNodeID)
+			}
+		case "assignment.update":
+			out.OrgAssignments.Update++
+			out.Events["org.assignment.changed.v1"]++
+			if body.OrgNodeID != nil {
+				addAffected(*body.OrgNodeID)
+			}
+		case "assignment.correct":
+			out.OrgAssignments.Update++
+			out.Events["org.assignment.changed.v1"]++
+		case "assignment.rescind":
+			out.OrgAssignments.Rescind++
+			out.Events["org.assignment.changed.v1"]++
+		}
+	}
+
+	sample := make([]string, 0, 20)
+	for id := range affected {
+		if len(sample) >= 20 {
+			break
+		}
+		sample = append(sample, id.String())
+	}
+	out.Affected.OrgNodeIDsCount = len(affected)
+	out.Affected.OrgNodeIDsSample = sample
+	return out, nil
+}
@@ -26,6 +26,10 @@ func maybeLogFrozenWindowRejected(ctx context.Context, tenantID uuid.UUID, reque
 		"freeze_violation":  freeze.Violation,
 		"error_code":        svcErr.Code,
 	}
+	if freeze.Period != nil {
+		fields["freeze_period_id"] = freeze.Period.ID.String()
+		fields["freeze_period_label"] = freeze.Period.Label
+	}
 	if initiatorID != uuid.Nil {
 		fields["initiator_id"] = initiatorID.String()
 	}
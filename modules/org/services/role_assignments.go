@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	orgRoleCodeMaxLen = 64
+	orgRoleNameMaxLen = 255
+)
+
+type RoleInsert struct {
+	Code        string
+	Name        string
+	Description *string
+	IsSystem    bool
+}
+
+type RoleAssignmentInsert struct {
+	RoleID        uuid.UUID
+	SubjectType   string
+	SubjectID     uuid.UUID
+	OrgNodeID     uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+type CreateRoleInput struct {
+	Code        string
+	Name        string
+	Description *string
+}
+
+// CreateRole defines a tenant role. System roles are seeded by migrations; roles created here
+// are always is_system=false.
+func (s *OrgService) CreateRole(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in CreateRoleInput) (*OrgRole, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	code := strings.TrimSpace(in.Code)
+	name := strings.TrimSpace(in.Name)
+	if code == "" || name == "" {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "code/name are required", nil)
+	}
+	if utf8.RuneCountInString(code) > orgRoleCodeMaxLen || utf8.RuneCountInString(name) > orgRoleNameMaxLen {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "code/name is too long", nil)
+	}
+	description := trimOptionalText(in.Description)
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*OrgRole, error) {
+		id, err := s.repo.InsertRole(txCtx, tenantID, RoleInsert{
+			Code:        code,
+			Name:        name,
+			Description: description,
+			IsSystem:    false,
+		})
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		role := &OrgRole{ID: id, Code: code, Name: name, Description: description, IsSystem: false}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:       requestID,
+			TransactionTime: txTime,
+			InitiatorID:     initiatorID,
+			ChangeType:      "role.created",
+			EntityType:      "org_role",
+			EntityID:        id,
+			EffectiveDate:   normalizeValidDateUTC(txTime),
+			EndDate:         endOfTime,
+			NewValues: map[string]any{
+				"role_id":     id.String(),
+				"code":        code,
+				"name":        name,
+				"description": derefString(description),
+				"is_system":   false,
+			},
+			Operation: "Create",
+		})
+		if err != nil {
+			return nil, err
+		}
+		return role, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+type CreateRoleAssignmentInput struct {
+	RoleID        uuid.UUID
+	SubjectType   string
+	SubjectID     uuid.UUID
+	OrgNodeID     uuid.UUID
+	EffectiveDate time.Time
+}
+
+type RoleAssignmentWriteResult struct {
+	AssignmentID  uuid.UUID
+	EffectiveDate time.Time
+	EndDate       time.Time
+}
+
+func normalizeRoleSubjectType(raw string) (string, error) {
+	subjectType := strings.TrimSpace(raw)
+	if subjectType == "" {
+		subjectType = "user"
+	}
+	switch subjectType {
+	case "user", "group":
+		return subjectType, nil
+	default:
+		return "", newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "subject_type must be user or group", nil)
+	}
+}
+
+func roleAssignmentAuditValues(id uuid.UUID, roleID uuid.UUID, roleCode string, subjectType string, subjectID uuid.UUID, orgNodeID uuid.UUID, effectiveDate time.Time, endDate time.Time) map[string]any {
+	return map[string]any{
+		"assignment_id":  id.String(),
+		"role_id":        roleID.String(),
+		"role_code":      roleCode,
+		"subject_type":   subjectType,
+		"subject_id":     subjectID.String(),
+		"org_node_id":    orgNodeID.String(),
+		"effective_date": effectiveDate.UTC().Format(time.RFC3339),
+		"end_date":       endDate.UTC().Format(time.RFC3339),
+	}
+}
+
+// CreateRoleAssignment grants role to a user or group at an org node from effective_date on.
+func (s *OrgService) CreateRoleAssignment(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in CreateRoleAssignmentInput) (*RoleAssignmentWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if in.RoleID == uuid.Nil || in.SubjectID == uuid.Nil || in.OrgNodeID == uuid.Nil || in.EffectiveDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "role_id/subject_id/org_node_id/effective_date are required", nil)
+	}
+	subjectType, err := normalizeRoleSubjectType(in.SubjectType)
+	if err != nil {
+		return nil, err
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*RoleAssignmentWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, in.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "role_assignment.created", "org_role_assignment", uuid.Nil, in.EffectiveDate, freeze, err, logrus.Fields{
+				"org_node_id": in.OrgNodeID.String(),
+				"operation":   "Create",
+			})
+			return nil, err
+		}
+
+		role, err := s.repo.GetRoleByID(txCtx, tenantID, in.RoleID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_ROLE_NOT_FOUND", "role_id not found", err)
+			}
+			return nil, err
+		}
+
+		exists, err := s.repo.NodeExistsAt(txCtx, tenantID, in.OrgNodeID, HierarchyTypeOrgUnit, in.EffectiveDate)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_NODE_NOT_FOUND_AT_DATE", "org_node_id not found at effective_date", nil)
+		}
+
+		id, err := s.repo.InsertRoleAssignment(txCtx, tenantID, RoleAssignmentInsert{
+			RoleID:        role.ID,
+			SubjectType:   subjectType,
+			SubjectID:     in.SubjectID,
+			OrgNodeID:     in.OrgNodeID,
+			EffectiveDate: in.EffectiveDate,
+			EndDate:       endOfTime,
+		})
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "role_assignment.created",
+			EntityType:           "org_role_assignment",
+			EntityID:             id,
+			EffectiveDate:        in.EffectiveDate,
+			EndDate:              endOfTime,
+			NewValues:            roleAssignmentAuditValues(id, role.ID, role.Code, subjectType, in.SubjectID, in.OrgNodeID, in.EffectiveDate, endOfTime),
+			Operation:            "Create",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        in.EffectiveDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &RoleAssignmentWriteResult{AssignmentID: id, EffectiveDate: in.EffectiveDate, EndDate: endOfTime}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+type EndRoleAssignmentInput struct {
+	AssignmentID uuid.UUID
+	// EndDate is the last day the assignment is in force.
+	EndDate time.Time
+}
+
+// EndRoleAssignment end-dates an assignment; it stays in force through end_date.
+func (s *OrgService) EndRoleAssignment(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in EndRoleAssignmentInput) (*RoleAssignmentWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if in.AssignmentID == uuid.Nil || in.EndDate.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "assignment_id/end_date are required", nil)
+	}
+	in.EndDate = normalizeValidDateUTC(in.EndDate)
+	affectedAt := in.EndDate.AddDate(0, 0, 1)
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*RoleAssignmentWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockRoleAssignmentByID(txCtx, tenantID, in.AssignmentID)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		if in.EndDate.Before(current.EffectiveDate) || !in.EndDate.Before(current.EndDate) {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_INVALID_END_DATE", "end_date must be within current window", nil)
+		}
+
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, affectedAt)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "role_assignment.ended", "org_role_assignment", current.AssignmentID, affectedAt, freeze, err, logrus.Fields{
+				"org_node_id": current.SourceOrgNodeID.String(),
+				"operation":   "Update",
+			})
+			return nil, err
+		}
+
+		if err := s.repo.UpdateRoleAssignmentEndDate(txCtx, tenantID, current.AssignmentID, in.EndDate); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:            requestID,
+			TransactionTime:      txTime,
+			InitiatorID:          initiatorID,
+			ChangeType:           "role_assignment.ended",
+			EntityType:           "org_role_assignment",
+			EntityID:             current.AssignmentID,
+			EffectiveDate:        current.EffectiveDate,
+			EndDate:              in.EndDate,
+			OldValues:            roleAssignmentAuditValues(current.AssignmentID, current.RoleID, current.RoleCode, current.SubjectType, current.SubjectID, current.SourceOrgNodeID, current.EffectiveDate, current.EndDate),
+			NewValues:            roleAssignmentAuditValues(current.AssignmentID, current.RoleID, current.RoleCode, current.SubjectType, current.SubjectID, current.SourceOrgNodeID, current.EffectiveDate, in.EndDate),
+			Operation:            "Update",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        affectedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &RoleAssignmentWriteResult{AssignmentID: current.AssignmentID, EffectiveDate: current.EffectiveDate, EndDate: in.EndDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
+
+type RescindRoleAssignmentInput struct {
+	AssignmentID uuid.UUID
+	ReasonNote   *string
+}
+
+// RescindRoleAssignment removes an assignment that was recorded in error, as if it never
+// existed. The audit log keeps the removed values.
+func (s *OrgService) RescindRoleAssignment(ctx context.Context, tenantID uuid.UUID, requestID string, initiatorID uuid.UUID, in RescindRoleAssignmentInput) (*RoleAssignmentWriteResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if requestID == "" {
+		requestID = uuid.NewString()
+	}
+	txTime := time.Now().UTC()
+
+	if in.AssignmentID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "assignment_id is required", nil)
+	}
+
+	written, err := inTx(ctx, tenantID, func(txCtx context.Context) (*RoleAssignmentWriteResult, error) {
+		settings, err := s.repo.GetOrgSettings(txCtx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := s.repo.LockRoleAssignmentByID(txCtx, tenantID, in.AssignmentID)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+
+		freeze, err := s.freezeCheck(txCtx, tenantID, settings, txTime, current.EffectiveDate)
+		if err != nil {
+			maybeLogFrozenWindowRejected(txCtx, tenantID, requestID, initiatorID, "role_assignment.rescinded", "org_role_assignment", current.AssignmentID, current.EffectiveDate, freeze, err, logrus.Fields{
+				"org_node_id": current.SourceOrgNodeID.String(),
+				"operation":   "Rescind",
+			})
+			return nil, err
+		}
+
+		if err := s.repo.DeleteRoleAssignment(txCtx, tenantID, current.AssignmentID); err != nil {
+			return nil, mapPgError(err)
+		}
+
+		_, err = s.repo.InsertAuditLog(txCtx, tenantID, AuditLogInsert{
+			RequestID:       requestID,
+			TransactionTime: txTime,
+			InitiatorID:     initiatorID,
+			ChangeType:      "role_assignment.rescinded",
+			EntityType:      "org_role_assignment",
+			EntityID:        current.AssignmentID,
+			EffectiveDate:   current.EffectiveDate,
+			EndDate:         current.EndDate,
+			OldValues:       roleAssignmentAuditValues(current.AssignmentID, current.RoleID, current.RoleCode, current.SubjectType, current.SubjectID, current.SourceOrgNodeID, current.EffectiveDate, current.EndDate),
+			Meta: map[string]any{
+				"reason_note": derefString(trimOptionalText(in.ReasonNote)),
+			},
+			Operation:            "Rescind",
+			FreezeMode:           freeze.Mode,
+			FreezeViolation:      freeze.Violation,
+			FreezeOverrideReason: freeze.OverrideReason,
+			FreezeCutoffUTC:      freeze.CutoffUTC,
+			AffectedAtUTC:        current.EffectiveDate,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &RoleAssignmentWriteResult{AssignmentID: current.AssignmentID, EffectiveDate: current.EffectiveDate, EndDate: current.EndDate}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !shouldSkipCacheInvalidation(ctx) {
+		s.InvalidateTenantCacheWithReason(tenantID, "write_commit")
+	}
+	return written, nil
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeRoleSubjectType(t *testing.T) {
+	got, err := normalizeRoleSubjectType("")
+	require.NoError(t, err)
+	require.Equal(t, "user", got)
+
+	got, err = normalizeRoleSubjectType(" group ")
+	require.NoError(t, err)
+	require.Equal(t, "group", got)
+
+	_, err = normalizeRoleSubjectType("team")
+	var svcErr *ServiceError
+	require.True(t, errors.As(err, &svcErr))
+	require.Equal(t, "ORG_INVALID_BODY", svcErr.Code)
+}
+
+func TestRoleAssignmentWrites_ValidateBeforeTx(t *testing.T) {
+	svc := &OrgService{}
+	tenantID := uuid.New()
+	var svcErr *ServiceError
+
+	_, err := svc.CreateRoleAssignment(context.Background(), tenantID, "", uuid.New(), CreateRoleAssignmentInput{
+		RoleID:        uuid.New(),
+		SubjectID:     uuid.New(),
+		EffectiveDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	require.True(t, errors.As(err, &svcErr))
+	require.Equal(t, "ORG_INVALID_BODY", svcErr.Code)
+
+	_, err = svc.EndRoleAssignment(context.Background(), tenantID, "", uuid.New(), EndRoleAssignmentInput{AssignmentID: uuid.New()})
+	require.True(t, errors.As(err, &svcErr))
+	require.Equal(t, "ORG_INVALID_BODY", svcErr.Code)
+
+	_, err = svc.CreateRole(context.Background(), uuid.Nil, "", uuid.New(), CreateRoleInput{Code: "x", Name: "x"})
+	require.True(t, errors.As(err, &svcErr))
+	require.Equal(t, "ORG_NO_TENANT", svcErr.Code)
+}
@@ -0,0 +1,455 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	ScenarioStatusDraft     = "draft"
+	ScenarioStatusConverted = "converted"
+
+	scenarioMaxCommands = 100
+	scenarioMaxMoves    = 10
+)
+
+// ScenarioCommandTypes are the batch commands a scenario may stage. They use the same payloads
+// as POST /org/api/batch so a converted scenario replays unchanged as a change request.
+var ScenarioCommandTypes = []string{"node.create", "node.move", "assignment.update"}
+
+type ScenarioCommand struct {
+	Type    string          `json:"type"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+type ScenarioRow struct {
+	ID              uuid.UUID
+	Name            string
+	Description     *string
+	EffectiveDate   time.Time
+	Commands        []ScenarioCommand
+	Status          string
+	ChangeRequestID *uuid.UUID
+	CreatedBy       uuid.UUID
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Payload returns the scenario's commands in the preflight and change request payload shape.
+func (r *ScenarioRow) Payload() PreflightInput {
+	commands := make([]Command, 0, len(r.Commands))
+	for _, cmd := range r.Commands {
+		commands = append(commands, Command{Type: cmd.Type, Payload: cmd.Payload})
+	}
+	effectiveDate := ""
+	if !r.EffectiveDate.IsZero() {
+		effectiveDate = normalizeValidTimeDayUTC(r.EffectiveDate).Format(time.DateOnly)
+	}
+	return PreflightInput{
+		EffectiveDate: effectiveDate,
+		Commands:      commands,
+	}
+}
+
+type ScenarioInput struct {
+	Name          string
+	Description   *string
+	EffectiveDate time.Time
+	Commands      []ScenarioCommand
+}
+
+func normalizeScenarioInput(in ScenarioInput) (ScenarioInput, error) {
+	in.Name = strings.TrimSpace(in.Name)
+	if in.Name == "" || in.EffectiveDate.IsZero() {
+		return in, newServiceError(http.StatusBadRequest, "ORG_INVALID_BODY", "name/effective_date are required", nil)
+	}
+	if in.Description != nil {
+		if v := strings.TrimSpace(*in.Description); v != "" {
+			in.Description = &v
+		} else {
+			in.Description = nil
+		}
+	}
+	in.EffectiveDate = normalizeValidDateUTC(in.EffectiveDate)
+	if in.Commands == nil {
+		in.Commands = []ScenarioCommand{}
+	}
+	if len(in.Commands) > scenarioMaxCommands {
+		return in, newServiceError(http.StatusUnprocessableEntity, "ORG_SCENARIO_TOO_LARGE", "commands size is invalid", nil)
+	}
+	moves := 0
+	for i := range in.Commands {
+		in.Commands[i].Type = strings.TrimSpace(in.Commands[i].Type)
+		if !slices.Contains(ScenarioCommandTypes, in.Commands[i].Type) {
+			return in, newServiceError(http.StatusUnprocessableEntity, "ORG_SCENARIO_INVALID_COMMAND", "command type is not supported in scenarios", nil)
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(in.Commands[i].Payload, &obj); err != nil || obj == nil {
+			return in, newServiceError(http.StatusUnprocessableEntity, "ORG_SCENARIO_INVALID_COMMAND", "command payload must be an object", err)
+		}
+		if in.Commands[i].Type == "node.move" {
+			moves++
+		}
+	}
+	if moves > scenarioMaxMoves {
+		return in, newServiceError(http.StatusUnprocessableEntity, "ORG_SCENARIO_TOO_LARGE", "too many move commands", nil)
+	}
+	return in, nil
+}
+
+func mapScenarioNotDraft(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return newServiceError(http.StatusConflict, "ORG_SCENARIO_NOT_DRAFT", "scenario is not draft", err)
+	}
+	return err
+}
+
+func (s *OrgService) CreateScenario(ctx context.Context, tenantID uuid.UUID, createdBy uuid.UUID, in ScenarioInput) (*ScenarioRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	in, err := normalizeScenarioInput(in)
+	if err != nil {
+		return nil, err
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) (*ScenarioRow, error) {
+		row, err := s.repo.InsertScenario(txCtx, tenantID, createdBy, in)
+		if err != nil {
+			return nil, err
+		}
+		return &row, nil
+	})
+}
+
+// UpdateScenario replaces name, description, effective date and commands of a draft scenario.
+func (s *OrgService) UpdateScenario(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, in ScenarioInput) (*ScenarioRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	in, err := normalizeScenarioInput(in)
+	if err != nil {
+		return nil, err
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) (*ScenarioRow, error) {
+		if _, err := s.repo.GetScenario(txCtx, tenantID, id); err != nil {
+			return nil, mapPgError(err)
+		}
+		row, err := s.repo.UpdateScenarioDraft(txCtx, tenantID, id, in)
+		if err != nil {
+			return nil, mapScenarioNotDraft(err)
+		}
+		return &row, nil
+	})
+}
+
+func (s *OrgService) GetScenario(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) (*ScenarioRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) (*ScenarioRow, error) {
+		row, err := s.repo.GetScenario(txCtx, tenantID, id)
+		if err != nil {
+			return nil, mapPgError(err)
+		}
+		return &row, nil
+	})
+}
+
+func (s *OrgService) ListScenarios(ctx context.Context, tenantID uuid.UUID, limit int) ([]ScenarioRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) ([]ScenarioRow, error) {
+		return s.repo.ListScenarios(txCtx, tenantID, limit)
+	})
+}
+
+func (s *OrgService) DeleteScenario(ctx context.Context, tenantID uuid.UUID, id uuid.UUID) error {
+	if tenantID == uuid.Nil {
+		return newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	_, err := inTx(ctx, tenantID, func(txCtx context.Context) (struct{}, error) {
+		if _, err := s.repo.GetScenario(txCtx, tenantID, id); err != nil {
+			return struct{}{}, mapPgError(err)
+		}
+		return struct{}{}, mapScenarioNotDraft(s.repo.DeleteScenarioDraft(txCtx, tenantID, id))
+	})
+	return err
+}
+
+// MarkScenarioConverted links a draft scenario to the change request created from it. Run it in
+// the transaction that saved the change request so a scenario converts at most once.
+func (s *OrgService) MarkScenarioConverted(ctx context.Context, tenantID uuid.UUID, id uuid.UUID, changeRequestID uuid.UUID) (*ScenarioRow, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	return inTx(ctx, tenantID, func(txCtx context.Context) (*ScenarioRow, error) {
+		row, err := s.repo.MarkScenarioConverted(txCtx, tenantID, id, changeRequestID)
+		if err != nil {
+			return nil, mapScenarioNotDraft(err)
+		}
+		return &row, nil
+	})
+}
+
+// SubtreeStaffingFigures counts planned/active positions and active assignments. Headcount is
+// distinct people, so someone holding two positions in one subtree counts once.
+type SubtreeStaffingFigures struct {
+	Positions   int
+	CapacityFTE float64
+	Headcount   int
+	FTE         float64
+}
+
+type SubtreeStaffingNode struct {
+	HierarchyNode
+	Direct SubtreeStaffingFigures
+	Total  SubtreeStaffingFigures
+}
+
+type SubtreeStaffingReport struct {
+	TenantID      uuid.UUID
+	EffectiveDate time.Time
+	Nodes         []SubtreeStaffingNode
+	Summary       *StaffingSummaryResult
+}
+
+// ScenarioEvaluation is a scenario's staged tree next to the live tree on its effective date.
+type ScenarioEvaluation struct {
+	Scenario *ScenarioRow
+	Live     *SubtreeStaffingReport
+	Staged   *SubtreeStaffingReport
+	Subtrees []SubtreeStaffingComparison
+}
+
+// EvaluateScenario reads the live tree, then replays the scenario's commands in a rolled-back
+// transaction and reads the tree again from inside it. Nothing is written and neither read
+// touches the tenant cache.
+func (s *OrgService) EvaluateScenario(ctx context.Context, tenantID uuid.UUID, initiatorID uuid.UUID, row *ScenarioRow) (*ScenarioEvaluation, error) {
+	live, err := s.GetSubtreeStaffing(ctx, tenantID, row.EffectiveDate)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := row.Payload()
+	var staged *SubtreeStaffingReport
+	if err := inRolledBackTx(ctx, tenantID, func(txCtx context.Context) error {
+		txCtx = WithSkipReadCache(txCtx)
+		if _, _, err := s.ExecuteCommands(txCtx, tenantID, "scenario:"+row.ID.String(), initiatorID, payload.EffectiveDate, payload.Commands, "ORG_SCENARIO_INVALID_COMMAND"); err != nil {
+			return err
+		}
+		staged, err = s.GetSubtreeStaffing(txCtx, tenantID, row.EffectiveDate)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &ScenarioEvaluation{
+		Scenario: row,
+		Live:     live,
+		Staged:   staged,
+		Subtrees: CompareSubtreeStaffing(live, staged),
+	}, nil
+}
+
+// GetSubtreeStaffing returns the OrgUnit tree with direct and rolled-up staffing per node plus
+// the reports_057 staffing summary for the root. It always reads through edges, so it reflects
+// writes staged in the caller's transaction.
+func (s *OrgService) GetSubtreeStaffing(ctx context.Context, tenantID uuid.UUID, asOf time.Time) (*SubtreeStaffingReport, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+	asOf = normalizeValidDateUTC(asOf)
+
+	nodes, _, err := s.GetHierarchyAsOf(ctx, tenantID, HierarchyTypeOrgUnit, asOf)
+	if err != nil {
+		return nil, err
+	}
+	type states struct {
+		positions   []PositionStateRow
+		assignments []AssignmentStateRow
+	}
+	st, err := inTx(ctx, tenantID, func(txCtx context.Context) (states, error) {
+		positions, err := s.repo.ListPositionStatesAsOf(txCtx, tenantID, asOf)
+		if err != nil {
+			return states{}, err
+		}
+		assignments, err := s.repo.ListAssignmentStatesAsOf(txCtx, tenantID, asOf)
+		if err != nil {
+			return states{}, err
+		}
+		return states{positions: positions, assignments: assignments}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &SubtreeStaffingReport{
+		TenantID:      tenantID,
+		EffectiveDate: asOf,
+		Nodes:         rollUpSubtreeStaffing(nodes, st.positions, st.assignments),
+	}
+	if len(nodes) > 0 {
+		summary, err := s.GetStaffingSummary(ctx, tenantID, StaffingSummaryInput{
+			EffectiveDate: asOf,
+			Scope:         StaffingScopeSubtree,
+			Backend:       DeepReadBackendEdges,
+		})
+		if err != nil {
+			return nil, err
+		}
+		out.Summary = summary
+	}
+	return out, nil
+}
+
+func rollUpSubtreeStaffing(nodes []HierarchyNode, positions []PositionStateRow, assignments []AssignmentStateRow) []SubtreeStaffingNode {
+	out := make([]SubtreeStaffingNode, len(nodes))
+	indexByID := make(map[uuid.UUID]int, len(nodes))
+	for i, n := range nodes {
+		out[i] = SubtreeStaffingNode{HierarchyNode: n}
+		indexByID[n.ID] = i
+	}
+	// ancestors walks from a node up to the root; a broken parent chain simply stops early.
+	ancestors := func(i int, visit func(int)) {
+		for seen := 0; seen <= len(out); seen++ {
+			visit(i)
+			parentID := out[i].ParentID
+			if parentID == nil {
+				return
+			}
+			p, ok := indexByID[*parentID]
+			if !ok {
+				return
+			}
+			i = p
+		}
+	}
+
+	for _, p := range positions {
+		if p.LifecycleStatus != "planned" && p.LifecycleStatus != "active" {
+			continue
+		}
+		i, ok := indexByID[p.OrgNodeID]
+		if !ok {
+			continue
+		}
+		out[i].Direct.Positions++
+		out[i].Direct.CapacityFTE += p.CapacityFTE
+		ancestors(i, func(j int) {
+			out[j].Total.Positions++
+			out[j].Total.CapacityFTE += p.CapacityFTE
+		})
+	}
+
+	directPeople := make([]map[uuid.UUID]struct{}, len(out))
+	totalPeople := make([]map[uuid.UUID]struct{}, len(out))
+	for _, a := range assignments {
+		i, ok := indexByID[a.OrgNodeID]
+		if !ok {
+			continue
+		}
+		out[i].Direct.FTE += a.AllocatedFTE
+		if directPeople[i] == nil {
+			directPeople[i] = map[uuid.UUID]struct{}{}
+		}
+		directPeople[i][a.SubjectID] = struct{}{}
+		ancestors(i, func(j int) {
+			out[j].Total.FTE += a.AllocatedFTE
+			if totalPeople[j] == nil {
+				totalPeople[j] = map[uuid.UUID]struct{}{}
+			}
+			totalPeople[j][a.SubjectID] = struct{}{}
+		})
+	}
+	for i := range out {
+		out[i].Direct.Headcount = len(directPeople[i])
+		out[i].Total.Headcount = len(totalPeople[i])
+	}
+	return out
+}
+
+// SubtreeStaffingComparison lines up one top-level subtree in the live and the scenario tree.
+// A subtree missing on one side (created, or moved below another subtree) has a nil figure there.
+type SubtreeStaffingComparison struct {
+	NodeID   uuid.UUID
+	Code     string
+	Name     string
+	Live     *SubtreeStaffingFigures
+	Scenario *SubtreeStaffingFigures
+}
+
+// CompareSubtreeStaffing compares the whole tree (first row) and each direct child of the root
+// between two reports, ordered by code.
+func CompareSubtreeStaffing(live, scenario *SubtreeStaffingReport) []SubtreeStaffingComparison {
+	rows := map[uuid.UUID]*SubtreeStaffingComparison{}
+	var rootID uuid.UUID
+	collect := func(report *SubtreeStaffingReport, pick func(*SubtreeStaffingComparison, *SubtreeStaffingFigures)) {
+		if report == nil {
+			return
+		}
+		var root *uuid.UUID
+		for _, n := range report.Nodes {
+			if n.ParentID == nil {
+				id := n.ID
+				root = &id
+				rootID = id
+				break
+			}
+		}
+		if root == nil {
+			return
+		}
+		for _, n := range report.Nodes {
+			isRoot := n.ID == *root
+			if !isRoot && (n.ParentID == nil || *n.ParentID != *root) {
+				continue
+			}
+			row, ok := rows[n.ID]
+			if !ok {
+				row = &SubtreeStaffingComparison{NodeID: n.ID}
+				rows[n.ID] = row
+			}
+			row.Code, row.Name = n.Code, n.Name
+			total := n.Total
+			pick(row, &total)
+		}
+	}
+	collect(live, func(row *SubtreeStaffingComparison, f *SubtreeStaffingFigures) { row.Live = f })
+	collect(scenario, func(row *SubtreeStaffingComparison, f *SubtreeStaffingFigures) { row.Scenario = f })
+
+	out := make([]SubtreeStaffingComparison, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, *row)
+	}
+	slices.SortFunc(out, func(a, b SubtreeStaffingComparison) int {
+		if (a.NodeID == rootID) != (b.NodeID == rootID) {
+			if a.NodeID == rootID {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(a.Code, b.Code); c != 0 {
+			return c
+		}
+		return strings.Compare(a.NodeID.String(), b.NodeID.String())
+	})
+	return out
+}
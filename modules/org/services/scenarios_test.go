@@ -0,0 +1,84 @@
+package services
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRollUpSubtreeStaffing_CountsDistinctPeople(t *testing.T) {
+	root, sales, emea := uuid.New(), uuid.New(), uuid.New()
+	posA, posB, posC := uuid.New(), uuid.New(), uuid.New()
+	alice, bob := uuid.New(), uuid.New()
+
+	nodes := []HierarchyNode{
+		{ID: root, Code: "ROOT"},
+		{ID: sales, Code: "SALES", ParentID: &root},
+		{ID: emea, Code: "EMEA", ParentID: &sales},
+	}
+	positions := []PositionStateRow{
+		{PositionID: posA, OrgNodeID: sales, LifecycleStatus: "active", CapacityFTE: 1},
+		{PositionID: posB, OrgNodeID: emea, LifecycleStatus: "planned", CapacityFTE: 1},
+		{PositionID: posC, OrgNodeID: emea, LifecycleStatus: "inactive", CapacityFTE: 1},
+	}
+	assignments := []AssignmentStateRow{
+		{PositionID: posA, OrgNodeID: sales, SubjectID: alice, AllocatedFTE: 0.5},
+		{PositionID: posB, OrgNodeID: emea, SubjectID: alice, AllocatedFTE: 0.5},
+		{PositionID: posB, OrgNodeID: emea, SubjectID: bob, AllocatedFTE: 0.5},
+	}
+
+	got := map[string]SubtreeStaffingNode{}
+	for _, n := range rollUpSubtreeStaffing(nodes, positions, assignments) {
+		got[n.Code] = n
+	}
+
+	require.Equal(t, SubtreeStaffingFigures{Positions: 1, CapacityFTE: 1, Headcount: 1, FTE: 0.5}, got["SALES"].Direct)
+	require.Equal(t, SubtreeStaffingFigures{Positions: 2, CapacityFTE: 2, Headcount: 2, FTE: 1.5}, got["SALES"].Total)
+	require.Equal(t, SubtreeStaffingFigures{Positions: 1, CapacityFTE: 1, Headcount: 2, FTE: 1}, got["EMEA"].Total)
+	require.Equal(t, got["SALES"].Total, got["ROOT"].Total)
+	require.Equal(t, SubtreeStaffingFigures{}, got["ROOT"].Direct)
+}
+
+func TestCompareSubtreeStaffing_RootFirstAndOneSidedSubtrees(t *testing.T) {
+	root, sales, ops, lab := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	live := &SubtreeStaffingReport{Nodes: []SubtreeStaffingNode{
+		{HierarchyNode: HierarchyNode{ID: root, Code: "ROOT"}, Total: SubtreeStaffingFigures{Headcount: 3}},
+		{HierarchyNode: HierarchyNode{ID: sales, Code: "SALES", ParentID: &root}, Total: SubtreeStaffingFigures{Headcount: 2}},
+		{HierarchyNode: HierarchyNode{ID: ops, Code: "OPS", ParentID: &root}, Total: SubtreeStaffingFigures{Headcount: 1}},
+	}}
+	// OPS moves below SALES and a new LAB subtree is created under the root.
+	scenario := &SubtreeStaffingReport{Nodes: []SubtreeStaffingNode{
+		{HierarchyNode: HierarchyNode{ID: root, Code: "ROOT"}, Total: SubtreeStaffingFigures{Headcount: 3}},
+		{HierarchyNode: HierarchyNode{ID: sales, Code: "SALES", ParentID: &root}, Total: SubtreeStaffingFigures{Headcount: 3}},
+		{HierarchyNode: HierarchyNode{ID: ops, Code: "OPS", ParentID: &sales}, Total: SubtreeStaffingFigures{Headcount: 1}},
+		{HierarchyNode: HierarchyNode{ID: lab, Code: "LAB", ParentID: &root}},
+	}}
+
+	rows := CompareSubtreeStaffing(live, scenario)
+	require.Len(t, rows, 4)
+	codes := []string{rows[0].Code, rows[1].Code, rows[2].Code, rows[3].Code}
+	require.Equal(t, []string{"ROOT", "LAB", "OPS", "SALES"}, codes)
+
+	require.Nil(t, rows[1].Live)
+	require.NotNil(t, rows[1].Scenario)
+	require.NotNil(t, rows[2].Live)
+	require.Nil(t, rows[2].Scenario)
+	require.Equal(t, 2, rows[3].Live.Headcount)
+	require.Equal(t, 3, rows[3].Scenario.Headcount)
+}
+
+func TestScenarioRowPayload(t *testing.T) {
+	row := &ScenarioRow{
+		EffectiveDate: time.Date(2026, 3, 1, 15, 30, 0, 0, time.UTC),
+		Commands: []ScenarioCommand{
+			{Type: "node.move", Payload: json.RawMessage(`{"id":"x"}`)},
+		},
+	}
+
+	payload := row.Payload()
+	require.Equal(t, "2026-03-01", payload.EffectiveDate)
+	require.Equal(t, []Command{{Type: "node.move", Payload: json.RawMessage(`{"id":"x"}`)}}, payload.Commands)
+}
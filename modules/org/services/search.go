@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+type SearchKind string
+
+const (
+	SearchKindNode       SearchKind = "node"
+	SearchKindPosition   SearchKind = "position"
+	SearchKindJobProfile SearchKind = "job_profile"
+	SearchKindPerson     SearchKind = "person"
+)
+
+var AllSearchKinds = []SearchKind{SearchKindNode, SearchKindPosition, SearchKindJobProfile, SearchKindPerson}
+
+const (
+	searchMinQueryRunes = 2
+	searchMaxQueryRunes = 100
+	searchDefaultLimit  = 20
+	searchMaxLimit      = 100
+)
+
+func ParseSearchKind(v string) (SearchKind, bool) {
+	k := SearchKind(strings.TrimSpace(strings.ToLower(v)))
+	for _, known := range AllSearchKinds {
+		if k == known {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+type SearchParams struct {
+	Query string
+	Kinds []SearchKind
+	// HierarchyType scopes hits to one hierarchy; empty searches all of them. Positions and
+	// people only belong to OrgUnit, so any other type returns node and job profile hits only.
+	HierarchyType string
+	AsOf          time.Time
+	Limit         int
+}
+
+// SearchHitRow is a ranked match as returned by the repository; Path is filled in by the
+// service.
+type SearchHitRow struct {
+	Kind         SearchKind
+	ID           uuid.UUID
+	Code         string
+	Name         string
+	MatchedField string
+	Score        float64
+	OrgNodeID    *uuid.UUID
+	// HierarchyType is org_nodes.type of OrgNodeID; empty when the hit is not attached to a node.
+	HierarchyType string
+}
+
+type SearchHit struct {
+	SearchHitRow
+	Path []NodePathNode
+}
+
+type SearchResult struct {
+	TenantID      uuid.UUID
+	Query         string
+	EffectiveDate time.Time
+	Kinds         []SearchKind
+	HierarchyType string
+	Hits          []SearchHit
+}
+
+// Search ranks nodes, positions, job profiles and people matching the query as of a date:
+// exact code/pernr/name matches first, then prefix, substring and trigram (fuzzy) matches.
+// Hits attached to the hierarchy carry their root-first ancestor path, in the same shape as
+// GetNodePath, resolved against the edges of the hierarchy the node belongs to, so callers
+// never have to load the whole tree.
+func (s *OrgService) Search(ctx context.Context, tenantID uuid.UUID, params SearchParams) (*SearchResult, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	query := strings.Join(strings.Fields(params.Query), " ")
+	if n := utf8.RuneCountInString(query); n < searchMinQueryRunes || n > searchMaxQueryRunes {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "q must be between 2 and 100 characters", nil)
+	}
+	hierarchyType := strings.TrimSpace(params.HierarchyType)
+	if hierarchyType != "" && !IsValidHierarchyType(hierarchyType) {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "type is invalid", nil)
+	}
+	kinds := params.Kinds
+	if len(kinds) == 0 {
+		kinds = AllSearchKinds
+	}
+	limit := params.Limit
+	if limit <= 0 {
+		limit = searchDefaultLimit
+	}
+	if limit > searchMaxLimit {
+		limit = searchMaxLimit
+	}
+	asOf := params.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now().UTC()
+	}
+
+	hits, err := inTx(ctx, tenantID, func(txCtx context.Context) ([]SearchHit, error) {
+		rows, err := s.repo.SearchAsOf(txCtx, tenantID, query, kinds, hierarchyType, asOf, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		// Edges are partitioned by hierarchy type, so paths are loaded once per type present.
+		nodeIDsByType := map[string][]uuid.UUID{}
+		seen := make(map[uuid.UUID]struct{}, len(rows))
+		for _, row := range rows {
+			if row.OrgNodeID == nil || row.HierarchyType == "" {
+				continue
+			}
+			if _, ok := seen[*row.OrgNodeID]; ok {
+				continue
+			}
+			seen[*row.OrgNodeID] = struct{}{}
+			nodeIDsByType[row.HierarchyType] = append(nodeIDsByType[row.HierarchyType], *row.OrgNodeID)
+		}
+		paths := make(map[uuid.UUID][]NodePathNode, len(seen))
+		for _, t := range HierarchyTypes {
+			nodeIDs := nodeIDsByType[t]
+			if len(nodeIDs) == 0 {
+				continue
+			}
+			typePaths, err := s.repo.ListNodePathsAsOf(txCtx, tenantID, t, nodeIDs, asOf)
+			if err != nil {
+				return nil, err
+			}
+			for id, p := range typePaths {
+				paths[id] = p
+			}
+		}
+
+		out := make([]SearchHit, 0, len(rows))
+		for _, row := range rows {
+			hit := SearchHit{SearchHitRow: row, Path: []NodePathNode{}}
+			if row.OrgNodeID != nil {
+				if p, ok := paths[*row.OrgNodeID]; ok {
+					hit.Path = p
+				}
+			}
+			out = append(out, hit)
+		}
+		return out, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &SearchResult{
+		TenantID:      tenantID,
+		Query:         query,
+		EffectiveDate: asOf.UTC(),
+		Kinds:         kinds,
+		HierarchyType: hierarchyType,
+		Hits:          hits,
+	}, nil
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// trendMaxBuckets caps a trend report at five years of monthly buckets.
+const trendMaxBuckets = 60
+
+type TrendInterval string
+
+const (
+	TrendIntervalMonth   TrendInterval = "month"
+	TrendIntervalQuarter TrendInterval = "quarter"
+)
+
+// TrendBucket is one reporting period; both bounds are inclusive valid dates.
+type TrendBucket struct {
+	Start time.Time
+	End   time.Time
+	Label string
+}
+
+// TrendBucketDBRow holds the raw counts for one bucket. Headcount counts people with an active
+// primary assignment in scope on the bucket's first and last day; positions, vacancies and
+// manager spans are measured on the last day.
+type TrendBucketDBRow struct {
+	HeadcountStart   int
+	HeadcountEnd     int
+	Hires            int
+	Terminations     int
+	PositionsTotal   int
+	VacantPositions  int
+	ManagerPositions int
+	DirectReports    int
+}
+
+type TrendPoint struct {
+	TrendBucket
+	TrendBucketDBRow
+	// TurnoverRate is terminations over the average of opening and closing headcount.
+	TurnoverRate float64
+	VacancyRate  float64
+	// AvgSpanOfControl is direct reports per position that has at least one report.
+	AvgSpanOfControl float64
+}
+
+type TrendReportInput struct {
+	OrgNodeID          *uuid.UUID
+	From               time.Time
+	To                 time.Time
+	Interval           TrendInterval
+	Scope              StaffingScope
+	LifecycleStatuses  []string
+	IncludeSystem      bool
+	MaxScopeNodesLimit int
+}
+
+type TrendReport struct {
+	TenantID  uuid.UUID
+	OrgNodeID uuid.UUID
+	From      time.Time
+	To        time.Time
+	Interval  TrendInterval
+	Scope     StaffingScope
+	Points    []TrendPoint
+	Source    StaffingReportSource
+}
+
+// GetTrendReport returns headcount, hires, terminations, turnover, vacancy rate and average span
+// of control per month or quarter between from and to. The scope is resolved once, as of to, so
+// every bucket is measured against the same set of org nodes.
+func (s *OrgService) GetTrendReport(ctx context.Context, tenantID uuid.UUID, in TrendReportInput) (*TrendReport, error) {
+	if tenantID == uuid.Nil {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_NO_TENANT", "tenant_id is required", nil)
+	}
+	if in.From.IsZero() || in.To.IsZero() {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "from/to are required", nil)
+	}
+	from := normalizeValidDateUTC(in.From)
+	to := normalizeValidDateUTC(in.To)
+	if to.Before(from) {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "to must not be before from", nil)
+	}
+
+	interval := in.Interval
+	if strings.TrimSpace(string(interval)) == "" {
+		interval = TrendIntervalMonth
+	}
+	if interval != TrendIntervalMonth && interval != TrendIntervalQuarter {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "interval is invalid", nil)
+	}
+	buckets := trendBuckets(from, to, interval)
+	if len(buckets) > trendMaxBuckets {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "time range exceeds limit", nil)
+	}
+
+	scope := in.Scope
+	if strings.TrimSpace(string(scope)) == "" {
+		scope = StaffingScopeSubtree
+	}
+	if scope != StaffingScopeSelf && scope != StaffingScopeSubtree {
+		return nil, newServiceError(http.StatusBadRequest, "ORG_INVALID_QUERY", "scope is invalid", nil)
+	}
+
+	statuses, err := normalizeStaffingLifecycleStatuses(in.LifecycleStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	maxScope := in.MaxScopeNodesLimit
+	if maxScope <= 0 {
+		maxScope = staffingMaxScopeNodes
+	}
+
+	backend := OrgDeepReadBackendForTenant(tenantID)
+
+	return inTx(ctx, tenantID, func(txCtx context.Context) (*TrendReport, error) {
+		rootID, err := resolveOrgNodeID(txCtx, s.repo, tenantID, in.OrgNodeID)
+		if err != nil {
+			return nil, err
+		}
+
+		exists, err := s.repo.NodeExistsAt(txCtx, tenantID, rootID, staffingScopeHierarchy, to)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, newServiceError(http.StatusUnprocessableEntity, "ORG_NODE_NOT_FOUND_AT_DATE", "org_node_id not found at effective_date", nil)
+		}
+
+		nodeIDs, err := staffingResolveScopeNodeIDs(txCtx, s.repo, tenantID, rootID, to, scope, backend, maxScope)
+		if err != nil {
+			return nil, err
+		}
+
+		var snapshotBuildID *uuid.UUID
+		if backend == DeepReadBackendSnapshot {
+			id, err := s.repo.GetActiveSnapshotBuildID(txCtx, tenantID, staffingScopeHierarchy, to)
+			if err != nil {
+				return nil, err
+			}
+			snapshotBuildID = &id
+		}
+
+		rows, err := s.repo.GetTrendReport(txCtx, tenantID, buckets, nodeIDs, statuses, in.IncludeSystem)
+		if err != nil {
+			return nil, err
+		}
+
+		return &TrendReport{
+			TenantID:  tenantID,
+			OrgNodeID: rootID,
+			From:      from,
+			To:        to,
+			Interval:  interval,
+			Scope:     scope,
+			Points:    trendPoints(buckets, rows),
+			Source: StaffingReportSource{
+				DeepReadBackend: backend,
+				SnapshotBuildID: snapshotBuildID,
+			},
+		}, nil
+	})
+}
+
+// trendBuckets splits [from, to] at calendar month or quarter boundaries. The first and last
+// buckets are clipped to the range.
+func trendBuckets(from, to time.Time, interval TrendInterval) []TrendBucket {
+	months := 1
+	if interval == TrendIntervalQuarter {
+		months = 3
+	}
+	start := time.Date(from.Year(), from.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if months == 3 {
+		start = time.Date(from.Year(), time.Month((int(from.Month())-1)/3*3+1), 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	var out []TrendBucket
+	for !start.After(to) {
+		next := start.AddDate(0, months, 0)
+		b := TrendBucket{Start: start, End: next.AddDate(0, 0, -1)}
+		if months == 3 {
+			b.Label = fmt.Sprintf("%d-Q%d", start.Year(), (int(start.Month())-1)/3+1)
+		} else {
+			b.Label = start.Format("2006-01")
+		}
+		if b.Start.Before(from) {
+			b.Start = from
+		}
+		if b.End.After(to) {
+			b.End = to
+		}
+		out = append(out, b)
+		if len(out) > trendMaxBuckets {
+			break
+		}
+		start = next
+	}
+	return out
+}
+
+func trendPoints(buckets []TrendBucket, rows []TrendBucketDBRow) []TrendPoint {
+	out := make([]TrendPoint, 0, len(buckets))
+	for i, b := range buckets {
+		p := TrendPoint{TrendBucket: b}
+		if i < len(rows) {
+			p.TrendBucketDBRow = rows[i]
+		}
+		if avg := float64(p.HeadcountStart+p.HeadcountEnd) / 2; avg > 0 {
+			p.TurnoverRate = float64(p.Terminations) / avg
+		}
+		if p.PositionsTotal > 0 {
+			p.VacancyRate = float64(p.VacantPositions) / float64(p.PositionsTotal)
+		}
+		if p.ManagerPositions > 0 {
+			p.AvgSpanOfControl = float64(p.DirectReports) / float64(p.ManagerPositions)
+		}
+		out = append(out, p)
+	}
+	return out
+}
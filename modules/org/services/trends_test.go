@@ -0,0 +1,55 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrendBuckets_MonthClipsRange(t *testing.T) {
+	from := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+
+	got := trendBuckets(from, to, TrendIntervalMonth)
+	require.Len(t, got, 3)
+	require.Equal(t, "2025-01", got[0].Label)
+	require.Equal(t, from, got[0].Start)
+	require.Equal(t, time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC), got[0].End)
+	require.Equal(t, time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), got[1].Start)
+	require.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), got[1].End)
+	require.Equal(t, to, got[2].End)
+}
+
+func TestTrendBuckets_Quarter(t *testing.T) {
+	from := time.Date(2024, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	got := trendBuckets(from, to, TrendIntervalQuarter)
+	require.Len(t, got, 3)
+	require.Equal(t, []string{"2024-Q4", "2025-Q1", "2025-Q2"}, []string{got[0].Label, got[1].Label, got[2].Label})
+	require.Equal(t, from, got[0].Start)
+	require.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), got[1].End)
+}
+
+func TestTrendBuckets_StopsPastLimit(t *testing.T) {
+	from := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	require.Len(t, trendBuckets(from, to, TrendIntervalMonth), trendMaxBuckets+1)
+}
+
+func TestTrendPoints_Rates(t *testing.T) {
+	buckets := []TrendBucket{{Label: "a"}, {Label: "b"}}
+	rows := []TrendBucketDBRow{
+		{HeadcountStart: 10, HeadcountEnd: 6, Terminations: 4, PositionsTotal: 8, VacantPositions: 2, ManagerPositions: 2, DirectReports: 5},
+	}
+
+	got := trendPoints(buckets, rows)
+	require.Len(t, got, 2)
+	require.InDelta(t, 0.5, got[0].TurnoverRate, 0.0001)
+	require.InDelta(t, 0.25, got[0].VacancyRate, 0.0001)
+	require.InDelta(t, 2.5, got[0].AvgSpanOfControl, 0.0001)
+	require.Zero(t, got[1].TurnoverRate)
+	require.Zero(t, got[1].VacancyRate)
+	require.Zero(t, got[1].AvgSpanOfControl)
+}
@@ -17,3 +17,5 @@ CREATE INDEX persons_tenant_pernr_idx ON persons (tenant_id, pernr);
 
 CREATE INDEX persons_tenant_display_name_idx ON persons (tenant_id, display_name);
 
+CREATE INDEX persons_display_name_trgm_idx ON persons USING gin (display_name gin_trgm_ops);
+
@@ -0,0 +1,100 @@
+package authz
+
+import (
+	"bufio"
+	"encoding/csv"
+	"os"
+	"strings"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+)
+
+// LoadPolicyLine is persist.LoadPolicyLine for this model. Rows written before a column was added
+// to the policy definition (p rows without cond) are padded with empty values, so existing
+// policy files keep loading and an empty cond means "no condition".
+func LoadPolicyLine(line string, m model.Model) error {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+
+	r := csv.NewReader(strings.NewReader(line))
+	r.Comma = ','
+	r.Comment = '#'
+	r.TrimLeadingSpace = true
+	tokens, err := r.Read()
+	if err != nil {
+		return err
+	}
+	for i := range tokens {
+		tokens[i] = strings.TrimSpace(tokens[i])
+	}
+
+	key := tokens[0]
+	if ast, ok := m[key[:1]][key]; ok {
+		for len(tokens)-1 < len(ast.Tokens) {
+			tokens = append(tokens, "")
+		}
+	}
+	return persist.LoadPolicyArray(tokens, m)
+}
+
+// fileAdapter reads policy.csv through LoadPolicyLine; everything else is casbin's file adapter.
+type fileAdapter struct {
+	*fileadapter.Adapter
+	path string
+}
+
+func newFileAdapter(path string) *fileAdapter {
+	return &fileAdapter{Adapter: fileadapter.NewAdapter(path), path: path}
+}
+
+func (a *fileAdapter) LoadPolicy(m model.Model) error {
+	f, err := os.Open(a.path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if err := LoadPolicyLine(scanner.Text(), m); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// ValidatePolicyRecord checks a CSV policy row (ptype first) beyond what Casbin checks on load:
+// p rows carry an allow or deny effect and, optionally, a condition that parses.
+func ValidatePolicyRecord(record []string) error {
+	if len(record) == 0 {
+		return configError("empty policy row")
+	}
+	ptype := strings.TrimSpace(record[0])
+	switch {
+	case ptype == "p":
+		if len(record) < 6 || len(record) > 7 {
+			return configError("p row needs sub, obj, act, dom, eft[, cond]; got %d fields", len(record)-1)
+		}
+		switch strings.ToLower(strings.TrimSpace(record[5])) {
+		case "allow", "deny":
+		default:
+			return configError("p row effect must be allow or deny, got %q", strings.TrimSpace(record[5]))
+		}
+		if len(record) == 7 {
+			if _, err := ParseCondition(record[6]); err != nil {
+				return err
+			}
+		}
+	case strings.HasPrefix(ptype, "g"):
+		if len(record) < 3 {
+			return configError("%s row needs at least two fields", ptype)
+		}
+	default:
+		return configError("unsupported policy type %q", ptype)
+	}
+	return nil
+}
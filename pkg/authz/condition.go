@@ -0,0 +1,474 @@
+package authz
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Condition is the parsed form of the cond column of a p row. Conditions are a small,
+// side-effect-free language evaluated against Request.Attributes, never arbitrary expressions:
+//
+//	cond   = clause { "&&" clause }
+//	clause = attr op value
+//	       | "hours" HH:MM "-" HH:MM [zone]
+//	       | "weekdays" day "-" day [zone]
+//	op     = "==" | "!=" | "<" | "<=" | ">" | ">=" | "in" | "not_in"
+//	value  = number | 'text' | word | $attr | (value|value|...)
+//
+// For example "amount <= $approval_limit && hours 09:00-18:00 Asia/Tashkent" or
+// "org_node_id in $subject_org_subtree". hours and weekdays read the "now" attribute when the
+// caller supplies one and the wall clock otherwise. Commas are not allowed so a condition stays a
+// single CSV field.
+type Condition struct {
+	raw     string
+	clauses []conditionClause
+}
+
+type conditionClause interface {
+	eval(attrs Attributes, now time.Time) (bool, error)
+}
+
+// ErrMissingAttribute is returned when a condition refers to an attribute the request does not carry.
+var ErrMissingAttribute = errors.New("authz: condition attribute missing")
+
+var (
+	attrNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_.]*$`)
+	clockPattern    = regexp.MustCompile(`^([01][0-9]|2[0-3]):([0-5][0-9])$`)
+	conditionOps    = []string{"not_in", "in", "==", "!=", "<=", ">=", "<", ">"}
+	weekdayNames    = map[string]time.Weekday{
+		"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+		"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+	}
+)
+
+// ParseCondition validates and parses a condition. An empty string is the always-true condition.
+func ParseCondition(raw string) (Condition, error) {
+	raw = strings.TrimSpace(raw)
+	cond := Condition{raw: raw}
+	if raw == "" {
+		return cond, nil
+	}
+	if strings.ContainsAny(raw, ",\n\"") {
+		return Condition{}, fmt.Errorf("authz: condition %q must not contain commas, double quotes or newlines", raw)
+	}
+	for _, part := range strings.Split(raw, "&&") {
+		clause, err := parseClause(strings.TrimSpace(part))
+		if err != nil {
+			return Condition{}, fmt.Errorf("authz: condition %q: %w", raw, err)
+		}
+		cond.clauses = append(cond.clauses, clause)
+	}
+	return cond, nil
+}
+
+// String returns the condition as written in the policy row.
+func (c Condition) String() string {
+	return c.raw
+}
+
+// Eval reports whether every clause holds for attrs. It returns ErrMissingAttribute (wrapped)
+// when an attribute the condition needs is absent.
+func (c Condition) Eval(attrs Attributes, now time.Time) (bool, error) {
+	for _, clause := range c.clauses {
+		ok, err := clause.eval(attrs, now)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func parseClause(s string) (conditionClause, error) {
+	if s == "" {
+		return nil, errors.New("empty clause")
+	}
+	fields := strings.Fields(s)
+	switch fields[0] {
+	case "hours":
+		return parseHoursClause(fields[1:])
+	case "weekdays":
+		return parseWeekdaysClause(fields[1:])
+	}
+	for _, op := range conditionOps {
+		left, right, ok := cutOperator(s, op)
+		if !ok {
+			continue
+		}
+		if !attrNamePattern.MatchString(left) {
+			return nil, fmt.Errorf("invalid attribute name %q", left)
+		}
+		value, err := parseConditionValue(right, op == "in" || op == "not_in")
+		if err != nil {
+			return nil, err
+		}
+		return compareClause{attr: left, op: op, value: value}, nil
+	}
+	return nil, fmt.Errorf("unsupported clause %q", s)
+}
+
+// cutOperator splits s around op; word operators must stand alone between spaces.
+func cutOperator(s, op string) (string, string, bool) {
+	sep := op
+	if op == "in" || op == "not_in" {
+		sep = " " + op + " "
+	}
+	left, right, ok := strings.Cut(s, sep)
+	if !ok {
+		return "", "", false
+	}
+	left, right = strings.TrimSpace(left), strings.TrimSpace(right)
+	if left == "" || right == "" {
+		return "", "", false
+	}
+	return left, right, true
+}
+
+type conditionValue struct {
+	literal any
+	attrRef string
+	list    []conditionValue
+	isList  bool
+}
+
+func parseConditionValue(s string, allowList bool) (conditionValue, error) {
+	switch {
+	case strings.HasPrefix(s, "$"):
+		name := strings.TrimPrefix(s, "$")
+		if !attrNamePattern.MatchString(name) {
+			return conditionValue{}, fmt.Errorf("invalid attribute reference %q", s)
+		}
+		return conditionValue{attrRef: name}, nil
+	case strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")"):
+		if !allowList {
+			return conditionValue{}, fmt.Errorf("list %q is only allowed with in/not_in", s)
+		}
+		out := conditionValue{isList: true}
+		for _, item := range strings.Split(s[1:len(s)-1], "|") {
+			v, err := parseConditionValue(strings.TrimSpace(item), false)
+			if err != nil {
+				return conditionValue{}, err
+			}
+			out.list = append(out.list, v)
+		}
+		return out, nil
+	case len(s) >= 2 && strings.HasPrefix(s, "'") && strings.HasSuffix(s, "'"):
+		return conditionValue{literal: s[1 : len(s)-1]}, nil
+	case s == "":
+		return conditionValue{}, errors.New("missing value")
+	case strings.ContainsAny(s, " '()|$"):
+		return conditionValue{}, fmt.Errorf("invalid value %q", s)
+	}
+	if f, err := strconv.ParseFloat(s, 64); err == nil {
+		return conditionValue{literal: f}, nil
+	}
+	return conditionValue{literal: s}, nil
+}
+
+func (v conditionValue) resolve(attrs Attributes) (any, error) {
+	if v.attrRef == "" {
+		return v.literal, nil
+	}
+	value, ok := attrs[v.attrRef]
+	if !ok || value == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMissingAttribute, v.attrRef)
+	}
+	return value, nil
+}
+
+type compareClause struct {
+	attr  string
+	op    string
+	value conditionValue
+}
+
+func (c compareClause) eval(attrs Attributes, _ time.Time) (bool, error) {
+	left, ok := attrs[c.attr]
+	if !ok || left == nil {
+		return false, fmt.Errorf("%w: %s", ErrMissingAttribute, c.attr)
+	}
+
+	if c.op == "in" || c.op == "not_in" {
+		members, err := c.members(attrs)
+		if err != nil {
+			return false, err
+		}
+		found := false
+		for _, member := range members {
+			if valuesEqual(left, member) {
+				found = true
+				break
+			}
+		}
+		return found == (c.op == "in"), nil
+	}
+
+	right, err := c.value.resolve(attrs)
+	if err != nil {
+		return false, err
+	}
+	switch c.op {
+	case "==":
+		return valuesEqual(left, right), nil
+	case "!=":
+		return !valuesEqual(left, right), nil
+	}
+	l, lok := toFloat(left)
+	r, rok := toFloat(right)
+	if !lok || !rok {
+		return false, fmt.Errorf("authz: %s %s needs numbers, got %v and %v", c.attr, c.op, left, right)
+	}
+	switch c.op {
+	case "<":
+		return l < r, nil
+	case "<=":
+		return l <= r, nil
+	case ">":
+		return l > r, nil
+	default:
+		return l >= r, nil
+	}
+}
+
+func (c compareClause) members(attrs Attributes) ([]any, error) {
+	if c.value.isList {
+		out := make([]any, 0, len(c.value.list))
+		for _, item := range c.value.list {
+			v, err := item.resolve(attrs)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	}
+	v, err := c.value.resolve(attrs)
+	if err != nil {
+		return nil, err
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return []any{v}, nil
+	}
+	out := make([]any, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		out = append(out, rv.Index(i).Interface())
+	}
+	return out, nil
+}
+
+func valuesEqual(a, b any) bool {
+	if af, ok := toFloat(a); ok {
+		if bf, ok := toFloat(b); ok {
+			return af == bf
+		}
+	}
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// conditionClock returns the evaluation time in loc: the "now" attribute when set, else now.
+func conditionClock(attrs Attributes, now time.Time, loc *time.Location) (time.Time, error) {
+	if v, ok := attrs["now"]; ok && v != nil {
+		switch t := v.(type) {
+		case time.Time:
+			now = t
+		case string:
+			parsed, err := time.Parse(time.RFC3339, t)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("authz: now attribute must be RFC3339: %w", err)
+			}
+			now = parsed
+		default:
+			return time.Time{}, fmt.Errorf("authz: now attribute has unsupported type %T", v)
+		}
+	}
+	return now.In(loc), nil
+}
+
+func parseZone(fields []string) (*time.Location, error) {
+	switch len(fields) {
+	case 0:
+		return time.UTC, nil
+	case 1:
+		return time.LoadLocation(fields[0])
+	default:
+		return nil, fmt.Errorf("unexpected %q", strings.Join(fields[1:], " "))
+	}
+}
+
+type hoursClause struct {
+	from, to int // minutes after midnight; to < from wraps past midnight
+	loc      *time.Location
+}
+
+func parseHoursClause(fields []string) (conditionClause, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("hours needs HH:MM-HH:MM")
+	}
+	fromRaw, toRaw, ok := strings.Cut(fields[0], "-")
+	if !ok {
+		return nil, errors.New("hours needs HH:MM-HH:MM")
+	}
+	from, err := parseClock(fromRaw)
+	if err != nil {
+		return nil, err
+	}
+	to, err := parseClock(toRaw)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := parseZone(fields[1:])
+	if err != nil {
+		return nil, err
+	}
+	return hoursClause{from: from, to: to, loc: loc}, nil
+}
+
+func parseClock(s string) (int, error) {
+	m := clockPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	h, _ := strconv.Atoi(m[1])
+	min, _ := strconv.Atoi(m[2])
+	return h*60 + min, nil
+}
+
+func (c hoursClause) eval(attrs Attributes, now time.Time) (bool, error) {
+	t, err := conditionClock(attrs, now, c.loc)
+	if err != nil {
+		return false, err
+	}
+	minute := t.Hour()*60 + t.Minute()
+	if c.from <= c.to {
+		return minute >= c.from && minute < c.to, nil
+	}
+	return minute >= c.from || minute < c.to, nil
+}
+
+type weekdaysClause struct {
+	from, to time.Weekday
+	loc      *time.Location
+}
+
+func parseWeekdaysClause(fields []string) (conditionClause, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("weekdays needs day-day")
+	}
+	fromRaw, toRaw, ok := strings.Cut(strings.ToLower(fields[0]), "-")
+	from, fromOK := weekdayNames[fromRaw]
+	to, toOK := weekdayNames[toRaw]
+	if !ok || !fromOK || !toOK {
+		return nil, fmt.Errorf("invalid weekday range %q", fields[0])
+	}
+	loc, err := parseZone(fields[1:])
+	if err != nil {
+		return nil, err
+	}
+	return weekdaysClause{from: from, to: to, loc: loc}, nil
+}
+
+func (c weekdaysClause) eval(attrs Attributes, now time.Time) (bool, error) {
+	t, err := conditionClock(attrs, now, c.loc)
+	if err != nil {
+		return false, err
+	}
+	day := t.Weekday()
+	if c.from <= c.to {
+		return day >= c.from && day <= c.to, nil
+	}
+	return day >= c.from || day <= c.to, nil
+}
+
+var conditionCache sync.Map // raw condition -> conditionCacheEntry
+
+type conditionCacheEntry struct {
+	cond Condition
+	err  error
+}
+
+func cachedCondition(raw string) (Condition, error) {
+	if v, ok := conditionCache.Load(raw); ok {
+		entry := v.(conditionCacheEntry)
+		return entry.cond, entry.err
+	}
+	cond, err := ParseCondition(raw)
+	conditionCache.Store(raw, conditionCacheEntry{cond: cond, err: err})
+	return cond, err
+}
+
+// conditionMatcher backs authzCond(p.cond, p.eft, r.attrs) in the model's matcher. A condition
+// that cannot be evaluated (bad syntax, missing attribute, type mismatch) fails closed: an allow
+// row does not apply and a deny row does.
+func conditionMatcher(args ...any) (any, error) {
+	if len(args) != 3 {
+		return false, fmt.Errorf("authz: authzCond expects 3 arguments, got %d", len(args))
+	}
+	raw, _ := args[0].(string)
+	if strings.TrimSpace(raw) == "" {
+		return true, nil
+	}
+	effect, _ := args[1].(string)
+	failClosed := strings.EqualFold(strings.TrimSpace(effect), "deny")
+
+	var attrs Attributes
+	switch a := args[2].(type) {
+	case Attributes:
+		attrs = a
+	case map[string]any:
+		attrs = a
+	}
+
+	cond, err := cachedCondition(raw)
+	if err != nil {
+		return failClosed, nil
+	}
+	ok, err := cond.Eval(attrs, time.Now())
+	if err != nil {
+		return failClosed, nil
+	}
+	return ok, nil
+}
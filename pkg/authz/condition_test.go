@@ -0,0 +1,105 @@
+package authz
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionEval(t *testing.T) {
+	noon := time.Date(2026, 1, 14, 12, 0, 0, 0, time.UTC) // Wednesday
+	cases := []struct {
+		name  string
+		cond  string
+		attrs Attributes
+		want  bool
+		err   bool
+	}{
+		{name: "empty", cond: "", want: true},
+		{name: "number literal", cond: "amount < 1000", attrs: Attributes{"amount": 250}, want: true},
+		{name: "number from string attr", cond: "amount < 1000", attrs: Attributes{"amount": "2500"}, want: false},
+		{name: "attribute reference", cond: "amount <= $approval_limit", attrs: Attributes{"amount": 900.5, "approval_limit": int64(1000)}, want: true},
+		{name: "string equality", cond: "department == 'ops'", attrs: Attributes{"department": "ops"}, want: true},
+		{name: "bare word", cond: "department != ops", attrs: Attributes{"department": "finance"}, want: true},
+		{name: "list", cond: "region in (eu|us)", attrs: Attributes{"region": "us"}, want: true},
+		{name: "not in list", cond: "region not_in (eu|us)", attrs: Attributes{"region": "us"}, want: false},
+		{name: "subtree", cond: "org_node_id in $subject_org_subtree", attrs: Attributes{
+			"org_node_id":         uuid.MustParse("2d6b3d39-6d0a-4b8e-8d3c-1e5c6c0a4f01"),
+			"subject_org_subtree": []string{"0c7b", "2d6b3d39-6d0a-4b8e-8d3c-1e5c6c0a4f01"},
+		}, want: true},
+		{name: "conjunction", cond: "amount < 10 && region == eu", attrs: Attributes{"amount": 5, "region": "us"}, want: false},
+		{name: "working hours", cond: "hours 09:00-18:00", want: true},
+		{name: "outside hours in zone", cond: "hours 09:00-18:00 America/New_York", want: false},
+		{name: "overnight", cond: "hours 22:00-06:00", want: false},
+		{name: "now attribute", cond: "hours 22:00-06:00", attrs: Attributes{"now": "2026-01-14T23:30:00Z"}, want: true},
+		{name: "weekdays", cond: "weekdays mon-fri && hours 09:00-18:00", want: true},
+		{name: "weekend", cond: "weekdays sat-sun", want: false},
+		{name: "missing attribute", cond: "amount < 10", err: true},
+		{name: "type mismatch", cond: "department < 10", attrs: Attributes{"department": "ops"}, err: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cond, err := ParseCondition(tc.cond)
+			require.NoError(t, err)
+			got, err := cond.Eval(tc.attrs, noon)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestParseConditionRejects(t *testing.T) {
+	for _, raw := range []string{
+		"amount, 10",
+		"amount ~ 10",
+		"amount < (1|2)",
+		"1amount < 10",
+		"hours 9-18",
+		"hours 09:00-18:00 Mars/Olympus",
+		"weekdays mon-someday",
+		"amount < $",
+		"amount < 10 && ",
+	} {
+		_, err := ParseCondition(raw)
+		require.Error(t, err, raw)
+	}
+}
+
+func TestServiceDenyOverridesAndConditions(t *testing.T) {
+	svc := newTestService(t)
+	userID := uuid.New()
+	subject := SubjectForUser(uuid.Nil, userID)
+	require.NoError(t, svc.UseAdapter(context.Background(), textAdapter{policy: strings.Join([]string{
+		"p, role:finance.approver, finance.payments, approve, global, allow, amount <= $approval_limit",
+		"p, role:finance.approver, finance.payments, approve, global, deny, counterparty_region in (blocked|sanctioned)",
+		"g, " + subject + ", role:finance.approver, global",
+	}, "\n")}))
+
+	check := func(attrs Attributes) bool {
+		t.Helper()
+		allowed, err := svc.Check(context.Background(), NewRequest(subject, "global", "finance.payments", "approve", WithAttributes(attrs)))
+		require.NoError(t, err)
+		return allowed
+	}
+	require.True(t, check(Attributes{"amount": 500, "approval_limit": 1000, "counterparty_region": "eu"}))
+	require.False(t, check(Attributes{"amount": 5000, "approval_limit": 1000, "counterparty_region": "eu"}), "allow condition does not hold")
+	require.False(t, check(Attributes{"amount": 500, "approval_limit": 1000, "counterparty_region": "sanctioned"}), "deny overrides allow")
+	require.False(t, check(Attributes{"amount": 500, "approval_limit": 1000}), "deny rows fail closed on missing attributes")
+
+	result, err := svc.Inspect(context.Background(), NewRequest(subject, "global", "finance.payments", "approve", WithAttributes(Attributes{
+		"amount": 500, "approval_limit": 1000, "counterparty_region": "sanctioned",
+	})))
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.NotNil(t, result.MatchedRule)
+	require.Equal(t, "deny", result.MatchedRule.Effect)
+	require.Equal(t, "counterparty_region in (blocked|sanctioned)", result.MatchedRule.Condition)
+}
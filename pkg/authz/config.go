@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/casbin/casbin/v2/persist"
 	"github.com/sirupsen/logrus"
 
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
@@ -18,13 +19,15 @@ type Config struct {
 	FlagMode     Mode
 	Logger       *logrus.Logger
 	FlagProvider FlagProvider
+	// Adapter overrides the policy file at PolicyPath, e.g. with the shared policystore.Store.
+	Adapter persist.Adapter
 }
 
 func (c Config) validate() error {
 	if c.ModelPath == "" {
 		return configError("missing model path")
 	}
-	if c.PolicyPath == "" {
+	if c.PolicyPath == "" && c.Adapter == nil {
 		return configError("missing policy path")
 	}
 	if c.FlagPath == "" && c.FlagProvider == nil {
@@ -35,7 +38,9 @@ func (c Config) validate() error {
 
 func (c Config) normalized() Config {
 	c.ModelPath = filepath.Clean(c.ModelPath)
-	c.PolicyPath = filepath.Clean(c.PolicyPath)
+	if c.PolicyPath != "" {
+		c.PolicyPath = filepath.Clean(c.PolicyPath)
+	}
 	if c.FlagPath != "" {
 		c.FlagPath = filepath.Clean(c.FlagPath)
 	}
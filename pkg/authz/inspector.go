@@ -13,6 +13,19 @@ type InspectionResult struct {
 	Trace           []string
 	Latency         time.Duration
 	OriginalRequest Request
+	// MatchedRule is the p row that decided the request: the first matching deny row, otherwise
+	// the first matching allow row. It is nil when nothing matched.
+	MatchedRule *MatchedRule
+}
+
+// MatchedRule names the fields of the deciding p row.
+type MatchedRule struct {
+	Subject   string
+	Object    string
+	Action    string
+	Domain    string
+	Effect    string
+	Condition string
 }
 
 // Inspect evaluates a request and returns diagnostic information for debugging.
@@ -40,6 +53,31 @@ func (s *Service) Inspect(ctx context.Context, req Request) (InspectionResult, e
 			Attributes: cloneAttributes(req.Attributes),
 		},
 	}
+	if len(trace) > 0 {
+		result.MatchedRule = s.matchedRule(trace)
+	}
 	recordDebugMetrics(result.Mode, result.Allowed, latency)
 	return result, nil
 }
+
+// matchedRule maps an EnforceEx explanation onto the model's p tokens. Callers hold s.mu.
+func (s *Service) matchedRule(trace []string) *MatchedRule {
+	ast, ok := s.enforcer.GetModel()["p"]["p"]
+	if !ok {
+		return nil
+	}
+	fields := make(map[string]string, len(ast.Tokens))
+	for i, token := range ast.Tokens {
+		if i < len(trace) {
+			fields[token] = trace[i]
+		}
+	}
+	return &MatchedRule{
+		Subject:   fields["p_sub"],
+		Object:    fields["p_obj"],
+		Action:    fields["p_act"],
+		Domain:    fields["p_dom"],
+		Effect:    fields["p_eft"],
+		Condition: fields["p_cond"],
+	}
+}
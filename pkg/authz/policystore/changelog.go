@@ -0,0 +1,140 @@
+package policystore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrNoChangeSet is returned by ChangeLog.Get for an unknown id.
+var ErrNoChangeSet = errors.New("authz/policystore: change set not found")
+
+// ChangeSet is one applied policy change. Added and Removed hold canonical policy lines; Policy is
+// the snapshot the change produced, which is what reverting to it restores.
+type ChangeSet struct {
+	ID           int64
+	BaseRevision string
+	Revision     string
+	Policy       []byte
+	Added        []string
+	Removed      []string
+	Reason       string
+	AuthorID     uint
+	Author       string
+	// RevertOf is the change set whose snapshot this one restored, if it is a revert.
+	RevertOf  *int64
+	CreatedAt time.Time
+}
+
+// ChangeLog records applied change sets. It works with either policy backend: the file backend
+// keeps no history of its own, and the store's revisions carry no reason or diff.
+type ChangeLog struct {
+	pool *pgxpool.Pool
+}
+
+// NewChangeLog constructs a ChangeLog on the given pool.
+func NewChangeLog(pool *pgxpool.Pool) *ChangeLog {
+	return &ChangeLog{pool: pool}
+}
+
+// Record stores cs and returns it with its id and timestamp.
+func (l *ChangeLog) Record(ctx context.Context, cs ChangeSet) (ChangeSet, error) {
+	if cs.Added == nil {
+		cs.Added = []string{}
+	}
+	if cs.Removed == nil {
+		cs.Removed = []string{}
+	}
+	var authorID *int64
+	if cs.AuthorID != 0 {
+		id := int64(cs.AuthorID)
+		authorID = &id
+	}
+	if err := l.pool.QueryRow(ctx, `
+INSERT INTO authz_policy_changes (base_revision, revision, policy, added, removed, reason, author_id, author, revert_of)
+VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, NULLIF($8, ''), $9)
+RETURNING id, created_at
+`, cs.BaseRevision, cs.Revision, string(cs.Policy), cs.Added, cs.Removed, cs.Reason, authorID, cs.Author, cs.RevertOf).Scan(&cs.ID, &cs.CreatedAt); err != nil {
+		return ChangeSet{}, fmt.Errorf("authz/policystore: record change set: %w", err)
+	}
+	return cs, nil
+}
+
+const selectChangeSetColumns = `
+SELECT id, base_revision, revision, added, removed, COALESCE(reason, ''), COALESCE(author_id, 0),
+       COALESCE(author, ''), revert_of, created_at`
+
+func scanChangeSet(row pgx.Row, extra ...any) (ChangeSet, error) {
+	var (
+		cs       ChangeSet
+		authorID int64
+	)
+	dest := append([]any{
+		&cs.ID, &cs.BaseRevision, &cs.Revision, &cs.Added, &cs.Removed, &cs.Reason, &authorID,
+		&cs.Author, &cs.RevertOf, &cs.CreatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return ChangeSet{}, err
+	}
+	cs.AuthorID = uint(authorID)
+	return cs, nil
+}
+
+// List returns change sets newest first without their snapshots, along with the total count.
+func (l *ChangeLog) List(ctx context.Context, limit, offset int) ([]ChangeSet, int, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	var total int
+	if err := l.pool.QueryRow(ctx, `SELECT count(*) FROM authz_policy_changes`).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("authz/policystore: count change sets: %w", err)
+	}
+
+	rows, err := l.pool.Query(ctx, selectChangeSetColumns+`
+FROM authz_policy_changes
+ORDER BY id DESC
+LIMIT $1 OFFSET $2
+`, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("authz/policystore: list change sets: %w", err)
+	}
+	defer rows.Close()
+
+	var out []ChangeSet
+	for rows.Next() {
+		cs, err := scanChangeSet(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("authz/policystore: list change sets: %w", err)
+		}
+		out = append(out, cs)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("authz/policystore: list change sets: %w", err)
+	}
+	return out, total, nil
+}
+
+// Get returns one change set including its snapshot.
+func (l *ChangeLog) Get(ctx context.Context, id int64) (ChangeSet, error) {
+	var policy string
+	cs, err := scanChangeSet(l.pool.QueryRow(ctx, selectChangeSetColumns+`, policy
+FROM authz_policy_changes
+WHERE id = $1
+`, id), &policy)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ChangeSet{}, ErrNoChangeSet
+	}
+	if err != nil {
+		return ChangeSet{}, fmt.Errorf("authz/policystore: load change set %d: %w", id, err)
+	}
+	cs.Policy = []byte(policy)
+	return cs, nil
+}
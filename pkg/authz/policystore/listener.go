@@ -0,0 +1,91 @@
+package policystore
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/sirupsen/logrus"
+)
+
+// ListenerOptions tunes a Listener.
+type ListenerOptions struct {
+	// RetryInterval is how long to wait before reconnecting after the LISTEN connection drops.
+	RetryInterval time.Duration
+	Logger        *logrus.Entry
+}
+
+// Listener reloads the local enforcer whenever another replica commits a new head revision.
+type Listener struct {
+	pool   *pgxpool.Pool
+	reload func(context.Context) error
+	opts   ListenerOptions
+}
+
+// NewListener constructs a Listener that calls reload (typically authz.Service.ReloadPolicy) on
+// every notification on NotifyChannel.
+func NewListener(pool *pgxpool.Pool, reload func(context.Context) error, opts ListenerOptions) (*Listener, error) {
+	if pool == nil {
+		return nil, errors.New("authz/policystore: pool is required")
+	}
+	if reload == nil {
+		return nil, errors.New("authz/policystore: reload callback is required")
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = 5 * time.Second
+	}
+	if opts.Logger == nil {
+		opts.Logger = logrus.NewEntry(logrus.StandardLogger())
+	}
+	return &Listener{pool: pool, reload: reload, opts: opts}, nil
+}
+
+// Run listens until ctx is done, reconnecting after connection loss.
+func (l *Listener) Run(ctx context.Context) error {
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		l.opts.Logger.WithError(err).Warn("authz/policystore: listener disconnected; retrying")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(l.opts.RetryInterval):
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	pooled, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection stays subscribed, so it never goes back to the pool.
+	conn := pooled.Hijack()
+	defer func() { _ = conn.Close(context.Background()) }()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{NotifyChannel}.Sanitize()); err != nil {
+		return err
+	}
+
+	// Catch up on anything committed while this replica was not listening.
+	l.reloadPolicy(ctx, "")
+
+	for {
+		n, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		l.reloadPolicy(ctx, n.Payload)
+	}
+}
+
+func (l *Listener) reloadPolicy(ctx context.Context, revision string) {
+	if err := l.reload(ctx); err != nil {
+		// The next notification or reconnect retries; until then the previous policy stays in force.
+		l.opts.Logger.WithError(err).WithField("revision", revision).Error("authz/policystore: reload failed")
+	}
+}
@@ -0,0 +1,331 @@
+// Package policystore keeps the aggregated Casbin policy in Postgres so every server replica
+// enforces the same revision. It implements persist.Adapter for pkg/authz.Service, records each
+// applied policy as a full snapshot and announces new heads on NotifyChannel.
+package policystore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/casbin/casbin/v2/persist"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/pkg/authz"
+	"github.com/iota-uz/iota-sdk/pkg/authz/version"
+)
+
+// NotifyChannel is the Postgres channel a new head revision is announced on; the payload is the
+// revision hash.
+const NotifyChannel = "authz_policy_changed"
+
+const (
+	SourceApply  = "apply"
+	SourceImport = "import"
+	SourceRevert = "revert"
+)
+
+var (
+	// ErrNoRevision is returned while the store is empty, before the first import.
+	ErrNoRevision = errors.New("authz/policystore: no policy revision")
+	// ErrStaleRevision is returned by Commit when the head moved past the caller's base revision.
+	ErrStaleRevision = errors.New("authz/policystore: base revision is not current")
+)
+
+// Revision is one stored policy snapshot. Revision is the sha256 of Policy, the same hash
+// authz-pack writes to policy.csv.rev, so a file and its imported snapshot share a revision.
+type Revision struct {
+	ID        int64
+	Revision  string
+	ParentID  *int64
+	Policy    []byte
+	Entries   int
+	Source    string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// CommitInput describes a new head revision.
+type CommitInput struct {
+	// BaseRevision must match the current head; it is empty only for the first revision.
+	BaseRevision string
+	Policy       []byte
+	Source       string
+	CreatedBy    string
+}
+
+// Store reads and writes policy revisions. The zero value is not usable; use NewStore.
+type Store struct {
+	pool        *pgxpool.Pool
+	loadTimeout time.Duration
+}
+
+var (
+	_ persist.Adapter  = (*Store)(nil)
+	_ version.Provider = (*Store)(nil)
+)
+
+// NewStore constructs a Store on the given pool.
+func NewStore(pool *pgxpool.Pool) *Store {
+	return &Store{pool: pool, loadTimeout: 10 * time.Second}
+}
+
+// RevisionHash returns the revision identifier of a policy snapshot.
+func RevisionHash(policy []byte) string {
+	sum := sha256.Sum256(policy)
+	return hex.EncodeToString(sum[:])
+}
+
+// CountEntries returns the number of policy lines in a CSV snapshot, skipping blanks and comments.
+func CountEntries(policy []byte) int {
+	n := 0
+	scanner := bufio.NewScanner(bytes.NewReader(policy))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		n++
+	}
+	return n
+}
+
+const selectHeadSQL = `
+SELECT r.id, r.revision, r.parent_id, r.policy, r.entries, r.source, COALESCE(r.created_by, ''), r.created_at
+FROM authz_policy_head h
+JOIN authz_policy_revisions r ON r.id = h.revision_id
+WHERE h.singleton`
+
+func scanRevision(row pgx.Row) (Revision, error) {
+	var (
+		rev    Revision
+		policy string
+	)
+	if err := row.Scan(&rev.ID, &rev.Revision, &rev.ParentID, &policy, &rev.Entries, &rev.Source, &rev.CreatedBy, &rev.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Revision{}, ErrNoRevision
+		}
+		return Revision{}, err
+	}
+	rev.Policy = []byte(policy)
+	return rev, nil
+}
+
+// Head returns the revision currently enforced.
+func (s *Store) Head(ctx context.Context) (Revision, error) {
+	rev, err := scanRevision(s.pool.QueryRow(ctx, selectHeadSQL))
+	if err != nil && !errors.Is(err, ErrNoRevision) {
+		return Revision{}, fmt.Errorf("authz/policystore: load head: %w", err)
+	}
+	return rev, err
+}
+
+// Current implements version.Provider with the head revision.
+func (s *Store) Current(ctx context.Context) (version.Metadata, error) {
+	head, err := s.Head(ctx)
+	if err != nil {
+		return version.Metadata{}, err
+	}
+	return version.Metadata{
+		Revision:    head.Revision,
+		GeneratedAt: head.CreatedAt,
+		Entries:     head.Entries,
+	}, nil
+}
+
+// Revisions lists stored revisions, newest first, without their policy text.
+func (s *Store) Revisions(ctx context.Context, limit int) ([]Revision, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := s.pool.Query(ctx, `
+SELECT id, revision, parent_id, entries, source, COALESCE(created_by, ''), created_at
+FROM authz_policy_revisions
+ORDER BY id DESC
+LIMIT $1
+`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("authz/policystore: list revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Revision
+	for rows.Next() {
+		var rev Revision
+		if err := rows.Scan(&rev.ID, &rev.Revision, &rev.ParentID, &rev.Entries, &rev.Source, &rev.CreatedBy, &rev.CreatedAt); err != nil {
+			return nil, fmt.Errorf("authz/policystore: list revisions: %w", err)
+		}
+		out = append(out, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("authz/policystore: list revisions: %w", err)
+	}
+	return out, nil
+}
+
+// Commit stores a new snapshot and moves the head to it, provided the head is still at
+// in.BaseRevision. Listeners on NotifyChannel are told once the transaction commits.
+func (s *Store) Commit(ctx context.Context, in CommitInput) (Revision, error) {
+	if strings.TrimSpace(in.Source) == "" {
+		return Revision{}, errors.New("authz/policystore: source is required")
+	}
+	if err := validatePolicy(in.Policy); err != nil {
+		return Revision{}, err
+	}
+
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return Revision{}, fmt.Errorf("authz/policystore: begin: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var parentID *int64
+	head, err := scanRevision(tx.QueryRow(ctx, selectHeadSQL+" FOR UPDATE OF h"))
+	switch {
+	case errors.Is(err, ErrNoRevision):
+		if strings.TrimSpace(in.BaseRevision) != "" {
+			return Revision{}, ErrStaleRevision
+		}
+	case err != nil:
+		return Revision{}, fmt.Errorf("authz/policystore: lock head: %w", err)
+	default:
+		if head.Revision != strings.TrimSpace(in.BaseRevision) {
+			return Revision{}, ErrStaleRevision
+		}
+		parentID = &head.ID
+	}
+
+	rev := Revision{
+		Revision:  RevisionHash(in.Policy),
+		ParentID:  parentID,
+		Policy:    in.Policy,
+		Entries:   CountEntries(in.Policy),
+		Source:    in.Source,
+		CreatedBy: in.CreatedBy,
+	}
+	if err := tx.QueryRow(ctx, `
+INSERT INTO authz_policy_revisions (revision, parent_id, policy, entries, source, created_by)
+VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+RETURNING id, created_at
+`, rev.Revision, rev.ParentID, string(rev.Policy), rev.Entries, rev.Source, rev.CreatedBy).Scan(&rev.ID, &rev.CreatedAt); err != nil {
+		return Revision{}, fmt.Errorf("authz/policystore: insert revision: %w", err)
+	}
+
+	if parentID == nil {
+		// Two replicas bootstrapping an empty store race on the singleton key; the loser is stale.
+		if _, err := tx.Exec(ctx, `INSERT INTO authz_policy_head (singleton, revision_id) VALUES (true, $1)`, rev.ID); err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return Revision{}, ErrStaleRevision
+			}
+			return Revision{}, fmt.Errorf("authz/policystore: insert head: %w", err)
+		}
+	} else {
+		if _, err := tx.Exec(ctx, `UPDATE authz_policy_head SET revision_id = $1, updated_at = now() WHERE singleton`, rev.ID); err != nil {
+			return Revision{}, fmt.Errorf("authz/policystore: update head: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `SELECT pg_notify($1, $2)`, NotifyChannel, rev.Revision); err != nil {
+		return Revision{}, fmt.Errorf("authz/policystore: notify: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return Revision{}, fmt.Errorf("authz/policystore: commit: %w", err)
+	}
+	return rev, nil
+}
+
+// Bootstrap imports the policy file at policyPath as the first revision when the store is empty
+// and is a no-op otherwise, so every replica can call it on startup.
+func (s *Store) Bootstrap(ctx context.Context, policyPath string) error {
+	if _, err := s.Head(ctx); !errors.Is(err, ErrNoRevision) {
+		return err
+	}
+	policy, err := os.ReadFile(policyPath)
+	if err != nil {
+		return fmt.Errorf("authz/policystore: read seed policy: %w", err)
+	}
+	_, err = s.Commit(ctx, CommitInput{Policy: policy, Source: SourceImport})
+	if errors.Is(err, ErrStaleRevision) {
+		return nil
+	}
+	return err
+}
+
+// validatePolicy rejects snapshots Casbin could not load, so a bad import never becomes the head.
+func validatePolicy(policy []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(policy))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		record, err := csv.NewReader(strings.NewReader(text)).Read()
+		if err != nil {
+			return fmt.Errorf("authz/policystore: line %d: %w", line, err)
+		}
+		for i := range record {
+			record[i] = strings.TrimSpace(record[i])
+		}
+		if err := authz.ValidatePolicyRecord(record); err != nil {
+			return fmt.Errorf("authz/policystore: line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// loadPolicyText feeds a CSV snapshot into a Casbin model line by line, like the file adapter.
+func loadPolicyText(policy []byte, m model.Model) error {
+	scanner := bufio.NewScanner(bytes.NewReader(policy))
+	for scanner.Scan() {
+		if err := authz.LoadPolicyLine(scanner.Text(), m); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// LoadPolicy implements persist.Adapter by loading the head revision.
+func (s *Store) LoadPolicy(m model.Model) error {
+	ctx, cancel := context.WithTimeout(context.Background(), s.loadTimeout)
+	defer cancel()
+
+	head, err := s.Head(ctx)
+	if err != nil {
+		return err
+	}
+	return loadPolicyText(head.Policy, m)
+}
+
+// SavePolicy is not supported: policy changes go through Commit so they keep a base revision.
+func (s *Store) SavePolicy(model.Model) error {
+	return errors.New("not implemented")
+}
+
+// AddPolicy is not supported; like the file adapter, Casbin then keeps the rule in memory only.
+func (s *Store) AddPolicy(string, string, []string) error {
+	return errors.New("not implemented")
+}
+
+// RemovePolicy is not supported; like the file adapter, Casbin then keeps the change in memory only.
+func (s *Store) RemovePolicy(string, string, []string) error {
+	return errors.New("not implemented")
+}
+
+// RemoveFilteredPolicy is not supported; like the file adapter, Casbin then keeps the change in memory only.
+func (s *Store) RemoveFilteredPolicy(string, string, int, ...string) error {
+	return errors.New("not implemented")
+}
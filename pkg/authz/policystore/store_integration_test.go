@@ -0,0 +1,156 @@
+//go:build integration
+
+package policystore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// newIntegrationStore applies the policy store and change log migrations in a throwaway schema.
+func newIntegrationStore(t *testing.T) (*Store, *pgxpool.Pool) {
+	t.Helper()
+	dsn := os.Getenv("AUTHZ_POLICY_STORE_TEST_DSN")
+	if dsn == "" {
+		t.Skip("AUTHZ_POLICY_STORE_TEST_DSN is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	schema := "authz_policystore_it_" + strings.ReplaceAll(uuid.NewString()[:8], "-", "")
+	admin, err := pgx.Connect(ctx, dsn)
+	require.NoError(t, err)
+	_, err = admin.Exec(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		_ = admin.Close(context.Background())
+	})
+
+	cfg, err := pgxpool.ParseConfig(dsn)
+	require.NoError(t, err)
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	for _, name := range []string{"changes-1768636800.sql", "changes-1768723200.sql"} {
+		migration, err := os.ReadFile(filepath.Join("..", "..", "..", "migrations", name))
+		require.NoError(t, err)
+		up, _, _ := strings.Cut(string(migration), "-- +migrate Down")
+		_, err = pool.Exec(ctx, strings.Replace(up, "-- +migrate Up", "", 1))
+		require.NoError(t, err)
+	}
+
+	return NewStore(pool), pool
+}
+
+func TestStore_Integration_CommitHistoryAndNotify(t *testing.T) {
+	store, pool := newIntegrationStore(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	_, err := store.Head(ctx)
+	require.ErrorIs(t, err, ErrNoRevision)
+
+	first := []byte("p, role:core.admin, core.users, list, global, allow\n")
+	seed := filepath.Join(t.TempDir(), "policy.csv")
+	require.NoError(t, os.WriteFile(seed, first, 0o644))
+	require.NoError(t, store.Bootstrap(ctx, seed))
+	require.NoError(t, os.WriteFile(seed, []byte("p, role:other, core.users, list, global, allow\n"), 0o644))
+	require.NoError(t, store.Bootstrap(ctx, seed))
+	head, err := store.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, RevisionHash(first), head.Revision)
+	require.Equal(t, SourceImport, head.Source)
+	require.Nil(t, head.ParentID)
+
+	var reloads atomic.Int32
+	listener, err := NewListener(pool, func(context.Context) error {
+		reloads.Add(1)
+		return nil
+	}, ListenerOptions{RetryInterval: 100 * time.Millisecond})
+	require.NoError(t, err)
+	listenCtx, stopListener := context.WithCancel(ctx)
+	defer stopListener()
+	go func() { _ = listener.Run(listenCtx) }()
+	require.Eventually(t, func() bool { return reloads.Load() == 1 }, 5*time.Second, 20*time.Millisecond, "listener reloads once on connect")
+
+	second := []byte("p, role:core.admin, core.users, (list|view), global, allow\n")
+	rev, err := store.Commit(ctx, CommitInput{BaseRevision: head.Revision, Policy: second, Source: SourceApply, CreatedBy: "admin@example.com"})
+	require.NoError(t, err)
+	require.Equal(t, head.ID, *rev.ParentID)
+	require.Eventually(t, func() bool { return reloads.Load() == 2 }, 5*time.Second, 20*time.Millisecond, "listener reloads on notify")
+
+	_, err = store.Commit(ctx, CommitInput{BaseRevision: head.Revision, Policy: first, Source: SourceApply})
+	require.ErrorIs(t, err, ErrStaleRevision)
+
+	meta, err := store.Current(ctx)
+	require.NoError(t, err)
+	require.Equal(t, RevisionHash(second), meta.Revision)
+	require.Equal(t, 1, meta.Entries)
+
+	revisions, err := store.Revisions(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, rev.ID, revisions[0].ID)
+	require.Equal(t, "admin@example.com", revisions[0].CreatedBy)
+}
+
+func TestChangeLog_Integration_RecordListGet(t *testing.T) {
+	_, pool := newIntegrationStore(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	log := NewChangeLog(pool)
+
+	policy := []byte("p, role:core.admin, core.users, list, global, allow\n")
+	first, err := log.Record(ctx, ChangeSet{
+		BaseRevision: "base",
+		Revision:     RevisionHash(policy),
+		Policy:       policy,
+		Added:        []string{"p, role:core.admin, core.users, list, global, allow"},
+		Reason:       "grant list",
+		AuthorID:     7,
+		Author:       "admin@example.com",
+	})
+	require.NoError(t, err)
+	require.NotZero(t, first.ID)
+
+	revert, err := log.Record(ctx, ChangeSet{
+		BaseRevision: first.Revision,
+		Revision:     "base",
+		Policy:       []byte(""),
+		Removed:      first.Added,
+		RevertOf:     &first.ID,
+	})
+	require.NoError(t, err)
+
+	list, total, err := log.List(ctx, 10, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	require.Len(t, list, 2)
+	require.Equal(t, revert.ID, list[0].ID)
+	require.Equal(t, first.ID, *list[0].RevertOf)
+	require.Empty(t, list[0].Added)
+	require.Nil(t, list[1].Policy, "List omits snapshots")
+
+	got, err := log.Get(ctx, first.ID)
+	require.NoError(t, err)
+	require.Equal(t, policy, got.Policy)
+	require.Equal(t, uint(7), got.AuthorID)
+	require.Equal(t, "grant list", got.Reason)
+	require.Equal(t, first.Added, got.Added)
+
+	_, err = log.Get(ctx, first.ID+100)
+	require.ErrorIs(t, err, ErrNoChangeSet)
+}
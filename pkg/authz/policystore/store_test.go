@@ -0,0 +1,49 @@
+package policystore
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/casbin/casbin/v2/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iota-uz/iota-sdk/pkg/authz/version"
+)
+
+func TestRevisionHashMatchesPackedPolicy(t *testing.T) {
+	root := filepath.Join("..", "..", "..", "config", "access")
+	policy, err := os.ReadFile(filepath.Join(root, "policy.csv"))
+	require.NoError(t, err)
+	raw, err := os.ReadFile(filepath.Join(root, "policy.csv.rev"))
+	require.NoError(t, err)
+	var meta version.Metadata
+	require.NoError(t, json.Unmarshal(raw, &meta))
+
+	require.Equal(t, meta.Revision, RevisionHash(policy))
+	require.Equal(t, meta.Entries, CountEntries(policy))
+	require.NoError(t, validatePolicy(policy))
+}
+
+func TestLoadPolicyText(t *testing.T) {
+	m, err := model.NewModelFromFile(filepath.Join("..", "testdata", "model.conf"))
+	require.NoError(t, err)
+
+	policy := []byte("# header\n\np, role:core.admin, core.users, list, global, allow\n  g, tenant:global:user:1, role:core.admin, global\n")
+	require.NoError(t, loadPolicyText(policy, m))
+	require.Len(t, m["p"]["p"].Policy, 1)
+	require.Len(t, m["g"]["g"].Policy, 1)
+	require.Equal(t, []string{"tenant:global:user:1", "role:core.admin", "global"}, m["g"]["g"].Policy[0])
+	require.Equal(t, []string{"role:core.admin", "core.users", "list", "global", "allow", ""}, m["p"]["p"].Policy[0], "rows without cond are padded")
+}
+
+func TestValidatePolicy(t *testing.T) {
+	require.NoError(t, validatePolicy([]byte("# only a comment\n")))
+	require.NoError(t, validatePolicy([]byte("g2, tenant:global:user:1, role:core.admin\n")))
+	require.Error(t, validatePolicy([]byte("x, a, b, c\n")))
+	require.Error(t, validatePolicy([]byte("p, role:core.admin\n")))
+	require.NoError(t, validatePolicy([]byte("p, role:finance.approver, finance.payments, approve, global, deny, amount > $approval_limit\n")))
+	require.Error(t, validatePolicy([]byte("p, role:core.admin, core.users, list, global, maybe\n")))
+	require.Error(t, validatePolicy([]byte("p, role:core.admin, core.users, list, global, allow, amount ~ 3\n")))
+}
@@ -0,0 +1,65 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScope(t *testing.T) {
+	scope, err := ParseScope(" Core.Users:READ ")
+	require.NoError(t, err)
+	assert.Equal(t, Scope{Object: "core.users", Action: "read"}, scope)
+
+	scope, err = ParseScope("org.*")
+	require.NoError(t, err)
+	assert.Equal(t, "org.*:*", scope.String())
+
+	for _, raw := range []string{"", ":read", "core.users:a:b", "co*re.users", "core users"} {
+		_, err := ParseScope(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestScopeAllows(t *testing.T) {
+	mustScope := func(raw string) Scope {
+		scope, err := ParseScope(raw)
+		require.NoError(t, err)
+		return scope
+	}
+
+	assert.True(t, mustScope("*").Allows("core.users", "delete"))
+	assert.True(t, mustScope("org.*").Allows("org.nodes", "update"))
+	assert.False(t, mustScope("org.*").Allows("organization.nodes", "update"))
+	assert.True(t, mustScope("core.users:read").Allows("core.users", "list"))
+	assert.True(t, mustScope("core.users:read").Allows("core.users", "View"))
+	assert.False(t, mustScope("core.users:read").Allows("core.users", "update"))
+	assert.False(t, mustScope("core.users:read").Allows("core.roles", "view"))
+	assert.True(t, mustScope("core.users:update").Allows("core.users", "update"))
+	assert.False(t, ScopesAllow(nil, "core.users", "view"))
+}
+
+func TestServiceAuthorizeScoped(t *testing.T) {
+	svc := newTestService(t)
+	req := NewRequest(
+		SubjectForUser(uuid.Nil, uuid.MustParse("f6f8b13e-755f-41e0-af1a-f2671e40c15c")),
+		DomainFromTenant(uuid.Nil),
+		ObjectName("core", "users"),
+		NormalizeAction("list"),
+	)
+
+	ctx := WithScopes(context.Background(), []Scope{{Object: "core.users", Action: "read"}})
+	require.NoError(t, svc.Authorize(ctx, req))
+
+	ctx = WithScopes(context.Background(), []Scope{{Object: "core.roles", Action: "*"}})
+	require.Error(t, svc.Authorize(ctx, req))
+	allowed, err := svc.Check(ctx, req)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+
+	svc.flagProvider = staticFlagProvider{mode: ModeDisabled}
+	require.Error(t, svc.Authorize(ctx, req), "scopes apply even when enforcement is off")
+}
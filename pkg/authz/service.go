@@ -6,7 +6,7 @@ import (
 	"sync"
 
 	"github.com/casbin/casbin/v2"
-	fileadapter "github.com/casbin/casbin/v2/persist/file-adapter"
+	"github.com/casbin/casbin/v2/persist"
 	"github.com/sirupsen/logrus"
 )
 
@@ -33,10 +33,15 @@ func NewService(cfg Config) (*Service, error) {
 		logger = logrus.WithField("component", "authz")
 	}
 
-	enf, err := casbin.NewEnforcer(cfg.ModelPath, fileadapter.NewAdapter(cfg.PolicyPath))
+	adapter := cfg.Adapter
+	if adapter == nil {
+		adapter = newFileAdapter(cfg.PolicyPath)
+	}
+	enf, err := casbin.NewEnforcer(cfg.ModelPath, adapter)
 	if err != nil {
 		return nil, fmt.Errorf("authz: failed to initialize enforcer: %w", err)
 	}
+	enf.AddFunction("authzCond", conditionMatcher)
 	if err := enf.LoadPolicy(); err != nil {
 		return nil, fmt.Errorf("authz: failed to load policies: %w", err)
 	}
@@ -56,6 +61,18 @@ func NewService(cfg Config) (*Service, error) {
 
 // Authorize returns an error if the request is denied.
 func (s *Service) Authorize(ctx context.Context, req Request) error {
+	// Scopes are a property of the credential, not of the policy rollout, so they are enforced
+	// in every mode.
+	if scopes, ok := ScopesFromContext(ctx); ok && !ScopesAllow(scopes, req.Object, req.Action) {
+		s.logger.WithContext(ctx).WithFields(logrus.Fields{
+			"subject": req.Subject,
+			"domain":  req.Domain,
+			"object":  req.Object,
+			"action":  req.Action,
+		}).Warn("authz denied request outside token scopes")
+		return forbiddenError(req)
+	}
+
 	switch mode := s.flagProvider.Mode(); mode {
 	case ModeDisabled:
 		return nil
@@ -111,6 +128,10 @@ func (s *Service) Authorize(ctx context.Context, req Request) error {
 
 // Check evaluates a request without returning an authorization error.
 func (s *Service) Check(ctx context.Context, req Request) (bool, error) {
+	if scopes, ok := ScopesFromContext(ctx); ok && !ScopesAllow(scopes, req.Object, req.Action) {
+		return false, nil
+	}
+
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -121,7 +142,7 @@ func (s *Service) Check(ctx context.Context, req Request) (bool, error) {
 	return res, nil
 }
 
-// ReloadPolicy reloads policy data from disk.
+// ReloadPolicy reloads policy data from the configured adapter.
 func (s *Service) ReloadPolicy(ctx context.Context) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -133,6 +154,25 @@ func (s *Service) ReloadPolicy(ctx context.Context) error {
 	return nil
 }
 
+// UseAdapter switches the policy source and loads it. The previous adapter and policy stay in
+// force if the new source cannot be loaded.
+func (s *Service) UseAdapter(ctx context.Context, adapter persist.Adapter) error {
+	if adapter == nil {
+		return configError("missing policy adapter")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous := s.enforcer.GetAdapter()
+	s.enforcer.SetAdapter(adapter)
+	if err := s.enforcer.LoadPolicy(); err != nil {
+		s.enforcer.SetAdapter(previous)
+		return fmt.Errorf("authz: load policy from new adapter failed: %w", err)
+	}
+	s.logger.WithContext(ctx).WithField("adapter", fmt.Sprintf("%T", adapter)).Info("authz policy adapter switched")
+	return nil
+}
+
 // Enforcer exposes the underlying casbin enforcer (read-only usage only).
 func (s *Service) Enforcer() *casbin.Enforcer {
 	s.mu.RLock()
@@ -2,9 +2,12 @@ package authz
 
 import (
 	"context"
+	"errors"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/casbin/casbin/v2/model"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 )
@@ -107,3 +110,56 @@ func TestServiceInspectClonesAttributes(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "ops", value)
 }
+
+type textAdapter struct {
+	policy string
+	err    error
+}
+
+func (a textAdapter) LoadPolicy(m model.Model) error {
+	if a.err != nil {
+		return a.err
+	}
+	for _, line := range strings.Split(a.policy, "\n") {
+		if err := LoadPolicyLine(line, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (textAdapter) SavePolicy(model.Model) error                { return errors.New("not implemented") }
+func (textAdapter) AddPolicy(string, string, []string) error    { return errors.New("not implemented") }
+func (textAdapter) RemovePolicy(string, string, []string) error { return errors.New("not implemented") }
+func (textAdapter) RemoveFilteredPolicy(string, string, int, ...string) error {
+	return errors.New("not implemented")
+}
+
+func TestServiceUseAdapter(t *testing.T) {
+	svc := newTestService(t)
+	userID := uuid.MustParse("f6f8b13e-755f-41e0-af1a-f2671e40c15c")
+	list := NewRequest(SubjectForUser(uuid.Nil, userID), DomainFromTenant(uuid.Nil), ObjectName("core", "users"), NormalizeAction("list"))
+	view := NewRequest(SubjectForUser(uuid.Nil, userID), DomainFromTenant(uuid.Nil), ObjectName("core", "groups"), NormalizeAction("view"))
+
+	err := svc.UseAdapter(context.Background(), textAdapter{err: errors.New("store unavailable")})
+	require.Error(t, err)
+	allowed, err := svc.Check(context.Background(), list)
+	require.NoError(t, err)
+	require.True(t, allowed, "a failed switch keeps the previous policy")
+
+	require.NoError(t, svc.UseAdapter(context.Background(), textAdapter{policy: strings.Join([]string{
+		"p, role:core.viewer, core.groups, view, global, allow",
+		"g, " + SubjectForUser(uuid.Nil, userID) + ", role:core.viewer, global",
+	}, "\n")}))
+	allowed, err = svc.Check(context.Background(), list)
+	require.NoError(t, err)
+	require.False(t, allowed)
+	allowed, err = svc.Check(context.Background(), view)
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	require.NoError(t, svc.ReloadPolicy(context.Background()))
+	allowed, err = svc.Check(context.Background(), view)
+	require.NoError(t, err)
+	require.True(t, allowed, "reload reads from the new adapter")
+}
@@ -122,6 +122,11 @@ type AuthzOptions struct {
 	FlagConfigPath string `env:"AUTHZ_FLAG_CONFIG" envDefault:"config/access/authz_flags.yaml"`
 	FixturesPath   string `env:"AUTHZ_FIXTURES_PATH" envDefault:"config/access/fixtures"`
 	Mode           string `env:"AUTHZ_MODE" envDefault:"shadow"`
+	// PolicyStore is "file" (PolicyPath on local disk) or "db" (shared authz_policy_* tables).
+	PolicyStore string `env:"AUTHZ_POLICY_STORE" envDefault:"file"`
+	// RequireApproval routes policy edits through change requests that another admin approves;
+	// when false, admins may also apply staged edits directly.
+	RequireApproval bool `env:"AUTHZ_REQUIRE_APPROVAL" envDefault:"false"`
 }
 
 type OutboxOptions struct {
@@ -158,6 +163,7 @@ type Configuration struct {
 	MigrationsDir    string        `env:"MIGRATIONS_DIR" envDefault:"migrations"`
 	ServerPort       int           `env:"PORT" envDefault:"3200"`
 	SessionDuration  time.Duration `env:"SESSION_DURATION" envDefault:"720h"`
+	APITokenMaxTTL   time.Duration `env:"API_TOKEN_MAX_TTL" envDefault:"8760h"`
 	GoAppEnvironment string        `env:"GO_APP_ENV" envDefault:"development"`
 	SocketAddress    string        `env:"-"`
 	OpenAIKey        string        `env:"OPENAI_KEY"`
@@ -209,6 +215,11 @@ type Configuration struct {
 	OrgChangeRequestsEnabled bool `env:"ORG_CHANGE_REQUESTS_ENABLED" envDefault:"false"`
 	OrgPreflightEnabled      bool `env:"ORG_PREFLIGHT_ENABLED" envDefault:"false"`
 
+	// Org change request scheduler: applies approved requests once scheduled_for passes and
+	// escalates overdue approval steps.
+	OrgChangeRequestSchedulerEnabled  bool          `env:"ORG_CHANGE_REQUEST_SCHEDULER_ENABLED" envDefault:"false"`
+	OrgChangeRequestSchedulerInterval time.Duration `env:"ORG_CHANGE_REQUEST_SCHEDULER_INTERVAL" envDefault:"30s"`
+
 	// DEV-PLAN-032: Org permission mapping & associations (default off).
 	OrgSecurityGroupMappingsEnabled bool `env:"ORG_SECURITY_GROUP_MAPPINGS_ENABLED" envDefault:"false"`
 	OrgLinksEnabled                 bool `env:"ORG_LINKS_ENABLED" envDefault:"false"`
@@ -1,7 +1,12 @@
 package importpkg
 
 import (
+	"bufio"
+	"encoding/csv"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/xuri/excelize/v2"
 )
@@ -33,3 +38,55 @@ func (r *ExcelFileReader) ReadExcelRows(filePath string) ([][]string, error) {
 
 	return file.GetRows(sheets[0])
 }
+
+// CSVFileReader reads comma-separated files through the same FileReader contract
+type CSVFileReader struct{}
+
+// NewCSVFileReader creates a new CSV file reader
+func NewCSVFileReader() *CSVFileReader {
+	return &CSVFileReader{}
+}
+
+func (r *CSVFileReader) ReadExcelRows(filePath string) ([][]string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	reader := csv.NewReader(bufio.NewReader(file))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	// Excel exports often start with a UTF-8 byte order mark
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\ufeff")
+	}
+	return rows, nil
+}
+
+// ExtensionFileReader picks the CSV or Excel reader based on the file extension
+type ExtensionFileReader struct {
+	csv   FileReader
+	excel FileReader
+}
+
+// NewExtensionFileReader creates a reader accepting both .csv and .xlsx files
+func NewExtensionFileReader() *ExtensionFileReader {
+	return &ExtensionFileReader{
+		csv:   NewCSVFileReader(),
+		excel: NewExcelFileReader(),
+	}
+}
+
+func (r *ExtensionFileReader) ReadExcelRows(filePath string) ([][]string, error) {
+	if strings.EqualFold(filepath.Ext(filePath), ".csv") {
+		return r.csv.ReadExcelRows(filePath)
+	}
+	return r.excel.ReadExcelRows(filePath)
+}
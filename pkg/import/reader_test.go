@@ -0,0 +1,32 @@
+package importpkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVFileReader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rows.csv")
+	require.NoError(t, os.WriteFile(path, []byte("\ufeffname,amount\nfoo, 1\n\"bar, baz\",2,extra\n"), 0o600))
+
+	rows, err := NewCSVFileReader().ReadExcelRows(path)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"name", "amount"},
+		{"foo", "1"},
+		{"bar, baz", "2", "extra"},
+	}, rows)
+}
+
+func TestExtensionFileReader_CSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rows.CSV")
+	require.NoError(t, os.WriteFile(path, []byte("a,b\n1,2\n"), 0o600))
+
+	rows, err := NewExtensionFileReader().ReadExcelRows(path)
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b"}, {"1", "2"}}, rows)
+}
@@ -5,11 +5,16 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/gorilla/mux"
 
+	"github.com/iota-uz/iota-sdk/modules/core/domain/aggregates/user"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/permission"
+	"github.com/iota-uz/iota-sdk/modules/core/domain/entities/session"
 	"github.com/iota-uz/iota-sdk/modules/core/services"
 	"github.com/iota-uz/iota-sdk/pkg/application"
+	"github.com/iota-uz/iota-sdk/pkg/authz"
 	"github.com/iota-uz/iota-sdk/pkg/composables"
 	"github.com/iota-uz/iota-sdk/pkg/configuration"
 	"github.com/iota-uz/iota-sdk/pkg/constants"
@@ -19,7 +24,10 @@ func getToken(r *http.Request) (string, error) {
 	conf := configuration.Use()
 	token, err := r.Cookie(conf.SidCookieKey)
 	if errors.Is(err, http.ErrNoCookie) {
-		v := r.Header.Get("Authorization")
+		v := strings.TrimSpace(r.Header.Get("Authorization"))
+		if len(v) > len("Bearer ") && strings.EqualFold(v[:len("Bearer ")], "Bearer ") {
+			v = strings.TrimSpace(v[len("Bearer "):])
+		}
 		if v == "" {
 			return "", errors.New("no token found")
 		}
@@ -45,8 +53,13 @@ func Authorize() mux.MiddlewareFunc {
 				if err != nil {
 					panic(err)
 				}
-				authService := app.Service(services.AuthService{}).(*services.AuthService)
-				sess, err := authService.Authorize(ctx, token)
+				var sess *session.Session
+				if strings.HasPrefix(token, services.APITokenPrefix) {
+					sess, ctx, err = authorizeAPIToken(ctx, app, token, getRealIP(r, configuration.Use()))
+				} else {
+					authService := app.Service(services.AuthService{}).(*services.AuthService)
+					sess, err = authService.Authorize(ctx, token)
+				}
 				if err != nil {
 					next.ServeHTTP(w, r)
 					return
@@ -67,6 +80,45 @@ func Authorize() mux.MiddlewareFunc {
 	}
 }
 
+// authorizeAPIToken authenticates an API token and returns a session for its owner that lasts as
+// long as the token, along with a context restricted to the token's scopes.
+func authorizeAPIToken(
+	ctx context.Context,
+	app application.Application,
+	secret string,
+	ip string,
+) (*session.Session, context.Context, error) {
+	tokenService := app.Service(services.APITokenService{}).(*services.APITokenService)
+	token, err := tokenService.Authenticate(ctx, secret, ip)
+	if err != nil {
+		return nil, ctx, err
+	}
+	scopes, err := authz.ParseScopes(token.Scopes)
+	if err != nil {
+		return nil, ctx, err
+	}
+	sess := &session.Session{
+		UserID:    token.UserID,
+		TenantID:  token.TenantID,
+		IP:        ip,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}
+	return sess, authz.WithScopes(ctx, scopes), nil
+}
+
+// scopedUser limits the legacy permission checks of a user authenticated with an API token.
+// Permissions name resources rather than authz objects, so only scopes covering every object
+// carry over; narrower tokens are limited to what authz.Service enforces.
+type scopedUser struct {
+	user.User
+	scopes []authz.Scope
+}
+
+func (u scopedUser) Can(perm *permission.Permission) bool {
+	return u.User.Can(perm) && authz.ScopesAllow(u.scopes, "*", string(perm.Action))
+}
+
 func ProvideUser() mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(
@@ -87,6 +139,9 @@ func ProvideUser() mux.MiddlewareFunc {
 					next.ServeHTTP(w, r)
 					return
 				}
+				if scopes, ok := authz.ScopesFromContext(ctx); ok {
+					u = scopedUser{User: u, scopes: scopes}
+				}
 				// Set the user in context
 				ctx = context.WithValue(ctx, constants.UserKey, u)
 
@@ -0,0 +1,128 @@
+// Command dbsync moves the aggregated policy between policy.csv and the database policy store
+// (AUTHZ_POLICY_STORE=db), so authz-pack output can be published and the live policy reviewed
+// with the existing file tooling.
+//
+//	go run ./scripts/authz/dbsync import   # policy.csv -> new head revision
+//	go run ./scripts/authz/dbsync export   # head revision -> policy.csv + policy.csv.rev
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/iota-uz/iota-sdk/pkg/authz/policystore"
+	"github.com/iota-uz/iota-sdk/pkg/authz/version"
+	"github.com/iota-uz/iota-sdk/pkg/configuration"
+)
+
+func main() {
+	var (
+		dsn     = flag.String("dsn", "", "PostgreSQL DSN (defaults to configuration database options)")
+		policy  = flag.String("policy", "config/access/policy.csv", "path to the aggregated policy file")
+		revPath = flag.String("rev", "", "path to the revision metadata file (defaults to <policy>.rev)")
+		actor   = flag.String("actor", os.Getenv("USER"), "recorded as created_by on imported revisions")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: dbsync [flags] import|export\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *dsn == "" {
+		*dsn = configuration.Use().Database.Opts
+	}
+	if *revPath == "" {
+		*revPath = *policy + ".rev"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pool, err := pgxpool.New(ctx, *dsn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "authz-db-sync: %v\n", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+	store := policystore.NewStore(pool)
+
+	switch flag.Arg(0) {
+	case "import":
+		err = importPolicy(ctx, store, *policy, *actor)
+	case "export":
+		err = exportPolicy(ctx, store, *policy, *revPath)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "authz-db-sync: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func importPolicy(ctx context.Context, store *policystore.Store, policyPath, actor string) error {
+	data, err := os.ReadFile(policyPath)
+	if err != nil {
+		return err
+	}
+
+	base := ""
+	head, err := store.Head(ctx)
+	switch {
+	case errors.Is(err, policystore.ErrNoRevision):
+	case err != nil:
+		return err
+	default:
+		if head.Revision == policystore.RevisionHash(data) {
+			fmt.Printf("already at revision %s\n", head.Revision)
+			return nil
+		}
+		base = head.Revision
+	}
+
+	rev, err := store.Commit(ctx, policystore.CommitInput{
+		BaseRevision: base,
+		Policy:       data,
+		Source:       policystore.SourceImport,
+		CreatedBy:    actor,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("imported %s as revision %s (%d entries)\n", policyPath, rev.Revision, rev.Entries)
+	return nil
+}
+
+func exportPolicy(ctx context.Context, store *policystore.Store, policyPath, revPath string) error {
+	head, err := store.Head(ctx)
+	if err != nil {
+		return err
+	}
+	payload, err := json.MarshalIndent(version.Metadata{
+		Revision:    head.Revision,
+		GeneratedAt: head.CreatedAt.UTC(),
+		Entries:     head.Entries,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(policyPath, head.Policy, 0o644); err != nil {
+		return err
+	}
+	if err := os.WriteFile(revPath, payload, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("exported revision %s to %s\n", head.Revision, policyPath)
+	return nil
+}
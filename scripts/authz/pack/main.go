@@ -13,6 +13,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/iota-uz/iota-sdk/pkg/authz"
 )
 
 type policyEntry struct {
@@ -98,6 +100,9 @@ func parsePolicyFile(path string, entries *[]policyEntry) error {
 		if err != nil {
 			return fmt.Errorf("line %d: %w", lineNumber, err)
 		}
+		if err := authz.ValidatePolicyRecord(rec); err != nil {
+			return fmt.Errorf("line %d: %w", lineNumber, err)
+		}
 		key := buildSortKey(rec)
 		*entries = append(*entries, policyEntry{
 			raw:     strings.Join(rec, ", "),